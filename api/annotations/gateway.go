@@ -12,6 +12,12 @@ const (
 	// This annotation will be removed in v2.3.
 	PerConnectionBufferLimit gwv1.AnnotationKey = "kgateway.dev/per-connection-buffer-limit"
 
+	// Drain is the annotation key used to drain the proxies of a gateway, e.g. before node maintenance.
+	// When set to "true", the HTTP listeners of the gateway gracefully close their connections so that
+	// clients reconnect through other gateways, and the Gateway reports the Draining condition.
+	// Removing the annotation, or setting it to "false", restores the normal configuration.
+	Drain gwv1.AnnotationKey = "kgateway.dev/drain"
+
 	// AlpnProtocols is the annotation key used to set the ALPN protocols for a TLS listener.
 	// The value is a comma separated list of protocols, e.g "h2,http/1.1".
 	// If not present, the listener will use the default ALPN protocols ("h2", "http/1.1").
@@ -0,0 +1,8 @@
+package annotations
+
+import gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+// ControllerVersion is the annotation key set on the GatewayClasses managed by kgateway.
+// Its value is the version of the controller that reconciles the GatewayClass and
+// publishes its status, including the supported features.
+const ControllerVersion gwv1.AnnotationKey = "kgateway.dev/controller-version"
@@ -3,7 +3,9 @@ package settings
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -40,6 +42,24 @@ func (v *ValidationMode) Decode(value string) error {
 	}
 }
 
+// ReplacementStatusCode is the HTTP status code of the direct response that replaces
+// an invalid route when validation is enabled.
+type ReplacementStatusCode uint32
+
+// Decode implements envconfig.Decoder.
+func (c *ReplacementStatusCode) Decode(value string) error {
+	code, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid route replacement status code: %q", value)
+	}
+	// a successful status code would hide that the route was replaced
+	if code < 400 || code > 599 {
+		return fmt.Errorf("invalid route replacement status code: %q, must be between 400 and 599", value)
+	}
+	*c = ReplacementStatusCode(code)
+	return nil
+}
+
 // DnsLookupFamily controls the DNS lookup family for all static clusters created via Backend resources.
 type DnsLookupFamily string
 
@@ -166,6 +186,11 @@ type Settings struct {
 	// By default, this is disabled.
 	XdsTLS bool `split_words:"true" default:"false"`
 
+	// EnableValidationWebhook serves the admission webhook that validates TrafficPolicies and Backends
+	// beyond what their CRD schemas can express. The webhook serves TLS on port 9443 using the certificate
+	// mounted at /etc/webhook-tls.
+	EnableValidationWebhook bool `split_words:"true" default:"false"`
+
 	// DefaultImageRegistry is the default image registry to use for the kgateway image.
 	DefaultImageRegistry string `split_words:"true" default:"cr.kgateway.dev"`
 	// DefaultImageTag is the default image tag to use for the kgateway image.
@@ -207,10 +232,50 @@ type Settings struct {
 	// - "STRICT": Builds on STANDARD by running targeted validation
 	ValidationMode ValidationMode `split_words:"true" default:"STANDARD"`
 
+	// RouteReplacementStatusCode is the HTTP status code returned by the direct response that
+	// replaces a route that failed translation or validation. Sibling routes and listeners are
+	// unaffected by the replacement. Must be between 400 and 599. Defaults to 500.
+	RouteReplacementStatusCode ReplacementStatusCode `split_words:"true" default:"500"`
+
+	// XdsDebounceWindow is the quiet period over which endpoint changes are coalesced before they are
+	// translated, and over which xDS snapshot updates for a proxy are coalesced before being pushed, so
+	// that bursts of input events (e.g. EndpointSlice churn during a rollout) result in a single
+	// translation and update. Setting it to 0 disables debouncing.
+	XdsDebounceWindow time.Duration `split_words:"true" default:"300ms"`
+
+	// XdsDebounceMaxWait bounds how long a pending endpoint change or xDS snapshot update may be delayed
+	// by a continuous stream of events before it is translated or pushed regardless.
+	XdsDebounceMaxWait time.Duration `split_words:"true" default:"1s"`
+
+	// XdsStaleProxyThreshold is how long a connected proxy may lag the latest xDS snapshot for its Gateway
+	// before it is reported as stale by the kgateway_xds_stale_proxies metric and the admin server.
+	// Setting it to 0 disables stale proxy detection.
+	XdsStaleProxyThreshold time.Duration `split_words:"true" default:"1m"`
+
+	// XdsSnapshotPersistence persists the xDS snapshots served to the proxies to ConfigMaps in the namespace of the
+	// controller, so that after a restart the proxies are served the last snapshots right away instead of only once
+	// the translation completed. The persisted snapshots are replaced by the fresh ones once ready. Secrets are never
+	// persisted, so TLS listeners and clusters only warm once the fresh secrets are served. Only the leader replica
+	// writes the ConfigMaps.
+	XdsSnapshotPersistence bool `split_words:"true" default:"false"`
+
+	// InputStalenessThreshold is how long the informers feeding the translation may lag the API server
+	// before xDS snapshot updates are held back, so that proxies keep the last good configuration instead of
+	// configuration translated from inconsistent inputs. Updates resume once the informers caught up. A Warning
+	// event is emitted on the Gateways whose updates are held back. Informers of quiet resources only advance
+	// with the watch bookmarks the API server sends about every minute, so it must be well above that. Setting
+	// it to 0 disables the detection.
+	InputStalenessThreshold time.Duration `split_words:"true" default:"0"`
+
 	// EnableBuiltinDefaultMetrics enables the default builtin controller-runtime metrics and go runtime metrics.
 	// Since these metrics can be numerous, it is disabled by default.
 	EnableBuiltinDefaultMetrics bool `split_words:"true" default:"false"`
 
+	// EnableCollectionMetrics enables per-collection metrics of the krt collections used for translation:
+	// object counts, transformation latencies and recomputes. It is disabled by default as it adds overhead
+	// to every transformation.
+	EnableCollectionMetrics bool `split_words:"true" default:"false"`
+
 	// GlobalPolicyNamespace is the namespace where policies that can attach to resources
 	// in any namespace are defined.
 	GlobalPolicyNamespace string `split_words:"true"`
@@ -218,6 +283,26 @@ type Settings struct {
 	// Controls if leader election is disabled. Defaults to false.
 	DisableLeaderElection bool `split_words:"true" default:"false"`
 
+	// GatewayShards is the number of shards that Gateways are split across. Values lower than 2 disable sharding.
+	// When sharding is enabled, the controller must run as a StatefulSet with this many replicas; each replica
+	// translates and serves xDS only for the Gateways of its shard, and every shard must be exposed by a
+	// `<xdsServiceName>-<ordinal>` Service selecting the shard's pod. The Helm chart sets this up when
+	// `controller.sharding.shards` is 2 or more.
+	// A shard releases its Gateways when it shuts down. On scale-down, the Gateways of removed shards are only
+	// claimed by the remaining shards once released, so that they are never served by two shards. If a removed
+	// replica didn't shut down cleanly, its Gateways are claimed once its leader lease expired.
+	GatewayShards uint32 `split_words:"true" default:"0"`
+
+	// GatewayNodePortAddresses publishes the addresses of the nodes in the status of Gateways exposed through a
+	// NodePort Service instead of the cluster IPs of the Service. The external IP of each node is used, or its
+	// internal IP if it has none.
+	GatewayNodePortAddresses bool `split_words:"true" default:"false"`
+
+	// EnableFaultInjection allows TrafficPolicies to inject delays and aborts into requests.
+	// It is disabled by default to prevent fault injection meant for chaos testing from
+	// accidentally reaching production traffic. The Helm chart sets it from controller.faultInjection.enabled.
+	EnableFaultInjection bool `split_words:"true" default:"false"`
+
 	PolicyMerge string `split_words:"true" default:"{}"`
 
 	// EnableWaypoint enables kgateway to translate istio waypoints
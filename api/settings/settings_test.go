@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/require"
@@ -38,14 +39,25 @@ func allEnvVarsSet() map[string]string {
 		"KGW_ENABLE_ENVOY":                             "false",
 		"KGW_WEIGHTED_ROUTE_PRECEDENCE":                "true",
 		"KGW_VALIDATION_MODE":                          string(ValidationStrict),
+		"KGW_ROUTE_REPLACEMENT_STATUS_CODE":            "503",
+		"KGW_XDS_DEBOUNCE_WINDOW":                      "100ms",
+		"KGW_XDS_DEBOUNCE_MAX_WAIT":                    "5s",
+		"KGW_XDS_STALE_PROXY_THRESHOLD":                "2m",
+		"KGW_XDS_SNAPSHOT_PERSISTENCE":                 "true",
+		"KGW_INPUT_STALENESS_THRESHOLD":                "5m",
 		"KGW_ENABLE_BUILTIN_DEFAULT_METRICS":           "true",
+		"KGW_ENABLE_COLLECTION_METRICS":                "true",
 		"KGW_GLOBAL_POLICY_NAMESPACE":                  "foo",
 		"KGW_DISABLE_LEADER_ELECTION":                  "true",
+		"KGW_GATEWAY_SHARDS":                           "3",
+		"KGW_GATEWAY_NODE_PORT_ADDRESSES":              "true",
+		"KGW_ENABLE_FAULT_INJECTION":                   "true",
 		"KGW_POLICY_MERGE":                             `{"TrafficPolicy":{"extProc":"DeepMerge"}}`,
 		"KGW_GATEWAY_CLASS_PARAMETERS_REFS":            `{"kgateway":{"name":"custom-gwp","namespace":"infra"}}`,
 		"KGW_ENABLE_WAYPOINT":                          "true",
 		"KGW_XDS_AUTH":                                 "false",
 		"KGW_XDS_TLS":                                  "true",
+		"KGW_ENABLE_VALIDATION_WEBHOOK":                "true",
 		"KGW_ENABLE_EXPERIMENTAL_GATEWAY_API_FEATURES": "false",
 	}
 }
@@ -88,9 +100,16 @@ func TestSettings(t *testing.T) {
 				EnableEnvoy:                          true,
 				WeightedRoutePrecedence:              false,
 				ValidationMode:                       ValidationStandard,
+				RouteReplacementStatusCode:           500,
+				XdsDebounceWindow:                    300 * time.Millisecond,
+				XdsDebounceMaxWait:                   time.Second,
+				XdsStaleProxyThreshold:               time.Minute,
 				EnableBuiltinDefaultMetrics:          false,
+				EnableCollectionMetrics:              false,
 				GlobalPolicyNamespace:                "",
 				DisableLeaderElection:                false,
+				GatewayShards:                        0,
+				EnableFaultInjection:                 false,
 				PolicyMerge:                          "{}",
 				EnableWaypoint:                       false,
 				XdsAuth:                              true,
@@ -123,13 +142,24 @@ func TestSettings(t *testing.T) {
 				EnableEnvoy:                          false,
 				WeightedRoutePrecedence:              true,
 				ValidationMode:                       ValidationStrict,
+				RouteReplacementStatusCode:           503,
+				XdsDebounceWindow:                    100 * time.Millisecond,
+				XdsDebounceMaxWait:                   5 * time.Second,
+				XdsStaleProxyThreshold:               2 * time.Minute,
+				XdsSnapshotPersistence:               true,
+				InputStalenessThreshold:              5 * time.Minute,
 				EnableBuiltinDefaultMetrics:          true,
+				EnableCollectionMetrics:              true,
 				GlobalPolicyNamespace:                "foo",
 				DisableLeaderElection:                true,
+				GatewayShards:                        3,
+				GatewayNodePortAddresses:             true,
+				EnableFaultInjection:                 true,
 				PolicyMerge:                          `{"TrafficPolicy":{"extProc":"DeepMerge"}}`,
 				EnableWaypoint:                       true,
 				XdsAuth:                              false,
 				XdsTLS:                               true,
+				EnableValidationWebhook:              true,
 				EnableExperimentalGatewayAPIFeatures: false,
 				GatewayClassParametersRefs: GatewayClassParametersRefs{
 					"kgateway": {
@@ -167,6 +197,34 @@ func TestSettings(t *testing.T) {
 			},
 			expectedErrorStr: `invalid validation mode: "invalid"`,
 		},
+		{
+			name: "errors on invalid route replacement status code",
+			envVars: map[string]string{
+				"KGW_ROUTE_REPLACEMENT_STATUS_CODE": "abc",
+			},
+			expectedErrorStr: `invalid route replacement status code: "abc"`,
+		},
+		{
+			name: "errors on out of range route replacement status code",
+			envVars: map[string]string{
+				"KGW_ROUTE_REPLACEMENT_STATUS_CODE": "100",
+			},
+			expectedErrorStr: `invalid route replacement status code: "100", must be between 400 and 599`,
+		},
+		{
+			name: "errors on successful route replacement status code",
+			envVars: map[string]string{
+				"KGW_ROUTE_REPLACEMENT_STATUS_CODE": "200",
+			},
+			expectedErrorStr: `invalid route replacement status code: "200", must be between 400 and 599`,
+		},
+		{
+			name: "errors on invalid xds debounce window",
+			envVars: map[string]string{
+				"KGW_XDS_DEBOUNCE_WINDOW": "soon",
+			},
+			expectedErrorStr: `invalid duration "soon"`,
+		},
 		{
 			name: "errors on invalid gatewayclass parameters refs: missing name",
 			envVars: map[string]string{
@@ -205,6 +263,10 @@ func TestSettings(t *testing.T) {
 				EnableEnvoy:                          true,
 				WeightedRoutePrecedence:              false,
 				ValidationMode:                       ValidationStandard,
+				RouteReplacementStatusCode:           500,
+				XdsDebounceWindow:                    300 * time.Millisecond,
+				XdsDebounceMaxWait:                   time.Second,
+				XdsStaleProxyThreshold:               time.Minute,
 				PolicyMerge:                          "{}",
 				XdsAuth:                              true,
 				XdsTLS:                               false,
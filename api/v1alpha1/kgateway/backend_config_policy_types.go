@@ -40,6 +40,9 @@ type BackendConfigPolicyList struct {
 // BackendConfigPolicySpec defines the desired state of BackendConfigPolicy.
 //
 // +kubebuilder:validation:AtMostOneOf=http1ProtocolOptions;http2ProtocolOptions
+// +kubebuilder:validation:XValidation:rule="!has(self.connectionPool) || !has(self.connectionPool.http) || !has(self.commonHttpProtocolOptions) || (!has(self.connectionPool.http.maxRequestsPerConnection) || !has(self.commonHttpProtocolOptions.maxRequestsPerConnection)) && (!has(self.connectionPool.http.idleTimeout) || !has(self.commonHttpProtocolOptions.idleTimeout))",message="connectionPool.http and commonHttpProtocolOptions can't both set maxRequestsPerConnection or idleTimeout"
+// +kubebuilder:validation:XValidation:rule="!has(self.connectionPool) || !has(self.connectionPool.tcp) || !has(self.circuitBreakers) || !has(self.connectionPool.tcp.maxConnections) || !has(self.circuitBreakers.maxConnections)",message="connectionPool.tcp and circuitBreakers can't both set maxConnections"
+// +kubebuilder:validation:XValidation:rule="!has(self.upstreamProtocol) || !(self.upstreamProtocol == 'http1' && has(self.http2ProtocolOptions)) && !(self.upstreamProtocol == 'http2' && has(self.http1ProtocolOptions))",message="the protocol options must match the upstreamProtocol"
 type BackendConfigPolicySpec struct {
 	// TargetRefs specifies the target references to attach the policy to.
 	// +optional
@@ -73,6 +76,13 @@ type BackendConfigPolicySpec struct {
 	// +optional
 	TCPKeepalive *TCPKeepalive `json:"tcpKeepalive,omitempty"`
 
+	// DualStackConnect connects to dual-stack backends over both IPv4 and IPv6: the hostnames of
+	// the backend are resolved to both their IPv4 and IPv6 addresses, and connection attempts
+	// alternate between the two address families, starting with IPv6 (happy eyeballs, RFC 8305).
+	// Like DNS, the resolution only applies to backends that resolve to Envoy DNS clusters.
+	// +optional
+	DualStackConnect *bool `json:"dualStackConnect,omitempty"`
+
 	// Additional options when handling HTTP requests upstream, applicable to
 	// both HTTP1 and HTTP2 requests.
 	// +optional
@@ -88,6 +98,15 @@ type BackendConfigPolicySpec struct {
 	// +optional
 	Http2ProtocolOptions *Http2ProtocolOptions `json:"http2ProtocolOptions,omitempty"`
 
+	// UpstreamProtocol selects the HTTP protocol of the connections to the backend, e.g. for backends that
+	// serve both HTTP/1.1 and HTTP/2. When unset, the protocol is derived from the appProtocol of the backend.
+	// With http1 and http2, the protocol is used regardless of the appProtocol of the backend, along with
+	// http1ProtocolOptions or http2ProtocolOptions respectively.
+	// With auto, the protocol is negotiated with the backend via ALPN when the backend uses TLS, preferring
+	// HTTP/2, and the protocol of the downstream request is used otherwise.
+	// +optional
+	UpstreamProtocol *UpstreamProtocol `json:"upstreamProtocol,omitempty"`
+
 	// TLS contains the options necessary to configure a backend to use TLS origination.
 	// See [Envoy documentation](https://www.envoyproxy.io/docs/envoy/latest/api-v3/extensions/transport_sockets/tls/v3/tls.proto#envoy-v3-api-msg-extensions-transport-sockets-tls-v3-sslconfig) for more details.
 	// +optional
@@ -110,12 +129,26 @@ type BackendConfigPolicySpec struct {
 	// +optional
 	CircuitBreakers *CircuitBreakers `json:"circuitBreakers,omitempty"`
 
+	// ConnectionPool contains the options to tune the pool of connections to the backend.
+	// It is a shorthand for the corresponding fields of CommonHttpProtocolOptions and CircuitBreakers,
+	// which can't set the same fields.
+	// +optional
+	ConnectionPool *ConnectionPool `json:"connectionPool,omitempty"`
+
 	// UpstreamProxyProtocol configures the PROXY protocol for upstream connections to the backend.
 	// When enabled, the proxy protocol header is prepended to upstream connections,
 	// allowing backend services to see the original client connection information.
 	// See [Envoy documentation](https://www.envoyproxy.io/docs/envoy/latest/api-v3/extensions/transport_sockets/proxy_protocol/v3/upstream_proxy_protocol.proto) for more details.
 	// +optional
 	UpstreamProxyProtocol *UpstreamProxyProtocol `json:"upstreamProxyProtocol,omitempty"`
+
+	// UnreadyEndpointFallback sends requests to the endpoints of a Kubernetes Service that are not
+	// ready, such as pods that are starting up, when the Service has no ready or serving endpoints,
+	// rather than failing them with a 503 response.
+	// Regardless of this setting, endpoints of terminating pods that are still serving keep receiving
+	// requests while there are not enough ready endpoints, e.g. during a rolling update.
+	// +optional
+	UnreadyEndpointFallback *bool `json:"unreadyEndpointFallback,omitempty"`
 }
 
 // CircuitBreakers contains the options to configure circuit breaker thresholds for the default priority.
@@ -193,6 +226,45 @@ type Http1ProtocolOptions struct {
 	OverrideStreamErrorOnInvalidHttpMessage *bool `json:"overrideStreamErrorOnInvalidHttpMessage,omitempty"`
 }
 
+// ConnectionPool contains the options to tune the pool of connections to a backend.
+// +kubebuilder:validation:AtLeastOneOf=http;tcp
+type ConnectionPool struct {
+	// HTTP contains the options of the connections to HTTP backends.
+	// +optional
+	HTTP *HTTPConnectionPool `json:"http,omitempty"`
+
+	// TCP contains the options common to the connections to TCP and HTTP backends.
+	// +optional
+	TCP *TCPConnectionPool `json:"tcp,omitempty"`
+}
+
+// HTTPConnectionPool contains the options of the connections to HTTP backends.
+// +kubebuilder:validation:AtLeastOneOf=maxRequestsPerConnection;idleTimeout
+type HTTPConnectionPool struct {
+	// MaxRequestsPerConnection is the maximum number of requests sent over a single connection to the
+	// backend before it is closed. If set to 0 or unspecified, connections are reused without limit.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxRequestsPerConnection *int32 `json:"maxRequestsPerConnection,omitempty"`
+
+	// IdleTimeout is how long a connection to the backend is kept open without active requests.
+	// If not specified, this defaults to 1 hour.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="idleTimeout must be positive"
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+}
+
+// TCPConnectionPool contains the options common to the connections to TCP and HTTP backends.
+// +kubebuilder:validation:AtLeastOneOf=maxConnections
+type TCPConnectionPool struct {
+	// MaxConnections is the maximum number of connections the proxy opens to the backend.
+	// If not specified, defaults to 1024.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxConnections *int32 `json:"maxConnections,omitempty"`
+}
+
 // CommonHttpProtocolOptions are options that are applicable to both HTTP1 and HTTP2 requests.
 // See [Envoy documentation](https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/core/v3/protocol.proto#envoy-v3-api-msg-config-core-v3-httpprotocoloptions) for more details.
 type CommonHttpProtocolOptions struct {
@@ -259,7 +331,7 @@ type Http2ProtocolOptions struct {
 type TCPKeepalive struct {
 	// Maximum number of keep-alive probes to send before dropping the connection.
 	// +optional
-	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Minimum=1
 	KeepAliveProbes *int32 `json:"keepAliveProbes,omitempty"`
 
 	// The number of seconds a connection needs to be idle before keep-alive probes start being sent.
@@ -414,6 +486,18 @@ type LoadBalancer struct {
 	// +kubebuilder:validation:Enum=WeightedLb
 	LocalityType *LocalityType `json:"localityType,omitempty"`
 
+	// ZoneAware enables zone-aware routing. Requests are sent to the endpoints in the same zone as
+	// the proxy, and fail over to the endpoints in the same region, then to the other endpoints, when
+	// there are not enough healthy endpoints left. The locality of the proxy and of the endpoints is
+	// derived from the topology.kubernetes.io/region and topology.kubernetes.io/zone labels of their nodes.
+	// +optional
+	ZoneAware *ZoneAwareLoadBalancing `json:"zoneAware,omitempty"`
+
+	// Subset routes requests to the subset of endpoints whose pods have the given labels, e.g.
+	// `version: v2`. The endpoints of the subset are balanced with the configured load balancer type.
+	// +optional
+	Subset *LoadBalancerSubsetConfig `json:"subset,omitempty"`
+
 	// If set to true, the load balancer will drain connections when the host set changes.
 	//
 	// Ring Hash or Maglev can be used to ensure that clients with the same key
@@ -429,6 +513,47 @@ type LoadBalancer struct {
 	CloseConnectionsOnHostSetChange *bool `json:"closeConnectionsOnHostSetChange,omitempty"`
 }
 
+// ZoneAwareLoadBalancing configures zone-aware routing.
+type ZoneAwareLoadBalancing struct {
+	// FailoverThreshold is the percentage of healthy endpoints in a locality under which part of the
+	// requests fail over to the next locality, in proportion to the missing healthy endpoints.
+	// For example, with a threshold of 80, a zone with 60% of healthy endpoints receives 75% of the
+	// requests. When unset, the Envoy default of about 71 applies, i.e. an overprovisioning factor of 1.4.
+	// See [Envoy documentation](https://www.envoyproxy.io/docs/envoy/latest/intro/arch_overview/upstream/load_balancing/priority).
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	FailoverThreshold *int32 `json:"failoverThreshold,omitempty"`
+}
+
+// LoadBalancerSubsetConfig configures subset load balancing.
+type LoadBalancerSubsetConfig struct {
+	// Selector is the set of labels the pods of the endpoints of the subset must have.
+	// +required
+	// +kubebuilder:validation:MinProperties=1
+	// +kubebuilder:validation:MaxProperties=8
+	Selector map[string]string `json:"selector"`
+
+	// FallbackPolicy is the behavior when no endpoint matches the selector.
+	// With AllEndpoints, requests are balanced across all the endpoints of the backend.
+	// With None, requests fail as if the backend had no healthy endpoints.
+	// Defaults to AllEndpoints.
+	// +optional
+	// +kubebuilder:default=AllEndpoints
+	FallbackPolicy SubsetFallbackPolicy `json:"fallbackPolicy,omitempty"`
+}
+
+// SubsetFallbackPolicy is the behavior of subset load balancing when no endpoint matches the subset.
+// +kubebuilder:validation:Enum=AllEndpoints;None
+type SubsetFallbackPolicy string
+
+const (
+	// SubsetFallbackPolicyAllEndpoints balances requests across all the endpoints of the backend.
+	SubsetFallbackPolicyAllEndpoints SubsetFallbackPolicy = "AllEndpoints"
+	// SubsetFallbackPolicyNone fails requests as if the backend had no healthy endpoints.
+	SubsetFallbackPolicyNone SubsetFallbackPolicy = "None"
+)
+
 // LoadBalancerLeastRequestConfig configures the least request load balancer type.
 type LoadBalancerLeastRequestConfig struct {
 	// How many choices to take into account.
@@ -711,6 +836,20 @@ type UpstreamProxyProtocol struct {
 	Version *ProxyProtocolVersion `json:"version,omitempty"`
 }
 
+// UpstreamProtocol is the HTTP protocol of the connections to a backend.
+// +kubebuilder:validation:Enum=http1;http2;auto
+type UpstreamProtocol string
+
+const (
+	// UpstreamProtocolHTTP1 uses HTTP/1.1 for the connections to the backend.
+	UpstreamProtocolHTTP1 UpstreamProtocol = "http1"
+	// UpstreamProtocolHTTP2 uses HTTP/2 for the connections to the backend.
+	UpstreamProtocolHTTP2 UpstreamProtocol = "http2"
+	// UpstreamProtocolAuto negotiates the protocol with the backend via ALPN when it uses TLS,
+	// and uses the protocol of the downstream request otherwise.
+	UpstreamProtocolAuto UpstreamProtocol = "auto"
+)
+
 // ProxyProtocolVersion defines the PROXY protocol version.
 // +kubebuilder:validation:Enum=V1;V2
 type ProxyProtocolVersion string
@@ -3,6 +3,7 @@ package kgateway
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
@@ -40,6 +41,11 @@ const (
 	BackendTypeDynamicForwardProxy BackendType = "DynamicForwardProxy"
 	// BackendTypeGCP is the type for GCP backends.
 	BackendTypeGCP BackendType = "GCP"
+	// BackendTypeDNS is the type for DNS backends.
+	BackendTypeDNS BackendType = "DNS"
+	// BackendTypePluginPrefix is the prefix of the type of backends resolved by a plugin.
+	// The type of such a backend is `plugin/<name>`, where name is the name of its resolver.
+	BackendTypePluginPrefix = "plugin/"
 )
 
 // BackendSpec defines the desired state of Backend.
@@ -47,10 +53,14 @@ const (
 // +kubebuilder:validation:XValidation:message="static backend must be specified when type is 'Static'",rule="self.type == 'Static' ? has(self.static) : true"
 // +kubebuilder:validation:XValidation:message="dynamicForwardProxy backend must be specified when type is 'DynamicForwardProxy'",rule="self.type == 'DynamicForwardProxy' ? has(self.dynamicForwardProxy) : true"
 // +kubebuilder:validation:XValidation:message="gcp backend must be specified when type is 'GCP'",rule="self.type == 'GCP' ? has(self.gcp) : true"
-// +kubebuilder:validation:ExactlyOneOf=aws;static;dynamicForwardProxy;gcp
+// +kubebuilder:validation:XValidation:message="dns backend must be specified when type is 'DNS'",rule="self.type == 'DNS' ? has(self.dns) : true"
+// +kubebuilder:validation:XValidation:message="plugin backend must be specified with a matching name when type is 'plugin/<name>'",rule="has(self.type) && self.type.startsWith('plugin/') ? has(self.plugin) && self.type == 'plugin/' + self.plugin.name : true"
+// +kubebuilder:validation:ExactlyOneOf=aws;static;dynamicForwardProxy;gcp;dns;plugin
 type BackendSpec struct {
 	// Type indicates the type of the backend to be used.
-	// +kubebuilder:validation:Enum=AWS;Static;DynamicForwardProxy;GCP
+	// One of AWS, Static, DynamicForwardProxy, GCP, DNS, or plugin/<name> for backends resolved by a plugin.
+	// +kubebuilder:validation:MaxLength=70
+	// +kubebuilder:validation:XValidation:message="type must be one of AWS, Static, DynamicForwardProxy, GCP, DNS or plugin/<name>",rule="self in ['AWS', 'Static', 'DynamicForwardProxy', 'GCP', 'DNS'] || self.matches('^plugin/[a-z0-9]([-a-z0-9]*[a-z0-9])?$')"
 	// Deprecated: The Type field is deprecated and will be removed in a future release.
 	// The backend type is inferred from the configuration.
 	// +optional
@@ -67,10 +77,16 @@ type BackendSpec struct {
 	// Gcp is the GCP backend configuration.
 	// +optional
 	Gcp *GcpBackend `json:"gcp,omitempty"`
+	// Dns is the DNS backend configuration.
+	// +optional
+	Dns *DnsBackend `json:"dns,omitempty"`
+	// Plugin is the configuration of a backend whose endpoints are resolved by a plugin.
+	// +optional
+	Plugin *PluginBackend `json:"plugin,omitempty"`
 }
 
 // AppProtocol defines the application protocol to use when communicating with the backend.
-// +kubebuilder:validation:Enum=http2;grpc;grpc-web;kubernetes.io/h2c;kubernetes.io/ws
+// +kubebuilder:validation:Enum=http2;grpc;grpc-web;kubernetes.io/h2c;kubernetes.io/ws;kgateway.dev/a2a
 type AppProtocol string
 
 const (
@@ -84,6 +100,8 @@ const (
 	AppProtocolKubernetesH2C AppProtocol = "kubernetes.io/h2c"
 	// AppProtocolKubernetesWs is the kubernetes.io/ws app protocol.
 	AppProtocolKubernetesWs AppProtocol = "kubernetes.io/ws"
+	// AppProtocolA2A is the agent-to-agent (A2A) app protocol. A2A backends are only supported by agentgateway.
+	AppProtocolA2A AppProtocol = "kgateway.dev/a2a"
 )
 
 // DynamicForwardProxyBackend is the dynamic forward proxy backend configuration.
@@ -239,6 +257,60 @@ type GcpBackend struct {
 	Audience *string `json:"audience,omitempty"`
 }
 
+// DnsResolution defines how Envoy resolves the hostname of a DNS backend.
+// +kubebuilder:validation:Enum=Strict;Logical
+type DnsResolution string
+
+const (
+	// DnsResolutionStrict continuously resolves the hostname and load balances across
+	// all the addresses it resolves to.
+	DnsResolutionStrict DnsResolution = "Strict"
+	// DnsResolutionLogical only uses the first address the hostname resolves to when
+	// a new connection is needed. This is suited for large web services that return
+	// a different set of addresses on each lookup.
+	DnsResolutionLogical DnsResolution = "Logical"
+)
+
+// DnsBackend is an external backend reached through a fully qualified domain name,
+// which is resolved by Envoy.
+type DnsBackend struct {
+	// Hostname is the fully qualified domain name of the backend.
+	// +required
+	Hostname gwv1.PreciseHostname `json:"hostname"`
+	// Port is the port to use for the backend.
+	// +required
+	Port gwv1.PortNumber `json:"port"`
+	// Resolution defines how the hostname is resolved.
+	// Defaults to Strict.
+	// +optional
+	// +kubebuilder:default=Strict
+	Resolution DnsResolution `json:"resolution,omitempty"`
+	// RefreshRate is how often Envoy re-resolves the hostname.
+	// Minimum value is 1ms. If unset, Envoy's default of 5s is used.
+	// The dns refreshRate of a BackendConfigPolicy targeting the backend takes precedence.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1ms')",message="refreshRate must be at least 1ms"
+	RefreshRate *metav1.Duration `json:"refreshRate,omitempty"`
+	// AppProtocol is the application protocol to use when communicating with the backend.
+	// +optional
+	AppProtocol *AppProtocol `json:"appProtocol,omitempty"`
+}
+
+// PluginBackend is the configuration of a backend whose cluster and endpoints are resolved by
+// a backend resolver registered by a plugin.
+type PluginBackend struct {
+	// Name is the name of the backend resolver.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	Name string `json:"name"`
+	// Config is the configuration of the backend, passed as is to the resolver.
+	// +optional
+	Config *runtime.RawExtension `json:"config,omitempty"`
+}
+
 // Host defines a static backend host.
 type Host struct {
 	// Host is the host name to use for the backend.
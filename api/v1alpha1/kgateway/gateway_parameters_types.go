@@ -60,6 +60,16 @@ type GatewayParametersSpec struct {
 	// +optional
 	// +kubebuilder:pruning:PreserveUnknownFields
 	SelfManaged *SelfManagedGateway `json:"selfManaged,omitempty"`
+
+	// StrictValidation rejects unknown fields in the spec of the overlays
+	// defined by these GatewayParameters. Each overlay is decoded against the
+	// type of the resource it patches, and an unknown field fails the
+	// deployment of the Gateway with the path of the field in the error, which
+	// is reported on the status of the GatewayParameters. Defaults to false,
+	// in which case unknown fields are ignored.
+	//
+	// +optional
+	StrictValidation *bool `json:"strictValidation,omitempty"`
 }
 
 func (in *GatewayParametersSpec) GetKube() *KubernetesProxyConfig {
@@ -76,8 +86,75 @@ func (in *GatewayParametersSpec) GetSelfManaged() *SelfManagedGateway {
 	return in.SelfManaged
 }
 
-// The current conditions of the GatewayParameters. This is not currently implemented.
-type GatewayParametersStatus struct{}
+func (in *GatewayParametersSpec) GetStrictValidation() *bool {
+	if in == nil {
+		return nil
+	}
+	return in.StrictValidation
+}
+
+// GatewayParametersStatus reports whether the GatewayParameters could be used to
+// deploy the proxies of the Gateways that reference it.
+type GatewayParametersStatus struct {
+	// Conditions describe the current state of the GatewayParameters.
+	//
+	// Known condition types are:
+	//
+	// * "Accepted": the GatewayParameters could be used to render the resources
+	//   of every Gateway that references it.
+	// * "Applied": the overlays of the GatewayParameters could be applied to the
+	//   resources of every Gateway that references it.
+	//
+	// When they are False, the message lists the failing Gateways with their error.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Gateways are the Gateways whose proxy is currently deployed with the
+	// GatewayParameters, either through their infrastructure parametersRef or
+	// through the parametersRef of their GatewayClass.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=64
+	Gateways []GatewayParametersGatewayReference `json:"gateways,omitempty"`
+}
+
+// GatewayParametersGatewayReference references a Gateway that uses a GatewayParameters.
+type GatewayParametersGatewayReference struct {
+	// Name of the Gateway.
+	// +required
+	Name string `json:"name"`
+
+	// Namespace of the Gateway.
+	// +required
+	Namespace string `json:"namespace"`
+}
+
+const (
+	// GatewayParametersConditionAccepted reports whether the GatewayParameters could be used
+	// to render the resources of the Gateways that reference it.
+	GatewayParametersConditionAccepted = "Accepted"
+	// GatewayParametersConditionApplied reports whether the overlays of the GatewayParameters
+	// could be applied to the resources of the Gateways that reference it.
+	GatewayParametersConditionApplied = "Applied"
+
+	// GatewayParametersReasonAccepted is used with the Accepted condition when it is True.
+	GatewayParametersReasonAccepted = "Accepted"
+	// GatewayParametersReasonInvalid is used with the Accepted condition when the resources
+	// of a Gateway can't be rendered with the GatewayParameters.
+	GatewayParametersReasonInvalid = "Invalid"
+	// GatewayParametersReasonApplied is used with the Applied condition when it is True.
+	GatewayParametersReasonApplied = "Applied"
+	// GatewayParametersReasonOverlayFailed is used with the Applied condition when an
+	// overlay can't be applied to the resources of a Gateway.
+	GatewayParametersReasonOverlayFailed = "OverlayFailed"
+	// GatewayParametersReasonNotAccepted is used with the Applied condition when the
+	// overlays were not applied because the GatewayParameters is not accepted.
+	GatewayParametersReasonNotAccepted = "NotAccepted"
+)
 
 type SelfManagedGateway struct{}
 
@@ -384,6 +461,14 @@ type EnvoyBootstrap struct {
 	//
 	// +optional
 	DnsResolver *DnsResolver `json:"dnsResolver,omitempty"`
+
+	// Configuration of the Envoy admin interface. The admin interface is always
+	// served on 127.0.0.1:19000 inside the proxy pod, for the readiness, metrics
+	// and graceful shutdown endpoints. This configures the admin listener, which
+	// exposes it on port 19001.
+	//
+	// +optional
+	Admin *EnvoyAdmin `json:"admin,omitempty"`
 }
 
 // LogFormat configures Envoy's application log format. Either JSON or Text must be specified.
@@ -414,6 +499,69 @@ type DnsResolver struct {
 	UdpMaxQueries *int32 `json:"udpMaxQueries,omitempty"`
 }
 
+// EnvoyAdmin configures the admin listener, which exposes the Envoy admin interface
+// to GET requests.
+type EnvoyAdmin struct {
+	// Whether the admin listener is enabled. Defaults to false.
+	//
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// The IP address the admin listener binds to. Defaults to 127.0.0.1, i.e.
+	// the admin interface is only reachable from inside the proxy pod, e.g.
+	// through `kubectl port-forward`. Set to 0.0.0.0 or :: to make it reachable
+	// from other pods, preferably along with authTokenSecretRef.
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=2
+	// +kubebuilder:validation:MaxLength=45
+	BindAddress *string `json:"bindAddress,omitempty"`
+
+	// Reference to a Secret in the namespace of the Gateway, whose `token` key
+	// holds the token the requests to the admin listener must present in an
+	// `Authorization: Bearer <token>` header. Requests without it are rejected
+	// with a 401. The Secret is read on every request, so the token can be
+	// rotated without restarting the proxy.
+	//
+	// +optional
+	AuthTokenSecretRef *corev1.LocalObjectReference `json:"authTokenSecretRef,omitempty"`
+
+	// Whether the admin listener accepts `POST /runtime_modify` requests, which
+	// reload the runtime values of the proxy without restarting it. Defaults to
+	// false. The admin listener only accepts GET requests otherwise.
+	//
+	// +optional
+	EnableReload *bool `json:"enableReload,omitempty"`
+}
+
+func (in *EnvoyAdmin) GetEnabled() *bool {
+	if in == nil {
+		return nil
+	}
+	return in.Enabled
+}
+
+func (in *EnvoyAdmin) GetBindAddress() *string {
+	if in == nil {
+		return nil
+	}
+	return in.BindAddress
+}
+
+func (in *EnvoyAdmin) GetAuthTokenSecretRef() *corev1.LocalObjectReference {
+	if in == nil {
+		return nil
+	}
+	return in.AuthTokenSecretRef
+}
+
+func (in *EnvoyAdmin) GetEnableReload() *bool {
+	if in == nil {
+		return nil
+	}
+	return in.EnableReload
+}
+
 func (in *EnvoyBootstrap) GetLogFormat() *LogFormat {
 	if in == nil {
 		return nil
@@ -442,6 +590,13 @@ func (in *EnvoyBootstrap) GetDnsResolver() *DnsResolver {
 	return in.DnsResolver
 }
 
+func (in *EnvoyBootstrap) GetAdmin() *EnvoyAdmin {
+	if in == nil {
+		return nil
+	}
+	return in.Admin
+}
+
 func (in *DnsResolver) GetUdpMaxQueries() *int32 {
 	if in == nil {
 		return nil
@@ -659,6 +814,7 @@ func (in *IstioContainer) GetIstioMetaClusterId() *string {
 }
 
 // Configuration for the stats server.
+// +kubebuilder:validation:XValidation:rule="!has(self.disabledMetrics) || !has(self.matcher) || !has(self.matcher.inclusionList)",message="disabledMetrics cannot be set with matcher.inclusionList"
 type StatsConfig struct {
 	// Whether to expose metrics annotations and ports for scraping metrics.
 	//
@@ -686,6 +842,54 @@ type StatsConfig struct {
 	//
 	// +optional
 	Matcher *StatsMatcher `json:"matcher,omitempty"`
+
+	// ExtraLabels are constant labels added to all the metrics emitted by the
+	// proxy, e.g. to identify the gateway or region in dashboards. Label names
+	// must be valid Prometheus label names and must not start with the
+	// reserved `envoy_` or `__` prefixes, or be `le` or `quantile`.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxProperties=16
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k.matches('^[a-zA-Z_][a-zA-Z0-9_]*$'))",message="extraLabels names must be valid Prometheus label names"
+	// +kubebuilder:validation:XValidation:rule="self.all(k, !k.startsWith('envoy_') && !k.startsWith('__') && k != 'le' && k != 'quantile')",message="extraLabels names must not use a reserved label name"
+	ExtraLabels map[string]string `json:"extraLabels,omitempty"`
+
+	// HistogramBuckets overrides the bucket boundaries of the latency histograms
+	// emitted by the proxy, in milliseconds. Buckets are decimal numbers that must
+	// be positive and in strictly ascending order, e.g. ["0.5", "1", "5", "25", "100"].
+	// If unset, Envoy's default buckets are used.
+	//
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:items:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +kubebuilder:validation:XValidation:rule="self.all(b, double(b) > 0.0)",message="histogramBuckets must be positive"
+	// +kubebuilder:validation:XValidation:rule="self.map(b, double(b)).isSorted()",message="histogramBuckets must be in ascending order"
+	HistogramBuckets []string `json:"histogramBuckets,omitempty"`
+
+	// DisabledMetrics are RE2 regular expressions matching the full names of
+	// the metrics the proxy must not emit, e.g. high-cardinality per-route
+	// metrics such as `vhost\..*\.route\..*`. They are added to the
+	// exclusion list of the matcher, so they cannot be set with an inclusion list.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=1024
+	DisabledMetrics []string `json:"disabledMetrics,omitempty"`
+
+	// Prefix is prepended, followed by an underscore, to the names of the
+	// metrics scraped from the proxy, e.g. `edge` turns `envoy_cluster_upstream_rq`
+	// into `edge_envoy_cluster_upstream_rq`, to avoid collisions in a shared
+	// Prometheus. It must be a valid Prometheus metric name prefix.
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_:][a-zA-Z0-9_:]*$`
+	Prefix *string `json:"prefix,omitempty"`
 }
 
 func (in *StatsConfig) GetEnabled() *bool {
@@ -723,6 +927,34 @@ func (in *StatsConfig) GetMatcher() *StatsMatcher {
 	return in.Matcher
 }
 
+func (in *StatsConfig) GetExtraLabels() map[string]string {
+	if in == nil {
+		return nil
+	}
+	return in.ExtraLabels
+}
+
+func (in *StatsConfig) GetHistogramBuckets() []string {
+	if in == nil {
+		return nil
+	}
+	return in.HistogramBuckets
+}
+
+func (in *StatsConfig) GetDisabledMetrics() []string {
+	if in == nil {
+		return nil
+	}
+	return in.DisabledMetrics
+}
+
+func (in *StatsConfig) GetPrefix() *string {
+	if in == nil {
+		return nil
+	}
+	return in.Prefix
+}
+
 // StatsMatcher specifies either an inclusion or exclusion list for Envoy stats.
 // See Envoy's envoy.config.metrics.v3.StatsMatcher for details.
 // +kubebuilder:validation:MaxProperties=1
@@ -25,6 +25,7 @@ type JWTAuth struct {
 // If multiple providers are specified for a given JWT policy, the providers will be `OR`-ed together and will allow validation to any of the providers.
 type JWTProvider struct {
 	// Issuer of the JWT. the 'iss' claim of the JWT must match this.
+	// Issuers containing a colon must be URIs, e.g. https://accounts.example.com for OIDC providers.
 	// +kubebuilder:validation:MaxLength=2048
 	// +optional
 	Issuer string `json:"issuer"`
@@ -139,6 +140,7 @@ type RemoteJWKS struct {
 	// For example, https://example.com/keys
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern="^https?://[^/?#]+"
 	// +required
 	URL string `json:"url"`
 
@@ -107,6 +107,25 @@ type ListenerConfig struct {
 	// +kubebuilder:validation:Minimum=0
 	PerConnectionBufferLimitBytes *int32 `json:"perConnectionBufferLimitBytes,omitempty"`
 
+	// IdleTimeout is the idle timeout for the downstream connections of the listener. A connection
+	// without traffic or active requests for this long is closed, so idle connections do not
+	// accumulate on long-lived listeners.
+	// For HTTP listeners, it sets the idle timeout of the HTTP connection manager, and httpSettings.idleTimeout
+	// takes precedence when both are set. For TCP and TLS listeners, it sets the idle timeout of the TCP proxy.
+	// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/api-v3/extensions/filters/network/tcp_proxy/v3/tcp_proxy.proto#envoy-v3-api-field-extensions-filters-network-tcp-proxy-v3-tcpproxy-idle-timeout
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="idleTimeout must be positive"
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+
+	// OriginalDst enables the transparent proxy mode of the listener, for mesh sidecar scenarios where
+	// connections are redirected to the gateway (e.g. by iptables). The original destination of the
+	// connections is restored and they are handed off to the listener of the original destination port.
+	// It is mutually exclusive with routes: the listener is not programmed if routes are attached to it.
+	// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/listener/v3/listener.proto#envoy-v3-api-field-config-listener-v3-listener-use-original-dst
+	// +optional
+	OriginalDst *bool `json:"originalDst,omitempty"`
+
 	// RBAC specifies network-level role-based access control for this listener.
 	// Network RBAC is evaluated at the TCP connection level, before any HTTP processing begins.
 	// This allows filtering based on connection attributes such as source IP address, destination port,
@@ -275,6 +294,14 @@ type HTTPSettings struct {
 	// +kubebuilder:validation:Maximum=8192
 	MaxRequestHeadersKb *int32 `json:"maxRequestHeadersKb,omitempty"`
 
+	// MaxRequestHeadersCount sets the maximum number of request headers that Envoy will accept.
+	// Requests with more headers are rejected, which guards against header-bomb attacks.
+	// If unset, the Envoy default is 100.
+	// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/core/v3/protocol.proto#envoy-v3-api-field-config-core-v3-httpprotocoloptions-max-headers-count
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxRequestHeadersCount *int32 `json:"maxRequestHeadersCount,omitempty"`
+
 	// UuidRequestIdConfig configures the behavior of the UUID request ID extension.
 	// This extension sets the x-request-id header to a UUID value.
 	// +optional
@@ -298,14 +325,55 @@ type AccessLog struct {
 	// Filter access logs configuration
 	// +optional
 	Filter *AccessLogFilter `json:"filter,omitempty"`
+
+	// Sampling logs only a fraction of the requests that pass the filter, to
+	// reduce the volume of access logs on high-traffic gateways.
+	// +optional
+	Sampling *AccessLogSampling `json:"sampling,omitempty"`
+}
+
+// AccessLogSampling configures the fraction of requests that are logged, as
+// either a percentage or a rate.
+// When a request has an x-request-id header, sampling is based on it, so that a
+// sampled request is logged by every access log with the same sampling
+// configuration.
+// +kubebuilder:validation:ExactlyOneOf=percentage;rate
+type AccessLogSampling struct {
+	// Percentage of requests to log, from 0 to 100.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percentage *int32 `json:"percentage,omitempty"`
+
+	// Rate of requests to log, as a fraction, e.g. a numerator of 1 and a
+	// denominator of TEN_THOUSAND to log one request in ten thousand.
+	// +optional
+	Rate *FractionalPercent `json:"rate,omitempty"`
+
+	// AlwaysLogErrors logs requests that fail, i.e. with a 5xx response code or
+	// an Envoy response flag such as an upstream connection failure, regardless
+	// of sampling. The filter of the access log still applies to them.
+	// +optional
+	AlwaysLogErrors *bool `json:"alwaysLogErrors,omitempty"`
 }
 
 // FileSink represents the file sink configuration for access logs.
 // +kubebuilder:validation:ExactlyOneOf=stringFormat;jsonFormat
+// +kubebuilder:validation:XValidation:message="exactly one of path or destination must be set",rule="has(self.path) != has(self.destination)"
 type FileSink struct {
 	// the file path to which the file access logging service will sink
-	// +required
-	Path string `json:"path"`
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Destination is where the access logs are written: `stdout` or `stderr`, so
+	// that they are collected along with the proxy's container logs, or an
+	// absolute file path, e.g. on a volume shared with a log collector sidecar.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=4096
+	// +kubebuilder:validation:XValidation:message="destination must be stdout, stderr or an absolute file path",rule="self == 'stdout' || self == 'stderr' || self.startsWith('/')"
+	Destination *string `json:"destination,omitempty"`
+
 	// the format string by which envoy will format the log lines
 	// https://www.envoyproxy.io/docs/envoy/v1.33.0/configuration/observability/access_log/usage#format-strings
 	// +optional
@@ -316,6 +384,13 @@ type FileSink struct {
 	JsonFormat *runtime.RawExtension `json:"jsonFormat,omitempty"`
 }
 
+const (
+	// AccessLogDestinationStdout writes access logs to the standard output of the proxy.
+	AccessLogDestinationStdout = "stdout"
+	// AccessLogDestinationStderr writes access logs to the standard error of the proxy.
+	AccessLogDestinationStderr = "stderr"
+)
+
 // AccessLogGrpcService represents the gRPC service configuration for access logs.
 // Ref: https://www.envoyproxy.io/docs/envoy/latest/api-v3/extensions/access_loggers/grpc/v3/als.proto#envoy-v3-api-msg-extensions-access-loggers-grpc-v3-httpgrpcaccesslogconfig
 type AccessLogGrpcService struct {
@@ -424,11 +499,17 @@ type BackoffStrategy struct {
 }
 
 // OpenTelemetryAccessLogService represents the OTel configuration for access logs.
+// Access logs are exported with OTLP, over either gRPC or HTTP.
 // Ref: https://www.envoyproxy.io/docs/envoy/latest/api-v3/extensions/access_loggers/open_telemetry/v3/logs_service.proto
+// +kubebuilder:validation:ExactlyOneOf=grpcService;httpService
 type OpenTelemetryAccessLogService struct {
 	// Send access logs to gRPC service
-	// +required
-	GrpcService CommonAccessLogGrpcService `json:"grpcService"`
+	// +optional
+	GrpcService *CommonAccessLogGrpcService `json:"grpcService,omitempty"`
+
+	// Send access logs to an OTLP/HTTP endpoint
+	// +optional
+	HttpService *OpenTelemetryAccessLogHttpService `json:"httpService,omitempty"`
 
 	// OpenTelemetry LogResource fields, following Envoy access logging formatting.
 	// +optional
@@ -449,6 +530,36 @@ type OpenTelemetryAccessLogService struct {
 	ResourceAttributes *KeyAnyValueList `json:"resourceAttributes,omitempty"`
 }
 
+// OpenTelemetryAccessLogHttpService represents the OTLP/HTTP endpoint access logs are exported to.
+// Ref: https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/core/v3/http_service.proto
+type OpenTelemetryAccessLogHttpService struct {
+	// The backend of the OTLP collector. Can be any type of supported backend (Kubernetes Service, kgateway Backend, etc..)
+	// +required
+	BackendRef gwv1.BackendRef `json:"backendRef"`
+
+	// Endpoint is the URL the access logs are posted to, it must be a full URL with protocol, host and path.
+	// For example, http://otel-collector.monitoring:4318/v1/logs
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern="^https?://[^/?#]+"
+	// +required
+	Endpoint string `json:"endpoint"`
+
+	// Headers to add to the export requests, e.g. to authenticate with the collector.
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	Headers []HeaderValue `json:"headers,omitempty"`
+
+	// The timeout of the export requests. Defaults to 2s.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// name of log stream
+	// +optional
+	LogName *string `json:"logName,omitempty"`
+}
+
 // A list of key-value pair that is used to store Span attributes, Link attributes, etc.
 type KeyAnyValueList struct {
 	// A collection of key/value pairs of key-value pairs.
@@ -658,11 +769,24 @@ type GrpcStatusFilter struct {
 
 // Tracing represents the top-level Envoy's tracer.
 // Ref: https://www.envoyproxy.io/docs/envoy/latest/api-v3/extensions/filters/network/http_connection_manager/v3/http_connection_manager.proto#extensions-filters-network-http-connection-manager-v3-httpconnectionmanager-tracing
+// +kubebuilder:validation:XValidation:message="the openTelemetry provider only supports W3C propagation",rule="!has(self.propagation) || self.propagation == 'W3C' || !has(self.provider.openTelemetry)"
+// +kubebuilder:validation:XValidation:message="the zipkin provider only supports B3 and B3AndW3C propagation",rule="!has(self.propagation) || self.propagation != 'W3C' || !has(self.provider.zipkin)"
+// +kubebuilder:validation:XValidation:message="the datadog provider does not support setting the propagation",rule="!has(self.propagation) || !has(self.provider.datadog)"
 type Tracing struct {
 	// Provider defines the upstream to which envoy sends traces
 	// +required
 	Provider TracingProvider `json:"provider"`
 
+	// Propagation selects the headers the trace context is extracted from and
+	// injected into: `W3C` for the W3C trace context `traceparent` and `tracestate`
+	// headers, `B3` for the Zipkin B3 headers, or `B3AndW3C` to extract either,
+	// preferring B3, and inject both.
+	// Defaults to the propagation of the provider. The OpenTelemetry provider
+	// only supports W3C propagation, the Zipkin provider B3 and B3AndW3C
+	// propagation, and the Datadog provider does not support setting it.
+	// +optional
+	Propagation *TracingPropagation `json:"propagation,omitempty"`
+
 	// Target percentage of requests managed by this HTTP connection manager that will be force traced if the x-client-trace-id header is set. Defaults to 100%
 	// +optional
 	// +kubebuilder:validation:Minimum=0
@@ -694,12 +818,39 @@ type Tracing struct {
 	// +optional
 	Attributes []CustomAttribute `json:"attributes,omitempty"`
 
+	// CustomTags adds the value of a request header to the active span as a tag,
+	// keyed by the tag name. No tag is added when the request does not have the header.
+	// A tag name must not also be set in attributes.
+	// +optional
+	// +kubebuilder:validation:MaxProperties=32
+	// +kubebuilder:validation:XValidation:rule="self.all(tag, tag != '')",message="custom tag names must not be empty"
+	// +kubebuilder:validation:XValidation:rule=`self.all(tag, self[tag].matches('^:?[A-Za-z0-9!#$%&\x27*+.^_\x60|~-]+$'))`,message="custom tag header names must be valid HTTP header names"
+	CustomTags map[string]CustomTagHeaderName `json:"customTags,omitempty"`
+
 	// Create separate tracing span for each upstream request if true. Defaults to false
 	// Link to envoy docs for more info
 	// +optional
 	SpawnUpstreamSpan *bool `json:"spawnUpstreamSpan,omitempty"`
 }
 
+// CustomTagHeaderName is the name of the request header a custom tag takes its value from.
+// +kubebuilder:validation:MaxLength=256
+type CustomTagHeaderName string
+
+// TracingPropagation is the format in which the trace context is propagated.
+// +kubebuilder:validation:Enum=W3C;B3;B3AndW3C
+type TracingPropagation string
+
+const (
+	// TracingPropagationW3C propagates the trace context in the W3C trace context headers.
+	TracingPropagationW3C TracingPropagation = "W3C"
+	// TracingPropagationB3 propagates the trace context in the Zipkin B3 headers.
+	TracingPropagationB3 TracingPropagation = "B3"
+	// TracingPropagationB3AndW3C extracts the trace context from either the B3 or the W3C
+	// headers, preferring B3, and injects it into both.
+	TracingPropagationB3AndW3C TracingPropagation = "B3AndW3C"
+)
+
 // Describes attributes for the active span.
 // Ref: https://www.envoyproxy.io/docs/envoy/latest/api-v3/type/tracing/v3/custom_tag.proto#envoy-v3-api-msg-type-tracing-v3-customtag
 // +kubebuilder:validation:MaxProperties=2
@@ -818,6 +969,43 @@ type TracingProvider struct {
 	// Tracing contains various settings for Envoy's OTel tracer.
 	// +optional
 	OpenTelemetry *OpenTelemetryTracingConfig `json:"openTelemetry,omitempty"`
+
+	// Zipkin sends traces to a Zipkin collector.
+	// +optional
+	Zipkin *ZipkinTracingConfig `json:"zipkin,omitempty"`
+
+	// Datadog sends traces to a Datadog agent.
+	// +optional
+	Datadog *DatadogTracingConfig `json:"datadog,omitempty"`
+}
+
+// ZipkinTracingConfig represents Envoy's Zipkin tracer.
+// The spans are reported with the envoy cluster name as the service name. Ie: `<gateway-name>.<gateway-namespace>`
+// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/trace/v3/zipkin.proto.html
+type ZipkinTracingConfig struct {
+	// The Zipkin collector to send the spans to. Can be any type of supported backend (Kubernetes Service, kgateway Backend, etc..)
+	// +required
+	BackendRef gwv1.BackendRef `json:"backendRef"`
+
+	// The API endpoint of the Zipkin collector the spans are sent to. Defaults to `/api/v2/spans`
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^/`
+	CollectorEndpoint *string `json:"collectorEndpoint,omitempty"`
+}
+
+// DatadogTracingConfig represents Envoy's Datadog tracer.
+// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/trace/v3/datadog.proto.html
+type DatadogTracingConfig struct {
+	// The Datadog agent to send the traces to. Can be any type of supported backend (Kubernetes Service, kgateway Backend, etc..)
+	// +required
+	BackendRef gwv1.BackendRef `json:"backendRef"`
+
+	// The name for the service the traces are reported for.
+	// Defaults to the envoy cluster name. Ie: `<gateway-name>.<gateway-namespace>`
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	ServiceName *string `json:"serviceName,omitempty"`
 }
 
 // OpenTelemetryTracingConfig represents the top-level Envoy's OpenTelemetry tracer.
@@ -956,6 +1144,15 @@ type ClientCertificateValidationConfig struct {
 	// +kubebuilder:validation:MaxItems=8
 	// +required
 	CACertificateRefs []gwv1.ObjectReference `json:"caCertificateRefs"`
+
+	// VerifySubjectAltNames restricts accepted client certificates to those whose DNS
+	// Subject Alternative Name matches one of the specified values. When unset, any
+	// client certificate signed by a trusted CA is accepted.
+	// Takes precedence over the kgateway.dev/verify-subject-alt-names listener TLS option.
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MinLength=1
+	// +optional
+	VerifySubjectAltNames []string `json:"verifySubjectAltNames,omitempty"`
 }
 
 // ClientCertificateValidationMode specifies how client certificate validation is enforced.
@@ -0,0 +1,41 @@
+package kgateway
+
+import (
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// LuaFilter configures a Lua script that is run by the Envoy Lua filter.
+// The script must define an `envoy_on_request` and/or `envoy_on_response` function.
+// See https://www.envoyproxy.io/docs/envoy/latest/configuration/http/http_filters/lua_filter
+//
+// +kubebuilder:validation:ExactlyOneOf=inline;configMapRef
+type LuaFilter struct {
+	// Inline is the Lua source code of the script.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=65536
+	Inline *string `json:"inline,omitempty"`
+
+	// ConfigMapRef references a ConfigMap key containing the Lua source code of the script.
+	// +optional
+	ConfigMapRef *LuaConfigMapReference `json:"configMapRef,omitempty"`
+}
+
+// LuaConfigMapReference identifies a key in a Kubernetes ConfigMap containing a Lua script.
+type LuaConfigMapReference struct {
+	// Name of the ConfigMap containing the Lua script.
+	// +required
+	Name gwv1.ObjectName `json:"name"`
+
+	// Namespace of the ConfigMap. If not specified, defaults to the namespace of the TrafficPolicy.
+	// Note that a ConfigMap in a different namespace requires a ReferenceGrant to be accessible.
+	// +optional
+	Namespace *gwv1.Namespace `json:"namespace,omitempty"`
+
+	// Key in the ConfigMap that contains the Lua script.
+	// Defaults to "script.lua" if not specified.
+	// +optional
+	// +kubebuilder:default="script.lua"
+	// +kubebuilder:validation:MinLength=1
+	Key *string `json:"key,omitempty"`
+}
@@ -126,7 +126,8 @@ type TrafficPolicySpec struct {
 	JWTAuth *JWTAuth `json:"jwtAuth,omitempty"`
 
 	// UrlRewrite specifies URL rewrite rules for matching requests.
-	// NOTE: This field is only honored for HTTPRoute targets.
+	// NOTE: This field is only honored for HTTPRoute targets. It is ignored on the rules that have a
+	// URLRewrite or RequestRedirect filter, and the policy reports a Conflicted condition.
 	// +optional
 	UrlRewrite *URLRewrite `json:"urlRewrite,omitempty"`
 
@@ -165,8 +166,36 @@ type TrafficPolicySpec struct {
 	// FaultInjection configures fault injection for chaos engineering and
 	// resiliency testing. Supports delay injection, abort injection,
 	// and response rate limiting.
+	// Injecting faults requires the controller to be started with
+	// KGW_ENABLE_FAULT_INJECTION=true; policies that inject faults are
+	// rejected otherwise.
 	// +optional
 	FaultInjection *FaultInjectionPolicy `json:"faultInjection,omitempty"`
+
+	// LuaFilters configures Lua scripts to run on requests and responses.
+	// Scripts are executed in the order they are listed.
+	// LuaFilters set on a more specific attachment point (e.g. a route) replace
+	// the ones inherited from a less specific one (e.g. a gateway).
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	LuaFilters []LuaFilter `json:"luaFilters,omitempty"`
+
+	// GRPC configures gRPC specific behavior, such as gRPC-Web translation and
+	// timeouts requested by gRPC clients.
+	// Requests are proxied to backends over HTTP/2 when the backend port sets
+	// its `appProtocol` to `grpc` or `kubernetes.io/h2c`.
+	// +optional
+	GRPC *GRPCPolicy `json:"grpc,omitempty"`
+
+	// UpgradeConfigs enables HTTP upgrades, such as WebSocket, on the targeted routes,
+	// in addition to the upgrades enabled on the listener via ListenerPolicy.
+	// NOTE: This field is only honored for HTTPRoute targets.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=2
+	UpgradeConfigs []UpgradeType `json:"upgradeConfigs,omitempty"`
 }
 
 // URLRewrite specifies URL rewrite rules using regular expressions.
@@ -180,18 +209,16 @@ type URLRewrite struct {
 
 // PathRegexRewrite specifies how to rewrite the URL path.
 type PathRegexRewrite struct {
-	// Pattern is the regex pattern that matches the URL path.
+	// Pattern is the regex pattern that matches the URL path, without the query string.
 	// The pattern must be a valid RE2 regular expression.
-	// If the HTTPRoute uses a RegularExpression path match, this field can use capture groups
-	// from that match.
 	// +required
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=1024
 	Pattern string `json:"pattern"`
 
 	// Substitution is the replacement string for the matched pattern.
-	// It can include backreferences to captured groups from the pattern (e.g., \1, \2)
-	// or named groups (e.g., \g<name>).
+	// It can reference the groups captured by the pattern with \1 to \9, e.g.
+	// `^/api/v(\d+)/users/(.*)$` with `/internal/users/\2?version=\1`.
 	// +required
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=1024
@@ -345,6 +372,7 @@ type RateLimit struct {
 
 // LocalRateLimitPolicy represents a policy for local rate limiting.
 // It defines the configuration for rate limiting using a token bucket mechanism.
+// +kubebuilder:validation:XValidation:rule="!has(self.perClientIP) || !self.perClientIP || has(self.tokenBucket)",message="tokenBucket must be set when perClientIP is enabled"
 type LocalRateLimitPolicy struct {
 	// TokenBucket represents the configuration for a token bucket local rate-limiting mechanism.
 	// It defines the parameters for controlling the rate at which requests are allowed.
@@ -362,6 +390,13 @@ type LocalRateLimitPolicy struct {
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=100
 	PercentEnforced *int32 `json:"percentEnforced,omitempty"`
+
+	// PerClientIP, when true, gives each downstream client IP address its own token bucket
+	// configured by TokenBucket, instead of a single bucket shared by all clients.
+	// The client IP is the remote address as determined by the listener, which honors
+	// the PROXY protocol and trusted X-Forwarded-For hops when they are configured.
+	// +optional
+	PerClientIP *bool `json:"perClientIP,omitempty"`
 }
 
 // TokenBucket defines the configuration for a token bucket rate-limiting mechanism.
@@ -636,8 +671,9 @@ type Buffer struct {
 // +kubebuilder:validation:AtLeastOneOf=responseCompression;requestDecompression
 type Compression struct {
 	// ResponseCompression controls response compression to the downstream.
-	// If set, responses with the appropriate `Accept-Encoding` header with certain textual content types will be compressed using gzip.
-	// The content-types that will be compressed are:
+	// If set, responses with the appropriate `Accept-Encoding` header with certain textual content types will be compressed,
+	// using gzip unless other algorithms are configured.
+	// Unless configured otherwise, the content-types that will be compressed are:
 	// - `application/javascript`
 	// - `application/json`
 	// - `application/xhtml+xml`
@@ -660,8 +696,43 @@ type ResponseCompression struct {
 	// Disables compression.
 	// +optional
 	Disable *shared.PolicyDisable `json:"disable,omitempty"`
+
+	// Algorithms is the list of compression algorithms to offer. The algorithm
+	// used for a response is negotiated with the client via the `Accept-Encoding`
+	// header. If unset, only gzip is used.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=2
+	Algorithms []CompressionAlgorithm `json:"algorithms,omitempty"`
+
+	// MinContentLength is the minimum response size, in bytes, that triggers
+	// compression. If unset, Envoy's default of 30 bytes is used.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinContentLength *int32 `json:"minContentLength,omitempty"`
+
+	// ContentTypes is the list of response content types to compress. If unset,
+	// common textual content types such as `application/json`, `text/html` and
+	// `text/plain` are compressed.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	ContentTypes []string `json:"contentTypes,omitempty"`
 }
 
+// CompressionAlgorithm is a response compression algorithm.
+// +kubebuilder:validation:Enum=Gzip;Brotli
+type CompressionAlgorithm string
+
+const (
+	// CompressionAlgorithmGzip compresses responses using gzip.
+	CompressionAlgorithmGzip CompressionAlgorithm = "Gzip"
+	// CompressionAlgorithmBrotli compresses responses using brotli.
+	CompressionAlgorithmBrotli CompressionAlgorithm = "Brotli"
+)
+
 // RequestDecompression enables request gzip decompression.
 type RequestDecompression struct {
 	// Disables decompression.
@@ -802,3 +873,44 @@ type FaultResponseRateLimit struct {
 	// +kubebuilder:default=100
 	Percentage *int32 `json:"percentage,omitempty"`
 }
+
+// GRPCPolicy configures gRPC specific behavior for routes.
+//
+// +kubebuilder:validation:AtLeastOneOf=web;maxTimeout
+// +kubebuilder:validation:XValidation:rule="!has(self.timeoutOffset) || has(self.maxTimeout)",message="timeoutOffset requires maxTimeout to be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.timeoutOffset) || !has(self.maxTimeout) || duration(self.maxTimeout) == duration('0s') || duration(self.timeoutOffset) < duration(self.maxTimeout)",message="timeoutOffset must be less than maxTimeout"
+type GRPCPolicy struct {
+	// Web enables the translation of gRPC-Web requests from browser clients to
+	// gRPC. As gRPC-Web is carried over HTTP/1.1, it can only be enabled on
+	// listeners that accept HTTP/1.1 requests; it is rejected on TLS listeners
+	// that only negotiate `h2` via ALPN.
+	// +optional
+	Web *bool `json:"web,omitempty"`
+
+	// MaxTimeout caps the timeout a client can request using the `grpc-timeout`
+	// header. When set, the `grpc-timeout` header takes precedence over the route
+	// timeout, limited to this value. A value of 0s does not cap the timeout
+	// requested by the client.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	MaxTimeout *metav1.Duration `json:"maxTimeout,omitempty"`
+
+	// TimeoutOffset is subtracted from the timeout requested using the
+	// `grpc-timeout` header, so that the gateway times out before the client
+	// and can return a meaningful error. Only applies when maxTimeout is set, and
+	// must be less than maxTimeout unless maxTimeout is 0s.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	TimeoutOffset *metav1.Duration `json:"timeoutOffset,omitempty"`
+}
+
+// UpgradeType is the type of an HTTP upgrade.
+// +kubebuilder:validation:Enum=websocket;CONNECT
+type UpgradeType string
+
+const (
+	// UpgradeTypeWebSocket upgrades HTTP/1.1 connections to WebSocket.
+	UpgradeTypeWebSocket UpgradeType = "websocket"
+	// UpgradeTypeConnect proxies CONNECT requests.
+	UpgradeTypeConnect UpgradeType = "CONNECT"
+)
@@ -114,6 +114,11 @@ func (in *AccessLog) DeepCopyInto(out *AccessLog) {
 		*out = new(AccessLogFilter)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Sampling != nil {
+		in, out := &in.Sampling, &out.Sampling
+		*out = new(AccessLogSampling)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLog.
@@ -191,6 +196,36 @@ func (in *AccessLogGrpcService) DeepCopy() *AccessLogGrpcService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogSampling) DeepCopyInto(out *AccessLogSampling) {
+	*out = *in
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Rate != nil {
+		in, out := &in.Rate, &out.Rate
+		*out = new(FractionalPercent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AlwaysLogErrors != nil {
+		in, out := &in.AlwaysLogErrors, &out.AlwaysLogErrors
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogSampling.
+func (in *AccessLogSampling) DeepCopy() *AccessLogSampling {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogSampling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlwaysOnConfig) DeepCopyInto(out *AlwaysOnConfig) {
 	*out = *in
@@ -462,6 +497,11 @@ func (in *BackendConfigPolicySpec) DeepCopyInto(out *BackendConfigPolicySpec) {
 		*out = new(TCPKeepalive)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DualStackConnect != nil {
+		in, out := &in.DualStackConnect, &out.DualStackConnect
+		*out = new(bool)
+		**out = **in
+	}
 	if in.CommonHttpProtocolOptions != nil {
 		in, out := &in.CommonHttpProtocolOptions, &out.CommonHttpProtocolOptions
 		*out = new(CommonHttpProtocolOptions)
@@ -477,6 +517,11 @@ func (in *BackendConfigPolicySpec) DeepCopyInto(out *BackendConfigPolicySpec) {
 		*out = new(Http2ProtocolOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.UpstreamProtocol != nil {
+		in, out := &in.UpstreamProtocol, &out.UpstreamProtocol
+		*out = new(UpstreamProtocol)
+		**out = **in
+	}
 	if in.TLS != nil {
 		in, out := &in.TLS, &out.TLS
 		*out = new(TLS)
@@ -502,11 +547,21 @@ func (in *BackendConfigPolicySpec) DeepCopyInto(out *BackendConfigPolicySpec) {
 		*out = new(CircuitBreakers)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ConnectionPool != nil {
+		in, out := &in.ConnectionPool, &out.ConnectionPool
+		*out = new(ConnectionPool)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.UpstreamProxyProtocol != nil {
 		in, out := &in.UpstreamProxyProtocol, &out.UpstreamProxyProtocol
 		*out = new(UpstreamProxyProtocol)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.UnreadyEndpointFallback != nil {
+		in, out := &in.UnreadyEndpointFallback, &out.UnreadyEndpointFallback
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendConfigPolicySpec.
@@ -579,6 +634,16 @@ func (in *BackendSpec) DeepCopyInto(out *BackendSpec) {
 		*out = new(GcpBackend)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Dns != nil {
+		in, out := &in.Dns, &out.Dns
+		*out = new(DnsBackend)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(PluginBackend)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendSpec.
@@ -831,6 +896,11 @@ func (in *ClientCertificateValidationConfig) DeepCopyInto(out *ClientCertificate
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.VerifySubjectAltNames != nil {
+		in, out := &in.VerifySubjectAltNames, &out.VerifySubjectAltNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientCertificateValidationConfig.
@@ -982,6 +1052,31 @@ func (in *Compression) DeepCopy() *Compression {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionPool) DeepCopyInto(out *ConnectionPool) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPConnectionPool)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TCP != nil {
+		in, out := &in.TCP, &out.TCP
+		*out = new(TCPConnectionPool)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionPool.
+func (in *ConnectionPool) DeepCopy() *ConnectionPool {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cookie) DeepCopyInto(out *Cookie) {
 	*out = *in
@@ -1188,6 +1283,27 @@ func (in *DNS) DeepCopy() *DNS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogTracingConfig) DeepCopyInto(out *DatadogTracingConfig) {
+	*out = *in
+	in.BackendRef.DeepCopyInto(&out.BackendRef)
+	if in.ServiceName != nil {
+		in, out := &in.ServiceName, &out.ServiceName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogTracingConfig.
+func (in *DatadogTracingConfig) DeepCopy() *DatadogTracingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogTracingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DirectResponse) DeepCopyInto(out *DirectResponse) {
 	*out = *in
@@ -1272,6 +1388,31 @@ func (in *DirectResponseSpec) DeepCopy() *DirectResponseSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DnsBackend) DeepCopyInto(out *DnsBackend) {
+	*out = *in
+	if in.RefreshRate != nil {
+		in, out := &in.RefreshRate, &out.RefreshRate
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.AppProtocol != nil {
+		in, out := &in.AppProtocol, &out.AppProtocol
+		*out = new(AppProtocol)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DnsBackend.
+func (in *DnsBackend) DeepCopy() *DnsBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(DnsBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DnsResolver) DeepCopyInto(out *DnsResolver) {
 	*out = *in
@@ -1383,6 +1524,41 @@ func (in *EnvironmentResourceDetectorConfig) DeepCopy() *EnvironmentResourceDete
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyAdmin) DeepCopyInto(out *EnvoyAdmin) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BindAddress != nil {
+		in, out := &in.BindAddress, &out.BindAddress
+		*out = new(string)
+		**out = **in
+	}
+	if in.AuthTokenSecretRef != nil {
+		in, out := &in.AuthTokenSecretRef, &out.AuthTokenSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.EnableReload != nil {
+		in, out := &in.EnableReload, &out.EnableReload
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyAdmin.
+func (in *EnvoyAdmin) DeepCopy() *EnvoyAdmin {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyAdmin)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvoyBootstrap) DeepCopyInto(out *EnvoyBootstrap) {
 	*out = *in
@@ -1408,6 +1584,11 @@ func (in *EnvoyBootstrap) DeepCopyInto(out *EnvoyBootstrap) {
 		*out = new(DnsResolver)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Admin != nil {
+		in, out := &in.Admin, &out.Admin
+		*out = new(EnvoyAdmin)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyBootstrap.
@@ -1859,6 +2040,11 @@ func (in *FaultResponseRateLimit) DeepCopy() *FaultResponseRateLimit {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileSink) DeepCopyInto(out *FileSink) {
 	*out = *in
+	if in.Destination != nil {
+		in, out := &in.Destination, &out.Destination
+		*out = new(string)
+		**out = **in
+	}
 	if in.StringFormat != nil {
 		in, out := &in.StringFormat, &out.StringFormat
 		*out = new(string)
@@ -1961,6 +2147,36 @@ func (in *FractionalPercent) DeepCopy() *FractionalPercent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCPolicy) DeepCopyInto(out *GRPCPolicy) {
+	*out = *in
+	if in.Web != nil {
+		in, out := &in.Web, &out.Web
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxTimeout != nil {
+		in, out := &in.MaxTimeout, &out.MaxTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.TimeoutOffset != nil {
+		in, out := &in.TimeoutOffset, &out.TimeoutOffset
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCPolicy.
+func (in *GRPCPolicy) DeepCopy() *GRPCPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewayExtension) DeepCopyInto(out *GatewayExtension) {
 	*out = *in
@@ -2093,7 +2309,7 @@ func (in *GatewayParameters) DeepCopyInto(out *GatewayParameters) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParameters.
@@ -2114,6 +2330,21 @@ func (in *GatewayParameters) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayParametersGatewayReference) DeepCopyInto(out *GatewayParametersGatewayReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParametersGatewayReference.
+func (in *GatewayParametersGatewayReference) DeepCopy() *GatewayParametersGatewayReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParametersGatewayReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewayParametersList) DeepCopyInto(out *GatewayParametersList) {
 	*out = *in
@@ -2204,6 +2435,11 @@ func (in *GatewayParametersSpec) DeepCopyInto(out *GatewayParametersSpec) {
 		*out = new(SelfManagedGateway)
 		**out = **in
 	}
+	if in.StrictValidation != nil {
+		in, out := &in.StrictValidation, &out.StrictValidation
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParametersSpec.
@@ -2219,6 +2455,18 @@ func (in *GatewayParametersSpec) DeepCopy() *GatewayParametersSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewayParametersStatus) DeepCopyInto(out *GatewayParametersStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Gateways != nil {
+		in, out := &in.Gateways, &out.Gateways
+		*out = make([]GatewayParametersGatewayReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParametersStatus.
@@ -2301,6 +2549,31 @@ func (in *GrpcStatusFilter) DeepCopy() *GrpcStatusFilter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPConnectionPool) DeepCopyInto(out *HTTPConnectionPool) {
+	*out = *in
+	if in.MaxRequestsPerConnection != nil {
+		in, out := &in.MaxRequestsPerConnection, &out.MaxRequestsPerConnection
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPConnectionPool.
+func (in *HTTPConnectionPool) DeepCopy() *HTTPConnectionPool {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPConnectionPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPListenerPolicy) DeepCopyInto(out *HTTPListenerPolicy) {
 	*out = *in
@@ -2483,6 +2756,11 @@ func (in *HTTPSettings) DeepCopyInto(out *HTTPSettings) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MaxRequestHeadersCount != nil {
+		in, out := &in.MaxRequestHeadersCount, &out.MaxRequestHeadersCount
+		*out = new(int32)
+		**out = **in
+	}
 	if in.UuidRequestIdConfig != nil {
 		in, out := &in.UuidRequestIdConfig, &out.UuidRequestIdConfig
 		*out = new(UuidRequestIdConfig)
@@ -3182,6 +3460,16 @@ func (in *ListenerConfig) DeepCopyInto(out *ListenerConfig) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.OriginalDst != nil {
+		in, out := &in.OriginalDst, &out.OriginalDst
+		*out = new(bool)
+		**out = **in
+	}
 	if in.RBAC != nil {
 		in, out := &in.RBAC, &out.RBAC
 		*out = new(shared.Authorization)
@@ -3384,6 +3672,16 @@ func (in *LoadBalancer) DeepCopyInto(out *LoadBalancer) {
 		*out = new(LocalityType)
 		**out = **in
 	}
+	if in.ZoneAware != nil {
+		in, out := &in.ZoneAware, &out.ZoneAware
+		*out = new(ZoneAwareLoadBalancing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Subset != nil {
+		in, out := &in.Subset, &out.Subset
+		*out = new(LoadBalancerSubsetConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CloseConnectionsOnHostSetChange != nil {
 		in, out := &in.CloseConnectionsOnHostSetChange, &out.CloseConnectionsOnHostSetChange
 		*out = new(bool)
@@ -3520,6 +3818,28 @@ func (in *LoadBalancerRoundRobinConfig) DeepCopy() *LoadBalancerRoundRobinConfig
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerSubsetConfig) DeepCopyInto(out *LoadBalancerSubsetConfig) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerSubsetConfig.
+func (in *LoadBalancerSubsetConfig) DeepCopy() *LoadBalancerSubsetConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerSubsetConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalJWKS) DeepCopyInto(out *LocalJWKS) {
 	*out = *in
@@ -3563,6 +3883,11 @@ func (in *LocalRateLimitPolicy) DeepCopyInto(out *LocalRateLimitPolicy) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.PerClientIP != nil {
+		in, out := &in.PerClientIP, &out.PerClientIP
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalRateLimitPolicy.
@@ -3600,6 +3925,56 @@ func (in *LogFormat) DeepCopy() *LogFormat {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LuaConfigMapReference) DeepCopyInto(out *LuaConfigMapReference) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(apisv1.Namespace)
+		**out = **in
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LuaConfigMapReference.
+func (in *LuaConfigMapReference) DeepCopy() *LuaConfigMapReference {
+	if in == nil {
+		return nil
+	}
+	out := new(LuaConfigMapReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LuaFilter) DeepCopyInto(out *LuaFilter) {
+	*out = *in
+	if in.Inline != nil {
+		in, out := &in.Inline, &out.Inline
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(LuaConfigMapReference)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LuaFilter.
+func (in *LuaFilter) DeepCopy() *LuaFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(LuaFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetadataKey) DeepCopyInto(out *MetadataKey) {
 	*out = *in
@@ -3942,10 +4317,52 @@ func (in *OAuth2Provider) DeepCopy() *OAuth2Provider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenTelemetryAccessLogHttpService) DeepCopyInto(out *OpenTelemetryAccessLogHttpService) {
+	*out = *in
+	in.BackendRef.DeepCopyInto(&out.BackendRef)
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HeaderValue, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LogName != nil {
+		in, out := &in.LogName, &out.LogName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryAccessLogHttpService.
+func (in *OpenTelemetryAccessLogHttpService) DeepCopy() *OpenTelemetryAccessLogHttpService {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenTelemetryAccessLogHttpService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenTelemetryAccessLogService) DeepCopyInto(out *OpenTelemetryAccessLogService) {
 	*out = *in
-	in.GrpcService.DeepCopyInto(&out.GrpcService)
+	if in.GrpcService != nil {
+		in, out := &in.GrpcService, &out.GrpcService
+		*out = new(CommonAccessLogGrpcService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HttpService != nil {
+		in, out := &in.HttpService, &out.HttpService
+		*out = new(OpenTelemetryAccessLogHttpService)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Body != nil {
 		in, out := &in.Body, &out.Body
 		*out = new(string)
@@ -4061,6 +4478,26 @@ func (in *PathRegexRewrite) DeepCopy() *PathRegexRewrite {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginBackend) DeepCopyInto(out *PluginBackend) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginBackend.
+func (in *PluginBackend) DeepCopy() *PluginBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Pod) DeepCopyInto(out *Pod) {
 	*out = *in
@@ -4434,6 +4871,21 @@ func (in *ResponseCompression) DeepCopyInto(out *ResponseCompression) {
 		*out = new(shared.PolicyDisable)
 		**out = **in
 	}
+	if in.Algorithms != nil {
+		in, out := &in.Algorithms, &out.Algorithms
+		*out = make([]CompressionAlgorithm, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinContentLength != nil {
+		in, out := &in.MinContentLength, &out.MinContentLength
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ContentTypes != nil {
+		in, out := &in.ContentTypes, &out.ContentTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseCompression.
@@ -4919,6 +5371,28 @@ func (in *StatsConfig) DeepCopyInto(out *StatsConfig) {
 		*out = new(StatsMatcher)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExtraLabels != nil {
+		in, out := &in.ExtraLabels, &out.ExtraLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.HistogramBuckets != nil {
+		in, out := &in.HistogramBuckets, &out.HistogramBuckets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DisabledMetrics != nil {
+		in, out := &in.DisabledMetrics, &out.DisabledMetrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatsConfig.
@@ -4975,6 +5449,26 @@ func (in *StatusCodeFilter) DeepCopy() *StatusCodeFilter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPConnectionPool) DeepCopyInto(out *TCPConnectionPool) {
+	*out = *in
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPConnectionPool.
+func (in *TCPConnectionPool) DeepCopy() *TCPConnectionPool {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPConnectionPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TCPKeepalive) DeepCopyInto(out *TCPKeepalive) {
 	*out = *in
@@ -5160,6 +5654,11 @@ func (in *TokenBucket) DeepCopy() *TokenBucket {
 func (in *Tracing) DeepCopyInto(out *Tracing) {
 	*out = *in
 	in.Provider.DeepCopyInto(&out.Provider)
+	if in.Propagation != nil {
+		in, out := &in.Propagation, &out.Propagation
+		*out = new(TracingPropagation)
+		**out = **in
+	}
 	if in.ClientSampling != nil {
 		in, out := &in.ClientSampling, &out.ClientSampling
 		*out = new(int32)
@@ -5192,6 +5691,13 @@ func (in *Tracing) DeepCopyInto(out *Tracing) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CustomTags != nil {
+		in, out := &in.CustomTags, &out.CustomTags
+		*out = make(map[string]CustomTagHeaderName, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.SpawnUpstreamSpan != nil {
 		in, out := &in.SpawnUpstreamSpan, &out.SpawnUpstreamSpan
 		*out = new(bool)
@@ -5217,6 +5723,16 @@ func (in *TracingProvider) DeepCopyInto(out *TracingProvider) {
 		*out = new(OpenTelemetryTracingConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Zipkin != nil {
+		in, out := &in.Zipkin, &out.Zipkin
+		*out = new(ZipkinTracingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Datadog != nil {
+		in, out := &in.Datadog, &out.Datadog
+		*out = new(DatadogTracingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingProvider.
@@ -5405,6 +5921,23 @@ func (in *TrafficPolicySpec) DeepCopyInto(out *TrafficPolicySpec) {
 		*out = new(FaultInjectionPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LuaFilters != nil {
+		in, out := &in.LuaFilters, &out.LuaFilters
+		*out = make([]LuaFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(GRPCPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeConfigs != nil {
+		in, out := &in.UpgradeConfigs, &out.UpgradeConfigs
+		*out = make([]UpgradeType, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficPolicySpec.
@@ -5568,3 +6101,44 @@ func (in *UuidRequestIdConfig) DeepCopy() *UuidRequestIdConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZipkinTracingConfig) DeepCopyInto(out *ZipkinTracingConfig) {
+	*out = *in
+	in.BackendRef.DeepCopyInto(&out.BackendRef)
+	if in.CollectorEndpoint != nil {
+		in, out := &in.CollectorEndpoint, &out.CollectorEndpoint
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZipkinTracingConfig.
+func (in *ZipkinTracingConfig) DeepCopy() *ZipkinTracingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ZipkinTracingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneAwareLoadBalancing) DeepCopyInto(out *ZoneAwareLoadBalancing) {
+	*out = *in
+	if in.FailoverThreshold != nil {
+		in, out := &in.FailoverThreshold, &out.FailoverThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneAwareLoadBalancing.
+func (in *ZoneAwareLoadBalancing) DeepCopy() *ZoneAwareLoadBalancing {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneAwareLoadBalancing)
+	in.DeepCopyInto(out)
+	return out
+}
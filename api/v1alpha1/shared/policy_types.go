@@ -31,6 +31,14 @@ const (
 	//
 	PolicyConditionAttached PolicyConditionType = "Attached"
 
+	// PolicyConditionConflicted indicates that parts of the policy overlap with
+	// filters configured natively on a targeted route, which take precedence.
+	//
+	// Possible reasons for this condition to be True are:
+	// * RouteFilterOverlap
+	//
+	PolicyConditionConflicted PolicyConditionType = "Conflicted"
+
 	// PolicyReasonValid is used with the "Accepted" condition when the policy
 	// has been accepted by the system.
 	PolicyReasonValid PolicyConditionReason = "Valid"
@@ -58,6 +66,15 @@ const (
 	// PolicyReasonPartiallyValid is used with the "Accepted" condition when the policy has been accepted by the system,
 	// but some of the referenced resources are not valid.
 	PolicyReasonPartiallyValid PolicyConditionReason = "PartiallyValid"
+
+	// PolicyReasonTargetNotFound is used with the "Accepted" and "Attached" conditions when
+	// a resource targeted by the policy does not exist.
+	PolicyReasonTargetNotFound PolicyConditionReason = "TargetNotFound"
+
+	// PolicyReasonRouteFilterOverlap is used with the "Conflicted" condition when
+	// a field of the policy is ignored because a targeted route configures the
+	// same capability natively.
+	PolicyReasonRouteFilterOverlap PolicyConditionReason = "RouteFilterOverlap"
 )
 
 // PolicyDisable is used to disable a policy.
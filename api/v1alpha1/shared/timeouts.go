@@ -14,6 +14,8 @@ type Timeouts struct {
 	Request *metav1.Duration `json:"request,omitempty"`
 
 	// StreamIdle specifies a timeout for a requests' idle streams.
+	// It overrides the stream idle timeout of the listener, set with the streamIdleTimeout of its
+	// HTTP settings, e.g. to let long-polling and server-sent events requests stay idle for longer.
 	// A value of 0 effectively disables the timeout.
 	// +optional
 	//
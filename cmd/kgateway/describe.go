@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/describe"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/schemes"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/kubeutils"
+)
+
+func newDescribeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "describe",
+		Short: "Describe kgateway resources",
+	}
+	cmd.AddCommand(newDescribeGatewayCmd())
+	return cmd
+}
+
+func newDescribeGatewayCmd() *cobra.Command {
+	var output, kubeContext, adminAddress string
+	cmd := &cobra.Command{
+		Use:   "gateway <namespace>/<name>",
+		Short: "Describe a Gateway with its rendered objects, attached routes and policies, and connected proxies",
+		Long: `Describe a Gateway with its rendered objects, attached routes and policies, and connected proxies.
+
+The effective GatewayParameters, the rendered objects and the xDS versions acknowledged by the proxies
+are read from the admin server of the control plane, e.g. through:
+
+  kubectl port-forward -n kgateway-system deploy/kgateway 9095
+
+They are omitted with a warning when the admin server is unreachable.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, name, ok := strings.Cut(args[0], "/")
+			if !ok || namespace == "" || name == "" {
+				return fmt.Errorf("gateway must be <namespace>/<name>, got %q", args[0])
+			}
+			if output != describe.OutputText && output != describe.OutputYAML && output != describe.OutputJSON {
+				return fmt.Errorf("unsupported output format %q, must be one of: yaml, json", output)
+			}
+
+			restConfig, err := kubeutils.GetRestConfigWithKubeContext(kubeContext)
+			if err != nil {
+				return fmt.Errorf("error getting kubeconfig: %w", err)
+			}
+			kubeClient, err := client.New(restConfig, client.Options{Scheme: schemes.GatewayScheme()})
+			if err != nil {
+				return fmt.Errorf("error creating kubernetes client: %w", err)
+			}
+			var controlPlane describe.ControlPlane
+			if adminAddress != "" {
+				controlPlane = describe.NewAdminClient(adminAddress)
+			}
+
+			desc, err := describe.NewDescriber(kubeClient, controlPlane).DescribeGateway(cmd.Context(), types.NamespacedName{Namespace: namespace, Name: name})
+			if err != nil {
+				return err
+			}
+			return describe.Print(cmd.OutOrStdout(), desc, output)
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output format, one of: yaml, json. Defaults to a human readable description")
+	cmd.Flags().StringVar(&kubeContext, "context", "", "Name of the kubeconfig context to use")
+	cmd.Flags().StringVar(&adminAddress, "admin-address", fmt.Sprintf("localhost:%d", wellknown.KgatewayAdminPort), "Address of the kgateway admin server, empty to skip the control plane")
+	return cmd
+}
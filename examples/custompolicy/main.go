@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"istio.io/istio/pkg/kube/krt"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/setup"
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/custompolicy"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/reporter"
+)
+
+/******
+
+An example plugin, that adds a policy kind defined by a CRD kgateway knows nothing about. Unlike the
+ConfigMap policy of the plugin example, the WafPolicy is a real policy: it attaches to its targets
+through spec.targetRefs, and kgateway reports its status on it. We add the WAF mode to the metadata of
+the envoy routes of the targeted HTTPRoutes.
+
+The CRD of the policy is in wafpolicy-crd.yaml. Example WafPolicy:
+
+apiVersion: waf.example.com/v1alpha1
+kind: WafPolicy
+metadata:
+  name: my-waf-policy
+spec:
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: my-http-route
+  mode: Block
+
+A WafPolicy with a mode other than Block or Detect is reported as not accepted on its status.
+
+*****/
+
+var wafPolicyGVR = schema.GroupVersionResource{
+	Group:    "waf.example.com",
+	Version:  "v1alpha1",
+	Resource: "wafpolicies",
+}
+
+// Our policy IR.
+type wafPolicyIr struct {
+	creationTime time.Time
+	metadata     *structpb.Struct
+}
+
+var _ ir.PolicyIR = &wafPolicyIr{}
+
+func (d *wafPolicyIr) CreationTime() time.Time {
+	return d.creationTime
+}
+
+func (d *wafPolicyIr) Equals(in any) bool {
+	d2, ok := in.(*wafPolicyIr)
+	if !ok {
+		return false
+	}
+	return d.creationTime == d2.creationTime && proto.Equal(d.metadata, d2.metadata)
+}
+
+// convert a WafPolicy to our IR. The errors returned are reported on the status of the policy.
+func wafPolicyToIr(_ krt.HandlerContext, obj *unstructured.Unstructured) (ir.PolicyIR, []error) {
+	mode, _, err := unstructured.NestedString(obj.Object, "spec", "mode")
+	if err != nil {
+		return nil, []error{err}
+	}
+	if mode != "Block" && mode != "Detect" {
+		return nil, []error{fmt.Errorf("invalid mode %q: must be Block or Detect", mode)}
+	}
+
+	return &wafPolicyIr{
+		creationTime: obj.GetCreationTimestamp().Time,
+		metadata: &structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				"mode": structpb.NewStringValue(mode),
+			},
+		},
+	}, nil
+}
+
+// Our translation pass struct.
+type wafPolicyPass struct {
+	ir.UnimplementedProxyTranslationPass
+}
+
+// ApplyForRoute is called when a an HTTPRouteRule targeted by a WafPolicy is being translated to an envoy route.
+func (s *wafPolicyPass) ApplyForRoute(pCtx *ir.RouteContext, out *envoyroutev3.Route) error {
+	wafIr, ok := pCtx.Policy.(*wafPolicyIr)
+	if !ok {
+		return nil
+	}
+	if out.GetMetadata() == nil {
+		out.Metadata = &envoycorev3.Metadata{}
+	}
+	if out.GetMetadata().GetFilterMetadata() == nil {
+		out.Metadata.FilterMetadata = map[string]*structpb.Struct{}
+	}
+	out.Metadata.FilterMetadata["example.waf"] = wafIr.metadata
+	return nil
+}
+
+// A function that initializes our plugins.
+func pluginFactory(ctx context.Context, commoncol *collections.CommonCollections, mergeSettingsJSON string) []sdk.Plugin {
+	return []sdk.Plugin{
+		// The custom policy plugin watches the WafPolicies, attaches them with their targetRefs and
+		// reports their status.
+		custompolicy.NewPlugin(commoncol, custompolicy.Definition{
+			GVR:       wafPolicyGVR,
+			Kind:      "WafPolicy",
+			Translate: wafPolicyToIr,
+			NewGatewayTranslationPass: func(tctx ir.GwTranslationCtx, reporter reporter.Reporter) ir.ProxyTranslationPass {
+				return &wafPolicyPass{}
+			},
+		}),
+	}
+}
+
+func main() {
+	setup, _ := setup.New(
+		setup.WithExtraPlugins(pluginFactory),
+	)
+	setup.Start(context.Background())
+}
@@ -2,6 +2,7 @@ package apiclient
 
 import (
 	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/kube/informerfactory"
 	"k8s.io/client-go/rest"
 
 	"github.com/kgateway-dev/kgateway/v2/pkg/client/clientset/versioned"
@@ -13,11 +14,15 @@ type Client interface {
 	kube.Client
 	Core() kube.Client
 	Kgateway() versioned.Interface
+	// InformerResourceVersions returns the resource version each informer created through the client
+	// is synced to, by informer.
+	InformerResourceVersions() map[string]string
 }
 
 type client struct {
 	kube.Client
-	kgateway versioned.Interface
+	kgateway  versioned.Interface
+	informers *informerTracker
 }
 
 func New(restConfig *rest.Config) (*client, error) {
@@ -34,11 +39,21 @@ func New(restConfig *rest.Config) (*client, error) {
 	RegisterTypes()
 	kube.EnableCrdWatcher(kubeClient)
 	return &client{
-		Client:   kubeClient,
-		kgateway: cli,
+		Client:    kubeClient,
+		kgateway:  cli,
+		informers: newInformerTracker(kubeClient.Informers()),
 	}, nil
 }
 
+// Informers returns the informer factory of the client, which records the informers it creates.
+func (c *client) Informers() informerfactory.InformerFactory {
+	return c.informers
+}
+
+func (c *client) InformerResourceVersions() map[string]string {
+	return c.informers.resourceVersions()
+}
+
 func (c *client) Kgateway() versioned.Interface {
 	return c.kgateway
 }
@@ -79,6 +79,11 @@ func (c *cli) Core() kube.Client {
 	return c.Client
 }
 
+// InformerResourceVersions returns nil, as the fake client doesn't track its informers.
+func (c *cli) InformerResourceVersions() map[string]string {
+	return nil
+}
+
 func fakeIstioClient(objects ...client.Object) kube.Client {
 	c := kube.NewFakeClient(testutils.ToRuntimeObjects(objects...)...)
 	// Also add to the Dynamic store
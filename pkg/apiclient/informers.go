@@ -0,0 +1,74 @@
+package apiclient
+
+import (
+	"maps"
+	"strings"
+	"sync"
+
+	"istio.io/istio/pkg/kube/informerfactory"
+	"istio.io/istio/pkg/kube/kubetypes"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+// informerTracker is an informer factory that records the informers it creates, so that the
+// progress of their watches can be tracked.
+type informerTracker struct {
+	informerfactory.InformerFactory
+
+	mu        sync.Mutex
+	informers map[string]cache.SharedIndexInformer
+}
+
+func newInformerTracker(factory informerfactory.InformerFactory) *informerTracker {
+	return &informerTracker{
+		InformerFactory: factory,
+		informers:       make(map[string]cache.SharedIndexInformer),
+	}
+}
+
+// InformerFor returns the informer of the factory for the resource and records it.
+func (t *informerTracker) InformerFor(
+	resource schema.GroupVersionResource,
+	opts kubetypes.InformerOptions,
+	newFunc informerfactory.NewInformerFunc,
+) informerfactory.StartableInformer {
+	inf := t.InformerFactory.InformerFor(resource, opts, newFunc)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.informers[informerName(resource, opts)] = inf.Informer
+	return inf
+}
+
+// resourceVersions returns the resource version each informer is synced to, by informer.
+func (t *informerTracker) resourceVersions() map[string]string {
+	t.mu.Lock()
+	informers := maps.Clone(t.informers)
+	t.mu.Unlock()
+
+	out := make(map[string]string, len(informers))
+	for name, inf := range informers {
+		out[name] = inf.LastSyncResourceVersion()
+	}
+	return out
+}
+
+// informerName identifies an informer by its resource and the options that make it distinct,
+// e.g. `pods` or `secrets{namespace=kgateway-system}`.
+func informerName(resource schema.GroupVersionResource, opts kubetypes.InformerOptions) string {
+	var filters []string
+	if opts.Namespace != "" {
+		filters = append(filters, "namespace="+opts.Namespace)
+	}
+	if opts.LabelSelector != "" {
+		filters = append(filters, "labels="+opts.LabelSelector)
+	}
+	if opts.FieldSelector != "" {
+		filters = append(filters, "fields="+opts.FieldSelector)
+	}
+	name := resource.GroupResource().String()
+	if len(filters) == 0 {
+		return name
+	}
+	return name + "{" + strings.Join(filters, ",") + "}"
+}
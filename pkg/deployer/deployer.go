@@ -22,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
@@ -212,6 +213,17 @@ func (d *Deployer) GetObjsToDeploy(ctx context.Context, obj client.Object) ([]cl
 	return objs, nil
 }
 
+// PlanObjects returns the objects the deployer creates for the Gateway, in the order they are applied
+// and with their namespace and ownerRef set, without applying them. It only reads from the cluster, so
+// it can back tooling that exports or diffs the resources of a Gateway as well as its reconciliation.
+func (d *Deployer) PlanObjects(ctx context.Context, gw *gwv1.Gateway) ([]client.Object, error) {
+	objs, err := d.GetObjsToDeploy(ctx, gw)
+	if err != nil {
+		return nil, err
+	}
+	return d.SetNamespaceAndOwnerWithGVK(gw, wellknown.GatewayGVK, objs), nil
+}
+
 // Deprecated: use SetNamespaceAndOwnerWithGVK
 // Using this without specifying the GVK breaks with client-go clients which do not set the
 // GVK in the TypeMeta after the initial List()
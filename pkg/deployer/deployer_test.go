@@ -16,6 +16,7 @@ import (
 	"istio.io/istio/pkg/config/schema/gvk"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -39,6 +40,7 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/plugins/listenerpolicy"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/xds"
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
 	"github.com/kgateway-dev/kgateway/v2/pkg/version"
 	deployertest "github.com/kgateway-dev/kgateway/v2/test/deployer"
 	translatortest "github.com/kgateway-dev/kgateway/v2/test/translator"
@@ -366,6 +368,251 @@ var _ = Describe("Deployer", func() {
 		})
 	})
 
+	Context("planning objects", func() {
+		// newDeployer returns a deployer for the default Gateway, whose cluster has the given live objects
+		newDeployer := func(gw *gwv1.Gateway, live ...client.Object) (*deployer.Deployer, apiclient.Client) {
+			gwc := defaultGatewayClassWithParamsRef()
+			fakeClient := fake.NewClient(GinkgoT(), append([]client.Object{gwc, defaultGatewayParams()}, live...)...)
+			gwp := deployerinternal.NewGatewayParameters(fakeClient, &deployer.Inputs{
+				CommonCollections: deployertest.NewCommonCols(GinkgoT(), gwc, gw),
+				ControlPlane: deployer.ControlPlaneInfo{
+					XdsHost: "something.cluster.local",
+					XdsPort: 1234,
+				},
+				ImageInfo: &deployer.ImageInfo{
+					Registry: "foo",
+					Tag:      "bar",
+				},
+				GatewayClassName:         wellknown.DefaultGatewayClassName,
+				WaypointGatewayClassName: wellknown.DefaultWaypointClassName,
+			})
+			d, err := deployerinternal.NewGatewayDeployer(
+				wellknown.DefaultGatewayControllerName,
+				scheme,
+				fakeClient,
+				gwp,
+			)
+			Expect(err).NotTo(HaveOccurred())
+			fakeClient.RunAndWait(context.Background().Done())
+			return d, fakeClient
+		}
+
+		It("returns the objects of a Gateway without applying them", func() {
+			gw := defaultGateway()
+			d, fakeClient := newDeployer(gw)
+
+			objs, err := d.PlanObjects(context.Background(), gw)
+			Expect(err).NotTo(HaveOccurred())
+
+			var kinds []string
+			for _, obj := range objs {
+				kinds = append(kinds, obj.GetObjectKind().GroupVersionKind().Kind)
+				Expect(obj.GetName()).To(Equal(gw.Name))
+				Expect(obj.GetNamespace()).To(Equal(gw.Namespace))
+				Expect(obj.GetOwnerReferences()).To(ConsistOf(metav1.OwnerReference{
+					APIVersion: wellknown.GatewayGVK.GroupVersion().String(),
+					Kind:       wellknown.GatewayGVK.Kind,
+					Name:       gw.Name,
+					UID:        gw.UID,
+					Controller: new(true),
+				}))
+			}
+			Expect(kinds).To(ConsistOf("ServiceAccount", "ConfigMap", "Service", "Deployment"))
+
+			// planning doesn't create anything in the cluster
+			deployments, err := fakeClient.Kube().AppsV1().Deployments(gw.Namespace).List(context.Background(), metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deployments.Items).To(BeEmpty())
+		})
+
+		It("diffs the planned objects against the live objects", func() {
+			gw := defaultGateway()
+			d, _ := newDeployer(gw)
+			planned, err := d.PlanObjects(context.Background(), gw)
+			Expect(err).NotTo(HaveOccurred())
+
+			var live []client.Object
+			for _, obj := range planned {
+				obj = obj.DeepCopyObject().(client.Object)
+				// fields managed by the API server are ignored
+				obj.SetResourceVersion("42")
+				obj.SetUID("1234")
+				obj.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kgateway"}})
+				switch o := obj.(type) {
+				case *appsv1.Deployment:
+					// not deployed yet
+					continue
+				case *corev1.Service:
+					o.Spec.Ports[0].Port = 8081
+					o.Spec.ClusterIP = "10.0.0.1"
+				case *corev1.ConfigMap:
+					o.Annotations = map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{}"}
+				}
+				live = append(live, obj)
+			}
+			stalePDB := &policyv1.PodDisruptionBudget{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       wellknown.PodDisruptionBudgetGVK.Kind,
+					APIVersion: wellknown.PodDisruptionBudgetGVK.GroupVersion().String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      gw.Name,
+					Namespace: gw.Namespace,
+					Labels:    map[string]string{wellknown.GatewayNameLabel: gw.Name},
+				},
+			}
+			otherGatewayPDB := stalePDB.DeepCopy()
+			otherGatewayPDB.Name = "other"
+			otherGatewayPDB.Labels[wellknown.GatewayNameLabel] = "other"
+			live = append(live, stalePDB, otherGatewayPDB)
+
+			d, _ = newDeployer(gw, live...)
+			diff, err := d.DiffObjects(context.Background(), gw)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.Empty()).To(BeFalse())
+
+			Expect(diff.Added).To(HaveLen(1))
+			Expect(diff.Added[0].GetKind()).To(Equal("Deployment"))
+			Expect(diff.Added[0].GetName()).To(Equal(gw.Name))
+
+			Expect(diff.Changed).To(HaveLen(1))
+			Expect(diff.Changed[0].Planned.GetKind()).To(Equal("Service"))
+			ports, _, _ := unstructured.NestedSlice(diff.Changed[0].Live.Object, "spec", "ports")
+			Expect(ports[0]).To(HaveKeyWithValue("port", BeNumerically("==", 8081)))
+
+			Expect(diff.Removed).To(HaveLen(1))
+			Expect(diff.Removed[0].GetKind()).To(Equal("PodDisruptionBudget"))
+			Expect(diff.Removed[0].GetName()).To(Equal(gw.Name))
+		})
+
+		It("reports no difference when the live objects match the plan", func() {
+			gw := defaultGateway()
+			d, _ := newDeployer(gw)
+			planned, err := d.PlanObjects(context.Background(), gw)
+			Expect(err).NotTo(HaveOccurred())
+
+			d, _ = newDeployer(gw, planned...)
+			diff, err := d.DiffObjects(context.Background(), gw)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.Empty()).To(BeTrue(), "%+v", diff)
+		})
+	})
+
+	Context("plugin chart fragments", func() {
+		var (
+			wafFragment = func() sdk.DeployerChartFragment {
+				return sdk.DeployerChartFragment{
+					Name: "waf",
+					Values: func(gw *gwv1.Gateway) (any, error) {
+						return map[string]any{"mode": "Block", "port": 9000}, nil
+					},
+					Templates: map[string][]byte{
+						"service.yaml": []byte(`apiVersion: v1
+kind: Service
+metadata:
+  name: {{ .Values.gateway.name }}-waf
+  annotations:
+    waf.example.com/mode: {{ .Values.plugins.waf.mode | quote }}
+spec:
+  ports:
+  - name: waf
+    port: {{ .Values.plugins.waf.port }}
+`),
+					},
+				}
+			}
+
+			newDeployer = func(gwc *gwv1.GatewayClass, gwp *kgateway.GatewayParameters, gw *gwv1.Gateway, fragments ...sdk.DeployerChartFragment) (*deployer.Deployer, error) {
+				fakeClient := fake.NewClient(GinkgoT(), gwc, gwp)
+				gwParams := deployerinternal.NewGatewayParameters(fakeClient, &deployer.Inputs{
+					CommonCollections: deployertest.NewCommonCols(GinkgoT(), gwc, gw),
+					ControlPlane: deployer.ControlPlaneInfo{
+						XdsHost: "something.cluster.local",
+						XdsPort: 1234,
+					},
+					ImageInfo: &deployer.ImageInfo{
+						Registry: "foo",
+						Tag:      "bar",
+					},
+					GatewayClassName:         wellknown.DefaultGatewayClassName,
+					WaypointGatewayClassName: wellknown.DefaultWaypointClassName,
+					DeployerChartFragments:   fragments,
+				})
+				d, err := deployerinternal.NewGatewayDeployer(
+					wellknown.DefaultGatewayControllerName,
+					scheme,
+					fakeClient,
+					gwParams,
+				)
+				fakeClient.RunAndWait(context.Background().Done())
+				return d, err
+			}
+		)
+
+		It("renders the templates of the fragments with their values", func() {
+			gw := defaultGateway()
+			d, err := newDeployer(defaultGatewayClassWithParamsRef(), defaultGatewayParams(), gw, wafFragment())
+			Expect(err).NotTo(HaveOccurred())
+
+			var objs clientObjects
+			objs, err = d.GetObjsToDeploy(context.Background(), gw)
+			Expect(err).NotTo(HaveOccurred())
+			objs = d.SetNamespaceAndOwner(gw, objs)
+			Expect(objs).To(HaveLen(5))
+			svc := objs.findService(gw.Name + "-waf")
+			Expect(svc).ToNot(BeNil())
+			Expect(svc.Annotations).To(HaveKeyWithValue("waf.example.com/mode", "Block"))
+			Expect(svc.Spec.Ports).To(HaveLen(1))
+			Expect(svc.Spec.Ports[0].Port).To(Equal(int32(9000)))
+		})
+
+		It("fails to get the objects when the values of a fragment fail", func() {
+			gw := defaultGateway()
+			fragment := wafFragment()
+			fragment.Values = func(gw *gwv1.Gateway) (any, error) {
+				return nil, errors.New("no WAF for Gateway " + gw.Name)
+			}
+			d, err := newDeployer(defaultGatewayClassWithParamsRef(), defaultGatewayParams(), gw, fragment)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = d.GetObjsToDeploy(context.Background(), gw)
+			Expect(err).To(MatchError(ContainSubstring("failed to get the values of deployer chart fragment waf: no WAF for Gateway foo")))
+		})
+
+		It("rejects fragments with the same name", func() {
+			gw := defaultGateway()
+			_, err := newDeployer(defaultGatewayClassWithParamsRef(), defaultGatewayParams(), gw, wafFragment(), wafFragment())
+			Expect(err).To(MatchError(`deployer chart fragment "waf" is contributed by more than one plugin`))
+		})
+
+		It("rejects fragments with an invalid name", func() {
+			gw := defaultGateway()
+			fragment := wafFragment()
+			fragment.Name = "waf-plugin"
+			_, err := newDeployer(defaultGatewayClassWithParamsRef(), defaultGatewayParams(), gw, fragment)
+			Expect(err).To(MatchError(ContainSubstring(`invalid deployer chart fragment name "waf-plugin"`)))
+		})
+
+		It("applies the overlays to the objects rendered by the fragments", func() {
+			gw := defaultGateway()
+			gwp := defaultGatewayParams()
+			gwp.Spec.Kube.ServiceOverlay = &shared.KubernetesResourceOverlay{
+				Metadata: &shared.ObjectMetadata{Labels: map[string]string{"team": "overlay"}},
+			}
+			d, err := newDeployer(defaultGatewayClassWithParamsRef(), gwp, gw, wafFragment())
+			Expect(err).NotTo(HaveOccurred())
+
+			var objs clientObjects
+			objs, err = d.GetObjsToDeploy(context.Background(), gw)
+			Expect(err).NotTo(HaveOccurred())
+			objs = d.SetNamespaceAndOwner(gw, objs)
+			svc := objs.findService(gw.Name + "-waf")
+			Expect(svc).ToNot(BeNil())
+			Expect(svc.Labels).To(HaveKeyWithValue("team", "overlay"))
+			Expect(objs.findService(gw.Name).Labels).To(HaveKeyWithValue("team", "overlay"))
+		})
+	})
+
 	Context("self managed gateway", func() {
 		var (
 			d   *deployer.Deployer
@@ -1258,6 +1505,18 @@ var _ = Describe("Deployer", func() {
 				}
 				Expect(foundLogLevel).To(BeTrue(), "envoy proxy log level not found")
 
+				By("verifying the pod identity is added to the node metadata")
+				Expect(envoyContainer.Args).To(ContainElements(
+					"--config-yaml",
+					`{"node":{"metadata":{"pod_name":"$(POD_NAME)","pod_uid":"$(POD_UID)"}}}`,
+				))
+				Expect(envoyContainer.Env).To(ContainElement(corev1.EnvVar{
+					Name: "POD_UID",
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.uid"},
+					},
+				}))
+
 				bootstrapCfg := objs.getEnvoyConfig(defaultNamespace, defaultConfigMapName)
 				Expect(bootstrapCfg.StaticResources.Listeners).To(HaveLen(2))
 				prometheusListener := bootstrapCfg.StaticResources.Listeners[1]
@@ -2296,6 +2555,89 @@ var _ = Describe("Deployer", func() {
 			Expect(deploymentPorts[1].Name).To(Equal(fmt.Sprintf("listener-%d", listenerSetPort)))
 			Expect(deploymentPorts[1].ContainerPort).To(Equal(listenerSetPort))
 		})
+
+		It("does not expose ports of listener sets in namespaces that are not allowed", func() {
+			sameNamespace := gwv1.NamespacesFromSame
+			gw := &gwv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: defaultNamespace,
+					UID:       "1235",
+				},
+				Spec: gwv1.GatewaySpec{
+					GatewayClassName: wellknown.DefaultGatewayClassName,
+					AllowedListeners: &gwv1.AllowedListeners{
+						Namespaces: &gwv1.ListenerNamespaces{
+							From: &sameNamespace,
+						},
+					},
+					Listeners: []gwv1.Listener{
+						{
+							Name: "gateway-listener",
+							Port: gwv1.PortNumber(listenerPort),
+						},
+					},
+				},
+			}
+
+			ls := &gwv1.ListenerSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ls",
+					Namespace: "other",
+				},
+				Spec: gwv1.ListenerSetSpec{
+					Listeners: []gwv1.ListenerEntry{
+						{
+							Name: "listenerset-listener",
+							Port: gwv1.PortNumber(listenerSetPort),
+						},
+					},
+					ParentRef: gwv1.ParentGatewayReference{
+						Kind:      (*gwv1.Kind)(&gw.Kind),
+						Group:     (*gwv1.Group)(&gw.APIVersion),
+						Name:      gwv1.ObjectName(gw.Name),
+						Namespace: (*gwv1.Namespace)(&gw.Namespace),
+					},
+				},
+			}
+
+			fakeClient := fake.NewClient(GinkgoT(), defaultGatewayClass(), defaultGatewayParams())
+			gwParams := deployerinternal.NewGatewayParameters(
+				fakeClient, &deployer.Inputs{
+					CommonCollections: deployertest.NewCommonCols(GinkgoT(), defaultGatewayClass(), gw, ls),
+					Dev:               false,
+					ControlPlane: deployer.ControlPlaneInfo{
+						XdsHost: "something.cluster.local", XdsPort: 1234,
+					},
+					ImageInfo: &deployer.ImageInfo{
+						Registry: "foo",
+						Tag:      "bar",
+					},
+				})
+			d, err := deployerinternal.NewGatewayDeployer(
+				wellknown.DefaultGatewayControllerName,
+				scheme,
+				fakeClient,
+				gwParams,
+			)
+			Expect(err).NotTo(HaveOccurred())
+			fakeClient.RunAndWait(context.Background().Done())
+
+			var objs clientObjects
+			objs, err = d.GetObjsToDeploy(context.Background(), gw)
+			Expect(err).NotTo(HaveOccurred())
+			objs = d.SetNamespaceAndOwner(gw, objs)
+
+			servicePorts := objs.findService(gw.Name).Spec.Ports
+			Expect(servicePorts).To(HaveLen(1))
+			Expect(servicePorts[0].Port).To(Equal(listenerPort))
+
+			deploymentPorts := objs.findDeployment(gw.Name).Spec.Template.Spec.Containers[0].Ports
+			Expect(deploymentPorts[0].ContainerPort).To(Equal(listenerPort))
+			for _, p := range deploymentPorts {
+				Expect(p.ContainerPort).NotTo(Equal(listenerSetPort))
+			}
+		})
 	})
 })
 
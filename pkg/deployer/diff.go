@@ -0,0 +1,161 @@
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/kubeutils"
+)
+
+// ObjectChange is an object whose live state differs from the planned one.
+type ObjectChange struct {
+	Planned *unstructured.Unstructured
+	Live    *unstructured.Unstructured
+}
+
+// ObjectsDiff is the difference between the objects planned for a Gateway and the live objects.
+type ObjectsDiff struct {
+	// Added are the planned objects that don't exist in the cluster.
+	Added []*unstructured.Unstructured
+	// Changed are the planned objects whose live state differs.
+	Changed []ObjectChange
+	// Removed are the live objects of the Gateway that are no longer planned.
+	Removed []*unstructured.Unstructured
+}
+
+// Empty returns whether the live objects match the planned objects.
+func (d ObjectsDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// DiffObjects compares the objects planned for the Gateway with the live objects in the cluster,
+// without applying anything. A live object is only reported as changed when it differs in a field
+// set by the plan, so that the fields managed by the API server, such as defaults, the status and
+// most of the metadata, are ignored. The live objects of the Gateway are found through the gateway
+// name label, among the kinds of the planned objects and the kinds pruned by the deployer.
+func (d *Deployer) DiffObjects(ctx context.Context, gw *gwv1.Gateway) (ObjectsDiff, error) {
+	var diff ObjectsDiff
+
+	planned, err := d.PlanObjects(ctx, gw)
+	if err != nil {
+		return diff, err
+	}
+
+	type objectKey struct {
+		gvk  schema.GroupVersionKind
+		name string
+	}
+	plannedKeys := map[objectKey]bool{}
+	gvks := []schema.GroupVersionKind{
+		wellknown.PodDisruptionBudgetGVK,
+		wellknown.HorizontalPodAutoscalerGVK,
+		wellknown.VerticalPodAutoscalerGVK,
+	}
+	for _, obj := range planned {
+		u, err := kubeutils.ToUnstructured(obj)
+		if err != nil {
+			return diff, fmt.Errorf("error converting object %s to unstructured: %w", kubeutils.NamespacedNameFrom(obj), err)
+		}
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		plannedKeys[objectKey{gvk: gvk, name: obj.GetName()}] = true
+		if !slices.Contains(gvks, gvk) {
+			gvks = append(gvks, gvk)
+		}
+
+		gvr, err := d.gvkToGVR(gvk)
+		if err != nil {
+			return diff, fmt.Errorf("error getting GVR for object %s: %w", kubeutils.NamespacedNameFrom(obj), err)
+		}
+		live, err := d.client.Dynamic().Resource(gvr).Namespace(obj.GetNamespace()).Get(ctx, obj.GetName(), metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			diff.Added = append(diff.Added, u)
+		case err != nil:
+			return diff, fmt.Errorf("failed to get object %s %s: %w", gvk.String(), kubeutils.NamespacedNameFrom(obj), err)
+		case !plannedFieldsMatch(u, live):
+			diff.Changed = append(diff.Changed, ObjectChange{Planned: u, Live: live.DeepCopy()})
+		}
+	}
+
+	labelSelector := fmt.Sprintf("%s=%s", wellknown.GatewayNameLabel, kubeutils.SafeGatewayLabelValue(gw.GetName()))
+	for _, gvk := range gvks {
+		gvr, err := d.gvkToGVR(gvk)
+		if err != nil {
+			logger.Debug("skipping diff for unknown GVK", "gvk", gvk.String(), "error", err)
+			continue
+		}
+		list, err := d.client.Dynamic().Resource(gvr).Namespace(gw.GetNamespace()).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// Resource type doesn't exist (e.g., VPA CRD not installed)
+				continue
+			}
+			return diff, fmt.Errorf("failed to list %s: %w", gvk.String(), err)
+		}
+		for _, item := range list.Items {
+			if !plannedKeys[objectKey{gvk: gvk, name: item.GetName()}] {
+				diff.Removed = append(diff.Removed, item.DeepCopy())
+			}
+		}
+	}
+
+	return diff, nil
+}
+
+// plannedFieldsMatch returns whether the live object has the values of all the fields set in the
+// planned object, ignoring its status.
+func plannedFieldsMatch(planned, live *unstructured.Unstructured) bool {
+	for k, v := range planned.Object {
+		if k == "status" {
+			continue
+		}
+		if !subsetOf(v, live.Object[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+// subsetOf returns whether the fields set in planned have the same value in live.
+func subsetOf(planned, live any) bool {
+	switch p := planned.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		l, ok := live.(map[string]any)
+		if !ok {
+			return len(p) == 0 && live == nil
+		}
+		for k, v := range p {
+			if !subsetOf(v, l[k]) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		l, ok := live.([]any)
+		if !ok {
+			return len(p) == 0 && live == nil
+		}
+		if len(p) != len(l) {
+			return false
+		}
+		for i := range p {
+			if !subsetOf(p[i], l[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return equality.Semantic.DeepEqual(planned, live)
+	}
+}
@@ -33,6 +33,10 @@ func DefaultGatewayIRGetter(gw *gwv1.Gateway, commonCollections *collections.Com
 func GatewayIRFrom(gw *gwv1.Gateway, controllerNameGuess string) *ir.GatewayForDeployer {
 	ports := sets.New[int32]()
 	for _, l := range gw.Spec.Listeners {
+		// UDP listeners are rejected by the translator, nothing would listen on their ports
+		if l.Protocol == gwv1.UDPProtocolType {
+			continue
+		}
 		ports.Insert(l.Port)
 	}
 	return &ir.GatewayForDeployer{
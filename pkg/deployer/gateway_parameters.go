@@ -9,7 +9,9 @@ import (
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/eventutils"
 )
 
 // Inputs is the set of options used to configure gateway/inference pool deployment.
@@ -21,6 +23,12 @@ type Inputs struct {
 	CommonCollections        *collections.CommonCollections
 	GatewayClassName         string
 	WaypointGatewayClassName string
+	// EventRecorder emits Warning events on the objects whose deployment fails
+	EventRecorder *eventutils.RateLimitedRecorder
+	// DeployerMutators are the mutators contributed by the plugins, called on the objects of every Gateway
+	DeployerMutators []sdk.DeployerMutator
+	// DeployerChartFragments are the chart fragments contributed by the plugins, rendered for every Gateway
+	DeployerChartFragments []sdk.DeployerChartFragment
 }
 
 // UpdateSecurityContexts updates the security contexts in the gateway parameters.
@@ -62,10 +62,22 @@ func GetSupportedFeaturesForStandardGateway(enableExperimentalGatewayAPIFeatures
 }
 
 // GetSupportedFeaturesForWaypointGateway returns the supported features for the waypoint Gateway class.
-// Waypoint gateways have similar support to standard gateways but may have some differences.
+// Waypoints build a filter chain per captured Service port from the HTTPRoutes attached to the Service,
+// rather than from the Gateway listeners, so they don't support the listener, GRPCRoute and TLSRoute
+// features of standard gateways.
 func GetSupportedFeaturesForWaypointGateway(enableExperimentalGatewayAPIFeatures bool) []gwv1.SupportedFeature {
-	// For now, waypoint gateways support the same features as standard gateways
-	return GetSupportedFeaturesForStandardGateway(enableExperimentalGatewayAPIFeatures)
+	unsupported := sets.New(
+		gwv1.FeatureName(features.GRPCRouteFeature.Name),
+		gwv1.FeatureName(features.TLSRouteFeature.Name),
+		gwv1.FeatureName(features.TLSRouteModeTerminateFeature.Name),
+		gwv1.FeatureName(features.TLSRouteModeMixedFeature.Name),
+		gwv1.FeatureName(features.ListenerSetFeature.Name),
+		gwv1.FeatureName(features.GatewayFrontendClientCertificateValidationFeature.Name),
+		gwv1.FeatureName(features.GatewayFrontendClientCertificateValidationInsecureFallbackFeature.Name),
+	)
+	return slices.DeleteFunc(GetSupportedFeaturesForStandardGateway(enableExperimentalGatewayAPIFeatures), func(f gwv1.SupportedFeature) bool {
+		return unsupported.Has(f.Name)
+	})
 }
 
 // GetCommonExemptFeatures returns the set of features that are commonly unsupported across all gateway classes.
@@ -1,6 +1,8 @@
 package deployer
 
 import (
+	"cmp"
+	"slices"
 	"testing"
 
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -46,3 +48,33 @@ func TestGetSupportedFeaturesForStandardGatewayExcludesTLSRouteWhenExperimentalD
 		t.Fatalf("expected %q to be exempted when experimental Gateway API features are disabled", features.SupportTLSRouteModeMixed)
 	}
 }
+
+func TestGetSupportedFeaturesForWaypointGateway(t *testing.T) {
+	for _, enableExperimental := range []bool{true, false} {
+		waypoint := GetSupportedFeaturesForWaypointGateway(enableExperimental)
+		standardNames := supportedFeatureSet(GetSupportedFeaturesForStandardGateway(enableExperimental))
+		supportedNames := supportedFeatureSet(waypoint)
+
+		for name := range supportedNames {
+			if _, ok := standardNames[name]; !ok {
+				t.Fatalf("expected waypoint feature %q to be supported by the standard class", name)
+			}
+		}
+		if _, ok := supportedNames[gwv1.FeatureName(features.SupportHTTPRoute)]; !ok {
+			t.Fatalf("expected %q to be supported by waypoints", features.SupportHTTPRoute)
+		}
+		for _, unsupported := range []features.FeatureName{
+			features.SupportGRPCRoute,
+			features.SupportTLSRoute,
+			features.SupportListenerSet,
+			features.SupportGatewayFrontendClientCertificateValidation,
+		} {
+			if _, ok := supportedNames[gwv1.FeatureName(unsupported)]; ok {
+				t.Fatalf("expected %q to be exempted for waypoints", unsupported)
+			}
+		}
+		if !slices.IsSortedFunc(waypoint, func(a, b gwv1.SupportedFeature) int { return cmp.Compare(a.Name, b.Name) }) {
+			t.Fatalf("expected waypoint features to be sorted")
+		}
+	}
+}
@@ -118,6 +118,10 @@ func deepMergeStatsConfig(dst *kgateway.StatsConfig, src *kgateway.StatsConfig)
 	dst.EnableStatsRoute = MergePointers(dst.GetEnableStatsRoute(), src.GetEnableStatsRoute())
 	dst.StatsRoutePrefixRewrite = MergePointers(dst.GetStatsRoutePrefixRewrite(), src.GetStatsRoutePrefixRewrite())
 	dst.Matcher = MergePointers(dst.GetMatcher(), src.GetMatcher())
+	dst.ExtraLabels = DeepMergeMaps(dst.GetExtraLabels(), src.GetExtraLabels())
+	dst.HistogramBuckets = OverrideSlices(dst.GetHistogramBuckets(), src.GetHistogramBuckets())
+	dst.DisabledMetrics = OverrideSlices(dst.GetDisabledMetrics(), src.GetDisabledMetrics())
+	dst.Prefix = MergePointers(dst.GetPrefix(), src.GetPrefix())
 
 	return dst
 }
@@ -657,6 +661,36 @@ func deepMergeEnvoyBootstrap(dst, src *kgateway.EnvoyBootstrap) *kgateway.EnvoyB
 
 	dst.ComponentLogLevels = DeepMergeMaps(dst.GetComponentLogLevels(), src.GetComponentLogLevels())
 	dst.DnsResolver = deepMergeDnsResolver(dst.GetDnsResolver(), src.GetDnsResolver())
+	dst.Admin = deepMergeEnvoyAdmin(dst.GetAdmin(), src.GetAdmin())
+
+	return dst
+}
+
+func deepMergeEnvoyAdmin(dst, src *kgateway.EnvoyAdmin) *kgateway.EnvoyAdmin {
+	// nil src override means just use dst
+	if src == nil {
+		return dst
+	}
+
+	if dst == nil {
+		return src
+	}
+
+	if src.GetEnabled() != nil {
+		dst.Enabled = src.GetEnabled()
+	}
+
+	if src.GetBindAddress() != nil {
+		dst.BindAddress = src.GetBindAddress()
+	}
+
+	if src.GetAuthTokenSecretRef() != nil {
+		dst.AuthTokenSecretRef = src.GetAuthTokenSecretRef()
+	}
+
+	if src.GetEnableReload() != nil {
+		dst.EnableReload = src.GetEnableReload()
+	}
 
 	return dst
 }
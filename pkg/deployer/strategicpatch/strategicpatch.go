@@ -2,8 +2,10 @@ package strategicpatch
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
@@ -14,7 +16,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	kjson "sigs.k8s.io/json"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
@@ -50,11 +54,16 @@ func FromGatewayParameters(params *kgateway.GatewayParameters) *ResourceOverlays
 // OverlayApplier applies overlays to rendered k8s objects using strategic merge patch semantics.
 type OverlayApplier struct {
 	overlays *ResourceOverlays
+	// strict rejects overlay specs with fields unknown to the type of the patched resource
+	strict bool
 }
 
 // NewOverlayApplierFromGatewayParameters creates a new OverlayApplier from GatewayParameters.
 func NewOverlayApplierFromGatewayParameters(params *kgateway.GatewayParameters) *OverlayApplier {
-	return &OverlayApplier{overlays: FromGatewayParameters(params)}
+	return &OverlayApplier{
+		overlays: FromGatewayParameters(params),
+		strict:   params != nil && ptr.Deref(params.Spec.GetStrictValidation(), false),
+	}
 }
 
 // NewOverlayApplierFromOverlays creates a new OverlayApplier from ResourceOverlays directly.
@@ -69,6 +78,11 @@ func (a *OverlayApplier) ApplyOverlays(objs []client.Object) ([]client.Object, e
 	if a.overlays == nil {
 		return objs, nil
 	}
+	if a.strict {
+		if err := a.overlays.ValidateStrict(); err != nil {
+			return nil, err
+		}
+	}
 
 	// Find the Deployment first - we need it for PDB/HPA/VPA creation
 	var deployment *appsv1.Deployment
@@ -140,6 +154,83 @@ func (a *OverlayApplier) ApplyOverlays(objs []client.Object) ([]client.Object, e
 	return objs, nil
 }
 
+// ValidateStrict decodes the spec of each overlay against the type of the resource it patches,
+// returning an error naming the path of every field unknown to that type. The VerticalPodAutoscaler
+// overlay is not validated, as its type is not known to the controller.
+func (o *ResourceOverlays) ValidateStrict() error {
+	var errs []error
+	for _, overlay := range []struct {
+		overlay *shared.KubernetesResourceOverlay
+		gvk     schema.GroupVersionKind
+	}{
+		{o.Deployment, wellknown.DeploymentGVK},
+		{o.Service, wellknown.ServiceGVK},
+		{o.ServiceAccount, wellknown.ServiceAccountGVK},
+		{o.PodDisruptionBudget, wellknown.PodDisruptionBudgetGVK},
+		{o.HorizontalPodAutoscaler, wellknown.HorizontalPodAutoscalerGVK},
+	} {
+		if err := validateOverlaySpecStrict(overlay.overlay, overlay.gvk); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateOverlaySpecStrict decodes the spec of the overlay against the type of the given kind,
+// disallowing unknown fields. Strategic merge patch directives are not fields of the type, so
+// they are removed before decoding.
+func validateOverlaySpecStrict(overlay *shared.KubernetesResourceOverlay, gvk schema.GroupVersionKind) error {
+	if overlay == nil || overlay.Spec == nil || len(overlay.Spec.Raw) == 0 {
+		return nil
+	}
+
+	var patch any
+	if err := json.Unmarshal(overlay.Spec.Raw, &patch); err != nil {
+		return fmt.Errorf("invalid %s overlay spec: %w", gvk.Kind, err)
+	}
+	wrapped, err := json.Marshal(map[string]any{"spec": withoutPatchDirectives(patch)})
+	if err != nil {
+		return fmt.Errorf("invalid %s overlay spec: %w", gvk.Kind, err)
+	}
+
+	obj, err := getDataObjectForGVK(gvk)
+	if err != nil {
+		return err
+	}
+	strictErrs, err := kjson.UnmarshalStrict(wrapped, obj)
+	if err != nil {
+		return fmt.Errorf("invalid %s overlay spec: %w", gvk.Kind, err)
+	}
+	if len(strictErrs) > 0 {
+		return fmt.Errorf("invalid %s overlay spec: %w", gvk.Kind, errors.Join(strictErrs...))
+	}
+	return nil
+}
+
+// withoutPatchDirectives returns the patch without the strategic merge patch directives, i.e. the
+// keys starting with '$' such as $patch, $retainKeys or $setElementOrder/<field>.
+func withoutPatchDirectives(patch any) any {
+	switch v := patch.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, val := range v {
+			if strings.HasPrefix(k, "$") {
+				continue
+			}
+			out[k] = withoutPatchDirectives(val)
+		}
+		return out
+	case []any:
+		out := make([]any, 0, len(v))
+		for _, val := range v {
+			out = append(out, withoutPatchDirectives(val))
+		}
+		return out
+	default:
+		return v
+	}
+}
+
 // applyOverlay applies a KubernetesResourceOverlay to a single object.
 func applyOverlay(obj client.Object, overlay *shared.KubernetesResourceOverlay, gvk schema.GroupVersionKind) (client.Object, error) {
 	// Apply metadata first
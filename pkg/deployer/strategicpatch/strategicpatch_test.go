@@ -472,3 +472,110 @@ func TestCreatePodDisruptionBudget_DeploymentLabelsNotMutated(t *testing.T) {
 	// The original deployment labels must not have been mutated.
 	assert.NotContains(t, dep.GetLabels(), "extra")
 }
+
+func TestOverlayApplier_ApplyOverlays_StrictValidation(t *testing.T) {
+	specPatch := []byte(`{
+		"replicas": 2,
+		"template": {
+			"spec": {
+				"containers": [{
+					"name": "kgateway-proxy",
+					"imagePullPolicie": "Always"
+				}]
+			}
+		}
+	}`)
+	newParams := func(strict *bool) *kgateway.GatewayParameters {
+		return &kgateway.GatewayParameters{
+			Spec: kgateway.GatewayParametersSpec{
+				StrictValidation: strict,
+				Kube: &kgateway.KubernetesProxyConfig{
+					GatewayParametersOverlays: kgateway.GatewayParametersOverlays{
+						DeploymentOverlay: &shared.KubernetesResourceOverlay{
+							Spec: &apiextensionsv1.JSON{Raw: specPatch},
+						},
+					},
+				},
+			},
+		}
+	}
+	newObjs := func() []client.Object {
+		return []client.Object{
+			&appsv1.Deployment{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-deployment",
+				},
+			},
+		}
+	}
+
+	t.Run("strict rejects unknown field", func(t *testing.T) {
+		applier := NewOverlayApplierFromGatewayParameters(newParams(ptr.To(true)))
+		_, err := applier.ApplyOverlays(newObjs())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Deployment")
+		assert.Contains(t, err.Error(), "spec.template.spec.containers[0].imagePullPolicie")
+	})
+
+	t.Run("permissive ignores unknown field", func(t *testing.T) {
+		for _, strict := range []*bool{nil, ptr.To(false)} {
+			applier := NewOverlayApplierFromGatewayParameters(newParams(strict))
+			objs, err := applier.ApplyOverlays(newObjs())
+			require.NoError(t, err)
+			assert.Equal(t, ptr.To[int32](2), objs[0].(*appsv1.Deployment).Spec.Replicas)
+		}
+	})
+}
+
+func TestResourceOverlays_ValidateStrict(t *testing.T) {
+	tests := []struct {
+		name     string
+		overlays *ResourceOverlays
+		wantErr  string
+	}{
+		{
+			name: "known fields and patch directives",
+			overlays: &ResourceOverlays{
+				Deployment: &shared.KubernetesResourceOverlay{
+					Spec: &apiextensionsv1.JSON{Raw: []byte(`{"template":{"spec":{"containers":[{"name":"sidecar","$patch":"delete"}]}}}`)},
+				},
+				Service: &shared.KubernetesResourceOverlay{
+					Spec: &apiextensionsv1.JSON{Raw: []byte(`{"type":"NodePort"}`)},
+				},
+			},
+		},
+		{
+			name: "unknown service field",
+			overlays: &ResourceOverlays{
+				Service: &shared.KubernetesResourceOverlay{
+					Spec: &apiextensionsv1.JSON{Raw: []byte(`{"typ":"NodePort"}`)},
+				},
+			},
+			wantErr: `invalid Service overlay spec: unknown field "spec.typ"`,
+		},
+		{
+			name: "unknown pod disruption budget field",
+			overlays: &ResourceOverlays{
+				PodDisruptionBudget: &shared.KubernetesResourceOverlay{
+					Spec: &apiextensionsv1.JSON{Raw: []byte(`{"minAvailible":1}`)},
+				},
+			},
+			wantErr: `invalid PodDisruptionBudget overlay spec: unknown field "spec.minAvailible"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.overlays.ValidateStrict()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -16,6 +16,8 @@ const (
 // helmConfig stores the top-level helm values used by the deployer.
 type HelmConfig struct {
 	Gateway *HelmGateway `json:"gateway,omitempty"`
+	// Plugins are the values of the chart fragments contributed by the plugins, by fragment name
+	Plugins map[string]any `json:"plugins,omitempty"`
 }
 
 type HelmGateway struct {
@@ -80,6 +82,7 @@ type HelmGateway struct {
 
 	// envoy bootstrap values
 	DnsResolver *HelmDnsResolver `json:"dnsResolver,omitempty"`
+	Admin       *HelmAdmin       `json:"admin,omitempty"`
 
 	// xds values
 	Xds *HelmXds `json:"xds,omitempty"`
@@ -138,6 +141,13 @@ type HelmDnsResolver struct {
 	UdpMaxQueries *int32 `json:"udpMaxQueries,omitempty"`
 }
 
+// HelmAdmin configures the admin listener, which exposes the envoy admin interface
+type HelmAdmin struct {
+	BindAddress         *string `json:"bindAddress,omitempty"`
+	AuthTokenSecretName *string `json:"authTokenSecretName,omitempty"`
+	EnableReload        *bool   `json:"enableReload,omitempty"`
+}
+
 type HelmIstio struct {
 	Enabled *bool `json:"enabled,omitempty"`
 }
@@ -171,6 +181,9 @@ type HelmStatsConfig struct {
 	EnableStatsRoute   *bool             `json:"enableStatsRoute,omitempty"`
 	StatsPrefixRewrite *string           `json:"statsPrefixRewrite,omitempty"`
 	Matcher            *HelmStatsMatcher `json:"matcher,omitempty"`
+	ExtraLabels        map[string]string `json:"extraLabels,omitempty"`
+	HistogramBuckets   []string          `json:"histogramBuckets,omitempty"`
+	Prefix             *string           `json:"prefix,omitempty"`
 }
 
 // HelmStatsMatcher represents mutually exclusive inclusion or exclusion lists for Envoy stats.
@@ -6,10 +6,12 @@ import (
 	"net/netip"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"istio.io/istio/pkg/slices"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
@@ -34,6 +36,37 @@ var ComponentLogLevelEmptyError = func(key string, value string) error {
 	return fmt.Errorf("an empty key or value was provided in componentLogLevels: key=%s, value=%s", key, value)
 }
 
+var StatsExtraLabelInvalidError = func(name string) error {
+	return fmt.Errorf("invalid stats extraLabels name %q: must be a valid Prometheus label name", name)
+}
+
+var StatsExtraLabelReservedError = func(name string) error {
+	return fmt.Errorf("invalid stats extraLabels name %q: the envoy_ and __ prefixes, le and quantile are reserved", name)
+}
+
+var StatsHistogramBucketNotPositiveError = func(index int, bucket string) error {
+	return fmt.Errorf("invalid stats histogramBuckets[%d] %q: buckets must be positive", index, bucket)
+}
+
+var StatsHistogramBucketNotAscendingError = func(index int, bucket, previous string) error {
+	return fmt.Errorf("invalid stats histogramBuckets[%d] %q: buckets must be in ascending order, but it follows %q", index, bucket, previous)
+}
+
+var StatsDisabledMetricsWithInclusionListError = errors.New("stats disabledMetrics cannot be set with matcher.inclusionList")
+
+var StatsPrefixInvalidError = func(prefix string) error {
+	return fmt.Errorf("invalid stats prefix %q: prefix must be a valid Prometheus metric name", prefix)
+}
+
+var AdminBindAddressInvalidError = func(address string) error {
+	return fmt.Errorf("invalid admin bindAddress %q: must be an IP address", address)
+}
+
+var (
+	statsLabelNameRegex  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	statsMetricNameRegex = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+)
+
 // Extract the listener ports from a Gateway and corresponding listener sets. These will be used to populate:
 // 1. the ports exposed on the envoy container
 // 2. the ports exposed on the proxy service
@@ -279,15 +312,50 @@ func GetImageValues(image *kgateway.Image) *HelmImage {
 }
 
 // Get the stats values for the envoy listener in the configmap for bootstrap.
-func GetStatsValues(statsConfig *kgateway.StatsConfig) *HelmStatsConfig {
+// GetAdminValues returns the values of the admin listener, or nil when it is not enabled.
+func GetAdminValues(adminConfig *kgateway.EnvoyAdmin) (*HelmAdmin, error) {
+	if !ptr.Deref(adminConfig.GetEnabled(), false) {
+		return nil, nil
+	}
+
+	bindAddress := ptr.Deref(adminConfig.GetBindAddress(), "127.0.0.1")
+	if _, err := netip.ParseAddr(bindAddress); err != nil {
+		return nil, AdminBindAddressInvalidError(bindAddress)
+	}
+	vals := &HelmAdmin{
+		BindAddress:  &bindAddress,
+		EnableReload: adminConfig.GetEnableReload(),
+	}
+	if ref := adminConfig.GetAuthTokenSecretRef(); ref != nil {
+		vals.AuthTokenSecretName = &ref.Name
+	}
+	return vals, nil
+}
+
+func GetStatsValues(statsConfig *kgateway.StatsConfig) (*HelmStatsConfig, error) {
 	if statsConfig == nil {
-		return nil
+		return nil, nil
+	}
+	if err := validateStatsExtraLabels(statsConfig.GetExtraLabels()); err != nil {
+		return nil, err
+	}
+	if err := validateStatsHistogramBuckets(statsConfig.GetHistogramBuckets()); err != nil {
+		return nil, err
+	}
+	if err := validateStatsDisabledMetrics(statsConfig.GetDisabledMetrics()); err != nil {
+		return nil, err
+	}
+	if prefix := statsConfig.GetPrefix(); prefix != nil && !statsMetricNameRegex.MatchString(*prefix) {
+		return nil, StatsPrefixInvalidError(*prefix)
 	}
 	vals := &HelmStatsConfig{
 		Enabled:            statsConfig.GetEnabled(),
 		RoutePrefixRewrite: statsConfig.GetRoutePrefixRewrite(),
 		EnableStatsRoute:   statsConfig.GetEnableStatsRoute(),
 		StatsPrefixRewrite: statsConfig.GetStatsRoutePrefixRewrite(),
+		ExtraLabels:        statsConfig.GetExtraLabels(),
+		HistogramBuckets:   statsConfig.GetHistogramBuckets(),
+		Prefix:             statsConfig.GetPrefix(),
 	}
 
 	if m := statsConfig.GetMatcher(); m != nil {
@@ -300,7 +368,68 @@ func GetStatsValues(statsConfig *kgateway.StatsConfig) *HelmStatsConfig {
 		vals.Matcher = hm
 	}
 
-	return vals
+	// the disabled metrics are excluded by the matcher, as Envoy doesn't create the stats it excludes
+	if disabled := statsConfig.GetDisabledMetrics(); len(disabled) > 0 {
+		if vals.Matcher == nil {
+			vals.Matcher = &HelmStatsMatcher{}
+		}
+		if len(vals.Matcher.InclusionList) > 0 {
+			return nil, StatsDisabledMetricsWithInclusionListError
+		}
+		for _, pattern := range disabled {
+			vals.Matcher.ExclusionList = append(vals.Matcher.ExclusionList, HelmStringMatcher{SafeRegex: new(pattern)})
+		}
+	}
+
+	return vals, nil
+}
+
+// validateStatsDisabledMetrics checks that the disabled metrics are valid RE2 regular expressions.
+func validateStatsDisabledMetrics(patterns []string) error {
+	for i, p := range patterns {
+		if _, err := regexp.Compile(p); err != nil {
+			return fmt.Errorf("invalid stats disabledMetrics[%d] %q: %w", i, p, err)
+		}
+	}
+	return nil
+}
+
+// validateStatsHistogramBuckets checks that the histogram buckets are positive numbers in strictly ascending order.
+func validateStatsHistogramBuckets(buckets []string) error {
+	prev := 0.0
+	for i, b := range buckets {
+		v, err := strconv.ParseFloat(b, 64)
+		if err != nil {
+			return fmt.Errorf("invalid stats histogramBuckets[%d] %q: %w", i, b, err)
+		}
+		if v <= 0 {
+			return StatsHistogramBucketNotPositiveError(i, b)
+		}
+		if i > 0 && v <= prev {
+			return StatsHistogramBucketNotAscendingError(i, b, buckets[i-1])
+		}
+		prev = v
+	}
+	return nil
+}
+
+// validateStatsExtraLabels checks that the extra stats labels are valid Prometheus label names
+// that don't collide with the labels Envoy and Prometheus reserve.
+func validateStatsExtraLabels(labels map[string]string) error {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !statsLabelNameRegex.MatchString(name) {
+			return StatsExtraLabelInvalidError(name)
+		}
+		if strings.HasPrefix(name, "envoy_") || strings.HasPrefix(name, "__") || name == "le" || name == "quantile" {
+			return StatsExtraLabelReservedError(name)
+		}
+	}
+	return nil
 }
 
 func toHelmStringMatcher(l []shared.StringMatcher) []HelmStringMatcher {
@@ -10,6 +10,7 @@ import (
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
 )
 
 func TestComponentLogLevelsToString(t *testing.T) {
@@ -292,3 +293,278 @@ func TestGetServiceValues(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPortsValues(t *testing.T) {
+	gw := &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"},
+		Spec: gwv1.GatewaySpec{
+			Listeners: []gwv1.Listener{
+				{Name: "http", Protocol: gwv1.HTTPProtocolType, Port: 80},
+				{Name: "tcp", Protocol: gwv1.TCPProtocolType, Port: 5432},
+				{Name: "dns-tcp", Protocol: gwv1.TCPProtocolType, Port: 53},
+				{Name: "dns-udp", Protocol: gwv1.UDPProtocolType, Port: 53},
+				{Name: "syslog", Protocol: gwv1.UDPProtocolType, Port: 514},
+			},
+		},
+	}
+
+	ports := GetPortsValues(GatewayIRFrom(gw, "kgateway.dev/kgateway"), nil)
+
+	type port struct {
+		name     string
+		port     int32
+		protocol string
+	}
+	got := make([]port, 0, len(ports))
+	for _, p := range ports {
+		assert.Equal(t, *p.Port, *p.TargetPort)
+		got = append(got, port{name: *p.Name, port: *p.Port, protocol: *p.Protocol})
+	}
+	assert.Equal(t, []port{
+		{name: "listener-53", port: 53, protocol: "TCP"},
+		{name: "listener-80", port: 80, protocol: "TCP"},
+		{name: "listener-5432", port: 5432, protocol: "TCP"},
+	}, got, "the ports of UDP listeners, which are not translated, must not be exposed")
+}
+
+func TestGetStatsValuesExtraLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  map[string]string
+		wantErr error
+	}{
+		{
+			name:   "custom labels are passed through",
+			labels: map[string]string{"gateway": "gw", "region": "us-east-1"},
+		},
+		{
+			name:    "invalid label name is rejected",
+			labels:  map[string]string{"gateway-name": "gw"},
+			wantErr: StatsExtraLabelInvalidError("gateway-name"),
+		},
+		{
+			name:    "envoy prefix is reserved",
+			labels:  map[string]string{"region": "us-east-1", "envoy_cluster_name": "foo"},
+			wantErr: StatsExtraLabelReservedError("envoy_cluster_name"),
+		},
+		{
+			name:    "le is reserved",
+			labels:  map[string]string{"le": "1"},
+			wantErr: StatsExtraLabelReservedError("le"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetStatsValues(&kgateway.StatsConfig{ExtraLabels: tt.labels})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.labels, got.ExtraLabels)
+		})
+	}
+}
+
+func TestGetStatsValuesHistogramBuckets(t *testing.T) {
+	tests := []struct {
+		name    string
+		buckets []string
+		wantErr error
+	}{
+		{
+			name:    "custom buckets are passed through",
+			buckets: []string{"0.5", "1", "5", "25", "100"},
+		},
+		{
+			name:    "non-ascending buckets are rejected",
+			buckets: []string{"1", "10", "5"},
+			wantErr: StatsHistogramBucketNotAscendingError(2, "5", "10"),
+		},
+		{
+			name:    "equal buckets are rejected",
+			buckets: []string{"1", "1.0"},
+			wantErr: StatsHistogramBucketNotAscendingError(1, "1.0", "1"),
+		},
+		{
+			name:    "zero bucket is rejected",
+			buckets: []string{"0", "1"},
+			wantErr: StatsHistogramBucketNotPositiveError(0, "0"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetStatsValues(&kgateway.StatsConfig{HistogramBuckets: tt.buckets})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.buckets, got.HistogramBuckets)
+		})
+	}
+}
+
+func TestGetStatsValuesDisabledMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *kgateway.StatsConfig
+		want    *HelmStatsMatcher
+		wantErr string
+	}{
+		{
+			name: "disabled metrics are excluded",
+			config: &kgateway.StatsConfig{
+				DisabledMetrics: []string{`vhost\..*\.route\..*`, `cluster\..*\.upstream_rq_[0-9]{3}`},
+			},
+			want: &HelmStatsMatcher{
+				ExclusionList: []HelmStringMatcher{
+					{SafeRegex: new(`vhost\..*\.route\..*`)},
+					{SafeRegex: new(`cluster\..*\.upstream_rq_[0-9]{3}`)},
+				},
+			},
+		},
+		{
+			name: "disabled metrics are appended to the exclusion list",
+			config: &kgateway.StatsConfig{
+				Matcher: &kgateway.StatsMatcher{
+					ExclusionList: []shared.StringMatcher{{Prefix: new("http.")}},
+				},
+				DisabledMetrics: []string{`vhost\..*\.route\..*`},
+			},
+			want: &HelmStatsMatcher{
+				ExclusionList: []HelmStringMatcher{
+					{Prefix: new("http.")},
+					{SafeRegex: new(`vhost\..*\.route\..*`)},
+				},
+			},
+		},
+		{
+			name: "invalid pattern is rejected",
+			config: &kgateway.StatsConfig{
+				DisabledMetrics: []string{"vhost\\..*", "route\\.(.*"},
+			},
+			wantErr: "invalid stats disabledMetrics[1] \"route\\\\.(.*\": error parsing regexp: missing closing ): `route\\.(.*`",
+		},
+		{
+			name: "inclusion list is rejected",
+			config: &kgateway.StatsConfig{
+				Matcher: &kgateway.StatsMatcher{
+					InclusionList: []shared.StringMatcher{{Prefix: new("http.")}},
+				},
+				DisabledMetrics: []string{`vhost\..*\.route\..*`},
+			},
+			wantErr: StatsDisabledMetricsWithInclusionListError.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetStatsValues(tt.config)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Matcher)
+		})
+	}
+}
+
+func TestGetStatsValuesPrefix(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  *string
+		wantErr error
+	}{
+		{
+			name:   "custom prefix is passed through",
+			prefix: new("edge_gw"),
+		},
+		{
+			name: "unset prefix",
+		},
+		{
+			name:    "prefix starting with a digit is rejected",
+			prefix:  new("1edge"),
+			wantErr: StatsPrefixInvalidError("1edge"),
+		},
+		{
+			name:    "prefix with a dash is rejected",
+			prefix:  new("edge-gw"),
+			wantErr: StatsPrefixInvalidError("edge-gw"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetStatsValues(&kgateway.StatsConfig{Prefix: tt.prefix})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.prefix, got.Prefix)
+		})
+	}
+}
+
+func TestGetAdminValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		admin   *kgateway.EnvoyAdmin
+		want    *HelmAdmin
+		wantErr error
+	}{
+		{
+			name: "unset admin",
+		},
+		{
+			name:  "disabled admin listener",
+			admin: &kgateway.EnvoyAdmin{Enabled: new(false), BindAddress: new("0.0.0.0")},
+		},
+		{
+			name:  "bound to localhost by default",
+			admin: &kgateway.EnvoyAdmin{Enabled: new(true)},
+			want:  &HelmAdmin{BindAddress: new("127.0.0.1")},
+		},
+		{
+			name: "bound to all addresses with token auth",
+			admin: &kgateway.EnvoyAdmin{
+				Enabled:            new(true),
+				BindAddress:        new("::"),
+				AuthTokenSecretRef: &corev1.LocalObjectReference{Name: "admin-token"},
+			},
+			want: &HelmAdmin{BindAddress: new("::"), AuthTokenSecretName: new("admin-token")},
+		},
+		{
+			name:  "reload enabled",
+			admin: &kgateway.EnvoyAdmin{Enabled: new(true), EnableReload: new(true)},
+			want:  &HelmAdmin{BindAddress: new("127.0.0.1"), EnableReload: new(true)},
+		},
+		{
+			name:    "hostname bind address is rejected",
+			admin:   &kgateway.EnvoyAdmin{Enabled: new(true), BindAddress: new("localhost")},
+			wantErr: AdminBindAddressInvalidError("localhost"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetAdminValues(tt.admin)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/controller"
+)
+
+// The rendered gateway endpoint returns the effective GatewayParameters of a Gateway and the objects the
+// deployer would apply for it, without applying them. The Gateway is selected with ?gateway=<namespace>/<name>.
+func addGatewayRenderHandler(path string, mux *http.ServeMux, profiles map[string]dynamicProfileDescription, renderer *controller.GatewayRenderer) {
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if renderer == nil {
+			writeJSON(w, map[string]string{"error": "Gateway deployer not available"}, r)
+			return
+		}
+		namespace, name, ok := strings.Cut(r.URL.Query().Get("gateway"), "/")
+		if !ok || namespace == "" || name == "" {
+			writeJSON(w, map[string]string{"error": "gateway query parameter must be <namespace>/<name>"}, r)
+			return
+		}
+		rendered, err := renderer.Render(r.Context(), types.NamespacedName{Namespace: namespace, Name: name})
+		if err != nil {
+			writeJSON(w, map[string]string{"error": fmt.Sprintf("failed to render gateway %s/%s: %v", namespace, name, err)}, r)
+			return
+		}
+		writeJSON(w, rendered, r)
+	})
+	profiles[path] = func() string {
+		return "Effective GatewayParameters and rendered objects of a Gateway (?gateway=<namespace>/<name>)"
+	}
+}
@@ -0,0 +1,54 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/controller"
+)
+
+func TestGatewayRenderHandler(t *testing.T) {
+	testCases := []struct {
+		name     string
+		renderer *controller.GatewayRenderer
+		query    string
+		want     string
+	}{
+		{
+			name:  "no renderer",
+			query: "?gateway=default/gw",
+			want:  `{"error":"Gateway deployer not available"}`,
+		},
+		{
+			name:     "missing gateway",
+			renderer: controller.NewGatewayRenderer(),
+			want:     `{"error":"gateway query parameter must be <namespace>/<name>"}`,
+		},
+		{
+			name:     "gateway without namespace",
+			renderer: controller.NewGatewayRenderer(),
+			query:    "?gateway=gw",
+			want:     `{"error":"gateway query parameter must be <namespace>/<name>"}`,
+		},
+		{
+			name:     "deployer not running",
+			renderer: controller.NewGatewayRenderer(),
+			query:    "?gateway=default/gw",
+			want:     `{"error":"failed to render gateway default/gw: gateway deployer is not running"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			addGatewayRenderHandler("/gateways/rendered", mux, map[string]dynamicProfileDescription{}, tc.renderer)
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/gateways/rendered"+tc.query, nil))
+			assert.JSONEq(t, tc.want, w.Body.String())
+		})
+	}
+}
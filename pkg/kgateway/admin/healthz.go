@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/health"
+)
+
+// healthzResponse is the detailed view of the readiness of the control plane.
+type healthzResponse struct {
+	Ready       bool                `json:"ready"`
+	Error       string              `json:"error,omitempty"`
+	Collections []health.SyncStatus `json:"collections"`
+	// Inputs is whether the informers lag the API server, while which xDS snapshot updates are held back.
+	// It doesn't affect the readiness, as proxies keep being served the last good configuration.
+	Inputs *health.InputStaleness `json:"inputs,omitempty"`
+}
+
+func addHealthzHandler(path string, mux *http.ServeMux, profiles map[string]dynamicProfileDescription, tracker *health.SyncTracker, inputs *health.InputStalenessTracker) {
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if tracker == nil {
+			writeJSON(w, map[string]string{"error": "sync state not available"}, r)
+			return
+		}
+		resp := healthzResponse{Ready: true, Collections: tracker.Status()}
+		if inputs != nil {
+			status := inputs.Status()
+			resp.Inputs = &status
+		}
+		if err := tracker.Ready(r); err != nil {
+			resp.Ready = false
+			resp.Error = err.Error()
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		writeJSON(w, resp, r)
+	})
+	profiles[path] = func() string {
+		return "Sync state of the collections and xDS snapshots the readiness check waits for, and staleness of the inputs"
+	}
+}
@@ -0,0 +1,54 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/health"
+)
+
+func TestHealthzDetailed(t *testing.T) {
+	var pushed atomic.Bool
+	tracker := health.NewSyncTracker()
+	tracker.Add(
+		health.Check{Name: "Gateways", Synced: func() bool { return true }},
+		health.Check{Name: "InitialXdsPush", Synced: pushed.Load},
+	)
+	mux := http.NewServeMux()
+	profiles := map[string]dynamicProfileDescription{}
+	addHealthzHandler("/healthz/detailed", mux, profiles, tracker, nil)
+	assert.Contains(t, profiles, "/healthz/detailed")
+
+	get := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz/detailed", nil))
+		return rec
+	}
+
+	rec := get()
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.JSONEq(t, `{
+		"ready": false,
+		"error": "waiting for InitialXdsPush to sync",
+		"collections": [
+			{"name": "Gateways", "synced": true},
+			{"name": "InitialXdsPush", "synced": false}
+		]
+	}`, rec.Body.String())
+
+	pushed.Store(true)
+	rec = get()
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"ready": true,
+		"collections": [
+			{"name": "Gateways", "synced": true},
+			{"name": "InitialXdsPush", "synced": true}
+		]
+	}`, rec.Body.String())
+}
@@ -14,13 +14,15 @@ import (
 	"istio.io/istio/pkg/kube/krt"
 
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/controller"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/health"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/xds"
 	"github.com/kgateway-dev/kgateway/v2/pkg/version"
 )
 
 func RunAdminServer(ctx context.Context, setupOpts *controller.SetupOpts) error {
 	// serverHandlers defines the custom handlers that the Admin Server will support
-	serverHandlers := getServerHandlers(ctx, setupOpts.KrtDebugger, setupOpts.Cache)
+	serverHandlers := getServerHandlers(ctx, setupOpts.KrtDebugger, setupOpts.Cache, setupOpts.ProxyStatus, setupOpts.SyncTracker, setupOpts.InputStaleness, setupOpts.GatewayRenderer)
 
 	startHandlers(ctx, serverHandlers)
 
@@ -33,17 +35,23 @@ type dynamicProfileDescription func() string
 
 // getServerHandlers returns the custom handlers for the Admin Server, which will be bound to the http.ServeMux
 // These endpoints serve as the basis for an Admin Interface for the Control Plane (https://github.com/kgateway-dev/kgateway/issues/6494)
-func getServerHandlers(_ context.Context, dbg *krt.DebugHandler, cache envoycache.SnapshotCache) func(mux *http.ServeMux, profiles map[string]dynamicProfileDescription) {
+func getServerHandlers(_ context.Context, dbg *krt.DebugHandler, cache envoycache.SnapshotCache, proxyStatus *xds.ProxyStatusTracker, tracker *health.SyncTracker, inputs *health.InputStalenessTracker, renderer *controller.GatewayRenderer) func(mux *http.ServeMux, profiles map[string]dynamicProfileDescription) {
 	return func(m *http.ServeMux, profiles map[string]dynamicProfileDescription) {
 		addXdsSnapshotHandler("/snapshots/xds", m, profiles, cache)
 
+		addXdsProxiesHandler("/snapshots/xds/proxies", m, profiles, proxyStatus)
+
 		addKrtSnapshotHandler("/snapshots/krt", m, profiles, dbg)
 
+		addGatewayRenderHandler("/gateways/rendered", m, profiles, renderer)
+
 		addLoggingHandler("/logging", m, profiles)
 
 		addPprofHandler("/debug/pprof/", m, profiles)
 
 		addVersionHandler("/version", m, profiles)
+
+		addHealthzHandler("/healthz/detailed", m, profiles, tracker, inputs)
 	}
 }
 
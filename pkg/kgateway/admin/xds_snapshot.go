@@ -8,6 +8,8 @@ import (
 	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/xds"
 )
 
 // The xDS Snapshot is intended to return the full in-memory xDS cache that the Control Plane manages
@@ -24,6 +26,19 @@ func addXdsSnapshotHandler(path string, mux *http.ServeMux, profiles map[string]
 	profiles[path] = func() string { return "XDS Snapshot (Envoy only)" }
 }
 
+// The xDS proxies endpoint returns the versions acknowledged by every connected proxy, to find the
+// proxies that lag the latest snapshot of their Gateway.
+func addXdsProxiesHandler(path string, mux *http.ServeMux, profiles map[string]dynamicProfileDescription, proxyStatus *xds.ProxyStatusTracker) {
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if proxyStatus == nil {
+			writeJSON(w, map[string]string{"error": "Envoy xDS cache not available (Envoy controller may be disabled)"}, r)
+			return
+		}
+		writeJSON(w, proxyStatus.ProxyStatuses(), r)
+	})
+	profiles[path] = func() string { return "Acknowledged xDS versions and staleness of connected proxies (Envoy only)" }
+}
+
 func getXdsSnapshotDataFromCache(xdsCache cache.SnapshotCache) SnapshotResponseData {
 	cacheKeys := xdsCache.GetStatusKeys()
 	cacheEntries := make(map[string]any, len(cacheKeys))
@@ -13,8 +13,10 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient"
 	"github.com/kgateway-dev/kgateway/v2/pkg/deployer"
 	internaldeployer "github.com/kgateway-dev/kgateway/v2/pkg/kgateway/deployer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/sharding"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/eventutils"
 )
 
 // rateLimiter uses token bucket for overall rate limiting and exponential backoff for per-item rate limiting
@@ -53,6 +55,16 @@ type GatewayConfig struct {
 	AdditionalGatewayClasses map[string]*deployer.GatewayClassInfo
 	// CertWatcher is the shared certificate watcher for xDS TLS
 	CertWatcher *certwatcher.CertWatcher
+	// Shard is the shard of Gateways deployed by this controller
+	Shard sharding.Shard
+	// EventRecorder emits Warning events on the objects whose deployment fails
+	EventRecorder *eventutils.RateLimitedRecorder
+	// DeployerMutators are the mutators contributed by the plugins, called on the objects deployed for every Gateway
+	DeployerMutators []pluginsdk.DeployerMutator
+	// DeployerChartFragments are the chart fragments contributed by the plugins, rendered for every Gateway
+	DeployerChartFragments []pluginsdk.DeployerChartFragment
+	// GatewayRenderer, if set, renders the resources of Gateways with the deployer of the controller
+	GatewayRenderer *GatewayRenderer
 }
 
 type HelmValuesGeneratorOverrideFunc func(inputs *deployer.Inputs) deployer.HelmValuesGenerator
@@ -68,7 +80,7 @@ func NewBaseGatewayController(
 
 	// Initialize Gateway reconciler
 	if err := watchGw(cfg, helmValuesGeneratorOverride, gatewayControllerExtension); err != nil {
-		return nil
+		return err
 	}
 
 	// Initialize GatewayClass reconciler
@@ -98,6 +110,9 @@ func watchGw(
 		CommonCollections:        cfg.CommonCollections,
 		GatewayClassName:         cfg.GatewayClassName,
 		WaypointGatewayClassName: cfg.WaypointGatewayClassName,
+		EventRecorder:            cfg.EventRecorder,
+		DeployerMutators:         cfg.DeployerMutators,
+		DeployerChartFragments:   cfg.DeployerChartFragments,
 	}
 
 	gwParams := internaldeployer.NewGatewayParameters(cfg.Client, inputs)
@@ -114,6 +129,9 @@ func watchGw(
 	if err != nil {
 		return err
 	}
+	if cfg.GatewayRenderer != nil {
+		cfg.GatewayRenderer.init(cfg.Mgr.GetAPIReader(), d, gwParams)
+	}
 
 	return cfg.Mgr.Add(NewGatewayReconciler(cfg, d, gwParams, gatewayControllerExtension))
 }
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/deployer"
+	internaldeployer "github.com/kgateway-dev/kgateway/v2/pkg/kgateway/deployer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/kubeutils"
+)
+
+// RenderedGateway is what the deployer resolves and renders for a Gateway, without applying it.
+type RenderedGateway struct {
+	// Parameters are the effective GatewayParameters of the Gateway, unset when its values are generated by an override
+	Parameters *kgateway.GatewayParameters `json:"parameters,omitempty"`
+	// Objects are the objects the deployer applies for the Gateway, in order
+	Objects []*unstructured.Unstructured `json:"objects"`
+}
+
+// GatewayRenderer renders the resources of Gateways on demand, for the admin server.
+// It can render once the Gateway controller is built.
+type GatewayRenderer struct {
+	mu       sync.RWMutex
+	reader   client.Reader
+	deployer *deployer.Deployer
+	params   *internaldeployer.GatewayParameters
+}
+
+func NewGatewayRenderer() *GatewayRenderer {
+	return &GatewayRenderer{}
+}
+
+func (r *GatewayRenderer) init(reader client.Reader, d *deployer.Deployer, params *internaldeployer.GatewayParameters) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reader = reader
+	r.deployer = d
+	r.params = params
+}
+
+// Render returns the effective GatewayParameters of the Gateway and the objects planned for it.
+func (r *GatewayRenderer) Render(ctx context.Context, nn types.NamespacedName) (*RenderedGateway, error) {
+	r.mu.RLock()
+	reader, d, params := r.reader, r.deployer, r.params
+	r.mu.RUnlock()
+	if d == nil {
+		return nil, errors.New("gateway deployer is not running")
+	}
+
+	gw := &gwv1.Gateway{}
+	if err := reader.Get(ctx, nn, gw); err != nil {
+		return nil, err
+	}
+
+	effective, err := params.EffectiveGatewayParameters(gw)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving GatewayParameters: %w", err)
+	}
+	planned, err := d.PlanObjects(ctx, gw)
+	if err != nil {
+		return nil, fmt.Errorf("error rendering objects: %w", err)
+	}
+
+	rendered := &RenderedGateway{
+		Parameters: effective,
+		Objects:    make([]*unstructured.Unstructured, 0, len(planned)),
+	}
+	for _, obj := range planned {
+		u, err := kubeutils.ToUnstructured(obj)
+		if err != nil {
+			return nil, fmt.Errorf("error converting object %s to unstructured: %w", kubeutils.NamespacedNameFrom(obj), err)
+		}
+		rendered.Objects = append(rendered.Objects, u)
+	}
+	return rendered, nil
+}
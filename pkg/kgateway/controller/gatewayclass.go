@@ -1,16 +1,20 @@
 package controller
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"math"
+	"slices"
 
 	"istio.io/istio/pkg/config/schema/gvr"
 	"istio.io/istio/pkg/kube"
 	"istio.io/istio/pkg/kube/controllers"
 	"istio.io/istio/pkg/kube/kclient"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,12 +23,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
+	apiannotations "github.com/kgateway-dev/kgateway/v2/api/annotations"
 	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient"
 	"github.com/kgateway-dev/kgateway/v2/pkg/deployer"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
 	"github.com/kgateway-dev/kgateway/v2/pkg/reports"
 	"github.com/kgateway-dev/kgateway/v2/pkg/utils/kubeutils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/version"
 )
 
 var _ manager.LeaderElectionRunnable = (*gatewayClassReconciler)(nil)
@@ -123,23 +129,21 @@ func (r *gatewayClassReconciler) reconcile(req types.NamespacedName) (rErr error
 		}
 	}
 
+	return r.updateGatewayClassStatus(req)
+}
+
+// updateGatewayClassStatus accepts the GatewayClass and publishes its supported features, skipping the update
+// when the status is unchanged.
+func (r *gatewayClassReconciler) updateGatewayClassStatus(req types.NamespacedName) error {
 	gwClass := r.gwClassClient.Get(req.Name, req.Namespace)
 	if gwClass == nil || gwClass.GetDeletionTimestamp() != nil {
 		logger.Debug("gatewayclass not found, skipping status update", "ref", req)
 		return nil
 	}
 
-	// Update status
-	status := gwClass.Status
-	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
-		Type:               string(gwv1.GatewayClassConditionStatusAccepted),
-		Status:             metav1.ConditionTrue,
-		Reason:             string(gwv1.GatewayClassReasonAccepted),
-		ObservedGeneration: gwClass.Generation,
-		Message:            reports.GatewayClassAcceptedMessage,
-	})
-	if i, ok := r.classInfo[gwClass.Name]; ok {
-		status.SupportedFeatures = i.SupportedFeatures
+	status := buildGatewayClassStatus(gwClass, r.classInfo[gwClass.Name])
+	if equality.Semantic.DeepEqual(status, gwClass.Status) {
+		return nil
 	}
 
 	_, err := r.gwClassClient.UpdateStatus(&gwv1.GatewayClass{
@@ -153,6 +157,25 @@ func (r *gatewayClassReconciler) reconcile(req types.NamespacedName) (rErr error
 	return nil
 }
 
+// buildGatewayClassStatus builds the status of the GatewayClass, publishing the features supported by the
+// class, if it is one of ours, sorted by name so that the status doesn't change when their order does.
+func buildGatewayClassStatus(gwClass *gwv1.GatewayClass, info *deployer.GatewayClassInfo) gwv1.GatewayClassStatus {
+	status := *gwClass.Status.DeepCopy()
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               string(gwv1.GatewayClassConditionStatusAccepted),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(gwv1.GatewayClassReasonAccepted),
+		ObservedGeneration: gwClass.Generation,
+		Message:            reports.GatewayClassAcceptedMessage,
+	})
+	if info != nil {
+		status.SupportedFeatures = slices.SortedFunc(slices.Values(info.SupportedFeatures), func(a, b gwv1.SupportedFeature) int {
+			return cmp.Compare(a.Name, b.Name)
+		})
+	}
+	return status
+}
+
 func (r *gatewayClassReconciler) reconcileGatewayClasses() error {
 	var errs []error
 	for name, info := range r.classInfo {
@@ -188,7 +211,7 @@ func (r *gatewayClassReconciler) buildDesiredGatewayClass(name string, info *dep
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
-			Annotations: info.Annotations,
+			Annotations: gatewayClassAnnotations(info),
 			Labels:      info.Labels,
 		},
 		Spec: gwv1.GatewayClassSpec{
@@ -202,6 +225,20 @@ func (r *gatewayClassReconciler) buildDesiredGatewayClass(name string, info *dep
 	return gwc
 }
 
+// gatewayClassAnnotations returns the annotations of the GatewayClass along with the version of the controller.
+func gatewayClassAnnotations(info *deployer.GatewayClassInfo) map[string]string {
+	annotations := maps.Clone(info.Annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	controllerVersion := version.Version
+	if controllerVersion == "" {
+		controllerVersion = version.UndefinedVersion
+	}
+	annotations[string(apiannotations.ControllerVersion)] = controllerVersion
+	return annotations
+}
+
 func (r *gatewayClassReconciler) applyGatewayClass(gwc *gwv1.GatewayClass, controllerName string) error {
 	gvr := gvr.GatewayClass
 	c := r.client.Dynamic().Resource(gvr).Namespace(metav1.NamespaceNone)
@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"istio.io/istio/pkg/config/schema/gvr"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/test"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	apiannotations "github.com/kgateway-dev/kgateway/v2/api/annotations"
+	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
+	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient/fake"
+	"github.com/kgateway-dev/kgateway/v2/pkg/deployer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/version"
+)
+
+func TestGetDefaultClassInfoSupportedFeatures(t *testing.T) {
+	const (
+		standardClass = "kgateway"
+		waypointClass = "kgateway-waypoint"
+	)
+
+	for _, enableExperimental := range []bool{true, false} {
+		classInfos := GetDefaultClassInfo(&apisettings.Settings{
+			EnableEnvoy:                          true,
+			EnableWaypoint:                       true,
+			EnableExperimentalGatewayAPIFeatures: enableExperimental,
+		}, standardClass, waypointClass, "ctrl.kgateway.dev", nil)
+
+		require.Equal(t, deployer.GetSupportedFeaturesForStandardGateway(enableExperimental), classInfos[standardClass].SupportedFeatures)
+		require.Equal(t, deployer.GetSupportedFeaturesForWaypointGateway(enableExperimental), classInfos[waypointClass].SupportedFeatures)
+		assert.Contains(t, classInfos[standardClass].SupportedFeatures, gwv1.SupportedFeature{Name: "GRPCRoute"})
+		assert.NotContains(t, classInfos[waypointClass].SupportedFeatures, gwv1.SupportedFeature{Name: "GRPCRoute"})
+		if enableExperimental {
+			assert.Contains(t, classInfos[standardClass].SupportedFeatures, gwv1.SupportedFeature{Name: "TLSRoute"})
+		} else {
+			assert.NotContains(t, classInfos[standardClass].SupportedFeatures, gwv1.SupportedFeature{Name: "TLSRoute"})
+		}
+	}
+}
+
+func TestBuildGatewayClassStatus(t *testing.T) {
+	gwClass := &gwv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "kgateway", Generation: 2},
+		Status: gwv1.GatewayClassStatus{
+			SupportedFeatures: []gwv1.SupportedFeature{{Name: "Stale"}},
+		},
+	}
+	supportedFeatures := deployer.GetSupportedFeaturesForStandardGateway(true)
+	reversed := slices.Clone(supportedFeatures)
+	slices.Reverse(reversed)
+
+	t.Run("sorted supported features", func(t *testing.T) {
+		status := buildGatewayClassStatus(gwClass, &deployer.GatewayClassInfo{SupportedFeatures: reversed})
+
+		assert.Equal(t, supportedFeatures, status.SupportedFeatures)
+		assert.Equal(t, status.SupportedFeatures,
+			buildGatewayClassStatus(gwClass, &deployer.GatewayClassInfo{SupportedFeatures: supportedFeatures}).SupportedFeatures)
+		assert.True(t, meta.IsStatusConditionTrue(status.Conditions, string(gwv1.GatewayClassConditionStatusAccepted)))
+		assert.Equal(t, []gwv1.SupportedFeature{{Name: "Stale"}}, gwClass.Status.SupportedFeatures)
+	})
+
+	t.Run("keeps the supported features of other classes", func(t *testing.T) {
+		status := buildGatewayClassStatus(gwClass, nil)
+
+		assert.Equal(t, []gwv1.SupportedFeature{{Name: "Stale"}}, status.SupportedFeatures)
+	})
+}
+
+func TestGatewayClassReconcilerUpdatesSupportedFeatures(t *testing.T) {
+	gwClass := &gwv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "kgateway", Generation: 1},
+		Spec:       gwv1.GatewayClassSpec{ControllerName: "ctrl.kgateway.dev"},
+	}
+	cli := fake.NewClient(t, gwClass)
+	r := &gatewayClassReconciler{
+		gwClassClient: kclient.NewFilteredDelayed[*gwv1.GatewayClass](cli, gvr.GatewayClass, kclient.Filter{}),
+	}
+	cli.RunAndWait(test.NewStop(t))
+
+	supportedFeatures := func() []gwv1.SupportedFeature {
+		return r.gwClassClient.Get(gwClass.Name, "").Status.SupportedFeatures
+	}
+	reconcileWith := func(enableExperimental bool) {
+		r.classInfo = map[string]*deployer.GatewayClassInfo{
+			gwClass.Name: {SupportedFeatures: deployer.GetSupportedFeaturesForStandardGateway(enableExperimental)},
+		}
+		require.NoError(t, r.updateGatewayClassStatus(types.NamespacedName{Name: gwClass.Name}))
+	}
+
+	reconcileWith(true)
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, deployer.GetSupportedFeaturesForStandardGateway(true), supportedFeatures())
+	}, time.Second, 10*time.Millisecond)
+
+	// disabling the experimental Gateway API features, e.g. on restart, updates the supported features
+	reconcileWith(false)
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, deployer.GetSupportedFeaturesForStandardGateway(false), supportedFeatures())
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestGatewayClassAnnotations(t *testing.T) {
+	info := &deployer.GatewayClassInfo{Annotations: map[string]string{"foo": "bar"}}
+
+	annotations := gatewayClassAnnotations(info)
+
+	assert.Equal(t, "bar", annotations["foo"])
+	assert.NotEmpty(t, annotations[string(apiannotations.ControllerVersion)])
+	if version.Version != "" {
+		assert.Equal(t, version.Version, annotations[string(apiannotations.ControllerVersion)])
+	}
+	assert.Equal(t, map[string]string{"foo": "bar"}, info.Annotations)
+}
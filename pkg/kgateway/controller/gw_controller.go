@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"cmp"
 	"context"
 	"crypto/tls"
 	"errors"
@@ -8,6 +9,7 @@ import (
 	"maps"
 	"math"
 	"slices"
+	"strings"
 
 	"istio.io/istio/pkg/config/schema/gvk"
 	"istio.io/istio/pkg/config/schema/gvr"
@@ -25,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	utilretry "k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -33,6 +36,7 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/deployer"
 	internaldeployer "github.com/kgateway-dev/kgateway/v2/pkg/kgateway/deployer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/sharding"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/logging"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
@@ -62,10 +66,17 @@ type gatewayReconciler struct {
 	nsClient         kclient.Client[*corev1.Namespace]
 	svcClient        kclient.Client[*corev1.Service]
 	deploymentClient kclient.Client[*appsv1.Deployment]
+	podClient        kclient.Client[*corev1.Pod]
 	svcAccountClient kclient.Client[*corev1.ServiceAccount]
 	configMapClient  kclient.Client[*corev1.ConfigMap]
+	// nodeClient is only set when node addresses are published for NodePort Services
+	nodeClient kclient.Client[*corev1.Node]
 
 	controllerExtension pluginsdk.GatewayControllerExtension
+	shard               sharding.Shard
+
+	// gwParamsTracker tracks the GatewayParameters the Gateways are deployed with, to report them on their status
+	gwParamsTracker *gatewayParametersTracker
 
 	queue controllers.Queue
 }
@@ -84,16 +95,27 @@ func NewGatewayReconciler(
 		controllerName:      cfg.ControllerName,
 		enableEnvoy:         cfg.CommonCollections.Settings.EnableEnvoy,
 		controllerExtension: controllerExtension,
+		shard:               cfg.Shard,
+		gwParamsTracker:     newGatewayParametersTracker(),
 
 		gwClient:         kclient.NewFilteredDelayed[*gwv1.Gateway](cfg.Client, gvr.KubernetesGateway, filter),
 		gwClassClient:    kclient.NewFilteredDelayed[*gwv1.GatewayClass](cfg.Client, gvr.GatewayClass, filter),
 		nsClient:         kclient.NewFiltered[*corev1.Namespace](cfg.Client, filter),
 		svcClient:        kclient.NewFiltered[*corev1.Service](cfg.Client, filter),
 		deploymentClient: kclient.NewFiltered[*appsv1.Deployment](cfg.Client, filter),
+		// only proxy pods are watched, to report why they aren't ready
+		podClient: kclient.NewFiltered[*corev1.Pod](cfg.Client, kclient.Filter{
+			ObjectFilter:  cfg.Client.ObjectFilter(),
+			LabelSelector: wellknown.GatewayNameLabel,
+		}),
 		svcAccountClient: kclient.NewFiltered[*corev1.ServiceAccount](cfg.Client, filter),
 		configMapClient:  kclient.NewFiltered[*corev1.ConfigMap](cfg.Client, filter),
 	}
 
+	if cfg.CommonCollections.Settings.GatewayNodePortAddresses {
+		r.nodeClient = kclient.New[*corev1.Node](cfg.Client)
+	}
+
 	// Reuse the parameter client from the deployer to avoid duplicate watches
 	// This client is only created when the controller is enabled
 	r.gwParamClient = gwParams.GetGatewayParametersClient()
@@ -159,8 +181,8 @@ func NewGatewayReconciler(
 		p := fetchGatewaysByGatewayClass(o)
 		return []types.NamespacedName{p}
 	})
-	// gwParamEventHandler is a handler that reconciles Gateways based on GatewayParameters changes
-	gwParamEventHandler := controllers.ObjectHandler(func(o controllers.Object) {
+	// reconcileGatewaysForParams reconciles the Gateways that use the changed GatewayParameters
+	reconcileGatewaysForParams := func(o controllers.Object) {
 		gwpName := o.GetName()
 		gwpNamespace := o.GetNamespace()
 
@@ -193,9 +215,20 @@ func NewGatewayReconciler(
 				}
 			}
 		}
-	})
+	}
+	// gwParamEventHandler is a handler that reconciles Gateways based on GatewayParameters changes
+	gwParamEventHandler := controllers.ObjectHandler(reconcileGatewaysForParams)
 	if r.gwParamClient != nil {
-		r.gwParamClient.AddEventHandler(gwParamEventHandler)
+		r.gwParamClient.AddEventHandler(controllers.FromEventHandler(func(o controllers.Event) {
+			// status updates, e.g. the ones reporting the deployment of the Gateways, don't change the deployed resources
+			if o.Event == controllers.EventUpdate &&
+				o.New.GetGeneration() == o.Old.GetGeneration() &&
+				maps.Equal(o.New.GetLabels(), o.Old.GetLabels()) &&
+				maps.Equal(o.New.GetAnnotations(), o.Old.GetAnnotations()) {
+				return
+			}
+			reconcileGatewaysForParams(o.Latest())
+		}))
 	}
 
 	// Custom event handler for ListenerSet changes
@@ -213,6 +246,33 @@ func NewGatewayReconciler(
 	r.svcClient.AddEventHandler(parentHandler)
 	r.configMapClient.AddEventHandler(parentHandler)
 
+	// Deployment events cover the changes of ready replicas, pod events are only needed to update the reason
+	// reported while the proxy has no ready replicas
+	r.podClient.AddEventHandler(controllers.FromEventHandler(func(o controllers.Event) {
+		if o.Event != controllers.EventUpdate || podNotReadyReason(o.Old.(*corev1.Pod)) == podNotReadyReason(o.New.(*corev1.Pod)) {
+			return
+		}
+		gwLabel := o.New.GetLabels()[wellknown.GatewayNameLabel]
+		for _, gw := range r.gwClient.List(o.New.GetNamespace(), labels.Everything()) {
+			if kubeutils.SafeGatewayLabelValue(gw.Name) == gwLabel {
+				r.queue.AddObject(gw)
+			}
+		}
+	}))
+
+	// Reconcile all Gateways when the node addresses change, as they may be published for NodePort Services
+	if r.nodeClient != nil {
+		r.nodeClient.AddEventHandler(controllers.FromEventHandler(func(o controllers.Event) {
+			if o.Event == controllers.EventUpdate &&
+				slices.Equal(o.Old.(*corev1.Node).Status.Addresses, o.New.(*corev1.Node).Status.Addresses) {
+				return
+			}
+			for _, gw := range r.gwClient.List(metav1.NamespaceAll, labels.Everything()) {
+				r.queue.AddObject(gw)
+			}
+		}))
+	}
+
 	// Register controller extensions
 	if controllerExtension != nil {
 		controllerExtension.Register(r.queue, gwParamEventHandler)
@@ -237,10 +297,14 @@ func (r *gatewayReconciler) Start(ctx context.Context) error {
 		r.gwClassClient.HasSynced,
 		r.nsClient.HasSynced,
 		r.deploymentClient.HasSynced,
+		r.podClient.HasSynced,
 		r.svcAccountClient.HasSynced,
 		r.svcClient.HasSynced,
 		r.configMapClient.HasSynced,
 	}
+	if r.nodeClient != nil {
+		hasSynced = append(hasSynced, r.nodeClient.HasSynced)
+	}
 	// Add GatewayParameters cache sync handlers
 	hasSynced = append(hasSynced, r.gwParams.GetCacheSyncHandlers()...)
 
@@ -257,6 +321,7 @@ func (r *gatewayReconciler) Start(ctx context.Context) error {
 		r.gwClassClient,
 		r.nsClient,
 		r.deploymentClient,
+		r.podClient,
 		r.svcAccountClient,
 		r.svcClient,
 		r.configMapClient,
@@ -264,6 +329,9 @@ func (r *gatewayReconciler) Start(ctx context.Context) error {
 	if r.gwParamClient != nil {
 		clients = append(clients, r.gwParamClient)
 	}
+	if r.nodeClient != nil {
+		clients = append(clients, r.nodeClient)
+	}
 	controllers.ShutdownAll(clients...)
 	if r.controllerExtension != nil {
 		r.controllerExtension.Stop()
@@ -281,6 +349,7 @@ func (r *gatewayReconciler) Reconcile(req types.NamespacedName) (rErr error) {
 	if gw == nil || gw.GetDeletionTimestamp() != nil {
 		// ignore the event if the Gateway is not found. A subsequent event should handle this if needed
 		logger.Debug("gateway not found, skipping reconciliation", "ref", req)
+		r.updateGatewayParametersStatuses(r.gwParamsTracker.forget(req))
 		return nil
 	}
 
@@ -299,12 +368,22 @@ func (r *gatewayReconciler) Reconcile(req types.NamespacedName) (rErr error) {
 	}
 	if !isEnvoyGateway {
 		// Not our GatewayClass at all
+		r.updateGatewayParametersStatuses(r.gwParamsTracker.forget(req))
+		return nil
+	}
+
+	if !r.shard.Owns(gw) {
+		logger.Debug("skipping gateway owned by another shard", "gateway", req)
+		r.updateGatewayParametersStatuses(r.gwParamsTracker.forget(req))
 		return nil
 	}
 
 	logger.Info("reconciling Gateway", "ref", req)
 	ctx := context.Background()
-	objs, err := r.deployer.GetObjsToDeploy(ctx, gw)
+	objs, err := r.deployer.PlanObjects(ctx, gw)
+	if !errors.Is(err, internaldeployer.ErrNoValidPorts) {
+		r.recordGatewayParameters(gw, err)
+	}
 	if err != nil {
 		if errors.Is(err, internaldeployer.ErrNoValidPorts) {
 			// status is reported from translator, so return normally
@@ -339,7 +418,6 @@ func (r *gatewayReconciler) Reconcile(req types.NamespacedName) (rErr error) {
 			return fmt.Errorf("failed to update status for Gateway %s: %w", req, statusErr)
 		}
 	}
-	objs = r.deployer.SetNamespaceAndOwnerWithGVK(gw, wellknown.GatewayGVK, objs)
 	err = r.deployer.DeployObjsWithSource(ctx, objs, gw)
 	if err != nil {
 		return err
@@ -351,6 +429,12 @@ func (r *gatewayReconciler) Reconcile(req types.NamespacedName) (rErr error) {
 		return fmt.Errorf("error pruning removed resources for Gateway %s: %w", req, err)
 	}
 
+	// gate the Programmed condition on the readiness of the proxy Deployment we manage
+	err = r.updateProgrammedStatus(ctx, gw, objs)
+	if err != nil {
+		return fmt.Errorf("error updating programmed status for Gateway %s: %w", req, err)
+	}
+
 	// find the name/ns of the service we own so we can grab addresses
 	// from it for status
 	var generatedSvc *metav1.ObjectMeta
@@ -394,26 +478,142 @@ func (r *gatewayReconciler) updateStatus(ctx context.Context, gw *gwv1.Gateway,
 	}
 
 	// update gateway addresses in the status
-	desiredAddresses := getDesiredAddresses(gw, svc)
+	var nodes []*corev1.Node
+	if r.nodeClient != nil && svc != nil && svc.Spec.Type == corev1.ServiceTypeNodePort {
+		nodes = r.nodeClient.List(metav1.NamespaceAll, labels.Everything())
+	}
+	desiredAddresses := getDesiredAddresses(gw, svc, nodes)
 	return updateGatewayAddresses(ctx, r.gwClient, client.ObjectKeyFromObject(gw), desiredAddresses)
 }
 
-func getDesiredAddresses(gw *gwv1.Gateway, svc *corev1.Service) []gwv1.GatewayStatusAddress {
+// updateProgrammedStatus sets Programmed=False with Reason=NoReadyReplicas on the Gateway while the proxy
+// Deployment has no ready replicas, and Programmed=True once it has, leaving the condition to the status
+// reporter otherwise. Self-managed Gateways have no Deployment, so the reporter sets their condition alone.
+func (r *gatewayReconciler) updateProgrammedStatus(ctx context.Context, gw *gwv1.Gateway, objs []client.Object) error {
+	var deployment *appsv1.Deployment
+	for _, obj := range objs {
+		if d, ok := obj.(*appsv1.Deployment); ok {
+			deployment = d
+			break
+		}
+	}
+	if deployment == nil {
+		return nil
+	}
+
+	existing := meta.FindStatusCondition(gw.Status.Conditions, string(gwv1.GatewayConditionProgrammed))
+	noReadyReplicas := existing != nil &&
+		existing.Status == metav1.ConditionFalse &&
+		existing.Reason == string(reports.GatewayReasonNoReadyReplicas)
+
+	var condition metav1.Condition
+	if message, ready := r.deploymentReadiness(deployment); !ready {
+		condition = metav1.Condition{
+			Type:               string(gwv1.GatewayConditionProgrammed),
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: gw.Generation,
+			Reason:             string(reports.GatewayReasonNoReadyReplicas),
+			Message:            message,
+		}
+		if noReadyReplicas && existing.Message == message && existing.ObservedGeneration == gw.Generation {
+			return nil
+		}
+	} else if noReadyReplicas {
+		condition = metav1.Condition{
+			Type:               string(gwv1.GatewayConditionProgrammed),
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: gw.Generation,
+			Reason:             string(gwv1.GatewayReasonProgrammed),
+			Message:            reports.GatewayProgrammedMessage,
+		}
+	} else {
+		return nil
+	}
+	return r.updateGatewayStatusWithRetry(ctx, gw, condition)
+}
+
+// deploymentReadiness returns whether the Deployment has ready replicas, or else a message summarizing why
+// its pods aren't ready.
+func (r *gatewayReconciler) deploymentReadiness(desired *appsv1.Deployment) (string, bool) {
+	ref := types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
+	deployment := r.deploymentClient.Get(desired.Name, desired.Namespace)
+	if deployment == nil {
+		return fmt.Sprintf("Deployment %s not found", ref), false
+	}
+	if deployment.Status.ReadyReplicas > 0 {
+		return "", true
+	}
+
+	message := fmt.Sprintf("Deployment %s has no ready replicas", ref)
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return message, false
+	}
+	reasons := sets.New[string]()
+	for _, pod := range r.podClient.List(deployment.Namespace, selector) {
+		if reason := podNotReadyReason(pod); reason != "" {
+			reasons.Insert(reason)
+		}
+	}
+	if reasons.Len() > 0 {
+		message += ": " + strings.Join(sets.List(reasons), ", ")
+	}
+	return message, false
+}
+
+// podNotReadyReason summarizes why the pod isn't ready, e.g., ImagePullBackOff or Unschedulable,
+// or returns an empty string if it is ready or no reason is known.
+func podNotReadyReason(pod *corev1.Pod) string {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+			return ""
+		}
+		if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse && cond.Reason != "" {
+			return cond.Reason
+		}
+	}
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+				return status.State.Waiting.Reason
+			}
+			if status.State.Terminated != nil && status.State.Terminated.Reason != "" && status.State.Terminated.ExitCode != 0 {
+				return status.State.Terminated.Reason
+			}
+		}
+	}
+	return ""
+}
+
+// getDesiredAddresses returns the addresses to publish in the status of the Gateway. The ingress
+// addresses of a LoadBalancer Service and the node addresses are sorted, so that their order
+// doesn't change between reconciliations when the order of the ingress entries or nodes does.
+// nodes are only passed for NodePort Services when node addresses are published.
+func getDesiredAddresses(gw *gwv1.Gateway, svc *corev1.Service, nodes []*corev1.Node) []gwv1.GatewayStatusAddress {
 	var ret []gwv1.GatewayStatusAddress
-	seen := sets.New[gwv1.GatewayStatusAddress]()
+	seen := sets.New[string]()
 
 	if svc != nil && svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
 		if len(svc.Status.LoadBalancer.Ingress) == 0 {
 			return nil
 		}
 		for _, ing := range svc.Status.LoadBalancer.Ingress {
-			if addr, ok := convertIngressAddr(ing); ok {
-				seen.Insert(addr)
+			if addr, ok := convertIngressAddr(ing); ok && !seen.Has(addressKey(addr)) {
+				seen.Insert(addressKey(addr))
 				ret = append(ret, addr)
 			}
 		}
+		sortAddresses(ret)
 
 		return ret
+	} else if svc != nil && len(nodes) > 0 {
+		for _, node := range nodes {
+			if addr, ok := convertNodeAddr(node); ok && !seen.Has(addressKey(addr)) {
+				seen.Insert(addressKey(addr))
+				ret = append(ret, addr)
+			}
+		}
+		sortAddresses(ret)
 	} else if svc != nil {
 		t := gwv1.IPAddressType
 		if len(svc.Spec.ClusterIPs) != 0 {
@@ -436,7 +636,7 @@ func getDesiredAddresses(gw *gwv1.Gateway, svc *corev1.Service) []gwv1.GatewaySt
 			Type:  specAddr.Type,
 			Value: specAddr.Value,
 		}
-		if !seen.Has(addr) {
+		if !seen.Has(addressKey(addr)) {
 			ret = append(ret, addr)
 		}
 	}
@@ -560,6 +760,43 @@ func convertIngressAddr(ing corev1.LoadBalancerIngress) (gwv1.GatewayStatusAddre
 	return gwv1.GatewayStatusAddress{}, false
 }
 
+// convertNodeAddr returns the external IP of the node, or its internal IP if it has none.
+func convertNodeAddr(node *corev1.Node) (gwv1.GatewayStatusAddress, bool) {
+	for _, addrType := range []corev1.NodeAddressType{corev1.NodeExternalIP, corev1.NodeInternalIP} {
+		for _, addr := range node.Status.Addresses {
+			if addr.Type == addrType && addr.Address != "" {
+				t := gwv1.IPAddressType
+				return gwv1.GatewayStatusAddress{
+					Type:  &t,
+					Value: addr.Address,
+				}, true
+			}
+		}
+	}
+	return gwv1.GatewayStatusAddress{}, false
+}
+
+// addressKey identifies an address by its type and value, as the type is a pointer.
+func addressKey(addr gwv1.GatewayStatusAddress) string {
+	return string(ptr.Deref(addr.Type, gwv1.IPAddressType)) + "/" + addr.Value
+}
+
+// sortAddresses sorts IP addresses before hostnames, and each by value.
+func sortAddresses(addrs []gwv1.GatewayStatusAddress) {
+	slices.SortFunc(addrs, func(a, b gwv1.GatewayStatusAddress) int {
+		isHostname := func(addr gwv1.GatewayStatusAddress) bool {
+			return ptr.Deref(addr.Type, gwv1.IPAddressType) == gwv1.HostnameAddressType
+		}
+		if isHostname(a) != isHostname(b) {
+			if isHostname(b) {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(a.Value, b.Value)
+	})
+}
+
 func fetchGatewaysByParametersRef(
 	gw *gwv1.Gateway,
 ) *types.NamespacedName {
@@ -0,0 +1,260 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/test"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient/fake"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/reports"
+)
+
+func TestGetDesiredAddresses(t *testing.T) {
+	ip := func(value string) gwv1.GatewayStatusAddress {
+		return gwv1.GatewayStatusAddress{Type: ptr.To(gwv1.IPAddressType), Value: value}
+	}
+	hostname := func(value string) gwv1.GatewayStatusAddress {
+		return gwv1.GatewayStatusAddress{Type: ptr.To(gwv1.HostnameAddressType), Value: value}
+	}
+	lbService := func(ingress ...corev1.LoadBalancerIngress) *corev1.Service {
+		return &corev1.Service{
+			Spec:   corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, ClusterIP: "10.0.0.1"},
+			Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: ingress}},
+		}
+	}
+	nodePortService := &corev1.Service{
+		Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort, ClusterIPs: []string{"10.0.0.1"}},
+	}
+	node := func(name string, addrs ...corev1.NodeAddress) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.NodeStatus{Addresses: addrs},
+		}
+	}
+	gw := &gwv1.Gateway{
+		Spec: gwv1.GatewaySpec{
+			Addresses: []gwv1.GatewaySpecAddress{{Type: ptr.To(gwv1.IPAddressType), Value: "192.168.0.10"}},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		svc   *corev1.Service
+		nodes []*corev1.Node
+		want  []gwv1.GatewayStatusAddress
+	}{
+		{
+			name: "load balancer without ingress",
+			svc:  lbService(),
+			want: nil,
+		},
+		{
+			name: "load balancer hostname",
+			svc:  lbService(corev1.LoadBalancerIngress{Hostname: "lb.elb.amazonaws.com"}),
+			want: []gwv1.GatewayStatusAddress{hostname("lb.elb.amazonaws.com")},
+		},
+		{
+			name: "load balancer ips and hostnames",
+			svc: lbService(
+				corev1.LoadBalancerIngress{Hostname: "b.elb.amazonaws.com"},
+				corev1.LoadBalancerIngress{IP: "203.0.113.2"},
+				corev1.LoadBalancerIngress{Hostname: "a.elb.amazonaws.com"},
+				corev1.LoadBalancerIngress{IP: "203.0.113.1"},
+				corev1.LoadBalancerIngress{IP: "203.0.113.1"},
+			),
+			want: []gwv1.GatewayStatusAddress{
+				ip("203.0.113.1"),
+				ip("203.0.113.2"),
+				hostname("a.elb.amazonaws.com"),
+				hostname("b.elb.amazonaws.com"),
+			},
+		},
+		{
+			name: "node port",
+			svc:  nodePortService,
+			want: []gwv1.GatewayStatusAddress{ip("10.0.0.1"), ip("192.168.0.10")},
+		},
+		{
+			name: "node port with node addresses",
+			svc:  nodePortService,
+			nodes: []*corev1.Node{
+				node("b",
+					corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "172.16.0.2"},
+					corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "198.51.100.2"},
+				),
+				node("c", corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "172.16.0.3"}),
+				node("a", corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "198.51.100.1"}),
+				node("d", corev1.NodeAddress{Type: corev1.NodeHostName, Address: "d"}),
+			},
+			want: []gwv1.GatewayStatusAddress{
+				ip("172.16.0.3"),
+				ip("198.51.100.1"),
+				ip("198.51.100.2"),
+				ip("192.168.0.10"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, getDesiredAddresses(gw, tt.svc, tt.nodes))
+		})
+	}
+
+	t.Run("stable when the load balancer ingress order changes", func(t *testing.T) {
+		ingress := []corev1.LoadBalancerIngress{
+			{IP: "203.0.113.1"},
+			{Hostname: "lb.elb.amazonaws.com"},
+			{IP: "203.0.113.2"},
+		}
+		want := getDesiredAddresses(gw, lbService(ingress...), nil)
+		slices.Reverse(ingress)
+		assert.Equal(t, want, getDesiredAddresses(gw, lbService(ingress...), nil))
+	})
+}
+
+func TestUpdateProgrammedStatus(t *testing.T) {
+	gateway := &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default", Generation: 1},
+		Spec:       gwv1.GatewaySpec{GatewayClassName: "kgateway"},
+	}
+	deployment := func(readyReplicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{wellknown.GatewayNameLabel: "gw"}},
+			},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: readyReplicas},
+		}
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gw-1",
+			Namespace: "default",
+			Labels:    map[string]string{wellknown.GatewayNameLabel: "gw"},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "kgateway-proxy",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+			}},
+		},
+	}
+
+	newReconciler := func(t *testing.T, objs ...client.Object) *gatewayReconciler {
+		cli := fake.NewClient(t, objs...)
+		r := &gatewayReconciler{
+			gwClient:         kclient.New[*gwv1.Gateway](cli),
+			deploymentClient: kclient.New[*appsv1.Deployment](cli),
+			podClient:        kclient.New[*corev1.Pod](cli),
+		}
+		cli.RunAndWait(test.NewStop(t))
+		return r
+	}
+	programmed := func(r *gatewayReconciler) *metav1.Condition {
+		gw := r.gwClient.Get(gateway.Name, gateway.Namespace)
+		return meta.FindStatusCondition(gw.Status.Conditions, string(gwv1.GatewayConditionProgrammed))
+	}
+
+	t.Run("no ready replicas", func(t *testing.T) {
+		r := newReconciler(t, gateway.DeepCopy(), deployment(0), pod)
+		require.NoError(t, r.updateProgrammedStatus(context.Background(), r.gwClient.Get("gw", "default"), []client.Object{deployment(0)}))
+
+		require.Eventually(t, func() bool {
+			return programmed(r) != nil
+		}, time.Second, 10*time.Millisecond)
+		cond := programmed(r)
+		assert.Equal(t, metav1.ConditionFalse, cond.Status)
+		assert.Equal(t, string(reports.GatewayReasonNoReadyReplicas), cond.Reason)
+		assert.Equal(t, "Deployment default/gw has no ready replicas: ImagePullBackOff", cond.Message)
+	})
+
+	t.Run("ready replicas", func(t *testing.T) {
+		gw := gateway.DeepCopy()
+		gw.Status.Conditions = []metav1.Condition{{
+			Type:   string(gwv1.GatewayConditionProgrammed),
+			Status: metav1.ConditionFalse,
+			Reason: string(reports.GatewayReasonNoReadyReplicas),
+		}}
+		r := newReconciler(t, gw, deployment(1))
+		require.NoError(t, r.updateProgrammedStatus(context.Background(), r.gwClient.Get("gw", "default"), []client.Object{deployment(1)}))
+
+		require.Eventually(t, func() bool {
+			return programmed(r).Status == metav1.ConditionTrue
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, string(gwv1.GatewayReasonProgrammed), programmed(r).Reason)
+	})
+
+	t.Run("ready replicas leave the condition to the reporter", func(t *testing.T) {
+		r := newReconciler(t, gateway.DeepCopy(), deployment(1))
+		require.NoError(t, r.updateProgrammedStatus(context.Background(), r.gwClient.Get("gw", "default"), []client.Object{deployment(1)}))
+
+		assert.Nil(t, programmed(r))
+	})
+
+	t.Run("self-managed", func(t *testing.T) {
+		r := newReconciler(t, gateway.DeepCopy())
+		require.NoError(t, r.updateProgrammedStatus(context.Background(), r.gwClient.Get("gw", "default"), nil))
+
+		assert.Nil(t, programmed(r))
+	})
+}
+
+func TestPodNotReadyReason(t *testing.T) {
+	tests := []struct {
+		name   string
+		status corev1.PodStatus
+		want   string
+	}{
+		{
+			name: "ready",
+			status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+			want: "",
+		},
+		{
+			name: "unschedulable",
+			status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: "Unschedulable"}},
+			},
+			want: "Unschedulable",
+		},
+		{
+			name: "crash loop",
+			status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				}},
+			},
+			want: "CrashLoopBackOff",
+		},
+		{
+			name: "failed init container",
+			status: corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{{
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}},
+				}},
+			},
+			want: "Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, podNotReadyReason(&corev1.Pod{Status: tt.status}))
+		})
+	}
+}
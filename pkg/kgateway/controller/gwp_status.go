@@ -0,0 +1,213 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilretry "k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	internaldeployer "github.com/kgateway-dev/kgateway/v2/pkg/kgateway/deployer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+)
+
+// gatewayParametersTracker tracks the GatewayParameters each reconciled Gateway is deployed with,
+// along with the error the deployment failed with, if any, to report them on the GatewayParameters status.
+type gatewayParametersTracker struct {
+	mu sync.Mutex
+	// results are the deployment errors of the Gateways, by GatewayParameters and Gateway
+	results map[types.NamespacedName]map[types.NamespacedName]error
+	// params are the GatewayParameters of the Gateways
+	params map[types.NamespacedName][]types.NamespacedName
+}
+
+func newGatewayParametersTracker() *gatewayParametersTracker {
+	return &gatewayParametersTracker{
+		results: map[types.NamespacedName]map[types.NamespacedName]error{},
+		params:  map[types.NamespacedName][]types.NamespacedName{},
+	}
+}
+
+// record records that the Gateway was deployed with params, failing with err if it isn't nil. It returns the
+// GatewayParameters whose status is affected: the ones the Gateway is deployed with and the ones it no longer is.
+func (t *gatewayParametersTracker) record(gw types.NamespacedName, params []types.NamespacedName, err error) []types.NamespacedName {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	affected := t.forgetLocked(gw)
+	for _, p := range params {
+		if t.results[p] == nil {
+			t.results[p] = map[types.NamespacedName]error{}
+		}
+		t.results[p][gw] = err
+		if !slices.Contains(t.params[gw], p) {
+			t.params[gw] = append(t.params[gw], p)
+		}
+		if !slices.Contains(affected, p) {
+			affected = append(affected, p)
+		}
+	}
+	return affected
+}
+
+// forget forgets the Gateway, e.g. when it is deleted, and returns the GatewayParameters it was deployed with.
+func (t *gatewayParametersTracker) forget(gw types.NamespacedName) []types.NamespacedName {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.forgetLocked(gw)
+}
+
+func (t *gatewayParametersTracker) forgetLocked(gw types.NamespacedName) []types.NamespacedName {
+	params := t.params[gw]
+	delete(t.params, gw)
+	for _, p := range params {
+		delete(t.results[p], gw)
+		if len(t.results[p]) == 0 {
+			delete(t.results, p)
+		}
+	}
+	return params
+}
+
+// gatewayResults returns the deployment errors of the Gateways deployed with the GatewayParameters.
+func (t *gatewayParametersTracker) gatewayResults(params types.NamespacedName) map[types.NamespacedName]error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return maps.Clone(t.results[params])
+}
+
+// buildGatewayParametersStatus builds the status of the GatewayParameters from the deployment errors of the
+// Gateways deployed with it. The conditions are cleared when no Gateway is deployed with the GatewayParameters.
+func buildGatewayParametersStatus(
+	gwp *kgateway.GatewayParameters,
+	results map[types.NamespacedName]error,
+) kgateway.GatewayParametersStatus {
+	status := kgateway.GatewayParametersStatus{}
+	if len(results) == 0 {
+		return status
+	}
+
+	gateways := slices.SortedFunc(maps.Keys(results), func(a, b types.NamespacedName) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	var invalid, overlayFailed []string
+	for _, gw := range gateways {
+		status.Gateways = append(status.Gateways, kgateway.GatewayParametersGatewayReference{
+			Name:      gw.Name,
+			Namespace: gw.Namespace,
+		})
+
+		err := results[gw]
+		if err == nil {
+			continue
+		}
+		var overlayErr *internaldeployer.OverlayError
+		var mutatorErr *internaldeployer.DeployerMutatorError
+		switch {
+		case errors.As(err, &overlayErr) && overlayErr.Parameters == client.ObjectKeyFromObject(gwp):
+			overlayFailed = append(overlayFailed, fmt.Sprintf("Gateway %s: %v", gw, overlayErr.Err))
+		case overlayErr != nil:
+			// the overlays of the other GatewayParameters of the Gateway failed to apply, after
+			// the resources were rendered with this one
+		case errors.As(err, &mutatorErr):
+			// a plugin failed to mutate the resources after they were rendered with this
+			// GatewayParameters, which is reported on the Gateway only
+		default:
+			invalid = append(invalid, fmt.Sprintf("Gateway %s: %v", gw, err))
+		}
+	}
+
+	// keep the last transition times of the conditions that didn't change
+	status.Conditions = slices.Clone(gwp.Status.Conditions)
+	accepted := metav1.Condition{
+		Type:               kgateway.GatewayParametersConditionAccepted,
+		Status:             metav1.ConditionTrue,
+		Reason:             kgateway.GatewayParametersReasonAccepted,
+		Message:            "The GatewayParameters is valid for all the Gateways that use it",
+		ObservedGeneration: gwp.Generation,
+	}
+	applied := metav1.Condition{
+		Type:               kgateway.GatewayParametersConditionApplied,
+		Status:             metav1.ConditionTrue,
+		Reason:             kgateway.GatewayParametersReasonApplied,
+		Message:            "The overlays were applied to the resources of all the Gateways that use the GatewayParameters",
+		ObservedGeneration: gwp.Generation,
+	}
+	if len(invalid) > 0 {
+		accepted.Status = metav1.ConditionFalse
+		accepted.Reason = kgateway.GatewayParametersReasonInvalid
+		accepted.Message = strings.Join(invalid, "; ")
+		applied.Status = metav1.ConditionFalse
+		applied.Reason = kgateway.GatewayParametersReasonNotAccepted
+		applied.Message = "The overlays were not applied to the Gateways the GatewayParameters is invalid for"
+	}
+	if len(overlayFailed) > 0 {
+		applied.Status = metav1.ConditionFalse
+		applied.Reason = kgateway.GatewayParametersReasonOverlayFailed
+		applied.Message = strings.Join(overlayFailed, "; ")
+	}
+	meta.SetStatusCondition(&status.Conditions, accepted)
+	meta.SetStatusCondition(&status.Conditions, applied)
+	return status
+}
+
+// updateGatewayParametersStatus updates the status of the GatewayParameters from the deployment errors of the
+// Gateways deployed with it.
+func (r *gatewayReconciler) updateGatewayParametersStatus(params types.NamespacedName) error {
+	err := utilretry.RetryOnConflict(utilretry.DefaultRetry, func() error {
+		gwp := r.gwParamClient.Get(params.Name, params.Namespace)
+		if gwp == nil {
+			// nothing to report on deleted GatewayParameters
+			return nil
+		}
+		status := buildGatewayParametersStatus(gwp, r.gwParamsTracker.gatewayResults(params))
+		if equality.Semantic.DeepEqual(status, gwp.Status) {
+			return nil
+		}
+		_, err := r.gwParamClient.UpdateStatus(&kgateway.GatewayParameters{
+			ObjectMeta: pluginsdk.CloneObjectMetaForStatus(gwp.ObjectMeta),
+			Status:     status,
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update status for GatewayParameters %s: %w", params, err)
+	}
+	return nil
+}
+
+// recordGatewayParameters records the GatewayParameters the Gateway is deployed with, failing with err if it isn't
+// nil, and updates their status.
+func (r *gatewayReconciler) recordGatewayParameters(gw *gwv1.Gateway, err error) {
+	if r.gwParamClient == nil {
+		return
+	}
+	var params []types.NamespacedName
+	for _, p := range r.gwParams.ResolveGatewayParameters(gw) {
+		params = append(params, client.ObjectKeyFromObject(p))
+	}
+	r.updateGatewayParametersStatuses(r.gwParamsTracker.record(client.ObjectKeyFromObject(gw), params, err))
+}
+
+// updateGatewayParametersStatuses updates the status of the GatewayParameters, logging the failures so that
+// they don't fail the reconciliation of the Gateway.
+func (r *gatewayReconciler) updateGatewayParametersStatuses(params []types.NamespacedName) {
+	if r.gwParamClient == nil {
+		return
+	}
+	for _, p := range params {
+		if err := r.updateGatewayParametersStatus(p); err != nil {
+			logger.Error("error updating GatewayParameters status", "ref", p, "error", err)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/test"
+	appsv1 "k8s.io/api/apps/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient/fake"
+	"github.com/kgateway-dev/kgateway/v2/pkg/deployer/strategicpatch"
+	internaldeployer "github.com/kgateway-dev/kgateway/v2/pkg/kgateway/deployer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+)
+
+func TestBuildGatewayParametersStatus(t *testing.T) {
+	gwp := &kgateway.GatewayParameters{
+		ObjectMeta: metav1.ObjectMeta{Name: "params", Namespace: "default", Generation: 3},
+		Spec: kgateway.GatewayParametersSpec{Kube: &kgateway.KubernetesProxyConfig{
+			GatewayParametersOverlays: kgateway.GatewayParametersOverlays{
+				DeploymentOverlay: &shared.KubernetesResourceOverlay{
+					Spec: &apiextensionsv1.JSON{Raw: []byte(`{"replicas":"many"}`)},
+				},
+			},
+		}},
+	}
+	_, patchErr := strategicpatch.NewOverlayApplierFromGatewayParameters(gwp).
+		ApplyOverlays([]client.Object{&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "gw"}}})
+	require.Error(t, patchErr)
+
+	gw1 := types.NamespacedName{Namespace: "ns1", Name: "gw"}
+	gw2 := types.NamespacedName{Namespace: "default", Name: "gw-b"}
+	gw3 := types.NamespacedName{Namespace: "default", Name: "gw-a"}
+
+	t.Run("malformed patch", func(t *testing.T) {
+		status := buildGatewayParametersStatus(gwp, map[types.NamespacedName]error{
+			gw1: &internaldeployer.OverlayError{Parameters: client.ObjectKeyFromObject(gwp), Err: patchErr},
+			gw2: nil,
+		})
+
+		accepted := meta.FindStatusCondition(status.Conditions, kgateway.GatewayParametersConditionAccepted)
+		require.NotNil(t, accepted)
+		assert.Equal(t, metav1.ConditionTrue, accepted.Status)
+		assert.Equal(t, int64(3), accepted.ObservedGeneration)
+
+		applied := meta.FindStatusCondition(status.Conditions, kgateway.GatewayParametersConditionApplied)
+		require.NotNil(t, applied)
+		assert.Equal(t, metav1.ConditionFalse, applied.Status)
+		assert.Equal(t, kgateway.GatewayParametersReasonOverlayFailed, applied.Reason)
+		assert.Equal(t, "Gateway ns1/gw: "+patchErr.Error(), applied.Message)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		status := buildGatewayParametersStatus(gwp, map[types.NamespacedName]error{
+			gw1: errors.New("failed to get helm values: invalid image"),
+		})
+
+		accepted := meta.FindStatusCondition(status.Conditions, kgateway.GatewayParametersConditionAccepted)
+		require.NotNil(t, accepted)
+		assert.Equal(t, metav1.ConditionFalse, accepted.Status)
+		assert.Equal(t, kgateway.GatewayParametersReasonInvalid, accepted.Reason)
+		assert.Equal(t, "Gateway ns1/gw: failed to get helm values: invalid image", accepted.Message)
+
+		applied := meta.FindStatusCondition(status.Conditions, kgateway.GatewayParametersConditionApplied)
+		require.NotNil(t, applied)
+		assert.Equal(t, metav1.ConditionFalse, applied.Status)
+		assert.Equal(t, kgateway.GatewayParametersReasonNotAccepted, applied.Reason)
+	})
+
+	t.Run("overlay failure of other parameters", func(t *testing.T) {
+		status := buildGatewayParametersStatus(gwp, map[types.NamespacedName]error{
+			gw1: &internaldeployer.OverlayError{Parameters: types.NamespacedName{Namespace: "ns1", Name: "other"}, Err: patchErr},
+		})
+
+		assert.True(t, meta.IsStatusConditionTrue(status.Conditions, kgateway.GatewayParametersConditionAccepted))
+		assert.True(t, meta.IsStatusConditionTrue(status.Conditions, kgateway.GatewayParametersConditionApplied))
+	})
+
+	t.Run("multiple gateways", func(t *testing.T) {
+		status := buildGatewayParametersStatus(gwp, map[types.NamespacedName]error{gw1: nil, gw2: nil, gw3: nil})
+
+		assert.Equal(t, []kgateway.GatewayParametersGatewayReference{
+			{Namespace: "default", Name: "gw-a"},
+			{Namespace: "default", Name: "gw-b"},
+			{Namespace: "ns1", Name: "gw"},
+		}, status.Gateways)
+		assert.True(t, meta.IsStatusConditionTrue(status.Conditions, kgateway.GatewayParametersConditionAccepted))
+		assert.True(t, meta.IsStatusConditionTrue(status.Conditions, kgateway.GatewayParametersConditionApplied))
+	})
+
+	t.Run("keeps the last transition time of unchanged conditions", func(t *testing.T) {
+		transitioned := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+		withStatus := gwp.DeepCopy()
+		withStatus.Status.Conditions = []metav1.Condition{{
+			Type:               kgateway.GatewayParametersConditionAccepted,
+			Status:             metav1.ConditionTrue,
+			Reason:             kgateway.GatewayParametersReasonAccepted,
+			LastTransitionTime: transitioned,
+		}}
+
+		status := buildGatewayParametersStatus(withStatus, map[types.NamespacedName]error{gw1: nil})
+
+		accepted := meta.FindStatusCondition(status.Conditions, kgateway.GatewayParametersConditionAccepted)
+		require.NotNil(t, accepted)
+		assert.Equal(t, transitioned, accepted.LastTransitionTime)
+	})
+
+	t.Run("no gateways", func(t *testing.T) {
+		assert.Equal(t, kgateway.GatewayParametersStatus{}, buildGatewayParametersStatus(gwp, nil))
+	})
+}
+
+func TestUpdateGatewayParametersStatus(t *testing.T) {
+	gwp := &kgateway.GatewayParameters{
+		ObjectMeta: metav1.ObjectMeta{Name: "params", Namespace: "default", Generation: 1},
+	}
+	other := &kgateway.GatewayParameters{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", Generation: 1},
+	}
+	cli := fake.NewClient(t, gwp, other)
+	r := &gatewayReconciler{
+		gwParamClient:   kclient.NewFilteredDelayed[*kgateway.GatewayParameters](cli, wellknown.GatewayParametersGVR, kclient.Filter{}),
+		gwParamsTracker: newGatewayParametersTracker(),
+	}
+	cli.RunAndWait(test.NewStop(t))
+
+	params := client.ObjectKeyFromObject(gwp)
+	gw1 := types.NamespacedName{Namespace: "default", Name: "gw-1"}
+	gw2 := types.NamespacedName{Namespace: "default", Name: "gw-2"}
+	status := func(p types.NamespacedName) kgateway.GatewayParametersStatus {
+		return r.gwParamClient.Get(p.Name, p.Namespace).Status
+	}
+	gateways := func(p types.NamespacedName) []kgateway.GatewayParametersGatewayReference {
+		return status(p).Gateways
+	}
+
+	// both Gateways are deployed with the GatewayParameters
+	r.updateGatewayParametersStatuses(r.gwParamsTracker.record(gw1, []types.NamespacedName{params}, nil))
+	r.updateGatewayParametersStatuses(r.gwParamsTracker.record(gw2, []types.NamespacedName{params}, nil))
+	require.Eventually(t, func() bool {
+		return len(gateways(params)) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []kgateway.GatewayParametersGatewayReference{
+		{Namespace: "default", Name: "gw-1"},
+		{Namespace: "default", Name: "gw-2"},
+	}, gateways(params))
+	assert.True(t, meta.IsStatusConditionTrue(status(params).Conditions, kgateway.GatewayParametersConditionApplied))
+
+	// gw-2 switches to the other GatewayParameters
+	r.updateGatewayParametersStatuses(r.gwParamsTracker.record(gw2, []types.NamespacedName{client.ObjectKeyFromObject(other)}, nil))
+	require.Eventually(t, func() bool {
+		return len(gateways(params)) == 1 && len(gateways(client.ObjectKeyFromObject(other))) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "gw-1", gateways(params)[0].Name)
+
+	// deleting the last Gateway that uses the GatewayParameters clears its status
+	r.updateGatewayParametersStatuses(r.gwParamsTracker.forget(gw1))
+	require.Eventually(t, func() bool {
+		s := status(params)
+		return len(s.Gateways) == 0 && len(s.Conditions) == 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Len(t, gateways(client.ObjectKeyFromObject(other)), 1)
+}
@@ -25,7 +25,9 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/plugins/waypoint"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/registry"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/health"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/proxy_syncer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/sharding"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/xds"
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
@@ -34,6 +36,7 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/eventutils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/utils/kubeutils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/utils/namespaces"
 	"github.com/kgateway-dev/kgateway/v2/pkg/validator"
@@ -42,6 +45,12 @@ import (
 type SetupOpts struct {
 	Cache envoycache.SnapshotCache
 
+	// ProxyStatus tracks the xDS versions acknowledged by the connected proxies
+	ProxyStatus *xds.ProxyStatusTracker
+
+	// SnapshotStore persists the xDS snapshots set in Cache, if snapshot persistence is enabled
+	SnapshotStore *xds.SnapshotStore
+
 	KrtDebugger *krt.DebugHandler
 
 	// static set of global Settings
@@ -51,6 +60,21 @@ type SetupOpts struct {
 	// Used by the Gateway controller to trigger reconciliation on cert changes
 	CertWatcher *certwatcher.CertWatcher
 
+	// Shard is the shard of Gateways served by this replica
+	Shard sharding.Shard
+
+	// SyncTracker records the sync state the readiness check and the admin server report
+	SyncTracker *health.SyncTracker
+
+	// InputStaleness detects informers lagging the API server, while which xDS snapshot updates are held back
+	InputStaleness *health.InputStalenessTracker
+
+	// EventRecorder emits Warning events on the objects whose translation or deployment fails
+	EventRecorder *eventutils.RateLimitedRecorder
+
+	// GatewayRenderer renders the resources of Gateways for the admin server
+	GatewayRenderer *GatewayRenderer
+
 	PprofBindAddress       string
 	HealthProbeBindAddress string
 	MetricsBindAddress     string
@@ -101,6 +125,7 @@ type ControllerBuilder struct {
 	cfg         StartConfig
 	mgr         ctrl.Manager
 	commoncol   *collections.CommonCollections
+	extensions  sdk.Plugin
 
 	ready atomic.Bool
 }
@@ -157,9 +182,19 @@ func NewControllerBuilder(ctx context.Context, cfg StartConfig) (*ControllerBuil
 			mergedPlugins,
 			cfg.CommonCollections,
 			cfg.SetupOpts.Cache,
+			cfg.SetupOpts.ProxyStatus,
+			cfg.SetupOpts.InputStaleness,
+			cfg.SetupOpts.EventRecorder,
 			cfg.Validator,
 		)
 		proxySyncer.Init(ctx, cfg.KrtOptions)
+		if cfg.SetupOpts.SyncTracker != nil {
+			cfg.SetupOpts.SyncTracker.Add(proxySyncer.SyncChecks()...)
+		}
+		if cfg.SetupOpts.SnapshotStore != nil {
+			// restored snapshots are dropped once the initial fresh snapshots were pushed
+			go cfg.SetupOpts.SnapshotStore.Run(ctx, proxySyncer.HasSynced, cfg.Manager.Elected())
+		}
 		if err := cfg.Manager.Add(proxySyncer); err != nil {
 			setupLog.Error(err, "unable to add proxySyncer runnable")
 			return nil, err
@@ -174,7 +209,7 @@ func NewControllerBuilder(ctx context.Context, cfg StartConfig) (*ControllerBuil
 			proxySyncer.ReportQueue(),
 			proxySyncer.BackendPolicyReportQueue(),
 			proxySyncer.CacheSyncs(),
-			cfg.StatusSyncerOptions...,
+			append([]proxy_syncer.StatusSyncerOption{proxy_syncer.WithEventRecorder(cfg.SetupOpts.EventRecorder)}, cfg.StatusSyncerOptions...)...,
 		)
 		if err := cfg.Manager.Add(statusSyncer); err != nil {
 			setupLog.Error(err, "unable to add statusSyncer runnable")
@@ -192,11 +227,17 @@ func NewControllerBuilder(ctx context.Context, cfg StartConfig) (*ControllerBuil
 		cfg:         cfg,
 		mgr:         cfg.Manager,
 		commoncol:   cfg.CommonCollections,
+		extensions:  mergedPlugins,
 	}
 
 	// wait for the ControllerBuilder to Start
 	// as well as its subcomponents (mainly ProxySyncer) before marking ready
-	if err := cfg.Manager.AddReadyzCheck("ready-ping", func(_ *http.Request) error {
+	if err := cfg.Manager.AddReadyzCheck("ready-ping", func(req *http.Request) error {
+		if cfg.SetupOpts.SyncTracker != nil {
+			if err := cfg.SetupOpts.SyncTracker.Ready(req); err != nil {
+				return err
+			}
+		}
 		if !cb.HasSynced() {
 			return errors.New("not synced")
 		}
@@ -204,22 +245,39 @@ func NewControllerBuilder(ctx context.Context, cfg StartConfig) (*ControllerBuil
 	}); err != nil {
 		setupLog.Error(err, "failed setting up healthz")
 	}
+	if len(mergedPlugins.ContributesReadinessChecks) > 0 {
+		checker := health.NewPluginChecker(health.ReadinessProbe, mergedPlugins.ContributesReadinessChecks)
+		if err := cfg.Manager.AddReadyzCheck("plugins", checker.Check); err != nil {
+			setupLog.Error(err, "failed setting up the readiness checks of the plugins")
+		}
+	}
+	if len(mergedPlugins.ContributesLivenessChecks) > 0 {
+		checker := health.NewPluginChecker(health.LivenessProbe, mergedPlugins.ContributesLivenessChecks)
+		if err := cfg.Manager.AddHealthzCheck("plugins", checker.Check); err != nil {
+			setupLog.Error(err, "failed setting up the liveness checks of the plugins")
+		}
+	}
 
 	return cb, nil
 }
 
 func pluginFactoryWithBuiltin(cfg StartConfig) extensions2.K8sGatewayExtensionsFactory {
 	return func(ctx context.Context, commoncol *collections.CommonCollections) sdk.Plugin {
+		// the extra plugins are built first, for the backend plugin to resolve Backends with
+		// their backend resolvers. They are still merged after the builtin ones.
+		var extraPlugins []sdk.Plugin
+		if cfg.ExtraPlugins != nil {
+			extraPlugins = cfg.ExtraPlugins(ctx, commoncol, cfg.SetupOpts.GlobalSettings.PolicyMerge)
+		}
 		plugins := registry.Plugins(
 			ctx,
 			commoncol,
 			*cfg.SetupOpts.GlobalSettings,
 			cfg.Validator,
+			registry.BackendResolvers(extraPlugins...),
 		)
 		plugins = append(plugins, krtcollections.NewBuiltinPlugin(ctx))
-		if cfg.ExtraPlugins != nil {
-			plugins = append(plugins, cfg.ExtraPlugins(ctx, commoncol, cfg.SetupOpts.GlobalSettings.PolicyMerge)...)
-		}
+		plugins = append(plugins, extraPlugins...)
 		return registry.MergePlugins(plugins...)
 	}
 }
@@ -229,10 +287,16 @@ func (c *ControllerBuilder) Build(ctx context.Context) error {
 
 	globalSettings := c.cfg.SetupOpts.GlobalSettings
 
+	shard := c.cfg.SetupOpts.Shard
 	xdsHost := globalSettings.XdsServiceHost
 	if xdsHost == "" {
+		xdsServiceName := globalSettings.XdsServiceName
+		if shard.Enabled() {
+			// proxies of the gateways owned by this shard connect to the shard's own xDS Service
+			xdsServiceName = sharding.XdsServiceName(xdsServiceName, shard.Ordinal)
+		}
 		xdsHost = kubeutils.ServiceFQDN(metav1.ObjectMeta{
-			Name:      globalSettings.XdsServiceName,
+			Name:      xdsServiceName,
 			Namespace: namespaces.GetPodNamespace(),
 		})
 	}
@@ -264,6 +328,11 @@ func (c *ControllerBuilder) Build(ctx context.Context) error {
 		GatewayClassName:         c.cfg.GatewayClassName,
 		WaypointGatewayClassName: c.cfg.WaypointGatewayClassName,
 		CertWatcher:              c.cfg.SetupOpts.CertWatcher,
+		Shard:                    shard,
+		EventRecorder:            c.cfg.SetupOpts.EventRecorder,
+		DeployerMutators:         c.extensions.ContributesDeployerMutators,
+		DeployerChartFragments:   c.extensions.ContributesDeployerChartFragments,
+		GatewayRenderer:          c.cfg.SetupOpts.GatewayRenderer,
 	}
 
 	setupLog.Info("creating base gateway controller")
@@ -4,14 +4,20 @@ import (
 	"embed"
 	"fmt"
 	"io/fs"
+	"path"
 	"path/filepath"
+	"regexp"
 
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
 
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
 	"github.com/kgateway-dev/kgateway/v2/pkg/version"
 )
 
+// chartFragmentNameRegex matches the names of the chart fragments, which must be usable as keys of the helm values.
+var chartFragmentNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
 func loadChart(fs embed.FS) (*chart.Chart, error) {
 	c, err := loadFs(fs)
 	if err != nil {
@@ -69,3 +75,30 @@ func loadFs(filesystem fs.FS) (*chart.Chart, error) {
 
 	return loader.LoadFiles(bufferedFiles)
 }
+
+// addChartFragments adds the templates of the chart fragments contributed by the plugins to the chart,
+// under templates/plugins/<name>/. It fails when fragments have the same name, as their values would
+// collide under `plugins.<name>`.
+func addChartFragments(c *chart.Chart, fragments []sdk.DeployerChartFragment) error {
+	names := make(map[string]bool, len(fragments))
+	for _, f := range fragments {
+		if !chartFragmentNameRegex.MatchString(f.Name) {
+			return fmt.Errorf("invalid deployer chart fragment name %q: must match %s", f.Name, chartFragmentNameRegex)
+		}
+		if names[f.Name] {
+			return fmt.Errorf("deployer chart fragment %q is contributed by more than one plugin", f.Name)
+		}
+		names[f.Name] = true
+
+		for name, data := range f.Templates {
+			if name == "" || path.Base(name) != name {
+				return fmt.Errorf("invalid template name %q of deployer chart fragment %q: must be a file name", name, f.Name)
+			}
+			c.Templates = append(c.Templates, &chart.File{
+				Name: path.Join("templates", "plugins", f.Name, name),
+				Data: data,
+			})
+		}
+	}
+	return nil
+}
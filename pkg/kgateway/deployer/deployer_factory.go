@@ -12,6 +12,11 @@ func NewGatewayDeployer(controllerName string, scheme *runtime.Scheme, client ap
 	if err != nil {
 		return nil, err
 	}
+	if gwParams != nil {
+		if err := addChartFragments(envoyChart, gwParams.inputs.DeployerChartFragments); err != nil {
+			return nil, err
+		}
+	}
 	return deployer.NewDeployer(
 		controllerName, scheme, client, envoyChart, gwParams, GatewayReleaseNameAndNamespace, opts...), nil
 }
@@ -10,6 +10,7 @@ import (
 	"helm.sh/helm/v3/pkg/chart"
 	"istio.io/istio/pkg/kube/kclient"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,6 +32,38 @@ var (
 	ErrNotFound = errors.New("resource not found")
 )
 
+// OverlayFailedReason is the reason of the events emitted on a GatewayParameters whose overlays fail to apply.
+const OverlayFailedReason = "OverlayFailed"
+
+// OverlayError is returned when the overlays of a GatewayParameters fail to apply to the resources of a Gateway.
+type OverlayError struct {
+	// Parameters is the GatewayParameters that defines the overlays
+	Parameters types.NamespacedName
+	Err        error
+}
+
+func (e *OverlayError) Error() string {
+	return fmt.Sprintf("failed to apply the overlays of GatewayParameters %s: %v", e.Parameters, e.Err)
+}
+
+func (e *OverlayError) Unwrap() error {
+	return e.Err
+}
+
+// DeployerMutatorError is returned when a deployer mutator contributed by a plugin fails to mutate the
+// resources of a Gateway.
+type DeployerMutatorError struct {
+	Err error
+}
+
+func (e *DeployerMutatorError) Error() string {
+	return fmt.Sprintf("failed to mutate the resources of the Gateway: %v", e.Err)
+}
+
+func (e *DeployerMutatorError) Unwrap() error {
+	return e.Err
+}
+
 func NewGatewayParameters(cli apiclient.Client, inputs *deployer.Inputs) *GatewayParameters {
 	gp := &GatewayParameters{
 		inputs: inputs,
@@ -99,7 +132,27 @@ func (gp *GatewayParameters) GetCacheSyncHandlers() []cache.InformerSynced {
 // It applies GatewayParameters overlays to the rendered objects.
 // When both GatewayClass and Gateway have parameters, the overlays
 // are applied in order: GatewayClass first, then Gateway on top.
+// The deployer mutators contributed by the plugins are then called on the objects of a Gateway.
 func (gp *GatewayParameters) PostProcessObjects(ctx context.Context, obj client.Object, rendered []client.Object) ([]client.Object, error) {
+	rendered, err := gp.applyOverlays(ctx, obj, rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	gw, ok := obj.(*gwv1.Gateway)
+	if !ok {
+		return rendered, nil
+	}
+	for _, mutate := range gp.inputs.DeployerMutators {
+		if err := mutate(gw, rendered); err != nil {
+			return nil, &DeployerMutatorError{Err: err}
+		}
+	}
+
+	return rendered, nil
+}
+
+func (gp *GatewayParameters) applyOverlays(ctx context.Context, obj client.Object, rendered []client.Object) ([]client.Object, error) {
 	// Check if override implements ObjectPostProcessor and delegate to it
 	if gp.helmValuesGeneratorOverride != nil {
 		if postProcessor, ok := gp.helmValuesGeneratorOverride.(deployer.ObjectPostProcessor); ok {
@@ -119,26 +172,51 @@ func (gp *GatewayParameters) PostProcessObjects(ctx context.Context, obj client.
 	resolved := gp.kgwParameters.resolveParametersForOverlays(gw)
 
 	// Apply overlays in order: GatewayClass first, then Gateway.
-	if resolved.gatewayClassGWP != nil {
-		applier := strategicpatch.NewOverlayApplierFromGatewayParameters(resolved.gatewayClassGWP)
-		var err error
-		rendered, err = applier.ApplyOverlays(rendered)
-		if err != nil {
-			return nil, err
+	for _, params := range []*kgateway.GatewayParameters{resolved.gatewayClassGWP, resolved.gatewayGWP} {
+		if params == nil {
+			continue
 		}
-	}
-	if resolved.gatewayGWP != nil {
-		applier := strategicpatch.NewOverlayApplierFromGatewayParameters(resolved.gatewayGWP)
+		applier := strategicpatch.NewOverlayApplierFromGatewayParameters(params)
 		var err error
 		rendered, err = applier.ApplyOverlays(rendered)
 		if err != nil {
-			return nil, err
+			// the overlay is reported on the GatewayParameters that defines it, rather than on the Gateway
+			gp.inputs.EventRecorder.Warningf(params, OverlayFailedReason,
+				"Failed to apply overlays to the resources of Gateway %s/%s: %v", gw.Namespace, gw.Name, err)
+			return nil, &OverlayError{Parameters: client.ObjectKeyFromObject(params), Err: err}
 		}
 	}
 
 	return rendered, nil
 }
 
+// ResolveGatewayParameters returns the GatewayParameters the resources of the Gateway are deployed with:
+// the ones of its GatewayClass first, then its own. It returns nothing when the values are generated by
+// an override, which doesn't consume GatewayParameters.
+func (gp *GatewayParameters) ResolveGatewayParameters(gw *gwv1.Gateway) []*kgateway.GatewayParameters {
+	if gp.helmValuesGeneratorOverride != nil || gp.kgwParameters == nil {
+		return nil
+	}
+	resolved := gp.kgwParameters.resolveParametersForOverlays(gw)
+	var params []*kgateway.GatewayParameters
+	for _, p := range []*kgateway.GatewayParameters{resolved.gatewayClassGWP, resolved.gatewayGWP} {
+		if p != nil {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// EffectiveGatewayParameters returns the GatewayParameters the values of the Gateway are generated from:
+// the defaults merged with the GatewayParameters of its GatewayClass, then with its own. It returns
+// nothing when the values are generated by an override, which doesn't consume GatewayParameters.
+func (gp *GatewayParameters) EffectiveGatewayParameters(gw *gwv1.Gateway) (*kgateway.GatewayParameters, error) {
+	if gp.helmValuesGeneratorOverride != nil || gp.kgwParameters == nil {
+		return nil, nil
+	}
+	return gp.kgwParameters.getGatewayParametersForGateway(gw)
+}
+
 func GatewayReleaseNameAndNamespace(obj client.Object) (string, string) {
 	// A helm release is never installed, only a template is generated, so the name doesn't matter
 	// Use a hard-coded name to avoid going over the 53 character name limit
@@ -371,6 +449,21 @@ func (k *kgatewayParameters) getValues(gw *gwv1.Gateway, gwParam *kgateway.Gatew
 		Gateway: gtw,
 	}
 
+	// add the values of the chart fragments contributed by the plugins
+	for _, f := range k.inputs.DeployerChartFragments {
+		if f.Values == nil {
+			continue
+		}
+		fragmentVals, err := f.Values(gw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the values of deployer chart fragment %s: %w", f.Name, err)
+		}
+		if vals.Plugins == nil {
+			vals.Plugins = map[string]any{}
+		}
+		vals.Plugins[f.Name] = fragmentVals
+	}
+
 	// Inject xDS CA certificate into Helm values if TLS is enabled
 	if k.inputs.ControlPlane.XdsTLS {
 		if err := injectXdsCACertificate(k.inputs.ControlPlane.XdsTlsCaPath, vals); err != nil {
@@ -461,6 +554,11 @@ func (k *kgatewayParameters) getValues(gw *gwv1.Gateway, gwParam *kgateway.Gatew
 		}
 	}
 
+	gateway.Admin, err = deployer.GetAdminValues(envoyContainerConfig.GetBootstrap().GetAdmin())
+	if err != nil {
+		return nil, err
+	}
+
 	gateway.Resources = envoyContainerConfig.GetResources()
 	gateway.SecurityContext = envoyContainerConfig.GetSecurityContext()
 	gateway.Image = deployer.GetImageValues(envoyContainerConfig.GetImage())
@@ -472,7 +570,10 @@ func (k *kgatewayParameters) getValues(gw *gwv1.Gateway, gwParam *kgateway.Gatew
 	gateway.SdsContainer = deployer.GetSdsContainerValues(sdsContainerConfig)
 	gateway.IstioContainer = deployer.GetIstioContainerValues(istioContainerConfig)
 
-	gateway.Stats = deployer.GetStatsValues(statsConfig)
+	gateway.Stats, err = deployer.GetStatsValues(statsConfig)
+	if err != nil {
+		return nil, err
+	}
 
 	return vals, nil
 }
@@ -2,14 +2,18 @@ package deployer
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"istio.io/istio/pkg/kube/krt/krttest"
 	"istio.io/istio/pkg/test"
 	"istio.io/istio/pkg/util/smallset"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/ptr"
@@ -18,6 +22,7 @@ import (
 
 	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
 	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient/fake"
 	"github.com/kgateway-dev/kgateway/v2/pkg/deployer"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
@@ -25,6 +30,8 @@ import (
 	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
+	"github.com/kgateway-dev/kgateway/v2/pkg/schemes"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/eventutils"
 )
 
 const (
@@ -111,6 +118,140 @@ func TestShouldUseExtendedGatewayParameters(t *testing.T) {
 	assert.Contains(t, vals, "testHelmValuesGenerator")
 }
 
+func TestPostProcessObjectsOverlayFailureEvent(t *testing.T) {
+	gwc := defaultGatewayClass()
+	gwParams := emptyGatewayParameters()
+	gwParams.Spec.Kube = &kgateway.KubernetesProxyConfig{
+		GatewayParametersOverlays: kgateway.GatewayParametersOverlays{
+			DeploymentOverlay: &shared.KubernetesResourceOverlay{
+				Spec: &apiextensionsv1.JSON{Raw: []byte(`{"replicas":"many"}`)},
+			},
+		},
+	}
+	gw := &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: defaultNamespace},
+		Spec:       gwv1.GatewaySpec{GatewayClassName: wellknown.DefaultGatewayClassName},
+	}
+
+	ctx := t.Context()
+	recorder := &eventutils.FakeRecorder{}
+	inputs := defaultInputs(t, gwc, gw)
+	inputs.EventRecorder = eventutils.NewRateLimitedRecorder(recorder, schemes.DefaultScheme(), time.Minute)
+	fakeClient := fake.NewClient(t, gwc, gwParams)
+	gwp := NewGatewayParameters(fakeClient, inputs)
+	fakeClient.RunAndWait(ctx.Done())
+
+	for range 2 {
+		_, err := gwp.PostProcessObjects(ctx, gw, []client.Object{&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}})
+		var overlayErr *OverlayError
+		require.ErrorAs(t, err, &overlayErr)
+		assert.Equal(t, client.ObjectKeyFromObject(gwParams), overlayErr.Parameters)
+	}
+
+	// the persistent failure is reported once per interval, on the GatewayParameters defining the overlay
+	events := recorder.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, corev1.EventTypeWarning, events[0].Type)
+	assert.Equal(t, OverlayFailedReason, events[0].Reason)
+	assert.Equal(t, wellknown.GatewayParametersGVK.Kind, events[0].InvolvedObject.Kind)
+	assert.Equal(t, gwParams.Namespace, events[0].InvolvedObject.Namespace)
+	assert.Equal(t, gwParams.Name, events[0].InvolvedObject.Name)
+	assert.Contains(t, events[0].Message, "Gateway default/foo")
+}
+
+func TestPostProcessObjectsDeployerMutators(t *testing.T) {
+	gwc := defaultGatewayClass()
+	gwParams := emptyGatewayParameters()
+	gwParams.Spec.Kube = &kgateway.KubernetesProxyConfig{
+		GatewayParametersOverlays: kgateway.GatewayParametersOverlays{
+			DeploymentOverlay: &shared.KubernetesResourceOverlay{
+				Metadata: &shared.ObjectMetadata{Labels: map[string]string{"team": "overlay"}},
+			},
+		},
+	}
+	gw := &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: defaultNamespace},
+		Spec:       gwv1.GatewaySpec{GatewayClassName: wellknown.DefaultGatewayClassName},
+	}
+	setLabel := func(key, value string) sdk.DeployerMutator {
+		return func(gw *gwv1.Gateway, objs []client.Object) error {
+			for _, obj := range objs {
+				labels := obj.GetLabels()
+				if labels == nil {
+					labels = map[string]string{}
+				}
+				labels[key] = value
+				obj.SetLabels(labels)
+			}
+			return nil
+		}
+	}
+	rendered := func() []client.Object {
+		return []client.Object{
+			&appsv1.Deployment{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			},
+			&corev1.Service{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		mutators []sdk.DeployerMutator
+		want     map[string]string
+		wantErr  string
+	}{
+		{
+			name:     "mutates all objects after the overlays",
+			mutators: []sdk.DeployerMutator{setLabel("team", "platform")},
+			want:     map[string]string{"team": "platform"},
+		},
+		{
+			name:     "called in order",
+			mutators: []sdk.DeployerMutator{setLabel("team", "first"), setLabel("team", "second")},
+			want:     map[string]string{"team": "second"},
+		},
+		{
+			name: "error fails the deployment",
+			mutators: []sdk.DeployerMutator{
+				func(gw *gwv1.Gateway, objs []client.Object) error {
+					return fmt.Errorf("no sidecar for Gateway %s", gw.Name)
+				},
+				setLabel("team", "unreachable"),
+			},
+			wantErr: "failed to mutate the resources of the Gateway: no sidecar for Gateway foo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			inputs := defaultInputs(t, gwc, gw)
+			inputs.DeployerMutators = tt.mutators
+			fakeClient := fake.NewClient(t, gwc, gwParams)
+			gwp := NewGatewayParameters(fakeClient, inputs)
+			fakeClient.RunAndWait(ctx.Done())
+
+			objs, err := gwp.PostProcessObjects(ctx, gw, rendered())
+			if tt.wantErr != "" {
+				var mutatorErr *DeployerMutatorError
+				require.ErrorAs(t, err, &mutatorErr)
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, objs, 2)
+			for _, obj := range objs {
+				assert.Equal(t, tt.want, obj.GetLabels())
+			}
+		})
+	}
+}
+
 func defaultGatewayClass() *gwv1.GatewayClass {
 	return &gwv1.GatewayClass{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,83 @@
+package describe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/controller"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/xds"
+)
+
+const adminRequestTimeout = 10 * time.Second
+
+// AdminClient reads the debug endpoints of the kgateway admin server, e.g. through a port-forward.
+type AdminClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+var _ ControlPlane = &AdminClient{}
+
+// NewAdminClient returns a client of the admin server at the address, e.g. localhost:9095.
+func NewAdminClient(address string) *AdminClient {
+	return &AdminClient{
+		baseURL: "http://" + address,
+		client:  &http.Client{Timeout: adminRequestTimeout},
+	}
+}
+
+func (c *AdminClient) RenderedGateway(ctx context.Context, nn types.NamespacedName) (*controller.RenderedGateway, error) {
+	rendered := &controller.RenderedGateway{}
+	query := url.Values{"gateway": []string{nn.String()}}
+	if err := c.get(ctx, "/gateways/rendered?"+query.Encode(), rendered); err != nil {
+		return nil, err
+	}
+	return rendered, nil
+}
+
+func (c *AdminClient) ProxyStatuses(ctx context.Context) ([]xds.ProxyStatus, error) {
+	var statuses []xds.ProxyStatus
+	if err := c.get(ctx, "/snapshots/xds/proxies", &statuses); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+func (c *AdminClient) get(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", path, resp.Status)
+	}
+	return decodeAdminResponse(body, out)
+}
+
+// decodeAdminResponse decodes the payload of an admin endpoint, which reports its errors as {"error": "..."}.
+func decodeAdminResponse(body []byte, out any) error {
+	var errResp struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &errResp) == nil && errResp.Error != "" {
+		return errors.New(errResp.Error)
+	}
+	return json.Unmarshal(body, out)
+}
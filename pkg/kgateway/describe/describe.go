@@ -0,0 +1,343 @@
+// Package describe assembles everything known about a Gateway from the cluster and from the debug
+// endpoints of the control plane, for support tooling.
+package describe
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/controller"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/xds"
+)
+
+// ControlPlane is the debug API of the kgateway control plane.
+type ControlPlane interface {
+	// RenderedGateway returns the effective GatewayParameters and the objects rendered for the Gateway
+	RenderedGateway(ctx context.Context, nn types.NamespacedName) (*controller.RenderedGateway, error)
+	// ProxyStatuses returns the xDS versions acknowledged by the connected proxies
+	ProxyStatuses(ctx context.Context) ([]xds.ProxyStatus, error)
+}
+
+// Description is everything known about a Gateway. Its JSON and YAML forms are a stable interface:
+// fields may be added, but not renamed or removed.
+type Description struct {
+	Gateway Gateway `json:"gateway"`
+	// Parameters are the effective GatewayParameters, i.e. the defaults merged with the GatewayParameters
+	// of the GatewayClass and of the Gateway
+	Parameters *kgateway.GatewayParameters `json:"parameters,omitempty"`
+	// RenderedObjects are the objects the deployer applies for the Gateway
+	RenderedObjects []*unstructured.Unstructured `json:"renderedObjects,omitempty"`
+	Routes          []Route                      `json:"routes"`
+	Policies        []Policy                     `json:"policies"`
+	// Proxies are the connected proxies of the Gateway and the xDS versions they acknowledged,
+	// null when they can't be read from the control plane
+	Proxies []xds.ProxyStatus `json:"proxies"`
+	// Warnings list the data that couldn't be collected
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+type Gateway struct {
+	Namespace        string                      `json:"namespace"`
+	Name             string                      `json:"name"`
+	GatewayClassName string                      `json:"gatewayClassName"`
+	Addresses        []gwv1.GatewayStatusAddress `json:"addresses,omitempty"`
+	Conditions       []metav1.Condition          `json:"conditions,omitempty"`
+	Listeners        []gwv1.ListenerStatus       `json:"listeners,omitempty"`
+}
+
+// Route is a route attached to the Gateway, directly or through one of its ListenerSets.
+type Route struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Parent is the Gateway or ListenerSet the conditions are reported for, as <kind>/<namespace>/<name>
+	Parent     string             `json:"parent"`
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// Policy is a policy reporting the Gateway as an ancestor.
+type Policy struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// MergeOutcome is the reason of the Attached condition, e.g. Merged or Overridden
+	MergeOutcome string             `json:"mergeOutcome,omitempty"`
+	Conditions   []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// Describer describes Gateways from the cluster and, when reachable, the control plane.
+type Describer struct {
+	client       client.Reader
+	controlPlane ControlPlane
+}
+
+func NewDescriber(c client.Reader, controlPlane ControlPlane) *Describer {
+	return &Describer{
+		client:       c,
+		controlPlane: controlPlane,
+	}
+}
+
+// DescribeGateway describes the Gateway. It only fails when the Gateway can't be read: the data that
+// can't be collected otherwise, e.g. because the control plane is unreachable, is reported as warnings.
+func (d *Describer) DescribeGateway(ctx context.Context, nn types.NamespacedName) (*Description, error) {
+	gw := &gwv1.Gateway{}
+	if err := d.client.Get(ctx, nn, gw); err != nil {
+		return nil, fmt.Errorf("failed to get Gateway %s: %w", nn, err)
+	}
+
+	desc := &Description{
+		Gateway: Gateway{
+			Namespace:        gw.Namespace,
+			Name:             gw.Name,
+			GatewayClassName: string(gw.Spec.GatewayClassName),
+			Addresses:        gw.Status.Addresses,
+			Conditions:       gw.Status.Conditions,
+			Listeners:        gw.Status.Listeners,
+		},
+		Routes:   []Route{},
+		Policies: []Policy{},
+	}
+
+	parents, err := d.parentsOf(ctx, gw)
+	if err != nil {
+		desc.Warnings = append(desc.Warnings, fmt.Sprintf("failed to list ListenerSets: %v", err))
+	}
+	d.describeRoutes(ctx, desc, parents)
+	d.describePolicies(ctx, desc, gw)
+	d.describeControlPlane(ctx, desc, nn)
+
+	return desc, nil
+}
+
+type parentKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+func (k parentKey) String() string {
+	return fmt.Sprintf("%s/%s/%s", k.kind, k.namespace, k.name)
+}
+
+// parentsOf returns the Gateway and the ListenerSets attached to it, which routes can be attached to.
+func (d *Describer) parentsOf(ctx context.Context, gw *gwv1.Gateway) ([]parentKey, error) {
+	parents := []parentKey{{kind: wellknown.GatewayKind, namespace: gw.Namespace, name: gw.Name}}
+
+	var listenerSets gwv1.ListenerSetList
+	if err := d.client.List(ctx, &listenerSets); err != nil {
+		if meta.IsNoMatchError(err) {
+			return parents, nil
+		}
+		return parents, err
+	}
+	for _, ls := range listenerSets.Items {
+		ref := ls.Spec.ParentRef
+		if ref.Kind != nil && string(*ref.Kind) != wellknown.GatewayKind {
+			continue
+		}
+		namespace := ls.Namespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+		if namespace == gw.Namespace && string(ref.Name) == gw.Name {
+			parents = append(parents, parentKey{kind: wellknown.ListenerSetKind, namespace: ls.Namespace, name: ls.Name})
+		}
+	}
+	return parents, nil
+}
+
+// statusList is a list of objects of a kind, whose statuses are read once it is listed.
+type statusList[S any] struct {
+	kind     string
+	list     client.ObjectList
+	statuses func() []objectStatus[S]
+}
+
+type objectStatus[S any] struct {
+	meta   metav1.ObjectMeta
+	status S
+}
+
+func objectStatuses[T, S any](items []T, get func(T) (metav1.ObjectMeta, S)) []objectStatus[S] {
+	statuses := make([]objectStatus[S], 0, len(items))
+	for _, item := range items {
+		m, s := get(item)
+		statuses = append(statuses, objectStatus[S]{meta: m, status: s})
+	}
+	return statuses
+}
+
+func (d *Describer) describeRoutes(ctx context.Context, desc *Description, parents []parentKey) {
+	var (
+		httpRoutes gwv1.HTTPRouteList
+		grpcRoutes gwv1.GRPCRouteList
+		tlsRoutes  gwv1.TLSRouteList
+		tcpRoutes  gwv1a2.TCPRouteList
+	)
+	routeLists := []statusList[gwv1.RouteStatus]{
+		{wellknown.HTTPRouteKind, &httpRoutes, func() []objectStatus[gwv1.RouteStatus] {
+			return objectStatuses(httpRoutes.Items, func(r gwv1.HTTPRoute) (metav1.ObjectMeta, gwv1.RouteStatus) {
+				return r.ObjectMeta, r.Status.RouteStatus
+			})
+		}},
+		{wellknown.GRPCRouteKind, &grpcRoutes, func() []objectStatus[gwv1.RouteStatus] {
+			return objectStatuses(grpcRoutes.Items, func(r gwv1.GRPCRoute) (metav1.ObjectMeta, gwv1.RouteStatus) {
+				return r.ObjectMeta, r.Status.RouteStatus
+			})
+		}},
+		{wellknown.TLSRouteKind, &tlsRoutes, func() []objectStatus[gwv1.RouteStatus] {
+			return objectStatuses(tlsRoutes.Items, func(r gwv1.TLSRoute) (metav1.ObjectMeta, gwv1.RouteStatus) {
+				return r.ObjectMeta, r.Status.RouteStatus
+			})
+		}},
+		{wellknown.TCPRouteKind, &tcpRoutes, func() []objectStatus[gwv1.RouteStatus] {
+			return objectStatuses(tcpRoutes.Items, func(r gwv1a2.TCPRoute) (metav1.ObjectMeta, gwv1.RouteStatus) {
+				return r.ObjectMeta, r.Status.RouteStatus
+			})
+		}},
+	}
+
+	for _, rl := range routeLists {
+		if err := d.client.List(ctx, rl.list); err != nil {
+			// the CRDs of the experimental route kinds may not be installed
+			if !meta.IsNoMatchError(err) {
+				desc.Warnings = append(desc.Warnings, fmt.Sprintf("failed to list %ss: %v", rl.kind, err))
+			}
+			continue
+		}
+		for _, route := range rl.statuses() {
+			for _, parent := range route.status.Parents {
+				key := routeParentKey(route.meta.Namespace, parent.ParentRef)
+				if !slices.Contains(parents, key) {
+					continue
+				}
+				desc.Routes = append(desc.Routes, Route{
+					Kind:       rl.kind,
+					Namespace:  route.meta.Namespace,
+					Name:       route.meta.Name,
+					Parent:     key.String(),
+					Conditions: parent.Conditions,
+				})
+			}
+		}
+	}
+}
+
+func routeParentKey(routeNamespace string, ref gwv1.ParentReference) parentKey {
+	key := parentKey{kind: wellknown.GatewayKind, namespace: routeNamespace, name: string(ref.Name)}
+	if ref.Kind != nil {
+		key.kind = string(*ref.Kind)
+	}
+	if ref.Namespace != nil {
+		key.namespace = string(*ref.Namespace)
+	}
+	return key
+}
+
+func (d *Describer) describePolicies(ctx context.Context, desc *Description, gw *gwv1.Gateway) {
+	var (
+		trafficPolicies       kgateway.TrafficPolicyList
+		listenerPolicies      kgateway.ListenerPolicyList
+		httpListenerPolicies  kgateway.HTTPListenerPolicyList
+		backendConfigPolicies kgateway.BackendConfigPolicyList
+	)
+	policyLists := []statusList[gwv1.PolicyStatus]{
+		{wellknown.TrafficPolicyGVK.Kind, &trafficPolicies, func() []objectStatus[gwv1.PolicyStatus] {
+			return objectStatuses(trafficPolicies.Items, func(p kgateway.TrafficPolicy) (metav1.ObjectMeta, gwv1.PolicyStatus) {
+				return p.ObjectMeta, p.Status
+			})
+		}},
+		{wellknown.ListenerPolicyGVK.Kind, &listenerPolicies, func() []objectStatus[gwv1.PolicyStatus] {
+			return objectStatuses(listenerPolicies.Items, func(p kgateway.ListenerPolicy) (metav1.ObjectMeta, gwv1.PolicyStatus) {
+				return p.ObjectMeta, p.Status
+			})
+		}},
+		{wellknown.HTTPListenerPolicyGVK.Kind, &httpListenerPolicies, func() []objectStatus[gwv1.PolicyStatus] {
+			return objectStatuses(httpListenerPolicies.Items, func(p kgateway.HTTPListenerPolicy) (metav1.ObjectMeta, gwv1.PolicyStatus) {
+				return p.ObjectMeta, p.Status
+			})
+		}},
+		{wellknown.BackendConfigPolicyGVK.Kind, &backendConfigPolicies, func() []objectStatus[gwv1.PolicyStatus] {
+			return objectStatuses(backendConfigPolicies.Items, func(p kgateway.BackendConfigPolicy) (metav1.ObjectMeta, gwv1.PolicyStatus) {
+				return p.ObjectMeta, p.Status
+			})
+		}},
+	}
+
+	for _, pl := range policyLists {
+		if err := d.client.List(ctx, pl.list); err != nil {
+			desc.Warnings = append(desc.Warnings, fmt.Sprintf("failed to list %ss: %v", pl.kind, err))
+			continue
+		}
+		for _, policy := range pl.statuses() {
+			for _, ancestor := range policy.status.Ancestors {
+				if !refersToGateway(policy.meta.Namespace, ancestor.AncestorRef, gw) {
+					continue
+				}
+				p := Policy{
+					Kind:       pl.kind,
+					Namespace:  policy.meta.Namespace,
+					Name:       policy.meta.Name,
+					Conditions: ancestor.Conditions,
+				}
+				if attached := meta.FindStatusCondition(ancestor.Conditions, string(shared.PolicyConditionAttached)); attached != nil {
+					p.MergeOutcome = attached.Reason
+				}
+				desc.Policies = append(desc.Policies, p)
+			}
+		}
+	}
+}
+
+func refersToGateway(policyNamespace string, ref gwv1.ParentReference, gw *gwv1.Gateway) bool {
+	key := routeParentKey(policyNamespace, ref)
+	return key.kind == wellknown.GatewayKind && key.namespace == gw.Namespace && key.name == gw.Name
+}
+
+func (d *Describer) describeControlPlane(ctx context.Context, desc *Description, nn types.NamespacedName) {
+	if d.controlPlane == nil {
+		desc.Warnings = append(desc.Warnings, "control plane not configured: parameters, rendered objects and proxies are omitted")
+		return
+	}
+
+	rendered, err := d.controlPlane.RenderedGateway(ctx, nn)
+	if err != nil {
+		desc.Warnings = append(desc.Warnings, fmt.Sprintf("failed to get rendered gateway from the control plane, parameters and rendered objects are omitted: %v", err))
+	} else {
+		desc.Parameters = rendered.Parameters
+		desc.RenderedObjects = rendered.Objects
+	}
+
+	proxies, err := d.controlPlane.ProxyStatuses(ctx)
+	if err != nil {
+		desc.Warnings = append(desc.Warnings, fmt.Sprintf("failed to get proxy statuses from the control plane, proxies are omitted: %v", err))
+		return
+	}
+	desc.Proxies = []xds.ProxyStatus{}
+	for _, p := range proxies {
+		if servesGateway(p.CacheKey, nn) {
+			desc.Proxies = append(desc.Proxies, p)
+		}
+	}
+}
+
+// servesGateway returns whether the xDS cache key, in the <owner>~<namespace>~<name> format optionally
+// followed by the unique client segments, is the one of the Gateway.
+func servesGateway(cacheKey string, nn types.NamespacedName) bool {
+	parts := strings.SplitN(cacheKey, xds.KeyDelimiter, 4)
+	return len(parts) >= 3 && parts[1] == nn.Namespace && parts[2] == nn.Name
+}
@@ -0,0 +1,294 @@
+package describe
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/controller"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/xds"
+	"github.com/kgateway-dev/kgateway/v2/pkg/schemes"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/envutils"
+)
+
+var gwNN = types.NamespacedName{Namespace: "default", Name: "gw"}
+
+type fakeControlPlane struct {
+	rendered    *controller.RenderedGateway
+	renderedErr error
+	proxies     []xds.ProxyStatus
+	proxiesErr  error
+}
+
+func (f *fakeControlPlane) RenderedGateway(_ context.Context, _ types.NamespacedName) (*controller.RenderedGateway, error) {
+	return f.rendered, f.renderedErr
+}
+
+func (f *fakeControlPlane) ProxyStatuses(_ context.Context) ([]xds.ProxyStatus, error) {
+	return f.proxies, f.proxiesErr
+}
+
+func accepted(reason string) []metav1.Condition {
+	return []metav1.Condition{{Type: "Accepted", Status: metav1.ConditionTrue, Reason: reason}}
+}
+
+func clusterObjects() []client.Object {
+	return []client.Object{
+		&gwv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw"},
+			Spec:       gwv1.GatewaySpec{GatewayClassName: "kgateway"},
+			Status: gwv1.GatewayStatus{
+				Conditions: accepted("Accepted"),
+				Listeners: []gwv1.ListenerStatus{{
+					Name:           "http",
+					AttachedRoutes: 1,
+					Conditions:     accepted("Accepted"),
+				}},
+			},
+		},
+		&gwv1.ListenerSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "ls"},
+			Spec: gwv1.ListenerSetSpec{
+				ParentRef: gwv1.ParentGatewayReference{Name: "gw", Namespace: new(gwv1.Namespace("default"))},
+			},
+		},
+		&gwv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "route"},
+			Status: gwv1.HTTPRouteStatus{RouteStatus: gwv1.RouteStatus{Parents: []gwv1.RouteParentStatus{
+				{ParentRef: gwv1.ParentReference{Name: "gw"}, Conditions: accepted("Accepted")},
+				{ParentRef: gwv1.ParentReference{Name: "other-gw"}, Conditions: accepted("Accepted")},
+			}}},
+		},
+		&gwv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "ls-route"},
+			Status: gwv1.HTTPRouteStatus{RouteStatus: gwv1.RouteStatus{Parents: []gwv1.RouteParentStatus{{
+				ParentRef:  gwv1.ParentReference{Kind: new(gwv1.Kind(wellknown.ListenerSetKind)), Name: "ls"},
+				Conditions: accepted("Accepted"),
+			}}}},
+		},
+		&gwv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "unrelated"},
+			Status: gwv1.HTTPRouteStatus{RouteStatus: gwv1.RouteStatus{Parents: []gwv1.RouteParentStatus{{
+				ParentRef:  gwv1.ParentReference{Name: "gw"},
+				Conditions: accepted("Accepted"),
+			}}}},
+		},
+		&kgateway.TrafficPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "policy"},
+			Status: gwv1.PolicyStatus{Ancestors: []gwv1.PolicyAncestorStatus{{
+				AncestorRef: gwv1.ParentReference{Name: "gw"},
+				Conditions: []metav1.Condition{
+					{Type: "Accepted", Status: metav1.ConditionTrue, Reason: "Valid"},
+					{Type: "Attached", Status: metav1.ConditionTrue, Reason: "Merged"},
+				},
+			}}},
+		},
+	}
+}
+
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	return fake.NewClientBuilder().WithScheme(schemes.GatewayScheme()).WithObjects(objs...).Build()
+}
+
+func TestDescribeGateway(t *testing.T) {
+	deployment := &unstructured.Unstructured{}
+	deployment.SetKind("Deployment")
+	deployment.SetNamespace("default")
+	deployment.SetName("gw")
+	gwProxy := xds.ProxyStatus{
+		CacheKey:      wellknown.GatewayApiProxyValue + "~default~gw",
+		PodName:       "gw-abc",
+		AckedVersions: map[string]string{"listeners": "1"},
+	}
+	controlPlane := &fakeControlPlane{
+		rendered: &controller.RenderedGateway{
+			Parameters: &kgateway.GatewayParameters{ObjectMeta: metav1.ObjectMeta{Name: "kgateway"}},
+			Objects:    []*unstructured.Unstructured{deployment},
+		},
+		proxies: []xds.ProxyStatus{
+			gwProxy,
+			{CacheKey: wellknown.GatewayApiProxyValue + "~default~other-gw", PodName: "other-gw-abc"},
+		},
+	}
+
+	desc, err := NewDescriber(newFakeClient(t, clusterObjects()...), controlPlane).DescribeGateway(t.Context(), gwNN)
+	require.NoError(t, err)
+
+	assert.Equal(t, "kgateway", desc.Gateway.GatewayClassName)
+	assert.Len(t, desc.Gateway.Listeners, 1)
+	assert.Equal(t, []Route{
+		{Kind: "HTTPRoute", Namespace: "default", Name: "route", Parent: "Gateway/default/gw", Conditions: accepted("Accepted")},
+		{Kind: "HTTPRoute", Namespace: "other", Name: "ls-route", Parent: "ListenerSet/other/ls", Conditions: accepted("Accepted")},
+	}, desc.Routes)
+	require.Len(t, desc.Policies, 1)
+	assert.Equal(t, "policy", desc.Policies[0].Name)
+	assert.Equal(t, "Merged", desc.Policies[0].MergeOutcome)
+	assert.Equal(t, "kgateway", desc.Parameters.Name)
+	assert.Equal(t, []*unstructured.Unstructured{deployment}, desc.RenderedObjects)
+	assert.Equal(t, []xds.ProxyStatus{gwProxy}, desc.Proxies)
+	assert.Empty(t, desc.Warnings)
+}
+
+func TestDescribeGatewayDegradation(t *testing.T) {
+	t.Run("control plane unreachable", func(t *testing.T) {
+		controlPlane := &fakeControlPlane{
+			renderedErr: errors.New("connection refused"),
+			proxiesErr:  errors.New("connection refused"),
+		}
+		desc, err := NewDescriber(newFakeClient(t, clusterObjects()...), controlPlane).DescribeGateway(t.Context(), gwNN)
+		require.NoError(t, err)
+
+		assert.Len(t, desc.Routes, 2)
+		assert.Len(t, desc.Policies, 1)
+		assert.Nil(t, desc.Parameters)
+		assert.Nil(t, desc.RenderedObjects)
+		assert.Nil(t, desc.Proxies)
+		require.Len(t, desc.Warnings, 2)
+		assert.Contains(t, desc.Warnings[0], "parameters and rendered objects are omitted")
+		assert.Contains(t, desc.Warnings[1], "proxies are omitted")
+	})
+
+	t.Run("proxies unavailable only", func(t *testing.T) {
+		controlPlane := &fakeControlPlane{
+			rendered:   &controller.RenderedGateway{},
+			proxiesErr: errors.New("Envoy xDS cache not available"),
+		}
+		desc, err := NewDescriber(newFakeClient(t, clusterObjects()...), controlPlane).DescribeGateway(t.Context(), gwNN)
+		require.NoError(t, err)
+
+		assert.Nil(t, desc.Proxies)
+		require.Len(t, desc.Warnings, 1)
+		assert.Contains(t, desc.Warnings[0], "Envoy xDS cache not available")
+	})
+
+	t.Run("no proxy connected", func(t *testing.T) {
+		desc, err := NewDescriber(newFakeClient(t, clusterObjects()...), &fakeControlPlane{rendered: &controller.RenderedGateway{}}).DescribeGateway(t.Context(), gwNN)
+		require.NoError(t, err)
+
+		assert.NotNil(t, desc.Proxies)
+		assert.Empty(t, desc.Proxies)
+		assert.Empty(t, desc.Warnings)
+	})
+
+	t.Run("no control plane", func(t *testing.T) {
+		desc, err := NewDescriber(newFakeClient(t, clusterObjects()...), nil).DescribeGateway(t.Context(), gwNN)
+		require.NoError(t, err)
+
+		assert.Len(t, desc.Routes, 2)
+		require.Len(t, desc.Warnings, 1)
+		assert.Contains(t, desc.Warnings[0], "control plane not configured")
+	})
+
+	t.Run("gateway not found", func(t *testing.T) {
+		_, err := NewDescriber(newFakeClient(t), nil).DescribeGateway(t.Context(), gwNN)
+		require.ErrorContains(t, err, "failed to get Gateway default/gw")
+	})
+}
+
+// The machine-readable output is consumed by scripts, so its fields must not be renamed or removed.
+func TestPrintSchema(t *testing.T) {
+	behindSince := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	deployment := &unstructured.Unstructured{}
+	deployment.SetAPIVersion("apps/v1")
+	deployment.SetKind("Deployment")
+	deployment.SetNamespace("default")
+	deployment.SetName("gw")
+	desc := &Description{
+		Gateway: Gateway{
+			Namespace:        "default",
+			Name:             "gw",
+			GatewayClassName: "kgateway",
+			Addresses:        []gwv1.GatewayStatusAddress{{Value: "10.0.0.1"}},
+			Conditions:       accepted("Accepted"),
+			Listeners:        []gwv1.ListenerStatus{{Name: "http", AttachedRoutes: 1, SupportedKinds: []gwv1.RouteGroupKind{}, Conditions: accepted("Accepted")}},
+		},
+		Parameters: &kgateway.GatewayParameters{
+			ObjectMeta: metav1.ObjectMeta{Name: "kgateway"},
+			Spec: kgateway.GatewayParametersSpec{
+				Kube: &kgateway.KubernetesProxyConfig{
+					Deployment: &kgateway.ProxyDeployment{Replicas: new(int32(2))},
+				},
+			},
+		},
+		RenderedObjects: []*unstructured.Unstructured{deployment},
+		Routes:          []Route{{Kind: "HTTPRoute", Namespace: "default", Name: "route", Parent: "Gateway/default/gw", Conditions: accepted("Accepted")}},
+		Policies:        []Policy{{Kind: "TrafficPolicy", Namespace: "default", Name: "policy", MergeOutcome: "Merged", Conditions: accepted("Valid")}},
+		Proxies: []xds.ProxyStatus{{
+			CacheKey:       wellknown.GatewayApiProxyValue + "~default~gw",
+			NodeID:         "gw-abc.default",
+			PodName:        "gw-abc",
+			AckedVersions:  map[string]string{"listeners": "1"},
+			LatestVersions: map[string]string{"listeners": "2"},
+			BehindSince:    &behindSince,
+		}},
+		Warnings: []string{"failed to list TLSRoutes: forbidden"},
+	}
+
+	for _, output := range []string{OutputJSON, OutputYAML, OutputText} {
+		t.Run("output "+output, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, Print(&buf, desc, output))
+
+			goldenFile := "testdata/description." + output
+			if output == OutputText {
+				goldenFile = "testdata/description.txt"
+			}
+			if envutils.IsEnvTruthy("REFRESH_GOLDEN") {
+				require.NoError(t, os.WriteFile(goldenFile, buf.Bytes(), 0o644))
+			}
+			golden, err := os.ReadFile(goldenFile)
+			require.NoError(t, err)
+			assert.Equal(t, string(golden), buf.String())
+		})
+	}
+
+	require.ErrorContains(t, Print(&bytes.Buffer{}, desc, "wide"), `unsupported output format "wide"`)
+}
+
+func TestAdminClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gateways/rendered":
+			if r.URL.Query().Get("gateway") != "default/gw" {
+				w.Write([]byte(`{"error":"failed to render gateway"}`))
+				return
+			}
+			w.Write([]byte(`{"objects":[{"apiVersion":"v1","kind":"Service","metadata":{"name":"gw","namespace":"default"}}]}`))
+		case "/snapshots/xds/proxies":
+			w.Write([]byte(`{"error":"Envoy xDS cache not available (Envoy controller may be disabled)"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	c := NewAdminClient(strings.TrimPrefix(server.URL, "http://"))
+
+	rendered, err := c.RenderedGateway(t.Context(), gwNN)
+	require.NoError(t, err)
+	require.Len(t, rendered.Objects, 1)
+	assert.Equal(t, "Service", rendered.Objects[0].GetKind())
+
+	_, err = c.RenderedGateway(t.Context(), types.NamespacedName{Namespace: "default", Name: "missing"})
+	require.ErrorContains(t, err, "failed to render gateway")
+
+	_, err = c.ProxyStatuses(t.Context())
+	require.ErrorContains(t, err, "Envoy xDS cache not available")
+}
@@ -0,0 +1,142 @@
+package describe
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Output formats of a Description.
+const (
+	OutputText = ""
+	OutputYAML = "yaml"
+	OutputJSON = "json"
+)
+
+// Print writes the Description in the output format.
+func Print(w io.Writer, desc *Description, output string) error {
+	switch output {
+	case OutputText:
+		return printText(w, desc)
+	case OutputYAML:
+		b, err := yaml.Marshal(desc)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	case OutputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(desc)
+	default:
+		return fmt.Errorf("unsupported output format %q, must be one of: yaml, json", output)
+	}
+}
+
+func printText(w io.Writer, desc *Description) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	gw := desc.Gateway
+
+	fmt.Fprintf(tw, "Gateway:\t%s/%s\n", gw.Namespace, gw.Name)
+	fmt.Fprintf(tw, "GatewayClass:\t%s\n", gw.GatewayClassName)
+	if len(gw.Addresses) > 0 {
+		addresses := make([]string, 0, len(gw.Addresses))
+		for _, a := range gw.Addresses {
+			addresses = append(addresses, a.Value)
+		}
+		fmt.Fprintf(tw, "Addresses:\t%s\n", strings.Join(addresses, ", "))
+	}
+	printConditions(tw, "", gw.Conditions)
+
+	if len(gw.Listeners) > 0 {
+		fmt.Fprintln(tw, "\nListeners:")
+		for _, l := range gw.Listeners {
+			fmt.Fprintf(tw, "  %s\tattached routes: %d\n", l.Name, l.AttachedRoutes)
+			printConditions(tw, "  ", l.Conditions)
+		}
+	}
+
+	if desc.Parameters != nil {
+		fmt.Fprintln(tw, "\nParameters:")
+		b, err := yaml.Marshal(desc.Parameters.Spec)
+		if err != nil {
+			return err
+		}
+		for line := range strings.Lines(string(b)) {
+			fmt.Fprintf(tw, "  %s", line)
+		}
+	}
+
+	if len(desc.RenderedObjects) > 0 {
+		fmt.Fprintln(tw, "\nRendered objects:")
+		for _, obj := range desc.RenderedObjects {
+			fmt.Fprintf(tw, "  %s\t%s/%s\n", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+		}
+	}
+
+	fmt.Fprintln(tw, "\nRoutes:")
+	if len(desc.Routes) == 0 {
+		fmt.Fprintln(tw, "  <none>")
+	}
+	for _, r := range desc.Routes {
+		fmt.Fprintf(tw, "  %s\t%s/%s\tparent: %s\n", r.Kind, r.Namespace, r.Name, r.Parent)
+		printConditions(tw, "  ", r.Conditions)
+	}
+
+	fmt.Fprintln(tw, "\nPolicies:")
+	if len(desc.Policies) == 0 {
+		fmt.Fprintln(tw, "  <none>")
+	}
+	for _, p := range desc.Policies {
+		fmt.Fprintf(tw, "  %s\t%s/%s\t%s\n", p.Kind, p.Namespace, p.Name, p.MergeOutcome)
+		printConditions(tw, "  ", p.Conditions)
+	}
+
+	fmt.Fprintln(tw, "\nProxies:")
+	switch {
+	case desc.Proxies == nil:
+		fmt.Fprintln(tw, "  <unavailable>")
+	case len(desc.Proxies) == 0:
+		fmt.Fprintln(tw, "  <none>")
+	}
+	for _, p := range desc.Proxies {
+		name := p.PodName
+		if name == "" {
+			name = p.NodeID
+		}
+		state := "up to date"
+		switch {
+		case p.Stale:
+			state = "stale"
+		case p.BehindSince != nil:
+			state = "behind"
+		}
+		fmt.Fprintf(tw, "  %s\t%s\n", name, state)
+		for _, typeURL := range slices.Sorted(maps.Keys(p.AckedVersions)) {
+			fmt.Fprintf(tw, "    %s\tacked: %s\tlatest: %s\n", typeURL, p.AckedVersions[typeURL], p.LatestVersions[typeURL])
+		}
+	}
+
+	if len(desc.Warnings) > 0 {
+		fmt.Fprintln(tw, "\nWarnings:")
+		for _, warning := range desc.Warnings {
+			fmt.Fprintf(tw, "  %s\n", warning)
+		}
+	}
+
+	return tw.Flush()
+}
+
+func printConditions(w io.Writer, indent string, conditions []metav1.Condition) {
+	for _, c := range conditions {
+		fmt.Fprintf(w, "%s  %s\t%s\t%s\t%s\n", indent, c.Type, c.Status, c.Reason, c.Message)
+	}
+}
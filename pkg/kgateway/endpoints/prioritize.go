@@ -7,6 +7,8 @@ import (
 
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoyendpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	"istio.io/api/label"
 	"istio.io/api/networking/v1alpha3"
@@ -17,11 +19,23 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 )
 
+// envoyLbMetadataNamespace is the metadata namespace the subset load balancer matches endpoints with.
+const envoyLbMetadataNamespace = "envoy.lb"
+
 // EndpointsInputs is the collective IR that can be modified
 // by endpoint plugins to influence the ClusterLoadAssignment.
 type EndpointsInputs struct {
 	EndpointsForBackend ir.EndpointsForBackend
 	PriorityInfo        *PriorityInfo
+	// UnreadyEndpointFallback sends the unready endpoints of the backend to Envoy when it has
+	// no ready or serving endpoints, instead of an empty ClusterLoadAssignment.
+	UnreadyEndpointFallback bool
+	// OverprovisioningFactor controls how much traffic fails over to the next priority when a priority
+	// has unhealthy endpoints. Envoy uses a factor of 140 when it is nil.
+	OverprovisioningFactor *wrapperspb.UInt32Value
+	// SubsetLabelKeys are the labels of the endpoints added to their envoy.lb metadata,
+	// so that the subset load balancer of the cluster can select endpoints by label.
+	SubsetLabelKeys []string
 }
 
 // PrioritizeEndpoints converts EndpointsInputs into a ClusterLoadAssignment.
@@ -41,7 +55,17 @@ func PrioritizeEndpoints(
 		lbInfo.PriorityInfo = inputs.PriorityInfo
 	}
 
-	return prioritizeWithLbInfo(logger, inputs.EndpointsForBackend, lbInfo)
+	ep := inputs.EndpointsForBackend
+	ep.LbEps = filterUnhealthyEps(ep.LbEps, inputs.UnreadyEndpointFallback)
+	ep.LbEps = filterByTopologyHints(ep.LbEps, ucc.Locality.Zone)
+	ep.LbEps = addSubsetMetadata(ep.LbEps, inputs.SubsetLabelKeys)
+	cla := prioritizeWithLbInfo(logger, ep, lbInfo)
+	if inputs.OverprovisioningFactor != nil {
+		cla.Policy = &envoyendpointv3.ClusterLoadAssignment_Policy{
+			OverprovisioningFactor: inputs.OverprovisioningFactor,
+		}
+	}
+	return cla
 }
 
 type LoadBalancingInfo struct {
@@ -167,6 +191,115 @@ func filterInvalidEps(eps []ir.EndpointWithMd) []ir.EndpointWithMd {
 	})
 }
 
+// filterUnhealthyEps removes the unhealthy endpoints, i.e. the endpoints that are not ready.
+// If fallback is enabled and none of the endpoints is serving, the unhealthy endpoints are kept
+// and marked healthy instead, so that Envoy keeps sending them requests rather than failing them.
+func filterUnhealthyEps(lbEps ir.LocalityLbMap, fallback bool) ir.LocalityLbMap {
+	hasUnhealthy, hasServing := false, false
+	for _, eps := range lbEps {
+		for _, ep := range eps {
+			if ep.GetHealthStatus() == envoycorev3.HealthStatus_UNHEALTHY {
+				hasUnhealthy = true
+			} else {
+				hasServing = true
+			}
+		}
+	}
+	if !hasUnhealthy {
+		return lbEps
+	}
+
+	useUnhealthy := fallback && !hasServing
+	out := make(ir.LocalityLbMap, len(lbEps))
+	for loc, eps := range lbEps {
+		var kept []ir.EndpointWithMd
+		for _, ep := range eps {
+			if ep.GetHealthStatus() != envoycorev3.HealthStatus_UNHEALTHY {
+				kept = append(kept, ep)
+				continue
+			}
+			if useUnhealthy {
+				// the endpoint is shared by all clients, so it must not be modified in place
+				lbEp := proto.Clone(ep.LbEndpoint).(*envoyendpointv3.LbEndpoint)
+				lbEp.HealthStatus = envoycorev3.HealthStatus_UNKNOWN
+				kept = append(kept, ir.EndpointWithMd{LbEndpoint: lbEp, EndpointMd: ep.EndpointMd})
+			}
+		}
+		if len(kept) > 0 {
+			out[loc] = kept
+		}
+	}
+	return out
+}
+
+// filterByTopologyHints keeps the endpoints hinted for the zone of the proxy by the EndpointSlice
+// controller when the Service uses topology aware routing, like kube-proxy does. The hints are
+// ignored if the zone of the proxy is unknown, if any ready endpoint has no hints, e.g. while the
+// EndpointSlice controller updates them, or if no endpoint is hinted for the zone of the proxy.
+func filterByTopologyHints(lbEps ir.LocalityLbMap, zone string) ir.LocalityLbMap {
+	if zone == "" {
+		return lbEps
+	}
+	hasEndpointForZone := false
+	for _, eps := range lbEps {
+		for _, ep := range eps {
+			if ep.GetHealthStatus() == envoycorev3.HealthStatus_UNKNOWN && len(ep.EndpointMd.ForZones) == 0 {
+				return lbEps
+			}
+			if slices.Contains(ep.EndpointMd.ForZones, zone) {
+				hasEndpointForZone = true
+			}
+		}
+	}
+	if !hasEndpointForZone {
+		return lbEps
+	}
+
+	out := make(ir.LocalityLbMap, len(lbEps))
+	for loc, eps := range lbEps {
+		kept := slices.Filter(eps, func(ep ir.EndpointWithMd) bool {
+			return slices.Contains(ep.EndpointMd.ForZones, zone)
+		})
+		if len(kept) > 0 {
+			out[loc] = kept
+		}
+	}
+	return out
+}
+
+// addSubsetMetadata adds the values of the subset labels of each endpoint to its envoy.lb metadata.
+// The endpoints are shared by all the clients of the backend, so they are copied rather than mutated.
+func addSubsetMetadata(lbEps ir.LocalityLbMap, keys []string) ir.LocalityLbMap {
+	if len(keys) == 0 {
+		return lbEps
+	}
+	out := make(ir.LocalityLbMap, len(lbEps))
+	for loc, eps := range lbEps {
+		out[loc] = slices.Map(eps, func(ep ir.EndpointWithMd) ir.EndpointWithMd {
+			fields := make(map[string]*structpb.Value, len(keys))
+			for _, k := range keys {
+				if v, ok := ep.EndpointMd.Labels[k]; ok {
+					fields[k] = structpb.NewStringValue(v)
+				}
+			}
+			if len(fields) == 0 {
+				return ep
+			}
+			lbEp := proto.Clone(ep.LbEndpoint).(*envoyendpointv3.LbEndpoint)
+			if lbEp.GetMetadata() == nil {
+				lbEp.Metadata = &envoycorev3.Metadata{}
+			}
+			if lbEp.GetMetadata().GetFilterMetadata() == nil {
+				lbEp.Metadata.FilterMetadata = map[string]*structpb.Struct{}
+			}
+			lbEp.Metadata.FilterMetadata[envoyLbMetadataNamespace] = &structpb.Struct{Fields: fields}
+			ep.LbEndpoint = lbEp
+			return ep
+		})
+	}
+	return out
+}
+
 func getEndpoints(eps []ir.EndpointWithMd, lbinfo LoadBalancingInfo) []*envoyendpointv3.LocalityLbEndpoints {
 	if lbinfo.PriorityInfo != nil && lbinfo.PriorityInfo.FailoverPriority != nil {
 		return applyFailoverPriorityPerLocality(eps, lbinfo)
@@ -287,7 +420,10 @@ func applyLocalityFailover(
 }
 
 func LbPriority(proxyLocality, endpointsLocality *envoycorev3.Locality) int {
-	if proxyLocality.GetRegion() == endpointsLocality.GetRegion() {
+	// the locality of endpoints taken from their EndpointSlice only has a zone, which is unique across regions
+	sameRegion := proxyLocality.GetRegion() == endpointsLocality.GetRegion() ||
+		(endpointsLocality.GetRegion() == "" && endpointsLocality.GetZone() != "" && proxyLocality.GetZone() == endpointsLocality.GetZone())
+	if sameRegion {
 		if proxyLocality.GetZone() == endpointsLocality.GetZone() {
 			if proxyLocality.GetSubZone() == endpointsLocality.GetSubZone() {
 				return 0
@@ -0,0 +1,96 @@
+package backend
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoydnsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/dns/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/pluginutils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
+)
+
+// DnsIr is the internal representation of a DNS backend.
+type DnsIr struct {
+	hostname          string
+	port              uint32
+	clusterTypeConfig *anypb.Any
+}
+
+// Equals checks if two DnsIr objects are equal.
+func (u *DnsIr) Equals(other *DnsIr) bool {
+	if u == nil || other == nil {
+		return u == nil && other == nil
+	}
+	return u.hostname == other.hostname &&
+		u.port == other.port &&
+		proto.Equal(u.clusterTypeConfig, other.clusterTypeConfig)
+}
+
+// buildDnsIr builds the DNS IR from the backend specification.
+func buildDnsIr(in *kgateway.DnsBackend) (*DnsIr, error) {
+	hostname := string(in.Hostname)
+	if err := validateDnsHostname(hostname); err != nil {
+		return nil, err
+	}
+	if in.Port < 1 || in.Port > 65535 {
+		return nil, fmt.Errorf("invalid port %d for dns backend: must be between 1 and 65535", in.Port)
+	}
+
+	// strict DNS load balances across all the resolved addresses, while logical DNS
+	// treats all of them as a single endpoint and only connects to the first one.
+	dnsCluster := &envoydnsv3.DnsCluster{
+		AllAddressesInSingleEndpoint: in.Resolution == kgateway.DnsResolutionLogical,
+	}
+	if in.RefreshRate != nil {
+		if in.RefreshRate.Duration < time.Millisecond {
+			return nil, fmt.Errorf("invalid refreshRate %s for dns backend: must be at least 1ms", in.RefreshRate.Duration)
+		}
+		dnsCluster.DnsRefreshRate = durationpb.New(in.RefreshRate.Duration)
+	}
+	dnsClusterConfig, err := utils.MessageToAny(dnsCluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dns cluster config: %v", err)
+	}
+
+	return &DnsIr{
+		hostname:          hostname,
+		port:              uint32(in.Port), //nolint:gosec // G115: validated to be between 1 and 65535 above
+		clusterTypeConfig: dnsClusterConfig,
+	}, nil
+}
+
+// validateDnsHostname validates that the hostname is a fully qualified domain name.
+func validateDnsHostname(hostname string) error {
+	if _, err := netip.ParseAddr(hostname); err == nil {
+		return fmt.Errorf("invalid hostname %q for dns backend: must be a domain name, not an IP address", hostname)
+	}
+	if errs := validation.IsDNS1123Subdomain(hostname); len(errs) > 0 {
+		return fmt.Errorf("invalid hostname %q for dns backend: %s", hostname, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// processDns applies the DNS IR to the envoy cluster.
+func processDns(ir *DnsIr, out *envoyclusterv3.Cluster) error {
+	if ir == nil {
+		return fmt.Errorf("dns ir is nil")
+	}
+
+	out.ClusterDiscoveryType = &envoyclusterv3.Cluster_ClusterType{
+		ClusterType: &envoyclusterv3.Cluster_CustomClusterType{
+			Name:        dnsClusterExtensionName,
+			TypedConfig: proto.Clone(ir.clusterTypeConfig).(*anypb.Any),
+		},
+	}
+	pluginutils.EnvoySingleEndpointLoadAssignment(out, ir.hostname, ir.port)
+	return nil
+}
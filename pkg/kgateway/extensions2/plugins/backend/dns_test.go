@@ -0,0 +1,144 @@
+package backend
+
+import (
+	"testing"
+	"time"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoydnsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/dns/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+)
+
+func TestProcessDns(t *testing.T) {
+	tests := []struct {
+		name                 string
+		resolution           kgateway.DnsResolution
+		expectSingleEndpoint bool
+	}{
+		{
+			name:       "strict dns",
+			resolution: kgateway.DnsResolutionStrict,
+		},
+		{
+			name:       "strict dns by default",
+			resolution: "",
+		},
+		{
+			name:                 "logical dns",
+			resolution:           kgateway.DnsResolutionLogical,
+			expectSingleEndpoint: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ir, err := buildDnsIr(&kgateway.DnsBackend{
+				Hostname:   "api.example.com",
+				Port:       443,
+				Resolution: tt.resolution,
+			})
+			require.NoError(t, err)
+
+			cluster := &envoyclusterv3.Cluster{Name: "test-cluster"}
+			require.NoError(t, processDns(ir, cluster))
+
+			clusterType := cluster.GetClusterType()
+			require.NotNil(t, clusterType, "expected custom cluster type")
+			assert.Equal(t, dnsClusterExtensionName, clusterType.GetName())
+
+			var dnsCluster envoydnsv3.DnsCluster
+			require.NoError(t, anypb.UnmarshalTo(clusterType.GetTypedConfig(), &dnsCluster, proto.UnmarshalOptions{}))
+			assert.Equal(t, tt.expectSingleEndpoint, dnsCluster.GetAllAddressesInSingleEndpoint())
+
+			require.NotNil(t, cluster.LoadAssignment)
+			assert.Equal(t, "test-cluster", cluster.LoadAssignment.GetClusterName())
+			require.Len(t, cluster.LoadAssignment.Endpoints, 1)
+			require.Len(t, cluster.LoadAssignment.Endpoints[0].LbEndpoints, 1)
+			addr := cluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().GetAddress().GetSocketAddress()
+			assert.Equal(t, "api.example.com", addr.GetAddress())
+			assert.Equal(t, uint32(443), addr.GetPortValue())
+		})
+	}
+}
+
+func TestProcessDnsRefreshRate(t *testing.T) {
+	ir, err := buildDnsIr(&kgateway.DnsBackend{
+		Hostname:    "api.example.com",
+		Port:        443,
+		Resolution:  kgateway.DnsResolutionLogical,
+		RefreshRate: &metav1.Duration{Duration: 30 * time.Second},
+	})
+	require.NoError(t, err)
+
+	cluster := &envoyclusterv3.Cluster{Name: "test-cluster"}
+	require.NoError(t, processDns(ir, cluster))
+
+	var dnsCluster envoydnsv3.DnsCluster
+	require.NoError(t, anypb.UnmarshalTo(cluster.GetClusterType().GetTypedConfig(), &dnsCluster, proto.UnmarshalOptions{}))
+	assert.True(t, dnsCluster.GetAllAddressesInSingleEndpoint())
+	assert.Equal(t, 30*time.Second, dnsCluster.GetDnsRefreshRate().AsDuration())
+
+	// the refresh rate is part of the IR, so that a change re-translates the cluster
+	other, err := buildDnsIr(&kgateway.DnsBackend{
+		Hostname:    "api.example.com",
+		Port:        443,
+		Resolution:  kgateway.DnsResolutionLogical,
+		RefreshRate: &metav1.Duration{Duration: time.Minute},
+	})
+	require.NoError(t, err)
+	assert.False(t, ir.Equals(other))
+}
+
+func TestBuildDnsIrInvalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		backend     *kgateway.DnsBackend
+		expectedErr string
+	}{
+		{
+			name:        "ip address",
+			backend:     &kgateway.DnsBackend{Hostname: "10.0.0.1", Port: 80},
+			expectedErr: "must be a domain name, not an IP address",
+		},
+		{
+			name:        "invalid characters",
+			backend:     &kgateway.DnsBackend{Hostname: "api_example.com", Port: 80},
+			expectedErr: `invalid hostname "api_example.com" for dns backend`,
+		},
+		{
+			name:        "uppercase",
+			backend:     &kgateway.DnsBackend{Hostname: "API.example.com", Port: 80},
+			expectedErr: `invalid hostname "API.example.com" for dns backend`,
+		},
+		{
+			name:        "empty",
+			backend:     &kgateway.DnsBackend{Port: 80},
+			expectedErr: `invalid hostname "" for dns backend`,
+		},
+		{
+			name:        "zero refresh rate",
+			backend:     &kgateway.DnsBackend{Hostname: "api.example.com", Port: 80, RefreshRate: &metav1.Duration{}},
+			expectedErr: "invalid refreshRate 0s for dns backend: must be at least 1ms",
+		},
+		{
+			name:        "missing port",
+			backend:     &kgateway.DnsBackend{Hostname: "api.example.com"},
+			expectedErr: "invalid port 0 for dns backend",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ir, err := buildDnsIr(tt.backend)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+			assert.Nil(t, ir)
+		})
+	}
+}
@@ -10,9 +10,11 @@ import (
 	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	envoywellknown "github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"google.golang.org/protobuf/types/known/wrapperspb"
+	"istio.io/istio/pkg/kube/controllers"
 	"istio.io/istio/pkg/kube/kclient"
 	"istio.io/istio/pkg/kube/krt"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
@@ -38,6 +40,8 @@ type backendIr struct {
 	staticIr *StaticIr
 	dfpIr    *DfpIr
 	gcpIr    *GcpIr
+	dnsIr    *DnsIr
+	pluginIr *PluginIr
 	// +noKrtEquals
 	errors []error
 }
@@ -63,10 +67,24 @@ func (u *backendIr) Equals(other any) bool {
 	if !u.gcpIr.Equals(otherBackend.gcpIr) {
 		return false
 	}
+	// DNS
+	if !u.dnsIr.Equals(otherBackend.dnsIr) {
+		return false
+	}
+	// Plugin
+	if !u.pluginIr.Equals(otherBackend.pluginIr) {
+		return false
+	}
 	return true
 }
 
-func NewPlugin(commoncol *collections.CommonCollections) sdk.Plugin {
+// NewPlugin returns the plugin of the Backends. The Backends with the type `plugin/<name>` are
+// resolved by the resolver registered with that name in resolvers.
+func NewPlugin(
+	ctx context.Context,
+	commoncol *collections.CommonCollections,
+	resolvers map[string]sdk.BackendResolver,
+) sdk.Plugin {
 	cli := kclient.NewFilteredDelayed[*kgateway.Backend](
 		commoncol.Client,
 		wellknown.BackendGVR,
@@ -77,11 +95,9 @@ func NewPlugin(commoncol *collections.CommonCollections) sdk.Plugin {
 
 	gk := wellknown.BackendGVK.GroupKind()
 	translateFn := buildTranslateFunc(commoncol.Secrets)
+	pluginResolvers := newPluginResolvers(ctx, resolvers, commoncol.KrtOpts)
 	bcol := krt.NewCollection(col, func(krtctx krt.HandlerContext, i *kgateway.Backend) *ir.BackendObjectIR {
 		backendIR := translateFn(krtctx, i)
-		if len(backendIR.errors) > 0 {
-			logger.Error("failed to translate backend", "backend", i.GetName(), "error", errors.Join(backendIR.errors...))
-		}
 		objSrc := ir.ObjectSource{
 			Kind:      gk.Kind,
 			Group:     gk.Group,
@@ -93,6 +109,20 @@ func NewPlugin(commoncol *collections.CommonCollections) sdk.Plugin {
 		backend.CanonicalHostname = hostname(i)
 		backend.AppProtocol = parseAppProtocol(i)
 		backend.Obj = i
+
+		if i.Spec.Plugin != nil {
+			pluginIr, err := pluginResolvers.resolve(backend, i.Spec.Plugin)
+			if err != nil {
+				backendIR.errors = append(backendIR.errors, err)
+			}
+			backendIR.pluginIr = pluginIr
+		} else {
+			pluginResolvers.stop(types.NamespacedName{Namespace: i.GetNamespace(), Name: i.GetName()})
+		}
+		if len(backendIR.errors) > 0 {
+			logger.Error("failed to translate backend", "backend", i.GetName(), "error", errors.Join(backendIR.errors...))
+		}
+
 		backend.ObjIr = backendIR
 		backend.Errors = backendIR.errors
 
@@ -101,13 +131,19 @@ func NewPlugin(commoncol *collections.CommonCollections) sdk.Plugin {
 
 		return &backend
 	})
+	bcol.Register(func(o krt.Event[ir.BackendObjectIR]) {
+		if o.Event == controllers.EventDelete {
+			pluginResolvers.stop(types.NamespacedName{Namespace: o.Old.GetNamespace(), Name: o.Old.GetName()})
+		}
+	})
 	return sdk.Plugin{
 		ContributesBackends: map[schema.GroupKind]sdk.BackendPlugin{
 			gk: {
 				BackendInit: ir.BackendInit{
 					InitEnvoyBackend: processBackendForEnvoy,
 				},
-				Backends: bcol,
+				Backends:  bcol,
+				Endpoints: pluginResolvers.endpoints,
 			},
 		},
 		ContributesPolicies: map[schema.GroupKind]sdk.PolicyPlugin{
@@ -199,6 +235,12 @@ func buildTranslateFunc(
 				beIr.errors = append(beIr.errors, err)
 			}
 			beIr.gcpIr = gcpIr
+		case i.Spec.Dns != nil:
+			dnsIr, err := buildDnsIr(i.Spec.Dns)
+			if err != nil {
+				beIr.errors = append(beIr.errors, err)
+			}
+			beIr.dnsIr = dnsIr
 		}
 		return &beIr
 	}
@@ -233,22 +275,39 @@ func processBackendForEnvoy(ctx context.Context, in ir.BackendObjectIR, out *env
 			logger.Error("failed to process gcp backend", "error", err)
 			beIr.errors = append(beIr.errors, err)
 		}
+	case spec.Dns != nil:
+		if err := processDns(beIr.dnsIr, out); err != nil {
+			logger.Error("failed to process dns backend", "error", err)
+			beIr.errors = append(beIr.errors, err)
+		}
+	case spec.Plugin != nil:
+		if err := processPlugin(beIr.pluginIr, out); err != nil {
+			logger.Error("failed to process plugin backend", "error", err)
+			beIr.errors = append(beIr.errors, err)
+		}
 	}
 	return nil
 }
 
 func parseAppProtocol(b *kgateway.Backend) ir.AppProtocol {
-	if b.Spec.Static != nil {
-		appProtocol := b.Spec.Static.AppProtocol
-		if appProtocol != nil {
-			return ir.ParseAppProtocol(new(string(*appProtocol)))
-		}
+	var appProtocol *kgateway.AppProtocol
+	switch {
+	case b.Spec.Static != nil:
+		appProtocol = b.Spec.Static.AppProtocol
+	case b.Spec.Dns != nil:
+		appProtocol = b.Spec.Dns.AppProtocol
+	}
+	if appProtocol != nil {
+		return ir.ParseAppProtocol(new(string(*appProtocol)))
 	}
 	return ir.DefaultAppProtocol
 }
 
-// hostname returns the hostname for the backend. Only static backends are supported.
+// hostname returns the hostname for the backend. Only static and DNS backends are supported.
 func hostname(in *kgateway.Backend) string {
+	if in.Spec.Dns != nil {
+		return string(in.Spec.Dns.Hostname)
+	}
 	if in.Spec.Static == nil {
 		return ""
 	}
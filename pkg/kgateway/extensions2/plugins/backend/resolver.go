@@ -0,0 +1,209 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"istio.io/istio/pkg/kube/krt"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
+)
+
+// PluginIr is the internal representation of a backend resolved by a plugin.
+type PluginIr struct {
+	resolver string
+	config   []byte
+	cluster  *envoyclusterv3.Cluster
+}
+
+// Equals checks if two PluginIr objects are equal.
+func (u *PluginIr) Equals(other *PluginIr) bool {
+	if u == nil || other == nil {
+		return u == nil && other == nil
+	}
+	return u.resolver == other.resolver &&
+		bytes.Equal(u.config, other.config) &&
+		proto.Equal(u.cluster, other.cluster)
+}
+
+// pluginResolvers resolves the backends with the type `plugin/<name>` with the resolvers
+// registered by the plugins. The endpoints of the backends, including the updates sent by
+// the resolvers, are served through EDS.
+type pluginResolvers struct {
+	ctx       context.Context
+	resolvers map[string]sdk.BackendResolver
+	endpoints krt.StaticCollection[ir.EndpointsForBackend]
+
+	mu      sync.Mutex
+	running map[types.NamespacedName]*pluginResolution
+}
+
+// pluginResolution is the current resolution of a backend.
+type pluginResolution struct {
+	backend ir.BackendObjectIR
+	ir      *PluginIr
+	err     error
+	cancel  context.CancelFunc
+}
+
+func newPluginResolvers(
+	ctx context.Context,
+	resolvers map[string]sdk.BackendResolver,
+	krtOpts krtutil.KrtOptions,
+) *pluginResolvers {
+	return &pluginResolvers{
+		ctx:       ctx,
+		resolvers: resolvers,
+		endpoints: krt.NewStaticCollection[ir.EndpointsForBackend](nil, nil, krtOpts.ToOptions("PluginBackendEndpoints")...),
+		running:   make(map[types.NamespacedName]*pluginResolution),
+	}
+}
+
+// resolve resolves the backend with its resolver, unless its plugin config is unchanged since
+// it was last resolved.
+func (r *pluginResolvers) resolve(backend ir.BackendObjectIR, in *kgateway.PluginBackend) (*PluginIr, error) {
+	nn := types.NamespacedName{Namespace: backend.GetNamespace(), Name: backend.GetName()}
+	var config []byte
+	if in.Config != nil {
+		config = in.Config.Raw
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cur, ok := r.running[nn]; ok {
+		if cur.ir.resolver == in.Name && bytes.Equal(cur.ir.config, config) {
+			cur.backend = backend
+			return cur.ir, cur.err
+		}
+		r.stopLocked(nn)
+	}
+
+	res := &pluginResolution{
+		backend: backend,
+		ir: &PluginIr{
+			resolver: in.Name,
+			config:   config,
+		},
+	}
+	resolver, ok := r.resolvers[in.Name]
+	if !ok {
+		res.err = fmt.Errorf("no backend resolver registered with name %q", in.Name)
+		res.cancel = func() {}
+		r.running[nn] = res
+		return res.ir, res.err
+	}
+
+	ctx, cancel := context.WithCancel(r.ctx)
+	res.cancel = cancel
+	updates := make(chan []sdk.BackendEndpoint)
+	resolution, err := resolver.Resolve(ctx, sdk.BackendResolveRequest{Backend: nn, Config: config}, updates)
+	if err != nil {
+		cancel()
+		res.err = fmt.Errorf("failed to resolve backend with resolver %q: %w", in.Name, err)
+		r.running[nn] = res
+		return res.ir, res.err
+	}
+	if resolution != nil {
+		res.ir.cluster = resolution.Cluster
+		r.endpoints.UpdateObject(pluginEndpoints(backend, resolution.Endpoints))
+	} else {
+		r.endpoints.UpdateObject(pluginEndpoints(backend, nil))
+	}
+	r.running[nn] = res
+
+	go r.watch(ctx, nn, res, updates)
+	return res.ir, nil
+}
+
+// watch applies the endpoint updates sent by the resolver of the backend until ctx is done.
+func (r *pluginResolvers) watch(
+	ctx context.Context,
+	nn types.NamespacedName,
+	res *pluginResolution,
+	updates <-chan []sdk.BackendEndpoint,
+) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case eps := <-updates:
+			r.mu.Lock()
+			// drop the updates of a resolution that was replaced in the meantime
+			if r.running[nn] == res && ctx.Err() == nil {
+				r.endpoints.UpdateObject(pluginEndpoints(res.backend, eps))
+			}
+			r.mu.Unlock()
+		}
+	}
+}
+
+// stop stops the resolution of the backend and removes its endpoints.
+func (r *pluginResolvers) stop(nn types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopLocked(nn)
+}
+
+func (r *pluginResolvers) stopLocked(nn types.NamespacedName) {
+	res, ok := r.running[nn]
+	if !ok {
+		return
+	}
+	res.cancel()
+	delete(r.running, nn)
+	r.endpoints.DeleteObject(res.backend.ResourceName())
+}
+
+// pluginEndpoints converts the endpoints sent by a resolver to the endpoints of the backend.
+func pluginEndpoints(backend ir.BackendObjectIR, eps []sdk.BackendEndpoint) ir.EndpointsForBackend {
+	out := ir.NewEndpointsForBackend(backend)
+	for _, ep := range eps {
+		lbEp := krtcollections.CreateLBEndpoint(ep.Address, ep.Port, nil, false)
+		if ep.Weight > 0 {
+			lbEp.LoadBalancingWeight = wrapperspb.UInt32(ep.Weight)
+		}
+		out.Add(ep.Locality, ir.EndpointWithMd{
+			LbEndpoint: lbEp,
+		})
+	}
+	return *out
+}
+
+// processPlugin applies the plugin IR to the envoy cluster, which gets its endpoints through EDS.
+func processPlugin(ir *PluginIr, out *envoyclusterv3.Cluster) error {
+	if ir == nil {
+		return fmt.Errorf("plugin ir is nil")
+	}
+
+	if ir.cluster != nil {
+		settings := proto.Clone(ir.cluster).(*envoyclusterv3.Cluster)
+		settings.Name = ""
+		settings.ClusterDiscoveryType = nil
+		settings.LoadAssignment = nil
+		settings.EdsClusterConfig = nil
+		proto.Merge(out, settings)
+	}
+	out.ClusterDiscoveryType = &envoyclusterv3.Cluster_Type{
+		Type: envoyclusterv3.Cluster_EDS,
+	}
+	out.EdsClusterConfig = &envoyclusterv3.Cluster_EdsClusterConfig{
+		EdsConfig: &envoycorev3.ConfigSource{
+			ResourceApiVersion: envoycorev3.ApiVersion_V3,
+			ConfigSourceSpecifier: &envoycorev3.ConfigSource_Ads{
+				Ads: &envoycorev3.AggregatedConfigSource{},
+			},
+		},
+	}
+	return nil
+}
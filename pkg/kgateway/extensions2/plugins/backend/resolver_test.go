@@ -0,0 +1,191 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/endpoints"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
+)
+
+type fakeResolver struct {
+	resolution *sdk.BackendResolution
+	err        error
+
+	requests []sdk.BackendResolveRequest
+	updates  chan<- []sdk.BackendEndpoint
+	ctx      context.Context
+}
+
+func (f *fakeResolver) Resolve(ctx context.Context, req sdk.BackendResolveRequest, updates chan<- []sdk.BackendEndpoint) (*sdk.BackendResolution, error) {
+	f.requests = append(f.requests, req)
+	f.ctx = ctx
+	f.updates = updates
+	return f.resolution, f.err
+}
+
+func pluginBackendIR(name string) ir.BackendObjectIR {
+	gk := wellknown.BackendGVK.GroupKind()
+	backend := ir.NewBackendObjectIR(ir.ObjectSource{
+		Group:     gk.Group,
+		Kind:      gk.Kind,
+		Namespace: "default",
+		Name:      name,
+	}, 0, "")
+	backend.GvPrefix = ExtensionName
+	return backend
+}
+
+func endpointAddresses(eps *ir.EndpointsForBackend) []string {
+	var addresses []string
+	for _, lbEps := range eps.LbEps {
+		for _, ep := range lbEps {
+			addresses = append(addresses, ep.LbEndpoint.GetEndpoint().GetAddress().GetSocketAddress().GetAddress())
+		}
+	}
+	return addresses
+}
+
+func TestPluginResolversInitialEndpoints(t *testing.T) {
+	resolver := &fakeResolver{
+		resolution: &sdk.BackendResolution{
+			Cluster: &envoyclusterv3.Cluster{
+				Name:           "ignored",
+				ConnectTimeout: durationpb.New(3 * time.Second),
+			},
+			Endpoints: []sdk.BackendEndpoint{
+				{Address: "10.0.0.1", Port: 8080},
+				{Address: "10.0.0.2", Port: 8080, Weight: 3, Locality: ir.PodLocality{Region: "us-east1"}},
+			},
+		},
+	}
+	r := newPluginResolvers(t.Context(), map[string]sdk.BackendResolver{"registry": resolver}, krtutil.NewKrtOptions(t.Context().Done(), nil))
+	backend := pluginBackendIR("svc")
+
+	pluginIr, err := r.resolve(backend, &kgateway.PluginBackend{
+		Name:   "registry",
+		Config: &runtime.RawExtension{Raw: []byte(`{"service":"svc"}`)},
+	})
+	require.NoError(t, err)
+	require.Len(t, resolver.requests, 1)
+	assert.Equal(t, "default", resolver.requests[0].Backend.Namespace)
+	assert.Equal(t, "svc", resolver.requests[0].Backend.Name)
+	assert.JSONEq(t, `{"service":"svc"}`, string(resolver.requests[0].Config))
+
+	eps := r.endpoints.GetKey(backend.ResourceName())
+	require.NotNil(t, eps)
+	assert.Equal(t, backend.ClusterName(), eps.ClusterName)
+	assert.ElementsMatch(t, []string{"10.0.0.1", "10.0.0.2"}, endpointAddresses(eps))
+	weighted := eps.LbEps[ir.PodLocality{Region: "us-east1"}]
+	require.Len(t, weighted, 1)
+	assert.Equal(t, uint32(3), weighted[0].LbEndpoint.GetLoadBalancingWeight().GetValue())
+
+	cluster := &envoyclusterv3.Cluster{Name: backend.ClusterName()}
+	require.NoError(t, processPlugin(pluginIr, cluster))
+	assert.Equal(t, backend.ClusterName(), cluster.GetName())
+	assert.Equal(t, envoyclusterv3.Cluster_EDS, cluster.GetType())
+	assert.NotNil(t, cluster.GetEdsClusterConfig().GetEdsConfig().GetAds())
+	assert.Equal(t, 3*time.Second, cluster.GetConnectTimeout().AsDuration())
+
+	// resolving the same config again reuses the resolution
+	_, err = r.resolve(backend, &kgateway.PluginBackend{
+		Name:   "registry",
+		Config: &runtime.RawExtension{Raw: []byte(`{"service":"svc"}`)},
+	})
+	require.NoError(t, err)
+	assert.Len(t, resolver.requests, 1)
+
+	// stopping the resolution removes the endpoints
+	r.stop(resolver.requests[0].Backend)
+	assert.Nil(t, r.endpoints.GetKey(backend.ResourceName()))
+	assert.Error(t, resolver.ctx.Err())
+}
+
+func TestPluginResolversEndpointUpdates(t *testing.T) {
+	resolver := &fakeResolver{
+		resolution: &sdk.BackendResolution{
+			Endpoints: []sdk.BackendEndpoint{{Address: "10.0.0.1", Port: 8080}},
+		},
+	}
+	r := newPluginResolvers(t.Context(), map[string]sdk.BackendResolver{"registry": resolver}, krtutil.NewKrtOptions(t.Context().Done(), nil))
+	backend := pluginBackendIR("svc")
+
+	_, err := r.resolve(backend, &kgateway.PluginBackend{Name: "registry"})
+	require.NoError(t, err)
+	firstCtx, firstUpdates := resolver.ctx, resolver.updates
+
+	firstUpdates <- []sdk.BackendEndpoint{{Address: "10.0.0.2", Port: 8080}, {Address: "10.0.0.3", Port: 8080}}
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		eps := r.endpoints.GetKey(backend.ResourceName())
+		if assert.NotNil(c, eps) {
+			assert.ElementsMatch(c, []string{"10.0.0.2", "10.0.0.3"}, endpointAddresses(eps))
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// a config change resolves the backend again, and ends the previous resolution
+	_, err = r.resolve(backend, &kgateway.PluginBackend{
+		Name:   "registry",
+		Config: &runtime.RawExtension{Raw: []byte(`{"service":"other"}`)},
+	})
+	require.NoError(t, err)
+	assert.Len(t, resolver.requests, 2)
+	assert.Error(t, firstCtx.Err())
+	eps := r.endpoints.GetKey(backend.ResourceName())
+	require.NotNil(t, eps)
+	assert.ElementsMatch(t, []string{"10.0.0.1"}, endpointAddresses(eps))
+
+	resolver.updates <- []sdk.BackendEndpoint{{Address: "10.0.0.4", Port: 8080}}
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		eps := r.endpoints.GetKey(backend.ResourceName())
+		if assert.NotNil(c, eps) {
+			assert.ElementsMatch(c, []string{"10.0.0.4"}, endpointAddresses(eps))
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the updated endpoints are the load assignment of the cluster sent through EDS
+	eps = r.endpoints.GetKey(backend.ResourceName())
+	require.NotNil(t, eps)
+	cla := endpoints.PrioritizeEndpoints(logger, ir.UniqlyConnectedClient{}, endpoints.EndpointsInputs{EndpointsForBackend: *eps})
+	assert.Equal(t, backend.ClusterName(), cla.GetClusterName())
+	require.Len(t, cla.GetEndpoints(), 1)
+	require.Len(t, cla.GetEndpoints()[0].GetLbEndpoints(), 1)
+	socketAddress := cla.GetEndpoints()[0].GetLbEndpoints()[0].GetEndpoint().GetAddress().GetSocketAddress()
+	assert.Equal(t, "10.0.0.4", socketAddress.GetAddress())
+	assert.Equal(t, uint32(8080), socketAddress.GetPortValue())
+}
+
+func TestPluginResolversErrors(t *testing.T) {
+	resolver := &fakeResolver{err: errors.New("service not found")}
+	r := newPluginResolvers(t.Context(), map[string]sdk.BackendResolver{"registry": resolver}, krtutil.NewKrtOptions(t.Context().Done(), nil))
+
+	backend := pluginBackendIR("svc")
+	pluginIr, err := r.resolve(backend, &kgateway.PluginBackend{Name: "registry"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "service not found")
+	assert.NotNil(t, pluginIr)
+	assert.Nil(t, r.endpoints.GetKey(backend.ResourceName()))
+	assert.Error(t, resolver.ctx.Err())
+
+	// the error is kept until the config changes
+	_, err = r.resolve(backend, &kgateway.PluginBackend{Name: "registry"})
+	require.Error(t, err)
+	assert.Len(t, resolver.requests, 1)
+
+	unknown := pluginBackendIR("unknown")
+	_, err = r.resolve(unknown, &kgateway.PluginBackend{Name: "missing"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no backend resolver registered with name "missing"`)
+	assert.Nil(t, r.endpoints.GetKey(unknown.ResourceName()))
+}
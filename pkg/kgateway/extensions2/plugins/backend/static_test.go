@@ -11,6 +11,7 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 )
 
 func TestBuildStaticIrUsesDnsClusterForHostnames(t *testing.T) {
@@ -60,3 +61,15 @@ func TestProcessStaticUsesCustomDnsCluster(t *testing.T) {
 	assert.Equal(t, "example.com", endpoint.GetAddress().GetSocketAddress().GetAddress())
 	assert.Equal(t, uint32(8080), endpoint.GetAddress().GetSocketAddress().GetPortValue())
 }
+
+func TestParseAppProtocolA2A(t *testing.T) {
+	a2a := kgateway.AppProtocolA2A
+	for name, spec := range map[string]kgateway.BackendSpec{
+		"static": {Static: &kgateway.StaticBackend{AppProtocol: &a2a}},
+		"dns":    {Dns: &kgateway.DnsBackend{AppProtocol: &a2a}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, ir.A2AAppProtocol, parseAppProtocol(&kgateway.Backend{Spec: spec}))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package backendconfigpolicy
+
+import (
+	"errors"
+	"fmt"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+)
+
+// validateConnectionPool checks the values of the connection pool that the CRD validation
+// can't be relied on for.
+func validateConnectionPool(pool *kgateway.ConnectionPool) error {
+	var errs []error
+	if pool.HTTP != nil {
+		if v := pool.HTTP.MaxRequestsPerConnection; v != nil && *v < 0 {
+			errs = append(errs, fmt.Errorf("connectionPool.http.maxRequestsPerConnection must not be negative, got %d", *v))
+		}
+		if v := pool.HTTP.IdleTimeout; v != nil && v.Duration <= 0 {
+			errs = append(errs, fmt.Errorf("connectionPool.http.idleTimeout must be positive, got %s", v.Duration))
+		}
+	}
+	if pool.TCP != nil {
+		if v := pool.TCP.MaxConnections; v != nil && *v < 0 {
+			errs = append(errs, fmt.Errorf("connectionPool.tcp.maxConnections must not be negative, got %d", *v))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// applyConnectionPool renders the connection pool into the common HTTP protocol options and the
+// circuit breakers translated from the rest of the policy, creating them if needed.
+func applyConnectionPool(
+	pool *kgateway.ConnectionPool,
+	httpOptions *envoycorev3.HttpProtocolOptions,
+	circuitBreakers *envoyclusterv3.CircuitBreakers,
+) (*envoycorev3.HttpProtocolOptions, *envoyclusterv3.CircuitBreakers) {
+	if pool.HTTP != nil {
+		if httpOptions == nil {
+			httpOptions = &envoycorev3.HttpProtocolOptions{}
+		}
+		if pool.HTTP.MaxRequestsPerConnection != nil {
+			httpOptions.MaxRequestsPerConnection = wrapperspb.UInt32(uint32(*pool.HTTP.MaxRequestsPerConnection)) //nolint:gosec // G115: validated to be non-negative, safe for uint32
+		}
+		if pool.HTTP.IdleTimeout != nil {
+			httpOptions.IdleTimeout = durationpb.New(pool.HTTP.IdleTimeout.Duration)
+		}
+	}
+
+	if pool.TCP != nil && pool.TCP.MaxConnections != nil {
+		if circuitBreakers == nil {
+			circuitBreakers = &envoyclusterv3.CircuitBreakers{
+				Thresholds: []*envoyclusterv3.CircuitBreakers_Thresholds{{}},
+			}
+		}
+		// the thresholds of the default priority are the first and only ones
+		circuitBreakers.Thresholds[0].MaxConnections = wrapperspb.UInt32(uint32(*pool.TCP.MaxConnections)) //nolint:gosec // G115: validated to be non-negative, safe for uint32
+	}
+
+	return httpOptions, circuitBreakers
+}
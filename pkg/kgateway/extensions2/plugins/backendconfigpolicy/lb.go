@@ -2,7 +2,10 @@ package backendconfigpolicy
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
+	"strings"
 
 	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
@@ -14,10 +17,13 @@ import (
 	envoyrandomv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/load_balancing_policies/random/v3"
 	envoyringhashv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/load_balancing_policies/ring_hash/v3"
 	envoyroundrobinv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/load_balancing_policies/round_robin/v3"
+	envoysubsetv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/load_balancing_policies/subset/v3"
 	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
@@ -35,6 +41,24 @@ type LoadBalancerConfigIR struct {
 	commonLbConfig        *envoyclusterv3.Cluster_CommonLbConfig
 	loadBalancingPolicy   *envoyclusterv3.LoadBalancingPolicy
 	useHostnameForHashing bool
+	// zoneAware is set when endpoints must be prioritized based on the locality of the proxy
+	zoneAware *zoneAwareConfig
+	// subsetLabelKeys are the endpoint labels the subset load balancer selects endpoints with
+	subsetLabelKeys []string
+	// consistentHashingField is the field of the consistent hashing load balancer, if one is configured
+	consistentHashingField string
+}
+
+type zoneAwareConfig struct {
+	// overprovisioningFactor is nil to use the Envoy default
+	overprovisioningFactor *wrapperspb.UInt32Value
+}
+
+func (a *zoneAwareConfig) Equals(b *zoneAwareConfig) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return proto.Equal(a.overprovisioningFactor, b.overprovisioningFactor)
 }
 
 func translateLoadBalancerConfig(config *kgateway.LoadBalancer, policyName, policyNamespace string) (*LoadBalancerConfigIR, error) {
@@ -56,6 +80,17 @@ func translateLoadBalancerConfig(config *kgateway.LoadBalancer, policyName, poli
 		out.commonLbConfig.CloseConnectionsOnHostSetChange = *config.CloseConnectionsOnHostSetChange
 	}
 
+	if config.ZoneAware != nil {
+		out.zoneAware = &zoneAwareConfig{}
+		if config.ZoneAware.FailoverThreshold != nil {
+			// Envoy sends a locality min(100, healthy% * factor / 100) percent of the traffic of its priority,
+			// so the traffic starts failing over once the healthy percentage goes below 100 * 100 / factor.
+			out.zoneAware.overprovisioningFactor = &wrapperspb.UInt32Value{
+				Value: uint32(10000 / *config.ZoneAware.FailoverThreshold), // nolint:gosec // G115: kubebuilder validation ensures 1 <= value <= 100
+			}
+		}
+	}
+
 	var err error
 	switch {
 	case config.LeastRequest != nil:
@@ -64,16 +99,24 @@ func translateLoadBalancerConfig(config *kgateway.LoadBalancer, policyName, poli
 		out.loadBalancingPolicy, err = buildRoundRobinPolicy(config, policyName, policyNamespace)
 	case config.RingHash != nil:
 		out.loadBalancingPolicy, out.useHostnameForHashing, err = buildRingHashPolicy(config)
+		out.consistentHashingField = "loadBalancer.ringHash"
 	case config.Maglev != nil:
 		out.loadBalancingPolicy, out.useHostnameForHashing, err = buildMaglevPolicy(config)
+		out.consistentHashingField = "loadBalancer.maglev"
 	case config.Random != nil:
 		out.loadBalancingPolicy, err = buildRandomPolicy(config)
 	}
-
 	if err != nil {
 		return nil, err
 	}
 
+	if config.Subset != nil {
+		out.subsetLabelKeys, out.loadBalancingPolicy, err = buildSubsetPolicy(config.Subset, out.loadBalancingPolicy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return out, nil
 }
 
@@ -224,6 +267,52 @@ func buildRandomPolicy(config *kgateway.LoadBalancer) (*envoyclusterv3.LoadBalan
 	}, nil
 }
 
+// buildSubsetPolicy wraps the load balancing policy into a subset load balancing policy. Routes don't
+// select subsets, so requests always fall back to the default subset, i.e. the endpoints matching the selector.
+func buildSubsetPolicy(config *kgateway.LoadBalancerSubsetConfig, child *envoyclusterv3.LoadBalancingPolicy) ([]string, *envoyclusterv3.LoadBalancingPolicy, error) {
+	keys := slices.Sorted(maps.Keys(config.Selector))
+	defaultSubset := &structpb.Struct{Fields: make(map[string]*structpb.Value, len(keys))}
+	for _, k := range keys {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return nil, nil, fmt.Errorf("invalid subset selector label key %q: %s", k, strings.Join(errs, ", "))
+		}
+		v := config.Selector[k]
+		if errs := validation.IsValidLabelValue(v); len(errs) > 0 {
+			return nil, nil, fmt.Errorf("invalid subset selector value %q for label %q: %s", v, k, strings.Join(errs, ", "))
+		}
+		defaultSubset.Fields[k] = structpb.NewStringValue(v)
+	}
+
+	if child == nil {
+		// the subset load balancer needs a policy to pick the endpoints within the subset
+		var err error
+		child, err = buildRoundRobinPolicy(&kgateway.LoadBalancer{RoundRobin: &kgateway.LoadBalancerRoundRobinConfig{}}, "", "")
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	subsetAny, err := utils.MessageToAny(&envoysubsetv3.Subset{
+		FallbackPolicy:  envoysubsetv3.Subset_DEFAULT_SUBSET,
+		DefaultSubset:   defaultSubset,
+		SubsetSelectors: []*envoysubsetv3.Subset_LbSubsetSelector{{Keys: keys}},
+		// when no endpoint matches the default subset, fall back to all the endpoints
+		PanicModeAny:   config.FallbackPolicy != kgateway.SubsetFallbackPolicyNone,
+		SubsetLbPolicy: child,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return keys, &envoyclusterv3.LoadBalancingPolicy{
+		Policies: []*envoyclusterv3.LoadBalancingPolicy_Policy{{
+			TypedExtensionConfig: &envoycorev3.TypedExtensionConfig{
+				Name:        "envoy.load_balancing_policies.subset",
+				TypedConfig: subsetAny,
+			},
+		}},
+	}, nil
+}
+
 func applyLoadBalancerConfig(config *LoadBalancerConfigIR, out *envoyclusterv3.Cluster) {
 	if config == nil {
 		return
@@ -279,6 +368,22 @@ func disableUseHostnameForHashingIfPresent(typedCfg *envoycorev3.TypedExtensionC
 		return
 	}
 	switch m := msg.(type) {
+	case *envoysubsetv3.Subset:
+		// the hashing policy is the policy of the subsets
+		if len(m.GetSubsetLbPolicy().GetPolicies()) == 0 {
+			return
+		}
+		child := m.GetSubsetLbPolicy().GetPolicies()[0].GetTypedExtensionConfig()
+		before := proto.Clone(child)
+		disableUseHostnameForHashingIfPresent(child)
+		if proto.Equal(before, child) {
+			return
+		}
+		if anyMsg, err := utils.MessageToAny(m); err == nil {
+			typedCfg.TypedConfig = anyMsg
+		} else {
+			logger.Error("failed to re-pack Subset after mutating its load balancing policy", "error", err)
+		}
 	case *envoyringhashv3.RingHash:
 		if m.ConsistentHashingLbConfig != nil && m.ConsistentHashingLbConfig.UseHostnameForHashing {
 			m.ConsistentHashingLbConfig.UseHostnameForHashing = false
@@ -350,6 +455,15 @@ func (a *LoadBalancerConfigIR) Equals(b *LoadBalancerConfigIR) bool {
 	if !proto.Equal(a.loadBalancingPolicy, b.loadBalancingPolicy) {
 		return false
 	}
+	if !a.zoneAware.Equals(b.zoneAware) {
+		return false
+	}
+	if !slices.Equal(a.subsetLabelKeys, b.subsetLabelKeys) {
+		return false
+	}
+	if a.consistentHashingField != b.consistentHashingField {
+		return false
+	}
 
 	return true
 }
@@ -1,6 +1,8 @@
 package backendconfigpolicy
 
 import (
+	"maps"
+	"slices"
 	"testing"
 	"time"
 
@@ -14,10 +16,12 @@ import (
 	randomv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/load_balancing_policies/random/v3"
 	ringhashv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/load_balancing_policies/ring_hash/v3"
 	roundrobinv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/load_balancing_policies/round_robin/v3"
+	subsetv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/load_balancing_policies/subset/v3"
 	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -545,6 +549,87 @@ func TestApplyLoadBalancerConfig(t *testing.T) {
 				}
 			}(),
 		},
+		{
+			name: "Subset with the default load balancing policy",
+			config: &kgateway.LoadBalancer{
+				Subset: &kgateway.LoadBalancerSubsetConfig{
+					Selector:       map[string]string{"version": "v2", "app.kubernetes.io/name": "reviews"},
+					FallbackPolicy: kgateway.SubsetFallbackPolicyAllEndpoints,
+				},
+			},
+			expected: func() *envoyclusterv3.Cluster {
+				roundRobin, _ := utils.MessageToAny(&roundrobinv3.RoundRobin{})
+				msg, _ := utils.MessageToAny(&subsetv3.Subset{
+					FallbackPolicy: subsetv3.Subset_DEFAULT_SUBSET,
+					DefaultSubset: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"app.kubernetes.io/name": structpb.NewStringValue("reviews"),
+						"version":                structpb.NewStringValue("v2"),
+					}},
+					SubsetSelectors: []*subsetv3.Subset_LbSubsetSelector{{Keys: []string{"app.kubernetes.io/name", "version"}}},
+					PanicModeAny:    true,
+					SubsetLbPolicy: &envoyclusterv3.LoadBalancingPolicy{
+						Policies: []*envoyclusterv3.LoadBalancingPolicy_Policy{{
+							TypedExtensionConfig: &envoycorev3.TypedExtensionConfig{
+								Name:        "envoy.load_balancing_policies.round_robin",
+								TypedConfig: roundRobin,
+							},
+						}},
+					},
+				})
+				return &envoyclusterv3.Cluster{
+					Name: "test",
+					LoadBalancingPolicy: &envoyclusterv3.LoadBalancingPolicy{
+						Policies: []*envoyclusterv3.LoadBalancingPolicy_Policy{{
+							TypedExtensionConfig: &envoycorev3.TypedExtensionConfig{
+								Name:        "envoy.load_balancing_policies.subset",
+								TypedConfig: msg,
+							},
+						}},
+					},
+					CommonLbConfig: &envoyclusterv3.Cluster_CommonLbConfig{},
+				}
+			}(),
+		},
+		{
+			name: "Subset with Maglev and no fallback",
+			config: &kgateway.LoadBalancer{
+				Maglev: &kgateway.LoadBalancerMaglevConfig{},
+				Subset: &kgateway.LoadBalancerSubsetConfig{
+					Selector:       map[string]string{"version": "v2"},
+					FallbackPolicy: kgateway.SubsetFallbackPolicyNone,
+				},
+			},
+			expected: func() *envoyclusterv3.Cluster {
+				maglev, _ := utils.MessageToAny(&maglevv3.Maglev{})
+				msg, _ := utils.MessageToAny(&subsetv3.Subset{
+					FallbackPolicy: subsetv3.Subset_DEFAULT_SUBSET,
+					DefaultSubset: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"version": structpb.NewStringValue("v2"),
+					}},
+					SubsetSelectors: []*subsetv3.Subset_LbSubsetSelector{{Keys: []string{"version"}}},
+					SubsetLbPolicy: &envoyclusterv3.LoadBalancingPolicy{
+						Policies: []*envoyclusterv3.LoadBalancingPolicy_Policy{{
+							TypedExtensionConfig: &envoycorev3.TypedExtensionConfig{
+								Name:        "envoy.load_balancing_policies.maglev",
+								TypedConfig: maglev,
+							},
+						}},
+					},
+				})
+				return &envoyclusterv3.Cluster{
+					Name: "test",
+					LoadBalancingPolicy: &envoyclusterv3.LoadBalancingPolicy{
+						Policies: []*envoyclusterv3.LoadBalancingPolicy_Policy{{
+							TypedExtensionConfig: &envoycorev3.TypedExtensionConfig{
+								Name:        "envoy.load_balancing_policies.subset",
+								TypedConfig: msg,
+							},
+						}},
+					},
+					CommonLbConfig: &envoyclusterv3.Cluster_CommonLbConfig{},
+				}
+			}(),
+		},
 	}
 
 	for _, test := range tests {
@@ -566,6 +651,42 @@ func TestApplyLoadBalancerConfig(t *testing.T) {
 	}
 }
 
+func TestSubsetSelectorValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector map[string]string
+		err      string
+	}{
+		{
+			name:     "valid selector",
+			selector: map[string]string{"version": "v2", "example.com/track": "canary"},
+		},
+		{
+			name:     "invalid label key",
+			selector: map[string]string{"-version": "v2"},
+			err:      `invalid subset selector label key "-version"`,
+		},
+		{
+			name:     "invalid label value",
+			selector: map[string]string{"version": "v2/canary"},
+			err:      `invalid subset selector value "v2/canary" for label "version"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lbConfig, err := translateLoadBalancerConfig(&kgateway.LoadBalancer{
+				Subset: &kgateway.LoadBalancerSubsetConfig{Selector: test.selector},
+			}, "policy", "default")
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, slices.Sorted(maps.Keys(test.selector)), lbConfig.subsetLabelKeys)
+		})
+	}
+}
+
 func TestConstructHashPolicy(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -2,10 +2,14 @@ package backendconfigpolicy
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
 	"time"
 
 	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoycommondnsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/common/dns/v3"
 	envoydnsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/dns/v3"
 	envoyproxyprotocolv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/proxy_protocol/v3"
 	envoyrawbufferv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/raw_buffer/v3"
@@ -19,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/endpoints"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
@@ -45,9 +50,11 @@ type BackendConfigPolicyIR struct {
 	connectTimeout                *durationpb.Duration
 	perConnectionBufferLimitBytes *uint32
 	tcpKeepalive                  *envoycorev3.TcpKeepalive
+	dualStackConnect              *bool
 	commonHttpProtocolOptions     *envoycorev3.HttpProtocolOptions
 	http1ProtocolOptions          *envoycorev3.Http1ProtocolOptions
 	http2ProtocolOptions          *envoycorev3.Http2ProtocolOptions
+	upstreamProtocol              *kgateway.UpstreamProtocol
 	tlsConfig                     *envoytlsv3.UpstreamTlsContext
 	loadBalancerConfig            *LoadBalancerConfigIR
 	healthCheck                   *envoycorev3.HealthCheck
@@ -57,6 +64,7 @@ type BackendConfigPolicyIR struct {
 	dnsJitter                     *durationpb.Duration
 	respectDnsTtl                 *bool
 	upstreamProxyProtocol         *envoycorev3.ProxyProtocolConfig
+	unreadyEndpointFallback       *bool
 }
 
 var logger = logging.New("plugin/backendconfigpolicy")
@@ -101,6 +109,10 @@ func (d *BackendConfigPolicyIR) Equals(other any) bool {
 		return false
 	}
 
+	if !cmputils.PointerValsEqual(d.upstreamProtocol, d2.upstreamProtocol) {
+		return false
+	}
+
 	if !proto.Equal(d.tlsConfig, d2.tlsConfig) {
 		return false
 	}
@@ -133,9 +145,16 @@ func (d *BackendConfigPolicyIR) Equals(other any) bool {
 	if !cmputils.PointerValsEqual(d.respectDnsTtl, d2.respectDnsTtl) {
 		return false
 	}
+
+	if !cmputils.PointerValsEqual(d.dualStackConnect, d2.dualStackConnect) {
+		return false
+	}
 	if !proto.Equal(d.upstreamProxyProtocol, d2.upstreamProxyProtocol) {
 		return false
 	}
+	if !cmputils.PointerValsEqual(d.unreadyEndpointFallback, d2.unreadyEndpointFallback) {
+		return false
+	}
 	return true
 }
 
@@ -205,6 +224,7 @@ func NewPlugin(ctx context.Context, commoncol *collections.CommonCollections, v
 				Policies:                        backendConfigPolicyCol,
 				ProcessPolicyStaleStatusMarkers: processMarkers,
 				ProcessBackend:                  processBackend,
+				PerClientProcessEndpoints:       processEndpointsFn(commoncol),
 				GetPolicyStatus:                 getPolicyStatusFn(cli),
 				PatchPolicyStatus:               patchPolicyStatusFn(cli),
 			},
@@ -223,14 +243,21 @@ func processBackend(_ context.Context, polir ir.PolicyIR, backend ir.BackendObje
 	}
 
 	if pol.tcpKeepalive != nil {
-		out.UpstreamConnectionOptions = &envoyclusterv3.UpstreamConnectionOptions{
-			TcpKeepalive: pol.tcpKeepalive,
+		upstreamConnectionOptions(out).TcpKeepalive = pol.tcpKeepalive
+	}
+
+	if dualStackConnect(pol) {
+		upstreamConnectionOptions(out).HappyEyeballsConfig = &envoyclusterv3.UpstreamConnectionOptions_HappyEyeballsConfig{
+			FirstAddressFamilyVersion: envoyclusterv3.UpstreamConnectionOptions_V6,
+			FirstAddressFamilyCount:   wrapperspb.UInt32(1),
 		}
 	}
 
 	applyCommonHttpProtocolOptions(pol.commonHttpProtocolOptions, backend, out)
-	applyHttp1ProtocolOptions(pol.http1ProtocolOptions, backend, out)
-	applyHttp2ProtocolOptions(pol.http2ProtocolOptions, backend, out)
+	if pol.upstreamProtocol == nil {
+		applyHttp1ProtocolOptions(pol.http1ProtocolOptions, backend, out)
+		applyHttp2ProtocolOptions(pol.http2ProtocolOptions, backend, out)
+	}
 
 	if pol.tlsConfig != nil {
 		typedConfig, err := utils.MessageToAny(pol.tlsConfig)
@@ -246,6 +273,11 @@ func processBackend(_ context.Context, polir ir.PolicyIR, backend ir.BackendObje
 		}
 	}
 
+	// Apply the upstream protocol after TLS since the protocol can only be negotiated via ALPN with TLS
+	if pol.upstreamProtocol != nil {
+		applyUpstreamProtocol(*pol.upstreamProtocol, pol.http1ProtocolOptions, pol.http2ProtocolOptions, backend, out)
+	}
+
 	// Apply upstream proxy protocol after TLS so it can wrap the existing
 	// transport socket. ProxyProtocolUpstreamTransport requires a non-nil
 	// inner transport socket. If TLS is configured it wraps that, otherwise
@@ -271,6 +303,82 @@ func processBackend(_ context.Context, polir ir.PolicyIR, backend ir.BackendObje
 	applyDnsClusterConfig(pol, out)
 }
 
+// processEndpointsFn returns the endpoint plugin that applies the endpoint settings of the
+// BackendConfigPolicies attached to a backend: the fallback to unready endpoints, zone-aware routing
+// and the endpoint labels used by subset load balancing.
+func processEndpointsFn(commoncol *collections.CommonCollections) sdk.EndpointPlugin {
+	gk := wellknown.BackendConfigPolicyGVK.GroupKind()
+	return func(kctx krt.HandlerContext, _ context.Context, ucc ir.UniqlyConnectedClient, out *endpoints.EndpointsInputs) uint64 {
+		// the backend index is only set once all plugins are initialized
+		if commoncol.BackendIndex == nil {
+			return 0
+		}
+		backend := fetchBackendWithPolicy(kctx, commoncol.BackendIndex, out.EndpointsForBackend.UpstreamResourceName)
+		if backend == nil {
+			return 0
+		}
+		// policies are applied in order, so the last policy that sets a field wins
+		var fallback *bool
+		var zoneAware *zoneAwareConfig
+		var subsetLabelKeys []string
+		for _, polAttachment := range backend.AttachedPolicies.Policies[gk] {
+			pol, ok := polAttachment.PolicyIr.(*BackendConfigPolicyIR)
+			if !ok || len(polAttachment.Errors) > 0 {
+				continue
+			}
+			if pol.unreadyEndpointFallback != nil {
+				fallback = pol.unreadyEndpointFallback
+			}
+			if pol.loadBalancerConfig != nil && pol.loadBalancerConfig.zoneAware != nil {
+				zoneAware = pol.loadBalancerConfig.zoneAware
+			}
+			if pol.loadBalancerConfig != nil && len(pol.loadBalancerConfig.subsetLabelKeys) > 0 {
+				subsetLabelKeys = pol.loadBalancerConfig.subsetLabelKeys
+			}
+		}
+
+		var hash uint64
+		if fallback != nil && *fallback {
+			out.UnreadyEndpointFallback = true
+			hash ^= unreadyEndpointFallbackHash
+		}
+		// endpoints can only be prioritized for proxies whose locality is known, and priorities
+		// already set by other plugins, e.g. from a DestinationRule, take precedence
+		if zoneAware != nil && ucc.Locality != (ir.PodLocality{}) && out.PriorityInfo == nil {
+			out.PriorityInfo = &endpoints.PriorityInfo{}
+			out.OverprovisioningFactor = zoneAware.overprovisioningFactor
+			hash ^= zoneAwareHash ^ uint64(zoneAware.overprovisioningFactor.GetValue())
+		}
+		if len(subsetLabelKeys) > 0 {
+			out.SubsetLabelKeys = subsetLabelKeys
+			h := fnv.New64a()
+			for _, k := range subsetLabelKeys {
+				h.Write([]byte(k))
+				h.Write([]byte{0})
+			}
+			hash ^= subsetHash ^ h.Sum64()
+		}
+		return hash
+	}
+}
+
+// unreadyEndpointFallbackHash, zoneAwareHash and subsetHash are mixed into the hash of the endpoints of the
+// backends that use these settings, so that their ClusterLoadAssignment is recomputed when they change.
+const (
+	unreadyEndpointFallbackHash uint64 = 0x756e7265616479
+	zoneAwareHash               uint64 = 0x7a6f6e6561776172
+	subsetHash                  uint64 = 0x737562736574
+)
+
+func fetchBackendWithPolicy(kctx krt.HandlerContext, backendIndex *krtcollections.BackendIndex, resourceName string) *ir.BackendObjectIR {
+	for _, backends := range backendIndex.BackendsWithPolicy() {
+		if backend := krt.FetchOne(kctx, backends, krt.FilterKey(resourceName)); backend != nil {
+			return *backend
+		}
+	}
+	return nil
+}
+
 func translate(
 	commoncol *collections.CommonCollections,
 	krtctx krt.HandlerContext,
@@ -289,7 +397,11 @@ func translate(
 	}
 
 	if pol.Spec.TCPKeepalive != nil {
-		ir.tcpKeepalive = translateTCPKeepalive(pol.Spec.TCPKeepalive)
+		if err := validateTCPKeepalive(pol.Spec.TCPKeepalive); err != nil {
+			errs = append(errs, err)
+		} else {
+			ir.tcpKeepalive = translateTCPKeepalive(pol.Spec.TCPKeepalive)
+		}
 	}
 
 	if pol.Spec.CommonHttpProtocolOptions != nil {
@@ -308,6 +420,14 @@ func translate(
 		ir.http2ProtocolOptions = translateHttp2ProtocolOptions(pol.Spec.Http2ProtocolOptions)
 	}
 
+	if pol.Spec.UpstreamProtocol != nil {
+		if err := validateUpstreamProtocol(*pol.Spec.UpstreamProtocol); err != nil {
+			errs = append(errs, err)
+		} else {
+			ir.upstreamProtocol = pol.Spec.UpstreamProtocol
+		}
+	}
+
 	if pol.Spec.TLS != nil {
 		tlsConfig, err := translateTLSConfig(NewDefaultSecretGetter(commoncol.Secrets, krtctx), pol.Spec.TLS, pol.Namespace)
 		if err != nil {
@@ -339,6 +459,15 @@ func translate(
 		}
 	}
 
+	if pol.Spec.ConnectionPool != nil {
+		if err := validateConnectionPool(pol.Spec.ConnectionPool); err != nil {
+			errs = append(errs, err)
+		} else {
+			ir.commonHttpProtocolOptions, ir.circuitBreakers = applyConnectionPool(
+				pol.Spec.ConnectionPool, ir.commonHttpProtocolOptions, ir.circuitBreakers)
+		}
+	}
+
 	if pol.Spec.DNS != nil {
 		if pol.Spec.DNS.RefreshRate != nil {
 			ir.dnsRefreshRate = durationpb.New(pol.Spec.DNS.RefreshRate.Duration)
@@ -351,11 +480,25 @@ func translate(
 	if pol.Spec.UpstreamProxyProtocol != nil {
 		ir.upstreamProxyProtocol = translateUpstreamProxyProtocol(pol.Spec.UpstreamProxyProtocol)
 	}
+	ir.unreadyEndpointFallback = pol.Spec.UnreadyEndpointFallback
+	ir.dualStackConnect = pol.Spec.DualStackConnect
 	return &ir, errs
 }
 
+// upstreamConnectionOptions returns the upstream connection options of the cluster, initializing them if needed.
+func upstreamConnectionOptions(out *envoyclusterv3.Cluster) *envoyclusterv3.UpstreamConnectionOptions {
+	if out.GetUpstreamConnectionOptions() == nil {
+		out.UpstreamConnectionOptions = &envoyclusterv3.UpstreamConnectionOptions{}
+	}
+	return out.GetUpstreamConnectionOptions()
+}
+
+func dualStackConnect(pol *BackendConfigPolicyIR) bool {
+	return pol.dualStackConnect != nil && *pol.dualStackConnect
+}
+
 func applyDnsClusterConfig(pol *BackendConfigPolicyIR, out *envoyclusterv3.Cluster) {
-	if pol.dnsRefreshRate == nil && pol.dnsJitter == nil && pol.respectDnsTtl == nil {
+	if pol.dnsRefreshRate == nil && pol.dnsJitter == nil && pol.respectDnsTtl == nil && !dualStackConnect(pol) {
 		return
 	}
 
@@ -379,6 +522,10 @@ func applyDnsClusterConfig(pol *BackendConfigPolicyIR, out *envoyclusterv3.Clust
 	if pol.respectDnsTtl != nil {
 		dnsCluster.RespectDnsTtl = *pol.respectDnsTtl
 	}
+	// resolve both address families so that the hosts have addresses to race connection attempts across
+	if dualStackConnect(pol) {
+		dnsCluster.DnsLookupFamily = envoycommondnsv3.DnsLookupFamily_ALL
+	}
 
 	typedConfig, err := utils.MessageToAny(dnsCluster)
 	if err != nil {
@@ -435,6 +582,22 @@ func applyUpstreamProxyProtocol(ppConfig *envoycorev3.ProxyProtocolConfig, out *
 	}
 }
 
+// validateTCPKeepalive checks that the keepalive settings are positive. Envoy configures them in whole seconds,
+// so the durations must be at least one second.
+func validateTCPKeepalive(tcpKeepalive *kgateway.TCPKeepalive) error {
+	var errs []error
+	if tcpKeepalive.KeepAliveProbes != nil && *tcpKeepalive.KeepAliveProbes < 1 {
+		errs = append(errs, fmt.Errorf("tcpKeepalive.keepAliveProbes must be positive, got %d", *tcpKeepalive.KeepAliveProbes))
+	}
+	if tcpKeepalive.KeepAliveTime != nil && tcpKeepalive.KeepAliveTime.Duration < time.Second {
+		errs = append(errs, fmt.Errorf("tcpKeepalive.keepAliveTime must be at least 1s, got %s", tcpKeepalive.KeepAliveTime.Duration))
+	}
+	if tcpKeepalive.KeepAliveInterval != nil && tcpKeepalive.KeepAliveInterval.Duration < time.Second {
+		errs = append(errs, fmt.Errorf("tcpKeepalive.keepAliveInterval must be at least 1s, got %s", tcpKeepalive.KeepAliveInterval.Duration))
+	}
+	return errors.Join(errs...)
+}
+
 func translateTCPKeepalive(tcpKeepalive *kgateway.TCPKeepalive) *envoycorev3.TcpKeepalive {
 	out := &envoycorev3.TcpKeepalive{}
 	if tcpKeepalive.KeepAliveProbes != nil {
@@ -7,6 +7,7 @@ import (
 
 	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoycommondnsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/common/dns/v3"
 	envoydnsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/dns/v3"
 	preserve_case_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/header_formatters/preserve_case/v3"
 	envoyproxyprotocolv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/proxy_protocol/v3"
@@ -20,12 +21,23 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
+	"istio.io/istio/pkg/kube/krt"
+	"istio.io/istio/pkg/kube/krt/krttest"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/endpoints"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
 )
 
 func TestBackendConfigPolicyTranslation(t *testing.T) {
@@ -285,6 +297,97 @@ func TestBackendConfigPolicyTranslation(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "connection pool",
+			policy: &kgateway.BackendConfigPolicy{
+				Spec: kgateway.BackendConfigPolicySpec{
+					CommonHttpProtocolOptions: &kgateway.CommonHttpProtocolOptions{
+						MaxHeadersCount: new(int32(50)),
+					},
+					CircuitBreakers: &kgateway.CircuitBreakers{
+						MaxRequests: new(int32(200)),
+					},
+					ConnectionPool: &kgateway.ConnectionPool{
+						HTTP: &kgateway.HTTPConnectionPool{
+							MaxRequestsPerConnection: new(int32(10)),
+							IdleTimeout:              new(metav1.Duration{Duration: 90 * time.Second}),
+						},
+						TCP: &kgateway.TCPConnectionPool{
+							MaxConnections: new(int32(64)),
+						},
+					},
+				},
+			},
+			want: &envoyclusterv3.Cluster{
+				TypedExtensionProtocolOptions: map[string]*anypb.Any{
+					"envoy.extensions.upstreams.http.v3.HttpProtocolOptions": mustMessageToAny(t, &envoy_upstreams_http_v3.HttpProtocolOptions{
+						CommonHttpProtocolOptions: &envoycorev3.HttpProtocolOptions{
+							IdleTimeout:              durationpb.New(90 * time.Second),
+							MaxHeadersCount:          &wrapperspb.UInt32Value{Value: 50},
+							MaxRequestsPerConnection: &wrapperspb.UInt32Value{Value: 10},
+						},
+						UpstreamProtocolOptions: &envoy_upstreams_http_v3.HttpProtocolOptions_ExplicitHttpConfig_{
+							ExplicitHttpConfig: &envoy_upstreams_http_v3.HttpProtocolOptions_ExplicitHttpConfig{
+								ProtocolConfig: &envoy_upstreams_http_v3.HttpProtocolOptions_ExplicitHttpConfig_HttpProtocolOptions{},
+							},
+						},
+					}),
+				},
+				CircuitBreakers: &envoyclusterv3.CircuitBreakers{
+					Thresholds: []*envoyclusterv3.CircuitBreakers_Thresholds{
+						{
+							MaxConnections: &wrapperspb.UInt32Value{Value: 64},
+							MaxRequests:    &wrapperspb.UInt32Value{Value: 200},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "tcp connection pool only",
+			policy: &kgateway.BackendConfigPolicy{
+				Spec: kgateway.BackendConfigPolicySpec{
+					ConnectionPool: &kgateway.ConnectionPool{
+						TCP: &kgateway.TCPConnectionPool{
+							MaxConnections: new(int32(0)),
+						},
+					},
+				},
+			},
+			want: &envoyclusterv3.Cluster{
+				CircuitBreakers: &envoyclusterv3.CircuitBreakers{
+					Thresholds: []*envoyclusterv3.CircuitBreakers_Thresholds{
+						{MaxConnections: &wrapperspb.UInt32Value{Value: 0}},
+					},
+				},
+			},
+		},
+		{
+			name: "connection pool with negative max requests per connection",
+			policy: &kgateway.BackendConfigPolicy{
+				Spec: kgateway.BackendConfigPolicySpec{
+					ConnectionPool: &kgateway.ConnectionPool{
+						HTTP: &kgateway.HTTPConnectionPool{
+							MaxRequestsPerConnection: new(int32(-1)),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "connection pool with zero idle timeout",
+			policy: &kgateway.BackendConfigPolicy{
+				Spec: kgateway.BackendConfigPolicySpec{
+					ConnectionPool: &kgateway.ConnectionPool{
+						HTTP: &kgateway.HTTPConnectionPool{
+							IdleTimeout: new(metav1.Duration{}),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "upstream proxy protocol V1 without TLS",
 			policy: &kgateway.BackendConfigPolicy{
@@ -532,3 +635,260 @@ func mustMessageToAny(t *testing.T, msg proto.Message) *anypb.Any {
 	require.NoError(t, err, "failed to convert message to Any")
 	return a
 }
+
+func TestUnreadyEndpointFallback(t *testing.T) {
+	gk := wellknown.BackendConfigPolicyGVK.GroupKind()
+	backend := func(name string) ir.BackendObjectIR {
+		b := ir.NewBackendObjectIR(ir.ObjectSource{Kind: "Service", Namespace: "ns", Name: name}, 8080, "")
+		b.Obj = &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"}}
+		return b
+	}
+	policy := func(name, target string, fallback *bool) ir.PolicyWrapper {
+		return ir.PolicyWrapper{
+			ObjectSource: ir.ObjectSource{Group: gk.Group, Kind: gk.Kind, Namespace: "ns", Name: name},
+			Policy:       &kgateway.BackendConfigPolicy{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"}},
+			PolicyIR:     &BackendConfigPolicyIR{unreadyEndpointFallback: fallback},
+			TargetRefs:   []ir.PolicyRef{{Kind: "Service", Name: target}},
+		}
+	}
+
+	mock := krttest.NewMock(t, []any{
+		backend("fallback"),
+		backend("no-fallback"),
+		backend("no-policy"),
+		policy("fallback", "fallback", new(true)),
+		policy("no-fallback", "no-fallback", new(false)),
+	})
+	backends := krttest.GetMockCollection[ir.BackendObjectIR](mock)
+	policies := krtcollections.NewPolicyIndex(krtutil.KrtOptions{}, sdk.ContributesPolicies{
+		gk: {
+			Policies:       krttest.GetMockCollection[ir.PolicyWrapper](mock),
+			ProcessBackend: processBackend,
+		},
+	}, apisettings.Settings{})
+	refgrants := krtcollections.NewRefGrantIndex(krttest.GetMockCollection[*gwv1b1.ReferenceGrant](mock))
+	backendIndex := krtcollections.NewBackendIndex(krtutil.KrtOptions{}, policies, refgrants)
+	backendIndex.AddBackends(wellknown.ServiceGVK.GroupKind(), backends)
+	for !backendIndex.HasSynced() {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	processEndpoints := processEndpointsFn(&collections.CommonCollections{BackendIndex: backendIndex})
+	for name, want := range map[string]bool{"fallback": true, "no-fallback": false, "no-policy": false} {
+		t.Run(name, func(t *testing.T) {
+			out := &endpoints.EndpointsInputs{EndpointsForBackend: *ir.NewEndpointsForBackend(backend(name))}
+			hash := processEndpoints(krt.TestingDummyContext{}, context.Background(), ir.UniqlyConnectedClient{}, out)
+			assert.Equal(t, want, out.UnreadyEndpointFallback)
+			assert.Equal(t, want, hash != 0)
+		})
+	}
+}
+
+func TestZoneAwareEndpoints(t *testing.T) {
+	gk := wellknown.BackendConfigPolicyGVK.GroupKind()
+	backend := func(name string) ir.BackendObjectIR {
+		b := ir.NewBackendObjectIR(ir.ObjectSource{Kind: "Service", Namespace: "ns", Name: name}, 8080, "")
+		b.Obj = &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"}}
+		return b
+	}
+	policy := func(name string, zoneAware *kgateway.ZoneAwareLoadBalancing) ir.PolicyWrapper {
+		lbConfig, err := translateLoadBalancerConfig(&kgateway.LoadBalancer{
+			RoundRobin: &kgateway.LoadBalancerRoundRobinConfig{},
+			ZoneAware:  zoneAware,
+		}, name, "ns")
+		require.NoError(t, err)
+		return ir.PolicyWrapper{
+			ObjectSource: ir.ObjectSource{Group: gk.Group, Kind: gk.Kind, Namespace: "ns", Name: name},
+			Policy:       &kgateway.BackendConfigPolicy{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"}},
+			PolicyIR:     &BackendConfigPolicyIR{loadBalancerConfig: lbConfig},
+			TargetRefs:   []ir.PolicyRef{{Kind: "Service", Name: name}},
+		}
+	}
+
+	mock := krttest.NewMock(t, []any{
+		backend("zone-aware"),
+		backend("threshold"),
+		backend("not-zone-aware"),
+		policy("zone-aware", &kgateway.ZoneAwareLoadBalancing{}),
+		policy("threshold", &kgateway.ZoneAwareLoadBalancing{FailoverThreshold: new(int32(80))}),
+		policy("not-zone-aware", nil),
+	})
+	backends := krttest.GetMockCollection[ir.BackendObjectIR](mock)
+	policies := krtcollections.NewPolicyIndex(krtutil.KrtOptions{}, sdk.ContributesPolicies{
+		gk: {
+			Policies:       krttest.GetMockCollection[ir.PolicyWrapper](mock),
+			ProcessBackend: processBackend,
+		},
+	}, apisettings.Settings{})
+	refgrants := krtcollections.NewRefGrantIndex(krttest.GetMockCollection[*gwv1b1.ReferenceGrant](mock))
+	backendIndex := krtcollections.NewBackendIndex(krtutil.KrtOptions{}, policies, refgrants)
+	backendIndex.AddBackends(wellknown.ServiceGVK.GroupKind(), backends)
+	for !backendIndex.HasSynced() {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	processEndpoints := processEndpointsFn(&collections.CommonCollections{BackendIndex: backendIndex})
+	zonalClient := ir.NewUniqlyConnectedClient("gw", "ns", nil, ir.PodLocality{Region: "region", Zone: "zone"})
+
+	tests := []struct {
+		name       string
+		ucc        ir.UniqlyConnectedClient
+		prioritize bool
+		factor     uint32
+	}{
+		{name: "zone-aware", ucc: zonalClient, prioritize: true},
+		{name: "threshold", ucc: zonalClient, prioritize: true, factor: 125},
+		{name: "not-zone-aware", ucc: zonalClient},
+		// endpoints can't be prioritized when the locality of the proxy is unknown
+		{name: "zone-aware", ucc: ir.UniqlyConnectedClient{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &endpoints.EndpointsInputs{EndpointsForBackend: *ir.NewEndpointsForBackend(backend(tt.name))}
+			hash := processEndpoints(krt.TestingDummyContext{}, context.Background(), tt.ucc, out)
+			assert.Equal(t, tt.prioritize, out.PriorityInfo != nil)
+			assert.Equal(t, tt.prioritize, hash != 0)
+			assert.Equal(t, tt.factor, out.OverprovisioningFactor.GetValue())
+		})
+	}
+
+	// priorities set by other plugins are kept
+	priorityInfo := &endpoints.PriorityInfo{FailoverPriority: endpoints.NewPriorities([]string{"topology.istio.io/network"})}
+	out := &endpoints.EndpointsInputs{EndpointsForBackend: *ir.NewEndpointsForBackend(backend("threshold")), PriorityInfo: priorityInfo}
+	processEndpoints(krt.TestingDummyContext{}, context.Background(), zonalClient, out)
+	assert.Same(t, priorityInfo, out.PriorityInfo)
+	assert.Nil(t, out.OverprovisioningFactor)
+}
+
+func TestTCPKeepalive(t *testing.T) {
+	t.Run("renders the keepalive of the upstream connections", func(t *testing.T) {
+		policyIR, errs := translate(nil, nil, &kgateway.BackendConfigPolicy{
+			Spec: kgateway.BackendConfigPolicySpec{
+				TCPKeepalive: &kgateway.TCPKeepalive{
+					KeepAliveProbes:   new(int32(5)),
+					KeepAliveTime:     new(metav1.Duration{Duration: 2 * time.Minute}),
+					KeepAliveInterval: new(metav1.Duration{Duration: 1500 * time.Millisecond}),
+				},
+			},
+		})
+		require.Empty(t, errs)
+
+		cluster := &envoyclusterv3.Cluster{}
+		processBackend(context.Background(), policyIR, ir.BackendObjectIR{}, cluster)
+		assert.True(t, proto.Equal(&envoycorev3.TcpKeepalive{
+			KeepaliveProbes:   wrapperspb.UInt32(5),
+			KeepaliveTime:     wrapperspb.UInt32(120),
+			KeepaliveInterval: wrapperspb.UInt32(1),
+		}, cluster.GetUpstreamConnectionOptions().GetTcpKeepalive()), cluster.GetUpstreamConnectionOptions().String())
+	})
+
+	t.Run("unset fields use the OS defaults", func(t *testing.T) {
+		policyIR, errs := translate(nil, nil, &kgateway.BackendConfigPolicy{
+			Spec: kgateway.BackendConfigPolicySpec{
+				TCPKeepalive: &kgateway.TCPKeepalive{},
+			},
+		})
+		require.Empty(t, errs)
+
+		cluster := &envoyclusterv3.Cluster{}
+		processBackend(context.Background(), policyIR, ir.BackendObjectIR{}, cluster)
+		require.NotNil(t, cluster.GetUpstreamConnectionOptions().GetTcpKeepalive())
+		assert.True(t, proto.Equal(&envoycorev3.TcpKeepalive{}, cluster.GetUpstreamConnectionOptions().GetTcpKeepalive()))
+	})
+
+	t.Run("rejects values that are not positive", func(t *testing.T) {
+		policyIR, errs := translate(nil, nil, &kgateway.BackendConfigPolicy{
+			Spec: kgateway.BackendConfigPolicySpec{
+				TCPKeepalive: &kgateway.TCPKeepalive{
+					KeepAliveProbes:   new(int32(0)),
+					KeepAliveTime:     new(metav1.Duration{Duration: 500 * time.Millisecond}),
+					KeepAliveInterval: new(metav1.Duration{Duration: -time.Second}),
+				},
+			},
+		})
+		require.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], "tcpKeepalive.keepAliveProbes must be positive, got 0\n"+
+			"tcpKeepalive.keepAliveTime must be at least 1s, got 500ms\n"+
+			"tcpKeepalive.keepAliveInterval must be at least 1s, got -1s")
+		assert.Nil(t, policyIR.tcpKeepalive)
+
+		cluster := &envoyclusterv3.Cluster{}
+		processBackend(context.Background(), policyIR, ir.BackendObjectIR{}, cluster)
+		assert.Nil(t, cluster.GetUpstreamConnectionOptions())
+	})
+}
+
+func TestDualStackConnect(t *testing.T) {
+	happyEyeballs := &envoyclusterv3.UpstreamConnectionOptions_HappyEyeballsConfig{
+		FirstAddressFamilyVersion: envoyclusterv3.UpstreamConnectionOptions_V6,
+		FirstAddressFamilyCount:   wrapperspb.UInt32(1),
+	}
+	dnsCluster := func(t *testing.T) *envoyclusterv3.Cluster {
+		return &envoyclusterv3.Cluster{
+			ClusterDiscoveryType: &envoyclusterv3.Cluster_ClusterType{
+				ClusterType: &envoyclusterv3.Cluster_CustomClusterType{
+					Name: dnsClusterExtensionName,
+					TypedConfig: mustMessageToAny(t, &envoydnsv3.DnsCluster{
+						DnsLookupFamily: envoycommondnsv3.DnsLookupFamily_V4_PREFERRED,
+					}),
+				},
+			},
+		}
+	}
+
+	t.Run("DNS clusters resolve both address families", func(t *testing.T) {
+		policyIR, errs := translate(nil, nil, &kgateway.BackendConfigPolicy{
+			Spec: kgateway.BackendConfigPolicySpec{
+				DualStackConnect: new(true),
+				TCPKeepalive: &kgateway.TCPKeepalive{
+					KeepAliveProbes: new(int32(3)),
+				},
+			},
+		})
+		require.Empty(t, errs)
+
+		cluster := dnsCluster(t)
+		processBackend(context.Background(), policyIR, ir.BackendObjectIR{}, cluster)
+
+		assert.True(t, proto.Equal(happyEyeballs, cluster.GetUpstreamConnectionOptions().GetHappyEyeballsConfig()))
+		assert.Equal(t, uint32(3), cluster.GetUpstreamConnectionOptions().GetTcpKeepalive().GetKeepaliveProbes().GetValue())
+
+		got := &envoydnsv3.DnsCluster{}
+		require.NoError(t, cluster.GetClusterType().GetTypedConfig().UnmarshalTo(got))
+		assert.Equal(t, envoycommondnsv3.DnsLookupFamily_ALL, got.GetDnsLookupFamily())
+	})
+
+	t.Run("other clusters only sort the addresses of their hosts", func(t *testing.T) {
+		policyIR, errs := translate(nil, nil, &kgateway.BackendConfigPolicy{
+			Spec: kgateway.BackendConfigPolicySpec{
+				DualStackConnect: new(true),
+			},
+		})
+		require.Empty(t, errs)
+
+		cluster := &envoyclusterv3.Cluster{
+			ClusterDiscoveryType: &envoyclusterv3.Cluster_Type{Type: envoyclusterv3.Cluster_EDS},
+		}
+		processBackend(context.Background(), policyIR, ir.BackendObjectIR{}, cluster)
+
+		assert.True(t, proto.Equal(happyEyeballs, cluster.GetUpstreamConnectionOptions().GetHappyEyeballsConfig()))
+		assert.Equal(t, envoyclusterv3.Cluster_EDS, cluster.GetType())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		policyIR, errs := translate(nil, nil, &kgateway.BackendConfigPolicy{
+			Spec: kgateway.BackendConfigPolicySpec{
+				DualStackConnect: new(false),
+			},
+		})
+		require.Empty(t, errs)
+
+		cluster := dnsCluster(t)
+		processBackend(context.Background(), policyIR, ir.BackendObjectIR{}, cluster)
+
+		assert.Nil(t, cluster.GetUpstreamConnectionOptions())
+		got := &envoydnsv3.DnsCluster{}
+		require.NoError(t, cluster.GetClusterType().GetTypedConfig().UnmarshalTo(got))
+		assert.Equal(t, envoycommondnsv3.DnsLookupFamily_V4_PREFERRED, got.GetDnsLookupFamily())
+	})
+}
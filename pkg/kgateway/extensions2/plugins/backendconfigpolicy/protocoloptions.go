@@ -1,10 +1,13 @@
 package backendconfigpolicy
 
 import (
+	"fmt"
+
 	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	preserve_case_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/header_formatters/preserve_case/v3"
 	envoy_upstreams_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
+	envoywellknown "github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
@@ -144,3 +147,71 @@ func applyHttp2ProtocolOptions(http2ProtocolOptions *envoycorev3.Http2ProtocolOp
 		logger.Error("failed to apply http2 protocol options", "backend", backend.GetName(), "error", err)
 	}
 }
+
+func validateUpstreamProtocol(protocol kgateway.UpstreamProtocol) error {
+	switch protocol {
+	case kgateway.UpstreamProtocolHTTP1, kgateway.UpstreamProtocolHTTP2, kgateway.UpstreamProtocolAuto:
+		return nil
+	default:
+		return fmt.Errorf("unsupported upstreamProtocol %q, must be one of %q, %q or %q",
+			protocol, kgateway.UpstreamProtocolHTTP1, kgateway.UpstreamProtocolHTTP2, kgateway.UpstreamProtocolAuto)
+	}
+}
+
+// applyUpstreamProtocol sets the HTTP protocol of the connections to the backend, overriding the protocol
+// derived from its appProtocol. It must be applied after the transport socket since the protocol can only
+// be negotiated via ALPN when the backend uses TLS.
+func applyUpstreamProtocol(
+	protocol kgateway.UpstreamProtocol,
+	http1ProtocolOptions *envoycorev3.Http1ProtocolOptions,
+	http2ProtocolOptions *envoycorev3.Http2ProtocolOptions,
+	backend ir.BackendObjectIR,
+	out *envoyclusterv3.Cluster,
+) {
+	if http1ProtocolOptions == nil {
+		http1ProtocolOptions = &envoycorev3.Http1ProtocolOptions{}
+	}
+	if http2ProtocolOptions == nil {
+		http2ProtocolOptions = &envoycorev3.Http2ProtocolOptions{}
+	}
+
+	if err := translatorutils.MutateHttpOptions(out, func(opts *envoy_upstreams_v3.HttpProtocolOptions) {
+		switch protocol {
+		case kgateway.UpstreamProtocolHTTP1:
+			opts.UpstreamProtocolOptions = &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig_{
+				ExplicitHttpConfig: &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig{
+					ProtocolConfig: &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig_HttpProtocolOptions{
+						HttpProtocolOptions: http1ProtocolOptions,
+					},
+				},
+			}
+		case kgateway.UpstreamProtocolHTTP2:
+			opts.UpstreamProtocolOptions = &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig_{
+				ExplicitHttpConfig: &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig{
+					ProtocolConfig: &envoy_upstreams_v3.HttpProtocolOptions_ExplicitHttpConfig_Http2ProtocolOptions{
+						Http2ProtocolOptions: http2ProtocolOptions,
+					},
+				},
+			}
+		case kgateway.UpstreamProtocolAuto:
+			// Envoy rejects ALPN negotiation without TLS, so plaintext backends match the downstream protocol instead
+			if out.GetTransportSocket().GetName() == envoywellknown.TransportSocketTls {
+				opts.UpstreamProtocolOptions = &envoy_upstreams_v3.HttpProtocolOptions_AutoConfig{
+					AutoConfig: &envoy_upstreams_v3.HttpProtocolOptions_AutoHttpConfig{
+						HttpProtocolOptions:  http1ProtocolOptions,
+						Http2ProtocolOptions: http2ProtocolOptions,
+					},
+				}
+			} else {
+				opts.UpstreamProtocolOptions = &envoy_upstreams_v3.HttpProtocolOptions_UseDownstreamProtocolConfig{
+					UseDownstreamProtocolConfig: &envoy_upstreams_v3.HttpProtocolOptions_UseDownstreamHttpConfig{
+						HttpProtocolOptions:  http1ProtocolOptions,
+						Http2ProtocolOptions: http2ProtocolOptions,
+					},
+				}
+			}
+		}
+	}); err != nil {
+		logger.Error("failed to apply upstream protocol", "backend", backend.GetName(), "error", err)
+	}
+}
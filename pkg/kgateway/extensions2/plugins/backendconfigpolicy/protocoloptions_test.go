@@ -0,0 +1,141 @@
+package backendconfigpolicy
+
+import (
+	"context"
+	"testing"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	envoy_upstreams_http_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
+	envoywellknown "github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestUpstreamProtocol(t *testing.T) {
+	tlsTransportSocket := func(t *testing.T) *envoycorev3.TransportSocket {
+		return &envoycorev3.TransportSocket{
+			Name: envoywellknown.TransportSocketTls,
+			ConfigType: &envoycorev3.TransportSocket_TypedConfig{
+				TypedConfig: mustMessageToAny(t, &envoytlsv3.UpstreamTlsContext{Sni: "example.com"}),
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		spec    kgateway.BackendConfigPolicySpec
+		tls     bool
+		backend ir.BackendObjectIR
+		want    *envoy_upstreams_http_v3.HttpProtocolOptions
+	}{
+		{
+			name: "http1 overrides the appProtocol of the backend",
+			spec: kgateway.BackendConfigPolicySpec{
+				UpstreamProtocol: new(kgateway.UpstreamProtocolHTTP1),
+				Http1ProtocolOptions: &kgateway.Http1ProtocolOptions{
+					EnableTrailers: new(true),
+				},
+			},
+			backend: ir.BackendObjectIR{AppProtocol: ir.HTTP2AppProtocol},
+			want: &envoy_upstreams_http_v3.HttpProtocolOptions{
+				UpstreamProtocolOptions: &envoy_upstreams_http_v3.HttpProtocolOptions_ExplicitHttpConfig_{
+					ExplicitHttpConfig: &envoy_upstreams_http_v3.HttpProtocolOptions_ExplicitHttpConfig{
+						ProtocolConfig: &envoy_upstreams_http_v3.HttpProtocolOptions_ExplicitHttpConfig_HttpProtocolOptions{
+							HttpProtocolOptions: &envoycorev3.Http1ProtocolOptions{EnableTrailers: true},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "http2 applies the http2 options to any backend",
+			spec: kgateway.BackendConfigPolicySpec{
+				UpstreamProtocol: new(kgateway.UpstreamProtocolHTTP2),
+				Http2ProtocolOptions: &kgateway.Http2ProtocolOptions{
+					MaxConcurrentStreams: new(int32(100)),
+				},
+			},
+			want: &envoy_upstreams_http_v3.HttpProtocolOptions{
+				UpstreamProtocolOptions: &envoy_upstreams_http_v3.HttpProtocolOptions_ExplicitHttpConfig_{
+					ExplicitHttpConfig: &envoy_upstreams_http_v3.HttpProtocolOptions_ExplicitHttpConfig{
+						ProtocolConfig: &envoy_upstreams_http_v3.HttpProtocolOptions_ExplicitHttpConfig_Http2ProtocolOptions{
+							Http2ProtocolOptions: &envoycorev3.Http2ProtocolOptions{
+								MaxConcurrentStreams: wrapperspb.UInt32(100),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "auto negotiates the protocol via ALPN with TLS",
+			spec: kgateway.BackendConfigPolicySpec{
+				UpstreamProtocol: new(kgateway.UpstreamProtocolAuto),
+			},
+			tls: true,
+			want: &envoy_upstreams_http_v3.HttpProtocolOptions{
+				UpstreamProtocolOptions: &envoy_upstreams_http_v3.HttpProtocolOptions_AutoConfig{
+					AutoConfig: &envoy_upstreams_http_v3.HttpProtocolOptions_AutoHttpConfig{
+						HttpProtocolOptions:  &envoycorev3.Http1ProtocolOptions{},
+						Http2ProtocolOptions: &envoycorev3.Http2ProtocolOptions{},
+					},
+				},
+			},
+		},
+		{
+			name: "auto matches the downstream protocol without TLS",
+			spec: kgateway.BackendConfigPolicySpec{
+				UpstreamProtocol: new(kgateway.UpstreamProtocolAuto),
+				Http2ProtocolOptions: &kgateway.Http2ProtocolOptions{
+					MaxConcurrentStreams: new(int32(100)),
+				},
+			},
+			want: &envoy_upstreams_http_v3.HttpProtocolOptions{
+				UpstreamProtocolOptions: &envoy_upstreams_http_v3.HttpProtocolOptions_UseDownstreamProtocolConfig{
+					UseDownstreamProtocolConfig: &envoy_upstreams_http_v3.HttpProtocolOptions_UseDownstreamHttpConfig{
+						HttpProtocolOptions: &envoycorev3.Http1ProtocolOptions{},
+						Http2ProtocolOptions: &envoycorev3.Http2ProtocolOptions{
+							MaxConcurrentStreams: wrapperspb.UInt32(100),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policyIR, errs := translate(nil, nil, &kgateway.BackendConfigPolicy{Spec: tt.spec})
+			require.Empty(t, errs)
+
+			cluster := &envoyclusterv3.Cluster{}
+			if tt.tls {
+				cluster.TransportSocket = tlsTransportSocket(t)
+			}
+			processBackend(context.Background(), policyIR, tt.backend, cluster)
+
+			opts := &envoy_upstreams_http_v3.HttpProtocolOptions{}
+			require.NoError(t, cluster.GetTypedExtensionProtocolOptions()["envoy.extensions.upstreams.http.v3.HttpProtocolOptions"].UnmarshalTo(opts))
+			assert.Empty(t, cmp.Diff(tt.want, opts, protocmp.Transform()))
+		})
+	}
+}
+
+func TestUpstreamProtocolInvalid(t *testing.T) {
+	policyIR, errs := translate(nil, nil, &kgateway.BackendConfigPolicy{
+		Spec: kgateway.BackendConfigPolicySpec{
+			UpstreamProtocol: new(kgateway.UpstreamProtocol("http3")),
+		},
+	})
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], `unsupported upstreamProtocol "http3", must be one of "http1", "http2" or "auto"`)
+	assert.Nil(t, policyIR.upstreamProtocol)
+}
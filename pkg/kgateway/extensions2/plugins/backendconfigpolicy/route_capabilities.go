@@ -0,0 +1,24 @@
+package backendconfigpolicy
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+var _ ir.BackendRouteCapabilityPolicy = &BackendConfigPolicyIR{}
+
+// RouteCapabilityOverlaps implements ir.BackendRouteCapabilityPolicy.
+// Session persistence pins the requests of a session to a host before the load balancer is
+// consulted, so it takes precedence over the consistent hashing load balancers for the routes
+// that configure it.
+func (d *BackendConfigPolicyIR) RouteCapabilityOverlaps(native sets.Set[ir.RouteCapability]) []ir.RouteCapabilityOverlap {
+	if !native.Has(ir.RouteCapabilitySessionPersistence) ||
+		d.loadBalancerConfig == nil || d.loadBalancerConfig.consistentHashingField == "" {
+		return nil
+	}
+	return []ir.RouteCapabilityOverlap{{
+		Field:      d.loadBalancerConfig.consistentHashingField,
+		Capability: ir.RouteCapabilitySessionPersistence,
+	}}
+}
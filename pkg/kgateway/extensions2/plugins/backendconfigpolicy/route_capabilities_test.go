@@ -0,0 +1,64 @@
+package backendconfigpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestRouteCapabilityOverlaps(t *testing.T) {
+	sessionPersistence := sets.New(ir.RouteCapabilitySessionPersistence)
+
+	tests := []struct {
+		name         string
+		loadBalancer *kgateway.LoadBalancer
+		native       sets.Set[ir.RouteCapability]
+		want         []ir.RouteCapabilityOverlap
+	}{
+		{
+			name:         "ring hash is overridden by session persistence",
+			loadBalancer: &kgateway.LoadBalancer{RingHash: &kgateway.LoadBalancerRingHashConfig{}},
+			native:       sessionPersistence,
+			want: []ir.RouteCapabilityOverlap{
+				{Field: "loadBalancer.ringHash", Capability: ir.RouteCapabilitySessionPersistence},
+			},
+		},
+		{
+			name:         "maglev is overridden by session persistence",
+			loadBalancer: &kgateway.LoadBalancer{Maglev: &kgateway.LoadBalancerMaglevConfig{}},
+			native:       sessionPersistence,
+			want: []ir.RouteCapabilityOverlap{
+				{Field: "loadBalancer.maglev", Capability: ir.RouteCapabilitySessionPersistence},
+			},
+		},
+		{
+			name:         "round robin does not overlap",
+			loadBalancer: &kgateway.LoadBalancer{RoundRobin: &kgateway.LoadBalancerRoundRobinConfig{}},
+			native:       sessionPersistence,
+		},
+		{
+			name:         "ring hash without session persistence on the route",
+			loadBalancer: &kgateway.LoadBalancer{RingHash: &kgateway.LoadBalancerRingHashConfig{}},
+			native:       sets.New(ir.RouteCapabilityTimeouts),
+		},
+		{
+			name:   "no load balancer",
+			native: sessionPersistence,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policyIR, errs := translate(nil, nil, &kgateway.BackendConfigPolicy{
+				Spec: kgateway.BackendConfigPolicySpec{LoadBalancer: tt.loadBalancer},
+			})
+			require.Empty(t, errs)
+
+			assert.Equal(t, tt.want, policyIR.RouteCapabilityOverlaps(tt.native))
+		})
+	}
+}
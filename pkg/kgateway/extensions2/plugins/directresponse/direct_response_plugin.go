@@ -113,8 +113,12 @@ func (p *directResponsePluginGwPass) ApplyForRoute(pCtx *ir.RouteContext, output
 		return fmt.Errorf("DirectResponse cannot be applied to route with existing action: %T", outputRoute.GetAction())
 	}
 
+	if err := validateStatusCode(dr.spec.StatusCode); err != nil {
+		return err
+	}
+
 	drAction := &envoyroutev3.DirectResponseAction{
-		Status: uint32(dr.spec.StatusCode), // nolint:gosec // G115: kubebuilder validation ensures safe for uint32
+		Status: uint32(dr.spec.StatusCode), // nolint:gosec // G115: validated above
 	}
 	if dr.spec.Body != nil {
 		drAction.Body = &envoycorev3.DataSource{
@@ -157,6 +161,15 @@ func (p *directResponsePluginGwPass) ApplyForRoute(pCtx *ir.RouteContext, output
 	return nil
 }
 
+// validateStatusCode ensures the status code is a valid HTTP status code that Envoy accepts
+// for a direct response. This is also enforced by the CRD schema.
+func validateStatusCode(code int32) error {
+	if code < 200 || code > 599 {
+		return fmt.Errorf("DirectResponse status code %d is invalid: must be between 200 and 599", code)
+	}
+	return nil
+}
+
 func (p *directResponsePluginGwPass) ApplyForRouteBackend(
 	policy ir.PolicyIR,
 	pCtx *ir.RouteBackendContext,
@@ -0,0 +1,85 @@
+package directresponse
+
+import (
+	"testing"
+
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestApplyForRoute(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    kgateway.DirectResponseSpec
+		wantErr string
+		check   func(t *testing.T, action *envoyroutev3.DirectResponseAction)
+	}{
+		{
+			name: "static body",
+			spec: kgateway.DirectResponseSpec{
+				StatusCode: 503,
+				Body:       ptr.To("down for maintenance"),
+			},
+			check: func(t *testing.T, action *envoyroutev3.DirectResponseAction) {
+				assert.Equal(t, uint32(503), action.GetStatus())
+				assert.Equal(t, "down for maintenance", action.GetBody().GetInlineString())
+				assert.Nil(t, action.GetBodyFormat())
+			},
+		},
+		{
+			name: "templated text body with content type",
+			spec: kgateway.DirectResponseSpec{
+				StatusCode: 503,
+				BodyFormat: &kgateway.BodyFormat{
+					ContentType: ptr.To("text/html"),
+					Text:        ptr.To("<p>%REQ(:path)% is under maintenance</p>"),
+				},
+			},
+			check: func(t *testing.T, action *envoyroutev3.DirectResponseAction) {
+				assert.Equal(t, uint32(503), action.GetStatus())
+				assert.Nil(t, action.GetBody())
+				assert.Equal(t, "<p>%REQ(:path)% is under maintenance</p>", action.GetBodyFormat().GetTextFormatSource().GetInlineString())
+				assert.Equal(t, "text/html", action.GetBodyFormat().GetContentType())
+			},
+		},
+		{
+			name: "templated json body",
+			spec: kgateway.DirectResponseSpec{
+				StatusCode: 200,
+				BodyFormat: &kgateway.BodyFormat{
+					JSON: &apiextensionsv1.JSON{Raw: []byte(`{"path":"%REQ(:path)%"}`)},
+				},
+			},
+			check: func(t *testing.T, action *envoyroutev3.DirectResponseAction) {
+				assert.Equal(t, uint32(200), action.GetStatus())
+				assert.Equal(t, "%REQ(:path)%", action.GetBodyFormat().GetJsonFormat().GetFields()["path"].GetStringValue())
+			},
+		},
+		{
+			name:    "invalid status code is rejected",
+			spec:    kgateway.DirectResponseSpec{StatusCode: 99},
+			wantErr: "DirectResponse status code 99 is invalid: must be between 200 and 599",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &directResponsePluginGwPass{}
+			out := &envoyroutev3.Route{}
+			err := p.ApplyForRoute(&ir.RouteContext{Policy: &directResponse{spec: tt.spec}}, out)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, out.GetAction())
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, out.GetDirectResponse())
+		})
+	}
+}
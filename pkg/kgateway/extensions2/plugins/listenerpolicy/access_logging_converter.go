@@ -3,6 +3,8 @@ package listenerpolicy
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"path/filepath"
 
 	envoyaccesslogv3 "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
@@ -10,14 +12,17 @@ import (
 	cel "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/filters/cel/v3"
 	envoygrpc "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/grpc/v3"
 	envoy_open_telemetry "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/open_telemetry/v3"
+	envoyalstream "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/stream/v3"
 	envoy_metadata_formatter "github.com/envoyproxy/go-control-plane/envoy/extensions/formatter/metadata/v3"
 	envoy_req_without_query "github.com/envoyproxy/go-control-plane/envoy/extensions/formatter/req_without_query/v3"
 	envoytypev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	otelv1 "go.opentelemetry.io/proto/otlp/common/v1"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"istio.io/istio/pkg/kube/krt"
 	"k8s.io/utils/ptr"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
@@ -42,6 +47,13 @@ const (
 
 	k8sNamespaceNameKey = "k8s.namespace.name"
 	k8sContainerNameKey = "k8s.container.name"
+
+	stdoutAccessLogName = "envoy.access_loggers.stdout"
+	stderrAccessLogName = "envoy.access_loggers.stderr"
+
+	// accessLogSamplingRuntimeKey is the runtime key that can override the numerator of the sampling rate
+	// of access logs; when it is not set in the runtime, the configured rate is used
+	accessLogSamplingRuntimeKey = "kgateway.access_log.sampling"
 )
 
 // convertAccessLogConfig transforms a list of AccessLog configurations into Envoy AccessLog configurations
@@ -74,18 +86,33 @@ func convertAccessLogConfig(
 			continue
 		}
 		if log.OpenTelemetry != nil {
-			backend, err := commoncol.BackendIndex.GetBackendFromRef(krtctx, parentSrc, log.OpenTelemetry.GrpcService.BackendRef.BackendObjectReference)
+			backendRef, logName := otelBackendRef(log.OpenTelemetry)
+			if backendRef == nil {
+				continue
+			}
+			backend, err := commoncol.BackendIndex.GetBackendFromRef(krtctx, parentSrc, *backendRef)
 			// TODO: what is the correct behavior? maybe route to static blackhole?
 			if err != nil {
 				return nil, fmt.Errorf("%w: %v", ErrUnresolvedBackendRef, err)
 			}
-			grpcBackends[getLogId(log.OpenTelemetry.GrpcService.LogName, idx)] = backend
+			grpcBackends[getLogId(logName, idx)] = backend
 		}
 	}
 
 	return translateAccessLogs(configs, grpcBackends)
 }
 
+// otelBackendRef returns the backend ref and log name of the gRPC or HTTP service of an OTel access log
+func otelBackendRef(otelService *kgateway.OpenTelemetryAccessLogService) (*gwv1.BackendObjectReference, string) {
+	switch {
+	case otelService.GrpcService != nil:
+		return &otelService.GrpcService.BackendRef.BackendObjectReference, otelService.GrpcService.LogName
+	case otelService.HttpService != nil:
+		return &otelService.HttpService.BackendRef.BackendObjectReference, ptr.Deref(otelService.HttpService.LogName, "")
+	}
+	return nil, ""
+}
+
 func getLogId(logName string, idx int) string {
 	return fmt.Sprintf("%s-%d", logName, idx)
 }
@@ -134,10 +161,47 @@ func translateAccessLog(logConfig kgateway.AccessLog, grpcBackends map[string]*i
 	return accessLogCfg, nil
 }
 
-// createFileAccessLog generates a file-based access log configuration
+// createFileAccessLog generates a file-based access log configuration, or a stdout/stderr one
+// when the destination of the file sink is the standard output or error of the proxy
 func createFileAccessLog(fileSink *kgateway.FileSink) (proto.Message, error) {
-	fileCfg := &envoyalfile.FileAccessLog{Path: fileSink.Path}
+	logFormat, err := fileSinkLogFormat(fileSink)
+	if err != nil {
+		return nil, err
+	}
 
+	path := fileSink.Path
+	if fileSink.Destination != nil {
+		switch dest := *fileSink.Destination; dest {
+		case kgateway.AccessLogDestinationStdout:
+			stdoutCfg := &envoyalstream.StdoutAccessLog{}
+			if logFormat != nil {
+				stdoutCfg.AccessLogFormat = &envoyalstream.StdoutAccessLog_LogFormat{LogFormat: logFormat}
+			}
+			return stdoutCfg, nil
+		case kgateway.AccessLogDestinationStderr:
+			stderrCfg := &envoyalstream.StderrAccessLog{}
+			if logFormat != nil {
+				stderrCfg.AccessLogFormat = &envoyalstream.StderrAccessLog_LogFormat{LogFormat: logFormat}
+			}
+			return stderrCfg, nil
+		default:
+			if !filepath.IsAbs(dest) {
+				return nil, fmt.Errorf("access log destination %q must be %s, %s or an absolute file path",
+					dest, kgateway.AccessLogDestinationStdout, kgateway.AccessLogDestinationStderr)
+			}
+			path = dest
+		}
+	}
+
+	fileCfg := &envoyalfile.FileAccessLog{Path: path}
+	if logFormat != nil {
+		fileCfg.AccessLogFormat = &envoyalfile.FileAccessLog_LogFormat{LogFormat: logFormat}
+	}
+	return fileCfg, nil
+}
+
+// fileSinkLogFormat returns the format of the log lines of a file sink, or nil for the default format
+func fileSinkLogFormat(fileSink *kgateway.FileSink) (*envoycorev3.SubstitutionFormatString, error) {
 	formatterExtensions, err := getFormatterExtensions()
 	if err != nil {
 		return nil, err
@@ -145,33 +209,29 @@ func createFileAccessLog(fileSink *kgateway.FileSink) (proto.Message, error) {
 
 	switch {
 	case fileSink.StringFormat != nil:
-		fileCfg.AccessLogFormat = &envoyalfile.FileAccessLog_LogFormat{
-			LogFormat: &envoycorev3.SubstitutionFormatString{
-				Format: &envoycorev3.SubstitutionFormatString_TextFormatSource{
-					TextFormatSource: &envoycorev3.DataSource{
-						Specifier: &envoycorev3.DataSource_InlineString{
-							InlineString: *fileSink.StringFormat,
-						},
+		return &envoycorev3.SubstitutionFormatString{
+			Format: &envoycorev3.SubstitutionFormatString_TextFormatSource{
+				TextFormatSource: &envoycorev3.DataSource{
+					Specifier: &envoycorev3.DataSource_InlineString{
+						InlineString: *fileSink.StringFormat,
 					},
 				},
-				Formatters: formatterExtensions,
 			},
-		}
+			Formatters: formatterExtensions,
+		}, nil
 	case fileSink.JsonFormat != nil:
 		jsonStruct, err := utils.JSONToProtoStruct(fileSink.JsonFormat.Raw)
 		if err != nil {
 			return nil, fmt.Errorf("invalid access log jsonFormat: %w", err)
 		}
-		fileCfg.AccessLogFormat = &envoyalfile.FileAccessLog_LogFormat{
-			LogFormat: &envoycorev3.SubstitutionFormatString{
-				Format: &envoycorev3.SubstitutionFormatString_JsonFormat{
-					JsonFormat: jsonStruct,
-				},
-				Formatters: formatterExtensions,
+		return &envoycorev3.SubstitutionFormatString{
+			Format: &envoycorev3.SubstitutionFormatString_JsonFormat{
+				JsonFormat: jsonStruct,
 			},
-		}
+			Formatters: formatterExtensions,
+		}, nil
 	}
-	return fileCfg, nil
+	return nil, nil
 }
 
 // createGrpcAccessLog generates a gRPC-based access log configuration
@@ -232,6 +292,92 @@ func addAccessLogFilter(accessLogCfg *envoyaccesslogv3.AccessLog, filter *kgatew
 	return nil
 }
 
+// addAccessLogSampling samples the requests logged by an access log configuration. Sampling applies on top of
+// the filter of the access log, if any, and errors bypass it when the sampling always logs errors.
+func addAccessLogSampling(accessLogCfg *envoyaccesslogv3.AccessLog, sampling *kgateway.AccessLogSampling) error {
+	percentSampled, err := toEnvoySamplingPercent(sampling)
+	if err != nil {
+		return err
+	}
+
+	sampled := &envoyaccesslogv3.AccessLogFilter{
+		FilterSpecifier: &envoyaccesslogv3.AccessLogFilter_RuntimeFilter{
+			RuntimeFilter: &envoyaccesslogv3.RuntimeFilter{
+				RuntimeKey:     accessLogSamplingRuntimeKey,
+				PercentSampled: percentSampled,
+			},
+		},
+	}
+	if ptr.Deref(sampling.AlwaysLogErrors, false) {
+		sampled = &envoyaccesslogv3.AccessLogFilter{
+			FilterSpecifier: &envoyaccesslogv3.AccessLogFilter_OrFilter{
+				OrFilter: &envoyaccesslogv3.OrFilter{Filters: []*envoyaccesslogv3.AccessLogFilter{
+					sampled,
+					{
+						FilterSpecifier: &envoyaccesslogv3.AccessLogFilter_StatusCodeFilter{
+							StatusCodeFilter: &envoyaccesslogv3.StatusCodeFilter{
+								Comparison: &envoyaccesslogv3.ComparisonFilter{
+									Op:    envoyaccesslogv3.ComparisonFilter_GE,
+									Value: &envoycorev3.RuntimeUInt32{DefaultValue: 500},
+								},
+							},
+						},
+					},
+					{
+						// a response flag filter without flags matches requests with any response flag
+						FilterSpecifier: &envoyaccesslogv3.AccessLogFilter_ResponseFlagFilter{
+							ResponseFlagFilter: &envoyaccesslogv3.ResponseFlagFilter{},
+						},
+					},
+				}},
+			},
+		}
+	}
+
+	if accessLogCfg.Filter == nil {
+		accessLogCfg.Filter = sampled
+		return nil
+	}
+	accessLogCfg.Filter = &envoyaccesslogv3.AccessLogFilter{
+		FilterSpecifier: &envoyaccesslogv3.AccessLogFilter_AndFilter{
+			AndFilter: &envoyaccesslogv3.AndFilter{Filters: []*envoyaccesslogv3.AccessLogFilter{accessLogCfg.Filter, sampled}},
+		},
+	}
+	return nil
+}
+
+// toEnvoySamplingPercent returns the fraction of requests sampled by an access log sampling configuration
+func toEnvoySamplingPercent(sampling *kgateway.AccessLogSampling) (*envoytypev3.FractionalPercent, error) {
+	switch {
+	case sampling.Percentage != nil:
+		percentage := *sampling.Percentage
+		if percentage < 0 || percentage > 100 {
+			return nil, fmt.Errorf("access log sampling percentage %d must be between 0 and 100", percentage)
+		}
+		return &envoytypev3.FractionalPercent{
+			Numerator:   uint32(percentage), // nolint:gosec // G115: validated above
+			Denominator: envoytypev3.FractionalPercent_HUNDRED,
+		}, nil
+	case sampling.Rate != nil:
+		if sampling.Rate.Numerator < 0 {
+			return nil, fmt.Errorf("access log sampling rate numerator %d must not be negative", sampling.Rate.Numerator)
+		}
+		percent := &envoytypev3.FractionalPercent{
+			Numerator: uint32(sampling.Rate.Numerator), // nolint:gosec // G115: validated above
+		}
+		if sampling.Rate.Denominator != nil {
+			denominator, err := toEnvoyDenominatorType(*sampling.Rate.Denominator)
+			if err != nil {
+				return nil, err
+			}
+			percent.Denominator = denominator
+		}
+		return percent, nil
+	default:
+		return nil, errors.New("access log sampling must set one of percentage or rate")
+	}
+}
+
 // translateFilters translates a slice of filter types
 func translateFilters(filters []kgateway.FilterType) ([]*envoyaccesslogv3.AccessLogFilter, error) {
 	result := make([]*envoyaccesslogv3.AccessLogFilter, 0, len(filters))
@@ -434,13 +580,24 @@ func copyGrpcSettings(cfg *envoygrpc.HttpGrpcAccessLogConfig, grpcService *kgate
 }
 
 func copyOTelSettings(cfg *envoy_open_telemetry.OpenTelemetryAccessLogConfig, otelService *kgateway.OpenTelemetryAccessLogService, grpcBackends map[string]*ir.BackendObjectIR, accessLogId int) error {
-	config, err := generateGrpcServiceConfig(otelService.GrpcService, grpcBackends, accessLogId)
-	if err != nil {
-		return err
+	switch {
+	case otelService.GrpcService != nil:
+		config, err := generateGrpcServiceConfig(*otelService.GrpcService, grpcBackends, accessLogId)
+		if err != nil {
+			return err
+		}
+		cfg.LogName = otelService.GrpcService.LogName
+		cfg.GrpcService = config
+	case otelService.HttpService != nil:
+		config, err := generateOTelHttpServiceConfig(otelService.HttpService, grpcBackends, accessLogId)
+		if err != nil {
+			return err
+		}
+		cfg.LogName = ptr.Deref(otelService.HttpService.LogName, "")
+		cfg.HttpService = config
+	default:
+		return errors.New("one of grpc service or http service must be set")
 	}
-
-	cfg.LogName = otelService.GrpcService.LogName
-	cfg.GrpcService = config
 	if otelService.Body != nil {
 		cfg.Body = &otelv1.AnyValue{
 			Value: &otelv1.AnyValue_StringValue{
@@ -461,6 +618,56 @@ func copyOTelSettings(cfg *envoy_open_telemetry.OpenTelemetryAccessLogConfig, ot
 	return cfg.Validate()
 }
 
+// generateOTelHttpServiceConfig generates the OTLP/HTTP service the access logs are posted to
+func generateOTelHttpServiceConfig(httpService *kgateway.OpenTelemetryAccessLogHttpService, grpcBackends map[string]*ir.BackendObjectIR, accessLogId int) (*envoycorev3.HttpService, error) {
+	if err := validateOTLPEndpoint(httpService.Endpoint); err != nil {
+		return nil, err
+	}
+	backend := grpcBackends[getLogId(ptr.Deref(httpService.LogName, ""), accessLogId)]
+	if backend == nil {
+		return nil, errors.New("backend ref not found")
+	}
+
+	timeout := kgateway.HTTPDefaultTimeout
+	if httpService.Timeout != nil {
+		timeout = httpService.Timeout.Duration
+	}
+	config := &envoycorev3.HttpService{
+		HttpUri: &envoycorev3.HttpUri{
+			Uri: httpService.Endpoint,
+			HttpUpstreamType: &envoycorev3.HttpUri_Cluster{
+				Cluster: backend.ClusterName(),
+			},
+			Timeout: durationpb.New(timeout),
+		},
+	}
+	for _, header := range httpService.Headers {
+		config.RequestHeadersToAdd = append(config.RequestHeadersToAdd, &envoycorev3.HeaderValueOption{
+			Header: &envoycorev3.HeaderValue{
+				Key:   header.Key,
+				Value: ptr.Deref(header.Value, ""),
+			},
+			AppendAction: envoycorev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+		})
+	}
+	return config, nil
+}
+
+// validateOTLPEndpoint checks that the OTLP endpoint is an absolute http(s) URL.
+func validateOTLPEndpoint(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid otlp endpoint %q: %w", raw, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("otlp endpoint %q must use http or https", raw)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("otlp endpoint %q must have a host", raw)
+	}
+	return nil
+}
+
 func ToOTelKeyValueList(in *kgateway.KeyAnyValueList) *otelv1.KeyValueList {
 	kvList := make([]*otelv1.KeyValue, len(in.Values))
 	ret := &otelv1.KeyValueList{
@@ -627,6 +834,10 @@ func generateAccessLogConfig(pCtx *ir.HcmContext, policies []kgateway.AccessLog,
 		switch t := config.(type) {
 		case *envoyalfile.FileAccessLog:
 			cfg = newAccessLogWithConfig(wellknown.FileAccessLog, t)
+		case *envoyalstream.StdoutAccessLog:
+			cfg = newAccessLogWithConfig(stdoutAccessLogName, t)
+		case *envoyalstream.StderrAccessLog:
+			cfg = newAccessLogWithConfig(stderrAccessLogName, t)
 		case *envoygrpc.HttpGrpcAccessLogConfig:
 			cfg = newAccessLogWithConfig(wellknown.HTTPGRPCAccessLog, t)
 		case *envoy_open_telemetry.OpenTelemetryAccessLogConfig:
@@ -639,6 +850,11 @@ func generateAccessLogConfig(pCtx *ir.HcmContext, policies []kgateway.AccessLog,
 				return nil, err
 			}
 		}
+		if policies[i].Sampling != nil {
+			if err := addAccessLogSampling(cfg, policies[i].Sampling); err != nil {
+				return nil, err
+			}
+		}
 		accessLogs[i] = cfg
 	}
 	return accessLogs, nil
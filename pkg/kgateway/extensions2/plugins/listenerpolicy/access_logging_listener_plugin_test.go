@@ -12,9 +12,11 @@ import (
 	cel "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/filters/cel/v3"
 	envoygrpc "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/grpc/v3"
 	envoy_open_telemetry "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/open_telemetry/v3"
+	envoyalstream "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/stream/v3"
 	envoy_metadata_formatter "github.com/envoyproxy/go-control-plane/envoy/extensions/formatter/metadata/v3"
 	envoy_req_without_query "github.com/envoyproxy/go-control-plane/envoy/extensions/formatter/req_without_query/v3"
 	envoymatcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	envoytypev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	otelv1 "go.opentelemetry.io/proto/otlp/common/v1"
@@ -768,7 +770,7 @@ func TestConvertJsonFormat_EdgeCases(t *testing.T) {
 				config: []kgateway.AccessLog{
 					{
 						OpenTelemetry: &kgateway.OpenTelemetryAccessLogService{
-							GrpcService: kgateway.CommonAccessLogGrpcService{
+							GrpcService: &kgateway.CommonAccessLogGrpcService{
 								CommonGrpcService: kgateway.CommonGrpcService{
 									BackendRef: gwv1.BackendRef{
 										BackendObjectReference: gwv1.BackendObjectReference{
@@ -848,7 +850,7 @@ func TestConvertJsonFormat_EdgeCases(t *testing.T) {
 				config: []kgateway.AccessLog{
 					{
 						OpenTelemetry: &kgateway.OpenTelemetryAccessLogService{
-							GrpcService: kgateway.CommonAccessLogGrpcService{
+							GrpcService: &kgateway.CommonAccessLogGrpcService{
 								CommonGrpcService: kgateway.CommonGrpcService{
 									BackendRef: gwv1.BackendRef{
 										BackendObjectReference: gwv1.BackendObjectReference{
@@ -1538,9 +1540,309 @@ func TestAccessLogFilters(t *testing.T) {
 	}
 }
 
+func TestAccessLogSampling(t *testing.T) {
+	tenThousand := kgateway.TEN_THOUSAND
+	runtimeFilter := func(t *testing.T, f *envoyaccesslogv3.AccessLogFilter, numerator uint32, denominator envoytypev3.FractionalPercent_DenominatorType) {
+		t.Helper()
+		rf := f.GetRuntimeFilter()
+		require.NotNil(t, rf)
+		assert.Equal(t, accessLogSamplingRuntimeKey, rf.GetRuntimeKey())
+		assert.Equal(t, numerator, rf.GetPercentSampled().GetNumerator())
+		assert.Equal(t, denominator, rf.GetPercentSampled().GetDenominator())
+	}
+	errorsFilters := func(t *testing.T, filters []*envoyaccesslogv3.AccessLogFilter) {
+		t.Helper()
+		require.Len(t, filters, 3)
+		runtimeFilter(t, filters[0], 10, envoytypev3.FractionalPercent_HUNDRED)
+		sc := filters[1].GetStatusCodeFilter()
+		require.NotNil(t, sc)
+		assert.Equal(t, envoyaccesslogv3.ComparisonFilter_GE, sc.GetComparison().GetOp())
+		assert.Equal(t, uint32(500), sc.GetComparison().GetValue().GetDefaultValue())
+		rf := filters[2].GetResponseFlagFilter()
+		require.NotNil(t, rf)
+		assert.Empty(t, rf.GetFlags())
+	}
+
+	tests := []struct {
+		name     string
+		sampling *kgateway.AccessLogSampling
+		filter   *kgateway.AccessLogFilter
+		verify   func(t *testing.T, got *envoyaccesslogv3.AccessLog)
+		wantErr  string
+	}{
+		{
+			name:     "percentage",
+			sampling: &kgateway.AccessLogSampling{Percentage: new(int32(10))},
+			verify: func(t *testing.T, got *envoyaccesslogv3.AccessLog) {
+				runtimeFilter(t, got.GetFilter(), 10, envoytypev3.FractionalPercent_HUNDRED)
+			},
+		},
+		{
+			name:     "zero percentage",
+			sampling: &kgateway.AccessLogSampling{Percentage: new(int32(0))},
+			verify: func(t *testing.T, got *envoyaccesslogv3.AccessLog) {
+				runtimeFilter(t, got.GetFilter(), 0, envoytypev3.FractionalPercent_HUNDRED)
+			},
+		},
+		{
+			name: "rate",
+			sampling: &kgateway.AccessLogSampling{Rate: &kgateway.FractionalPercent{
+				Numerator:   1,
+				Denominator: &tenThousand,
+			}},
+			verify: func(t *testing.T, got *envoyaccesslogv3.AccessLog) {
+				runtimeFilter(t, got.GetFilter(), 1, envoytypev3.FractionalPercent_TEN_THOUSAND)
+			},
+		},
+		{
+			name:     "percentage with filter",
+			sampling: &kgateway.AccessLogSampling{Percentage: new(int32(10))},
+			filter:   &kgateway.AccessLogFilter{FilterType: &kgateway.FilterType{NotHealthCheckFilter: new(true)}},
+			verify: func(t *testing.T, got *envoyaccesslogv3.AccessLog) {
+				and := got.GetFilter().GetAndFilter()
+				require.NotNil(t, and)
+				require.Len(t, and.Filters, 2)
+				assert.NotNil(t, and.Filters[0].GetNotHealthCheckFilter())
+				runtimeFilter(t, and.Filters[1], 10, envoytypev3.FractionalPercent_HUNDRED)
+			},
+		},
+		{
+			name:     "always log errors",
+			sampling: &kgateway.AccessLogSampling{Percentage: new(int32(10)), AlwaysLogErrors: new(true)},
+			verify: func(t *testing.T, got *envoyaccesslogv3.AccessLog) {
+				or := got.GetFilter().GetOrFilter()
+				require.NotNil(t, or)
+				errorsFilters(t, or.Filters)
+			},
+		},
+		{
+			name:     "always log errors with filter",
+			sampling: &kgateway.AccessLogSampling{Percentage: new(int32(10)), AlwaysLogErrors: new(true)},
+			filter: &kgateway.AccessLogFilter{
+				OrFilter: []kgateway.FilterType{{NotHealthCheckFilter: new(true)}, {TraceableFilter: new(true)}},
+			},
+			verify: func(t *testing.T, got *envoyaccesslogv3.AccessLog) {
+				and := got.GetFilter().GetAndFilter()
+				require.NotNil(t, and)
+				require.Len(t, and.Filters, 2)
+				require.NotNil(t, and.Filters[0].GetOrFilter())
+				assert.Len(t, and.Filters[0].GetOrFilter().Filters, 2)
+				or := and.Filters[1].GetOrFilter()
+				require.NotNil(t, or)
+				errorsFilters(t, or.Filters)
+			},
+		},
+		{
+			name:     "percentage above 100",
+			sampling: &kgateway.AccessLogSampling{Percentage: new(int32(101))},
+			wantErr:  "access log sampling percentage 101 must be between 0 and 100",
+		},
+		{
+			name:     "negative percentage",
+			sampling: &kgateway.AccessLogSampling{Percentage: new(int32(-1))},
+			wantErr:  "access log sampling percentage -1 must be between 0 and 100",
+		},
+		{
+			name:     "neither percentage nor rate",
+			sampling: &kgateway.AccessLogSampling{AlwaysLogErrors: new(true)},
+			wantErr:  "access log sampling must set one of percentage or rate",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			accessLogs := []kgateway.AccessLog{{
+				FileSink: &kgateway.FileSink{Path: "/dev/stdout"},
+				Filter:   tc.filter,
+				Sampling: tc.sampling,
+			}}
+			cfgs, err := translateAccessLogs(accessLogs, nil)
+			require.NoError(t, err)
+
+			hcmCtx := &ir.HcmContext{
+				Gateway: ir.GatewayIR{
+					SourceObject: &ir.Gateway{
+						ObjectSource: ir.ObjectSource{
+							Name:      "gw",
+							Namespace: "default",
+						},
+					},
+				},
+			}
+
+			got, err := generateAccessLogConfig(hcmCtx, accessLogs, cfgs)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, got, 1)
+			tc.verify(t, got[0])
+		})
+	}
+}
+
 // Helper function to handle MessageToAny error in test cases
 func mustMessageToAny(t *testing.T, msg proto.Message) *anypb.Any {
 	a, err := utils.MessageToAny(msg)
 	require.NoError(t, err, "failed to convert message to Any")
 	return a
 }
+
+func TestFileSinkDestination(t *testing.T) {
+	formatters := []*envoycorev3.TypedExtensionConfig{
+		{
+			Name:        "envoy.formatter.req_without_query",
+			TypedConfig: mustMessageToAny(t, &envoy_req_without_query.ReqWithoutQuery{}),
+		},
+		{
+			Name:        "envoy.formatter.metadata",
+			TypedConfig: mustMessageToAny(t, &envoy_metadata_formatter.Metadata{}),
+		},
+	}
+	logFormat := &envoycorev3.SubstitutionFormatString{
+		Format: &envoycorev3.SubstitutionFormatString_TextFormatSource{
+			TextFormatSource: &envoycorev3.DataSource{
+				Specifier: &envoycorev3.DataSource_InlineString{InlineString: "%RESPONSE_CODE%"},
+			},
+		},
+		Formatters: formatters,
+	}
+
+	tests := []struct {
+		name        string
+		destination string
+		wantName    string
+		want        proto.Message
+		wantErr     string
+	}{
+		{
+			name:        "stdout",
+			destination: kgateway.AccessLogDestinationStdout,
+			wantName:    "envoy.access_loggers.stdout",
+			want: &envoyalstream.StdoutAccessLog{
+				AccessLogFormat: &envoyalstream.StdoutAccessLog_LogFormat{LogFormat: logFormat},
+			},
+		},
+		{
+			name:        "stderr",
+			destination: kgateway.AccessLogDestinationStderr,
+			wantName:    "envoy.access_loggers.stderr",
+			want: &envoyalstream.StderrAccessLog{
+				AccessLogFormat: &envoyalstream.StderrAccessLog_LogFormat{LogFormat: logFormat},
+			},
+		},
+		{
+			name:        "file",
+			destination: "/var/log/envoy/access.log",
+			wantName:    "envoy.access_loggers.file",
+			want: &envoyalfile.FileAccessLog{
+				Path:            "/var/log/envoy/access.log",
+				AccessLogFormat: &envoyalfile.FileAccessLog_LogFormat{LogFormat: logFormat},
+			},
+		},
+		{
+			name:        "relative path",
+			destination: "logs/access.log",
+			wantErr:     `access log destination "logs/access.log" must be stdout, stderr or an absolute file path`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := []kgateway.AccessLog{{
+				FileSink: &kgateway.FileSink{
+					Destination:  new(tt.destination),
+					StringFormat: new("%RESPONSE_CODE%"),
+				},
+			}}
+			configs, err := translateAccessLogs(config, nil)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			result, err := generateAccessLogConfig(&ir.HcmContext{}, config, configs)
+			require.NoError(t, err)
+			require.Len(t, result, 1)
+			assert.Equal(t, tt.wantName, result[0].Name)
+			assert.True(t, proto.Equal(mustMessageToAny(t, tt.want), result[0].GetTypedConfig()),
+				"got %v", result[0].GetTypedConfig())
+		})
+	}
+}
+
+func TestOTelHttpAccessLog(t *testing.T) {
+	origVersion := version.Version
+	version.Version = "v1.0.0-test"
+	t.Cleanup(func() { version.Version = origVersion })
+
+	otelLog := func(endpoint string) []kgateway.AccessLog {
+		return []kgateway.AccessLog{{
+			OpenTelemetry: &kgateway.OpenTelemetryAccessLogService{
+				HttpService: &kgateway.OpenTelemetryAccessLogHttpService{
+					BackendRef: gwv1.BackendRef{
+						BackendObjectReference: gwv1.BackendObjectReference{Name: "otel-collector"},
+					},
+					Endpoint: endpoint,
+					Headers:  []kgateway.HeaderValue{{Key: "authorization", Value: new("Bearer token")}},
+					LogName:  new("otel-log"),
+				},
+				ResourceAttributes: &kgateway.KeyAnyValueList{
+					Values: []kgateway.KeyAnyValue{{Key: "deployment.environment", Value: kgateway.AnyValue{StringValue: new("prod")}}},
+				},
+			},
+		}}
+	}
+	backends := map[string]*ir.BackendObjectIR{
+		"otel-log-0": {
+			ObjectSource: ir.ObjectSource{Kind: "Backend", Name: "otel-collector", Namespace: "default"},
+		},
+	}
+
+	t.Run("http service", func(t *testing.T) {
+		config := otelLog("http://otel-collector.monitoring:4318/v1/logs")
+		configs, err := translateAccessLogs(config, backends)
+		require.NoError(t, err)
+		result, err := generateAccessLogConfig(&ir.HcmContext{
+			Gateway: ir.GatewayIR{
+				SourceObject: &ir.Gateway{
+					ObjectSource: ir.ObjectSource{Namespace: "default", Name: "gw"},
+					Obj:          &gwv1.Gateway{},
+				},
+			},
+		}, config, configs)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "envoy.access_loggers.open_telemetry", result[0].Name)
+
+		got := &envoy_open_telemetry.OpenTelemetryAccessLogConfig{}
+		require.NoError(t, result[0].GetTypedConfig().UnmarshalTo(got))
+		want := &envoycorev3.HttpService{
+			HttpUri: &envoycorev3.HttpUri{
+				Uri:              "http://otel-collector.monitoring:4318/v1/logs",
+				HttpUpstreamType: &envoycorev3.HttpUri_Cluster{Cluster: backends["otel-log-0"].ClusterName()},
+				Timeout:          durationpb.New(kgateway.HTTPDefaultTimeout),
+			},
+			RequestHeadersToAdd: []*envoycorev3.HeaderValueOption{{
+				Header:       &envoycorev3.HeaderValue{Key: "authorization", Value: "Bearer token"},
+				AppendAction: envoycorev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+			}},
+		}
+		assert.True(t, proto.Equal(want, got.GetHttpService()), "got %v", got.GetHttpService())
+		assert.Nil(t, got.GetGrpcService())
+		assert.Equal(t, "otel-log", got.GetLogName())
+
+		attributes := map[string]string{}
+		for _, kv := range got.GetResourceAttributes().GetValues() {
+			attributes[kv.GetKey()] = kv.GetValue().GetStringValue()
+		}
+		assert.Equal(t, "prod", attributes["deployment.environment"])
+		assert.Equal(t, "gw.default", attributes["service.name"])
+	})
+
+	t.Run("bad endpoint", func(t *testing.T) {
+		_, err := translateAccessLogs(otelLog("otel-collector:4318/v1/logs"), backends)
+		require.ErrorContains(t, err, `otlp endpoint "otel-collector:4318/v1/logs" must use http or https`)
+	})
+}
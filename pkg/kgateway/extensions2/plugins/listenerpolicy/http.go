@@ -1,6 +1,7 @@
 package listenerpolicy
 
 import (
+	"fmt"
 	"net"
 	"reflect"
 	"slices"
@@ -8,7 +9,6 @@ import (
 
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
-	envoytracev3 "github.com/envoyproxy/go-control-plane/envoy/config/trace/v3"
 	healthcheckv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/health_check/v3"
 	envoy_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	envoy_header_mutationv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/early_header_mutation/header_mutation/v3"
@@ -52,12 +52,13 @@ type HttpListenerPolicyIr struct {
 	// Since the gateway name can only be determined during translation, the tracing config is split into the provider
 	// and the actual config. During translation, the default serviceName is set if not already provided
 	// and the final config is then marshalled.
-	tracingProvider               *envoytracev3.OpenTelemetryConfig
+	tracingProvider               proto.Message
 	tracingConfig                 *envoy_hcm.HttpConnectionManager_Tracing
 	acceptHttp10                  *bool
 	defaultHostForHttp10          *string
 	earlyHeaderMutationExtensions []*envoycorev3.TypedExtensionConfig
 	maxRequestHeadersKb           *uint32
+	maxRequestHeadersCount        *uint32
 	uuidRequestIdConfig           *envoyuuidv3.UuidRequestIdConfig
 }
 
@@ -175,6 +176,10 @@ func (d *HttpListenerPolicyIr) Equals(in any) bool {
 		return false
 	}
 
+	if !cmputils.PointerValsEqual(d.maxRequestHeadersCount, d2.maxRequestHeadersCount) {
+		return false
+	}
+
 	if !proto.Equal(d.uuidRequestIdConfig, d2.uuidRequestIdConfig) {
 		return false
 	}
@@ -219,7 +224,11 @@ func NewHttpListenerPolicy(krtctx krt.HandlerContext, commoncol *collections.Com
 
 	var xffNumTrustedHops *uint32
 	if h.XffNumTrustedHops != nil {
-		xffNumTrustedHops = new(uint32(*h.XffNumTrustedHops)) // nolint:gosec // G115: kubebuilder validation ensures safe for uint32
+		if *h.XffNumTrustedHops < 0 {
+			errs = append(errs, fmt.Errorf("xffNumTrustedHops must not be negative, got %d", *h.XffNumTrustedHops))
+		} else {
+			xffNumTrustedHops = new(uint32(*h.XffNumTrustedHops)) // nolint:gosec // G115: checked to be non-negative above
+		}
 	}
 
 	var xffConfig *envoyxffv3.XffConfig
@@ -263,6 +272,15 @@ func NewHttpListenerPolicy(krtctx krt.HandlerContext, commoncol *collections.Com
 		maxRequestHeadersKb = new(uint32(*h.MaxRequestHeadersKb)) // nolint:gosec // G115: kubebuilder validation ensures safe for uint32
 	}
 
+	var maxRequestHeadersCount *uint32
+	if h.MaxRequestHeadersCount != nil {
+		if *h.MaxRequestHeadersCount < 1 {
+			errs = append(errs, fmt.Errorf("maxRequestHeadersCount must be positive, got %d", *h.MaxRequestHeadersCount))
+		} else {
+			maxRequestHeadersCount = new(uint32(*h.MaxRequestHeadersCount)) // nolint:gosec // G115: checked to be positive above
+		}
+	}
+
 	var uuidRequestIdConfig *envoyuuidv3.UuidRequestIdConfig
 	if h.UuidRequestIdConfig != nil {
 		uuidRequestIdConfig = &envoyuuidv3.UuidRequestIdConfig{
@@ -292,6 +310,7 @@ func NewHttpListenerPolicy(krtctx krt.HandlerContext, commoncol *collections.Com
 		defaultHostForHttp10:          h.DefaultHostForHttp10,
 		earlyHeaderMutationExtensions: convertHeaderMutations(h.EarlyRequestHeaderModifier),
 		maxRequestHeadersKb:           maxRequestHeadersKb,
+		maxRequestHeadersCount:        maxRequestHeadersCount,
 		uuidRequestIdConfig:           uuidRequestIdConfig,
 	}, errs
 }
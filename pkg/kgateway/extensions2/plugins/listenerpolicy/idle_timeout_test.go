@@ -0,0 +1,103 @@
+package listenerpolicy
+
+import (
+	"testing"
+	"time"
+
+	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoytcp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestIdleTimeout(t *testing.T) {
+	spec := &kgateway.ListenerPolicySpec{
+		Default: &kgateway.ListenerDefaultConfig{
+			ListenerConfig: kgateway.ListenerConfig{
+				IdleTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		},
+		PerPort: []kgateway.ListenerPortConfig{
+			{
+				Port: 8443,
+				Listener: kgateway.ListenerConfig{
+					IdleTimeout: &metav1.Duration{Duration: 30 * time.Second},
+				},
+			},
+		},
+	}
+	objSrc := ir.ObjectSource{
+		Namespace: "test-ns",
+		Name:      "idle-timeout",
+	}
+
+	policyIR, errs := NewListenerPolicyIR(nil, nil, time.Now(), spec, objSrc)
+	require.Empty(t, errs)
+	require.NotNil(t, policyIR)
+
+	pass := NewGatewayTranslationPass(ir.GwTranslationCtx{}, nil).(*listenerPolicyPluginGwPass)
+	pass.ApplyListenerPlugin(&ir.ListenerContext{Port: 8080, Policy: policyIR}, &envoylistenerv3.Listener{})
+	pass.ApplyListenerPlugin(&ir.ListenerContext{Port: 8443, Policy: policyIR}, &envoylistenerv3.Listener{})
+
+	t.Run("HTTP listeners set the HCM idle timeout", func(t *testing.T) {
+		hcm := &envoy_hcm.HttpConnectionManager{}
+		require.NoError(t, pass.ApplyHCM(&ir.HcmContext{ListenerPort: 8080, Policy: policyIR}, hcm))
+		assert.Equal(t, 5*time.Minute, hcm.GetCommonHttpProtocolOptions().GetIdleTimeout().AsDuration())
+
+		hcm = &envoy_hcm.HttpConnectionManager{}
+		require.NoError(t, pass.ApplyHCM(&ir.HcmContext{ListenerPort: 8443, Policy: policyIR}, hcm))
+		assert.Equal(t, 30*time.Second, hcm.GetCommonHttpProtocolOptions().GetIdleTimeout().AsDuration())
+	})
+
+	t.Run("TCP listeners set the TCP proxy idle timeout", func(t *testing.T) {
+		tcpProxy := &envoytcp.TcpProxy{}
+		require.NoError(t, pass.ApplyTcpProxy(&ir.TcpProxyContext{ListenerPort: 8080}, tcpProxy))
+		assert.Equal(t, 5*time.Minute, tcpProxy.GetIdleTimeout().AsDuration())
+
+		tcpProxy = &envoytcp.TcpProxy{}
+		require.NoError(t, pass.ApplyTcpProxy(&ir.TcpProxyContext{ListenerPort: 8443}, tcpProxy))
+		assert.Equal(t, 30*time.Second, tcpProxy.GetIdleTimeout().AsDuration())
+	})
+
+	t.Run("listeners without a policy keep the default", func(t *testing.T) {
+		tcpProxy := &envoytcp.TcpProxy{}
+		require.NoError(t, pass.ApplyTcpProxy(&ir.TcpProxyContext{ListenerPort: 9090}, tcpProxy))
+		assert.Nil(t, tcpProxy.GetIdleTimeout())
+	})
+}
+
+func TestIdleTimeoutHttpSettingsPrecedence(t *testing.T) {
+	policyIR := &ListenerPolicyIR{
+		defaultPolicy: listenerPolicy{
+			idleTimeout: new(5 * time.Minute),
+			http: &HttpListenerPolicyIr{
+				idleTimeout: new(time.Minute),
+			},
+		},
+	}
+
+	pass := NewGatewayTranslationPass(ir.GwTranslationCtx{}, nil).(*listenerPolicyPluginGwPass)
+	hcm := &envoy_hcm.HttpConnectionManager{}
+	require.NoError(t, pass.ApplyHCM(&ir.HcmContext{ListenerPort: 8080, Policy: policyIR}, hcm))
+	assert.Equal(t, time.Minute, hcm.GetCommonHttpProtocolOptions().GetIdleTimeout().AsDuration())
+}
+
+func TestIdleTimeoutNegative(t *testing.T) {
+	spec := &kgateway.ListenerPolicySpec{
+		Default: &kgateway.ListenerDefaultConfig{
+			ListenerConfig: kgateway.ListenerConfig{
+				IdleTimeout: &metav1.Duration{Duration: -10 * time.Second},
+			},
+		},
+	}
+
+	policyIR, errs := NewListenerPolicyIR(nil, nil, time.Now(), spec, ir.ObjectSource{Namespace: "test-ns", Name: "invalid"})
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "idleTimeout must be positive, got -10s")
+	assert.Nil(t, policyIR.defaultPolicy.idleTimeout)
+}
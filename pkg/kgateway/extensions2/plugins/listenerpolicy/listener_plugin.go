@@ -9,8 +9,10 @@ import (
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	healthcheckv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/health_check/v3"
+	original_dst "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/original_dst/v3"
 	proxy_protocol "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/proxy_protocol/v3"
 	envoy_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoytcp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
 	preserve_case_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/header_formatters/preserve_case/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"google.golang.org/protobuf/proto"
@@ -54,6 +56,8 @@ type ListenerPolicyIR struct {
 type listenerPolicy struct {
 	proxyProtocol                 *anypb.Any
 	perConnectionBufferLimitBytes *uint32
+	idleTimeout                   *time.Duration
+	originalDst                   *bool
 	// only for default policy
 	clientCertificateValidation *ir.ClientCertificateValidationIR
 	rbacNetworkFilter           *anypb.Any
@@ -75,6 +79,18 @@ func newListenerPolicy(
 		perConnectionBufferLimitBytes = new(uint32(*i.PerConnectionBufferLimitBytes)) //nolint:gosec // G115: kubebuilder validation ensures 0 <= value <= 2147483647, safe for uint32
 	}
 
+	// A zero idleTimeout is rejected by CEL validation, so it is treated as unset: decoding YAML with
+	// sigs.k8s.io/yaml allocates it when httpSettings.idleTimeout is set.
+	var idleTimeout *time.Duration
+	if i.IdleTimeout != nil {
+		switch {
+		case i.IdleTimeout.Duration < 0:
+			errs = append(errs, fmt.Errorf("idleTimeout must be positive, got %s", i.IdleTimeout.Duration))
+		case i.IdleTimeout.Duration > 0:
+			idleTimeout = &i.IdleTimeout.Duration
+		}
+	}
+
 	// Translate network RBAC if configured
 	var rbacNetworkFilter *anypb.Any
 	if i.RBAC != nil {
@@ -91,6 +107,8 @@ func newListenerPolicy(
 	return listenerPolicy{
 		proxyProtocol:                 convertProxyProtocolConfig(objSrc, i.ProxyProtocol),
 		perConnectionBufferLimitBytes: perConnectionBufferLimitBytes,
+		idleTimeout:                   idleTimeout,
+		originalDst:                   i.OriginalDst,
 		rbacNetworkFilter:             rbacNetworkFilter,
 		http:                          http,
 	}, errs
@@ -153,6 +171,14 @@ func (d listenerPolicy) Equals(d2 listenerPolicy) bool {
 		return false
 	}
 
+	if !cmputils.PointerValsEqual(d.idleTimeout, d2.idleTimeout) {
+		return false
+	}
+
+	if !cmputils.PointerValsEqual(d.originalDst, d2.originalDst) {
+		return false
+	}
+
 	if !proto.Equal(d.rbacNetworkFilter, d2.rbacNetworkFilter) {
 		return false
 	}
@@ -213,11 +239,16 @@ type listenerPolicyPluginGwPass struct {
 	reporter reporter.Reporter
 
 	healthCheckPolicy  map[uint32]*healthcheckv3.HealthCheck
-	rbacNetworkFilters map[uint32]*anypb.Any // Track RBAC filters per port
-	currentPort        uint32                // Current listener port being translated
+	rbacNetworkFilters map[uint32]*anypb.Any    // Track RBAC filters per port
+	idleTimeouts       map[uint32]time.Duration // Track connection idle timeouts per port
+	originalDstErrs    map[uint32]error         // Track original destination mode conflicts per port
+	currentPort        uint32                   // Current listener port being translated
 }
 
-var _ ir.ProxyTranslationPass = &listenerPolicyPluginGwPass{}
+var (
+	_ ir.ProxyTranslationPass    = &listenerPolicyPluginGwPass{}
+	_ ir.TcpProxyTranslationPass = &listenerPolicyPluginGwPass{}
+)
 
 func NewListenerPolicyIR(
 	krtctx krt.HandlerContext,
@@ -326,6 +357,8 @@ func NewGatewayTranslationPass(tctx ir.GwTranslationCtx, reporter reporter.Repor
 		reporter:           reporter,
 		healthCheckPolicy:  map[uint32]*healthcheckv3.HealthCheck{},
 		rbacNetworkFilters: map[uint32]*anypb.Any{},
+		idleTimeouts:       map[uint32]time.Duration{},
+		originalDstErrs:    map[uint32]error{},
 	}
 }
 
@@ -367,6 +400,19 @@ func (p *listenerPolicyPluginGwPass) ApplyListenerPlugin(
 	if cfg.rbacNetworkFilter != nil {
 		p.rbacNetworkFilters[pCtx.Port] = cfg.rbacNetworkFilter
 	}
+	// Store the connection idle timeout for this port; apply it to the HCM or the TCP proxy of the filter chains
+	if cfg.idleTimeout != nil {
+		p.idleTimeouts[pCtx.Port] = *cfg.idleTimeout
+	}
+	// Hand off the connections to the listener of their original destination. Routes attached to the listener
+	// would never be used, so the conflict is reported on the filter chains of the port via NetworkFilters()
+	if cfg.originalDst != nil && *cfg.originalDst {
+		if pCtx.HasRoutes {
+			p.originalDstErrs[pCtx.Port] = fmt.Errorf("originalDst is mutually exclusive with routes, but routes are attached to the listener on port %d", pCtx.Port)
+		} else {
+			applyOriginalDst(out)
+		}
+	}
 
 	// Track the current port being translated
 	p.currentPort = pCtx.Port
@@ -377,6 +423,10 @@ func (p *listenerPolicyPluginGwPass) ApplyListenerPlugin(
 
 // NetworkFilters returns the RBAC network filter for the current listener port.
 func (p *listenerPolicyPluginGwPass) NetworkFilters() ([]filters.StagedNetworkFilter, error) {
+	if err := p.originalDstErrs[p.currentPort]; err != nil {
+		return nil, err
+	}
+
 	rbacFilter := p.rbacNetworkFilters[p.currentPort]
 	if rbacFilter == nil {
 		return nil, nil
@@ -422,6 +472,15 @@ func (p *listenerPolicyPluginGwPass) ApplyHCM(
 	out *envoy_hcm.HttpConnectionManager,
 ) error {
 	cfg := p.getPolicy(pCtx.Policy, pCtx.ListenerPort)
+
+	// translate the listener idleTimeout, httpSettings.idleTimeout takes precedence
+	if cfg.idleTimeout != nil {
+		if out.CommonHttpProtocolOptions == nil {
+			out.CommonHttpProtocolOptions = &envoycorev3.HttpProtocolOptions{}
+		}
+		out.GetCommonHttpProtocolOptions().IdleTimeout = durationpb.New(*cfg.idleTimeout)
+	}
+
 	policy := cfg.http
 	if policy == nil {
 		return nil
@@ -539,6 +598,14 @@ func (p *listenerPolicyPluginGwPass) ApplyHCM(
 		out.MaxRequestHeadersKb = wrapperspb.UInt32(*policy.maxRequestHeadersKb)
 	}
 
+	// translate maxRequestHeadersCount
+	if policy.maxRequestHeadersCount != nil {
+		if out.CommonHttpProtocolOptions == nil {
+			out.CommonHttpProtocolOptions = &envoycorev3.HttpProtocolOptions{}
+		}
+		out.GetCommonHttpProtocolOptions().MaxHeadersCount = wrapperspb.UInt32(*policy.maxRequestHeadersCount)
+	}
+
 	// translate uuidRequestIdConfig
 	if policy.uuidRequestIdConfig != nil {
 		requestIdExtensionAny, err := utils.MessageToAny(policy.uuidRequestIdConfig)
@@ -554,6 +621,16 @@ func (p *listenerPolicyPluginGwPass) ApplyHCM(
 	return nil
 }
 
+func (p *listenerPolicyPluginGwPass) ApplyTcpProxy(
+	pCtx *ir.TcpProxyContext,
+	out *envoytcp.TcpProxy,
+) error {
+	if idleTimeout, ok := p.idleTimeouts[pCtx.ListenerPort]; ok {
+		out.IdleTimeout = durationpb.New(idleTimeout)
+	}
+	return nil
+}
+
 func convertProxyProtocolConfig(objSrc ir.ObjectSource, config *kgateway.ProxyProtocolConfig) *anypb.Any {
 	if config == nil {
 		return nil
@@ -582,6 +659,7 @@ func convertClientCertificateValidationConfig(_ ir.ObjectSource, config *kgatewa
 	return &ir.ClientCertificateValidationIR{
 		RequireClientCertificate: requireClientCertificate,
 		CACertificateRefs:        config.CACertificateRefs,
+		VerifySubjectAltNames:    config.VerifySubjectAltNames,
 	}
 }
 
@@ -615,3 +693,31 @@ func (p *listenerPolicyPluginGwPass) applyProxyProtocol(
 
 	logger.Debug("added proxy protocol listener filter", "listener", out.Name)
 }
+
+// applyOriginalDst enables the transparent proxy mode of the listener: the original destination of the
+// redirected connections is restored and they are handed off to the listener bound to it.
+func applyOriginalDst(out *envoylistenerv3.Listener) {
+	for _, lf := range out.GetListenerFilters() {
+		if lf.GetName() == wellknown.OriginalDestination {
+			return
+		}
+	}
+
+	originalDstAny, err := utils.MessageToAny(&original_dst.OriginalDst{})
+	if err != nil {
+		// shouldn't happen
+		logger.Error("error translating originalDst", "error", err)
+		return
+	}
+
+	out.UseOriginalDst = wrapperspb.Bool(true)
+	// Prepend the filter so that the original destination is restored before the other listener filters run
+	out.ListenerFilters = append([]*envoylistenerv3.ListenerFilter{{
+		Name: wellknown.OriginalDestination,
+		ConfigType: &envoylistenerv3.ListenerFilter_TypedConfig{
+			TypedConfig: originalDstAny,
+		},
+	}}, out.GetListenerFilters()...)
+
+	logger.Debug("added original destination listener filter", "listener", out.Name)
+}
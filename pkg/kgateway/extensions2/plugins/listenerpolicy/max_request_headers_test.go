@@ -0,0 +1,60 @@
+package listenerpolicy
+
+import (
+	"testing"
+	"time"
+
+	envoy_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestMaxRequestHeaders(t *testing.T) {
+	spec := &kgateway.ListenerPolicySpec{
+		Default: &kgateway.ListenerDefaultConfig{
+			ListenerConfig: kgateway.ListenerConfig{
+				HTTPSettings: &kgateway.HTTPSettings{
+					MaxRequestHeadersKb:    new(int32(32)),
+					MaxRequestHeadersCount: new(int32(50)),
+				},
+			},
+		},
+		PerPort: []kgateway.ListenerPortConfig{
+			{
+				Port: 8443,
+				Listener: kgateway.ListenerConfig{
+					HTTPSettings: &kgateway.HTTPSettings{
+						MaxRequestHeadersCount: new(int32(20)),
+					},
+				},
+			},
+		},
+	}
+
+	policyIR, errs := NewListenerPolicyIR(nil, nil, time.Now(), spec, ir.ObjectSource{Namespace: "test-ns", Name: "max-headers"})
+	require.Empty(t, errs)
+
+	pass := NewGatewayTranslationPass(ir.GwTranslationCtx{}, nil).(*listenerPolicyPluginGwPass)
+
+	hcm := &envoy_hcm.HttpConnectionManager{}
+	require.NoError(t, pass.ApplyHCM(&ir.HcmContext{ListenerPort: 8080, Policy: policyIR}, hcm))
+	assert.Equal(t, uint32(32), hcm.GetMaxRequestHeadersKb().GetValue())
+	assert.Equal(t, uint32(50), hcm.GetCommonHttpProtocolOptions().GetMaxHeadersCount().GetValue())
+
+	hcm = &envoy_hcm.HttpConnectionManager{}
+	require.NoError(t, pass.ApplyHCM(&ir.HcmContext{ListenerPort: 8443, Policy: policyIR}, hcm))
+	assert.Nil(t, hcm.GetMaxRequestHeadersKb(), "per-port settings should not inherit the default")
+	assert.Equal(t, uint32(20), hcm.GetCommonHttpProtocolOptions().GetMaxHeadersCount().GetValue())
+}
+
+func TestMaxRequestHeadersCountNotPositive(t *testing.T) {
+	policy, errs := NewHttpListenerPolicy(nil, nil, &kgateway.HTTPSettings{
+		MaxRequestHeadersCount: new(int32(0)),
+	}, ir.ObjectSource{Namespace: "test-ns", Name: "invalid"})
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "maxRequestHeadersCount must be positive, got 0")
+	assert.Nil(t, policy.maxRequestHeadersCount)
+}
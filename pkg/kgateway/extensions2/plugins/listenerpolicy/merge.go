@@ -89,6 +89,8 @@ func mergeListenerPolicy(
 	mergeFuncs := []func(string, *listenerPolicy, *listenerPolicy, *ir.AttachedPolicyRef, ir.MergeOrigins, policy.MergeOptions, ir.MergeOrigins){
 		mergeProxyProtocol,
 		mergePerConnectionBufferLimitBytes,
+		mergeListenerIdleTimeout,
+		mergeOriginalDst,
 		mergeRbacNetworkFilter,
 		// Not merging ClientCertificateValidation since its only used for tls config.
 		mergeHttpSettings,
@@ -131,6 +133,38 @@ func mergePerConnectionBufferLimitBytes(
 	mergeOrigins.SetOne(origin+"perConnectionBufferLimitBytes", p2Ref, p2MergeOrigins)
 }
 
+func mergeListenerIdleTimeout(
+	origin string,
+	p1, p2 *listenerPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+) {
+	if !policy.IsMergeable(p1.idleTimeout, p2.idleTimeout, opts) {
+		return
+	}
+
+	p1.idleTimeout = p2.idleTimeout
+	mergeOrigins.SetOne(origin+"idleTimeout", p2Ref, p2MergeOrigins)
+}
+
+func mergeOriginalDst(
+	origin string,
+	p1, p2 *listenerPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+) {
+	if !policy.IsMergeable(p1.originalDst, p2.originalDst, opts) {
+		return
+	}
+
+	p1.originalDst = p2.originalDst
+	mergeOrigins.SetOne(origin+"originalDst", p2Ref, p2MergeOrigins)
+}
+
 func mergeHttpSettings(
 	origin string,
 	p1, p2 *listenerPolicy,
@@ -39,6 +39,7 @@ func MergeHttpPolicies(
 		mergeDefaultHostForHttp10,
 		mergeEarlyHeaderMutation,
 		mergeMaxRequestHeadersKb,
+		mergeMaxRequestHeadersCount,
 		mergeUuidRequestIdConfig,
 	}
 	for _, mergeFunc := range mergeFuncs {
@@ -343,6 +344,22 @@ func mergeMaxRequestHeadersKb(
 	mergeOrigins.SetOne(origin+"maxRequestHeadersKb", p2Ref, p2MergeOrigins)
 }
 
+func mergeMaxRequestHeadersCount(
+	origin string,
+	p1, p2 *HttpListenerPolicyIr,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+) {
+	if !policy.IsMergeable(p1.maxRequestHeadersCount, p2.maxRequestHeadersCount, opts) {
+		return
+	}
+
+	p1.maxRequestHeadersCount = p2.maxRequestHeadersCount
+	mergeOrigins.SetOne(origin+"maxRequestHeadersCount", p2Ref, p2MergeOrigins)
+}
+
 func mergeUuidRequestIdConfig(
 	origin string,
 	p1, p2 *HttpListenerPolicyIr,
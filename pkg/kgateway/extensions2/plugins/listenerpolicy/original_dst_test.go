@@ -0,0 +1,77 @@
+package listenerpolicy
+
+import (
+	"testing"
+	"time"
+
+	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	original_dst "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/original_dst/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestOriginalDst(t *testing.T) {
+	spec := &kgateway.ListenerPolicySpec{
+		Default: &kgateway.ListenerDefaultConfig{
+			ListenerConfig: kgateway.ListenerConfig{
+				OriginalDst:   new(true),
+				ProxyProtocol: &kgateway.ProxyProtocolConfig{},
+			},
+		},
+		PerPort: []kgateway.ListenerPortConfig{
+			{
+				Port: 8443,
+				Listener: kgateway.ListenerConfig{
+					OriginalDst: new(false),
+				},
+			},
+		},
+	}
+
+	policyIR, errs := NewListenerPolicyIR(nil, nil, time.Now(), spec, ir.ObjectSource{Namespace: "test-ns", Name: "original-dst"})
+	require.Empty(t, errs)
+
+	t.Run("listeners without routes hand off connections to their original destination", func(t *testing.T) {
+		pass := NewGatewayTranslationPass(ir.GwTranslationCtx{}, nil).(*listenerPolicyPluginGwPass)
+		out := &envoylistenerv3.Listener{}
+		pass.ApplyListenerPlugin(&ir.ListenerContext{Port: 15001, Policy: policyIR}, out)
+
+		assert.True(t, out.GetUseOriginalDst().GetValue())
+		require.Len(t, out.GetListenerFilters(), 2)
+		lf := out.GetListenerFilters()[0]
+		assert.Equal(t, wellknown.OriginalDestination, lf.GetName())
+		require.NoError(t, lf.GetTypedConfig().UnmarshalTo(&original_dst.OriginalDst{}))
+		assert.Equal(t, wellknown.ProxyProtocol, out.GetListenerFilters()[1].GetName())
+
+		networkFilters, err := pass.NetworkFilters()
+		require.NoError(t, err)
+		assert.Empty(t, networkFilters)
+	})
+
+	t.Run("disabled for the port", func(t *testing.T) {
+		pass := NewGatewayTranslationPass(ir.GwTranslationCtx{}, nil).(*listenerPolicyPluginGwPass)
+		out := &envoylistenerv3.Listener{}
+		pass.ApplyListenerPlugin(&ir.ListenerContext{Port: 8443, Policy: policyIR}, out)
+
+		assert.Nil(t, out.GetUseOriginalDst())
+		assert.Empty(t, out.GetListenerFilters())
+	})
+
+	t.Run("mutually exclusive with routes", func(t *testing.T) {
+		pass := NewGatewayTranslationPass(ir.GwTranslationCtx{}, nil).(*listenerPolicyPluginGwPass)
+		out := &envoylistenerv3.Listener{}
+		pass.ApplyListenerPlugin(&ir.ListenerContext{Port: 8080, Policy: policyIR, HasRoutes: true}, out)
+
+		assert.Nil(t, out.GetUseOriginalDst())
+		for _, lf := range out.GetListenerFilters() {
+			assert.NotEqual(t, wellknown.OriginalDestination, lf.GetName())
+		}
+
+		_, err := pass.NetworkFilters()
+		assert.EqualError(t, err, "originalDst is mutually exclusive with routes, but routes are attached to the listener on port 8080")
+	})
+}
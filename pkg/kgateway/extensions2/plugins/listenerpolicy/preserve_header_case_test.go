@@ -0,0 +1,66 @@
+package listenerpolicy
+
+import (
+	"testing"
+	"time"
+
+	envoy_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	preserve_case_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/header_formatters/preserve_case/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestPreserveHttp1HeaderCase(t *testing.T) {
+	spec := &kgateway.ListenerPolicySpec{
+		Default: &kgateway.ListenerDefaultConfig{
+			ListenerConfig: kgateway.ListenerConfig{
+				HTTPSettings: &kgateway.HTTPSettings{
+					PreserveHttp1HeaderCase: new(true),
+				},
+			},
+		},
+		PerPort: []kgateway.ListenerPortConfig{
+			{
+				Port: 8443,
+				Listener: kgateway.ListenerConfig{
+					HTTPSettings: &kgateway.HTTPSettings{
+						PreserveHttp1HeaderCase: new(false),
+					},
+				},
+			},
+			{
+				Port: 9090,
+				Listener: kgateway.ListenerConfig{
+					HTTPSettings: &kgateway.HTTPSettings{},
+				},
+			},
+		},
+	}
+
+	policyIR, errs := NewListenerPolicyIR(nil, nil, time.Now(), spec, ir.ObjectSource{Namespace: "test-ns", Name: "preserve-case"})
+	require.Empty(t, errs)
+
+	pass := NewGatewayTranslationPass(ir.GwTranslationCtx{}, nil).(*listenerPolicyPluginGwPass)
+
+	t.Run("renders the preserve case stateful formatter", func(t *testing.T) {
+		hcm := &envoy_hcm.HttpConnectionManager{}
+		require.NoError(t, pass.ApplyHCM(&ir.HcmContext{ListenerPort: 8080, Policy: policyIR}, hcm))
+
+		formatter := hcm.GetHttpProtocolOptions().GetHeaderKeyFormat().GetStatefulFormatter()
+		require.NotNil(t, formatter)
+		assert.Equal(t, "envoy.http.stateful_header_formatters.preserve_case", formatter.GetName())
+		var cfg preserve_case_v3.PreserveCaseFormatterConfig
+		require.NoError(t, formatter.GetTypedConfig().UnmarshalTo(&cfg))
+	})
+
+	t.Run("normalizes header case when disabled or unset", func(t *testing.T) {
+		for _, port := range []uint32{8443, 9090} {
+			hcm := &envoy_hcm.HttpConnectionManager{}
+			require.NoError(t, pass.ApplyHCM(&ir.HcmContext{ListenerPort: port, Policy: policyIR}, hcm))
+			assert.Nil(t, hcm.GetHttpProtocolOptions().GetHeaderKeyFormat(), "port %d", port)
+		}
+	})
+}
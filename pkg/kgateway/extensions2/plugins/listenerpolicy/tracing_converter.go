@@ -1,7 +1,12 @@
 package listenerpolicy
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
 
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoytracev3 "github.com/envoyproxy/go-control-plane/envoy/config/trace/v3"
@@ -11,8 +16,11 @@ import (
 	metadatav3 "github.com/envoyproxy/go-control-plane/envoy/type/metadata/v3"
 	tracingv3 "github.com/envoyproxy/go-control-plane/envoy/type/tracing/v3"
 	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	"istio.io/istio/pkg/kube/krt"
+	"k8s.io/utils/ptr"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
@@ -22,22 +30,34 @@ import (
 
 const (
 	otelTracerName                  = "envoy.tracers.opentelemetry"
+	zipkinTracerName                = "envoy.tracers.zipkin"
+	datadogTracerName               = "envoy.tracers.datadog"
 	environmentResourceDetectorName = "envoy.tracers.opentelemetry.resource_detectors.environment"
 	alwaysOnSamplerName             = "envoy.tracers.opentelemetry.samplers.always_on"
+	defaultZipkinCollectorEndpoint  = "/api/v2/spans"
 )
 
+// headerNameRegex matches HTTP header names and pseudo header names, as validated by the CRD.
+var headerNameRegex = regexp.MustCompile("^:?[A-Za-z0-9!#$%&'*+.^_`|~-]+$")
+
+// convertTracingConfig returns the tracing provider config, one of OpenTelemetryConfig, ZipkinConfig or
+// DatadogConfig, and the HCM tracing config.
 func convertTracingConfig(
 	policy *kgateway.HTTPSettings,
 	commoncol *collections.CommonCollections,
 	krtctx krt.HandlerContext,
 	parentSrc ir.ObjectSource,
-) (*envoytracev3.OpenTelemetryConfig, *envoy_hcm.HttpConnectionManager_Tracing, error) {
+) (proto.Message, *envoy_hcm.HttpConnectionManager_Tracing, error) {
 	config := policy.Tracing
 	if config == nil {
 		return nil, nil, nil
 	}
 
-	backend, err := commoncol.BackendIndex.GetBackendFromRef(krtctx, parentSrc, config.Provider.OpenTelemetry.GrpcService.BackendRef.BackendObjectReference)
+	backendRef, err := tracingProviderBackendRef(config.Provider)
+	if err != nil {
+		return nil, nil, err
+	}
+	backend, err := commoncol.BackendIndex.GetBackendFromRef(krtctx, parentSrc, backendRef.BackendObjectReference)
 	if err != nil {
 		return nil, nil, fmt.Errorf("%w: %v", ErrUnresolvedBackendRef, err)
 	}
@@ -48,12 +68,19 @@ func convertTracingConfig(
 func translateTracing(
 	config *kgateway.Tracing,
 	backend *ir.BackendObjectIR,
-) (*envoytracev3.OpenTelemetryConfig, *envoy_hcm.HttpConnectionManager_Tracing, error) {
+) (proto.Message, *envoy_hcm.HttpConnectionManager_Tracing, error) {
 	if config == nil {
 		return nil, nil, nil
 	}
 
-	provider, err := convertOTelTracingConfig(config.Provider.OpenTelemetry, backend)
+	if err := validateTracingProvider(config.Provider); err != nil {
+		return nil, nil, err
+	}
+	if err := validateTracingPropagation(config); err != nil {
+		return nil, nil, err
+	}
+
+	provider, err := convertTracingProvider(config, backend)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -85,6 +112,13 @@ func translateTracing(
 	if len(config.Attributes) != 0 {
 		tracingConfig.CustomTags = ConvertCustomAttributesToCustomTags(config.Attributes)
 	}
+	if len(config.CustomTags) != 0 {
+		customTags, err := convertTracingCustomTags(config.CustomTags, config.Attributes)
+		if err != nil {
+			return nil, nil, err
+		}
+		tracingConfig.CustomTags = append(tracingConfig.CustomTags, customTags...)
+	}
 	if config.SpawnUpstreamSpan != nil {
 		tracingConfig.SpawnUpstreamSpan = &wrapperspb.BoolValue{
 			Value: *config.SpawnUpstreamSpan,
@@ -94,6 +128,109 @@ func translateTracing(
 	return provider, tracingConfig, nil
 }
 
+// tracingProviderBackendRef returns the backend ref of the tracing provider.
+func tracingProviderBackendRef(provider kgateway.TracingProvider) (*gwv1.BackendRef, error) {
+	if err := validateTracingProvider(provider); err != nil {
+		return nil, err
+	}
+	switch {
+	case provider.OpenTelemetry != nil:
+		return &provider.OpenTelemetry.GrpcService.BackendRef, nil
+	case provider.Zipkin != nil:
+		return &provider.Zipkin.BackendRef, nil
+	default:
+		return &provider.Datadog.BackendRef, nil
+	}
+}
+
+// validateTracingProvider checks that exactly one tracing provider is configured.
+func validateTracingProvider(provider kgateway.TracingProvider) error {
+	var configured []string
+	if provider.OpenTelemetry != nil {
+		configured = append(configured, "openTelemetry")
+	}
+	if provider.Zipkin != nil {
+		configured = append(configured, "zipkin")
+	}
+	if provider.Datadog != nil {
+		configured = append(configured, "datadog")
+	}
+	switch len(configured) {
+	case 0:
+		return errors.New("tracing provider must set one of openTelemetry, zipkin or datadog")
+	case 1:
+		return nil
+	default:
+		return fmt.Errorf("tracing provider must set only one of openTelemetry, zipkin or datadog, got %s",
+			strings.Join(configured, ", "))
+	}
+}
+
+func convertTracingProvider(config *kgateway.Tracing, backend *ir.BackendObjectIR) (proto.Message, error) {
+	switch {
+	case config.Provider.OpenTelemetry != nil:
+		return convertOTelTracingConfig(config.Provider.OpenTelemetry, backend)
+	case config.Provider.Zipkin != nil:
+		return convertZipkinTracingConfig(config.Provider.Zipkin, config.Propagation, backend), nil
+	default:
+		return convertDatadogTracingConfig(config.Provider.Datadog, backend), nil
+	}
+}
+
+// validateTracingPropagation checks that the provider supports the selected trace context propagation.
+// Envoy's OpenTelemetry tracer only extracts and injects the W3C trace context headers, its Zipkin tracer
+// the B3 headers, optionally along with the W3C ones, and its Datadog tracer does not configure it.
+func validateTracingPropagation(config *kgateway.Tracing) error {
+	if config.Propagation == nil {
+		return nil
+	}
+	propagation := *config.Propagation
+	switch propagation {
+	case kgateway.TracingPropagationW3C, kgateway.TracingPropagationB3, kgateway.TracingPropagationB3AndW3C:
+	default:
+		return fmt.Errorf("unknown tracing propagation %q", propagation)
+	}
+	switch {
+	case config.Provider.OpenTelemetry != nil && propagation != kgateway.TracingPropagationW3C:
+		return fmt.Errorf("tracing propagation %s is not supported by the openTelemetry provider, which only supports %s",
+			propagation, kgateway.TracingPropagationW3C)
+	case config.Provider.Zipkin != nil && propagation == kgateway.TracingPropagationW3C:
+		return fmt.Errorf("tracing propagation %s is not supported by the zipkin provider, which only supports %s and %s",
+			propagation, kgateway.TracingPropagationB3, kgateway.TracingPropagationB3AndW3C)
+	case config.Provider.Datadog != nil:
+		return fmt.Errorf("tracing propagation %s is not supported by the datadog provider, which does not support setting it",
+			propagation)
+	}
+	return nil
+}
+
+// convertTracingCustomTags converts the custom tags, from tag name to request header name, to Envoy request
+// header CustomTags sorted by tag name.
+func convertTracingCustomTags(customTags map[string]kgateway.CustomTagHeaderName, attrs []kgateway.CustomAttribute) ([]*tracingv3.CustomTag, error) {
+	tags := make([]*tracingv3.CustomTag, 0, len(customTags))
+	for _, tag := range slices.Sorted(maps.Keys(customTags)) {
+		header := string(customTags[tag])
+		if tag == "" {
+			return nil, fmt.Errorf("custom tag for header %q must have a name", header)
+		}
+		if !headerNameRegex.MatchString(header) {
+			return nil, fmt.Errorf("custom tag %q header name %q is not a valid HTTP header name", tag, header)
+		}
+		if slices.ContainsFunc(attrs, func(attr kgateway.CustomAttribute) bool { return attr.Name == tag }) {
+			return nil, fmt.Errorf("custom tag %q is also set in attributes", tag)
+		}
+		tags = append(tags, &tracingv3.CustomTag{
+			Tag: tag,
+			Type: &tracingv3.CustomTag_RequestHeader{
+				RequestHeader: &tracingv3.CustomTag_Header{
+					Name: header,
+				},
+			},
+		})
+	}
+	return tags, nil
+}
+
 func convertOTelTracingConfig(
 	config *kgateway.OpenTelemetryTracingConfig,
 	backend *ir.BackendObjectIR,
@@ -143,20 +280,61 @@ func convertOTelTracingConfig(
 	return tracingCfg, nil
 }
 
-func updateTracingConfig(pCtx *ir.HcmContext, tracingProvider *envoytracev3.OpenTelemetryConfig, tracingConfig *envoy_hcm.HttpConnectionManager_Tracing) {
+func convertZipkinTracingConfig(
+	config *kgateway.ZipkinTracingConfig,
+	propagation *kgateway.TracingPropagation,
+	backend *ir.BackendObjectIR,
+) *envoytracev3.ZipkinConfig {
+	tracingCfg := &envoytracev3.ZipkinConfig{
+		CollectorCluster:         backend.ClusterName(),
+		CollectorEndpoint:        ptr.Deref(config.CollectorEndpoint, defaultZipkinCollectorEndpoint),
+		CollectorEndpointVersion: envoytracev3.ZipkinConfig_HTTP_JSON,
+	}
+	if ptr.Deref(propagation, "") == kgateway.TracingPropagationB3AndW3C {
+		tracingCfg.TraceContextOption = envoytracev3.ZipkinConfig_USE_B3_WITH_W3C_PROPAGATION
+	}
+	return tracingCfg
+}
+
+func convertDatadogTracingConfig(
+	config *kgateway.DatadogTracingConfig,
+	backend *ir.BackendObjectIR,
+) *envoytracev3.DatadogConfig {
+	return &envoytracev3.DatadogConfig{
+		CollectorCluster: backend.ClusterName(),
+		ServiceName:      ptr.Deref(config.ServiceName, ""),
+	}
+}
+
+func updateTracingConfig(pCtx *ir.HcmContext, tracingProvider proto.Message, tracingConfig *envoy_hcm.HttpConnectionManager_Tracing) {
 	if tracingProvider == nil || tracingConfig == nil {
 		return
 	}
-	if tracingProvider.ServiceName == "" {
-		tracingProvider.ServiceName = GenerateDefaultServiceName(pCtx.Gateway.SourceObject.GetName(), pCtx.Gateway.SourceObject.GetNamespace())
+	defaultServiceName := GenerateDefaultServiceName(pCtx.Gateway.SourceObject.GetName(), pCtx.Gateway.SourceObject.GetNamespace())
+
+	var tracerName string
+	switch provider := tracingProvider.(type) {
+	case *envoytracev3.OpenTelemetryConfig:
+		tracerName = otelTracerName
+		if provider.ServiceName == "" {
+			provider.ServiceName = defaultServiceName
+		}
+	case *envoytracev3.ZipkinConfig:
+		tracerName = zipkinTracerName
+	case *envoytracev3.DatadogConfig:
+		tracerName = datadogTracerName
+		if provider.ServiceName == "" {
+			provider.ServiceName = defaultServiceName
+		}
+	default:
+		logger.Error("unsupported tracing provider", "type", fmt.Sprintf("%T", tracingProvider))
+		return
 	}
 
-	otelCfg := utils.MustMessageToAny(tracingProvider)
-
 	tracingConfig.Provider = &envoytracev3.Tracing_Http{
-		Name: otelTracerName,
+		Name: tracerName,
 		ConfigType: &envoytracev3.Tracing_Http_TypedConfig{
-			TypedConfig: otelCfg,
+			TypedConfig: utils.MustMessageToAny(tracingProvider),
 		},
 	}
 }
@@ -437,6 +437,116 @@ func TestTracingConverter(t *testing.T) {
 					SpawnUpstreamSpan: &wrapperspb.BoolValue{Value: true},
 				},
 			},
+			{
+				name: "Zipkin Tracing minimal config",
+				config: &kgateway.Tracing{
+					Provider: kgateway.TracingProvider{
+						Zipkin: &kgateway.ZipkinTracingConfig{
+							BackendRef: gwv1.BackendRef{
+								BackendObjectReference: gwv1.BackendObjectReference{
+									Name: "test-service",
+								},
+							},
+						},
+					},
+				},
+				expected: &envoy_hcm.HttpConnectionManager_Tracing{
+					Provider: &envoytracev3.Tracing_Http{
+						Name: "envoy.tracers.zipkin",
+						ConfigType: &envoytracev3.Tracing_Http_TypedConfig{
+							TypedConfig: mustMessageToAny(t, &envoytracev3.ZipkinConfig{
+								CollectorCluster:         "backend_default_test-service_0",
+								CollectorEndpoint:        "/api/v2/spans",
+								CollectorEndpointVersion: envoytracev3.ZipkinConfig_HTTP_JSON,
+							}),
+						},
+					},
+				},
+			},
+			{
+				name: "Zipkin Tracing full config",
+				config: &kgateway.Tracing{
+					Provider: kgateway.TracingProvider{
+						Zipkin: &kgateway.ZipkinTracingConfig{
+							BackendRef: gwv1.BackendRef{
+								BackendObjectReference: gwv1.BackendObjectReference{
+									Name: "test-service",
+								},
+							},
+							CollectorEndpoint: new("/zipkin/api/v2/spans"),
+						},
+					},
+					Propagation:    new(kgateway.TracingPropagationB3AndW3C),
+					RandomSampling: new(int32(25)),
+				},
+				expected: &envoy_hcm.HttpConnectionManager_Tracing{
+					Provider: &envoytracev3.Tracing_Http{
+						Name: "envoy.tracers.zipkin",
+						ConfigType: &envoytracev3.Tracing_Http_TypedConfig{
+							TypedConfig: mustMessageToAny(t, &envoytracev3.ZipkinConfig{
+								CollectorCluster:         "backend_default_test-service_0",
+								CollectorEndpoint:        "/zipkin/api/v2/spans",
+								CollectorEndpointVersion: envoytracev3.ZipkinConfig_HTTP_JSON,
+								TraceContextOption:       envoytracev3.ZipkinConfig_USE_B3_WITH_W3C_PROPAGATION,
+							}),
+						},
+					},
+					RandomSampling: &typev3.Percent{
+						Value: 25,
+					},
+				},
+			},
+			{
+				name: "Datadog Tracing minimal config",
+				config: &kgateway.Tracing{
+					Provider: kgateway.TracingProvider{
+						Datadog: &kgateway.DatadogTracingConfig{
+							BackendRef: gwv1.BackendRef{
+								BackendObjectReference: gwv1.BackendObjectReference{
+									Name: "test-service",
+								},
+							},
+						},
+					},
+				},
+				expected: &envoy_hcm.HttpConnectionManager_Tracing{
+					Provider: &envoytracev3.Tracing_Http{
+						Name: "envoy.tracers.datadog",
+						ConfigType: &envoytracev3.Tracing_Http_TypedConfig{
+							TypedConfig: mustMessageToAny(t, &envoytracev3.DatadogConfig{
+								CollectorCluster: "backend_default_test-service_0",
+								ServiceName:      "gw.default",
+							}),
+						},
+					},
+				},
+			},
+			{
+				name: "Datadog Tracing with service name",
+				config: &kgateway.Tracing{
+					Provider: kgateway.TracingProvider{
+						Datadog: &kgateway.DatadogTracingConfig{
+							BackendRef: gwv1.BackendRef{
+								BackendObjectReference: gwv1.BackendObjectReference{
+									Name: "test-service",
+								},
+							},
+							ServiceName: new("my-gateway"),
+						},
+					},
+				},
+				expected: &envoy_hcm.HttpConnectionManager_Tracing{
+					Provider: &envoytracev3.Tracing_Http{
+						Name: "envoy.tracers.datadog",
+						ConfigType: &envoytracev3.Tracing_Http_TypedConfig{
+							TypedConfig: mustMessageToAny(t, &envoytracev3.DatadogConfig{
+								CollectorCluster: "backend_default_test-service_0",
+								ServiceName:      "my-gateway",
+							}),
+						},
+					},
+				},
+			},
 		}
 		for _, tc := range testCases {
 			_, cancel := context.WithCancel(context.Background())
@@ -478,3 +588,266 @@ func TestTracingConverter(t *testing.T) {
 		}
 	})
 }
+
+func TestTracingPropagation(t *testing.T) {
+	otelTracing := func(propagation *kgateway.TracingPropagation) *kgateway.Tracing {
+		return &kgateway.Tracing{
+			Provider: kgateway.TracingProvider{
+				OpenTelemetry: &kgateway.OpenTelemetryTracingConfig{
+					GrpcService: kgateway.CommonGrpcService{
+						BackendRef: gwv1.BackendRef{
+							BackendObjectReference: gwv1.BackendObjectReference{
+								Name: "test-service",
+							},
+						},
+					},
+				},
+			},
+			Propagation: propagation,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		propagation *kgateway.TracingPropagation
+		wantErr     string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:        "W3C",
+			propagation: new(kgateway.TracingPropagationW3C),
+		},
+		{
+			name:        "B3",
+			propagation: new(kgateway.TracingPropagationB3),
+			wantErr:     "tracing propagation B3 is not supported by the openTelemetry provider, which only supports W3C",
+		},
+		{
+			name:        "B3AndW3C",
+			propagation: new(kgateway.TracingPropagationB3AndW3C),
+			wantErr:     "tracing propagation B3AndW3C is not supported by the openTelemetry provider, which only supports W3C",
+		},
+		{
+			name:        "unknown",
+			propagation: new(kgateway.TracingPropagation("Jaeger")),
+			wantErr:     `unknown tracing propagation "Jaeger"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, config, err := translateTracing(otelTracing(tt.propagation), &ir.BackendObjectIR{
+				ObjectSource: ir.ObjectSource{
+					Kind:      "Backend",
+					Name:      "test-service",
+					Namespace: "default",
+				},
+			})
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, config)
+			// the OpenTelemetry tracer propagates the W3C trace context without further configuration
+			assert.True(t, proto.Equal(&envoytracev3.OpenTelemetryConfig{
+				GrpcService: &envoycorev3.GrpcService{
+					TargetSpecifier: &envoycorev3.GrpcService_EnvoyGrpc_{
+						EnvoyGrpc: &envoycorev3.GrpcService_EnvoyGrpc{
+							ClusterName: "backend_default_test-service_0",
+						},
+					},
+				},
+				ResourceDetectors: []*envoycorev3.TypedExtensionConfig{{
+					Name:        "envoy.tracers.opentelemetry.resource_detectors.environment",
+					TypedConfig: mustMessageToAny(t, &resource_detectorsv3.EnvironmentResourceDetectorConfig{}),
+				}},
+			}, provider), "unexpected provider %v", provider)
+		})
+	}
+}
+
+func TestTracingCustomTags(t *testing.T) {
+	otelTracing := func(attrs []kgateway.CustomAttribute, customTags map[string]kgateway.CustomTagHeaderName) *kgateway.Tracing {
+		return &kgateway.Tracing{
+			Provider: kgateway.TracingProvider{
+				OpenTelemetry: &kgateway.OpenTelemetryTracingConfig{
+					GrpcService: kgateway.CommonGrpcService{
+						BackendRef: gwv1.BackendRef{
+							BackendObjectReference: gwv1.BackendObjectReference{
+								Name: "test-service",
+							},
+						},
+					},
+				},
+			},
+			Attributes: attrs,
+			CustomTags: customTags,
+		}
+	}
+	requestHeaderTag := func(tag, header string) *tracingv3.CustomTag {
+		return &tracingv3.CustomTag{
+			Tag: tag,
+			Type: &tracingv3.CustomTag_RequestHeader{
+				RequestHeader: &tracingv3.CustomTag_Header{
+					Name: header,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		attrs      []kgateway.CustomAttribute
+		customTags map[string]kgateway.CustomTagHeaderName
+		expected   []*tracingv3.CustomTag
+		wantErr    string
+	}{
+		{
+			name: "sorted by tag name",
+			customTags: map[string]kgateway.CustomTagHeaderName{
+				"request.id": "x-request-id",
+				"host":       ":authority",
+				"tenant":     "X-Tenant",
+			},
+			expected: []*tracingv3.CustomTag{
+				requestHeaderTag("host", ":authority"),
+				requestHeaderTag("request.id", "x-request-id"),
+				requestHeaderTag("tenant", "X-Tenant"),
+			},
+		},
+		{
+			name: "after attributes",
+			attrs: []kgateway.CustomAttribute{{
+				Name:    "env",
+				Literal: &kgateway.CustomAttributeLiteral{Value: "prod"},
+			}},
+			customTags: map[string]kgateway.CustomTagHeaderName{"request.id": "x-request-id"},
+			expected: []*tracingv3.CustomTag{
+				{
+					Tag: "env",
+					Type: &tracingv3.CustomTag_Literal_{
+						Literal: &tracingv3.CustomTag_Literal{Value: "prod"},
+					},
+				},
+				requestHeaderTag("request.id", "x-request-id"),
+			},
+		},
+		{
+			name:       "invalid header name",
+			customTags: map[string]kgateway.CustomTagHeaderName{"user": "x user"},
+			wantErr:    `custom tag "user" header name "x user" is not a valid HTTP header name`,
+		},
+		{
+			name:       "empty header name",
+			customTags: map[string]kgateway.CustomTagHeaderName{"user": ""},
+			wantErr:    `custom tag "user" header name "" is not a valid HTTP header name`,
+		},
+		{
+			name:       "empty tag name",
+			customTags: map[string]kgateway.CustomTagHeaderName{"": "x-user"},
+			wantErr:    `custom tag for header "x-user" must have a name`,
+		},
+		{
+			name: "tag name set in attributes",
+			attrs: []kgateway.CustomAttribute{{
+				Name:    "user",
+				Literal: &kgateway.CustomAttributeLiteral{Value: "anonymous"},
+			}},
+			customTags: map[string]kgateway.CustomTagHeaderName{"user": "x-user"},
+			wantErr:    `custom tag "user" is also set in attributes`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, config, err := translateTracing(otelTracing(tt.attrs, tt.customTags), &ir.BackendObjectIR{
+				ObjectSource: ir.ObjectSource{
+					Kind:      "Backend",
+					Name:      "test-service",
+					Namespace: "default",
+				},
+			})
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, config)
+			require.Len(t, config.CustomTags, len(tt.expected))
+			for i := range tt.expected {
+				assert.True(t, proto.Equal(tt.expected[i], config.CustomTags[i]),
+					"custom tag %d mismatch\n %v\n %v\n", i, tt.expected[i], config.CustomTags[i])
+			}
+		})
+	}
+}
+
+func TestTracingProvider(t *testing.T) {
+	backendRef := gwv1.BackendRef{
+		BackendObjectReference: gwv1.BackendObjectReference{
+			Name: "test-service",
+		},
+	}
+	otel := &kgateway.OpenTelemetryTracingConfig{GrpcService: kgateway.CommonGrpcService{BackendRef: backendRef}}
+	zipkin := &kgateway.ZipkinTracingConfig{BackendRef: backendRef}
+	datadog := &kgateway.DatadogTracingConfig{BackendRef: backendRef}
+
+	tests := []struct {
+		name        string
+		provider    kgateway.TracingProvider
+		propagation *kgateway.TracingPropagation
+		wantErr     string
+	}{
+		{
+			name:     "no provider",
+			provider: kgateway.TracingProvider{},
+			wantErr:  "tracing provider must set one of openTelemetry, zipkin or datadog",
+		},
+		{
+			name:     "openTelemetry and zipkin",
+			provider: kgateway.TracingProvider{OpenTelemetry: otel, Zipkin: zipkin},
+			wantErr:  "tracing provider must set only one of openTelemetry, zipkin or datadog, got openTelemetry, zipkin",
+		},
+		{
+			name:        "all providers",
+			provider:    kgateway.TracingProvider{OpenTelemetry: otel, Zipkin: zipkin, Datadog: datadog},
+			propagation: new(kgateway.TracingPropagationB3),
+			wantErr:     "tracing provider must set only one of openTelemetry, zipkin or datadog, got openTelemetry, zipkin, datadog",
+		},
+		{
+			name:        "zipkin with B3 propagation",
+			provider:    kgateway.TracingProvider{Zipkin: zipkin},
+			propagation: new(kgateway.TracingPropagationB3),
+		},
+		{
+			name:        "zipkin with W3C propagation",
+			provider:    kgateway.TracingProvider{Zipkin: zipkin},
+			propagation: new(kgateway.TracingPropagationW3C),
+			wantErr:     "tracing propagation W3C is not supported by the zipkin provider, which only supports B3 and B3AndW3C",
+		},
+		{
+			name:        "datadog with propagation",
+			provider:    kgateway.TracingProvider{Datadog: datadog},
+			propagation: new(kgateway.TracingPropagationW3C),
+			wantErr:     "tracing propagation W3C is not supported by the datadog provider, which does not support setting it",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, _, err := translateTracing(&kgateway.Tracing{Provider: tt.provider, Propagation: tt.propagation}, &ir.BackendObjectIR{
+				ObjectSource: ir.ObjectSource{
+					Kind:      "Backend",
+					Name:      "test-service",
+					Namespace: "default",
+				},
+			})
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, provider)
+		})
+	}
+}
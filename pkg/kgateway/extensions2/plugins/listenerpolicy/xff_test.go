@@ -0,0 +1,76 @@
+package listenerpolicy
+
+import (
+	"testing"
+	"time"
+
+	envoy_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoyxffv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/original_ip_detection/xff/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestXffNumTrustedHops(t *testing.T) {
+	spec := &kgateway.ListenerPolicySpec{
+		Default: &kgateway.ListenerDefaultConfig{
+			ListenerConfig: kgateway.ListenerConfig{
+				HTTPSettings: &kgateway.HTTPSettings{
+					XffNumTrustedHops: new(int32(2)),
+					SkipXffAppend:     new(true),
+				},
+			},
+		},
+		PerPort: []kgateway.ListenerPortConfig{
+			{
+				Port: 8443,
+				Listener: kgateway.ListenerConfig{
+					HTTPSettings: &kgateway.HTTPSettings{
+						UseRemoteAddress:  new(false),
+						XffNumTrustedHops: new(int32(1)),
+						SkipXffAppend:     new(false),
+					},
+				},
+			},
+		},
+	}
+
+	policyIR, errs := NewListenerPolicyIR(nil, nil, time.Now(), spec, ir.ObjectSource{Namespace: "test-ns", Name: "xff"})
+	require.Empty(t, errs)
+
+	pass := NewGatewayTranslationPass(ir.GwTranslationCtx{}, nil).(*listenerPolicyPluginGwPass)
+
+	t.Run("trusted hops are set on the HCM", func(t *testing.T) {
+		hcm := &envoy_hcm.HttpConnectionManager{}
+		require.NoError(t, pass.ApplyHCM(&ir.HcmContext{ListenerPort: 8080, Policy: policyIR}, hcm))
+		assert.Equal(t, uint32(2), hcm.GetXffNumTrustedHops())
+		assert.True(t, hcm.GetSkipXffAppend())
+		assert.Empty(t, hcm.GetOriginalIpDetectionExtensions())
+	})
+
+	t.Run("trusted hops move to the XFF extension without the remote address", func(t *testing.T) {
+		hcm := &envoy_hcm.HttpConnectionManager{}
+		require.NoError(t, pass.ApplyHCM(&ir.HcmContext{ListenerPort: 8443, Policy: policyIR}, hcm))
+		assert.Zero(t, hcm.GetXffNumTrustedHops())
+		assert.False(t, hcm.GetUseRemoteAddress().GetValue())
+
+		require.Len(t, hcm.GetOriginalIpDetectionExtensions(), 1)
+		ext := hcm.GetOriginalIpDetectionExtensions()[0]
+		assert.Equal(t, "envoy.http.original_ip_detection.xff", ext.GetName())
+		var xff envoyxffv3.XffConfig
+		require.NoError(t, ext.GetTypedConfig().UnmarshalTo(&xff))
+		assert.Equal(t, uint32(1), xff.GetXffNumTrustedHops())
+		assert.False(t, xff.GetSkipXffAppend().GetValue())
+	})
+}
+
+func TestXffNumTrustedHopsNegative(t *testing.T) {
+	policy, errs := NewHttpListenerPolicy(nil, nil, &kgateway.HTTPSettings{
+		XffNumTrustedHops: new(int32(-1)),
+	}, ir.ObjectSource{Namespace: "test-ns", Name: "invalid"})
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "xffNumTrustedHops must not be negative, got -1")
+	assert.Nil(t, policy.xffNumTrustedHops)
+}
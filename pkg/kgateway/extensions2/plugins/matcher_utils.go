@@ -1,7 +1,9 @@
 package plugins
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"cel.dev/expr"
 	cncfcorev3 "github.com/cncf/xds/go/xds/core/v3"
@@ -72,7 +74,7 @@ func ParseCELExpression(env *cel.Env, celExpr sharedv1alpha1.CELExpression) (*ex
 
 	ast, iss := env.Parse(string(celExpr))
 	if iss.Err() != nil {
-		return nil, iss.Err()
+		return nil, celIssuesError(iss)
 	}
 
 	parsedExpr, err := cel.AstToParsedExpr(ast)
@@ -95,6 +97,16 @@ func ParseCELExpression(env *cel.Env, celExpr sharedv1alpha1.CELExpression) (*ex
 	return &celDevParsed, nil
 }
 
+// celIssuesError reports the errors of the issues with the line and column they are found at.
+func celIssuesError(iss *cel.Issues) error {
+	msgs := make([]string, 0, len(iss.Errors()))
+	for _, e := range iss.Errors() {
+		// columns are reported 0-based by the parser
+		msgs = append(msgs, fmt.Sprintf("line %d, column %d: %s", e.Location.Line(), e.Location.Column()+1, e.Message))
+	}
+	return errors.New(strings.Join(msgs, "; "))
+}
+
 // CreateCELMatcher creates a CEL matcher for RBAC policies from CEL expressions.
 func CreateCELMatcher(celExprs []sharedv1alpha1.CELExpression, action sharedv1alpha1.AuthorizationPolicyAction) (*cncfmatcherv3.Matcher_MatcherList_FieldMatcher, error) {
 	if len(celExprs) == 0 {
@@ -123,7 +135,7 @@ func CreateCELMatcher(celExprs []sharedv1alpha1.CELExpression, action sharedv1al
 		// Single expression - use SinglePredicate
 		celDevParsed, err := ParseCELExpression(env, celExprs[0])
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse CEL expression: %w", err)
+			return nil, fmt.Errorf("failed to parse CEL expression %q: %w", celExprs[0], err)
 		}
 
 		matcher := &cncfmatcherv3.CelMatcher{
@@ -157,7 +169,7 @@ func CreateCELMatcher(celExprs []sharedv1alpha1.CELExpression, action sharedv1al
 		for _, celExpr := range celExprs {
 			celDevParsed, err := ParseCELExpression(env, celExpr)
 			if err != nil {
-				return nil, fmt.Errorf("failed to parse CEL expression: %w", err)
+				return nil, fmt.Errorf("failed to parse CEL expression %q: %w", celExpr, err)
 			}
 
 			matcher := &cncfmatcherv3.CelMatcher{
@@ -219,6 +219,13 @@ func TestParseCELExpression(t *testing.T) {
 			name:        "incomplete expression",
 			expr:        `source.address ==`,
 			expectError: true,
+			errorString: "line 1, column 18: Syntax error: mismatched input '<EOF>'",
+		},
+		{
+			name:        "error on a later line",
+			expr:        "source.address == \"10.0.0.1\" &&\n  request.headers[",
+			expectError: true,
+			errorString: "line 2, column 19: Syntax error:",
 		},
 		{
 			name:        "unclosed string literal",
@@ -151,7 +151,7 @@ func constructAPIKeyAuth(
 	// Convert API KeySources to Envoy KeySource format
 	var envoyKeySources []*envoyapikeyauthv3.KeySource
 	if len(ak.KeySources) > 0 {
-		for _, keySource := range ak.KeySources {
+		for i, keySource := range ak.KeySources {
 			envoyKeySource := &envoyapikeyauthv3.KeySource{}
 			if keySource.Header != nil && *keySource.Header != "" {
 				envoyKeySource.Header = *keySource.Header
@@ -162,10 +162,11 @@ func constructAPIKeyAuth(
 			if keySource.Cookie != nil && *keySource.Cookie != "" {
 				envoyKeySource.Cookie = *keySource.Cookie
 			}
-			// Only add if at least one source is specified
-			if envoyKeySource.Header != "" || envoyKeySource.Query != "" || envoyKeySource.Cookie != "" {
-				envoyKeySources = append(envoyKeySources, envoyKeySource)
+			// The CRD requires a location; reject key sources that bypass it instead of dropping them
+			if envoyKeySource.Header == "" && envoyKeySource.Query == "" && envoyKeySource.Cookie == "" {
+				return fmt.Errorf("keySources[%d]: one of header, query or cookie must be set", i)
 			}
+			envoyKeySources = append(envoyKeySources, envoyKeySource)
 		}
 	}
 
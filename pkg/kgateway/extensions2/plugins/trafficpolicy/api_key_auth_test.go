@@ -7,7 +7,19 @@ import (
 	envoyapikeyauthv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/api_key_auth/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"istio.io/istio/pkg/kube/krt"
+	"istio.io/istio/pkg/kube/krt/krttest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 )
 
@@ -364,3 +376,98 @@ func TestHandleAPIKeyAuth(t *testing.T) {
 		})
 	}
 }
+
+func TestConstructAPIKeyAuth(t *testing.T) {
+	commoncol := &collections.CommonCollections{
+		Secrets: newTestSecretIndex(t, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-keys", Namespace: "default"},
+			Data:       map[string][]byte{"client1": []byte("k-123")},
+		}),
+	}
+	credentials := []*envoyapikeyauthv3.Credential{{Key: "k-123", Client: "client1"}}
+
+	tests := []struct {
+		name       string
+		keySources []kgateway.APIKeySource
+		want       []*envoyapikeyauthv3.KeySource
+		wantErr    string
+	}{
+		{
+			name: "defaults to the api-key header",
+			want: []*envoyapikeyauthv3.KeySource{{Header: "api-key"}},
+		},
+		{
+			name:       "key in a header",
+			keySources: []kgateway.APIKeySource{{Header: ptr.To("x-api-key")}},
+			want:       []*envoyapikeyauthv3.KeySource{{Header: "x-api-key"}},
+		},
+		{
+			name:       "key in a query parameter",
+			keySources: []kgateway.APIKeySource{{Query: ptr.To("api_key")}},
+			want:       []*envoyapikeyauthv3.KeySource{{Query: "api_key"}},
+		},
+		{
+			name: "header first, then query parameter",
+			keySources: []kgateway.APIKeySource{
+				{Header: ptr.To("x-api-key")},
+				{Query: ptr.To("api_key")},
+			},
+			want: []*envoyapikeyauthv3.KeySource{{Header: "x-api-key"}, {Query: "api_key"}},
+		},
+		{
+			name:       "key source without a location is rejected",
+			keySources: []kgateway.APIKeySource{{Header: ptr.To("x-api-key")}, {}},
+			wantErr:    "keySources[1]: one of header, query or cookie must be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &kgateway.TrafficPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+				Spec: kgateway.TrafficPolicySpec{
+					APIKeyAuth: &kgateway.APIKeyAuth{
+						KeySources: tt.keySources,
+						SecretRef:  &gwv1.SecretObjectReference{Name: "api-keys"},
+					},
+				},
+			}
+			out := &trafficPolicySpecIr{}
+
+			err := constructAPIKeyAuth(krt.TestingDummyContext{}, policy, commoncol, out)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, out.apiKeyAuth)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, out.apiKeyAuth)
+
+			want := &envoyapikeyauthv3.ApiKeyAuthPerRoute{
+				Credentials: credentials,
+				KeySources:  tt.want,
+				Forwarding:  &envoyapikeyauthv3.Forwarding{HideCredentials: true},
+			}
+			assert.True(t, proto.Equal(want, out.apiKeyAuth.config), "got %v", out.apiKeyAuth.config)
+			assert.NoError(t, out.apiKeyAuth.Validate())
+		})
+	}
+}
+
+// newTestSecretIndex returns a SecretIndex serving the secrets, without any ReferenceGrant.
+func newTestSecretIndex(t *testing.T, secrets ...*corev1.Secret) *krtcollections.SecretIndex {
+	var objs []any
+	for _, secret := range secrets {
+		objs = append(objs, ir.Secret{
+			ObjectSource: ir.ObjectSource{Kind: "Secret", Namespace: secret.Namespace, Name: secret.Name},
+			Obj:          secret,
+			Data:         secret.Data,
+		})
+	}
+	mock := krttest.NewMock(t, objs)
+	return krtcollections.NewSecretIndex(
+		map[schema.GroupKind]krt.Collection[ir.Secret]{
+			{Group: "", Kind: "Secret"}: krttest.GetMockCollection[ir.Secret](mock),
+		},
+		krtcollections.NewRefGrantIndex(krttest.GetMockCollection[*gwv1b1.ReferenceGrant](mock)),
+	)
+}
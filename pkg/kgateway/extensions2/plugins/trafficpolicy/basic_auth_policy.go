@@ -1,6 +1,8 @@
 package trafficpolicy
 
 import (
+	"crypto/sha1" //nolint:gosec // G505: only the digest size is used, htpasswd {SHA} hashes are SHA-1
+	"encoding/base64"
 	"fmt"
 	"strings"
 
@@ -137,7 +139,7 @@ func constructBasicAuth(
 
 	// Report invalid users if any were found
 	if len(invalidUsers) > 0 {
-		err = fmt.Errorf("basic auth: dropped %d user(s) with invalid hash format (only {SHA} is supported) or duplicate usernames.", len(invalidUsers))
+		err = fmt.Errorf("basic auth: dropped %d malformed user(s), user(s) with invalid hash format (only {SHA} is supported) or duplicate usernames.", len(invalidUsers))
 	}
 
 	allUsers := strings.Join(validUsers, "\n")
@@ -238,9 +240,13 @@ func validateAndFilterSHAUsers(htpasswdData string) (validUsers []string, invali
 
 		username := parts[0]
 		passwordHash := parts[1]
+		if username == "" {
+			logger.Warn("malformed htpasswd entry, empty username", "line", i+1)
+			invalidUsernames = append(invalidUsernames, username)
+			continue
+		}
 
-		// 5=len("{SHA}"), 28=SHA1 base64 length. these validations are copied from envoy source code.
-		validHash := strings.HasPrefix(passwordHash, shaPrefix) && len(passwordHash) == (28+5)
+		validHash := isSHAHash(passwordHash)
 		isDuplicate := validUsernames.Has(username)
 
 		// Check if the password hash uses {SHA} format
@@ -255,3 +261,14 @@ func validateAndFilterSHAUsers(htpasswdData string) (validUsers []string, invali
 
 	return validUsers, invalidUsernames
 }
+
+// isSHAHash returns true if the hash is the base64 encoded SHA-1 digest of a password prefixed by {SHA}.
+func isSHAHash(hash string) bool {
+	// 5=len("{SHA}"), 28=SHA1 base64 length. these validations are copied from envoy source code.
+	if !strings.HasPrefix(hash, shaPrefix) || len(hash) != (28+5) {
+		return false
+	}
+	// envoy compares the hash as is, a hash that doesn't decode would never match any password
+	digest, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(hash, shaPrefix))
+	return err == nil && len(digest) == sha1.Size
+}
@@ -3,7 +3,16 @@ package trafficpolicy
 import (
 	"testing"
 
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_basic_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/basic_auth/v3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"istio.io/istio/pkg/kube/krt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 )
 
 func TestValidateAndFilterSHAUsers(t *testing.T) {
@@ -105,6 +114,18 @@ user2:{SHA}2kuSN7rMzfGcB2DKt67EqDWQELA=`,
 			},
 			expectedInvalid: []string{"malformedentry"},
 		},
+		{
+			name:            "malformed entry without username",
+			htpasswdData:    ":{SHA}NWoZK3kTsExUV00Ywo1G5jlUKKs=",
+			expectedValid:   []string{},
+			expectedInvalid: []string{""},
+		},
+		{
+			name:            "SHA hash that isn't base64 is filtered out",
+			htpasswdData:    "user:{SHA}NWoZK3kTsExUV00Ywo1G5jlUKK!!",
+			expectedValid:   []string{},
+			expectedInvalid: []string{"user"},
+		},
 		{
 			name:            "username with special characters",
 			htpasswdData:    "user@example.com:{SHA}NWoZK3kTsExUV00Ywo1G5jlUKKs=",
@@ -147,3 +168,83 @@ user:{SHA}2kuSN7rMzfGcB2DKt67EqDWQELA=`,
 		})
 	}
 }
+
+func TestConstructBasicAuthFromSecret(t *testing.T) {
+	secrets := newTestSecretIndex(t,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "htpasswd", Namespace: "default"},
+			Data: map[string][]byte{".htpasswd": []byte(`# users of the legacy endpoints
+user1:{SHA}NWoZK3kTsExUV00Ywo1G5jlUKKs=
+user2:{SHA}2kuSN7rMzfGcB2DKt67EqDWQELA=
+`)},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "partially-malformed", Namespace: "default"},
+			Data: map[string][]byte{".htpasswd": []byte(`user1:{SHA}NWoZK3kTsExUV00Ywo1G5jlUKKs=
+user2 {SHA}2kuSN7rMzfGcB2DKt67EqDWQELA=
+`)},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "malformed", Namespace: "default"},
+			Data:       map[string][]byte{".htpasswd": []byte("not an htpasswd file")},
+		},
+	)
+
+	tests := []struct {
+		name      string
+		secretRef kgateway.SecretReference
+		wantUsers string
+		wantErr   string
+	}{
+		{
+			name:      "valid htpasswd secret",
+			secretRef: kgateway.SecretReference{Name: "htpasswd"},
+			wantUsers: "user1:{SHA}NWoZK3kTsExUV00Ywo1G5jlUKKs=\nuser2:{SHA}2kuSN7rMzfGcB2DKt67EqDWQELA=",
+		},
+		{
+			name:      "malformed entries are dropped",
+			secretRef: kgateway.SecretReference{Name: "partially-malformed"},
+			wantUsers: "user1:{SHA}NWoZK3kTsExUV00Ywo1G5jlUKKs=",
+			wantErr:   "basic auth: dropped 1 malformed user(s), user(s) with invalid hash format (only {SHA} is supported) or duplicate usernames.",
+		},
+		{
+			name:      "malformed htpasswd secret",
+			secretRef: kgateway.SecretReference{Name: "malformed"},
+			wantErr:   "basic auth: no valid users with {SHA} hash format found",
+		},
+		{
+			name:      "missing key",
+			secretRef: kgateway.SecretReference{Name: "htpasswd", Key: new("users")},
+			wantErr:   "basic auth: secret default/htpasswd does not contain key 'users'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &kgateway.TrafficPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+				Spec: kgateway.TrafficPolicySpec{
+					BasicAuth: &kgateway.BasicAuthPolicy{SecretRef: &tt.secretRef},
+				},
+			}
+			out := &trafficPolicySpecIr{}
+
+			err := constructBasicAuth(krt.TestingDummyContext{}, policy, out, secrets)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			if tt.wantUsers == "" {
+				assert.Nil(t, out.basicAuth)
+				return
+			}
+			require.NotNil(t, out.basicAuth)
+			want := &envoy_basic_auth_v3.BasicAuthPerRoute{
+				Users: &envoycorev3.DataSource{
+					Specifier: &envoycorev3.DataSource_InlineString{InlineString: tt.wantUsers},
+				},
+			}
+			assert.True(t, proto.Equal(want, out.basicAuth.policy), "got %v", out.basicAuth.policy)
+		})
+	}
+}
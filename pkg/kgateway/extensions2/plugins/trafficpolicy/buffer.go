@@ -1,6 +1,8 @@
 package trafficpolicy
 
 import (
+	"errors"
+	"fmt"
 	"math"
 
 	bufferv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/buffer/v3"
@@ -35,34 +37,37 @@ func (b *bufferIR) Equals(other PolicySubIR) bool {
 func (b *bufferIR) Validate() error { return nil }
 
 // constructBuffer constructs the buffer policy IR from the policy specification.
-func constructBuffer(spec kgateway.TrafficPolicySpec, out *trafficPolicySpecIr) {
+func constructBuffer(spec kgateway.TrafficPolicySpec, out *trafficPolicySpecIr) error {
 	if spec.Buffer == nil {
-		return
+		return nil
 	}
 
 	perRoute := &bufferv3.BufferPerRoute{}
 
-	if spec.Buffer.Disable != nil {
-		// Disable the filter
+	switch {
+	case spec.Buffer.Disable != nil:
+		// Disable the filter, which lifts the limit applied at a higher level
 		perRoute.Override = &bufferv3.BufferPerRoute_Disabled{
 			Disabled: true,
 		}
-	} else {
-		// Validate buffer size is within uint32 range
+	case spec.Buffer.MaxRequestSize != nil:
+		// This shouldn't happen due to CRD validation, but the limit must fit the uint32 of the filter
 		bufferSize := spec.Buffer.MaxRequestSize.Value()
-		if bufferSize < 0 || bufferSize > math.MaxUint32 {
-			// Log error and use max uint32 value as fallback
-			bufferSize = math.MaxUint32
+		if bufferSize <= 0 || bufferSize > math.MaxUint32 {
+			return fmt.Errorf("buffer: maxRequestSize must be greater than 0 and less than 4Gi, got %s", spec.Buffer.MaxRequestSize)
 		}
 		perRoute.Override = &bufferv3.BufferPerRoute_Buffer{
 			Buffer: &bufferv3.Buffer{
 				MaxRequestBytes: &wrapperspb.UInt32Value{Value: uint32(bufferSize)}, //nolint:gosec // G115: validated above
 			},
 		}
+	default:
+		return errors.New("buffer: one of maxRequestSize or disable must be set")
 	}
 	out.buffer = &bufferIR{
 		perRoute: perRoute,
 	}
+	return nil
 }
 
 func (p *trafficPolicyPluginGwPass) handleBuffer(fcn string, pCtxTypedFilterConfig *ir.TypedFilterConfigMap, buffer *bufferIR) {
@@ -1,12 +1,18 @@
 package trafficpolicy
 
 import (
+	"encoding/json"
 	"testing"
 
+	bufferv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/buffer/v3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
 )
 
 func TestBufferIREquals(t *testing.T) {
@@ -46,16 +52,89 @@ func TestBufferIREquals(t *testing.T) {
 			a := assert.New(t)
 
 			aOut := &trafficPolicySpecIr{}
-			constructBuffer(kgateway.TrafficPolicySpec{
+			a.NoError(constructBuffer(kgateway.TrafficPolicySpec{
 				Buffer: tt.a,
-			}, aOut)
+			}, aOut))
 
 			bOut := &trafficPolicySpecIr{}
-			constructBuffer(kgateway.TrafficPolicySpec{
+			a.NoError(constructBuffer(kgateway.TrafficPolicySpec{
 				Buffer: tt.b,
-			}, bOut)
+			}, bOut))
 
 			a.Equal(tt.want, aOut.buffer.Equals(bOut.buffer))
 		})
 	}
 }
+
+func TestConstructBuffer(t *testing.T) {
+	tests := []struct {
+		name    string
+		buffer  *kgateway.Buffer
+		want    *bufferv3.BufferPerRoute
+		wantErr string
+	}{
+		{
+			name: "limit",
+			buffer: &kgateway.Buffer{
+				MaxRequestSize: new(resource.MustParse("10Mi")),
+			},
+			want: &bufferv3.BufferPerRoute{
+				Override: &bufferv3.BufferPerRoute_Buffer{
+					Buffer: &bufferv3.Buffer{
+						MaxRequestBytes: wrapperspb.UInt32(10 * 1024 * 1024),
+					},
+				},
+			},
+		},
+		{
+			name: "unlimited",
+			buffer: &kgateway.Buffer{
+				Disable: &shared.PolicyDisable{},
+			},
+			want: &bufferv3.BufferPerRoute{
+				Override: &bufferv3.BufferPerRoute_Disabled{Disabled: true},
+			},
+		},
+		{
+			name: "size too large",
+			buffer: &kgateway.Buffer{
+				MaxRequestSize: new(resource.MustParse("4Gi")),
+			},
+			wantErr: "buffer: maxRequestSize must be greater than 0 and less than 4Gi, got 4Gi",
+		},
+		{
+			name: "size not positive",
+			buffer: &kgateway.Buffer{
+				MaxRequestSize: new(resource.MustParse("0")),
+			},
+			wantErr: "buffer: maxRequestSize must be greater than 0 and less than 4Gi, got 0",
+		},
+		{
+			name:    "neither size nor disable",
+			buffer:  &kgateway.Buffer{},
+			wantErr: "buffer: one of maxRequestSize or disable must be set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &trafficPolicySpecIr{}
+			err := constructBuffer(kgateway.TrafficPolicySpec{Buffer: tt.buffer}, out)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, out.buffer)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(tt.want, out.buffer.perRoute), "got %v", out.buffer.perRoute)
+		})
+	}
+}
+
+func TestBufferUnparseableSize(t *testing.T) {
+	// sizes are parsed when the policy is decoded, so a policy with an unparseable size never
+	// reaches the translation
+	var buffer kgateway.Buffer
+	err := json.Unmarshal([]byte(`{"maxRequestSize": "10Mx"}`), &buffer)
+	assert.ErrorContains(t, err, "quantities must match the regular expression")
+}
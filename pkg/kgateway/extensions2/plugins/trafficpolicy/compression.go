@@ -1,11 +1,20 @@
 package trafficpolicy
 
 import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	brotlicompressorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/brotli/compressor/v3"
 	gzipcompressorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/gzip/compressor/v3"
 	gzipdecompressorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/gzip/decompressor/v3"
 	compressorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/compressor/v3"
 	decompressorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/decompressor/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
@@ -21,6 +30,8 @@ const (
 
 type compressionIR struct {
 	enable bool
+	// compressors holds the compressor filters to enable, keyed by filter name
+	compressors map[string]*compressorv3.Compressor
 }
 
 type decompressionIR struct {
@@ -40,10 +51,23 @@ func (c *compressionIR) Equals(other PolicySubIR) bool {
 	if c == nil || other == nil {
 		return c == nil && oc == nil
 	}
-	return c.enable == oc.enable
+	return c.enable == oc.enable &&
+		maps.EqualFunc(c.compressors, oc.compressors, func(a, b *compressorv3.Compressor) bool {
+			return proto.Equal(a, b)
+		})
 }
 
-func (c *compressionIR) Validate() error { return nil }
+func (c *compressionIR) Validate() error {
+	if c == nil {
+		return nil
+	}
+	for _, compressor := range c.compressors {
+		if err := compressor.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
 func (d *decompressionIR) Equals(other PolicySubIR) bool {
 	od, ok := other.(*decompressionIR)
@@ -59,44 +83,79 @@ func (d *decompressionIR) Equals(other PolicySubIR) bool {
 func (d *decompressionIR) Validate() error { return nil }
 
 // constructCompression builds IR for response compression (per-route) and decompression (listener enable toggle).
-func constructCompression(spec kgateway.TrafficPolicySpec, out *trafficPolicySpecIr) {
+func constructCompression(spec kgateway.TrafficPolicySpec, out *trafficPolicySpecIr) error {
 	if spec.Compression == nil {
-		return
+		return nil
 	}
 
 	// Enable response compression if not disabled
 	if rc := spec.Compression.ResponseCompression; rc != nil {
-		// Note: we intentionally rely on Envoy defaults for algorithm (gzip at listener) and content types.
-		out.compression = &compressionIR{enable: (rc.Disable == nil)}
+		if rc.Disable != nil {
+			out.compression = &compressionIR{enable: false}
+		} else {
+			compressors, err := buildCompressors(rc)
+			if err != nil {
+				return fmt.Errorf("compression: %w", err)
+			}
+			out.compression = &compressionIR{enable: true, compressors: compressors}
+		}
 	}
 
 	// Enable request decompression if not disabled
 	if dc := spec.Compression.RequestDecompression; dc != nil {
 		out.decompression = &decompressionIR{enable: (dc.Disable == nil)}
 	}
+	return nil
 }
 
-func (p *trafficPolicyPluginGwPass) handleCompression(fcn string, pCtxTypedFilterConfig *ir.TypedFilterConfigMap, comp *compressionIR) {
-	if comp == nil {
-		return
+// buildCompressors builds one compressor filter per configured algorithm. Envoy negotiates
+// the algorithm to use with the client based on the Accept-Encoding request header.
+func buildCompressors(rc *kgateway.ResponseCompression) (map[string]*compressorv3.Compressor, error) {
+	if rc.MinContentLength != nil && *rc.MinContentLength < 0 {
+		// This shouldn't happen due to CRD validation
+		return nil, errors.New("minContentLength must be non-negative")
 	}
 
-	// Set per-route typed config to enable or disable compression on routes.
-	if comp.enable {
-		pCtxTypedFilterConfig.AddTypedConfig(compressorFilterName, EnableFilterPerRoute())
-	} else {
-		pCtxTypedFilterConfig.AddTypedConfig(compressorFilterName, DisableFilterPerRoute())
-		return
+	// The min content length and content types are only configurable on the filter, so
+	// response compression configured with non-default values gets its own filters.
+	var commonConfig *compressorv3.Compressor_CommonDirectionConfig
+	if rc.MinContentLength != nil || len(rc.ContentTypes) > 0 {
+		commonConfig = &compressorv3.Compressor_CommonDirectionConfig{
+			ContentType: rc.ContentTypes,
+		}
+		if rc.MinContentLength != nil {
+			commonConfig.MinContentLength = wrapperspb.UInt32(uint32(*rc.MinContentLength)) // nolint:gosec // G115: validated above
+		}
 	}
 
-	// Ensure a disabled baseline compressor filter is present in the listener chain.
-	if p.compressorInChain == nil {
-		p.compressorInChain = make(map[string]*compressorv3.Compressor)
+	algorithms := rc.Algorithms
+	if len(algorithms) == 0 {
+		algorithms = []kgateway.CompressionAlgorithm{kgateway.CompressionAlgorithmGzip}
 	}
-	if _, ok := p.compressorInChain[fcn]; !ok {
-		// Build gzip compressor library with Envoy defaults.
-		gzipAny, _ := utils.MessageToAny(&gzipcompressorv3.Gzip{})
-		p.compressorInChain[fcn] = &compressorv3.Compressor{
+
+	compressors := make(map[string]*compressorv3.Compressor, len(algorithms))
+	for _, algorithm := range algorithms {
+		var library *envoycorev3.TypedExtensionConfig
+		switch algorithm {
+		case kgateway.CompressionAlgorithmGzip:
+			// Build gzip compressor library with Envoy defaults.
+			gzipAny, _ := utils.MessageToAny(&gzipcompressorv3.Gzip{})
+			library = &envoycorev3.TypedExtensionConfig{
+				Name:        "envoy.compression.gzip.compressor",
+				TypedConfig: gzipAny,
+			}
+		case kgateway.CompressionAlgorithmBrotli:
+			brotliAny, _ := utils.MessageToAny(&brotlicompressorv3.Brotli{})
+			library = &envoycorev3.TypedExtensionConfig{
+				Name:        "envoy.compression.brotli.compressor",
+				TypedConfig: brotliAny,
+			}
+		default:
+			// This shouldn't happen due to CRD validation
+			return nil, fmt.Errorf("unsupported algorithm %q", algorithm)
+		}
+
+		compressor := &compressorv3.Compressor{
 			RequestDirectionConfig: &compressorv3.Compressor_RequestDirectionConfig{
 				CommonConfig: &compressorv3.Compressor_CommonDirectionConfig{
 					Enabled: &envoycorev3.RuntimeFeatureFlag{
@@ -104,11 +163,63 @@ func (p *trafficPolicyPluginGwPass) handleCompression(fcn string, pCtxTypedFilte
 					},
 				},
 			},
-			CompressorLibrary: &envoycorev3.TypedExtensionConfig{
-				Name:        "envoy.compression.gzip.compressor",
-				TypedConfig: gzipAny,
-			},
+			CompressorLibrary: library,
+		}
+		if commonConfig != nil {
+			compressor.ResponseDirectionConfig = &compressorv3.Compressor_ResponseDirectionConfig{
+				CommonConfig: commonConfig,
+			}
+		}
+		compressors[compressorFilterNameFor(algorithm, compressor, commonConfig != nil)] = compressor
+	}
+	return compressors, nil
+}
+
+// compressorFilterNameFor returns the name of the compressor filter for the given algorithm.
+// gzip with the default settings keeps the plain compressor filter name; any other filter is
+// named after its algorithm and, if it has custom settings, the hash of its config so that
+// policies with different settings on the same filter chain get distinct filters.
+func compressorFilterNameFor(
+	algorithm kgateway.CompressionAlgorithm,
+	compressor *compressorv3.Compressor,
+	customized bool,
+) string {
+	if algorithm == kgateway.CompressionAlgorithmGzip && !customized {
+		return compressorFilterName
+	}
+	name := fmt.Sprintf("%s/%s", compressorFilterName, strings.ToLower(string(algorithm)))
+	if customized {
+		name = fmt.Sprintf("%s-%x", name, utils.HashProto(compressor))
+	}
+	return name
+}
+
+func (p *trafficPolicyPluginGwPass) handleCompression(fcn string, pCtxTypedFilterConfig *ir.TypedFilterConfigMap, comp *compressionIR) {
+	if comp == nil {
+		return
+	}
+
+	// Set per-route typed config to enable or disable compression on routes. Disabling compression
+	// disables every compressor filter of the chain, whichever algorithm enabled it.
+	if !comp.enable {
+		pCtxTypedFilterConfig.AddTypedConfig(compressorFilterName, DisableFilterPerRoute())
+		for name := range p.compressorInChain[fcn] {
+			pCtxTypedFilterConfig.AddTypedConfig(name, DisableFilterPerRoute())
 		}
+		return
+	}
+
+	// Ensure a disabled baseline compressor filter is present in the listener chain for
+	// each compressor that is enabled on the route.
+	if p.compressorInChain == nil {
+		p.compressorInChain = make(map[string]map[string]*compressorv3.Compressor)
+	}
+	if p.compressorInChain[fcn] == nil {
+		p.compressorInChain[fcn] = make(map[string]*compressorv3.Compressor)
+	}
+	for name, compressor := range comp.compressors {
+		pCtxTypedFilterConfig.AddTypedConfig(name, EnableFilterPerRoute())
+		p.compressorInChain[fcn][name] = compressor
 	}
 }
 
@@ -143,12 +254,40 @@ func (p *trafficPolicyPluginGwPass) handleDecompression(fcn string, pCtxTypedFil
 	}
 }
 
+// deferCompressionDisable records the typed per filter config of a route, virtual host or route
+// configuration that disables compression. The routes of a filter chain are translated before the
+// policies of their virtual host and route configuration, so compressor filters enabled by those
+// policies are only known once the whole chain has been translated.
+func (p *trafficPolicyPluginGwPass) deferCompressionDisable(fcn string, comp *compressionIR, typedPerFilterConfig *map[string]*anypb.Any) {
+	if comp == nil || comp.enable {
+		return
+	}
+	if p.compressionDisabledIn == nil {
+		p.compressionDisabledIn = make(map[string][]*map[string]*anypb.Any)
+	}
+	p.compressionDisabledIn[fcn] = append(p.compressionDisabledIn[fcn], typedPerFilterConfig)
+}
+
 // HttpFilters wiring is in traffic_policy_plugin.go
 func addCompressionFiltersIfNeeded(staged []filters.StagedHttpFilter, p *trafficPolicyPluginGwPass, fcn string) []filters.StagedHttpFilter {
-	if c := p.compressorInChain[fcn]; c != nil {
+	compressors := p.compressorInChain[fcn]
+	// disable the compressor filters added to the chain after compression was disabled
+	for _, typedPerFilterConfig := range p.compressionDisabledIn[fcn] {
+		for name := range compressors {
+			if _, ok := (*typedPerFilterConfig)[name]; ok {
+				continue
+			}
+			if *typedPerFilterConfig == nil {
+				*typedPerFilterConfig = make(map[string]*anypb.Any)
+			}
+			disable, _ := utils.MessageToAny(DisableFilterPerRoute())
+			(*typedPerFilterConfig)[name] = disable
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(compressors)) {
 		filter := filters.MustNewStagedFilter(
-			compressorFilterName,
-			c,
+			name,
+			compressors[name],
 			filters.AfterStage(filters.WellKnownFilterStage(filters.CorsStage)),
 		)
 		filter.Filter.Disabled = true
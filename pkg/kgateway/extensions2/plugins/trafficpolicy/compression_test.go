@@ -0,0 +1,82 @@
+package trafficpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+)
+
+func TestConstructCompression(t *testing.T) {
+	tests := []struct {
+		name        string
+		compression *kgateway.Compression
+		wantErr     string
+		wantFilters []string
+	}{
+		{
+			name:        "defaults to gzip",
+			compression: &kgateway.Compression{ResponseCompression: &kgateway.ResponseCompression{}},
+			wantFilters: []string{compressorFilterName},
+		},
+		{
+			name: "gzip and brotli",
+			compression: &kgateway.Compression{ResponseCompression: &kgateway.ResponseCompression{
+				Algorithms: []kgateway.CompressionAlgorithm{kgateway.CompressionAlgorithmGzip, kgateway.CompressionAlgorithmBrotli},
+			}},
+			wantFilters: []string{compressorFilterName, compressorFilterName + "/brotli"},
+		},
+		{
+			name: "disabled",
+			compression: &kgateway.Compression{ResponseCompression: &kgateway.ResponseCompression{
+				Disable: &shared.PolicyDisable{},
+			}},
+		},
+		{
+			name: "negative min content length is rejected",
+			compression: &kgateway.Compression{ResponseCompression: &kgateway.ResponseCompression{
+				MinContentLength: ptr.To[int32](-1),
+			}},
+			wantErr: "compression: minContentLength must be non-negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &trafficPolicySpecIr{}
+			err := constructCompression(kgateway.TrafficPolicySpec{Compression: tt.compression}, out)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, out.compression)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, out.compression)
+			require.NoError(t, out.compression.Validate())
+			assert.Equal(t, len(tt.wantFilters) > 0, out.compression.enable)
+			for _, name := range tt.wantFilters {
+				assert.Contains(t, out.compression.compressors, name)
+			}
+			assert.Len(t, out.compression.compressors, len(tt.wantFilters))
+		})
+	}
+}
+
+func TestCompressionIREquals(t *testing.T) {
+	build := func(rc *kgateway.ResponseCompression) *compressionIR {
+		out := &trafficPolicySpecIr{}
+		require.NoError(t, constructCompression(kgateway.TrafficPolicySpec{
+			Compression: &kgateway.Compression{ResponseCompression: rc},
+		}, out))
+		return out.compression
+	}
+
+	a := build(&kgateway.ResponseCompression{MinContentLength: ptr.To[int32](100)})
+	assert.True(t, a.Equals(build(&kgateway.ResponseCompression{MinContentLength: ptr.To[int32](100)})))
+	assert.False(t, a.Equals(build(&kgateway.ResponseCompression{MinContentLength: ptr.To[int32](200)})))
+	assert.False(t, a.Equals(build(&kgateway.ResponseCompression{})))
+}
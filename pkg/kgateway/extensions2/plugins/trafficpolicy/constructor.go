@@ -64,7 +64,9 @@ func (c *TrafficPolicyConstructor) ConstructIR(
 		errors = append(errors, err)
 	}
 	// Construct local rate limit specific IR
-	constructLocalRateLimit(policyCR, &outSpec)
+	if err := constructLocalRateLimit(policyCR, &outSpec); err != nil {
+		errors = append(errors, err)
+	}
 	// Construct global rate limit specific IR
 	if err := constructGlobalRateLimit(krtctx, policyCR, c.FetchGatewayExtension, &outSpec); err != nil {
 		errors = append(errors, err)
@@ -74,18 +76,24 @@ func (c *TrafficPolicyConstructor) ConstructIR(
 	// Construct csrf specific IR
 	constructCSRF(policyCR.Spec, &outSpec)
 	// Construct compression/decompression specific IR
-	constructCompression(policyCR.Spec, &outSpec)
+	if err := constructCompression(policyCR.Spec, &outSpec); err != nil {
+		errors = append(errors, err)
+	}
 
 	// Construct header modifiers specific IR
-	constructHeaderModifiers(policyCR.Spec, &outSpec)
-	// Construct header modifiers specific IR
-	constructHeaderModifiers(policyCR.Spec, &outSpec)
+	if err := constructHeaderModifiers(policyCR.Spec, &outSpec); err != nil {
+		errors = append(errors, err)
+	}
 	// Construct auto host rewrite specific IR
 	constructAutoHostRewrite(policyCR.Spec, &outSpec)
 	// Construct buffer specific IR
-	constructBuffer(policyCR.Spec, &outSpec)
+	if err := constructBuffer(policyCR.Spec, &outSpec); err != nil {
+		errors = append(errors, err)
+	}
 	// Construct fault injection specific IR
-	constructFaultInjection(policyCR.Spec, &outSpec)
+	if err := constructFaultInjection(policyCR.Spec, c.commoncol.Settings.EnableFaultInjection, &outSpec); err != nil {
+		errors = append(errors, err)
+	}
 	// Construct timeout and retry specific IR
 	constructTimeoutRetry(policyCR.Spec, &outSpec)
 
@@ -116,6 +124,14 @@ func (c *TrafficPolicyConstructor) ConstructIR(
 	if err := constructBasicAuth(krtctx, policyCR, &outSpec, c.commoncol.Secrets); err != nil {
 		errors = append(errors, err)
 	}
+	// Construct lua specific IR
+	if err := constructLua(krtctx, policyCR, c.commoncol.ConfigMaps, &outSpec); err != nil {
+		errors = append(errors, err)
+	}
+	// Construct gRPC specific IR
+	constructGRPC(policyCR.Spec, &outSpec)
+	// Construct upgrade configs specific IR
+	constructUpgradeConfigs(policyCR.Spec, &outSpec)
 
 	for _, err := range errors {
 		logger.Error("error translating traffic policy", "namespace", policyCR.GetNamespace(), "name", policyCR.GetName(), "error", err)
@@ -1,6 +1,9 @@
 package trafficpolicy
 
 import (
+	"errors"
+	"fmt"
+
 	faultcommonfaultv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/common/fault/v3"
 	faulthttpv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/fault/v3"
 	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
@@ -33,9 +36,11 @@ func (f *faultInjectionIR) Equals(other PolicySubIR) bool {
 
 func (f *faultInjectionIR) Validate() error { return nil }
 
-func constructFaultInjection(spec kgateway.TrafficPolicySpec, out *trafficPolicySpecIr) {
+// constructFaultInjection builds the fault injection IR. Injecting faults is only allowed when
+// enabled in the controller settings, while disabling fault injection is always allowed.
+func constructFaultInjection(spec kgateway.TrafficPolicySpec, enabled bool, out *trafficPolicySpecIr) error {
 	if spec.FaultInjection == nil {
-		return
+		return nil
 	}
 
 	fi := spec.FaultInjection
@@ -45,7 +50,14 @@ func constructFaultInjection(spec kgateway.TrafficPolicySpec, out *trafficPolicy
 		out.faultInjection = &faultInjectionIR{
 			httpFault: nil,
 		}
-		return
+		return nil
+	}
+
+	if !enabled {
+		return errors.New("fault injection is disabled; set KGW_ENABLE_FAULT_INJECTION=true to allow it")
+	}
+	if err := validateFaultPercentages(fi); err != nil {
+		return fmt.Errorf("fault injection: %w", err)
 	}
 
 	httpFault := &faulthttpv3.HTTPFault{}
@@ -93,6 +105,33 @@ func constructFaultInjection(spec kgateway.TrafficPolicySpec, out *trafficPolicy
 	out.faultInjection = &faultInjectionIR{
 		httpFault: httpFault,
 	}
+	return nil
+}
+
+func validateFaultPercentages(fi *kgateway.FaultInjectionPolicy) error {
+	if fi.Delay != nil {
+		if err := validatePercentage("delay", fi.Delay.Percentage); err != nil {
+			return err
+		}
+	}
+	if fi.Abort != nil {
+		if err := validatePercentage("abort", fi.Abort.Percentage); err != nil {
+			return err
+		}
+	}
+	if fi.ResponseRateLimit != nil {
+		if err := validatePercentage("responseRateLimit", fi.ResponseRateLimit.Percentage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validatePercentage(field string, percentage *int32) error {
+	if percentage != nil && (*percentage < 0 || *percentage > 100) {
+		return fmt.Errorf("%s percentage %d must be between 0 and 100", field, *percentage)
+	}
+	return nil
 }
 
 func (p *trafficPolicyPluginGwPass) handleFaultInjection(fcn string, pCtxTypedFilterConfig *ir.TypedFilterConfigMap, fi *faultInjectionIR) {
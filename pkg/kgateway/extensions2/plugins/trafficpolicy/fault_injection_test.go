@@ -6,6 +6,7 @@ import (
 
 	faulthttpv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/fault/v3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
@@ -61,10 +62,10 @@ func TestFaultInjectionIREquals(t *testing.T) {
 			a := assert.New(t)
 
 			aOut := &trafficPolicySpecIr{}
-			constructFaultInjection(kgateway.TrafficPolicySpec{FaultInjection: tt.a}, aOut)
+			a.NoError(constructFaultInjection(kgateway.TrafficPolicySpec{FaultInjection: tt.a}, true, aOut))
 
 			bOut := &trafficPolicySpecIr{}
-			constructFaultInjection(kgateway.TrafficPolicySpec{FaultInjection: tt.b}, bOut)
+			a.NoError(constructFaultInjection(kgateway.TrafficPolicySpec{FaultInjection: tt.b}, true, bOut))
 
 			a.Equal(tt.want, aOut.faultInjection.Equals(bOut.faultInjection))
 		})
@@ -196,12 +197,92 @@ func TestConstructFaultInjection(t *testing.T) {
 			if tt.name != "nil faultInjection field leaves IR nil" {
 				spec.FaultInjection = &tt.spec
 			}
-			constructFaultInjection(spec, out)
+			require.NoError(t, constructFaultInjection(spec, true, out))
 			tt.verify(t, out)
 		})
 	}
 }
 
+func TestConstructFaultInjectionGuard(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    kgateway.FaultInjectionPolicy
+		enabled bool
+		wantErr string
+	}{
+		{
+			name: "delay is rejected when fault injection is not enabled",
+			spec: kgateway.FaultInjectionPolicy{
+				Delay: &kgateway.FaultDelay{FixedDelay: metav1.Duration{Duration: 100 * time.Millisecond}},
+			},
+			wantErr: "fault injection is disabled; set KGW_ENABLE_FAULT_INJECTION=true to allow it",
+		},
+		{
+			name: "abort is rejected when fault injection is not enabled",
+			spec: kgateway.FaultInjectionPolicy{
+				Abort: &kgateway.FaultAbort{HttpStatus: new(int32(503))},
+			},
+			wantErr: "fault injection is disabled; set KGW_ENABLE_FAULT_INJECTION=true to allow it",
+		},
+		{
+			name: "disable is allowed when fault injection is not enabled",
+			spec: kgateway.FaultInjectionPolicy{
+				Disable: &shared.PolicyDisable{},
+			},
+		},
+		{
+			name: "delay percentage above 100 is rejected",
+			spec: kgateway.FaultInjectionPolicy{
+				Delay: &kgateway.FaultDelay{
+					FixedDelay: metav1.Duration{Duration: 100 * time.Millisecond},
+					Percentage: new(int32(101)),
+				},
+			},
+			enabled: true,
+			wantErr: "fault injection: delay percentage 101 must be between 0 and 100",
+		},
+		{
+			name: "negative abort percentage is rejected",
+			spec: kgateway.FaultInjectionPolicy{
+				Abort: &kgateway.FaultAbort{
+					HttpStatus: new(int32(503)),
+					Percentage: new(int32(-1)),
+				},
+			},
+			enabled: true,
+			wantErr: "fault injection: abort percentage -1 must be between 0 and 100",
+		},
+		{
+			name: "boundary percentages are allowed",
+			spec: kgateway.FaultInjectionPolicy{
+				Delay: &kgateway.FaultDelay{
+					FixedDelay: metav1.Duration{Duration: 100 * time.Millisecond},
+					Percentage: new(int32(0)),
+				},
+				Abort: &kgateway.FaultAbort{
+					HttpStatus: new(int32(503)),
+					Percentage: new(int32(100)),
+				},
+			},
+			enabled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &trafficPolicySpecIr{}
+			err := constructFaultInjection(kgateway.TrafficPolicySpec{FaultInjection: &tt.spec}, tt.enabled, out)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, out.faultInjection)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, out.faultInjection)
+		})
+	}
+}
+
 func TestHandleFaultInjection_Disable(t *testing.T) {
 	t.Run("nil IR adds no config", func(t *testing.T) {
 		p := &trafficPolicyPluginGwPass{}
@@ -0,0 +1,128 @@
+package trafficpolicy
+
+import (
+	"fmt"
+	"slices"
+
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	grpcwebv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_web/v3"
+	envoy_wellknown "github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/kgateway-dev/kgateway/v2/api/annotations"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/filters"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const grpcWebFilterName = envoy_wellknown.GRPCWeb
+
+type grpcIR struct {
+	// web is nil when gRPC-Web translation is not configured by the policy
+	web *bool
+	// maxStreamDuration holds the grpc-timeout header settings applied to the route
+	maxStreamDuration *envoyroutev3.RouteAction_MaxStreamDuration
+}
+
+var _ PolicySubIR = &grpcIR{}
+
+func (g *grpcIR) Equals(other PolicySubIR) bool {
+	otherGRPC, ok := other.(*grpcIR)
+	if !ok {
+		return false
+	}
+	if g == nil || otherGRPC == nil {
+		return g == nil && otherGRPC == nil
+	}
+	if (g.web == nil) != (otherGRPC.web == nil) || (g.web != nil && *g.web != *otherGRPC.web) {
+		return false
+	}
+	return proto.Equal(g.maxStreamDuration, otherGRPC.maxStreamDuration)
+}
+
+func (g *grpcIR) Validate() error {
+	if g == nil || g.maxStreamDuration == nil {
+		return nil
+	}
+	return g.maxStreamDuration.Validate()
+}
+
+// constructGRPC constructs the gRPC policy IR from the policy specification.
+func constructGRPC(spec kgateway.TrafficPolicySpec, out *trafficPolicySpecIr) {
+	if spec.GRPC == nil {
+		return
+	}
+
+	out.grpc = &grpcIR{
+		web: spec.GRPC.Web,
+	}
+	if spec.GRPC.MaxTimeout != nil {
+		out.grpc.maxStreamDuration = &envoyroutev3.RouteAction_MaxStreamDuration{
+			GrpcTimeoutHeaderMax: durationpb.New(spec.GRPC.MaxTimeout.Duration),
+		}
+		if spec.GRPC.TimeoutOffset != nil {
+			out.grpc.maxStreamDuration.GrpcTimeoutHeaderOffset = durationpb.New(spec.GRPC.TimeoutOffset.Duration)
+		}
+	}
+}
+
+// handleGRPC enables or disables gRPC-Web translation for the route and registers the
+// disabled gRPC-Web filter in the chain when the policy enables it
+func (p *trafficPolicyPluginGwPass) handleGRPC(fcn string, pCtxTypedFilterConfig *ir.TypedFilterConfigMap, grpc *grpcIR) {
+	if grpc == nil || grpc.web == nil {
+		return
+	}
+
+	if !*grpc.web {
+		pCtxTypedFilterConfig.AddTypedConfig(grpcWebFilterName, DisableFilterPerRoute())
+		return
+	}
+
+	pCtxTypedFilterConfig.AddTypedConfig(grpcWebFilterName, EnableFilterPerRoute())
+	if p.grpcWebInChain == nil {
+		p.grpcWebInChain = make(map[string]bool)
+	}
+	p.grpcWebInChain[fcn] = true
+}
+
+// applyGRPCTimeouts configures the route to honor the grpc-timeout header sent by clients
+func applyGRPCTimeouts(grpc *grpcIR, action *envoyroutev3.RouteAction) {
+	if grpc == nil || grpc.maxStreamDuration == nil {
+		return
+	}
+	// Preserve the max stream duration set by other policies
+	if action.GetMaxStreamDuration() == nil {
+		action.MaxStreamDuration = &envoyroutev3.RouteAction_MaxStreamDuration{}
+	}
+	action.MaxStreamDuration.GrpcTimeoutHeaderMax = grpc.maxStreamDuration.GetGrpcTimeoutHeaderMax()
+	action.MaxStreamDuration.GrpcTimeoutHeaderOffset = grpc.maxStreamDuration.GetGrpcTimeoutHeaderOffset()
+}
+
+// addGRPCWebFilterIfNeeded adds the disabled gRPC-Web filter to the chain if a route enables it.
+// gRPC-Web is carried over HTTP/1.1, so the filter is not added to chains whose TLS config
+// only negotiates other protocols, and an error is returned instead.
+func addGRPCWebFilterIfNeeded(stagedFilters []filters.StagedHttpFilter, p *trafficPolicyPluginGwPass, fcc ir.FilterChainCommon) ([]filters.StagedHttpFilter, error) {
+	if !p.grpcWebInChain[fcc.FilterChainName] {
+		return stagedFilters, nil
+	}
+	if !acceptsHTTP1(fcc.TLS) {
+		return stagedFilters, fmt.Errorf("gRPC-Web requires HTTP/1.1 but the listener only negotiates ALPN protocols %v", fcc.TLS.AlpnProtocols)
+	}
+
+	filter := filters.MustNewStagedFilter(grpcWebFilterName, &grpcwebv3.GrpcWeb{}, filters.BeforeStage(filters.CorsStage))
+	filter.Filter.Disabled = true
+	return append(stagedFilters, filter), nil
+}
+
+// acceptsHTTP1 returns true if clients can send HTTP/1.1 requests to a filter chain with the given TLS config.
+// Plaintext chains, chains using the default ALPN protocols and chains without ALPN all accept HTTP/1.1.
+func acceptsHTTP1(tls *ir.TLSConfig) bool {
+	if tls == nil || len(tls.AlpnProtocols) == 0 {
+		return true
+	}
+	if len(tls.AlpnProtocols) == 1 && tls.AlpnProtocols[0] == string(annotations.AllowEmptyAlpnProtocols) {
+		return true
+	}
+	return slices.Contains(tls.AlpnProtocols, "http/1.1")
+}
@@ -0,0 +1,126 @@
+package trafficpolicy
+
+import (
+	"testing"
+	"time"
+
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kgateway-dev/kgateway/v2/api/annotations"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestGRPCTimeouts(t *testing.T) {
+	out := &trafficPolicySpecIr{}
+	constructGRPC(kgateway.TrafficPolicySpec{GRPC: &kgateway.GRPCPolicy{
+		MaxTimeout:    &metav1.Duration{Duration: 30 * time.Second},
+		TimeoutOffset: &metav1.Duration{Duration: 50 * time.Millisecond},
+	}}, out)
+	require.NotNil(t, out.grpc)
+	require.NoError(t, out.grpc.Validate())
+
+	p := &trafficPolicyPluginGwPass{}
+	route := &envoyroutev3.Route{Action: &envoyroutev3.Route_Route{Route: &envoyroutev3.RouteAction{}}}
+	p.handlePerRoutePolicies(*out, route)
+
+	msd := route.GetRoute().GetMaxStreamDuration()
+	assert.Equal(t, 30*time.Second, msd.GetGrpcTimeoutHeaderMax().AsDuration())
+	assert.Equal(t, 50*time.Millisecond, msd.GetGrpcTimeoutHeaderOffset().AsDuration())
+
+	// routes without a RouteAction are left untouched
+	redirect := &envoyroutev3.Route{Action: &envoyroutev3.Route_Redirect{Redirect: &envoyroutev3.RedirectAction{}}}
+	p.handlePerRoutePolicies(*out, redirect)
+	assert.Nil(t, redirect.GetRoute())
+}
+
+func TestGRPCWeb(t *testing.T) {
+	tests := []struct {
+		name       string
+		tls        *ir.TLSConfig
+		wantFilter bool
+		wantErr    string
+	}{
+		{
+			name:       "plaintext listener",
+			wantFilter: true,
+		},
+		{
+			name:       "TLS listener with default ALPN",
+			tls:        &ir.TLSConfig{},
+			wantFilter: true,
+		},
+		{
+			name:       "TLS listener allowing empty ALPN",
+			tls:        &ir.TLSConfig{AlpnProtocols: []string{string(annotations.AllowEmptyAlpnProtocols)}},
+			wantFilter: true,
+		},
+		{
+			name:       "TLS listener negotiating HTTP/1.1",
+			tls:        &ir.TLSConfig{AlpnProtocols: []string{"h2", "http/1.1"}},
+			wantFilter: true,
+		},
+		{
+			name:    "TLS listener only negotiating h2",
+			tls:     &ir.TLSConfig{AlpnProtocols: []string{"h2"}},
+			wantErr: "gRPC-Web requires HTTP/1.1 but the listener only negotiates ALPN protocols [h2]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &trafficPolicySpecIr{}
+			constructGRPC(kgateway.TrafficPolicySpec{GRPC: &kgateway.GRPCPolicy{Web: ptr.To(true)}}, out)
+
+			p := &trafficPolicyPluginGwPass{}
+			typedFilterConfig := ir.TypedFilterConfigMap{}
+			p.handlePolicies("fc", &typedFilterConfig, *out)
+			assert.NotNil(t, typedFilterConfig[grpcWebFilterName])
+
+			stagedFilters, err := p.HttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{FilterChainName: "fc", TLS: tt.tls})
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				assert.Empty(t, stagedFilters)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, stagedFilters, 1)
+			assert.Equal(t, grpcWebFilterName, stagedFilters[0].Filter.GetName())
+			assert.True(t, stagedFilters[0].Filter.GetDisabled())
+		})
+	}
+}
+
+func TestGRPCWebDisabled(t *testing.T) {
+	out := &trafficPolicySpecIr{}
+	constructGRPC(kgateway.TrafficPolicySpec{GRPC: &kgateway.GRPCPolicy{Web: ptr.To(false)}}, out)
+
+	p := &trafficPolicyPluginGwPass{}
+	typedFilterConfig := ir.TypedFilterConfigMap{}
+	p.handlePolicies("fc", &typedFilterConfig, *out)
+
+	// the route opts out of gRPC-Web enabled by another route, without adding the filter to the chain
+	assert.NotNil(t, typedFilterConfig[grpcWebFilterName])
+	stagedFilters, err := p.HttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{FilterChainName: "fc"})
+	require.NoError(t, err)
+	assert.Empty(t, stagedFilters)
+}
+
+func TestGRPCIREquals(t *testing.T) {
+	build := func(g *kgateway.GRPCPolicy) *grpcIR {
+		out := &trafficPolicySpecIr{}
+		constructGRPC(kgateway.TrafficPolicySpec{GRPC: g}, out)
+		return out.grpc
+	}
+
+	a := build(&kgateway.GRPCPolicy{Web: ptr.To(true), MaxTimeout: &metav1.Duration{Duration: time.Second}})
+	assert.True(t, a.Equals(build(&kgateway.GRPCPolicy{Web: ptr.To(true), MaxTimeout: &metav1.Duration{Duration: time.Second}})))
+	assert.False(t, a.Equals(build(&kgateway.GRPCPolicy{Web: ptr.To(false), MaxTimeout: &metav1.Duration{Duration: time.Second}})))
+	assert.False(t, a.Equals(build(&kgateway.GRPCPolicy{MaxTimeout: &metav1.Duration{Duration: time.Second}})))
+	assert.False(t, a.Equals(build(&kgateway.GRPCPolicy{Web: ptr.To(true), MaxTimeout: &metav1.Duration{Duration: 2 * time.Second}})))
+	assert.False(t, a.Equals(nil))
+}
@@ -1,8 +1,13 @@
 package trafficpolicy
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	header_mutationv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/header_mutation/v3"
 	"google.golang.org/protobuf/proto"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	sharedv1alpha1 "github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
@@ -41,9 +46,13 @@ func (hm *headerModifiersIR) Validate() error {
 }
 
 // constructHeaderModifiers constructs the headerModifiers policy IR from the policy specification.
-func constructHeaderModifiers(spec kgateway.TrafficPolicySpec, out *trafficPolicySpecIr) {
+func constructHeaderModifiers(spec kgateway.TrafficPolicySpec, out *trafficPolicySpecIr) error {
 	if spec.HeaderModifiers == nil {
-		return
+		return nil
+	}
+
+	if err := validateHeaderModifiers(spec.HeaderModifiers); err != nil {
+		return fmt.Errorf("header modifiers: %w", err)
 	}
 
 	p := buildHeaderModifiersPolicy(spec.HeaderModifiers)
@@ -51,6 +60,58 @@ func constructHeaderModifiers(spec kgateway.TrafficPolicySpec, out *trafficPolic
 	out.headerModifiers = &headerModifiersIR{
 		policy: p,
 	}
+	return nil
+}
+
+// validateHeaderModifiers validates the names of the request and response headers to modify.
+// The names of added and set headers are validated by the CRD schema, but the names of removed
+// headers are not, so an invalid name would otherwise only be caught when Envoy rejects the config.
+func validateHeaderModifiers(spec *sharedv1alpha1.HeaderModifiers) error {
+	var errs []error
+	errs = append(errs, validateHeaderFilterNames("request", spec.Request)...)
+	errs = append(errs, validateHeaderFilterNames("response", spec.Response)...)
+	return errors.Join(errs...)
+}
+
+func validateHeaderFilterNames(direction string, filter *gwv1.HTTPHeaderFilter) []error {
+	if filter == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, h := range filter.Add {
+		if !isValidHeaderName(string(h.Name)) {
+			errs = append(errs, fmt.Errorf("invalid %s header name %q in add", direction, h.Name))
+		}
+	}
+	for _, h := range filter.Set {
+		if !isValidHeaderName(string(h.Name)) {
+			errs = append(errs, fmt.Errorf("invalid %s header name %q in set", direction, h.Name))
+		}
+	}
+	for _, name := range filter.Remove {
+		if !isValidHeaderName(name) {
+			errs = append(errs, fmt.Errorf("invalid %s header name %q in remove", direction, name))
+		}
+	}
+	return errs
+}
+
+// isValidHeaderName reports whether name is a valid HTTP header field name as defined by RFC 7230 (a token).
+// Pseudo-headers such as ":authority" are not valid as they cannot be modified by the header mutation filter.
+func isValidHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", c):
+		default:
+			return false
+		}
+	}
+	return true
 }
 
 // handleHeaderModifiers adds header modifier filters.
@@ -2,11 +2,20 @@ package trafficpolicy
 
 import (
 	"testing"
+	"time"
 
 	mutation_rulesv3 "github.com/envoyproxy/go-control-plane/envoy/config/common/mutation_rules/v3"
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	header_mutationv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/header_mutation/v3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/policy"
 )
 
 // Helper to create a simple header mutations filter for testing.
@@ -158,3 +167,119 @@ func TestHeaderModifiersIRValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestConstructHeaderModifiers(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *shared.HeaderModifiers
+		wantErr string
+		verify  func(t *testing.T, out *trafficPolicySpecIr)
+	}{
+		{
+			name: "request modifications",
+			spec: &shared.HeaderModifiers{
+				Request: &gwv1.HTTPHeaderFilter{
+					Set:    []gwv1.HTTPHeader{{Name: "X-Frame-Options", Value: "DENY"}},
+					Add:    []gwv1.HTTPHeader{{Name: "X-Request-Source", Value: "gateway"}},
+					Remove: []string{"X-Internal-Debug"},
+				},
+			},
+			verify: func(t *testing.T, out *trafficPolicySpecIr) {
+				require.NotNil(t, out.headerModifiers)
+				mutations := out.headerModifiers.policy.GetMutations()
+				assert.Len(t, mutations.GetRequestMutations(), 3)
+				assert.Empty(t, mutations.GetResponseMutations())
+			},
+		},
+		{
+			name: "response modifications",
+			spec: &shared.HeaderModifiers{
+				Response: &gwv1.HTTPHeaderFilter{
+					Set:    []gwv1.HTTPHeader{{Name: "Strict-Transport-Security", Value: "max-age=31536000"}},
+					Remove: []string{"Server"},
+				},
+			},
+			verify: func(t *testing.T, out *trafficPolicySpecIr) {
+				require.NotNil(t, out.headerModifiers)
+				mutations := out.headerModifiers.policy.GetMutations()
+				assert.Empty(t, mutations.GetRequestMutations())
+				require.Len(t, mutations.GetResponseMutations(), 2)
+				assert.Equal(t, "Strict-Transport-Security", mutations.GetResponseMutations()[0].GetAppend().GetHeader().GetKey())
+				assert.Equal(t, "Server", mutations.GetResponseMutations()[1].GetRemove())
+			},
+		},
+		{
+			name: "invalid removed header name is rejected",
+			spec: &shared.HeaderModifiers{
+				Request: &gwv1.HTTPHeaderFilter{
+					Remove: []string{"X-Valid", "invalid header"},
+				},
+				Response: &gwv1.HTTPHeaderFilter{
+					Remove: []string{":status"},
+				},
+			},
+			wantErr: "header modifiers: invalid request header name \"invalid header\" in remove\ninvalid response header name \":status\" in remove",
+		},
+		{
+			name: "invalid set header name is rejected",
+			spec: &shared.HeaderModifiers{
+				Response: &gwv1.HTTPHeaderFilter{
+					Set: []gwv1.HTTPHeader{{Name: "X-Bad(Header)", Value: "v"}},
+				},
+			},
+			wantErr: "header modifiers: invalid response header name \"X-Bad(Header)\" in set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &trafficPolicySpecIr{}
+			err := constructHeaderModifiers(kgateway.TrafficPolicySpec{HeaderModifiers: tt.spec}, out)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, out.headerModifiers)
+				return
+			}
+			require.NoError(t, err)
+			tt.verify(t, out)
+		})
+	}
+}
+
+func TestMergeHeaderModifiersRouteOverridesGateway(t *testing.T) {
+	newPolicy := func(value string) *TrafficPolicy {
+		out := &trafficPolicySpecIr{}
+		err := constructHeaderModifiers(kgateway.TrafficPolicySpec{
+			HeaderModifiers: &shared.HeaderModifiers{
+				Response: &gwv1.HTTPHeaderFilter{
+					Set: []gwv1.HTTPHeader{{Name: "X-Frame-Options", Value: value}},
+				},
+			},
+		}, out)
+		require.NoError(t, err)
+		return &TrafficPolicy{ct: time.Now(), spec: *out}
+	}
+
+	gk := schema.GroupKind{Group: "gateway.kgateway.dev", Kind: "TrafficPolicy"}
+	gatewayPolicy := ir.PolicyAtt{
+		GroupKind:            gk,
+		PolicyRef:            &ir.AttachedPolicyRef{Name: "gateway-defaults"},
+		PolicyIr:             newPolicy("DENY"),
+		HierarchicalPriority: 0,
+	}
+	routePolicy := ir.PolicyAtt{
+		GroupKind:            gk,
+		PolicyRef:            &ir.AttachedPolicyRef{Name: "route-override"},
+		PolicyIr:             newPolicy("SAMEORIGIN"),
+		HierarchicalPriority: 1,
+	}
+
+	merged := policy.MergePolicies([]ir.PolicyAtt{gatewayPolicy, routePolicy}, mergeTrafficPolicies, "")
+	mergedPolicy, ok := merged.PolicyIr.(*TrafficPolicy)
+	require.True(t, ok)
+	require.NotNil(t, mergedPolicy.spec.headerModifiers)
+	mutations := mergedPolicy.spec.headerModifiers.policy.GetMutations().GetResponseMutations()
+	require.Len(t, mutations, 1)
+	assert.Equal(t, "SAMEORIGIN", mutations[0].GetAppend().GetHeader().GetValue(), "route level header modifiers should override the gateway defaults")
+	assert.Equal(t, []string{routePolicy.PolicyRef.ID()}, merged.MergeOrigins.Get("headerModifiers"))
+}
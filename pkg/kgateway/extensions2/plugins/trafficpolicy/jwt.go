@@ -9,8 +9,10 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"net/url"
 	"slices"
 	"sort"
+	"strings"
 
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	jwtauthnv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
@@ -193,6 +195,9 @@ func translateProvider(
 	resolver backendResolver,
 	gwExtObj ir.ObjectSource,
 ) (*jwtauthnv3.JwtProvider, error) {
+	if err := validateIssuer(provider.Issuer); err != nil {
+		return nil, err
+	}
 	claimToHeaders := translateJWTClaimsToHeaders(provider.ClaimsToHeaders)
 	var shouldForward bool
 	if provider.ForwardToken != nil && *provider.ForwardToken {
@@ -280,9 +285,15 @@ func translateJwks(
 				return err
 			}
 			out.JwksSourceSpecifier = jwkSource
+		default:
+			// shouldn't happen due to CRD validation
+			return errors.New("local jwks: one of inline or configMapRef must be set")
 		}
 	case jwkConfig.RemoteJWKS != nil:
 		remote := jwkConfig.RemoteJWKS
+		if err := validateRemoteJWKSURL(remote.URL); err != nil {
+			return err
+		}
 		backend, err := resolver.GetBackendFromRef(krtctx, gwExtObj, remote.BackendRef)
 		if err != nil {
 			return fmt.Errorf("remote jwks: unresolved backend ref: %w", err)
@@ -302,6 +313,40 @@ func translateJwks(
 			jwksOut.RemoteJwks.CacheDuration = durationpb.New(remote.CacheDuration.Duration)
 		}
 		out.JwksSourceSpecifier = jwksOut
+	default:
+		// shouldn't happen due to CRD validation
+		return errors.New("jwks: one of local or remote must be set")
+	}
+	return nil
+}
+
+// validateIssuer checks that the issuer is a StringOrURI as defined by RFC 7519: issuers containing
+// a colon must be URIs, and http(s) issuers, such as the ones of OIDC providers, must have a host.
+func validateIssuer(issuer string) error {
+	if !strings.Contains(issuer, ":") {
+		return nil
+	}
+	u, err := url.Parse(issuer)
+	if err != nil {
+		return fmt.Errorf("jwt: invalid issuer %q: %w", issuer, err)
+	}
+	if (u.Scheme == "http" || u.Scheme == "https") && u.Host == "" {
+		return fmt.Errorf("jwt: issuer %q must have a host", issuer)
+	}
+	return nil
+}
+
+// validateRemoteJWKSURL checks that the URL of the remote JWKS is an absolute http(s) URL.
+func validateRemoteJWKSURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("remote jwks: invalid url %q: %w", raw, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("remote jwks: url %q must use http or https", raw)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("remote jwks: url %q must have a host", raw)
 	}
 	return nil
 }
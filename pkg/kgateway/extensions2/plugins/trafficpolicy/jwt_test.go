@@ -5,9 +5,12 @@ import (
 	"testing"
 	"time"
 
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	jwtauthnv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"istio.io/istio/pkg/kube/krt"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -684,3 +687,124 @@ func TestTranslateJwksRemote(t *testing.T) {
 		assert.Contains(t, err.Error(), "remote jwks: unresolved backend ref")
 	})
 }
+
+func TestTranslateProviderWithRemoteJWKS(t *testing.T) {
+	backend := &ir.BackendObjectIR{
+		ObjectSource: ir.ObjectSource{
+			Kind:      "Service",
+			Namespace: "idp",
+			Name:      "keycloak",
+		},
+		GvPrefix: "svc",
+		Port:     8443,
+	}
+	provider := kgateway.JWTProvider{
+		Issuer:    "https://keycloak.example.com/realms/org",
+		Audiences: []string{"api"},
+		JWKS: kgateway.JWKS{
+			RemoteJWKS: &kgateway.RemoteJWKS{
+				URL:        "https://keycloak.example.com/realms/org/protocol/openid-connect/certs",
+				BackendRef: gwv1.BackendObjectReference{Name: "keycloak", Port: new(gwv1.PortNumber(8443))},
+			},
+		},
+		ClaimsToHeaders: []kgateway.JWTClaimToHeader{
+			{Name: "sub", Header: "x-user-id"},
+			{Name: "email", Header: "x-user-email"},
+		},
+	}
+
+	out, err := translateProvider(nil, provider, nil, &fakeBackendResolver{backend: backend}, ir.ObjectSource{Namespace: "idp"})
+	require.NoError(t, err)
+
+	expected := &jwtauthnv3.JwtProvider{
+		Issuer:            "https://keycloak.example.com/realms/org",
+		Audiences:         []string{"api"},
+		PayloadInMetadata: PayloadInMetadata,
+		ClaimToHeaders: []*jwtauthnv3.JwtClaimToHeader{
+			{ClaimName: "sub", HeaderName: "x-user-id"},
+			{ClaimName: "email", HeaderName: "x-user-email"},
+		},
+		ClearRouteCache: true,
+		JwksSourceSpecifier: &jwtauthnv3.JwtProvider_RemoteJwks{
+			RemoteJwks: &jwtauthnv3.RemoteJwks{
+				HttpUri: &envoycorev3.HttpUri{
+					Uri:              "https://keycloak.example.com/realms/org/protocol/openid-connect/certs",
+					HttpUpstreamType: &envoycorev3.HttpUri_Cluster{Cluster: backend.ClusterName()},
+					Timeout:          durationpb.New(remoteJWKSTimeoutSecs * time.Second),
+				},
+			},
+		},
+	}
+	assert.True(t, proto.Equal(expected, out), "got %v", out)
+}
+
+func TestValidateJWTProvider(t *testing.T) {
+	resolver := &fakeBackendResolver{backend: &ir.BackendObjectIR{
+		ObjectSource: ir.ObjectSource{Kind: "Service", Namespace: "idp", Name: "keycloak"},
+		GvPrefix:     "svc",
+		Port:         8443,
+	}}
+	remoteJWKS := func(url string) kgateway.JWKS {
+		return kgateway.JWKS{RemoteJWKS: &kgateway.RemoteJWKS{URL: url, BackendRef: gwv1.BackendObjectReference{Name: "keycloak"}}}
+	}
+
+	tests := []struct {
+		name     string
+		provider kgateway.JWTProvider
+		wantErr  string
+	}{
+		{
+			name:     "issuer url",
+			provider: kgateway.JWTProvider{Issuer: "https://idp.example.com", JWKS: remoteJWKS("https://idp.example.com/keys")},
+		},
+		{
+			name:     "issuer that isn't a url",
+			provider: kgateway.JWTProvider{Issuer: "kubernetes/serviceaccount", JWKS: remoteJWKS("https://idp.example.com/keys")},
+		},
+		{
+			name:     "issuer urn",
+			provider: kgateway.JWTProvider{Issuer: "urn:example:idp", JWKS: remoteJWKS("https://idp.example.com/keys")},
+		},
+		{
+			name:     "issuer url without host",
+			provider: kgateway.JWTProvider{Issuer: "https:///realms/org", JWKS: remoteJWKS("https://idp.example.com/keys")},
+			wantErr:  `jwt: issuer "https:///realms/org" must have a host`,
+		},
+		{
+			name:     "invalid issuer uri",
+			provider: kgateway.JWTProvider{Issuer: "://idp.example.com", JWKS: remoteJWKS("https://idp.example.com/keys")},
+			wantErr:  `jwt: invalid issuer "://idp.example.com"`,
+		},
+		{
+			name:     "remote jwks url without scheme",
+			provider: kgateway.JWTProvider{Issuer: "https://idp.example.com", JWKS: remoteJWKS("idp.example.com/keys")},
+			wantErr:  `remote jwks: url "idp.example.com/keys" must use http or https`,
+		},
+		{
+			name:     "remote jwks url without host",
+			provider: kgateway.JWTProvider{Issuer: "https://idp.example.com", JWKS: remoteJWKS("https:///keys")},
+			wantErr:  `remote jwks: url "https:///keys" must have a host`,
+		},
+		{
+			name:     "no jwks source",
+			provider: kgateway.JWTProvider{Issuer: "https://idp.example.com"},
+			wantErr:  "jwks: one of local or remote must be set",
+		},
+		{
+			name:     "empty local jwks",
+			provider: kgateway.JWTProvider{Issuer: "https://idp.example.com", JWKS: kgateway.JWKS{LocalJWKS: &kgateway.LocalJWKS{}}},
+			wantErr:  "local jwks: one of inline or configMapRef must be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := translateProvider(nil, tt.provider, nil, resolver, ir.ObjectSource{Namespace: "idp"})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -1,12 +1,17 @@
 package trafficpolicy
 
 import (
+	"errors"
+
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	ratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
 	localratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
 	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
+	"k8s.io/utils/ptr"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
@@ -16,6 +21,9 @@ const (
 	localRatelimitFilterEnabledRuntimeKey  = "local_rate_limit_enabled"
 	localRatelimitFilterEnforcedRuntimeKey = "local_rate_limit_enforced"
 	localRatelimitFilterDisabledRuntimeKey = "local_rate_limit_disabled"
+
+	// remoteAddressDescriptorKey is the descriptor key produced by the remote_address rate limit action
+	remoteAddressDescriptorKey = "remote_address"
 )
 
 type localRateLimitIR struct {
@@ -46,14 +54,39 @@ func (l *localRateLimitIR) Validate() error {
 }
 
 // constructLocalRateLimit constructs the local rate limit policy IR from the policy specification.
-func constructLocalRateLimit(in *kgateway.TrafficPolicy, out *trafficPolicySpecIr) {
+func constructLocalRateLimit(in *kgateway.TrafficPolicy, out *trafficPolicySpecIr) error {
 	if in.Spec.RateLimit == nil || in.Spec.RateLimit.Local == nil {
-		return
+		return nil
+	}
+	if err := validateLocalRateLimit(in.Spec.RateLimit.Local); err != nil {
+		return err
 	}
 	localRateLimit := toLocalRateLimitFilterConfig(in.Spec.RateLimit.Local)
 	out.localRateLimit = &localRateLimitIR{
 		config: localRateLimit,
 	}
+	return nil
+}
+
+// validateLocalRateLimit rejects token bucket settings that CRD validation normally guards against,
+// so that a policy applied without it cannot produce a filter config that envoy would NACK.
+func validateLocalRateLimit(t *kgateway.LocalRateLimitPolicy) error {
+	if t.TokenBucket == nil {
+		if ptr.Deref(t.PerClientIP, false) {
+			return errors.New("local rate limit tokenBucket must be set when perClientIP is enabled")
+		}
+		return nil
+	}
+	if t.TokenBucket.MaxTokens < 1 {
+		return errors.New("local rate limit maxTokens must be positive")
+	}
+	if t.TokenBucket.TokensPerFill != nil && *t.TokenBucket.TokensPerFill < 1 {
+		return errors.New("local rate limit tokensPerFill must be positive")
+	}
+	if t.TokenBucket.FillInterval.Duration <= 0 {
+		return errors.New("local rate limit fillInterval must be positive")
+	}
+	return nil
 }
 
 func toLocalRateLimitFilterConfig(t *kgateway.LocalRateLimitPolicy) *localratelimitv3.LocalRateLimit {
@@ -107,6 +140,24 @@ func toLocalRateLimitFilterConfig(t *kgateway.LocalRateLimitPolicy) *localrateli
 		},
 	}
 
+	// Give each client IP its own bucket via a wildcard descriptor: the remote_address action
+	// produces one descriptor value per client, and envoy creates a dynamic bucket for each.
+	// The default bucket is then only consumed when no client address is available.
+	if ptr.Deref(t.PerClientIP, false) {
+		lrl.RateLimits = []*envoyroutev3.RateLimit{{
+			Actions: []*envoyroutev3.RateLimit_Action{{
+				ActionSpecifier: &envoyroutev3.RateLimit_Action_RemoteAddress_{
+					RemoteAddress: &envoyroutev3.RateLimit_Action_RemoteAddress{},
+				},
+			}},
+		}}
+		lrl.Descriptors = []*ratelimitv3.LocalRateLimitDescriptor{{
+			Entries:     []*ratelimitv3.RateLimitDescriptor_Entry{{Key: remoteAddressDescriptorKey}},
+			TokenBucket: proto.Clone(tokenBucket).(*typev3.TokenBucket),
+		}}
+		lrl.AlwaysConsumeDefaultTokenBucket = wrapperspb.Bool(false)
+	}
+
 	return lrl
 }
 
@@ -4,11 +4,19 @@ import (
 	"testing"
 	"time"
 
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	ratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
 	localratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
 	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 )
 
 func TestLocalRateLimitIREquals(t *testing.T) {
@@ -191,3 +199,127 @@ func TestLocalRateLimitIRValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestConstructLocalRateLimit(t *testing.T) {
+	policy := func(local *kgateway.LocalRateLimitPolicy) *kgateway.TrafficPolicy {
+		return &kgateway.TrafficPolicy{
+			Spec: kgateway.TrafficPolicySpec{
+				RateLimit: &kgateway.RateLimit{Local: local},
+			},
+		}
+	}
+
+	t.Run("per client IP", func(t *testing.T) {
+		out := &trafficPolicySpecIr{}
+		err := constructLocalRateLimit(policy(&kgateway.LocalRateLimitPolicy{
+			TokenBucket: &kgateway.TokenBucket{
+				MaxTokens:     10,
+				TokensPerFill: new(int32(2)),
+				FillInterval:  metav1.Duration{Duration: time.Second},
+			},
+			PerClientIP: new(true),
+		}), out)
+		require.NoError(t, err)
+		require.NotNil(t, out.localRateLimit)
+
+		bucket := &typev3.TokenBucket{
+			MaxTokens:     10,
+			TokensPerFill: wrapperspb.UInt32(2),
+			FillInterval:  durationpb.New(time.Second),
+		}
+		want := &localratelimitv3.LocalRateLimit{
+			StatPrefix:  localRateLimitStatPrefix,
+			TokenBucket: bucket,
+			FilterEnabled: &envoycorev3.RuntimeFractionalPercent{
+				RuntimeKey:   localRatelimitFilterEnabledRuntimeKey,
+				DefaultValue: &typev3.FractionalPercent{Numerator: 100, Denominator: typev3.FractionalPercent_HUNDRED},
+			},
+			FilterEnforced: &envoycorev3.RuntimeFractionalPercent{
+				RuntimeKey:   localRatelimitFilterEnforcedRuntimeKey,
+				DefaultValue: &typev3.FractionalPercent{Numerator: 100, Denominator: typev3.FractionalPercent_HUNDRED},
+			},
+			RateLimits: []*envoyroutev3.RateLimit{{
+				Actions: []*envoyroutev3.RateLimit_Action{{
+					ActionSpecifier: &envoyroutev3.RateLimit_Action_RemoteAddress_{
+						RemoteAddress: &envoyroutev3.RateLimit_Action_RemoteAddress{},
+					},
+				}},
+			}},
+			Descriptors: []*ratelimitv3.LocalRateLimitDescriptor{{
+				Entries:     []*ratelimitv3.RateLimitDescriptor_Entry{{Key: "remote_address"}},
+				TokenBucket: bucket,
+			}},
+			AlwaysConsumeDefaultTokenBucket: wrapperspb.Bool(false),
+		}
+		assert.True(t, proto.Equal(want, out.localRateLimit.config), "got %v", out.localRateLimit.config)
+		assert.NoError(t, out.localRateLimit.Validate())
+	})
+
+	t.Run("shared bucket by default", func(t *testing.T) {
+		out := &trafficPolicySpecIr{}
+		err := constructLocalRateLimit(policy(&kgateway.LocalRateLimitPolicy{
+			TokenBucket: &kgateway.TokenBucket{
+				MaxTokens:    10,
+				FillInterval: metav1.Duration{Duration: time.Second},
+			},
+		}), out)
+		require.NoError(t, err)
+		assert.Empty(t, out.localRateLimit.config.GetRateLimits())
+		assert.Empty(t, out.localRateLimit.config.GetDescriptors())
+	})
+
+	errorTests := []struct {
+		name    string
+		local   *kgateway.LocalRateLimitPolicy
+		wantErr string
+	}{
+		{
+			name: "zero fill interval",
+			local: &kgateway.LocalRateLimitPolicy{
+				TokenBucket: &kgateway.TokenBucket{MaxTokens: 10},
+			},
+			wantErr: "local rate limit fillInterval must be positive",
+		},
+		{
+			name: "negative fill interval",
+			local: &kgateway.LocalRateLimitPolicy{
+				TokenBucket: &kgateway.TokenBucket{
+					MaxTokens:    10,
+					FillInterval: metav1.Duration{Duration: -time.Second},
+				},
+			},
+			wantErr: "local rate limit fillInterval must be positive",
+		},
+		{
+			name: "zero max tokens",
+			local: &kgateway.LocalRateLimitPolicy{
+				TokenBucket: &kgateway.TokenBucket{FillInterval: metav1.Duration{Duration: time.Second}},
+			},
+			wantErr: "local rate limit maxTokens must be positive",
+		},
+		{
+			name: "zero tokens per fill",
+			local: &kgateway.LocalRateLimitPolicy{
+				TokenBucket: &kgateway.TokenBucket{
+					MaxTokens:     10,
+					TokensPerFill: new(int32(0)),
+					FillInterval:  metav1.Duration{Duration: time.Second},
+				},
+			},
+			wantErr: "local rate limit tokensPerFill must be positive",
+		},
+		{
+			name:    "per client IP without token bucket",
+			local:   &kgateway.LocalRateLimitPolicy{PerClientIP: new(true)},
+			wantErr: "local rate limit tokenBucket must be set when perClientIP is enabled",
+		},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &trafficPolicySpecIr{}
+			err := constructLocalRateLimit(policy(tt.local), out)
+			require.EqualError(t, err, tt.wantErr)
+			assert.Nil(t, out.localRateLimit)
+		})
+	}
+}
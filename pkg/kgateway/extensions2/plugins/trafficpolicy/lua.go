@@ -0,0 +1,186 @@
+package trafficpolicy
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	luav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	"google.golang.org/protobuf/proto"
+	"istio.io/istio/pkg/kube/krt"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const (
+	luaFilterNamePrefix = "envoy.filters.http.lua"
+	defaultLuaScriptKey = "script.lua"
+)
+
+// luaFilterName returns the name of the Lua filter at the given position in the chain.
+// Each entry of a LuaFilters list is rendered into its own Lua filter, as Envoy only
+// allows a single script per route for a given filter.
+func luaFilterName(idx int) string {
+	return fmt.Sprintf("%s/%d", luaFilterNamePrefix, idx)
+}
+
+type luaIR struct {
+	// perRoute holds one per-route config per configured script, in execution order
+	perRoute []*luav3.LuaPerRoute
+}
+
+var _ PolicySubIR = &luaIR{}
+
+func (l *luaIR) Equals(other PolicySubIR) bool {
+	otherLua, ok := other.(*luaIR)
+	if !ok {
+		return false
+	}
+	if l == nil || otherLua == nil {
+		return l == nil && otherLua == nil
+	}
+	return slices.EqualFunc(l.perRoute, otherLua.perRoute, func(a, b *luav3.LuaPerRoute) bool {
+		return proto.Equal(a, b)
+	})
+}
+
+func (l *luaIR) Validate() error {
+	if l == nil {
+		return nil
+	}
+	for _, perRoute := range l.perRoute {
+		if err := perRoute.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// constructLua constructs the Lua policy IR from the policy specification.
+func constructLua(
+	krtctx krt.HandlerContext,
+	in *kgateway.TrafficPolicy,
+	configMaps *krtcollections.ConfigMapIndex,
+	out *trafficPolicySpecIr,
+) error {
+	if len(in.Spec.LuaFilters) == 0 {
+		return nil
+	}
+
+	var errs []error
+	perRoute := make([]*luav3.LuaPerRoute, 0, len(in.Spec.LuaFilters))
+	for i, lua := range in.Spec.LuaFilters {
+		source, err := luaSource(krtctx, configMaps, lua, in.Namespace)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("lua filter %d: %w", i, err))
+			continue
+		}
+		perRoute = append(perRoute, &luav3.LuaPerRoute{
+			Override: &luav3.LuaPerRoute_SourceCode{
+				SourceCode: &envoycorev3.DataSource{
+					Specifier: &envoycorev3.DataSource_InlineString{
+						InlineString: source,
+					},
+				},
+			},
+		})
+	}
+	if len(errs) > 0 {
+		// Do not partially apply the scripts as later scripts may depend on earlier ones
+		return errors.Join(errs...)
+	}
+
+	out.lua = &luaIR{
+		perRoute: perRoute,
+	}
+	return nil
+}
+
+// luaSource returns the Lua source code for the given filter, either inline or from the referenced ConfigMap.
+func luaSource(
+	krtctx krt.HandlerContext,
+	configMaps *krtcollections.ConfigMapIndex,
+	lua kgateway.LuaFilter,
+	policyNamespace string,
+) (string, error) {
+	switch {
+	case lua.Inline != nil && lua.ConfigMapRef != nil:
+		// This shouldn't happen due to CEL validation
+		return "", errors.New("only one of inline or configMapRef may be specified")
+	case lua.Inline != nil:
+		return *lua.Inline, nil
+	case lua.ConfigMapRef != nil:
+		return fetchLuaFromConfigMap(krtctx, configMaps, lua.ConfigMapRef, policyNamespace)
+	default:
+		// This shouldn't happen due to CEL validation
+		return "", errors.New("either inline or configMapRef must be specified")
+	}
+}
+
+// fetchLuaFromConfigMap retrieves a Lua script from a Kubernetes ConfigMap
+func fetchLuaFromConfigMap(
+	krtctx krt.HandlerContext,
+	configMaps *krtcollections.ConfigMapIndex,
+	ref *kgateway.LuaConfigMapReference,
+	policyNamespace string,
+) (string, error) {
+	namespace := gwv1.Namespace(policyNamespace)
+	if ref.Namespace != nil {
+		namespace = *ref.Namespace
+	}
+
+	key := defaultLuaScriptKey
+	if ref.Key != nil {
+		key = *ref.Key
+	}
+
+	// Use TrafficPolicy as the source for reference grants
+	from := krtcollections.From{
+		GroupKind: wellknown.TrafficPolicyGVK.GroupKind(),
+		Namespace: policyNamespace,
+	}
+
+	cm, err := configMaps.GetConfigMap(krtctx, from, gwv1.ObjectReference{
+		Kind:      "ConfigMap",
+		Name:      ref.Name,
+		Namespace: &namespace,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	data, exists := cm.Data[key]
+	if !exists {
+		return "", fmt.Errorf("configmap %s/%s does not contain key '%s'", namespace, ref.Name, key)
+	}
+	if data == "" {
+		return "", fmt.Errorf("configmap %s/%s key '%s' is empty", namespace, ref.Name, key)
+	}
+
+	return data, nil
+}
+
+// handleLua configures the per-route Lua scripts and registers the disabled Lua filters in the chain
+func (p *trafficPolicyPluginGwPass) handleLua(fcn string, pCtxTypedFilterConfig *ir.TypedFilterConfigMap, lua *luaIR) {
+	if lua == nil {
+		return
+	}
+
+	if p.luaInChain == nil {
+		p.luaInChain = make(map[string]int)
+	}
+	for i, perRoute := range lua.perRoute {
+		pCtxTypedFilterConfig.AddTypedConfig(luaFilterName(i), perRoute)
+	}
+	// Track the largest number of scripts used by a policy so that enough filters are
+	// added to the chain. The filters are disabled by default and only enabled on the
+	// routes that have a per-route script configured.
+	if len(lua.perRoute) > p.luaInChain[fcn] {
+		p.luaInChain[fcn] = len(lua.perRoute)
+	}
+}
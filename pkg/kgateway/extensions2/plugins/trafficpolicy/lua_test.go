@@ -0,0 +1,169 @@
+package trafficpolicy
+
+import (
+	"testing"
+
+	luav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"istio.io/istio/pkg/kube/krt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const testLuaScript = `function envoy_on_request(request_handle)
+  request_handle:headers():add("x-lua", "true")
+end`
+
+func TestLuaIREquals(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []kgateway.LuaFilter
+		want bool
+	}{
+		{
+			name: "both nil are equal",
+			want: true,
+		},
+		{
+			name: "nil vs non-nil are not equal",
+			b:    []kgateway.LuaFilter{{Inline: new(testLuaScript)}},
+			want: false,
+		},
+		{
+			name: "same scripts are equal",
+			a:    []kgateway.LuaFilter{{Inline: new(testLuaScript)}},
+			b:    []kgateway.LuaFilter{{Inline: new(testLuaScript)}},
+			want: true,
+		},
+		{
+			name: "different scripts are not equal",
+			a:    []kgateway.LuaFilter{{Inline: new(testLuaScript)}},
+			b:    []kgateway.LuaFilter{{Inline: new("function envoy_on_response(response_handle) end")}},
+			want: false,
+		},
+		{
+			name: "different number of scripts are not equal",
+			a:    []kgateway.LuaFilter{{Inline: new(testLuaScript)}},
+			b:    []kgateway.LuaFilter{{Inline: new(testLuaScript)}, {Inline: new(testLuaScript)}},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			aOut := &trafficPolicySpecIr{}
+			err := constructLua(krt.TestingDummyContext{}, &kgateway.TrafficPolicy{
+				Spec: kgateway.TrafficPolicySpec{LuaFilters: tt.a},
+			}, nil, aOut)
+			a.NoError(err)
+
+			bOut := &trafficPolicySpecIr{}
+			err = constructLua(krt.TestingDummyContext{}, &kgateway.TrafficPolicy{
+				Spec: kgateway.TrafficPolicySpec{LuaFilters: tt.b},
+			}, nil, bOut)
+			a.NoError(err)
+
+			a.Equal(tt.want, aOut.lua.Equals(bOut.lua))
+		})
+	}
+}
+
+func TestConstructLua(t *testing.T) {
+	tests := []struct {
+		name      string
+		filters   []kgateway.LuaFilter
+		wantErr   string
+		wantCount int
+	}{
+		{
+			name: "no lua filters leaves IR nil",
+		},
+		{
+			name: "inline scripts are kept in order",
+			filters: []kgateway.LuaFilter{
+				{Inline: new(testLuaScript)},
+				{Inline: new("function envoy_on_response(response_handle) end")},
+			},
+			wantCount: 2,
+		},
+		{
+			name: "inline and configMapRef both set is rejected",
+			filters: []kgateway.LuaFilter{
+				{
+					Inline:       new(testLuaScript),
+					ConfigMapRef: &kgateway.LuaConfigMapReference{Name: "lua-scripts"},
+				},
+			},
+			wantErr: "lua filter 0: only one of inline or configMapRef may be specified",
+		},
+		{
+			name:    "neither inline nor configMapRef set is rejected",
+			filters: []kgateway.LuaFilter{{}},
+			wantErr: "lua filter 0: either inline or configMapRef must be specified",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &trafficPolicySpecIr{}
+			err := constructLua(krt.TestingDummyContext{}, &kgateway.TrafficPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec:       kgateway.TrafficPolicySpec{LuaFilters: tt.filters},
+			}, nil, out)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, out.lua, "no scripts should be applied when one of them is invalid")
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantCount == 0 {
+				assert.Nil(t, out.lua)
+				return
+			}
+			require.NotNil(t, out.lua)
+			require.Len(t, out.lua.perRoute, tt.wantCount)
+			for i, perRoute := range out.lua.perRoute {
+				assert.Equal(t, *tt.filters[i].Inline, perRoute.GetSourceCode().GetInlineString())
+			}
+		})
+	}
+}
+
+func TestHandleLua(t *testing.T) {
+	p := &trafficPolicyPluginGwPass{}
+
+	typedFilterConfig := ir.TypedFilterConfigMap{}
+	p.handleLua("test-chain", &typedFilterConfig, &luaIR{
+		perRoute: []*luav3.LuaPerRoute{
+			{Override: &luav3.LuaPerRoute_Name{Name: "a"}},
+			{Override: &luav3.LuaPerRoute_Name{Name: "b"}},
+		},
+	})
+	assert.NotNil(t, typedFilterConfig[luaFilterName(0)])
+	assert.NotNil(t, typedFilterConfig[luaFilterName(1)])
+	assert.Equal(t, 2, p.luaInChain["test-chain"])
+
+	// a policy with fewer scripts must not shrink the number of filters in the chain
+	typedFilterConfig = ir.TypedFilterConfigMap{}
+	p.handleLua("test-chain", &typedFilterConfig, &luaIR{
+		perRoute: []*luav3.LuaPerRoute{
+			{Override: &luav3.LuaPerRoute_Name{Name: "c"}},
+		},
+	})
+	assert.NotNil(t, typedFilterConfig[luaFilterName(0)])
+	assert.Nil(t, typedFilterConfig[luaFilterName(1)])
+	assert.Equal(t, 2, p.luaInChain["test-chain"])
+
+	filters, err := p.HttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{FilterChainName: "test-chain"})
+	require.NoError(t, err)
+	require.Len(t, filters, 2)
+	for i, f := range filters {
+		assert.Equal(t, luaFilterName(i), f.Filter.GetName())
+		assert.True(t, f.Filter.GetDisabled(), "lua filters should be disabled by default")
+	}
+}
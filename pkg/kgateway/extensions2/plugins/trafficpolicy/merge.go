@@ -63,6 +63,9 @@ func MergeTrafficPolicies(
 		mergeOAuth,
 		mergeRouteTracing,
 		mergeFaultInjection,
+		mergeLua,
+		mergeGRPC,
+		mergeUpgradeConfigs,
 	}
 
 	for _, mergeFunc := range mergeFuncs {
@@ -653,12 +656,24 @@ func mergeFaultInjection(
 	defaultMerge(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, "faultInjection")
 }
 
-// fieldAccessor defines how to access and set a field on trafficPolicySpecIr
-type fieldAccessor[T any] struct {
-	Get func(*trafficPolicySpecIr) *T
-	Set func(*trafficPolicySpecIr, *T)
+func mergeLua(
+	p1, p2 *TrafficPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+	_ TrafficPolicyMergeOpts,
+) {
+	accessor := fieldAccessor[luaIR]{
+		Get: func(spec *trafficPolicySpecIr) *luaIR { return spec.lua },
+		Set: func(spec *trafficPolicySpecIr, val *luaIR) { spec.lua = val },
+	}
+	defaultMerge(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, "luaFilters")
 }
 
+// fieldAccessor defines how to access and set a field on trafficPolicySpecIr
+type fieldAccessor[T any] = policy.FieldAccessor[trafficPolicySpecIr, *T]
+
 // defaultMerge is a generic merge function that can handle any field on TrafficPolicy.spec.
 // It should be used when the policy being merged does not support deep merging or custom merge logic.
 func defaultMerge[T any](
@@ -670,25 +685,35 @@ func defaultMerge[T any](
 	accessor fieldAccessor[T],
 	fieldName string,
 ) {
-	p1Field := accessor.Get(&p1.spec)
-	p2Field := accessor.Get(&p2.spec)
+	policy.MergeField(&p1.spec, &p2.spec, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, fieldName)
+}
 
-	if !policy.IsMergeable(p1Field, p2Field, opts) {
-		return
+func mergeGRPC(
+	p1, p2 *TrafficPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+	_ TrafficPolicyMergeOpts,
+) {
+	accessor := fieldAccessor[grpcIR]{
+		Get: func(spec *trafficPolicySpecIr) *grpcIR { return spec.grpc },
+		Set: func(spec *trafficPolicySpecIr, val *grpcIR) { spec.grpc = val },
 	}
+	defaultMerge(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, "grpc")
+}
 
-	switch opts.Strategy {
-	case policy.AugmentedDeepMerge, policy.OverridableDeepMerge:
-		if p1Field != nil {
-			return
-		}
-		fallthrough // can override p1 if it is unset
-
-	case policy.AugmentedShallowMerge, policy.OverridableShallowMerge:
-		accessor.Set(&p1.spec, p2Field)
-		mergeOrigins.SetOne(fieldName, p2Ref, p2MergeOrigins)
-
-	default:
-		logger.Warn("unsupported merge strategy for policy", "strategy", opts.Strategy, "policy", p2Ref, "field", fieldName)
+func mergeUpgradeConfigs(
+	p1, p2 *TrafficPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+	_ TrafficPolicyMergeOpts,
+) {
+	accessor := fieldAccessor[upgradeConfigsIR]{
+		Get: func(spec *trafficPolicySpecIr) *upgradeConfigsIR { return spec.upgradeConfigs },
+		Set: func(spec *trafficPolicySpecIr, val *upgradeConfigsIR) { spec.upgradeConfigs = val },
 	}
+	defaultMerge(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, "upgradeConfigs")
 }
@@ -1,7 +1,9 @@
 package trafficpolicy
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"slices"
 	"strings"
 	"time"
@@ -127,6 +129,12 @@ func buildOAuth2ProviderConfig(
 	discoverer *oidcProviderConfigDiscoverer,
 ) (*oauthPerProviderConfig, error) {
 	in := ext.OAuth2
+	if in.Credentials.ClientID == "" {
+		return nil, errors.New("oauth2 client ID not specified")
+	}
+	if in.Credentials.ClientSecretRef.Name == "" {
+		return nil, errors.New("oauth2 client secret ref not specified")
+	}
 
 	tokenEndpoint := ptr.Deref(in.TokenEndpoint, "").String()
 	authorizationEndpoint := ptr.Deref(in.AuthorizationEndpoint, "").String()
@@ -164,6 +172,20 @@ func buildOAuth2ProviderConfig(
 	if authorizationEndpoint == "" {
 		return nil, fmt.Errorf("oauth2 authorization endpoint not specified or not found in issuer well-known configuration")
 	}
+	// discovered endpoints aren't validated by the CRD schema
+	for _, endpoint := range []struct{ name, uri string }{
+		{"token endpoint", tokenEndpoint},
+		{"authorization endpoint", authorizationEndpoint},
+		{"end session endpoint", endSessionEndpoint},
+		{"jwks uri", jwksURI},
+	} {
+		if endpoint.uri == "" {
+			continue
+		}
+		if err := validateHTTPSURL(endpoint.uri); err != nil {
+			return nil, fmt.Errorf("invalid oauth2 %s: %w", endpoint.name, err)
+		}
+	}
 
 	backend, err := resolveBackend(krtctx, backends, false, ext.ObjectSource, in.BackendRef.BackendObjectReference)
 	if err != nil || backend == nil {
@@ -436,6 +458,21 @@ func oauthHMACSecretName(name, namespace string) string {
 	return fmt.Sprintf("oauth2/hmac_secret/%s/%s", namespace, name)
 }
 
+// validateHTTPSURL returns an error if uri isn't an absolute https URL with a host.
+func validateHTTPSURL(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("%s: scheme must be https", uri)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%s: missing host", uri)
+	}
+	return nil
+}
+
 func parseRedirectPath(redirectURI string) (string, error) {
 	_, hostAndPath, found := strings.Cut(redirectURI, "://")
 	if !found {
@@ -2,8 +2,26 @@ package trafficpolicy
 
 import (
 	"testing"
+	"time"
 
+	envoyoauth2v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/oauth2/v3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"istio.io/istio/pkg/kube/krt"
+	"istio.io/istio/pkg/kube/krt/krttest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
 )
 
 func TestRedirectPath(t *testing.T) {
@@ -44,3 +62,166 @@ func TestRedirectPath(t *testing.T) {
 		})
 	}
 }
+
+func newTestOAuth2BackendIndex(t *testing.T) *krtcollections.BackendIndex {
+	t.Helper()
+	backend := ir.NewBackendObjectIR(ir.ObjectSource{Kind: "Service", Namespace: "default", Name: "idp"}, 443, "")
+	backend.Obj = &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "idp", Namespace: "default"}}
+	mock := krttest.NewMock(t, []any{backend})
+	policies := krtcollections.NewPolicyIndex(krtutil.KrtOptions{}, sdk.ContributesPolicies{}, apisettings.Settings{})
+	refgrants := krtcollections.NewRefGrantIndex(krttest.GetMockCollection[*gwv1b1.ReferenceGrant](mock))
+	backends := krtcollections.NewBackendIndex(krtutil.KrtOptions{}, policies, refgrants)
+	backends.AddBackends(wellknown.ServiceGVK.GroupKind(), krttest.GetMockCollection[ir.BackendObjectIR](mock))
+	for !backends.HasSynced() {
+		time.Sleep(10 * time.Millisecond)
+	}
+	return backends
+}
+
+func TestBuildOAuth2ProviderConfig(t *testing.T) {
+	backends := newTestOAuth2BackendIndex(t)
+	hmacSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: wellknown.OAuth2HMACSecret.Name, Namespace: wellknown.OAuth2HMACSecret.Namespace},
+		Data:       map[string][]byte{wellknown.OAuth2HMACSecretKey: []byte("hmac")},
+	}
+	clientSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "client", Namespace: "default"},
+		Data:       map[string][]byte{clientSecretKey: []byte("secret")},
+	}
+	emptyClientSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: "default"},
+		Data:       map[string][]byte{},
+	}
+	secrets := newTestSecretIndex(t, hmacSecret, clientSecret, emptyClientSecret)
+
+	discoverer := newOIDCProviderConfigDiscoverer()
+	discoverer.cache.Store("https://issuer.example.com", &oidcProviderConfig{
+		TokenEndpoint:         "https://issuer.example.com/token",
+		AuthorizationEndpoint: "https://issuer.example.com/authorize",
+		EndSessionEndpoint:    ptr.To("https://issuer.example.com/logout"),
+		JWKSURI:               "https://issuer.example.com/jwks",
+	})
+	discoverer.cache.Store("https://insecure.example.com", &oidcProviderConfig{
+		TokenEndpoint:         "http://insecure.example.com/token",
+		AuthorizationEndpoint: "https://insecure.example.com/authorize",
+	})
+
+	provider := func(mutate func(p *kgateway.OAuth2Provider)) *kgateway.OAuth2Provider {
+		p := &kgateway.OAuth2Provider{
+			BackendRef: gwv1.BackendRef{BackendObjectReference: gwv1.BackendObjectReference{
+				Name: "idp",
+				Port: ptr.To(gwv1.PortNumber(443)),
+			}},
+			IssuerURI:   ptr.To("https://issuer.example.com"),
+			RedirectURI: ptr.To("https://app.example.com/oauth2/callback"),
+			LogoutPath:  "/logout",
+			Scopes:      []string{"openid", "email"},
+			Credentials: kgateway.OAuth2Credentials{
+				ClientID:        "client-id",
+				ClientSecretRef: corev1.LocalObjectReference{Name: "client"},
+			},
+			Cookies: &kgateway.OAuth2CookieConfig{
+				Domain:   ptr.To("example.com"),
+				SameSite: ptr.To(kgateway.OAuth2CookieSameSiteStrict),
+			},
+		}
+		if mutate != nil {
+			mutate(p)
+		}
+		return p
+	}
+	build := func(p *kgateway.OAuth2Provider) (*oauthPerProviderConfig, error) {
+		ext := &ir.GatewayExtension{
+			ObjectSource: ir.ObjectSource{Group: "gateway.kgateway.dev", Kind: "GatewayExtension", Namespace: "default", Name: "oidc"},
+			OAuth2:       p,
+		}
+		return buildOAuth2ProviderConfig(krt.TestingDummyContext{}, ext, backends, secrets, discoverer)
+	}
+
+	t.Run("rendered config", func(t *testing.T) {
+		out, err := build(provider(nil))
+		require.NoError(t, err)
+		require.NoError(t, (&oauthIR{oauthPerProviderConfig: out}).Validate())
+
+		cfg := out.cfg.GetConfig()
+		assert.Equal(t, "https://issuer.example.com/token", cfg.GetTokenEndpoint().GetUri())
+		assert.Equal(t, "https://issuer.example.com/authorize", cfg.GetAuthorizationEndpoint())
+		assert.Equal(t, "https://issuer.example.com/logout", cfg.GetEndSessionEndpoint())
+		assert.Equal(t, "https://app.example.com/oauth2/callback", cfg.GetRedirectUri())
+		assert.Equal(t, "/oauth2/callback", cfg.GetRedirectPathMatcher().GetPath().GetExact())
+		assert.Equal(t, "/logout", cfg.GetSignoutPath().GetPath().GetExact())
+		assert.Equal(t, []string{"openid", "email"}, cfg.GetAuthScopes())
+		assert.Equal(t, "client-id", cfg.GetCredentials().GetClientId())
+		assert.Equal(t, oauthClientSecretName("client", "default"), cfg.GetCredentials().GetTokenSecret().GetName())
+		assert.Equal(t, "example.com", cfg.GetCredentials().GetCookieDomain())
+		assert.Equal(t, envoyoauth2v3.CookieConfig_STRICT, cfg.GetCookieConfigs().GetBearerTokenCookieConfig().GetSameSite())
+		assert.True(t, cfg.GetPreserveAuthorizationHeader())
+
+		require.Len(t, out.secrets, 2)
+		assert.Equal(t, []byte("secret"), out.secrets[0].GetGenericSecret().GetSecret().GetInlineBytes())
+		assert.Equal(t, []byte("hmac"), out.secrets[1].GetGenericSecret().GetSecret().GetInlineBytes())
+	})
+
+	tests := []struct {
+		name    string
+		mutate  func(p *kgateway.OAuth2Provider)
+		wantErr string
+	}{
+		{
+			name: "missing client secret",
+			mutate: func(p *kgateway.OAuth2Provider) {
+				p.Credentials.ClientSecretRef.Name = "missing"
+			},
+			wantErr: "not found",
+		},
+		{
+			name: "empty client secret",
+			mutate: func(p *kgateway.OAuth2Provider) {
+				p.Credentials.ClientSecretRef.Name = "empty"
+			},
+			wantErr: "client-secret not found or empty",
+		},
+		{
+			name: "missing client secret ref",
+			mutate: func(p *kgateway.OAuth2Provider) {
+				p.Credentials.ClientSecretRef.Name = ""
+			},
+			wantErr: "oauth2 client secret ref not specified",
+		},
+		{
+			name: "missing client ID",
+			mutate: func(p *kgateway.OAuth2Provider) {
+				p.Credentials.ClientID = ""
+			},
+			wantErr: "oauth2 client ID not specified",
+		},
+		{
+			name: "missing authorization endpoint",
+			mutate: func(p *kgateway.OAuth2Provider) {
+				p.IssuerURI = nil
+				p.TokenEndpoint = ptr.To(kgateway.HttpsUri("https://idp.example.com/token"))
+			},
+			wantErr: "oauth2 authorization endpoint not specified",
+		},
+		{
+			name: "discovered endpoint isn't https",
+			mutate: func(p *kgateway.OAuth2Provider) {
+				p.IssuerURI = ptr.To("https://insecure.example.com")
+			},
+			wantErr: "invalid oauth2 token endpoint: http://insecure.example.com/token: scheme must be https",
+		},
+		{
+			name: "invalid redirect URI",
+			mutate: func(p *kgateway.OAuth2Provider) {
+				p.RedirectURI = ptr.To("app.example.com/callback")
+			},
+			wantErr: "missing scheme",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := build(provider(tt.mutate))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -114,13 +114,25 @@ func TestTranslateRBAC(t *testing.T) {
 			expectedCELRules: map[string][]shared.CELExpression{},
 			wantErr:          false,
 		},
+		{
+			name:   "invalid CEL expression",
+			ns:     "test-ns",
+			tpName: "test-policy",
+			rbac: &shared.Authorization{
+				Action: shared.AuthorizationPolicyActionAllow,
+				Policy: shared.AuthorizationPolicy{
+					MatchExpressions: []shared.CELExpression{"request.auth.claims.groups == 'group1'", "request.auth.claims.groups =="},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := translateRBAC(tt.rbac)
 			if tt.wantErr {
-				assert.Error(t, err)
+				assert.ErrorContains(t, err, `failed to parse CEL expression "request.auth.claims.groups ==": line 1, column 30: Syntax error`)
 				return
 			}
 			require.NoError(t, err)
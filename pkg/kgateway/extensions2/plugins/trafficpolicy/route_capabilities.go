@@ -0,0 +1,112 @@
+package trafficpolicy
+
+import (
+	header_mutationv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/header_mutation/v3"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+// routeCapabilityField maps a TrafficPolicy field to the native HTTPRoute capabilities it overlaps with.
+type routeCapabilityField struct {
+	field        string
+	capabilities []ir.RouteCapability
+	isSet        func(*trafficPolicySpecIr) bool
+	unset        func(*trafficPolicySpecIr)
+}
+
+// routeCapabilityFields is the registry of TrafficPolicy fields that overlap with capabilities
+// HTTPRoute rules configure natively. The field names match the ones used in MergeOrigins.
+var routeCapabilityFields = []routeCapabilityField{
+	{
+		field:        "cors",
+		capabilities: []ir.RouteCapability{ir.RouteCapabilityCORS},
+		isSet:        func(s *trafficPolicySpecIr) bool { return s.cors != nil && s.cors.policy != nil },
+		unset:        func(s *trafficPolicySpecIr) { s.cors = nil },
+	},
+	{
+		field:        "headerModifiers.request",
+		capabilities: []ir.RouteCapability{ir.RouteCapabilityRequestHeaderModifier},
+		isSet: func(s *trafficPolicySpecIr) bool {
+			return len(s.headerModifiers.mutations().GetRequestMutations()) > 0
+		},
+		unset: func(s *trafficPolicySpecIr) {
+			s.headerModifiers = s.headerModifiers.without(func(m *header_mutationv3.Mutations) { m.RequestMutations = nil })
+		},
+	},
+	{
+		field:        "headerModifiers.response",
+		capabilities: []ir.RouteCapability{ir.RouteCapabilityResponseHeaderModifier},
+		isSet: func(s *trafficPolicySpecIr) bool {
+			return len(s.headerModifiers.mutations().GetResponseMutations()) > 0
+		},
+		unset: func(s *trafficPolicySpecIr) {
+			s.headerModifiers = s.headerModifiers.without(func(m *header_mutationv3.Mutations) { m.ResponseMutations = nil })
+		},
+	},
+	{
+		field: "urlRewrite",
+		// a redirect replaces the route action, so the rewrite can never be applied
+		capabilities: []ir.RouteCapability{ir.RouteCapabilityURLRewrite, ir.RouteCapabilityRequestRedirect},
+		isSet:        func(s *trafficPolicySpecIr) bool { return s.urlRewrite != nil && s.urlRewrite.regexMatch != nil },
+		unset:        func(s *trafficPolicySpecIr) { s.urlRewrite = nil },
+	},
+	{
+		field:        "timeouts.request",
+		capabilities: []ir.RouteCapability{ir.RouteCapabilityTimeouts},
+		isSet:        func(s *trafficPolicySpecIr) bool { return s.timeouts != nil && s.timeouts.routeTimeout != nil },
+		unset: func(s *trafficPolicySpecIr) {
+			s.timeouts = &timeoutsIR{routeStreamIdleTimeout: s.timeouts.routeStreamIdleTimeout}
+		},
+	},
+}
+
+var _ ir.RouteCapabilityPolicy = &TrafficPolicy{}
+
+// RouteCapabilityOverlaps implements ir.RouteCapabilityPolicy.
+func (d *TrafficPolicy) RouteCapabilityOverlaps(native sets.Set[ir.RouteCapability]) []ir.RouteCapabilityOverlap {
+	var overlaps []ir.RouteCapabilityOverlap
+	for _, f := range routeCapabilityFields {
+		if !f.isSet(&d.spec) {
+			continue
+		}
+		for _, c := range f.capabilities {
+			if native.Has(c) {
+				overlaps = append(overlaps, ir.RouteCapabilityOverlap{Field: f.field, Capability: c})
+			}
+		}
+	}
+	return overlaps
+}
+
+// WithoutFields implements ir.RouteCapabilityPolicy.
+func (d *TrafficPolicy) WithoutFields(fields []string) ir.PolicyIR {
+	out := &TrafficPolicy{ct: d.ct, spec: d.spec}
+	for _, f := range routeCapabilityFields {
+		for _, field := range fields {
+			if field == f.field && f.isSet(&out.spec) {
+				f.unset(&out.spec)
+			}
+		}
+	}
+	return out
+}
+
+func (hm *headerModifiersIR) mutations() *header_mutationv3.Mutations {
+	if hm == nil {
+		return nil
+	}
+	return hm.policy.GetMutations()
+}
+
+// without returns a copy of the header modifiers with the mutations updated by the given function,
+// or nil if no mutations remain.
+func (hm *headerModifiersIR) without(update func(*header_mutationv3.Mutations)) *headerModifiersIR {
+	m, _ := proto.Clone(hm.mutations()).(*header_mutationv3.Mutations)
+	update(m)
+	if len(m.GetRequestMutations()) == 0 && len(m.GetResponseMutations()) == 0 {
+		return nil
+	}
+	return &headerModifiersIR{policy: &header_mutationv3.HeaderMutationPerRoute{Mutations: m}}
+}
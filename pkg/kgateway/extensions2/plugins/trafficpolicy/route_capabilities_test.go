@@ -0,0 +1,114 @@
+package trafficpolicy
+
+import (
+	"testing"
+
+	mutation_rulesv3 "github.com/envoyproxy/go-control-plane/envoy/config/common/mutation_rules/v3"
+	corsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/cors/v3"
+	header_mutationv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/header_mutation/v3"
+	envoy_type_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestTrafficPolicyRouteCapabilityOverlaps(t *testing.T) {
+	headerMutation := func(request, response bool) *headerModifiersIR {
+		m := &header_mutationv3.Mutations{}
+		if request {
+			m.RequestMutations = []*mutation_rulesv3.HeaderMutation{{Action: &mutation_rulesv3.HeaderMutation_Remove{Remove: "x-request"}}}
+		}
+		if response {
+			m.ResponseMutations = []*mutation_rulesv3.HeaderMutation{{Action: &mutation_rulesv3.HeaderMutation_Remove{Remove: "x-response"}}}
+		}
+		return &headerModifiersIR{policy: &header_mutationv3.HeaderMutationPerRoute{Mutations: m}}
+	}
+
+	tests := []struct {
+		name      string
+		spec      trafficPolicySpecIr
+		native    []ir.RouteCapability
+		want      []ir.RouteCapabilityOverlap
+		wantAfter trafficPolicySpecIr
+	}{
+		{
+			name:      "cors overlaps with the CORS filter",
+			spec:      trafficPolicySpecIr{cors: &corsIR{policy: &corsv3.CorsPolicy{}}},
+			native:    []ir.RouteCapability{ir.RouteCapabilityCORS},
+			want:      []ir.RouteCapabilityOverlap{{Field: "cors", Capability: ir.RouteCapabilityCORS}},
+			wantAfter: trafficPolicySpecIr{},
+		},
+		{
+			name:      "request header modifiers overlap with the RequestHeaderModifier filter",
+			spec:      trafficPolicySpecIr{headerModifiers: headerMutation(true, true)},
+			native:    []ir.RouteCapability{ir.RouteCapabilityRequestHeaderModifier},
+			want:      []ir.RouteCapabilityOverlap{{Field: "headerModifiers.request", Capability: ir.RouteCapabilityRequestHeaderModifier}},
+			wantAfter: trafficPolicySpecIr{headerModifiers: headerMutation(false, true)},
+		},
+		{
+			name:      "response header modifiers overlap with the ResponseHeaderModifier filter",
+			spec:      trafficPolicySpecIr{headerModifiers: headerMutation(false, true)},
+			native:    []ir.RouteCapability{ir.RouteCapabilityResponseHeaderModifier},
+			want:      []ir.RouteCapabilityOverlap{{Field: "headerModifiers.response", Capability: ir.RouteCapabilityResponseHeaderModifier}},
+			wantAfter: trafficPolicySpecIr{},
+		},
+		{
+			name:      "url rewrite overlaps with the RequestRedirect filter",
+			spec:      trafficPolicySpecIr{urlRewrite: &urlRewriteIR{regexMatch: &envoy_type_matcher_v3.RegexMatchAndSubstitute{}}},
+			native:    []ir.RouteCapability{ir.RouteCapabilityRequestRedirect},
+			want:      []ir.RouteCapabilityOverlap{{Field: "urlRewrite", Capability: ir.RouteCapabilityRequestRedirect}},
+			wantAfter: trafficPolicySpecIr{},
+		},
+		{
+			name:      "url rewrite overlaps with the URLRewrite filter",
+			spec:      trafficPolicySpecIr{urlRewrite: &urlRewriteIR{regexMatch: &envoy_type_matcher_v3.RegexMatchAndSubstitute{}}},
+			native:    []ir.RouteCapability{ir.RouteCapabilityURLRewrite},
+			want:      []ir.RouteCapabilityOverlap{{Field: "urlRewrite", Capability: ir.RouteCapabilityURLRewrite}},
+			wantAfter: trafficPolicySpecIr{},
+		},
+		{
+			name: "request timeout overlaps with the rule timeouts",
+			spec: trafficPolicySpecIr{timeouts: &timeoutsIR{
+				routeTimeout:           durationpb.New(1),
+				routeStreamIdleTimeout: durationpb.New(2),
+			}},
+			native:    []ir.RouteCapability{ir.RouteCapabilityTimeouts},
+			want:      []ir.RouteCapabilityOverlap{{Field: "timeouts.request", Capability: ir.RouteCapabilityTimeouts}},
+			wantAfter: trafficPolicySpecIr{timeouts: &timeoutsIR{routeStreamIdleTimeout: durationpb.New(2)}},
+		},
+		{
+			name: "fields without a native counterpart on the route are not flagged",
+			spec: trafficPolicySpecIr{
+				cors:            &corsIR{policy: &corsv3.CorsPolicy{}},
+				headerModifiers: headerMutation(false, true),
+				timeouts:        &timeoutsIR{routeStreamIdleTimeout: durationpb.New(2)},
+			},
+			native: []ir.RouteCapability{ir.RouteCapabilityRequestHeaderModifier, ir.RouteCapabilityTimeouts},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &TrafficPolicy{spec: tt.spec}
+			got := policy.RouteCapabilityOverlaps(sets.New(tt.native...))
+			assert.Equal(t, tt.want, got)
+			if len(got) == 0 {
+				return
+			}
+
+			fields := make([]string, 0, len(got))
+			for _, overlap := range got {
+				fields = append(fields, overlap.Field)
+			}
+			after, ok := policy.WithoutFields(fields).(*TrafficPolicy)
+			require.True(t, ok)
+			assert.True(t, after.Equals(&TrafficPolicy{spec: tt.wantAfter}))
+			// the original policy is shared with other routes and must not be modified
+			assert.Equal(t, tt.want, policy.RouteCapabilityOverlaps(sets.New(tt.native...)))
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package trafficpolicy
 
 import (
+	"fmt"
+
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -54,6 +56,12 @@ func (a *timeoutsIR) Equals(other PolicySubIR) bool {
 }
 
 func (a *timeoutsIR) Validate() error {
+	if a == nil || a.routeStreamIdleTimeout == nil {
+		return nil
+	}
+	if a.routeStreamIdleTimeout.AsDuration() < 0 {
+		return fmt.Errorf("streamIdle timeout must not be negative, got %s", a.routeStreamIdleTimeout.AsDuration())
+	}
 	return nil
 }
 
@@ -0,0 +1,70 @@
+package trafficpolicy
+
+import (
+	"testing"
+	"time"
+
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+)
+
+func TestStreamIdleTimeout(t *testing.T) {
+	var out trafficPolicySpecIr
+	constructTimeoutRetry(kgateway.TrafficPolicySpec{
+		Timeouts: &shared.Timeouts{
+			StreamIdle: &metav1.Duration{Duration: time.Hour},
+		},
+	}, &out)
+	require.NoError(t, out.timeouts.Validate())
+
+	// the route idle timeout overrides the stream idle timeout of the HCM for the route
+	route := &envoyroutev3.Route{
+		Action: &envoyroutev3.Route_Route{Route: &envoyroutev3.RouteAction{}},
+	}
+	(&trafficPolicyPluginGwPass{}).handlePerRoutePolicies(out, route)
+	assert.Equal(t, time.Hour, route.GetRoute().GetIdleTimeout().AsDuration())
+	assert.Nil(t, route.GetRoute().GetTimeout())
+}
+
+func TestTimeoutsIRValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		ir          *timeoutsIR
+		expectError string
+	}{
+		{
+			name: "nil IR is valid",
+			ir:   nil,
+		},
+		{
+			name: "zero disables the timeout",
+			ir:   &timeoutsIR{routeStreamIdleTimeout: durationpb.New(0)},
+		},
+		{
+			name: "positive stream idle timeout",
+			ir:   &timeoutsIR{routeStreamIdleTimeout: durationpb.New(time.Minute)},
+		},
+		{
+			name:        "negative stream idle timeout",
+			ir:          &timeoutsIR{routeStreamIdleTimeout: durationpb.New(-time.Second)},
+			expectError: "streamIdle timeout must not be negative, got -1s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ir.Validate()
+			if tt.expectError != "" {
+				assert.EqualError(t, err, tt.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ import (
 	faulthttpv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/fault/v3"
 	header_mutationv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/header_mutation/v3"
 	localratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
+	luav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
 	envoyrbacv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
 	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	envoy_wellknown "github.com/envoyproxy/go-control-plane/pkg/wellknown"
@@ -104,6 +105,9 @@ type trafficPolicySpecIr struct {
 	oauth2          *oauthIR
 	tracing         *routeTracingIR
 	faultInjection  *faultInjectionIR
+	lua             *luaIR
+	grpc            *grpcIR
+	upgradeConfigs  *upgradeConfigsIR
 }
 
 func (d *TrafficPolicy) CreationTime() time.Time {
@@ -185,6 +189,15 @@ func (d *TrafficPolicy) Equals(in any) bool {
 	if !d.spec.faultInjection.Equals(d2.spec.faultInjection) {
 		return false
 	}
+	if !d.spec.lua.Equals(d2.spec.lua) {
+		return false
+	}
+	if !d.spec.grpc.Equals(d2.spec.grpc) {
+		return false
+	}
+	if !d.spec.upgradeConfigs.Equals(d2.spec.upgradeConfigs) {
+		return false
+	}
 	return true
 }
 
@@ -213,6 +226,10 @@ func (p *TrafficPolicy) Validate() error {
 	validators = append(validators, p.spec.oauth2.Validate)
 	validators = append(validators, p.spec.tracing.Validate)
 	validators = append(validators, p.spec.faultInjection.Validate)
+	validators = append(validators, p.spec.lua.Validate)
+	validators = append(validators, p.spec.grpc.Validate)
+	validators = append(validators, p.spec.timeouts.Validate)
+	validators = append(validators, p.spec.upgradeConfigs.Validate)
 	for _, validator := range validators {
 		if err := validator(); err != nil {
 			return err
@@ -237,11 +254,18 @@ type trafficPolicyPluginGwPass struct {
 	csrfInChain              map[string]*envoy_csrf_v3.CsrfPolicy
 	headerMutationInChain    map[string]*header_mutationv3.HeaderMutationPerRoute
 	bufferInChain            map[string]*bufferv3.Buffer
-	compressorInChain        map[string]*compressorv3.Compressor
+	compressorInChain        map[string]map[string]*compressorv3.Compressor
 	decompressorInChain      map[string]*decompressorv3.Decompressor
 	basicAuthInChain         map[string]*envoy_basic_auth_v3.BasicAuth
 	apiKeyAuthInChain        map[string]*envoy_api_key_auth_v3.ApiKeyAuth
 	faultInChain             map[string]*faulthttpv3.HTTPFault
+	// maps filter chain name to the number of Lua filters needed in the chain
+	luaInChain map[string]int
+	// maps filter chain name to whether a route enables gRPC-Web translation
+	grpcWebInChain map[string]bool
+	// maps filter chain name to the typed per filter configs of the routes, virtual hosts and route
+	// configurations that disable compression
+	compressionDisabledIn map[string][]*map[string]*anypb.Any
 	// maps secret name to secret in case the same secret is referenced in multiple attachment points (e.g., vhost and route)
 	secrets map[string]*envoytlsv3.Secret
 }
@@ -356,6 +380,7 @@ func (p *trafficPolicyPluginGwPass) ApplyRouteConfigPlugin(
 	}
 
 	p.handlePolicies(pCtx.FilterChainName, &pCtx.TypedFilterConfig, policy.spec)
+	p.deferCompressionDisable(pCtx.FilterChainName, policy.spec.compression, &out.TypedPerFilterConfig)
 }
 
 func (p *trafficPolicyPluginGwPass) ApplyVhostPlugin(
@@ -369,6 +394,7 @@ func (p *trafficPolicyPluginGwPass) ApplyVhostPlugin(
 
 	p.handlePerVHostPolicies(policy.spec, out)
 	p.handlePolicies(pCtx.FilterChainName, &pCtx.TypedFilterConfig, policy.spec)
+	p.deferCompressionDisable(pCtx.FilterChainName, policy.spec.compression, &out.TypedPerFilterConfig)
 }
 
 // called 0 or more times
@@ -380,6 +406,10 @@ func (p *trafficPolicyPluginGwPass) ApplyForRoute(pCtx *ir.RouteContext, outputR
 
 	p.handlePerRoutePolicies(policy.spec, outputRoute)
 	p.handlePolicies(pCtx.FilterChainName, &pCtx.TypedFilterConfig, policy.spec)
+	// the policy is also applied without an output route to validate its per-route config
+	if outputRoute != nil {
+		p.deferCompressionDisable(pCtx.FilterChainName, policy.spec.compression, &outputRoute.TypedPerFilterConfig)
+	}
 
 	return nil
 }
@@ -614,11 +644,21 @@ func (p *trafficPolicyPluginGwPass) HttpFilters(_ ir.HttpFiltersContext, fcc ir.
 		stagedFilters = append(stagedFilters, filter)
 	}
 
+	// Add Lua filters. Each one is disabled by default and enabled per-route with the script to run.
+	for i := range p.luaInChain[fcc.FilterChainName] {
+		filter := filters.MustNewStagedFilter(luaFilterName(i), &luav3.Lua{}, filters.DuringStage(filters.RouteStage))
+		filter.Filter.Disabled = true
+		stagedFilters = append(stagedFilters, filter)
+	}
+
+	// Add the gRPC-Web filter, rejecting it on listeners that do not accept HTTP/1.1
+	stagedFilters, err := addGRPCWebFilterIfNeeded(stagedFilters, p, fcc)
+
 	if len(stagedFilters) == 0 {
-		return nil, nil
+		return nil, err
 	}
 
-	return stagedFilters, nil
+	return stagedFilters, err
 }
 
 func (p *trafficPolicyPluginGwPass) ResourcesToAdd() ir.Resources {
@@ -657,6 +697,8 @@ func (p *trafficPolicyPluginGwPass) handlePolicies(
 	p.handleAPIKeyAuth(fcn, typedFilterConfig, spec.apiKeyAuth)
 	p.handleOauth2(fcn, typedFilterConfig, spec.oauth2)
 	p.handleFaultInjection(fcn, typedFilterConfig, spec.faultInjection)
+	p.handleLua(fcn, typedFilterConfig, spec.lua)
+	p.handleGRPC(fcn, typedFilterConfig, spec.grpc)
 }
 
 // handlePerRoutePolicies handles policies that are meant to be processed at the route level
@@ -696,6 +738,12 @@ func (p *trafficPolicyPluginGwPass) handlePerRoutePolicies(
 		action.RetryPolicy = spec.retry.policy
 	}
 
+	// Honor the grpc-timeout header sent by gRPC clients
+	applyGRPCTimeouts(spec.grpc, action)
+
+	// Enable the HTTP upgrades of the route
+	applyUpgradeConfigs(spec.upgradeConfigs, action)
+
 	// Apply URL rewrite configuration
 	applyURLRewrite(spec.urlRewrite, out)
 
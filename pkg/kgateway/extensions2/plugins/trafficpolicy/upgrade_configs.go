@@ -0,0 +1,86 @@
+package trafficpolicy
+
+import (
+	"fmt"
+	"slices"
+
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+)
+
+// knownUpgradeTypes are the upgrade types that can be enabled on routes
+var knownUpgradeTypes = []kgateway.UpgradeType{
+	kgateway.UpgradeTypeWebSocket,
+	kgateway.UpgradeTypeConnect,
+}
+
+type upgradeConfigsIR struct {
+	configs []*envoyroutev3.RouteAction_UpgradeConfig
+}
+
+var _ PolicySubIR = &upgradeConfigsIR{}
+
+func (u *upgradeConfigsIR) Equals(other PolicySubIR) bool {
+	otherUpgradeConfigs, ok := other.(*upgradeConfigsIR)
+	if !ok {
+		return false
+	}
+	if u == nil || otherUpgradeConfigs == nil {
+		return u == nil && otherUpgradeConfigs == nil
+	}
+	return slices.EqualFunc(u.configs, otherUpgradeConfigs.configs, func(a, b *envoyroutev3.RouteAction_UpgradeConfig) bool {
+		return proto.Equal(a, b)
+	})
+}
+
+// Validate checks that the upgrade types are known, as envoy does not reject unknown ones.
+func (u *upgradeConfigsIR) Validate() error {
+	if u == nil {
+		return nil
+	}
+	for _, cfg := range u.configs {
+		if !slices.Contains(knownUpgradeTypes, kgateway.UpgradeType(cfg.GetUpgradeType())) {
+			return fmt.Errorf("unknown upgrade type %q, must be one of %v", cfg.GetUpgradeType(), knownUpgradeTypes)
+		}
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// constructUpgradeConfigs constructs the upgrade configs policy IR from the policy specification.
+func constructUpgradeConfigs(spec kgateway.TrafficPolicySpec, out *trafficPolicySpecIr) {
+	if len(spec.UpgradeConfigs) == 0 {
+		return
+	}
+
+	out.upgradeConfigs = &upgradeConfigsIR{
+		configs: make([]*envoyroutev3.RouteAction_UpgradeConfig, 0, len(spec.UpgradeConfigs)),
+	}
+	for _, upgradeType := range spec.UpgradeConfigs {
+		out.upgradeConfigs.configs = append(out.upgradeConfigs.configs, &envoyroutev3.RouteAction_UpgradeConfig{
+			UpgradeType: string(upgradeType),
+			Enabled:     wrapperspb.Bool(true),
+		})
+	}
+}
+
+// applyUpgradeConfigs enables the upgrades on the route, keeping the ones already enabled
+// (e.g. websocket upgrades enabled for backends with the websocket app protocol)
+func applyUpgradeConfigs(upgradeConfigs *upgradeConfigsIR, action *envoyroutev3.RouteAction) {
+	if upgradeConfigs == nil {
+		return
+	}
+	for _, cfg := range upgradeConfigs.configs {
+		if slices.ContainsFunc(action.GetUpgradeConfigs(), func(uc *envoyroutev3.RouteAction_UpgradeConfig) bool {
+			return uc.GetUpgradeType() == cfg.GetUpgradeType()
+		}) {
+			continue
+		}
+		action.UpgradeConfigs = append(action.GetUpgradeConfigs(), proto.Clone(cfg).(*envoyroutev3.RouteAction_UpgradeConfig))
+	}
+}
@@ -0,0 +1,76 @@
+package trafficpolicy
+
+import (
+	"testing"
+
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+)
+
+func TestUpgradeConfigs(t *testing.T) {
+	out := &trafficPolicySpecIr{}
+	constructUpgradeConfigs(kgateway.TrafficPolicySpec{
+		UpgradeConfigs: []kgateway.UpgradeType{kgateway.UpgradeTypeWebSocket},
+	}, out)
+	require.NotNil(t, out.upgradeConfigs)
+	require.NoError(t, out.upgradeConfigs.Validate())
+
+	t.Run("enables websocket upgrades on the route", func(t *testing.T) {
+		route := &envoyroutev3.Route{Action: &envoyroutev3.Route_Route{Route: &envoyroutev3.RouteAction{}}}
+		(&trafficPolicyPluginGwPass{}).handlePerRoutePolicies(*out, route)
+
+		upgradeConfigs := route.GetRoute().GetUpgradeConfigs()
+		require.Len(t, upgradeConfigs, 1)
+		assert.Equal(t, "websocket", upgradeConfigs[0].GetUpgradeType())
+		assert.True(t, upgradeConfigs[0].GetEnabled().GetValue())
+	})
+
+	t.Run("keeps the upgrades already enabled on the route", func(t *testing.T) {
+		route := &envoyroutev3.Route{Action: &envoyroutev3.Route_Route{Route: &envoyroutev3.RouteAction{
+			UpgradeConfigs: []*envoyroutev3.RouteAction_UpgradeConfig{{UpgradeType: "websocket"}},
+		}}}
+		(&trafficPolicyPluginGwPass{}).handlePerRoutePolicies(*out, route)
+
+		upgradeConfigs := route.GetRoute().GetUpgradeConfigs()
+		require.Len(t, upgradeConfigs, 1)
+		assert.Nil(t, upgradeConfigs[0].GetEnabled())
+	})
+}
+
+func TestUpgradeConfigsValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		upgradeTypes []kgateway.UpgradeType
+		wantErr      string
+	}{
+		{
+			name:         "websocket",
+			upgradeTypes: []kgateway.UpgradeType{kgateway.UpgradeTypeWebSocket},
+		},
+		{
+			name:         "websocket and CONNECT",
+			upgradeTypes: []kgateway.UpgradeType{kgateway.UpgradeTypeWebSocket, kgateway.UpgradeTypeConnect},
+		},
+		{
+			name:         "unknown upgrade type",
+			upgradeTypes: []kgateway.UpgradeType{kgateway.UpgradeTypeWebSocket, "h2c"},
+			wantErr:      `unknown upgrade type "h2c", must be one of [websocket CONNECT]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &trafficPolicySpecIr{}
+			constructUpgradeConfigs(kgateway.TrafficPolicySpec{UpgradeConfigs: tt.upgradeTypes}, out)
+			err := out.upgradeConfigs.Validate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -50,9 +50,10 @@ func mergeSynced(funcs []func() bool) func() bool {
 
 func MergePlugins(plug ...sdk.Plugin) sdk.Plugin {
 	ret := sdk.Plugin{
-		ContributesPolicies:     make(map[schema.GroupKind]sdk.PolicyPlugin),
-		ContributesBackends:     make(map[schema.GroupKind]sdk.BackendPlugin),
-		ContributesLeaderAction: make(map[schema.GroupKind]func()),
+		ContributesPolicies:         make(map[schema.GroupKind]sdk.PolicyPlugin),
+		ContributesBackends:         make(map[schema.GroupKind]sdk.BackendPlugin),
+		ContributesLeaderAction:     make(map[schema.GroupKind]func()),
+		ContributesBackendResolvers: make(map[string]sdk.BackendResolver),
 	}
 	var funcs []sdk.GwTranslatorFactory
 	var hasSynced []func() bool
@@ -60,6 +61,13 @@ func MergePlugins(plug ...sdk.Plugin) sdk.Plugin {
 		maps.Copy(ret.ContributesPolicies, p.ContributesPolicies)
 		maps.Copy(ret.ContributesBackends, p.ContributesBackends)
 		maps.Copy(ret.ContributesLeaderAction, p.ContributesLeaderAction)
+		maps.Copy(ret.ContributesBackendResolvers, p.ContributesBackendResolvers)
+		ret.ContributesDeployerMutators = append(ret.ContributesDeployerMutators, p.ContributesDeployerMutators...)
+		ret.ContributesDeployerChartFragments = append(ret.ContributesDeployerChartFragments, p.ContributesDeployerChartFragments...)
+		ret.ContributesReadinessChecks = append(ret.ContributesReadinessChecks, p.ContributesReadinessChecks...)
+		ret.ContributesLivenessChecks = append(ret.ContributesLivenessChecks, p.ContributesLivenessChecks...)
+		ret.ContributesClusterMetadata = append(ret.ContributesClusterMetadata, p.ContributesClusterMetadata...)
+		ret.ContributesRouteAttachmentChecks = append(ret.ContributesRouteAttachmentChecks, p.ContributesRouteAttachmentChecks...)
 		if p.ContributesGwTranslator != nil {
 			funcs = append(funcs, p.ContributesGwTranslator)
 		}
@@ -72,15 +80,27 @@ func MergePlugins(plug ...sdk.Plugin) sdk.Plugin {
 	return ret
 }
 
+// BackendResolvers returns the backend resolvers contributed by the plugins, by name.
+func BackendResolvers(plugins ...sdk.Plugin) map[string]sdk.BackendResolver {
+	resolvers := make(map[string]sdk.BackendResolver)
+	for _, p := range plugins {
+		maps.Copy(resolvers, p.ContributesBackendResolvers)
+	}
+	return resolvers
+}
+
+// Plugins returns the builtin plugins. The Backends with the type `plugin/<name>` are resolved
+// with backendResolvers, usually contributed by extra plugins.
 func Plugins(
 	ctx context.Context,
 	commoncol *pluginsdkcol.CommonCollections,
 	globalSettings apisettings.Settings,
 	validator validator.Validator,
+	backendResolvers map[string]sdk.BackendResolver,
 ) []sdk.Plugin {
 	return []sdk.Plugin{
 		// Add plugins here
-		backend.NewPlugin(commoncol),
+		backend.NewPlugin(ctx, commoncol, backendResolvers),
 		trafficpolicy.NewPlugin(ctx, commoncol, globalSettings.PolicyMerge, validator),
 		directresponse.NewPlugin(ctx, commoncol),
 		kubernetes.NewPlugin(ctx, commoncol),
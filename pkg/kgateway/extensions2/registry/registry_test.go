@@ -0,0 +1,120 @@
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+)
+
+func TestMergePluginsDeployerMutatorsOrder(t *testing.T) {
+	var calls []string
+	mutator := func(name string) sdk.DeployerMutator {
+		return func(gw *gwv1.Gateway, objs []client.Object) error {
+			calls = append(calls, name)
+			return nil
+		}
+	}
+
+	merged := MergePlugins(
+		sdk.Plugin{ContributesDeployerMutators: []sdk.DeployerMutator{mutator("a1"), mutator("a2")}},
+		sdk.Plugin{},
+		sdk.Plugin{ContributesDeployerMutators: []sdk.DeployerMutator{mutator("b1")}},
+	)
+
+	require.Len(t, merged.ContributesDeployerMutators, 3)
+	for _, mutate := range merged.ContributesDeployerMutators {
+		require.NoError(t, mutate(&gwv1.Gateway{}, nil))
+	}
+	assert.Equal(t, []string{"a1", "a2", "b1"}, calls)
+}
+
+func TestMergePluginsDeployerChartFragments(t *testing.T) {
+	merged := MergePlugins(
+		sdk.Plugin{ContributesDeployerChartFragments: []sdk.DeployerChartFragment{{Name: "waf"}}},
+		sdk.Plugin{},
+		// fragments with the same name are kept, for the deployer to reject them
+		sdk.Plugin{ContributesDeployerChartFragments: []sdk.DeployerChartFragment{{Name: "sidecar"}, {Name: "waf"}}},
+	)
+
+	var names []string
+	for _, f := range merged.ContributesDeployerChartFragments {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"waf", "sidecar", "waf"}, names)
+}
+
+func TestMergePluginsHealthChecks(t *testing.T) {
+	merged := MergePlugins(
+		sdk.Plugin{
+			ContributesReadinessChecks: []sdk.HealthCheck{{Name: "extauth-bundle"}},
+			ContributesLivenessChecks:  []sdk.HealthCheck{{Name: "extauth-health"}},
+		},
+		sdk.Plugin{},
+		sdk.Plugin{ContributesReadinessChecks: []sdk.HealthCheck{{Name: "waf-rules"}}},
+	)
+
+	var readiness, liveness []string
+	for _, c := range merged.ContributesReadinessChecks {
+		readiness = append(readiness, c.Name)
+	}
+	for _, c := range merged.ContributesLivenessChecks {
+		liveness = append(liveness, c.Name)
+	}
+	assert.Equal(t, []string{"extauth-bundle", "waf-rules"}, readiness)
+	assert.Equal(t, []string{"extauth-health"}, liveness)
+}
+
+func TestMergePluginsClusterMetadata(t *testing.T) {
+	merged := MergePlugins(
+		sdk.Plugin{ContributesClusterMetadata: []sdk.ClusterMetadataContributor{{Name: "ownership"}}},
+		sdk.Plugin{},
+		sdk.Plugin{ContributesClusterMetadata: []sdk.ClusterMetadataContributor{{Name: "audit"}}},
+	)
+
+	var names []string
+	for _, c := range merged.ContributesClusterMetadata {
+		names = append(names, c.Name)
+	}
+	assert.Equal(t, []string{"ownership", "audit"}, names)
+}
+
+func TestMergePluginsRouteAttachmentChecks(t *testing.T) {
+	merged := MergePlugins(
+		sdk.Plugin{ContributesRouteAttachmentChecks: []sdk.RouteAttachmentCheck{{Name: "tenants"}}},
+		sdk.Plugin{},
+		sdk.Plugin{ContributesRouteAttachmentChecks: []sdk.RouteAttachmentCheck{{Name: "quota"}}},
+	)
+
+	var names []string
+	for _, c := range merged.ContributesRouteAttachmentChecks {
+		names = append(names, c.Name)
+	}
+	assert.Equal(t, []string{"tenants", "quota"}, names)
+}
+
+type namedResolver string
+
+func (namedResolver) Resolve(context.Context, sdk.BackendResolveRequest, chan<- []sdk.BackendEndpoint) (*sdk.BackendResolution, error) {
+	return nil, nil
+}
+
+func TestBackendResolvers(t *testing.T) {
+	plugins := []sdk.Plugin{
+		{ContributesBackendResolvers: map[string]sdk.BackendResolver{"consul": namedResolver("consul")}},
+		{},
+		{ContributesBackendResolvers: map[string]sdk.BackendResolver{"eureka": namedResolver("eureka")}},
+	}
+
+	resolvers := BackendResolvers(plugins...)
+	assert.Equal(t, map[string]sdk.BackendResolver{
+		"consul": namedResolver("consul"),
+		"eureka": namedResolver("eureka"),
+	}, resolvers)
+	assert.Equal(t, resolvers, MergePlugins(plugins...).ContributesBackendResolvers)
+}
@@ -0,0 +1,103 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+)
+
+const (
+	pluginSubsystem = "plugin"
+	checkLabelName  = "check"
+	probeLabelName  = "probe"
+
+	// ReadinessProbe and LivenessProbe are the probes the checks of the plugins feed.
+	ReadinessProbe = "readiness"
+	LivenessProbe  = "liveness"
+
+	// defaultPluginCheckTimeout bounds the checks of the plugins that don't set a timeout
+	defaultPluginCheckTimeout = 5 * time.Second
+)
+
+var pluginHealthGauge = metrics.NewGauge(
+	metrics.GaugeOpts{
+		Subsystem: pluginSubsystem,
+		Name:      "health",
+		Help:      "Result of the last run of the health checks of the plugins, 1 if healthy and 0 otherwise",
+	}, []string{checkLabelName, probeLabelName})
+
+// PluginChecker runs the health checks the plugins contribute to a probe.
+type PluginChecker struct {
+	probe  string
+	checks []sdk.HealthCheck
+}
+
+// NewPluginChecker returns a checker running the checks for the probe.
+func NewPluginChecker(probe string, checks []sdk.HealthCheck) *PluginChecker {
+	return &PluginChecker{probe: probe, checks: checks}
+}
+
+// Check runs the checks concurrently and returns an error listing the failed ones. A check
+// that doesn't return within its timeout fails, so that a hung plugin can't block the probe.
+// The results are recorded in the kgateway_plugin_health metric.
+// It's a healthz.Checker, so it can be used as a check of the controller manager.
+func (c *PluginChecker) Check(req *http.Request) error {
+	ctx := context.Background()
+	if req != nil {
+		ctx = req.Context()
+	}
+
+	errs := make([]error, len(c.checks))
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Go(func() {
+			errs[i] = runPluginCheck(ctx, check)
+		})
+	}
+	wg.Wait()
+
+	var failed []string
+	for i, check := range c.checks {
+		healthy := 1.0
+		if errs[i] != nil {
+			healthy = 0
+			failed = append(failed, fmt.Sprintf("%s: %v", check.Name, errs[i]))
+		}
+		pluginHealthGauge.Set(healthy,
+			metrics.Label{Name: checkLabelName, Value: check.Name},
+			metrics.Label{Name: probeLabelName, Value: c.probe},
+		)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("plugin checks failed: %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// runPluginCheck runs the check, giving up once its timeout expires even if it ignores its context.
+func runPluginCheck(ctx context.Context, check sdk.HealthCheck) error {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = defaultPluginCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// buffered so that a check returning after its timeout doesn't leak its goroutine
+	result := make(chan error, 1)
+	go func() {
+		result <- check.Check(ctx)
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+}
@@ -0,0 +1,97 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics/metricstest"
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+)
+
+func TestPluginCheckerReadiness(t *testing.T) {
+	pluginHealthGauge.Reset()
+
+	bundleErr := errors.New("policy bundle not fetched yet")
+	checker := NewPluginChecker(ReadinessProbe, []sdk.HealthCheck{
+		{Name: "extauth-bundle", Check: func(context.Context) error { return bundleErr }},
+		{Name: "other", Check: func(context.Context) error { return nil }},
+	})
+
+	err := checker.Check(nil)
+	require.Error(t, err)
+	assert.Equal(t, "plugin checks failed: extauth-bundle: policy bundle not fetched yet", err.Error())
+
+	// the bundle is fetched, the control plane is ready
+	bundleErr = nil
+	assert.NoError(t, checker.Check(nil))
+}
+
+func TestPluginCheckerTimeout(t *testing.T) {
+	pluginHealthGauge.Reset()
+
+	hung := make(chan struct{})
+	t.Cleanup(func() { close(hung) })
+	checker := NewPluginChecker(LivenessProbe, []sdk.HealthCheck{
+		{
+			Name:    "hung",
+			Timeout: 50 * time.Millisecond,
+			// ignores its context, the probe must not wait for it
+			Check: func(context.Context) error {
+				<-hung
+				return nil
+			},
+		},
+		{
+			Name:    "honors-context",
+			Timeout: 50 * time.Millisecond,
+			Check: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		},
+	})
+
+	start := time.Now()
+	err := checker.Check(nil)
+	assert.Less(t, time.Since(start), time.Second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "hung: timed out after 50ms")
+	assert.Contains(t, err.Error(), "honors-context: timed out after 50ms")
+}
+
+func TestPluginCheckerMetric(t *testing.T) {
+	pluginHealthGauge.Reset()
+
+	readiness := NewPluginChecker(ReadinessProbe, []sdk.HealthCheck{
+		{Name: "extauth-bundle", Check: func(context.Context) error { return nil }},
+	})
+	liveness := NewPluginChecker(LivenessProbe, []sdk.HealthCheck{
+		{Name: "extauth-bundle", Check: func(context.Context) error { return errors.New("degraded") }},
+	})
+	assert.NoError(t, readiness.Check(nil))
+	assert.Error(t, liveness.Check(nil))
+
+	gathered := metricstest.MustGatherMetrics(t)
+	gathered.AssertMetrics("kgateway_plugin_health", []metricstest.ExpectMetric{
+		&metricstest.ExpectedMetric{
+			Labels: []metrics.Label{
+				{Name: checkLabelName, Value: "extauth-bundle"},
+				{Name: probeLabelName, Value: LivenessProbe},
+			},
+			Value: 0,
+		},
+		&metricstest.ExpectedMetric{
+			Labels: []metrics.Label{
+				{Name: checkLabelName, Value: "extauth-bundle"},
+				{Name: probeLabelName, Value: ReadinessProbe},
+			},
+			Value: 1,
+		},
+	})
+}
@@ -0,0 +1,203 @@
+package health
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/logging"
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+)
+
+const (
+	inputsSubsystem   = "inputs"
+	informerLabelName = "informer"
+
+	// stalenessCheckInterval is how often the resource version of the API server is probed
+	stalenessCheckInterval = 10 * time.Second
+	// maxVersionProbes bounds the probes kept for informers that stopped making progress
+	maxVersionProbes = 360
+)
+
+var (
+	stalenessLogger = logging.New("health/staleness")
+
+	informerLagGauge = metrics.NewGauge(
+		metrics.GaugeOpts{
+			Subsystem: inputsSubsystem,
+			Name:      "informer_lag_seconds",
+			Help:      "How long the informer lags the resource version of the API server",
+		}, []string{informerLabelName})
+	inputsStaleGauge = metrics.NewGauge(
+		metrics.GaugeOpts{
+			Subsystem: inputsSubsystem,
+			Name:      "stale",
+			Help:      "Set to 1 while informers lag the API server beyond the staleness threshold and xDS snapshot updates are held back",
+		}, []string{})
+)
+
+// InputStalenessTracker detects when the informers feeding the translation fall behind the API server,
+// e.g. when their watches are throttled under API server pressure, so that the control plane doesn't
+// publish configuration translated from inconsistent inputs.
+//
+// The resource version of the API server is probed periodically, and an informer lags by the age of the
+// oldest probe its resource version hasn't reached yet. Informers advance their resource version with
+// every watch event, including the bookmarks the API server sends about every minute, so informers of
+// quiet resources catch up too.
+type InputStalenessTracker struct {
+	threshold        time.Duration
+	serverVersion    func(context.Context) (string, error)
+	informerVersions func() map[string]string
+	now              func() time.Time
+
+	mu         sync.Mutex
+	probes     []versionProbe
+	lags       map[string]time.Duration
+	staleSince time.Time
+}
+
+type versionProbe struct {
+	at      time.Time
+	version uint64
+}
+
+// InputStaleness is the staleness state of the inputs.
+type InputStaleness struct {
+	Stale      bool              `json:"stale"`
+	StaleSince *time.Time        `json:"staleSince,omitempty"`
+	Lags       map[string]string `json:"lags,omitempty"`
+}
+
+// NewInputStalenessTracker returns a tracker that considers the inputs stale once an informer lags the
+// API server for longer than the threshold. A threshold of 0 disables the detection.
+func NewInputStalenessTracker(
+	threshold time.Duration,
+	serverVersion func(context.Context) (string, error),
+	informerVersions func() map[string]string,
+) *InputStalenessTracker {
+	return &InputStalenessTracker{
+		threshold:        threshold,
+		serverVersion:    serverVersion,
+		informerVersions: informerVersions,
+		now:              time.Now,
+		lags:             make(map[string]time.Duration),
+	}
+}
+
+// Run probes the API server and updates the lag of the informers until the context is done.
+func (t *InputStalenessTracker) Run(ctx context.Context) {
+	if t.threshold <= 0 {
+		return
+	}
+	inputsStaleGauge.Set(0)
+	ticker := time.NewTicker(stalenessCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.Check(ctx)
+		}
+	}
+}
+
+// Stale returns true while an informer lags the API server for longer than the threshold.
+func (t *InputStalenessTracker) Stale() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return !t.staleSince.IsZero()
+}
+
+// Status returns the staleness state of the inputs and the lag of the lagging informers.
+func (t *InputStalenessTracker) Status() InputStaleness {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := InputStaleness{Stale: !t.staleSince.IsZero()}
+	if out.Stale {
+		staleSince := t.staleSince
+		out.StaleSince = &staleSince
+	}
+	for name, lag := range t.lags {
+		if lag == 0 {
+			continue
+		}
+		if out.Lags == nil {
+			out.Lags = make(map[string]string)
+		}
+		out.Lags[name] = lag.String()
+	}
+	return out
+}
+
+// Check probes the resource version of the API server and updates the lag of the informers. It is called
+// periodically by Run.
+func (t *InputStalenessTracker) Check(ctx context.Context) {
+	now := t.now()
+	serverVersion, err := t.serverVersion(ctx)
+	if err != nil {
+		// the lag is still updated from the previous probes
+		stalenessLogger.Debug("failed to get the resource version of the api server", "error", err)
+	}
+	informerVersions := t.informerVersions()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if v, err := strconv.ParseUint(serverVersion, 10, 64); err == nil {
+		t.probes = append(t.probes, versionProbe{at: now, version: v})
+		if len(t.probes) > maxVersionProbes {
+			t.probes = t.probes[len(t.probes)-maxVersionProbes:]
+		}
+	}
+
+	lags := make(map[string]time.Duration, len(informerVersions))
+	var caughtUp uint64
+	first := true
+	for name, version := range informerVersions {
+		v, err := strconv.ParseUint(version, 10, 64)
+		if err != nil {
+			// not synced yet, which the sync checks account for
+			continue
+		}
+		if first || v < caughtUp {
+			caughtUp = v
+			first = false
+		}
+		var lag time.Duration
+		if i := slices.IndexFunc(t.probes, func(p versionProbe) bool { return p.version > v }); i >= 0 {
+			lag = now.Sub(t.probes[i].at)
+		}
+		lags[name] = lag
+	}
+	// forget the probes every informer reached
+	if !first {
+		t.probes = slices.DeleteFunc(t.probes, func(p versionProbe) bool { return p.version <= caughtUp })
+	}
+
+	informerLagGauge.Reset()
+	var lagging []string
+	for name, lag := range lags {
+		informerLagGauge.Set(lag.Seconds(), metrics.Label{Name: informerLabelName, Value: name})
+		if lag > t.threshold {
+			lagging = append(lagging, name)
+		}
+	}
+	t.lags = lags
+	slices.Sort(lagging)
+
+	switch {
+	case len(lagging) > 0 && t.staleSince.IsZero():
+		t.staleSince = now
+		inputsStaleGauge.Set(1)
+		stalenessLogger.Warn("informers lag the api server; holding back xds snapshot updates until they catch up",
+			"informers", lagging, "threshold", t.threshold)
+	case len(lagging) == 0 && !t.staleSince.IsZero():
+		stalenessLogger.Info("informers caught up with the api server; resuming xds snapshot updates",
+			"stale_for", now.Sub(t.staleSince))
+		t.staleSince = time.Time{}
+		inputsStaleGauge.Set(0)
+	}
+}
@@ -0,0 +1,102 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics/metricstest"
+)
+
+func TestInputStalenessTracker(t *testing.T) {
+	informerLagGauge.Reset()
+	inputsStaleGauge.Reset()
+
+	now := time.Unix(0, 0)
+	serverVersion := "100"
+	var serverErr error
+	informers := map[string]string{"httproutes.gateway.networking.k8s.io": "100", "services": "100"}
+	tracker := NewInputStalenessTracker(time.Minute,
+		func(context.Context) (string, error) { return serverVersion, serverErr },
+		func() map[string]string { return informers },
+	)
+	tracker.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	tracker.Check(ctx)
+	assert.False(t, tracker.Stale(), "informers at the version of the api server are not stale")
+
+	// the watch of the routes falls behind, while the services keep up
+	now = now.Add(10 * time.Second)
+	serverVersion = "200"
+	informers = map[string]string{"httproutes.gateway.networking.k8s.io": "100", "services": "200"}
+	tracker.Check(ctx)
+	assert.False(t, tracker.Stale())
+
+	now = now.Add(40 * time.Second)
+	serverVersion = "250"
+	tracker.Check(ctx)
+	assert.False(t, tracker.Stale(), "lagging within the threshold is not stale")
+	assert.Equal(t, map[string]string{"httproutes.gateway.networking.k8s.io": "40s"}, tracker.Status().Lags)
+
+	// the api server can't be probed, the lag is still computed from the previous probes
+	now = now.Add(30 * time.Second)
+	serverErr = errors.New("too many requests")
+	informers = map[string]string{"httproutes.gateway.networking.k8s.io": "150", "services": "250"}
+	tracker.Check(ctx)
+	assert.True(t, tracker.Stale())
+	status := tracker.Status()
+	assert.True(t, status.Stale)
+	assert.Equal(t, time.Unix(80, 0), *status.StaleSince)
+	assert.Equal(t, map[string]string{"httproutes.gateway.networking.k8s.io": "1m10s"}, status.Lags)
+
+	gathered := metricstest.MustGatherMetrics(t)
+	gathered.AssertMetric("kgateway_inputs_stale", &metricstest.ExpectedMetric{
+		Labels: []metrics.Label{},
+		Value:  1,
+	})
+	gathered.AssertMetricsInclude("kgateway_inputs_informer_lag_seconds", []metricstest.ExpectMetric{
+		&metricstest.ExpectedMetric{
+			Labels: []metrics.Label{{Name: "informer", Value: "httproutes.gateway.networking.k8s.io"}},
+			Value:  70,
+		},
+		&metricstest.ExpectedMetric{
+			Labels: []metrics.Label{{Name: "informer", Value: "services"}},
+			Value:  0,
+		},
+	})
+
+	// a bookmark brings the routes to the version of the api server, even if none of them changed
+	now = now.Add(10 * time.Second)
+	serverErr = nil
+	serverVersion = "300"
+	informers = map[string]string{"httproutes.gateway.networking.k8s.io": "300", "services": "300"}
+	tracker.Check(ctx)
+	assert.False(t, tracker.Stale(), "informers that caught up are not stale anymore")
+	assert.Equal(t, InputStaleness{}, tracker.Status())
+	assert.Empty(t, tracker.probes, "probes reached by every informer are forgotten")
+
+	gathered = metricstest.MustGatherMetrics(t)
+	gathered.AssertMetric("kgateway_inputs_stale", &metricstest.ExpectedMetric{
+		Labels: []metrics.Label{},
+		Value:  0,
+	})
+}
+
+func TestInputStalenessTrackerIgnoresUnsyncedInformers(t *testing.T) {
+	now := time.Unix(0, 0)
+	tracker := NewInputStalenessTracker(time.Minute,
+		func(context.Context) (string, error) { return "100", nil },
+		func() map[string]string { return map[string]string{"pods": ""} },
+	)
+	tracker.now = func() time.Time { return now }
+
+	tracker.Check(context.Background())
+	now = now.Add(5 * time.Minute)
+	tracker.Check(context.Background())
+	assert.False(t, tracker.Stale(), "informers that didn't sync yet are accounted for by the sync checks")
+}
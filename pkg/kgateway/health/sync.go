@@ -0,0 +1,67 @@
+// Package health tracks whether the control plane is ready to serve xDS config to proxies.
+package health
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Check is a named condition the control plane waits for before it reports ready,
+// e.g. the initial sync of a krt collection.
+type Check struct {
+	Name   string
+	Synced func() bool
+}
+
+// SyncStatus is the sync state of a Check.
+type SyncStatus struct {
+	Name   string `json:"name"`
+	Synced bool   `json:"synced"`
+}
+
+// SyncTracker reports the control plane ready once all of its checks are synced.
+// Checks are expected to only go from unsynced to synced.
+type SyncTracker struct {
+	mu     sync.RWMutex
+	checks []Check
+}
+
+// NewSyncTracker returns a SyncTracker without checks.
+func NewSyncTracker() *SyncTracker {
+	return &SyncTracker{}
+}
+
+// Add registers checks with the tracker.
+func (t *SyncTracker) Add(checks ...Check) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.checks = append(t.checks, checks...)
+}
+
+// Status returns the sync state of all checks, in the order they were added.
+func (t *SyncTracker) Status() []SyncStatus {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	out := make([]SyncStatus, 0, len(t.checks))
+	for _, c := range t.checks {
+		out = append(out, SyncStatus{Name: c.Name, Synced: c.Synced()})
+	}
+	return out
+}
+
+// Ready returns an error listing the checks that are not synced yet.
+// It's a healthz.Checker, so it can be used as the readiness check of the controller manager.
+func (t *SyncTracker) Ready(_ *http.Request) error {
+	var pending []string
+	for _, s := range t.Status() {
+		if !s.Synced {
+			pending = append(pending, s.Name)
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("waiting for %s to sync", strings.Join(pending, ", "))
+	}
+	return nil
+}
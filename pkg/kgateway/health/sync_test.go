@@ -0,0 +1,31 @@
+package health
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncTrackerReady(t *testing.T) {
+	var secrets, snapshots atomic.Bool
+	tracker := NewSyncTracker()
+	require.NoError(t, tracker.Ready(nil), "ready without checks")
+
+	tracker.Add(
+		Check{Name: "Secrets", Synced: secrets.Load},
+		Check{Name: "XdsSnapshots", Synced: snapshots.Load},
+	)
+	require.EqualError(t, tracker.Ready(nil), "waiting for Secrets, XdsSnapshots to sync")
+
+	secrets.Store(true)
+	require.EqualError(t, tracker.Ready(nil), "waiting for XdsSnapshots to sync")
+	assert.Equal(t, []SyncStatus{
+		{Name: "Secrets", Synced: true},
+		{Name: "XdsSnapshots", Synced: false},
+	}, tracker.Status())
+
+	snapshots.Store(true)
+	require.NoError(t, tracker.Ready(nil))
+}
@@ -6,6 +6,9 @@ import (
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoyendpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/endpoints"
@@ -146,3 +149,296 @@ func TestTranslatesDestrulesFailover(t *testing.T) {
 	g.Expect(localLocality.Priority).To(gomega.Equal(uint32(0)))
 	g.Expect(remoteLocality.Priority).To(gomega.Equal(uint32(1)))
 }
+
+func TestUnreadyEndpointFallback(t *testing.T) {
+	endpoint := func(path string, healthStatus envoycorev3.HealthStatus) ir.EndpointWithMd {
+		return ir.EndpointWithMd{
+			LbEndpoint: &envoyendpointv3.LbEndpoint{
+				HealthStatus: healthStatus,
+				HostIdentifier: &envoyendpointv3.LbEndpoint_Endpoint{
+					Endpoint: &envoyendpointv3.Endpoint{
+						Address: &envoycorev3.Address{
+							Address: &envoycorev3.Address_Pipe{Pipe: &envoycorev3.Pipe{Path: path}},
+						},
+					},
+				},
+			},
+		}
+	}
+	claEndpoints := func(cla *envoyendpointv3.ClusterLoadAssignment) map[string]envoycorev3.HealthStatus {
+		out := map[string]envoycorev3.HealthStatus{}
+		for _, localityEps := range cla.GetEndpoints() {
+			for _, ep := range localityEps.GetLbEndpoints() {
+				out[ep.GetEndpoint().GetAddress().GetPipe().GetPath()] = ep.GetHealthStatus()
+			}
+		}
+		return out
+	}
+	us := ir.BackendObjectIR{
+		ObjectSource: ir.ObjectSource{
+			Namespace: "ns",
+			Name:      "name",
+		},
+	}
+
+	// during a rollout, the terminating endpoint that is still serving is kept as degraded
+	// and the endpoint that is starting up is not sent to Envoy, even with the fallback enabled
+	rollout := ir.NewEndpointsForBackend(us)
+	rollout.Add(ir.PodLocality{}, endpoint("ready", envoycorev3.HealthStatus_UNKNOWN))
+	rollout.Add(ir.PodLocality{}, endpoint("terminating", envoycorev3.HealthStatus_DEGRADED))
+	rollout.Add(ir.PodLocality{}, endpoint("starting", envoycorev3.HealthStatus_UNHEALTHY))
+	for _, fallback := range []bool{false, true} {
+		cla := endpoints.PrioritizeEndpoints(nil, ir.UniqlyConnectedClient{}, endpoints.EndpointsInputs{
+			EndpointsForBackend:     *rollout,
+			UnreadyEndpointFallback: fallback,
+		})
+		assert.Equal(t, map[string]envoycorev3.HealthStatus{
+			"ready":       envoycorev3.HealthStatus_UNKNOWN,
+			"terminating": envoycorev3.HealthStatus_DEGRADED,
+		}, claEndpoints(cla))
+	}
+
+	// without serving endpoints, unready endpoints are only used with the fallback enabled
+	unready := ir.NewEndpointsForBackend(us)
+	unready.Add(ir.PodLocality{Region: "R1"}, endpoint("starting", envoycorev3.HealthStatus_UNHEALTHY))
+
+	cla := endpoints.PrioritizeEndpoints(nil, ir.UniqlyConnectedClient{}, endpoints.EndpointsInputs{
+		EndpointsForBackend: *unready,
+	})
+	assert.Empty(t, cla.GetEndpoints())
+
+	cla = endpoints.PrioritizeEndpoints(nil, ir.UniqlyConnectedClient{}, endpoints.EndpointsInputs{
+		EndpointsForBackend:     *unready,
+		UnreadyEndpointFallback: true,
+	})
+	assert.Equal(t, map[string]envoycorev3.HealthStatus{
+		"starting": envoycorev3.HealthStatus_UNKNOWN,
+	}, claEndpoints(cla))
+	// the shared endpoint must not be modified
+	assert.Equal(t, envoycorev3.HealthStatus_UNHEALTHY, unready.LbEps[ir.PodLocality{Region: "R1"}][0].GetHealthStatus())
+}
+
+func TestZoneAwareFailover(t *testing.T) {
+	endpoint := func(path string, healthStatus envoycorev3.HealthStatus) ir.EndpointWithMd {
+		return ir.EndpointWithMd{
+			LbEndpoint: &envoyendpointv3.LbEndpoint{
+				HealthStatus: healthStatus,
+				HostIdentifier: &envoyendpointv3.LbEndpoint_Endpoint{
+					Endpoint: &envoyendpointv3.Endpoint{
+						Address: &envoycorev3.Address{
+							Address: &envoycorev3.Address_Pipe{Pipe: &envoycorev3.Pipe{Path: path}},
+						},
+					},
+				},
+			},
+		}
+	}
+	priorities := func(cla *envoyendpointv3.ClusterLoadAssignment) map[string]uint32 {
+		out := map[string]uint32{}
+		for _, localityEps := range cla.GetEndpoints() {
+			for _, ep := range localityEps.GetLbEndpoints() {
+				out[ep.GetEndpoint().GetAddress().GetPipe().GetPath()] = localityEps.GetPriority()
+			}
+		}
+		return out
+	}
+	us := ir.BackendObjectIR{
+		ObjectSource: ir.ObjectSource{
+			Namespace: "ns",
+			Name:      "name",
+		},
+	}
+	ucc := ir.NewUniqlyConnectedClient("gw", "ns", nil, ir.PodLocality{Region: "R1", Zone: "Z1"})
+
+	efu := ir.NewEndpointsForBackend(us)
+	efu.Add(ir.PodLocality{Region: "R1", Zone: "Z1"}, endpoint("same-zone", envoycorev3.HealthStatus_UNKNOWN))
+	efu.Add(ir.PodLocality{Zone: "Z1"}, endpoint("same-zone-from-slice", envoycorev3.HealthStatus_UNKNOWN))
+	efu.Add(ir.PodLocality{Region: "R1", Zone: "Z2"}, endpoint("same-region", envoycorev3.HealthStatus_UNKNOWN))
+	efu.Add(ir.PodLocality{Zone: "Z3"}, endpoint("other-zone-from-slice", envoycorev3.HealthStatus_UNKNOWN))
+	efu.Add(ir.PodLocality{Region: "R2", Zone: "Z4"}, endpoint("other-region", envoycorev3.HealthStatus_UNKNOWN))
+
+	// endpoints in the zone of the proxy are preferred, then endpoints in its region
+	cla := endpoints.PrioritizeEndpoints(nil, ucc, endpoints.EndpointsInputs{
+		EndpointsForBackend:    *efu,
+		PriorityInfo:           &endpoints.PriorityInfo{},
+		OverprovisioningFactor: wrapperspb.UInt32(125),
+	})
+	assert.Equal(t, map[string]uint32{
+		"same-zone":             0,
+		"same-zone-from-slice":  0,
+		"same-region":           1,
+		"other-zone-from-slice": 2,
+		"other-region":          2,
+	}, priorities(cla))
+	assert.Equal(t, uint32(125), cla.GetPolicy().GetOverprovisioningFactor().GetValue())
+
+	// when the endpoints in the zone of the proxy are unhealthy, requests fail over to the other zones
+	failover := ir.NewEndpointsForBackend(us)
+	failover.Add(ir.PodLocality{Region: "R1", Zone: "Z1"}, endpoint("same-zone", envoycorev3.HealthStatus_UNHEALTHY))
+	failover.Add(ir.PodLocality{Region: "R1", Zone: "Z2"}, endpoint("same-region", envoycorev3.HealthStatus_UNKNOWN))
+	failover.Add(ir.PodLocality{Region: "R2", Zone: "Z4"}, endpoint("other-region", envoycorev3.HealthStatus_UNKNOWN))
+
+	cla = endpoints.PrioritizeEndpoints(nil, ucc, endpoints.EndpointsInputs{
+		EndpointsForBackend: *failover,
+		PriorityInfo:        &endpoints.PriorityInfo{},
+	})
+	assert.Equal(t, map[string]uint32{
+		"same-region":  0,
+		"other-region": 1,
+	}, priorities(cla))
+	assert.Nil(t, cla.GetPolicy())
+}
+
+func TestTopologyAwareRouting(t *testing.T) {
+	endpoint := func(path string, healthStatus envoycorev3.HealthStatus, forZones ...string) ir.EndpointWithMd {
+		return ir.EndpointWithMd{
+			LbEndpoint: &envoyendpointv3.LbEndpoint{
+				HealthStatus: healthStatus,
+				HostIdentifier: &envoyendpointv3.LbEndpoint_Endpoint{
+					Endpoint: &envoyendpointv3.Endpoint{
+						Address: &envoycorev3.Address{
+							Address: &envoycorev3.Address_Pipe{Pipe: &envoycorev3.Pipe{Path: path}},
+						},
+					},
+				},
+			},
+			EndpointMd: ir.EndpointMetadata{ForZones: forZones},
+		}
+	}
+	paths := func(cla *envoyendpointv3.ClusterLoadAssignment) []string {
+		var out []string
+		for _, localityEps := range cla.GetEndpoints() {
+			for _, ep := range localityEps.GetLbEndpoints() {
+				out = append(out, ep.GetEndpoint().GetAddress().GetPipe().GetPath())
+			}
+		}
+		return out
+	}
+	us := ir.BackendObjectIR{
+		ObjectSource: ir.ObjectSource{
+			Namespace: "ns",
+			Name:      "name",
+		},
+	}
+	client := func(zone string) ir.UniqlyConnectedClient {
+		return ir.NewUniqlyConnectedClient("gw", "ns", nil, ir.PodLocality{Region: "R1", Zone: zone})
+	}
+
+	// endpoints of multiple slices, hinted by the EndpointSlice controller
+	hinted := ir.NewEndpointsForBackend(us)
+	hinted.Add(ir.PodLocality{Zone: "Z1"}, endpoint("z1-a", envoycorev3.HealthStatus_UNKNOWN, "Z1"))
+	hinted.Add(ir.PodLocality{Zone: "Z1"}, endpoint("z1-b", envoycorev3.HealthStatus_UNKNOWN, "Z1"))
+	hinted.Add(ir.PodLocality{Zone: "Z2"}, endpoint("z2", envoycorev3.HealthStatus_UNKNOWN, "Z2", "Z3"))
+	// unready endpoints do not need hints
+	hinted.Add(ir.PodLocality{Zone: "Z2"}, endpoint("z2-terminating", envoycorev3.HealthStatus_DEGRADED))
+
+	tests := []struct {
+		name     string
+		efu      *ir.EndpointsForBackend
+		zone     string
+		expected []string
+	}{
+		{
+			name:     "proxy gets the subset hinted for its zone",
+			efu:      hinted,
+			zone:     "Z1",
+			expected: []string{"z1-a", "z1-b"},
+		},
+		{
+			name:     "endpoints can be hinted for another zone without endpoints",
+			efu:      hinted,
+			zone:     "Z3",
+			expected: []string{"z2"},
+		},
+		{
+			name:     "all endpoints are used when none is hinted for the zone of the proxy",
+			efu:      hinted,
+			zone:     "Z4",
+			expected: []string{"z1-a", "z1-b", "z2", "z2-terminating"},
+		},
+		{
+			name:     "all endpoints are used when the zone of the proxy is unknown",
+			efu:      hinted,
+			zone:     "",
+			expected: []string{"z1-a", "z1-b", "z2", "z2-terminating"},
+		},
+		{
+			name: "all endpoints are used when a ready endpoint has no hints",
+			efu: func() *ir.EndpointsForBackend {
+				efu := ir.NewEndpointsForBackend(us)
+				efu.Add(ir.PodLocality{Zone: "Z1"}, endpoint("z1", envoycorev3.HealthStatus_UNKNOWN, "Z1"))
+				efu.Add(ir.PodLocality{Zone: "Z2"}, endpoint("z2", envoycorev3.HealthStatus_UNKNOWN))
+				return efu
+			}(),
+			zone:     "Z1",
+			expected: []string{"z1", "z2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cla := endpoints.PrioritizeEndpoints(nil, client(tt.zone), endpoints.EndpointsInputs{
+				EndpointsForBackend: *tt.efu,
+			})
+			assert.ElementsMatch(t, tt.expected, paths(cla))
+		})
+	}
+	// the shared endpoints must not be modified
+	assert.Len(t, hinted.LbEps[ir.PodLocality{Zone: "Z1"}], 2)
+	assert.Len(t, hinted.LbEps[ir.PodLocality{Zone: "Z2"}], 2)
+}
+
+func TestSubsetEndpointMetadata(t *testing.T) {
+	endpoint := func(path string, labels map[string]string) ir.EndpointWithMd {
+		return ir.EndpointWithMd{
+			LbEndpoint: &envoyendpointv3.LbEndpoint{
+				Metadata: &envoycorev3.Metadata{FilterMetadata: map[string]*structpb.Struct{
+					"envoy.transport_socket_match": {Fields: map[string]*structpb.Value{
+						"tlsMode": structpb.NewStringValue("istio"),
+					}},
+				}},
+				HostIdentifier: &envoyendpointv3.LbEndpoint_Endpoint{
+					Endpoint: &envoyendpointv3.Endpoint{
+						Address: &envoycorev3.Address{
+							Address: &envoycorev3.Address_Pipe{Pipe: &envoycorev3.Pipe{Path: path}},
+						},
+					},
+				},
+			},
+			EndpointMd: ir.EndpointMetadata{Labels: labels},
+		}
+	}
+	us := ir.BackendObjectIR{
+		ObjectSource: ir.ObjectSource{
+			Namespace: "ns",
+			Name:      "name",
+		},
+	}
+	efu := ir.NewEndpointsForBackend(us)
+	efu.Add(ir.PodLocality{}, endpoint("v1", map[string]string{"app": "reviews", "version": "v1"}))
+	efu.Add(ir.PodLocality{}, endpoint("v2", map[string]string{"app": "reviews", "version": "v2"}))
+	efu.Add(ir.PodLocality{}, endpoint("unlabeled", nil))
+
+	cla := endpoints.PrioritizeEndpoints(nil, ir.UniqlyConnectedClient{}, endpoints.EndpointsInputs{
+		EndpointsForBackend: *efu,
+		SubsetLabelKeys:     []string{"version"},
+	})
+
+	// all the endpoints are sent, so that the subset load balancer can fall back to them
+	lbMetadata := map[string]*structpb.Struct{}
+	for _, localityEps := range cla.GetEndpoints() {
+		for _, ep := range localityEps.GetLbEndpoints() {
+			path := ep.GetEndpoint().GetAddress().GetPipe().GetPath()
+			lbMetadata[path] = ep.GetMetadata().GetFilterMetadata()["envoy.lb"]
+			assert.NotNil(t, ep.GetMetadata().GetFilterMetadata()["envoy.transport_socket_match"], "existing metadata of %s should be kept", path)
+		}
+	}
+	assert.Len(t, lbMetadata, 3)
+	assert.Equal(t, map[string]any{"version": "v1"}, lbMetadata["v1"].AsMap())
+	assert.Equal(t, map[string]any{"version": "v2"}, lbMetadata["v2"].AsMap())
+	assert.Nil(t, lbMetadata["unlabeled"], "endpoints without the subset labels match no subset")
+
+	// the shared endpoints must not be modified
+	for _, ep := range efu.LbEps[ir.PodLocality{}] {
+		assert.NotContains(t, ep.GetMetadata().GetFilterMetadata(), "envoy.lb")
+	}
+}
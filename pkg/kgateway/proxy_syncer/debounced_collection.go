@@ -0,0 +1,165 @@
+package proxy_syncer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/kube/krt"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+)
+
+// debouncedCollection mirrors a translation input collection, applying its changes in batches so that
+// bursts of events (e.g. EndpointSlice churn while rolling a large backend) are translated once per
+// object rather than once per event.
+//
+// A pending change is applied once no new change has been received for the debounce window, or once
+// the oldest pending change has waited for maxWait, whichever comes first. Successive changes of an
+// object are coalesced into its latest state.
+type debouncedCollection[T any] struct {
+	krt.StaticCollection[T]
+
+	name    string
+	window  time.Duration
+	maxWait time.Duration
+
+	mu sync.Mutex
+	// pending holds the latest state of the objects changed since the last flush, nil for deleted objects
+	pending map[string]*T
+	// firstPending is the time at which the oldest pending change was received
+	firstPending time.Time
+
+	// flushMu serializes the flushes, so that an older state can't be applied after a newer one
+	flushMu sync.Mutex
+
+	kick   chan struct{}
+	synced chan struct{}
+}
+
+// newDebouncedCollection returns a collection that follows src with a delay of at most maxWait.
+// The collection is synced once the initial state of src was applied. src is returned as-is when
+// the window is not positive.
+func newDebouncedCollection[T any](
+	ctx context.Context,
+	src krt.Collection[T],
+	name string,
+	window, maxWait time.Duration,
+	opts ...krt.CollectionOption,
+) krt.Collection[T] {
+	if window <= 0 {
+		return src
+	}
+	d := &debouncedCollection[T]{
+		name:    name,
+		window:  window,
+		maxWait: max(maxWait, window),
+		pending: make(map[string]*T),
+		kick:    make(chan struct{}, 1),
+		synced:  make(chan struct{}),
+	}
+	d.StaticCollection = krt.NewStaticCollection[T](collectionSyncer(d.synced), nil, opts...)
+
+	registration := src.RegisterBatch(d.enqueue, true)
+	go func() {
+		// the initial state is applied as soon as it is known, without waiting for the window
+		if !registration.WaitUntilSynced(ctx.Done()) {
+			return
+		}
+		d.flush()
+		close(d.synced)
+	}()
+	go d.run(ctx)
+	return d.StaticCollection
+}
+
+func (d *debouncedCollection[T]) enqueue(events []krt.Event[T]) {
+	d.mu.Lock()
+	if len(d.pending) == 0 {
+		d.firstPending = time.Now()
+	}
+	for _, e := range events {
+		key := krt.GetKey(e.Latest())
+		if _, ok := d.pending[key]; ok {
+			inputCoalescedEventsTotal.Inc(collectionLabel(d.name))
+		}
+		if e.Event == controllers.EventDelete {
+			d.pending[key] = nil
+		} else {
+			d.pending[key] = e.New
+		}
+	}
+	inputDebounceQueueDepth.Set(float64(len(d.pending)), collectionLabel(d.name))
+	d.mu.Unlock()
+
+	select {
+	case d.kick <- struct{}{}:
+	default:
+	}
+}
+
+func (d *debouncedCollection[T]) run(ctx context.Context) {
+	timer := time.NewTimer(d.window)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.kick:
+			d.mu.Lock()
+			delay := min(d.window, d.maxWait-time.Since(d.firstPending))
+			d.mu.Unlock()
+			timer.Reset(max(delay, 0))
+		case <-timer.C:
+			d.flush()
+		}
+	}
+}
+
+// flush applies the pending changes. Objects whose latest state equals the applied one produce no event.
+func (d *debouncedCollection[T]) flush() {
+	d.flushMu.Lock()
+	defer d.flushMu.Unlock()
+
+	d.mu.Lock()
+	pending := d.pending
+	d.pending = make(map[string]*T, len(pending))
+	inputDebounceQueueDepth.Set(0, collectionLabel(d.name))
+	d.mu.Unlock()
+
+	for key, obj := range pending {
+		if obj == nil {
+			d.DeleteObject(key)
+		} else {
+			d.ConditionalUpdateObject(*obj)
+		}
+	}
+}
+
+func collectionLabel(name string) metrics.Label {
+	return metrics.Label{Name: collectionLabelName, Value: name}
+}
+
+// collectionSyncer is a krt.Syncer that is synced once the channel is closed.
+type collectionSyncer chan struct{}
+
+func (c collectionSyncer) HasSynced() bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c collectionSyncer) WaitUntilSynced(stop <-chan struct{}) bool {
+	select {
+	case <-c:
+		return true
+	case <-stop:
+		return false
+	}
+}
@@ -0,0 +1,150 @@
+package proxy_syncer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/kube/krt"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics/metricstest"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
+)
+
+type debounceTestObj struct {
+	Name  string
+	Value int
+}
+
+func (o debounceTestObj) ResourceName() string {
+	return o.Name
+}
+
+type recordingHandler struct {
+	mu     sync.Mutex
+	events []krt.Event[debounceTestObj]
+}
+
+func (r *recordingHandler) handle(events []krt.Event[debounceTestObj]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, events...)
+}
+
+func (r *recordingHandler) recorded() []krt.Event[debounceTestObj] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]krt.Event[debounceTestObj](nil), r.events...)
+}
+
+// newTestDebouncedCollection returns a synced debounced collection of src and a handler recording its events
+// after the initial state.
+func newTestDebouncedCollection(
+	t *testing.T,
+	src krt.Collection[debounceTestObj],
+	window, maxWait time.Duration,
+) (krt.Collection[debounceTestObj], *recordingHandler) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	krtOpts := krtutil.NewKrtOptions(ctx.Done(), nil)
+
+	col := newDebouncedCollection(ctx, src, "test", window, maxWait, krtOpts.ToOptions("debounced")...)
+	require.True(t, col.WaitUntilSynced(ctx.Done()))
+
+	rec := &recordingHandler{}
+	col.RegisterBatch(rec.handle, false)
+	return col, rec
+}
+
+func TestDebouncedCollectionCoalescesRapidEvents(t *testing.T) {
+	setupTest()
+
+	src := krt.NewStaticCollection[debounceTestObj](nil, []debounceTestObj{{Name: "a", Value: 0}})
+	col, rec := newTestDebouncedCollection(t, src, 100*time.Millisecond, 5*time.Second)
+	// the initial state is applied as soon as the source is synced
+	assert.Equal(t, &debounceTestObj{Name: "a", Value: 0}, col.GetKey("a"))
+
+	const numEvents = 50
+	for i := 1; i <= numEvents; i++ {
+		src.UpdateObject(debounceTestObj{Name: "a", Value: i})
+	}
+
+	require.Eventually(t, func() bool {
+		return len(rec.recorded()) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+	// make sure no other event happens after the first one
+	require.Never(t, func() bool {
+		return len(rec.recorded()) > 1
+	}, 300*time.Millisecond, 10*time.Millisecond)
+
+	event := rec.recorded()[0]
+	assert.Equal(t, controllers.EventUpdate, event.Event)
+	assert.Equal(t, debounceTestObj{Name: "a", Value: numEvents}, event.Latest(), "the latest state should be applied")
+
+	gathered := metricstest.MustGatherMetrics(t)
+	gathered.AssertMetricsInclude("kgateway_xds_snapshot_input_coalesced_events_total", []metricstest.ExpectMetric{
+		&metricstest.ExpectedMetric{
+			Labels: []metrics.Label{{Name: "collection", Value: "test"}},
+			Value:  numEvents - 1,
+		},
+	})
+	gathered.AssertMetricsInclude("kgateway_xds_snapshot_input_debounce_queue_depth", []metricstest.ExpectMetric{
+		&metricstest.ExpectedMetric{
+			Labels: []metrics.Label{{Name: "collection", Value: "test"}},
+			Value:  0,
+		},
+	})
+}
+
+func TestDebouncedCollectionMaxWait(t *testing.T) {
+	setupTest()
+
+	src := krt.NewStaticCollection[debounceTestObj](nil, nil)
+	maxWait := 200 * time.Millisecond
+	_, rec := newTestDebouncedCollection(t, src, 100*time.Millisecond, maxWait)
+
+	// keep sending events faster than the debounce window so that the window never elapses
+	start := time.Now()
+	for i := 0; time.Since(start) < 4*maxWait; i++ {
+		src.UpdateObject(debounceTestObj{Name: "a", Value: i})
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	assert.GreaterOrEqual(t, len(rec.recorded()), 2, "pending changes should be applied once the max wait elapses")
+}
+
+func TestDebouncedCollectionDeletes(t *testing.T) {
+	setupTest()
+
+	src := krt.NewStaticCollection[debounceTestObj](nil, []debounceTestObj{{Name: "a", Value: 1}, {Name: "b", Value: 1}})
+	col, rec := newTestDebouncedCollection(t, src, 50*time.Millisecond, time.Second)
+
+	// an object deleted and re-created unchanged within the window produces no event
+	src.DeleteObject("a")
+	src.UpdateObject(debounceTestObj{Name: "a", Value: 1})
+	src.DeleteObject("b")
+
+	require.Eventually(t, func() bool {
+		return col.GetKey("b") == nil
+	}, 2*time.Second, 10*time.Millisecond)
+	require.Never(t, func() bool {
+		return len(rec.recorded()) > 1
+	}, 200*time.Millisecond, 10*time.Millisecond)
+
+	events := rec.recorded()
+	require.Len(t, events, 1)
+	assert.Equal(t, controllers.EventDelete, events[0].Event)
+	assert.Equal(t, "b", events[0].Latest().Name)
+	assert.Equal(t, &debounceTestObj{Name: "a", Value: 1}, col.GetKey("a"))
+}
+
+func TestDebouncedCollectionDisabled(t *testing.T) {
+	src := krt.NewStaticCollection[debounceTestObj](nil, nil)
+	col := newDebouncedCollection(context.Background(), src, "test", 0, 0)
+	assert.Equal(t, krt.Collection[debounceTestObj](src), col, "the source should be used as-is without a window")
+}
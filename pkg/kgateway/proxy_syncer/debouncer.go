@@ -0,0 +1,350 @@
+package proxy_syncer
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	envoycachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/xds"
+)
+
+const (
+	syncTypeFull      = "full"
+	syncTypeEndpoints = "endpoints"
+	syncTypeWarming   = "warming"
+
+	// recheckInterval is how often the debouncer checks whether the proxies applied an intermediate
+	// snapshot, and whether held back snapshots can be pushed
+	recheckInterval = 100 * time.Millisecond
+	// defaultWarmTimeout is how long the debouncer waits for the proxies to apply an intermediate snapshot
+	// before pushing the next one anyway, e.g. when a proxy rejects it
+	defaultWarmTimeout = 10 * time.Second
+)
+
+// xdsDebouncer coalesces per-client xDS snapshot updates over a short quiet window so that
+// bursts of input events (e.g. EndpointSlice churn while rolling a large backend) result in
+// a single snapshot push per client rather than one push per event.
+//
+// A pending update is pushed once no new update for any client has been received for the
+// debounce window, or once the oldest pending update has waited for maxWait, whichever
+// comes first.
+//
+// When the proxies of a client can be checked for having applied a snapshot, pushes are ordered so
+// that proxies never get routes referencing clusters they haven't warmed, see nextSnapshotStep.
+type xdsDebouncer struct {
+	window  time.Duration
+	maxWait time.Duration
+	sync    func(XdsSnapWrapper)
+	clear   func(proxyKey string)
+	// applied returns true if the proxies of a client applied the snapshot. Pushes are only
+	// ordered when it is set.
+	applied     func(proxyKey string, snap *envoycache.Snapshot) bool
+	warmTimeout time.Duration
+	// paused returns true while snapshots must be held back, e.g. because they are translated from stale
+	// inputs. The proxies keep the last pushed snapshot, and the latest one is pushed once resumed.
+	paused func() bool
+	// onHold is called for each client whose snapshot is held back while paused
+	onHold func(proxyKey string)
+
+	// pushMu serializes the pushes with clearing the snapshots of clients that went away
+	pushMu sync.Mutex
+
+	mu sync.Mutex
+	// pending holds the latest not yet pushed snapshot for each client
+	pending map[string]XdsSnapWrapper
+	// firstPending is the time at which the oldest pending update was received
+	firstPending time.Time
+	// synced holds the last snapshot pushed for each client
+	synced map[string]*envoycache.Snapshot
+	// flushing is set while the pending snapshots are being pushed
+	flushing bool
+	// held is set when pending snapshots were held back while paused
+	held bool
+	// warming holds the snapshot each client converges to while an intermediate snapshot is applied
+	warming map[string]warmingSnapshot
+	// cached holds the keys of the clients whose snapshot was pushed to the xDS cache, by Gateway. Clients
+	// that went away are kept until their Gateway is deleted, as their snapshot stays in the cache.
+	cached map[string]map[string]struct{}
+
+	kick chan struct{}
+}
+
+func newXdsDebouncer(window, maxWait time.Duration, sync func(XdsSnapWrapper), clear func(proxyKey string)) *xdsDebouncer {
+	if maxWait < window {
+		maxWait = window
+	}
+	return &xdsDebouncer{
+		window:  window,
+		maxWait: maxWait,
+		sync:    sync,
+		clear:   clear,
+		pending: make(map[string]XdsSnapWrapper),
+		synced:  make(map[string]*envoycache.Snapshot),
+		warming: make(map[string]warmingSnapshot),
+		cached:  make(map[string]map[string]struct{}),
+		kick:    make(chan struct{}, 1),
+		// the timeout only matters once pushes are ordered
+		warmTimeout: defaultWarmTimeout,
+	}
+}
+
+type warmingSnapshot struct {
+	target XdsSnapWrapper
+	since  time.Time
+}
+
+// Enqueue records the latest snapshot for a client. If debouncing is disabled and pushes
+// aren't paused, the snapshot is pushed immediately.
+func (d *xdsDebouncer) Enqueue(snap XdsSnapWrapper) {
+	if d.window <= 0 && !d.isPaused() {
+		d.pushMu.Lock()
+		defer d.pushMu.Unlock()
+		d.push(snap)
+		return
+	}
+
+	d.mu.Lock()
+	if len(d.pending) == 0 {
+		d.firstPending = time.Now()
+	}
+	if _, ok := d.pending[snap.proxyKey]; ok {
+		snapshotCoalescedEventsTotal.Inc()
+	}
+	d.pending[snap.proxyKey] = snap
+	snapshotDebounceQueueDepth.Set(float64(len(d.pending)))
+	d.mu.Unlock()
+
+	select {
+	case d.kick <- struct{}{}:
+	default:
+	}
+}
+
+// Forget drops any pending or previously pushed snapshot for a client that went away. Its snapshot
+// is kept in the xDS cache, so that the client gets its config right away when it reconnects.
+func (d *xdsDebouncer) Forget(proxyKey string) {
+	d.pushMu.Lock()
+	defer d.pushMu.Unlock()
+	d.forget(proxyKey)
+}
+
+// Clear forgets a client whose Gateway was deleted and clears its snapshot from the xDS cache.
+// A flush in progress is waited for, so that it can't push the snapshot of the client again
+// after it was cleared.
+func (d *xdsDebouncer) Clear(proxyKey string) {
+	d.pushMu.Lock()
+	defer d.pushMu.Unlock()
+	d.clearClient(proxyKey)
+}
+
+// ClearGateway clears the snapshots of all the clients of a deleted Gateway from the xDS cache,
+// including the ones of clients that went away before, e.g. the proxies of a previous rollout.
+func (d *xdsDebouncer) ClearGateway(gateway string) {
+	d.pushMu.Lock()
+	defer d.pushMu.Unlock()
+	d.mu.Lock()
+	proxyKeys := slices.Collect(maps.Keys(d.cached[gateway]))
+	d.mu.Unlock()
+	for _, proxyKey := range proxyKeys {
+		d.clearClient(proxyKey)
+	}
+}
+
+func (d *xdsDebouncer) clearClient(proxyKey string) {
+	d.forget(proxyKey)
+	d.mu.Lock()
+	gateway := gatewayOfProxyKey(proxyKey)
+	delete(d.cached[gateway], proxyKey)
+	if len(d.cached[gateway]) == 0 {
+		delete(d.cached, gateway)
+	}
+	d.mu.Unlock()
+	if d.clear != nil {
+		d.clear(proxyKey)
+	}
+}
+
+func (d *xdsDebouncer) forget(proxyKey string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.pending, proxyKey)
+	delete(d.synced, proxyKey)
+	delete(d.warming, proxyKey)
+	snapshotDebounceQueueDepth.Set(float64(len(d.pending)))
+}
+
+// Run processes pending snapshots until the context is canceled.
+func (d *xdsDebouncer) Run(ctx context.Context) {
+	if d.window <= 0 && d.applied == nil && d.paused == nil {
+		return
+	}
+
+	timer := time.NewTimer(d.window)
+	timer.Stop()
+	defer timer.Stop()
+
+	var recheck <-chan time.Time
+	if d.applied != nil || d.paused != nil {
+		ticker := time.NewTicker(recheckInterval)
+		defer ticker.Stop()
+		recheck = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-recheck:
+			if d.isPaused() {
+				continue
+			}
+			d.pushWarmed()
+			// snapshots held back while paused are pushed once resumed
+			d.mu.Lock()
+			held := d.held
+			d.mu.Unlock()
+			if held {
+				d.flush()
+			}
+		case <-d.kick:
+			d.mu.Lock()
+			delay := min(d.window, d.maxWait-time.Since(d.firstPending))
+			d.mu.Unlock()
+			timer.Reset(max(delay, 0))
+		case <-timer.C:
+			d.flush()
+		}
+	}
+}
+
+// flush pushes all pending snapshots, unless paused.
+func (d *xdsDebouncer) flush() {
+	if d.isPaused() {
+		d.mu.Lock()
+		d.held = true
+		var held []string
+		if d.onHold != nil {
+			held = slices.Collect(maps.Keys(d.pending))
+		}
+		d.mu.Unlock()
+		for _, proxyKey := range held {
+			d.onHold(proxyKey)
+		}
+		return
+	}
+	d.pushMu.Lock()
+	defer d.pushMu.Unlock()
+
+	d.mu.Lock()
+	d.held = false
+	pending := d.pending
+	d.pending = make(map[string]XdsSnapWrapper, len(pending))
+	d.flushing = true
+	snapshotDebounceQueueDepth.Set(0)
+	d.mu.Unlock()
+
+	for _, snap := range pending {
+		d.push(snap)
+	}
+
+	d.mu.Lock()
+	d.flushing = false
+	d.mu.Unlock()
+}
+
+func (d *xdsDebouncer) isPaused() bool {
+	return d.paused != nil && d.paused()
+}
+
+// idle returns true if all the enqueued snapshots were pushed.
+func (d *xdsDebouncer) idle() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.pending) == 0 && !d.flushing
+}
+
+// pushWarmed pushes the next snapshot of the clients whose proxies applied their intermediate
+// snapshot, or that waited for them for longer than the warm timeout.
+func (d *xdsDebouncer) pushWarmed() {
+	d.pushMu.Lock()
+	defer d.pushMu.Unlock()
+
+	d.mu.Lock()
+	warming := maps.Clone(d.warming)
+	d.mu.Unlock()
+
+	for proxyKey, w := range warming {
+		d.mu.Lock()
+		current := d.synced[proxyKey]
+		d.mu.Unlock()
+		if current == nil {
+			continue
+		}
+		if !d.applied(proxyKey, current) {
+			if time.Since(w.since) < d.warmTimeout {
+				continue
+			}
+			logger.Warn("proxies did not apply the intermediate xds snapshot in time; pushing the next one",
+				"proxy_key", proxyKey, "timeout", d.warmTimeout)
+		}
+		d.push(w.target)
+	}
+}
+
+// push syncs the snapshot. Endpoint-only updates are counted separately, their other
+// resources are shared with the previous snapshot of the client, see snapshotPerClient.
+// When pushes are ordered, an intermediate snapshot may be pushed instead, in which case the
+// snapshot is pushed once the proxies applied it.
+func (d *xdsDebouncer) push(snap XdsSnapWrapper) {
+	d.mu.Lock()
+	prev := d.synced[snap.proxyKey]
+	_, warming := d.warming[snap.proxyKey]
+	delete(d.warming, snap.proxyKey)
+	syncType := syncTypeFull
+	switch {
+	case prev == nil:
+	case !warming && onlyEndpointsChanged(prev, snap.snap):
+		syncType = syncTypeEndpoints
+	case d.applied != nil:
+		if step := nextSnapshotStep(prev, snap.snap); step != snap.snap {
+			syncType = syncTypeWarming
+			d.warming[snap.proxyKey] = warmingSnapshot{target: snap, since: time.Now()}
+			snap = snap.WithSnapshot(step)
+		}
+	}
+	d.synced[snap.proxyKey] = snap.snap
+	gateway := gatewayOfProxyKey(snap.proxyKey)
+	if d.cached[gateway] == nil {
+		d.cached[gateway] = make(map[string]struct{})
+	}
+	d.cached[gateway][snap.proxyKey] = struct{}{}
+	d.mu.Unlock()
+
+	snapshotPushesTotal.Inc(syncTypeLabel(syncType))
+	d.sync(snap)
+}
+
+// gatewayOfProxyKey returns the key of the Gateway a client belongs to, see GatewayXdsResources.ResourceName.
+func gatewayOfProxyKey(proxyKey string) string {
+	cd := getDetailsFromXDSClientResourceName(proxyKey)
+	return xds.OwnerNamespaceNameID(cd.Role, cd.Namespace, cd.Gateway)
+}
+
+// onlyEndpointsChanged returns true if the resource versions of two snapshots differ for
+// endpoints only.
+func onlyEndpointsChanged(prev, next *envoycache.Snapshot) bool {
+	for i := range prev.Resources {
+		if envoycachetypes.ResponseType(i) == envoycachetypes.Endpoint {
+			continue
+		}
+		if prev.Resources[i].Version != next.Resources[i].Version {
+			return false
+		}
+	}
+	return true
+}
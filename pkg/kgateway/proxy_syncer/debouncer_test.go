@@ -0,0 +1,315 @@
+package proxy_syncer
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	envoycachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	envoyresource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics/metricstest"
+)
+
+const testProxyKey = "kgateway-kube-gateway-api~default~example-gateway"
+
+type recordingSyncer struct {
+	mu    sync.Mutex
+	snaps []XdsSnapWrapper
+}
+
+func (r *recordingSyncer) sync(snap XdsSnapWrapper) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.snaps = append(r.snaps, snap)
+}
+
+func (r *recordingSyncer) synced() []XdsSnapWrapper {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]XdsSnapWrapper(nil), r.snaps...)
+}
+
+// testSnap returns a snapshot for the test proxy with the given route and endpoint versions.
+func testSnap(routeVersion, endpointVersion string) XdsSnapWrapper {
+	snap := &envoycache.Snapshot{}
+	snap.Resources[envoycachetypes.Cluster] = envoycache.NewResources("c1", nil)
+	snap.Resources[envoycachetypes.Listener] = envoycache.NewResources("l1", nil)
+	snap.Resources[envoycachetypes.Route] = envoycache.NewResources(routeVersion, nil)
+	snap.Resources[envoycachetypes.Endpoint] = envoycache.NewResources(endpointVersion, nil)
+	return XdsSnapWrapper{snap: snap, proxyKey: testProxyKey}
+}
+
+func TestXdsDebouncerCoalescesRapidEvents(t *testing.T) {
+	setupTest()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rec := &recordingSyncer{}
+	d := newXdsDebouncer(100*time.Millisecond, 5*time.Second, rec.sync, nil)
+	go d.Run(ctx)
+
+	const numEvents = 50
+	for i := range numEvents {
+		d.Enqueue(testSnap("r1", fmt.Sprintf("e%d", i)))
+	}
+
+	require.Eventually(t, func() bool {
+		return len(rec.synced()) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+	// make sure no other sync happens after the first one
+	require.Never(t, func() bool {
+		return len(rec.synced()) > 1
+	}, 300*time.Millisecond, 10*time.Millisecond)
+
+	synced := rec.synced()[0]
+	assert.Equal(t, fmt.Sprintf("e%d", numEvents-1), synced.snap.Resources[envoycachetypes.Endpoint].Version,
+		"the latest snapshot should be pushed")
+
+	gathered := metricstest.MustGatherMetrics(t)
+	gathered.AssertMetric("kgateway_xds_snapshot_coalesced_events_total", &metricstest.ExpectedMetric{
+		Labels: []metrics.Label{},
+		Value:  numEvents - 1,
+	})
+	gathered.AssertMetric("kgateway_xds_snapshot_debounce_queue_depth", &metricstest.ExpectedMetric{
+		Labels: []metrics.Label{},
+		Value:  0,
+	})
+}
+
+func TestXdsDebouncerCountsEndpointUpdates(t *testing.T) {
+	setupTest()
+
+	rec := &recordingSyncer{}
+	// debouncing disabled so that every update is pushed
+	d := newXdsDebouncer(0, 0, rec.sync, nil)
+
+	eds := testSnap("r1", "e2")
+	d.Enqueue(testSnap("r1", "e1"))
+	d.Enqueue(eds)
+	d.Enqueue(testSnap("r2", "e2"))
+
+	synced := rec.synced()
+	require.Len(t, synced, 3)
+	// snapshots are pushed as they were built
+	assert.Same(t, eds.snap, synced[1].snap)
+	assert.Equal(t, "r2", synced[2].snap.Resources[envoycachetypes.Route].Version)
+
+	gathered := metricstest.MustGatherMetrics(t)
+	gathered.AssertMetricsInclude("kgateway_xds_snapshot_pushes_total", []metricstest.ExpectMetric{
+		&metricstest.ExpectedMetric{
+			Labels: []metrics.Label{{Name: "type", Value: syncTypeEndpoints}},
+			Value:  1,
+		},
+		&metricstest.ExpectedMetric{
+			Labels: []metrics.Label{{Name: "type", Value: syncTypeFull}},
+			Value:  2,
+		},
+	})
+}
+
+func TestXdsDebouncerMaxWait(t *testing.T) {
+	setupTest()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rec := &recordingSyncer{}
+	maxWait := 200 * time.Millisecond
+	d := newXdsDebouncer(100*time.Millisecond, maxWait, rec.sync, nil)
+	go d.Run(ctx)
+
+	// keep sending events faster than the debounce window so that the window never elapses
+	start := time.Now()
+	for i := 0; time.Since(start) < 4*maxWait; i++ {
+		d.Enqueue(testSnap("r1", fmt.Sprintf("e%d", i)))
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	assert.GreaterOrEqual(t, len(rec.synced()), 2, "pending updates should be pushed once the max wait elapses")
+}
+
+func TestXdsDebouncerForget(t *testing.T) {
+	setupTest()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rec := &recordingSyncer{}
+	d := newXdsDebouncer(50*time.Millisecond, time.Second, rec.sync, nil)
+	go d.Run(ctx)
+
+	d.Enqueue(testSnap("r1", "e1"))
+	d.Forget(testProxyKey)
+
+	require.Never(t, func() bool {
+		return len(rec.synced()) > 0
+	}, 200*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestXdsDebouncerForgetKeepsSnapshotForReconnect(t *testing.T) {
+	setupTest()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	xdsCache := envoycache.NewSnapshotCache(true, envoycache.IDHash{}, nil)
+	translator := NewProxyTranslator(xdsCache)
+	d := newXdsDebouncer(5*time.Millisecond, time.Second, func(snap XdsSnapWrapper) {
+		translator.syncXds(ctx, snap)
+	}, translator.clearXds)
+	go d.Run(ctx)
+
+	d.Enqueue(testSnap("r1", "e1"))
+	require.Eventually(t, func() bool {
+		_, err := xdsCache.GetSnapshot(testProxyKey)
+		return err == nil
+	}, time.Second, time.Millisecond)
+
+	// the client disconnects and quickly reconnects while its Gateway still exists
+	d.Forget(testProxyKey)
+	snap, err := xdsCache.GetSnapshot(testProxyKey)
+	require.NoError(t, err, "the snapshot should be kept for the client to reconnect")
+	assert.Equal(t, "r1", snap.GetVersion(envoyresource.RouteType))
+
+	d.Enqueue(testSnap("r1", "e1"))
+	require.Never(t, func() bool {
+		snap, err := xdsCache.GetSnapshot(testProxyKey)
+		return err != nil || snap.GetVersion(envoyresource.RouteType) != "r1"
+	}, 100*time.Millisecond, 5*time.Millisecond, "the reconnected client should never see an empty cache entry")
+}
+
+func TestXdsDebouncerClearsDeletedGateways(t *testing.T) {
+	setupTest()
+
+	baseline := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	xdsCache := envoycache.NewSnapshotCache(true, envoycache.IDHash{}, nil)
+	translator := NewProxyTranslator(xdsCache)
+	d := newXdsDebouncer(5*time.Millisecond, time.Second, func(snap XdsSnapWrapper) {
+		translator.syncXds(ctx, snap)
+	}, translator.clearXds)
+	go d.Run(ctx)
+
+	keys := make([]string, 0, 200)
+	for i := range 100 {
+		gateway := fmt.Sprintf("kgateway-kube-gateway-api~default~gw-%d", i)
+		// the proxies of two rollouts of the gateway, with different pod-template-hash labels
+		var rollouts []string
+		for _, hash := range []string{"5d8f7c9b4", "7c6b5d4f8"} {
+			snap := testSnap("r1", "e1")
+			snap.proxyKey = fmt.Sprintf("%s~%s~default", gateway, hash)
+			rollouts = append(rollouts, snap.proxyKey)
+
+			d.Enqueue(snap)
+			require.Eventually(t, func() bool {
+				_, err := xdsCache.GetSnapshot(snap.proxyKey)
+				return err == nil
+			}, time.Second, time.Millisecond, "snapshot of %s should be pushed", snap.proxyKey)
+		}
+		keys = append(keys, rollouts...)
+
+		// the proxies of both rollouts disconnect before the gateway is deleted
+		for _, key := range rollouts {
+			d.Forget(key)
+		}
+		d.ClearGateway(gateway)
+	}
+
+	for _, key := range keys {
+		_, err := xdsCache.GetSnapshot(key)
+		assert.Error(t, err, "snapshot of %s should be cleared", key)
+	}
+	assert.Empty(t, xdsCache.GetStatusKeys())
+	d.mu.Lock()
+	assert.Empty(t, d.pending)
+	assert.Empty(t, d.synced)
+	assert.Empty(t, d.cached)
+	d.mu.Unlock()
+
+	cancel()
+	// not using Eventually, as it runs the condition in a goroutine of its own
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), baseline, "goroutines should return to baseline")
+}
+
+func TestXdsDebouncerIdle(t *testing.T) {
+	setupTest()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// block the push of the snapshot, to check that the debouncer isn't idle while it's flushing
+	pushing := make(chan struct{})
+	release := make(chan struct{})
+	rec := &recordingSyncer{}
+	d := newXdsDebouncer(50*time.Millisecond, time.Second, func(snap XdsSnapWrapper) {
+		close(pushing)
+		<-release
+		rec.sync(snap)
+	}, nil)
+	go d.Run(ctx)
+	assert.True(t, d.idle())
+
+	d.Enqueue(testSnap("r1", "e1"))
+	assert.False(t, d.idle(), "enqueued snapshots are pending")
+
+	<-pushing
+	assert.False(t, d.idle(), "snapshots being pushed are pending")
+
+	close(release)
+	require.Eventually(t, d.idle, time.Second, 10*time.Millisecond)
+	assert.Len(t, rec.synced(), 1)
+}
+
+func TestXdsDebouncerPaused(t *testing.T) {
+	for _, window := range []time.Duration{0, 50 * time.Millisecond} {
+		t.Run(fmt.Sprintf("window %s", window), func(t *testing.T) {
+			setupTest()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var paused atomic.Bool
+			rec := &recordingSyncer{}
+			d := newXdsDebouncer(window, time.Second, rec.sync, nil)
+			d.paused = paused.Load
+			go d.Run(ctx)
+
+			d.Enqueue(testSnap("r1", "e1"))
+			require.Eventually(t, func() bool {
+				return len(rec.synced()) == 1
+			}, time.Second, 10*time.Millisecond)
+
+			// the inputs go stale: the proxies keep the last pushed snapshot
+			paused.Store(true)
+			d.Enqueue(testSnap("r2", "e2"))
+			d.Enqueue(testSnap("r3", "e3"))
+			require.Never(t, func() bool {
+				return len(rec.synced()) > 1
+			}, 300*time.Millisecond, 10*time.Millisecond, "snapshots should be held back while paused")
+			assert.False(t, d.idle())
+
+			// the inputs caught up: the latest snapshot is pushed
+			paused.Store(false)
+			require.Eventually(t, func() bool {
+				return len(rec.synced()) == 2
+			}, time.Second, 10*time.Millisecond)
+			assert.Equal(t, "r3", rec.synced()[1].snap.Resources[envoycachetypes.Route].Version)
+			assert.True(t, d.idle())
+		})
+	}
+}
@@ -28,3 +28,9 @@ func (s *ProxyTranslator) syncXds(
 	// snap.MakeConsistent()
 	s.xdsCache.SetSnapshot(ctx, proxyKey, snap)
 }
+
+// clearXds removes the snapshot and the watches of a proxy that went away from the xDS cache.
+func (s *ProxyTranslator) clearXds(proxyKey string) {
+	logger.Debug("clearing xds snapshot", "proxy_key", proxyKey)
+	s.xdsCache.ClearSnapshot(proxyKey)
+}
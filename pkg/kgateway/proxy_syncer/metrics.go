@@ -8,14 +8,16 @@ import (
 )
 
 const (
-	statusSubsystem   = "status_syncer"
-	snapshotSubsystem = "xds_snapshot"
-	syncerNameLabel   = "syncer"
-	gatewayLabel      = "gateway"
-	nameLabel         = "name"
-	namespaceLabel    = "namespace"
-	resultLabel       = "result"
-	resourceLabel     = "resource"
+	statusSubsystem     = "status_syncer"
+	snapshotSubsystem   = "xds_snapshot"
+	syncerNameLabel     = "syncer"
+	gatewayLabel        = "gateway"
+	nameLabel           = "name"
+	namespaceLabel      = "namespace"
+	resultLabel         = "result"
+	resourceLabel       = "resource"
+	syncTypeLabelName   = "type"
+	collectionLabelName = "collection"
 )
 
 var (
@@ -70,8 +72,52 @@ var (
 		},
 		[]string{gatewayLabel, namespaceLabel, resourceLabel},
 	)
+	snapshotDebounceQueueDepth = metrics.NewGauge(
+		metrics.GaugeOpts{
+			Subsystem: snapshotSubsystem,
+			Name:      "debounce_queue_depth",
+			Help:      "Current number of XDS snapshots waiting to be pushed",
+		},
+		[]string{},
+	)
+	snapshotCoalescedEventsTotal = metrics.NewCounter(
+		metrics.CounterOpts{
+			Subsystem: snapshotSubsystem,
+			Name:      "coalesced_events_total",
+			Help:      "Total number of XDS snapshot updates superseded by a newer update before being pushed",
+		},
+		[]string{},
+	)
+	inputDebounceQueueDepth = metrics.NewGauge(
+		metrics.GaugeOpts{
+			Subsystem: snapshotSubsystem,
+			Name:      "input_debounce_queue_depth",
+			Help:      "Current number of changed translation inputs waiting to be translated, by input collection",
+		},
+		[]string{collectionLabelName},
+	)
+	inputCoalescedEventsTotal = metrics.NewCounter(
+		metrics.CounterOpts{
+			Subsystem: snapshotSubsystem,
+			Name:      "input_coalesced_events_total",
+			Help:      "Total number of translation input changes superseded by a newer change before being translated, by input collection",
+		},
+		[]string{collectionLabelName},
+	)
+	snapshotPushesTotal = metrics.NewCounter(
+		metrics.CounterOpts{
+			Subsystem: snapshotSubsystem,
+			Name:      "pushes_total",
+			Help:      "Total number of XDS snapshots pushed, by type of update",
+		},
+		[]string{syncTypeLabelName},
+	)
 )
 
+func syncTypeLabel(syncType string) metrics.Label {
+	return metrics.Label{Name: syncTypeLabelName, Value: syncType}
+}
+
 // snapshotResourcesMetricLabels defines the labels for XDS snapshot resources metrics.
 type snapshotResourcesMetricLabels struct {
 	Gateway   string
@@ -199,4 +245,9 @@ func ResetMetrics() {
 	snapshotTransformsTotal.Reset()
 	snapshotTransformDuration.Reset()
 	snapshotResources.Reset()
+	snapshotDebounceQueueDepth.Reset()
+	snapshotCoalescedEventsTotal.Reset()
+	snapshotPushesTotal.Reset()
+	inputDebounceQueueDepth.Reset()
+	inputCoalescedEventsTotal.Reset()
 }
@@ -2,18 +2,28 @@ package proxy_syncer
 
 import (
 	"context"
+	"time"
 
 	"github.com/kgateway-dev/kgateway/v2/pkg/reports"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/eventutils"
 )
 
 type statusSyncerConfig struct {
 	CustomStatusSync func(ctx context.Context, rm reports.ReportMap)
+
+	StatusWriteBurst    int
+	StatusWriteInterval time.Duration
+
+	EventRecorder *eventutils.RateLimitedRecorder
 }
 
 type StatusSyncerOption func(*statusSyncerConfig)
 
 func processStatusSyncerOptions(opts ...StatusSyncerOption) *statusSyncerConfig {
-	cfg := &statusSyncerConfig{}
+	cfg := &statusSyncerConfig{
+		StatusWriteBurst:    defaultStatusWriteBurst,
+		StatusWriteInterval: defaultStatusWriteInterval,
+	}
 	for _, fn := range opts {
 		fn(cfg)
 	}
@@ -27,3 +37,19 @@ func WithCustomStatusSync(customSync func(ctx context.Context, rm reports.Report
 		}
 	}
 }
+
+// WithStatusWriteRateLimit limits the status writes of each object to burst writes per interval.
+// A non-positive burst or interval disables the limit.
+func WithStatusWriteRateLimit(burst int, interval time.Duration) StatusSyncerOption {
+	return func(cfg *statusSyncerConfig) {
+		cfg.StatusWriteBurst = burst
+		cfg.StatusWriteInterval = interval
+	}
+}
+
+// WithEventRecorder emits Warning events on the Gateways and routes whose status reports a failure.
+func WithEventRecorder(recorder *eventutils.RateLimitedRecorder) StatusSyncerOption {
+	return func(cfg *statusSyncerConfig) {
+		cfg.EventRecorder = recorder
+	}
+}
@@ -52,6 +52,33 @@ func (c endpointsWithUccName) Equals(k endpointsWithUccName) bool {
 	return c.endpoints.Version == k.endpoints.Version && c.resourceName == k.resourceName
 }
 
+// clientConfig holds the snapshot resources of a client except its endpoints.
+type clientConfig struct {
+	// +noKrtEquals
+	resources [envoycachetypes.UnknownType]envoycache.Resources
+	// +noKrtEquals
+	erroredClusters []string
+	resourceName    string
+}
+
+func (c clientConfig) ResourceName() string {
+	return c.resourceName
+}
+
+var _ krt.Equaler[clientConfig] = new(clientConfig)
+
+func (c clientConfig) Equals(k clientConfig) bool {
+	if c.resourceName != k.resourceName {
+		return false
+	}
+	for i, r := range c.resources {
+		if r.Version != k.resources[i].Version {
+			return false
+		}
+	}
+	return true
+}
+
 func snapshotPerClient(
 	krtopts krtutil.KrtOptions,
 	uccCol krt.Collection[ir.UniqlyConnectedClient],
@@ -115,7 +142,9 @@ func snapshotPerClient(
 		}
 	}, krtopts.ToOptions("EndpointResources")...)
 
-	xdsSnapshotsForUcc := krt.NewCollection(uccCol, func(kctx krt.HandlerContext, ucc ir.UniqlyConnectedClient) *XdsSnapWrapper {
+	// the listeners, routes, clusters and secrets of a client don't depend on its endpoints, so that
+	// endpoint changes only rebuild the endpoints of the snapshot
+	configForUcc := krt.NewCollection(uccCol, func(kctx krt.HandlerContext, ucc ir.UniqlyConnectedClient) *clientConfig {
 		defer (collectXDSTransformMetrics(ucc.ResourceName()))(nil)
 
 		listenerRouteSnapshot := krt.FetchOne(kctx, mostXdsSnapshots, krt.FilterKey(ucc.Role))
@@ -124,7 +153,6 @@ func snapshotPerClient(
 			return nil
 		}
 		clustersForUcc := krt.FetchOne(kctx, clusterSnapshot, krt.FilterKey(ucc.ResourceName()))
-		clientEndpointResources := krt.FetchOne(kctx, endpointResources, krt.FilterKey(ucc.ResourceName()))
 
 		// HACK
 		// https://github.com/solo-io/gloo/pull/10611/files#diff-060acb7cdd3a287a3aef1dd864aae3e0193da17b6230c382b649ce9dc0eca80b
@@ -138,7 +166,7 @@ func snapshotPerClient(
 		// with that computation and will almost always lose.
 		// While we're looking for a way to make this ordering predictable
 		// to avoid hacks like this, it will do for now.
-		if clustersForUcc == nil || clientEndpointResources == nil {
+		if clustersForUcc == nil {
 			logger.Info("no perclient clusters; defer building snapshot", "client", ucc.ResourceName())
 			return nil
 		}
@@ -146,7 +174,6 @@ func snapshotPerClient(
 		logger.Debug("found perclient clusters", "client", ucc.ResourceName(), "clusters", len(clustersForUcc.clusters.Items))
 		clusterResources := clustersForUcc.clusters
 
-		snap := XdsSnapWrapper{}
 		if len(listenerRouteSnapshot.Clusters) > 0 {
 			clustersProto := make(map[string]envoycachetypes.ResourceWithTTL, len(listenerRouteSnapshot.Clusters)+len(clustersForUcc.clusters.Items))
 			maps.Copy(clustersProto, clustersForUcc.clusters.Items)
@@ -157,24 +184,40 @@ func snapshotPerClient(
 			clusterResources.Items = clustersProto
 		}
 
-		snap.erroredClusters = clustersForUcc.erroredClusters
-		snap.proxyKey = ucc.ResourceName()
-		snapshot := &envoycache.Snapshot{}
-		snapshot.Resources[envoycachetypes.Cluster] = clusterResources
+		config := &clientConfig{
+			erroredClusters: clustersForUcc.erroredClusters,
+			resourceName:    ucc.ResourceName(),
+		}
+		config.resources[envoycachetypes.Cluster] = clusterResources
+		config.resources[envoycachetypes.Route] = listenerRouteSnapshot.Routes
+		config.resources[envoycachetypes.Listener] = listenerRouteSnapshot.Listeners
+		config.resources[envoycachetypes.Secret] = listenerRouteSnapshot.Secrets
+		return config
+	}, krtopts.ToOptions("PerClientXdsConfig")...)
+
+	xdsSnapshotsForUcc := krt.NewCollection(uccCol, func(kctx krt.HandlerContext, ucc ir.UniqlyConnectedClient) *XdsSnapWrapper {
+		config := krt.FetchOne(kctx, configForUcc, krt.FilterKey(ucc.ResourceName()))
+		clientEndpointResources := krt.FetchOne(kctx, endpointResources, krt.FilterKey(ucc.ResourceName()))
+		if config == nil || clientEndpointResources == nil {
+			return nil
+		}
+
+		snap := XdsSnapWrapper{
+			erroredClusters: config.erroredClusters,
+			proxyKey:        ucc.ResourceName(),
+		}
+		// the resources are shared with the config, only the endpoints are set
+		snapshot := &envoycache.Snapshot{Resources: config.resources}
 		// Exclude CLAs for STATIC clusters so ADS snapshot only contains resources Envoy will request.
-		endpointRes := filterEndpointResourcesForStaticClusters(clusterResources, clientEndpointResources.endpoints)
+		endpointRes := filterEndpointResourcesForStaticClusters(config.resources[envoycachetypes.Cluster], clientEndpointResources.endpoints)
 		snapshot.Resources[envoycachetypes.Endpoint] = endpointRes
-		snapshot.Resources[envoycachetypes.Route] = listenerRouteSnapshot.Routes
-		snapshot.Resources[envoycachetypes.Listener] = listenerRouteSnapshot.Listeners
-		snapshot.Resources[envoycachetypes.Secret] = listenerRouteSnapshot.Secrets
-		// envoycache.NewResources(version, resource)
 		snap.snap = snapshot
 		logger.Debug("snapshots", "proxy_key", snap.proxyKey,
-			"listeners", resourcesStringer(listenerRouteSnapshot.Listeners).String(),
-			"clusters", resourcesStringer(clusterResources).String(),
-			"routes", resourcesStringer(listenerRouteSnapshot.Routes).String(),
+			"listeners", resourcesStringer(snapshot.Resources[envoycachetypes.Listener]).String(),
+			"clusters", resourcesStringer(snapshot.Resources[envoycachetypes.Cluster]).String(),
+			"routes", resourcesStringer(snapshot.Resources[envoycachetypes.Route]).String(),
 			"endpoints", resourcesStringer(endpointRes).String(),
-			"secrets", resourcesStringer(listenerRouteSnapshot.Secrets).String(),
+			"secrets", resourcesStringer(snapshot.Resources[envoycachetypes.Secret]).String(),
 		)
 
 		return &snap
@@ -1,12 +1,26 @@
 package proxy_syncer
 
 import (
+	"context"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoyendpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	envoycachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"istio.io/istio/pkg/kube/krt"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics/metricstest"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
+	krtpkg "github.com/kgateway-dev/kgateway/v2/pkg/utils/krtutil"
 )
 
 func TestFilterEndpointResourcesForStaticClusters_FiltersStaticClusterCLAs(t *testing.T) {
@@ -131,6 +145,114 @@ func TestFilterEndpointResourcesForStaticClusters_MixedStaticAndNonStatic(t *tes
 	}
 }
 
+// testEndpoints returns the endpoints of the test backend with a single endpoint listening on the given port.
+func testEndpoints(port uint32) ir.EndpointsForBackend {
+	eps := ir.NewEndpointsForBackend(ir.BackendObjectIR{
+		ObjectSource: ir.ObjectSource{Namespace: "default", Name: "svc"},
+	})
+	eps.Add(ir.PodLocality{}, ir.EndpointWithMd{
+		LbEndpoint: &envoyendpointv3.LbEndpoint{
+			HostIdentifier: &envoyendpointv3.LbEndpoint_Endpoint{
+				Endpoint: &envoyendpointv3.Endpoint{
+					Address: &envoycorev3.Address{
+						Address: &envoycorev3.Address_SocketAddress{SocketAddress: &envoycorev3.SocketAddress{
+							Address:       "10.0.0.1",
+							PortSpecifier: &envoycorev3.SocketAddress_PortValue{PortValue: port},
+						}},
+					},
+				},
+			},
+		},
+	})
+	return *eps
+}
+
+func TestSnapshotPerClientCoalescesEndpointTranslations(t *testing.T) {
+	setupTest()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	krtOpts := krtutil.NewKrtOptions(ctx.Done(), nil)
+
+	gw := GatewayXdsResources{
+		NamespacedName: types.NamespacedName{Namespace: "default", Name: "example-gateway"},
+		Routes:         envoycache.NewResources("r1", nil),
+		Listeners:      envoycache.NewResources("l1", nil),
+		Secrets:        envoycache.NewResources("s1", nil),
+	}
+	ucc := ir.NewUniqlyConnectedClient(gw.ResourceName(), "", nil, ir.PodLocality{})
+	uccs := krt.NewStaticCollection(nil, []ir.UniqlyConnectedClient{ucc}, krtOpts.ToOptions("Uccs")...)
+	gws := krt.NewStaticCollection(nil, []GatewayXdsResources{gw}, krtOpts.ToOptions("Gateways")...)
+
+	clusters := krt.NewStaticCollection(nil, []uccWithCluster{{
+		Client:         ucc,
+		Cluster:        &envoyclusterv3.Cluster{Name: "svc", ClusterDiscoveryType: &envoyclusterv3.Cluster_Type{Type: envoyclusterv3.Cluster_EDS}},
+		ClusterVersion: 1,
+		Name:           "svc",
+	}}, krtOpts.ToOptions("Clusters")...)
+	perClientClusters := PerClientEnvoyClusters{
+		clusters: clusters,
+		index: krtpkg.UnnamedIndex(clusters, func(c uccWithCluster) []string {
+			return []string{c.Client.ResourceName()}
+		}),
+	}
+
+	endpoints := krt.NewStaticCollection(nil, []ir.EndpointsForBackend{testEndpoints(0)}, krtOpts.ToOptions("Endpoints")...)
+	var translations atomic.Int32
+	perClientEndpoints := NewPerClientEnvoyEndpoints(
+		krtOpts,
+		uccs,
+		newDebouncedCollection(ctx, endpoints, "Endpoints", 100*time.Millisecond, 5*time.Second, krtOpts.ToOptions("DebouncedEndpoints")...),
+		func(_ krt.HandlerContext, _ ir.UniqlyConnectedClient, ep ir.EndpointsForBackend) (*envoyendpointv3.ClusterLoadAssignment, uint64) {
+			translations.Add(1)
+			return &envoyendpointv3.ClusterLoadAssignment{ClusterName: ep.ClusterName}, 0
+		},
+	)
+
+	snaps := snapshotPerClient(krtOpts, uccs, gws, perClientEndpoints, perClientClusters)
+	require.True(t, snaps.WaitUntilSynced(ctx.Done()))
+	var snapUpdates atomic.Int32
+	snaps.RegisterBatch(func([]krt.Event[XdsSnapWrapper]) {
+		snapUpdates.Add(1)
+	}, false)
+
+	require.NotNil(t, snaps.GetKey(ucc.ResourceName()))
+	initial := snaps.GetKey(ucc.ResourceName()).snap
+	require.Equal(t, int32(1), translations.Load())
+
+	// a burst of endpoint changes, e.g. while rolling the backend
+	const numEvents = 20
+	for i := 1; i <= numEvents; i++ {
+		endpoints.UpdateObject(testEndpoints(uint32(i))) //nolint:gosec // G115: test port is always in range
+	}
+
+	require.Eventually(t, func() bool {
+		return snapUpdates.Load() > 0
+	}, 2*time.Second, 10*time.Millisecond)
+	require.Never(t, func() bool {
+		return snapUpdates.Load() > 1
+	}, 300*time.Millisecond, 10*time.Millisecond)
+
+	// the burst is translated once, and only the endpoints of the snapshot are rebuilt
+	assert.Equal(t, int32(2), translations.Load())
+	updated := snaps.GetKey(ucc.ResourceName()).snap
+	assert.NotEqual(t, initial.Resources[envoycachetypes.Endpoint].Version, updated.Resources[envoycachetypes.Endpoint].Version)
+	assert.Equal(t, "r1", updated.Resources[envoycachetypes.Route].Version)
+	assert.Equal(t, initial.Resources[envoycachetypes.Cluster].Version, updated.Resources[envoycachetypes.Cluster].Version)
+
+	gathered := metricstest.MustGatherMetrics(t)
+	gathered.AssertMetricsInclude("kgateway_xds_snapshot_transforms_total", []metricstest.ExpectMetric{
+		&metricstest.ExpectedMetric{
+			Labels: []metrics.Label{
+				{Name: gatewayLabel, Value: "example-gateway"},
+				{Name: namespaceLabel, Value: "default"},
+				{Name: resultLabel, Value: "success"},
+			},
+			Value: 1,
+		},
+	})
+}
+
 func mapKeys[M ~map[K]V, K comparable, V any](m M) []K {
 	keys := make([]K, 0, len(m))
 	for k := range m {
@@ -5,19 +5,24 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"slices"
 	"sync/atomic"
+	"time"
 
 	envoycachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
 	"google.golang.org/protobuf/proto"
 	"istio.io/istio/pkg/kube/controllers"
 	"istio.io/istio/pkg/kube/krt"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/health"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/translator"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/translator/irtranslator"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
@@ -30,6 +35,7 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 	krtutil "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
 	"github.com/kgateway-dev/kgateway/v2/pkg/reports"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/eventutils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/validator"
 )
 
@@ -51,6 +57,12 @@ type ProxySyncer struct {
 
 	apiClient       apiclient.Client
 	proxyTranslator ProxyTranslator
+	// proxyStatus tracks the snapshots applied by the proxies, so that pushes can be ordered
+	proxyStatus *xds.ProxyStatusTracker
+	// inputStaleness tells when the inputs of the translation are stale, while which snapshots are held back
+	inputStaleness *health.InputStalenessTracker
+	// recorder emits Warning events on the Gateways whose snapshots are held back
+	recorder *eventutils.RateLimitedRecorder
 
 	uniqueClients krt.Collection[ir.UniqlyConnectedClient]
 
@@ -59,8 +71,12 @@ type ProxySyncer struct {
 	mostXdsSnapshots        krt.Collection[GatewayXdsResources]
 	perclientSnapCollection krt.Collection[XdsSnapWrapper]
 
+	syncChecks  []health.Check
 	waitForSync []cache.InformerSynced
 	ready       atomic.Bool
+	// initialSnapshotsPushed is set once the snapshots of all the clients known when the caches synced
+	// were pushed to the xDS cache
+	initialSnapshotsPushed atomic.Bool
 
 	reportQueue              utils.AsyncQueue[reports.ReportMap]
 	backendPolicyReportQueue utils.AsyncQueue[reports.ReportMap]
@@ -143,6 +159,9 @@ func NewProxySyncer(
 	mergedPlugins plug.Plugin,
 	commonCols *collections.CommonCollections,
 	xdsCache envoycache.SnapshotCache,
+	proxyStatus *xds.ProxyStatusTracker,
+	inputStaleness *health.InputStalenessTracker,
+	recorder *eventutils.RateLimitedRecorder,
 	validator validator.Validator,
 ) *ProxySyncer {
 	return &ProxySyncer{
@@ -151,6 +170,9 @@ func NewProxySyncer(
 		mgr:                      mgr,
 		apiClient:                client,
 		proxyTranslator:          NewProxyTranslator(xdsCache),
+		proxyStatus:              proxyStatus,
+		inputStaleness:           inputStaleness,
+		recorder:                 recorder,
 		uniqueClients:            uniqueClients,
 		translator:               translator.NewCombinedTranslator(ctx, mergedPlugins, commonCols, validator),
 		plugins:                  mergedPlugins,
@@ -233,10 +255,20 @@ func (s *ProxySyncer) Init(ctx context.Context, krtopts krtutil.KrtOptions) {
 		return toResources(gw, *xdsSnap, rm)
 	}, krtopts.ToOptions("MostXdsSnapshots")...)
 
+	// endpoint changes are coalesced before they are translated, so that bursts of EndpointSlice
+	// events (e.g. while rolling a large backend) are translated once per backend
+	endpoints := newDebouncedCollection(
+		ctx,
+		s.commonCols.Endpoints,
+		"Endpoints",
+		s.commonCols.Settings.XdsDebounceWindow,
+		s.commonCols.Settings.XdsDebounceMaxWait,
+		krtopts.ToOptions("DebouncedEndpoints")...,
+	)
 	epPerClient := NewPerClientEnvoyEndpoints(
 		krtopts,
 		s.uniqueClients,
-		s.commonCols.Endpoints,
+		endpoints,
 		s.translator.TranslateEndpoints,
 	)
 	clustersPerClient := NewPerClientEnvoyClusters(
@@ -279,6 +311,16 @@ func (s *ProxySyncer) Init(ctx context.Context, krtopts krtutil.KrtOptions) {
 		objStatus := krt.Fetch(kctx, s.commonCols.Routes.GetHTTPRouteStatusMarkers())
 		s.commonCols.Routes.ProcessHTTPRouteStatusMarkers(objStatus, merged)
 
+		// Report policies targeting Gateways that do not exist, since no translation will report on them
+		gatewayExists := func(nn types.NamespacedName) bool {
+			return krt.FetchOne(kctx, s.commonCols.GatewayIndex.KubeGateways, krt.FilterObjectName(nn)) != nil
+		}
+		for _, plugin := range s.plugins.ContributesPolicies {
+			if plugin.Policies != nil && plugin.ProcessBackend == nil {
+				reportPolicyTargetsNotFound(krt.Fetch(kctx, plugin.Policies), gatewayExists, &merged)
+			}
+		}
+
 		for _, plugin := range s.plugins.ContributesPolicies {
 			if plugin.ProcessPolicyStaleStatusMarkers != nil && plugin.ProcessBackend == nil {
 				plugin.ProcessPolicyStaleStatusMarkers(kctx, &merged)
@@ -288,13 +330,16 @@ func (s *ProxySyncer) Init(ctx context.Context, krtopts krtutil.KrtOptions) {
 		return &report{merged}
 	})
 
-	s.waitForSync = []cache.InformerSynced{
-		s.commonCols.HasSynced,
-		finalBackends.HasSynced,
-		s.perclientSnapCollection.HasSynced,
-		s.mostXdsSnapshots.HasSynced,
-		s.plugins.HasSynced,
-		s.translator.HasSynced,
+	s.syncChecks = append(s.commonCols.SyncChecks(),
+		health.Check{Name: "FinalBackends", Synced: finalBackends.HasSynced},
+		health.Check{Name: "PerClientXdsSnapshots", Synced: s.perclientSnapCollection.HasSynced},
+		health.Check{Name: "GatewayXdsSnapshots", Synced: s.mostXdsSnapshots.HasSynced},
+		health.Check{Name: "Plugins", Synced: s.plugins.HasSynced},
+		health.Check{Name: "Translator", Synced: s.translator.HasSynced},
+	)
+	s.waitForSync = make([]cache.InformerSynced, 0, len(s.syncChecks))
+	for _, check := range s.syncChecks {
+		s.waitForSync = append(s.waitForSync, check.Synced)
 	}
 }
 
@@ -398,7 +443,8 @@ func (s *ProxySyncer) Start(ctx context.Context) error {
 	// when timer ticks, we will use the state of the mergedReports at that point in time to sync the status to k8s
 	s.statusReport.Register(func(o krt.Event[report]) {
 		if o.Event == controllers.EventDelete {
-			// TODO: handle garbage collection
+			// nothing is reported on anymore, so the statuses of the previously reported routes are removed
+			s.reportQueue.Enqueue(reports.NewReportMap())
 			return
 		}
 		s.reportQueue.Enqueue(o.Latest().reportMap)
@@ -411,35 +457,123 @@ func (s *ProxySyncer) Start(ctx context.Context) error {
 		s.backendPolicyReportQueue.Enqueue(o.Latest().reportMap)
 	})
 
-	s.perclientSnapCollection.RegisterBatch(func(o []krt.Event[XdsSnapWrapper]) {
-		for _, e := range o {
-			cd := getDetailsFromXDSClientResourceName(e.Latest().ResourceName())
+	debouncer := s.newXdsDebouncer(ctx)
+	go debouncer.Run(ctx)
 
+	// the snapshots of the clients that went away are kept in the xDS cache until their Gateway is deleted
+	s.mostXdsSnapshots.Register(func(e krt.Event[GatewayXdsResources]) {
+		if e.Event == controllers.EventDelete {
+			debouncer.ClearGateway(e.Latest().ResourceName())
+		}
+	})
+
+	snapshotsRegistration := s.perclientSnapCollection.RegisterBatch(func(o []krt.Event[XdsSnapWrapper]) {
+		for _, e := range o {
 			if e.Event != controllers.EventDelete {
-				snapWrap := e.Latest()
-				s.proxyTranslator.syncXds(ctx, snapWrap)
+				debouncer.Enqueue(e.Latest())
 			} else {
-				// key := e.Latest().proxyKey
-				// if _, err := s.proxyTranslator.xdsCache.GetSnapshot(key); err == nil {
-				// 	s.proxyTranslator.xdsCache.ClearSnapshot(e.Latest().proxyKey)
-				// }
+				cd := getDetailsFromXDSClientResourceName(e.Latest().ResourceName())
+				if s.mostXdsSnapshots.GetKey(xds.OwnerNamespaceNameID(cd.Role, cd.Namespace, cd.Gateway)) == nil {
+					// the gateway was deleted, so its snapshot would otherwise stay in the xDS cache
+					// until the control plane restarts
+					debouncer.Clear(e.Latest().proxyKey)
+				} else {
+					// the client disconnected, and keeps getting its snapshot right away if it reconnects
+					debouncer.Forget(e.Latest().proxyKey)
+				}
+
+				kmetrics.EndResourceXDSSync(kmetrics.ResourceSyncDetails{
+					Namespace:    cd.Namespace,
+					Gateway:      cd.Gateway,
+					ResourceName: cd.Gateway,
+				})
 			}
+		}
+	}, true)
+
+	// proxies connecting right after a restart must not get partial config, so the syncer is only
+	// ready once the initial snapshots were pushed to the xDS cache rather than just enqueued
+	go func() {
+		if !snapshotsRegistration.WaitUntilSynced(ctx.Done()) {
+			return
+		}
+		err := wait.PollUntilContextCancel(ctx, initialPushPollInterval, true, func(context.Context) (bool, error) {
+			return debouncer.idle(), nil
+		})
+		if err == nil {
+			logger.Info("initial xds snapshots pushed")
+			s.initialSnapshotsPushed.Store(true)
+		}
+	}()
 
+	s.ready.Store(true)
+	<-ctx.Done()
+	return nil
+}
+
+const (
+	initialPushPollInterval = 50 * time.Millisecond
+
+	// xdsUpdatesHeldBackReason is the reason of the events emitted on the Gateways whose snapshots are
+	// held back while the inputs are stale
+	xdsUpdatesHeldBackReason = "XDSUpdatesHeldBack"
+)
+
+// newXdsDebouncer returns the debouncer pushing the snapshots of the clients to the xDS cache.
+func (s *ProxySyncer) newXdsDebouncer(ctx context.Context) *xdsDebouncer {
+	// snapshot updates are coalesced before being pushed to the xDS cache so that bursts of
+	// input events don't result in a push per event
+	debouncer := newXdsDebouncer(
+		s.commonCols.Settings.XdsDebounceWindow,
+		s.commonCols.Settings.XdsDebounceMaxWait,
+		func(snapWrap XdsSnapWrapper) {
+			s.proxyTranslator.syncXds(ctx, snapWrap)
+
+			cd := getDetailsFromXDSClientResourceName(snapWrap.ResourceName())
 			kmetrics.EndResourceXDSSync(kmetrics.ResourceSyncDetails{
 				Namespace:    cd.Namespace,
 				Gateway:      cd.Gateway,
 				ResourceName: cd.Gateway,
 			})
+		},
+		s.proxyTranslator.clearXds,
+	)
+	if s.proxyStatus != nil {
+		// new clusters are warmed before routes reference them, and removed clusters are kept until
+		// routes no longer reference them
+		debouncer.applied = func(proxyKey string, snap *envoycache.Snapshot) bool {
+			return s.proxyStatus.Applied(proxyKey, snap)
 		}
-	}, true)
-
-	s.ready.Store(true)
-	<-ctx.Done()
-	return nil
+	}
+	if s.inputStaleness != nil {
+		// proxies keep the last good snapshot while the translation runs against stale inputs
+		debouncer.paused = s.inputStaleness.Stale
+		debouncer.onHold = func(proxyKey string) {
+			cd := getDetailsFromXDSClientResourceName(proxyKey)
+			s.recorder.Warningf(&corev1.ObjectReference{
+				APIVersion: wellknown.GatewayGVK.GroupVersion().String(),
+				Kind:       wellknown.GatewayKind,
+				Namespace:  cd.Namespace,
+				Name:       cd.Gateway,
+			}, xdsUpdatesHeldBackReason, "Config updates are held back while the informers of the controller lag the API server: %v",
+				s.inputStaleness.Status().Lags)
+		}
+	}
+	return debouncer
 }
 
+// HasSynced returns true once all collections synced and the initial xDS snapshots were pushed.
 func (s *ProxySyncer) HasSynced() bool {
-	return s.ready.Load()
+	return s.ready.Load() && s.initialSnapshotsPushed.Load()
+}
+
+// SyncChecks returns the checks the syncer waits for before it is ready, including the initial push
+// of the xDS snapshots.
+func (s *ProxySyncer) SyncChecks() []health.Check {
+	return append(slices.Clone(s.syncChecks),
+		health.Check{Name: "ProxySyncer", Synced: s.ready.Load},
+		health.Check{Name: "InitialXdsPush", Synced: s.initialSnapshotsPushed.Load},
+	)
 }
 
 // NeedLeaderElection returns false to ensure that the proxySyncer runs on all pods (leader and followers)
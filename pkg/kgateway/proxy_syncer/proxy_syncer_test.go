@@ -1,16 +1,27 @@
 package proxy_syncer
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
 
+	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	envoyresource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
+	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/health"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/reporter"
 	"github.com/kgateway-dev/kgateway/v2/pkg/reports"
+	"github.com/kgateway-dev/kgateway/v2/pkg/schemes"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/eventutils"
 )
 
 func TestIsGatewayStatusEqual(t *testing.T) {
@@ -368,3 +379,79 @@ func TestIsListenerSetStatusEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestProxySyncerHoldsBackSnapshotsWhileInputsAreStale(t *testing.T) {
+	setupTest()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	serverVersion, informerVersion := "10", "10"
+	var mu sync.Mutex
+	setVersions := func(server, informer string) {
+		mu.Lock()
+		defer mu.Unlock()
+		serverVersion, informerVersion = server, informer
+	}
+	// any lag is beyond the threshold, so the inputs are stale after two probes the informer didn't reach
+	inputs := health.NewInputStalenessTracker(time.Nanosecond,
+		func(context.Context) (string, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return serverVersion, nil
+		},
+		func() map[string]string {
+			mu.Lock()
+			defer mu.Unlock()
+			return map[string]string{"gateways": informerVersion}
+		},
+	)
+
+	xdsCache := envoycache.NewSnapshotCache(true, envoycache.IDHash{}, nil)
+	recorder := &eventutils.FakeRecorder{}
+	s := &ProxySyncer{
+		commonCols:      &collections.CommonCollections{Settings: apisettings.Settings{XdsDebounceWindow: 5 * time.Millisecond}},
+		proxyTranslator: NewProxyTranslator(xdsCache),
+		inputStaleness:  inputs,
+		recorder:        eventutils.NewRateLimitedRecorder(recorder, schemes.DefaultScheme(), time.Minute),
+	}
+	debouncer := s.newXdsDebouncer(ctx)
+	go debouncer.Run(ctx)
+
+	routeVersion := func() string {
+		snap, err := xdsCache.GetSnapshot(testProxyKey)
+		if err != nil {
+			return ""
+		}
+		return snap.GetVersion(envoyresource.RouteType)
+	}
+
+	debouncer.Enqueue(testSnap("r1", "e1"))
+	require.Eventually(t, func() bool { return routeVersion() == "r1" }, time.Second, time.Millisecond)
+
+	// the informer stops making progress while the api server moves on
+	setVersions("20", "10")
+	inputs.Check(ctx)
+	time.Sleep(time.Millisecond)
+	inputs.Check(ctx)
+	require.True(t, inputs.Stale())
+
+	debouncer.Enqueue(testSnap("r2", "e1"))
+	require.Never(t, func() bool { return routeVersion() != "r1" }, 200*time.Millisecond, 5*time.Millisecond,
+		"the proxies should keep the last good snapshot while the inputs are stale")
+
+	events := recorder.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, xdsUpdatesHeldBackReason, events[0].Reason)
+	assert.Equal(t, wellknown.GatewayKind, events[0].InvolvedObject.Kind)
+	assert.Equal(t, "default", events[0].InvolvedObject.Namespace)
+	assert.Equal(t, "example-gateway", events[0].InvolvedObject.Name)
+	assert.Contains(t, events[0].Message, "gateways")
+
+	// the informer catches up
+	setVersions("20", "20")
+	inputs.Check(ctx)
+	require.False(t, inputs.Stale())
+	require.Eventually(t, func() bool { return routeVersion() == "r2" }, time.Second, time.Millisecond,
+		"the latest snapshot should be pushed once the inputs caught up")
+}
@@ -0,0 +1,94 @@
+package proxy_syncer
+
+import (
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"slices"
+
+	envoycachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+)
+
+// nextSnapshotStep returns the snapshot to push to move a client from the current snapshot towards
+// the target one without referencing clusters the proxy doesn't have warm:
+//   - clusters added by the target are pushed with their endpoints first, along with the current
+//     listeners and routes, so that the proxy warms them before any route references them;
+//   - clusters removed by the target are kept until the proxy applied the listeners and routes
+//     that no longer reference them.
+//
+// The target is returned when it can be pushed as is. Otherwise the returned snapshot must be
+// applied by the proxy before asking for the next step.
+func nextSnapshotStep(current, target *envoycache.Snapshot) *envoycache.Snapshot {
+	if !listenersOrRoutesChanged(current, target) {
+		return target
+	}
+	curClusters := current.Resources[envoycachetypes.Cluster].Items
+	targetClusters := target.Resources[envoycachetypes.Cluster].Items
+	added := hasItemsNotIn(targetClusters, curClusters)
+	removed := hasItemsNotIn(curClusters, targetClusters)
+	if !added && !removed {
+		return target
+	}
+
+	step := &envoycache.Snapshot{}
+	step.Resources[envoycachetypes.Cluster] = mergeResources(current.Resources[envoycachetypes.Cluster], target.Resources[envoycachetypes.Cluster])
+	step.Resources[envoycachetypes.Endpoint] = mergeResources(current.Resources[envoycachetypes.Endpoint], target.Resources[envoycachetypes.Endpoint])
+	if added {
+		// the secrets of the new clusters are needed to warm them
+		step.Resources[envoycachetypes.Secret] = mergeResources(current.Resources[envoycachetypes.Secret], target.Resources[envoycachetypes.Secret])
+		step.Resources[envoycachetypes.Listener] = current.Resources[envoycachetypes.Listener]
+		step.Resources[envoycachetypes.Route] = current.Resources[envoycachetypes.Route]
+	} else {
+		step.Resources[envoycachetypes.Secret] = target.Resources[envoycachetypes.Secret]
+		step.Resources[envoycachetypes.Listener] = target.Resources[envoycachetypes.Listener]
+		step.Resources[envoycachetypes.Route] = target.Resources[envoycachetypes.Route]
+	}
+	return step
+}
+
+func listenersOrRoutesChanged(current, target *envoycache.Snapshot) bool {
+	return current.Resources[envoycachetypes.Listener].Version != target.Resources[envoycachetypes.Listener].Version ||
+		current.Resources[envoycachetypes.Route].Version != target.Resources[envoycachetypes.Route].Version
+}
+
+// hasItemsNotIn returns true if a has an item whose name isn't in b.
+func hasItemsNotIn(a, b map[string]envoycachetypes.ResourceWithTTL) bool {
+	for name := range a {
+		if _, ok := b[name]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeResources returns the resources of the target along with the resources of the current
+// snapshot the target doesn't have. The version is derived from the version of the target and the
+// names of the resources kept from the current snapshot, so that it only changes along with them.
+func mergeResources(current, target envoycache.Resources) envoycache.Resources {
+	var kept []string
+	for name := range current.Items {
+		if _, ok := target.Items[name]; !ok {
+			kept = append(kept, name)
+		}
+	}
+	if len(kept) == 0 {
+		return target
+	}
+
+	items := maps.Clone(target.Items)
+	if items == nil {
+		items = make(map[string]envoycachetypes.ResourceWithTTL, len(kept))
+	}
+	h := fnv.New64a()
+	slices.Sort(kept)
+	for _, name := range kept {
+		items[name] = current.Items[name]
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+	}
+	return envoycache.Resources{
+		Version: fmt.Sprintf("%s-%x", target.Version, h.Sum64()),
+		Items:   items,
+	}
+}
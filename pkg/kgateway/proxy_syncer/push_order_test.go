@@ -0,0 +1,295 @@
+package proxy_syncer
+
+import (
+	"context"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoyendpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	envoycachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	xdsserver "github.com/envoyproxy/go-control-plane/pkg/server/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/xds"
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics/metricstest"
+)
+
+// orderTestSnap returns a snapshot with an EDS cluster for each backend, and a route to the routed backends.
+func orderTestSnap(backends []string, routed []string) XdsSnapWrapper {
+	clusters := make([]envoycachetypes.Resource, 0, len(backends))
+	clas := make([]envoycachetypes.Resource, 0, len(backends))
+	for _, b := range backends {
+		clusters = append(clusters, &envoyclusterv3.Cluster{
+			Name:                 b,
+			ClusterDiscoveryType: &envoyclusterv3.Cluster_Type{Type: envoyclusterv3.Cluster_EDS},
+		})
+		clas = append(clas, &envoyendpointv3.ClusterLoadAssignment{ClusterName: b})
+	}
+	weighted := &envoyroutev3.WeightedCluster{}
+	for _, b := range routed {
+		weighted.Clusters = append(weighted.Clusters, &envoyroutev3.WeightedCluster_ClusterWeight{Name: b})
+	}
+	route := &envoyroutev3.RouteConfiguration{
+		Name: "route",
+		VirtualHosts: []*envoyroutev3.VirtualHost{{
+			Name:    "vhost",
+			Domains: []string{"*"},
+			Routes: []*envoyroutev3.Route{{
+				Action: &envoyroutev3.Route_Route{Route: &envoyroutev3.RouteAction{
+					ClusterSpecifier: &envoyroutev3.RouteAction_WeightedClusters{WeightedClusters: weighted},
+				}},
+			}},
+		}},
+	}
+
+	snap := &envoycache.Snapshot{}
+	snap.Resources[envoycachetypes.Cluster] = envoycache.NewResources(strings.Join(backends, ","), clusters)
+	snap.Resources[envoycachetypes.Endpoint] = envoycache.NewResources(strings.Join(backends, ","), clas)
+	snap.Resources[envoycachetypes.Listener] = envoycache.NewResources("l1", []envoycachetypes.Resource{&envoylistenerv3.Listener{Name: "listener"}})
+	snap.Resources[envoycachetypes.Route] = envoycache.NewResources(strings.Join(routed, ","), []envoycachetypes.Resource{route})
+	return XdsSnapWrapper{snap: snap, proxyKey: testProxyKey}
+}
+
+type adsResponse struct {
+	typeURL   string
+	resources []string
+}
+
+// mockADSClient subscribes to clusters, endpoints, listeners and routes like Envoy does: endpoints
+// are requested for the clusters it got, and routes for the listeners it got. It acks every response.
+type mockADSClient struct {
+	stream discoveryv3.AggregatedDiscoveryService_StreamAggregatedResourcesClient
+
+	mu        sync.Mutex
+	responses []adsResponse
+}
+
+func newMockADSClient(t *testing.T, ctx context.Context, conn *grpc.ClientConn) *mockADSClient {
+	t.Helper()
+	stream, err := discoveryv3.NewAggregatedDiscoveryServiceClient(conn).StreamAggregatedResources(ctx)
+	require.NoError(t, err)
+	c := &mockADSClient{stream: stream}
+	node := &envoycorev3.Node{
+		Id: "gw-pod",
+		Metadata: &structpb.Struct{Fields: map[string]*structpb.Value{
+			xds.RoleKey: structpb.NewStringValue(testProxyKey),
+		}},
+	}
+	require.NoError(t, stream.Send(&discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resource.ClusterType}))
+	require.NoError(t, stream.Send(&discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resource.ListenerType}))
+	go c.run()
+	return c
+}
+
+func (c *mockADSClient) run() {
+	var edsVersion, edsNonce string
+	for {
+		resp, err := c.stream.Recv()
+		if err != nil {
+			return
+		}
+		var names []string
+		for _, r := range resp.GetResources() {
+			msg, err := r.UnmarshalNew()
+			if err != nil {
+				return
+			}
+			names = append(names, envoycache.GetResourceName(msg))
+		}
+		slices.Sort(names)
+		c.mu.Lock()
+		c.responses = append(c.responses, adsResponse{typeURL: resp.GetTypeUrl(), resources: names})
+		c.mu.Unlock()
+
+		var sendErr error
+		ack := &discoveryv3.DiscoveryRequest{
+			VersionInfo:   resp.GetVersionInfo(),
+			ResponseNonce: resp.GetNonce(),
+			TypeUrl:       resp.GetTypeUrl(),
+		}
+		switch resp.GetTypeUrl() {
+		case resource.ClusterType:
+			sendErr = c.stream.Send(ack)
+			if sendErr == nil {
+				// request the endpoints of the new clusters
+				sendErr = c.stream.Send(&discoveryv3.DiscoveryRequest{
+					VersionInfo:   edsVersion,
+					ResponseNonce: edsNonce,
+					TypeUrl:       resource.EndpointType,
+					ResourceNames: names,
+				})
+			}
+		case resource.EndpointType:
+			edsVersion, edsNonce = resp.GetVersionInfo(), resp.GetNonce()
+			ack.ResourceNames = names
+			sendErr = c.stream.Send(ack)
+		case resource.ListenerType:
+			sendErr = c.stream.Send(ack)
+			if sendErr == nil {
+				sendErr = c.stream.Send(&discoveryv3.DiscoveryRequest{TypeUrl: resource.RouteType, ResourceNames: []string{"route"}})
+			}
+		case resource.RouteType:
+			ack.ResourceNames = []string{"route"}
+			sendErr = c.stream.Send(ack)
+		}
+		if sendErr != nil {
+			return
+		}
+	}
+}
+
+func (c *mockADSClient) received() []adsResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.responses)
+}
+
+// firstResponse returns the index of the first response of the type that matches.
+func firstResponse(responses []adsResponse, typeURL string, match func(adsResponse) bool) int {
+	return slices.IndexFunc(responses, func(r adsResponse) bool {
+		return r.typeURL == typeURL && match(r)
+	})
+}
+
+// pushedRoutes returns the route versions of the pushed snapshots, i.e. the backends they route to.
+func pushedRoutes(rec *recordingSyncer, start int) []string {
+	var routes []string
+	for _, snap := range rec.synced()[start:] {
+		routes = append(routes, snap.snap.Resources[envoycachetypes.Route].Version)
+	}
+	return routes
+}
+
+func TestXdsDebouncerOrdersPushes(t *testing.T) {
+	setupTest()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tracker := xds.NewProxyStatusTracker(0)
+	cache := tracker.Cache(envoycache.NewSnapshotCache(true, xds.NewNodeRoleHasher(), nil))
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	discoveryv3.RegisterAggregatedDiscoveryServiceServer(srv, xdsserver.NewServer(ctx, cache, tracker))
+	go srv.Serve(lis)
+	defer srv.Stop()
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	rec := &recordingSyncer{}
+	d := newXdsDebouncer(0, 0, func(snap XdsSnapWrapper) {
+		rec.sync(snap)
+		assert.NoError(t, cache.SetSnapshot(ctx, snap.proxyKey, snap.snap))
+	}, nil)
+	d.applied = func(proxyKey string, snap *envoycache.Snapshot) bool {
+		return tracker.Applied(proxyKey, snap)
+	}
+	go d.Run(ctx)
+
+	initial := orderTestSnap([]string{"a"}, []string{"a"})
+	d.Enqueue(initial)
+	client := newMockADSClient(t, ctx, conn)
+	require.Eventually(t, func() bool {
+		// the snapshot is trivially applied until the proxy subscribes to the routes
+		received := client.received()
+		return firstResponse(received, resource.RouteType, func(adsResponse) bool { return true }) != -1 &&
+			tracker.Applied(testProxyKey, initial.snap)
+	}, 5*time.Second, 10*time.Millisecond, "the proxy should apply the initial snapshot")
+
+	t.Run("add route to new backend", func(t *testing.T) {
+		start, pushed := len(client.received()), len(rec.synced())
+		target := orderTestSnap([]string{"a", "b"}, []string{"a", "b"})
+		d.Enqueue(target)
+		require.Eventually(t, func() bool {
+			return tracker.Applied(testProxyKey, target.snap)
+		}, 5*time.Second, 10*time.Millisecond, "the proxy should converge to the target snapshot")
+
+		responses := client.received()[start:]
+		cds := firstResponse(responses, resource.ClusterType, func(r adsResponse) bool { return slices.Contains(r.resources, "b") })
+		eds := firstResponse(responses, resource.EndpointType, func(r adsResponse) bool { return slices.Contains(r.resources, "b") })
+		rds := firstResponse(responses, resource.RouteType, func(adsResponse) bool { return true })
+		require.NotEqual(t, -1, cds, "the new cluster should be sent")
+		require.NotEqual(t, -1, eds, "the endpoints of the new cluster should be sent")
+		require.NotEqual(t, -1, rds, "the new route should be sent")
+		assert.Less(t, cds, rds, "the new cluster should be sent before the route referencing it")
+		assert.Less(t, eds, rds, "the endpoints of the new cluster should be sent before the route referencing it")
+		assert.Equal(t, []string{"a", "a,b"}, pushedRoutes(rec, pushed), "the new cluster should be pushed with the previous route first")
+	})
+
+	t.Run("remove backend", func(t *testing.T) {
+		start, pushed := len(client.received()), len(rec.synced())
+		target := orderTestSnap([]string{"a"}, []string{"a"})
+		d.Enqueue(target)
+		require.Eventually(t, func() bool {
+			return tracker.Applied(testProxyKey, target.snap)
+		}, 5*time.Second, 10*time.Millisecond, "the proxy should converge to the target snapshot")
+
+		responses := client.received()[start:]
+		rds := firstResponse(responses, resource.RouteType, func(adsResponse) bool { return true })
+		cds := firstResponse(responses, resource.ClusterType, func(r adsResponse) bool { return !slices.Contains(r.resources, "b") })
+		require.NotEqual(t, -1, rds, "the new route should be sent")
+		require.NotEqual(t, -1, cds, "the cluster should be removed")
+		assert.Less(t, rds, cds, "the cluster should only be removed once no route references it")
+		for _, r := range responses[:cds] {
+			if r.typeURL == resource.ClusterType {
+				assert.Contains(t, r.resources, "b", "the cluster should be kept until the route is applied")
+			}
+		}
+		assert.Equal(t, []string{"a", "a"}, pushedRoutes(rec, pushed), "the new route should be pushed with the removed cluster first")
+	})
+
+	gathered := metricstest.MustGatherMetrics(t)
+	gathered.AssertMetricsInclude("kgateway_xds_snapshot_pushes_total", []metricstest.ExpectMetric{
+		&metricstest.ExpectedMetric{
+			Labels: []metrics.Label{{Name: "type", Value: syncTypeWarming}},
+			Value:  2,
+		},
+	})
+}
+
+func TestXdsDebouncerWarmTimeout(t *testing.T) {
+	setupTest()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rec := &recordingSyncer{}
+	d := newXdsDebouncer(0, 0, rec.sync, nil)
+	// the proxy never applies the intermediate snapshot, e.g. because it rejects it
+	d.applied = func(string, *envoycache.Snapshot) bool { return false }
+	d.warmTimeout = 200 * time.Millisecond
+	go d.Run(ctx)
+
+	d.Enqueue(orderTestSnap([]string{"a"}, []string{"a"}))
+	target := orderTestSnap([]string{"b"}, []string{"b"})
+	d.Enqueue(target)
+
+	require.Eventually(t, func() bool {
+		synced := rec.synced()
+		return len(synced) > 0 && synced[len(synced)-1].snap == target.snap
+	}, 5*time.Second, 10*time.Millisecond, "the target snapshot should be pushed once the warm timeout elapses")
+
+	// the new cluster is added with the previous route, then the new route is pushed with the removed cluster
+	assert.Equal(t, []string{"a", "a", "b", "b"}, pushedRoutes(rec, 0))
+}
@@ -1,10 +1,14 @@
 package proxy_syncer
 
 import (
+	"fmt"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 	reportssdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/reporter"
 	"github.com/kgateway-dev/kgateway/v2/pkg/reports"
@@ -79,3 +83,56 @@ func GenerateBackendPolicyReport(in []*ir.BackendObjectIR) reports.ReportMap {
 
 	return merged
 }
+
+// reportPolicyTargetsNotFound adds a TargetNotFound ancestor status to each policy for every
+// Gateway it targets by name that does not exist. The missing Gateway itself is used as the
+// ancestor since there is no translated resource to relate the policy to.
+func reportPolicyTargetsNotFound(
+	policies []ir.PolicyWrapper,
+	gatewayExists func(types.NamespacedName) bool,
+	rm *reports.ReportMap,
+) {
+	reporter := reports.NewReporter(rm)
+	for _, pol := range policies {
+		for _, targetRef := range pol.TargetRefs {
+			if targetRef.Group != wellknown.GatewayGroup || targetRef.Kind != wellknown.GatewayKind || targetRef.Name == "" {
+				continue
+			}
+			gwNN := types.NamespacedName{Namespace: pol.Namespace, Name: targetRef.Name}
+			if gatewayExists(gwNN) {
+				continue
+			}
+
+			key := reportssdk.PolicyKey{
+				Group:     pol.Group,
+				Kind:      pol.Kind,
+				Namespace: pol.Namespace,
+				Name:      pol.Name,
+			}
+			ancestorRef := gwv1.ParentReference{
+				Group:     new(gwv1.Group(wellknown.GatewayGroup)),
+				Kind:      new(gwv1.Kind(wellknown.GatewayKind)),
+				Namespace: new(gwv1.Namespace(gwNN.Namespace)),
+				Name:      gwv1.ObjectName(gwNN.Name),
+			}
+			var generation int64
+			if pol.Policy != nil {
+				generation = pol.Policy.GetGeneration()
+			}
+			message := fmt.Sprintf("%s: Gateway %s", reportssdk.PolicyTargetNotFoundMsg, gwNN)
+			r := reporter.Policy(key, generation).AncestorRef(ancestorRef)
+			r.SetCondition(reportssdk.PolicyCondition{
+				Type:    string(shared.PolicyConditionAccepted),
+				Status:  metav1.ConditionFalse,
+				Reason:  string(shared.PolicyReasonTargetNotFound),
+				Message: message,
+			})
+			r.SetCondition(reportssdk.PolicyCondition{
+				Type:    string(shared.PolicyConditionAttached),
+				Status:  metav1.ConditionFalse,
+				Reason:  string(shared.PolicyReasonTargetNotFound),
+				Message: message,
+			})
+		}
+	}
+}
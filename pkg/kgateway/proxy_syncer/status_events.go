@@ -0,0 +1,60 @@
+package proxy_syncer
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/eventutils"
+)
+
+// isFailedCondition reports whether the condition reports a translation failure, e.g. an invalid
+// configuration or a missing Secret or Backend, as opposed to a condition that is still pending.
+func isFailedCondition(cond metav1.Condition) bool {
+	if cond.Status != metav1.ConditionFalse || cond.Reason == string(gwv1.GatewayReasonPending) {
+		return false
+	}
+	switch cond.Type {
+	case string(gwv1.RouteConditionAccepted), string(gwv1.RouteConditionResolvedRefs), string(gwv1.GatewayConditionProgrammed):
+		return true
+	}
+	return false
+}
+
+// recordRouteEvents emits a Warning event on the route for each failed condition of the parents
+// managed by controllerName.
+func recordRouteEvents(recorder *eventutils.RateLimitedRecorder, route client.Object, status *gwv1.RouteStatus, controllerName string) {
+	if recorder == nil || status == nil {
+		return
+	}
+	for _, parent := range status.Parents {
+		if string(parent.ControllerName) != controllerName {
+			continue
+		}
+		for _, cond := range parent.Conditions {
+			if isFailedCondition(cond) {
+				recorder.Warningf(route, cond.Reason, "Parent %s: %s", parent.ParentRef.Name, cond.Message)
+			}
+		}
+	}
+}
+
+// recordGatewayEvents emits a Warning event on the Gateway for each failed condition of the Gateway
+// and its listeners.
+func recordGatewayEvents(recorder *eventutils.RateLimitedRecorder, gw *gwv1.Gateway, status *gwv1.GatewayStatus) {
+	if recorder == nil || status == nil {
+		return
+	}
+	for _, cond := range status.Conditions {
+		if isFailedCondition(cond) {
+			recorder.Warningf(gw, cond.Reason, "%s", cond.Message)
+		}
+	}
+	for _, listener := range status.Listeners {
+		for _, cond := range listener.Conditions {
+			if isFailedCondition(cond) {
+				recorder.Warningf(gw, cond.Reason, "Listener %s: %s", listener.Name, cond.Message)
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package proxy_syncer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/schemes"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/eventutils"
+)
+
+func TestRecordRouteEvents(t *testing.T) {
+	route := &gwv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default"}}
+	status := &gwv1.RouteStatus{
+		Parents: []gwv1.RouteParentStatus{
+			{
+				ParentRef:      gwv1.ParentReference{Name: "gw"},
+				ControllerName: wellknown.DefaultGatewayControllerName,
+				Conditions: []metav1.Condition{
+					{
+						Type:   string(gwv1.RouteConditionAccepted),
+						Status: metav1.ConditionTrue,
+						Reason: string(gwv1.RouteReasonAccepted),
+					},
+					{
+						Type:    string(gwv1.RouteConditionResolvedRefs),
+						Status:  metav1.ConditionFalse,
+						Reason:  string(gwv1.RouteReasonBackendNotFound),
+						Message: "Service default/missing not found",
+					},
+				},
+			},
+			{
+				ParentRef:      gwv1.ParentReference{Name: "other"},
+				ControllerName: "example.com/other",
+				Conditions: []metav1.Condition{{
+					Type:   string(gwv1.RouteConditionAccepted),
+					Status: metav1.ConditionFalse,
+					Reason: string(gwv1.RouteReasonNotAllowedByListeners),
+				}},
+			},
+		},
+	}
+
+	recorder := &eventutils.FakeRecorder{}
+	r := eventutils.NewRateLimitedRecorder(recorder, schemes.DefaultScheme(), time.Minute)
+	// a persistent failure is reported once per interval across sync rounds
+	recordRouteEvents(r, route, status, wellknown.DefaultGatewayControllerName)
+	recordRouteEvents(r, route, status, wellknown.DefaultGatewayControllerName)
+
+	events := recorder.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, eventutils.Event{
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: gwv1.GroupVersion.String(),
+			Kind:       wellknown.HTTPRouteKind,
+			Namespace:  "default",
+			Name:       "route",
+		},
+		Type:    corev1.EventTypeWarning,
+		Reason:  string(gwv1.RouteReasonBackendNotFound),
+		Message: "Parent gw: Service default/missing not found",
+	}, events[0])
+}
+
+func TestRecordGatewayEvents(t *testing.T) {
+	gw := &gwv1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"}}
+	status := &gwv1.GatewayStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type:   string(gwv1.GatewayConditionAccepted),
+				Status: metav1.ConditionTrue,
+				Reason: string(gwv1.GatewayReasonAccepted),
+			},
+			{
+				Type:   string(gwv1.GatewayConditionProgrammed),
+				Status: metav1.ConditionFalse,
+				Reason: string(gwv1.GatewayReasonPending),
+			},
+		},
+		Listeners: []gwv1.ListenerStatus{{
+			Name: "https",
+			Conditions: []metav1.Condition{{
+				Type:    string(gwv1.ListenerConditionResolvedRefs),
+				Status:  metav1.ConditionFalse,
+				Reason:  string(gwv1.ListenerReasonInvalidCertificateRef),
+				Message: "Secret default/tls not found",
+			}},
+		}},
+	}
+
+	recorder := &eventutils.FakeRecorder{}
+	r := eventutils.NewRateLimitedRecorder(recorder, schemes.DefaultScheme(), time.Minute)
+	recordGatewayEvents(r, gw, status)
+	recordGatewayEvents(r, gw, status)
+
+	events := recorder.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, eventutils.Event{
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: gwv1.GroupVersion.String(),
+			Kind:       wellknown.GatewayKind,
+			Namespace:  "default",
+			Name:       "gw",
+		},
+		Type:    corev1.EventTypeWarning,
+		Reason:  string(gwv1.ListenerReasonInvalidCertificateRef),
+		Message: "Listener https: Secret default/tls not found",
+	}, events[0])
+}
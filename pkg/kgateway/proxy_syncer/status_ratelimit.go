@@ -0,0 +1,104 @@
+package proxy_syncer
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// defaultStatusWriteBurst is the number of status writes allowed per object within defaultStatusWriteInterval.
+	defaultStatusWriteBurst = 3
+	// defaultStatusWriteInterval is the interval over which status writes are limited per object.
+	defaultStatusWriteInterval = 10 * time.Second
+	// statusBatchWindow is how long the status syncer waits for newer reports before starting a sync round,
+	// so that a burst of translations results in a single round of status writes.
+	statusBatchWindow = 100 * time.Millisecond
+)
+
+// statusKey identifies an object whose status is written by the StatusSyncer.
+type statusKey struct {
+	Kind string
+	types.NamespacedName
+}
+
+type statusWriteWindow struct {
+	start  time.Time
+	writes int
+}
+
+// statusWriteLimiter limits the number of status writes per object within a fixed interval,
+// so that flapping conditions don't turn into a stream of writes to the API server.
+type statusWriteLimiter struct {
+	mu       sync.Mutex
+	burst    int
+	interval time.Duration
+	now      func() time.Time
+	windows  map[statusKey]statusWriteWindow
+}
+
+// newStatusWriteLimiter returns a limiter that allows burst writes per object within interval.
+// A non-positive burst or interval disables the limiter.
+func newStatusWriteLimiter(burst int, interval time.Duration) *statusWriteLimiter {
+	return &statusWriteLimiter{
+		burst:    burst,
+		interval: interval,
+		now:      time.Now,
+		windows:  map[statusKey]statusWriteWindow{},
+	}
+}
+
+// Allow records a status write for the object and reports whether it's allowed.
+func (l *statusWriteLimiter) Allow(key statusKey) bool {
+	if l == nil || l.burst <= 0 || l.interval <= 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	w := l.windows[key]
+	if now.Sub(w.start) >= l.interval {
+		w = statusWriteWindow{start: now}
+	}
+	if w.writes >= l.burst {
+		return false
+	}
+	w.writes++
+	l.windows[key] = w
+	return true
+}
+
+// prune drops the windows that have expired, so deleted objects don't accumulate.
+func (l *statusWriteLimiter) prune() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	for k, w := range l.windows {
+		if now.Sub(w.start) >= l.interval {
+			delete(l.windows, k)
+		}
+	}
+}
+
+// statusRound tracks the status writes of a single sync round.
+type statusRound struct {
+	limiter *statusWriteLimiter
+	// throttled is set when a write was denied by the limiter, in which case the round
+	// must be re-run once the limiter's interval has passed.
+	throttled bool
+}
+
+// allowWrite reports whether the status of the object can be written in this round.
+func (r *statusRound) allowWrite(kind string, nn types.NamespacedName) bool {
+	if r == nil || r.limiter.Allow(statusKey{Kind: kind, NamespacedName: nn}) {
+		return true
+	}
+	r.throttled = true
+	return false
+}
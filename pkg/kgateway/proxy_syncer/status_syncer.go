@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"time"
 
 	"github.com/avast/retry-go/v4"
@@ -32,6 +33,7 @@ import (
 	plug "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
 	"github.com/kgateway-dev/kgateway/v2/pkg/reports"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/eventutils"
 )
 
 var _ manager.LeaderElectionRunnable = &StatusSyncer{}
@@ -48,6 +50,8 @@ type StatusSyncer struct {
 	cacheSyncs                     []cache.InformerSynced
 
 	customStatusSync func(ctx context.Context, rm reports.ReportMap)
+	limiter          *statusWriteLimiter
+	recorder         *eventutils.RateLimitedRecorder
 }
 
 func NewStatusSyncer(
@@ -71,6 +75,8 @@ func NewStatusSyncer(
 		latestBackendPolicyReportQueue: backendPolicyReportQueue,
 		cacheSyncs:                     cacheSyncs,
 		customStatusSync:               cfg.CustomStatusSync,
+		limiter:                        newStatusWriteLimiter(cfg.StatusWriteBurst, cfg.StatusWriteInterval),
+		recorder:                       cfg.EventRecorder,
 	}
 }
 
@@ -101,38 +107,114 @@ func (s *StatusSyncer) Start(ctx context.Context) error {
 	routeStatusLogger := logger.With("subcomponent", "routeStatusSyncer")
 	listenerSetStatusLogger := logger.With("subcomponent", "listenerSetStatusSyncer")
 	gatewayStatusLogger := logger.With("subcomponent", "gatewayStatusSyncer")
-	go func() {
-		for {
-			latestReport, err := s.latestReportQueue.Dequeue(ctx)
-			if err != nil {
-				logger.Error("failed to dequeue gateway reports", "error", err)
-				return
-			}
-			s.syncGatewayStatus(ctx, gatewayStatusLogger, latestReport)
-			s.syncListenerSetStatus(ctx, listenerSetStatusLogger, latestReport)
-			s.syncRouteStatus(ctx, routeStatusLogger, latestReport)
-			s.syncPolicyStatus(ctx, latestReport)
-			if s.customStatusSync != nil {
-				s.customStatusSync(ctx, latestReport)
-			}
+	go s.runSyncLoop(ctx, "gateway", s.latestReportQueue, func(round *statusRound, latestReport reports.ReportMap) {
+		s.syncGatewayStatus(ctx, gatewayStatusLogger, round, latestReport)
+		s.syncListenerSetStatus(ctx, listenerSetStatusLogger, round, latestReport)
+		s.syncRouteStatus(ctx, routeStatusLogger, round, latestReport)
+		s.syncPolicyStatus(ctx, round, latestReport)
+		if s.customStatusSync != nil {
+			s.customStatusSync(ctx, latestReport)
 		}
-	}()
-	go func() {
-		for {
-			latestReport, err := s.latestBackendPolicyReportQueue.Dequeue(ctx)
-			if err != nil {
-				logger.Error("failed to dequeue backend policy reports", "error", err)
-				return
-			}
-			s.syncPolicyStatus(ctx, latestReport)
-		}
-	}()
+	})
+	go s.runSyncLoop(ctx, "backend policy", s.latestBackendPolicyReportQueue, func(round *statusRound, latestReport reports.ReportMap) {
+		s.syncPolicyStatus(ctx, round, latestReport)
+	})
 
 	<-ctx.Done()
 	return nil
 }
 
-func (s *StatusSyncer) syncRouteStatus(ctx context.Context, logger *slog.Logger, rm reports.ReportMap) {
+// runSyncLoop runs a sync round for each report dequeued from the queue. Reports that arrive within
+// statusBatchWindow of each other are batched into a single round for the latest one, and a round in
+// which status writes were throttled is re-run once the throttling interval has passed, so the latest
+// status is eventually written.
+func (s *StatusSyncer) runSyncLoop(
+	ctx context.Context,
+	name string,
+	queue utils.AsyncQueue[reports.ReportMap],
+	sync func(round *statusRound, latestReport reports.ReportMap),
+) {
+	var (
+		latestReport reports.ReportMap
+		// previousReport is the report the statuses were last synced for, used to find the routes
+		// that are no longer reported on
+		previousReport reports.ReportMap
+		resync         <-chan time.Time
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Error("failed to dequeue "+name+" reports", "error", ctx.Err())
+			return
+		case latestReport = <-queue.Next():
+			latestReport = s.batchReports(ctx, queue, latestReport)
+			latestReport, previousReport = withRemovedRoutes(previousReport, latestReport), latestReport
+		case <-resync:
+			logger.Debug("re-syncing throttled " + name + " statuses")
+		}
+
+		round := &statusRound{limiter: s.limiter}
+		sync(round, latestReport)
+		s.limiter.prune()
+
+		resync = nil
+		if round.throttled {
+			resync = time.After(s.limiter.interval)
+			// the removed routes are kept until their statuses were synced without throttling
+			previousReport = latestReport
+		}
+	}
+}
+
+// withRemovedRoutes returns the latest report with an empty report for each route that was in the
+// previous report but not in the latest one, e.g. because the Gateways it was attached to were
+// deleted, so that the parent statuses written for them are removed. The latest report is not modified.
+func withRemovedRoutes(previous, latest reports.ReportMap) reports.ReportMap {
+	latest.HTTPRoutes = addRemovedRoutes(previous.HTTPRoutes, latest.HTTPRoutes)
+	latest.GRPCRoutes = addRemovedRoutes(previous.GRPCRoutes, latest.GRPCRoutes)
+	latest.TCPRoutes = addRemovedRoutes(previous.TCPRoutes, latest.TCPRoutes)
+	latest.TLSRoutes = addRemovedRoutes(previous.TLSRoutes, latest.TLSRoutes)
+	return latest
+}
+
+func addRemovedRoutes(previous, latest map[types.NamespacedName]*reports.RouteReport) map[types.NamespacedName]*reports.RouteReport {
+	var merged map[types.NamespacedName]*reports.RouteReport
+	for nn := range previous {
+		if _, ok := latest[nn]; ok {
+			continue
+		}
+		if merged == nil {
+			merged = make(map[types.NamespacedName]*reports.RouteReport, len(latest)+1)
+			maps.Copy(merged, latest)
+		}
+		merged[nn] = &reports.RouteReport{}
+	}
+	if merged == nil {
+		return latest
+	}
+	return merged
+}
+
+// batchReports waits for statusBatchWindow and returns the latest report received from the queue.
+func (s *StatusSyncer) batchReports(
+	ctx context.Context,
+	queue utils.AsyncQueue[reports.ReportMap],
+	latestReport reports.ReportMap,
+) reports.ReportMap {
+	timer := time.NewTimer(statusBatchWindow)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return latestReport
+		case <-timer.C:
+			return latestReport
+		case latestReport = <-queue.Next():
+		}
+	}
+}
+
+func (s *StatusSyncer) syncRouteStatus(ctx context.Context, logger *slog.Logger, round *statusRound, rm reports.ReportMap) {
 	// Helper function to sync route status with retry
 	syncStatusWithRetry := func(
 		routeType string,
@@ -264,30 +346,37 @@ func (s *StatusSyncer) syncRouteStatus(ctx context.Context, logger *slog.Logger,
 		)
 	}
 
+	// Helper function to build route status, reporting its failures as events
+	buildStatus := func(route client.Object) *gwv1.RouteStatus {
+		status := rm.BuildRouteStatus(ctx, route, s.controllerName)
+		recordRouteEvents(s.recorder, route, status, s.controllerName)
+		return status
+	}
+
 	// Helper function to build route status and update if needed
 	buildAndUpdateStatus := func(route client.Object, routeType string) (*gwv1.RouteStatus, error) {
 		var status *gwv1.RouteStatus
 		switch r := route.(type) {
 		case *gwv1.HTTPRoute:
-			status = rm.BuildRouteStatus(ctx, r, s.controllerName)
+			status = buildStatus(r)
 			if status == nil || isRouteStatusEqual(&r.Status.RouteStatus, status) {
 				return nil, nil
 			}
 			r.Status.RouteStatus = *status
 		case *gwv1a2.TCPRoute:
-			status = rm.BuildRouteStatus(ctx, r, s.controllerName)
+			status = buildStatus(r)
 			if status == nil || isRouteStatusEqual(&r.Status.RouteStatus, status) {
 				return nil, nil
 			}
 			r.Status.RouteStatus = *status
 		case *gwv1.TLSRoute:
-			status = rm.BuildRouteStatus(ctx, r, s.controllerName)
+			status = buildStatus(r)
 			if status == nil || isRouteStatusEqual(&r.Status.RouteStatus, status) {
 				return nil, nil
 			}
 			r.Status.RouteStatus = *status
 		case *gwv1a2.TLSRoute:
-			status = rm.BuildRouteStatus(ctx, r, s.controllerName)
+			status = buildStatus(r)
 			if status == nil || isRouteStatusEqual(&r.Status.RouteStatus, status) {
 				return nil, nil
 			}
@@ -297,13 +386,17 @@ func (s *StatusSyncer) syncRouteStatus(ctx context.Context, logger *slog.Logger,
 			if unstructuredTLSRoute == nil {
 				return nil, nil
 			}
-			status = rm.BuildRouteStatus(ctx, unstructuredTLSRoute, s.controllerName)
+			status = buildStatus(unstructuredTLSRoute)
 			if status == nil || isRouteStatusEqual(&unstructuredTLSRoute.Status.RouteStatus, status) {
 				return nil, nil
 			}
+			if !round.allowWrite(routeType, client.ObjectKeyFromObject(route)) {
+				logger.Debug("throttling route status update", "resource_ref", client.ObjectKeyFromObject(route), "route_type", routeType)
+				return nil, nil
+			}
 			return status, updateUnstructuredTLSRouteStatus(ctx, s.mgr.GetClient().Status(), r, *status)
 		case *gwv1.GRPCRoute:
-			status = rm.BuildRouteStatus(ctx, r, s.controllerName)
+			status = buildStatus(r)
 			if status == nil || isRouteStatusEqual(&r.Status.RouteStatus, status) {
 				return nil, nil
 			}
@@ -313,6 +406,11 @@ func (s *StatusSyncer) syncRouteStatus(ctx context.Context, logger *slog.Logger,
 			return nil, nil
 		}
 
+		if !round.allowWrite(routeType, client.ObjectKeyFromObject(route)) {
+			logger.Debug("throttling route status update", "resource_ref", client.ObjectKeyFromObject(route), "route_type", routeType)
+			return nil, nil
+		}
+
 		// Update the status
 		return status, s.mgr.GetClient().Status().Update(ctx, route)
 	}
@@ -427,7 +525,7 @@ func updateUnstructuredTLSRouteStatus(ctx context.Context, writer statusWriter,
 }
 
 // syncGatewayStatus will build and update status for all Gateways in a reportMap
-func (s *StatusSyncer) syncGatewayStatus(ctx context.Context, logger *slog.Logger, rm reports.ReportMap) {
+func (s *StatusSyncer) syncGatewayStatus(ctx context.Context, logger *slog.Logger, round *statusRound, rm reports.ReportMap) {
 	for gwnn := range rm.Gateways {
 		finishMetrics := CollectStatusSyncMetrics(StatusSyncMetricLabels{
 			Name:      gwnn.Name,
@@ -466,6 +564,7 @@ func (s *StatusSyncer) syncGatewayStatus(ctx context.Context, logger *slog.Logge
 
 			// Build the desired status
 			newStatus := rm.BuildGWStatus(ctx, gw, nil)
+			recordGatewayEvents(s.recorder, &gw, newStatus)
 			if newStatus == nil {
 				logger.Debug("new status is nil; skipping status update", "gateway", gwnn.String())
 				return nil
@@ -479,6 +578,11 @@ func (s *StatusSyncer) syncGatewayStatus(ctx context.Context, logger *slog.Logge
 				return nil
 			}
 
+			if !round.allowWrite(wellknown.GatewayKind, gwnn) {
+				logger.Debug("throttling gateway status update", "gateway", gwnn.String())
+				return nil
+			}
+
 			// Apply the status update
 			gw.Status = *newStatus
 			if err := s.mgr.GetClient().Status().Update(ctx, &gw); err != nil {
@@ -527,7 +631,7 @@ func (s *StatusSyncer) syncGatewayStatus(ctx context.Context, logger *slog.Logge
 }
 
 // syncListenerSetStatus will build and update status for all Listener Sets in a reportMap
-func (s *StatusSyncer) syncListenerSetStatus(ctx context.Context, logger *slog.Logger, rm reports.ReportMap) {
+func (s *StatusSyncer) syncListenerSetStatus(ctx context.Context, logger *slog.Logger, round *statusRound, rm reports.ReportMap) {
 	// TODO: retry within loop per LS rather than as a full block
 	err := retry.Do(func() (rErr error) {
 		for gvk, listenerSetsForGVK := range rm.ListenerSets {
@@ -551,7 +655,11 @@ func (s *StatusSyncer) syncListenerSetStatus(ctx context.Context, logger *slog.L
 
 				lsStatus := ls.Status
 				if status := rm.BuildListenerSetStatus(ctx, ls); status != nil {
-					if !isListenerSetStatusEqual(&lsStatus, status) {
+					if isListenerSetStatusEqual(&lsStatus, status) {
+						logger.Debug("skipping k8s ls status update, status equal", "listenerset", lsnn.String(), "gvk", gvk.String())
+					} else if !round.allowWrite(gvk.Kind, lsnn) {
+						logger.Debug("throttling ls status update", "listenerset", lsnn.String(), "gvk", gvk.String())
+					} else {
 						ls.Status = *status
 						if err := s.patchListenerSetStatus(ctx, &ls, legacyListenerSet); err != nil {
 							if !apierrors.IsConflict(err) {
@@ -575,8 +683,6 @@ func (s *StatusSyncer) syncListenerSetStatus(ctx context.Context, logger *slog.L
 								break
 							}
 						}
-					} else {
-						logger.Debug("skipping k8s ls status update, status equal", "listenerset", lsnn.String(), "gvk", gvk.String())
 					}
 
 					metrics.EndResourceStatusSync(metrics.ResourceSyncDetails{
@@ -649,7 +755,7 @@ func (s *StatusSyncer) patchListenerSetStatus(
 	return s.mgr.GetClient().Status().Patch(ctx, legacyListenerSet, client.Merge)
 }
 
-func (s *StatusSyncer) syncPolicyStatus(ctx context.Context, rm reports.ReportMap) {
+func (s *StatusSyncer) syncPolicyStatus(ctx context.Context, round *statusRound, rm reports.ReportMap) {
 	// Sync Policy statuses
 	for key := range rm.Policies {
 		gk := schema.GroupKind{Group: key.Group, Kind: key.Kind}
@@ -674,7 +780,11 @@ func (s *StatusSyncer) syncPolicyStatus(ctx context.Context, rm reports.ReportMa
 			continue
 		}
 		status := rm.BuildPolicyStatus(ctx, key, s.controllerName, currentStatus)
-		if status == nil {
+		if status == nil || isPolicyStatusEqual(&currentStatus, status) {
+			continue
+		}
+		if !round.allowWrite(gk.Kind, nsName) {
+			logger.Debug("throttling policy status update", "group_kind", gk, "resource_ref", nsName)
 			continue
 		}
 
@@ -750,6 +860,10 @@ func isListenerSetStatusEqual(objA, objB *gwv1.ListenerSetStatus) bool {
 	return cmp.Equal(objA, objB, opts)
 }
 
+func isPolicyStatusEqual(objA, objB *gwv1.PolicyStatus) bool {
+	return cmp.Equal(objA, objB, opts)
+}
+
 // isRouteStatusEqual compares two RouteStatus objects directly
 func isRouteStatusEqual(objA, objB *gwv1.RouteStatus) bool {
 	return cmp.Equal(objA, objB, opts)
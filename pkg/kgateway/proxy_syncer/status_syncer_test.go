@@ -0,0 +1,208 @@
+package proxy_syncer
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/reporter"
+	"github.com/kgateway-dev/kgateway/v2/pkg/reports"
+)
+
+// fakeManager is a manager that only provides a client.
+type fakeManager struct {
+	manager.Manager
+	client client.Client
+}
+
+func (m fakeManager) GetClient() client.Client {
+	return m.client
+}
+
+type routeStatusTest struct {
+	syncer *StatusSyncer
+	client client.Client
+	route  *gwv1.HTTPRoute
+	// writes is the number of status updates sent to the API server, including failed ones.
+	writes int
+}
+
+func newRouteStatusTest(t *testing.T, opts ...StatusSyncerOption) *routeStatusTest {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gwv1.Install(scheme))
+
+	route := &gwv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default", Generation: 1},
+		Spec: gwv1.HTTPRouteSpec{
+			CommonRouteSpec: gwv1.CommonRouteSpec{
+				ParentRefs: []gwv1.ParentReference{{Name: "gw"}},
+			},
+		},
+	}
+
+	rt := &routeStatusTest{route: route}
+	conflicts := 0
+	rt.client = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(route).
+		WithObjects(route).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResource string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				rt.writes++
+				if obj.GetAnnotations()["conflicts"] != "" && conflicts < 2 {
+					conflicts++
+					return apierrors.NewConflict(schema.GroupResource{Resource: "httproutes"}, obj.GetName(), assert.AnError)
+				}
+				return c.SubResource(subResource).Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	rt.syncer = NewStatusSyncer(fakeManager{client: rt.client}, pluginsdk.Plugin{}, "kgateway.dev/kgateway", nil, nil, nil, nil, nil, opts...)
+	return rt
+}
+
+// report returns a report in which the route has the given Accepted reason.
+func (rt *routeStatusTest) report(reason gwv1.RouteConditionReason) reports.ReportMap {
+	rm := reports.NewReportMap()
+	rep := reports.NewReporter(&rm)
+	rep.Route(rt.route).ParentRef(&rt.route.Spec.ParentRefs[0]).SetCondition(reporter.RouteCondition{
+		Type:   gwv1.RouteConditionAccepted,
+		Status: metav1.ConditionTrue,
+		Reason: reason,
+	})
+	return rm
+}
+
+// sync runs a sync round for a report in which the route has the given Accepted reason.
+func (rt *routeStatusTest) sync(t *testing.T, reason gwv1.RouteConditionReason) *statusRound {
+	t.Helper()
+	return rt.syncReport(rt.report(reason))
+}
+
+func (rt *routeStatusTest) syncReport(rm reports.ReportMap) *statusRound {
+	round := &statusRound{limiter: rt.syncer.limiter}
+	rt.syncer.syncRouteStatus(context.Background(), slog.Default(), round, rm)
+	return round
+}
+
+func (rt *routeStatusTest) acceptedReason(t *testing.T) string {
+	t.Helper()
+
+	route := &gwv1.HTTPRoute{}
+	require.NoError(t, rt.client.Get(context.Background(), client.ObjectKeyFromObject(rt.route), route))
+	require.Len(t, route.Status.Parents, 1)
+	cond := findCondition(route.Status.Parents[0].Conditions, string(gwv1.RouteConditionAccepted))
+	require.NotNil(t, cond)
+	return cond.Reason
+}
+
+func findCondition(conds []metav1.Condition, condType string) *metav1.Condition {
+	for i := range conds {
+		if conds[i].Type == condType {
+			return &conds[i]
+		}
+	}
+	return nil
+}
+
+func TestRouteStatusUnchangedIsNotWritten(t *testing.T) {
+	rt := newRouteStatusTest(t)
+
+	rt.sync(t, gwv1.RouteReasonAccepted)
+	assert.Equal(t, 1, rt.writes)
+
+	for range 5 {
+		rt.sync(t, gwv1.RouteReasonAccepted)
+	}
+	assert.Equal(t, 1, rt.writes, "unchanged statuses must not be written")
+}
+
+func TestRouteStatusConflictIsRetried(t *testing.T) {
+	rt := newRouteStatusTest(t)
+	rt.route.Annotations = map[string]string{"conflicts": "true"}
+	require.NoError(t, rt.client.Update(context.Background(), rt.route))
+
+	rt.sync(t, gwv1.RouteReasonAccepted)
+
+	assert.Equal(t, 3, rt.writes, "expected two conflicts followed by a successful write")
+	assert.Equal(t, string(gwv1.RouteReasonAccepted), rt.acceptedReason(t))
+}
+
+func TestRouteStatusFlappingIsThrottled(t *testing.T) {
+	rt := newRouteStatusTest(t, WithStatusWriteRateLimit(2, time.Minute))
+	now := time.Now()
+	rt.syncer.limiter.now = func() time.Time { return now }
+
+	reasons := []gwv1.RouteConditionReason{gwv1.RouteReasonAccepted, gwv1.RouteReasonNoMatchingParent}
+	var round *statusRound
+	for i := range 9 {
+		round = rt.sync(t, reasons[i%2])
+	}
+	assert.Equal(t, 2, rt.writes, "flapping statuses must be limited to the burst per interval")
+	assert.True(t, round.throttled, "throttled rounds must be re-synced")
+
+	// once the interval has passed, the latest status is written
+	now = now.Add(time.Minute)
+	round = rt.sync(t, gwv1.RouteReasonAccepted)
+	assert.Equal(t, 3, rt.writes)
+	assert.False(t, round.throttled)
+	assert.Equal(t, string(gwv1.RouteReasonAccepted), rt.acceptedReason(t))
+}
+
+func TestRouteStatusOfRemovedRouteIsCleared(t *testing.T) {
+	rt := newRouteStatusTest(t)
+	previous := rt.report(gwv1.RouteReasonAccepted)
+	rt.syncReport(previous)
+	require.Equal(t, string(gwv1.RouteReasonAccepted), rt.acceptedReason(t))
+
+	// the Gateway of the route was deleted, so the route is no longer reported on
+	latest := reports.NewReportMap()
+	rt.syncReport(withRemovedRoutes(previous, latest))
+	assert.Empty(t, latest.HTTPRoutes, "the latest report must not be modified")
+
+	route := &gwv1.HTTPRoute{}
+	require.NoError(t, rt.client.Get(context.Background(), client.ObjectKeyFromObject(rt.route), route))
+	assert.Empty(t, route.Status.Parents, "the parent statuses of the removed route should be cleared")
+}
+
+func TestRouteStatusOfOtherControllersIsKeptForRemovedRoute(t *testing.T) {
+	rt := newRouteStatusTest(t)
+	otherParent := gwv1.RouteParentStatus{
+		ParentRef:      gwv1.ParentReference{Name: "other-gw"},
+		ControllerName: "example.com/other-controller",
+		Conditions: []metav1.Condition{{
+			Type:               string(gwv1.RouteConditionAccepted),
+			Status:             metav1.ConditionTrue,
+			Reason:             string(gwv1.RouteReasonAccepted),
+			LastTransitionTime: metav1.Now(),
+		}},
+	}
+	rt.route.Status.Parents = []gwv1.RouteParentStatus{otherParent}
+	require.NoError(t, rt.client.Status().Update(context.Background(), rt.route))
+
+	previous := rt.report(gwv1.RouteReasonAccepted)
+	rt.syncReport(previous)
+	rt.syncReport(withRemovedRoutes(previous, reports.NewReportMap()))
+
+	route := &gwv1.HTTPRoute{}
+	require.NoError(t, rt.client.Get(context.Background(), client.ObjectKeyFromObject(rt.route), route))
+	require.Len(t, route.Status.Parents, 1)
+	assert.Equal(t, otherParent.ControllerName, route.Status.Parents[0].ControllerName)
+}
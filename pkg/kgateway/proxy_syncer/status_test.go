@@ -293,3 +293,66 @@ func TestBackendPolicyStatusWithSectionName(t *testing.T) {
 	}]
 	a.Nil(ancestorNoSection, "ancestor report without SectionName should not exist")
 }
+
+func TestReportPolicyTargetsNotFound(t *testing.T) {
+	policy := ir.PolicyWrapper{
+		ObjectSource: ir.ObjectSource{
+			Group:     wellknown.TrafficPolicyGVK.Group,
+			Kind:      wellknown.TrafficPolicyGVK.Kind,
+			Namespace: "default",
+			Name:      "policy",
+		},
+		TargetRefs: []ir.PolicyRef{
+			{Group: wellknown.GatewayGroup, Kind: wellknown.GatewayKind, Name: "existing"},
+			{Group: wellknown.GatewayGroup, Kind: wellknown.GatewayKind, Name: "missing"},
+			{Group: wellknown.GatewayGroup, Kind: wellknown.HTTPRouteKind, Name: "route"},
+			{Group: wellknown.GatewayGroup, Kind: wellknown.GatewayKind, MatchLabels: map[string]string{"app": "gw"}},
+		},
+	}
+	gatewayExists := func(nn types.NamespacedName) bool {
+		return nn == types.NamespacedName{Namespace: "default", Name: "existing"}
+	}
+
+	a := assert.New(t)
+	rm := reports.NewReportMap()
+	reportPolicyTargetsNotFound([]ir.PolicyWrapper{policy}, gatewayExists, &rm)
+
+	report := rm.Policies[reporter.PolicyKey{
+		Group:     policy.Group,
+		Kind:      policy.Kind,
+		Namespace: policy.Namespace,
+		Name:      policy.Name,
+	}]
+	a.NotNil(report)
+	a.Len(report.Ancestors, 1)
+	ancestor := report.Ancestors[reports.ParentRefKey{
+		Group:          wellknown.GatewayGroup,
+		Kind:           wellknown.GatewayKind,
+		NamespacedName: types.NamespacedName{Namespace: "default", Name: "missing"},
+	}]
+	a.NotNil(ancestor)
+	diff := cmp.Diff(
+		ancestor.Conditions,
+		[]metav1.Condition{
+			{
+				Type:    string(shared.PolicyConditionAccepted),
+				Status:  metav1.ConditionFalse,
+				Reason:  string(shared.PolicyReasonTargetNotFound),
+				Message: "Target not found: Gateway default/missing",
+			},
+			{
+				Type:    string(shared.PolicyConditionAttached),
+				Status:  metav1.ConditionFalse,
+				Reason:  string(shared.PolicyReasonTargetNotFound),
+				Message: "Target not found: Gateway default/missing",
+			},
+		},
+		cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
+	)
+	a.Empty(diff)
+
+	// no report is created when all targets exist
+	rm = reports.NewReportMap()
+	reportPolicyTargetsNotFound([]ir.PolicyWrapper{policy}, func(types.NamespacedName) bool { return true }, &rm)
+	a.Empty(rm.Policies)
+}
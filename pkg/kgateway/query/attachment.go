@@ -0,0 +1,169 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/logging"
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+var logger = logging.New("query")
+
+// RouteReasonAttachmentCheckFailed is the reason of the Accepted=False condition of the routes
+// whose attachment could not be checked because a RouteAttachmentCheck panicked.
+const RouteReasonAttachmentCheckFailed gwv1.RouteConditionReason = "AttachmentCheckFailed"
+
+// attachmentKey identifies the result of a RouteAttachmentCheck for a route and a listener.
+type attachmentKey struct {
+	check    string
+	route    schema.GroupKind
+	routeNN  types.NamespacedName
+	parent   schema.GroupKind
+	parentNN types.NamespacedName
+	listener gwv1.SectionName
+}
+
+type attachmentResult struct {
+	routeGeneration  int64
+	parentGeneration int64
+	veto             *sdk.RouteAttachmentVeto
+}
+
+// attachmentChecks runs the RouteAttachmentChecks of the plugins and caches their results until
+// the generation of the route or of the parent changes.
+// The results are cached per Gateway, for the Gateway and its ListenerSets, and only the results
+// used by the latest translation of the Gateway are kept: the results of deleted routes, listeners
+// and ListenerSets are dropped on the next translation, and those of a deleted Gateway with it.
+type attachmentChecks struct {
+	checks []sdk.RouteAttachmentCheck
+
+	mu      sync.Mutex
+	results map[types.NamespacedName]map[attachmentKey]attachmentResult
+}
+
+func newAttachmentChecks(checks []sdk.RouteAttachmentCheck) *attachmentChecks {
+	return &attachmentChecks{
+		checks:  checks,
+		results: map[types.NamespacedName]map[attachmentKey]attachmentResult{},
+	}
+}
+
+// forget drops the results cached for the Gateway.
+func (a *attachmentChecks) forget(gateway types.NamespacedName) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.results, gateway)
+}
+
+// start returns the pass that attaches the routes of the Gateway and of its ListenerSets.
+func (a *attachmentChecks) start(gateway types.NamespacedName) *attachmentPass {
+	if a == nil || len(a.checks) == 0 {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return &attachmentPass{
+		checks:  a,
+		gateway: gateway,
+		cached:  a.results[gateway],
+		results: map[attachmentKey]attachmentResult{},
+	}
+}
+
+// attachmentPass runs the checks during a translation of a Gateway, reusing the results cached by
+// the previous translation.
+type attachmentPass struct {
+	checks  *attachmentChecks
+	gateway types.NamespacedName
+	// cached is the results of the previous pass, which are not modified anymore
+	cached  map[attachmentKey]attachmentResult
+	results map[attachmentKey]attachmentResult
+}
+
+// done replaces the results cached for the Gateway with the ones used by the pass.
+func (p *attachmentPass) done() {
+	if p == nil {
+		return
+	}
+	p.checks.mu.Lock()
+	defer p.checks.mu.Unlock()
+	p.checks.results[p.gateway] = p.results
+}
+
+// veto returns the veto of the first check that rejects the attachment of the route to the listener,
+// or nil if all checks allow it.
+func (p *attachmentPass) veto(
+	ctx context.Context,
+	resource client.Object,
+	l *gwv1.Listener,
+	route ir.Route,
+) *sdk.RouteAttachmentVeto {
+	if p == nil {
+		return nil
+	}
+
+	gvk := resourceGVK(resource)
+	routeGeneration := route.GetSourceObject().GetGeneration()
+	for _, check := range p.checks.checks {
+		key := attachmentKey{
+			check:    check.Name,
+			route:    route.GetGroupKind(),
+			routeNN:  types.NamespacedName{Namespace: route.GetNamespace(), Name: route.GetName()},
+			parent:   gvk.GroupKind(),
+			parentNN: types.NamespacedName{Namespace: resource.GetNamespace(), Name: resource.GetName()},
+			listener: l.Name,
+		}
+
+		res, ok := p.results[key]
+		if !ok {
+			res, ok = p.cached[key]
+		}
+		if !ok || res.routeGeneration != routeGeneration || res.parentGeneration != resource.GetGeneration() {
+			veto, err := runAttachmentCheck(ctx, check, sdk.RouteAttachment{
+				Route:    route,
+				Parent:   resource,
+				Listener: *l,
+			})
+			if err != nil {
+				logger.Error("route attachment check failed", "check", check.Name, "route", key.routeNN, "parent", key.parentNN, "listener", l.Name, "error", err)
+				return &sdk.RouteAttachmentVeto{
+					Reason:  RouteReasonAttachmentCheckFailed,
+					Message: err.Error(),
+				}
+			}
+			res = attachmentResult{
+				routeGeneration:  routeGeneration,
+				parentGeneration: resource.GetGeneration(),
+				veto:             veto,
+			}
+		}
+		p.results[key] = res
+		if res.veto != nil {
+			return res.veto
+		}
+	}
+	return nil
+}
+
+// runAttachmentCheck runs the check, turning a panic into an error so that a faulty check
+// doesn't take down the translation.
+func runAttachmentCheck(
+	ctx context.Context,
+	check sdk.RouteAttachmentCheck,
+	attachment sdk.RouteAttachment,
+) (veto *sdk.RouteAttachmentVeto, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic in route attachment check %s: %v", check.Name, r)
+		}
+	}()
+	return check.Check(ctx, attachment), nil
+}
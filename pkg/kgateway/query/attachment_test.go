@@ -0,0 +1,218 @@
+package query_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"istio.io/istio/pkg/kube/krt"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/query"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const routeReasonTenantNotAllowed gwv1.RouteConditionReason = "TenantNotAllowed"
+
+func attachmentTestGateway() *gwv1.Gateway {
+	gwWithListeners := gw()
+	gwWithListeners.Generation = 1
+	gwWithListeners.Spec.Listeners = []gwv1.Listener{
+		{
+			Name:     "shared",
+			Protocol: gwv1.HTTPProtocolType,
+		},
+		{
+			Name:     "tenant",
+			Protocol: gwv1.HTTPProtocolType,
+		},
+	}
+	return gwWithListeners
+}
+
+func attachmentTestRoute() *gwv1.HTTPRoute {
+	hr := httpRoute()
+	hr.Spec.ParentRefs = []gwv1.ParentReference{
+		{
+			Name: "test",
+		},
+	}
+	return hr
+}
+
+// vetoListener returns a check that vetoes the attachment to the given listener, and counts its calls
+func vetoListener(listener gwv1.SectionName, calls *int) sdk.RouteAttachmentCheck {
+	return sdk.RouteAttachmentCheck{
+		Name: "tenants",
+		Check: func(ctx context.Context, attachment sdk.RouteAttachment) *sdk.RouteAttachmentVeto {
+			*calls++
+			if attachment.Listener.Name != listener {
+				return nil
+			}
+			return &sdk.RouteAttachmentVeto{
+				Reason:  routeReasonTenantNotAllowed,
+				Message: "namespace default is not a tenant of " + attachment.Parent.GetName(),
+			}
+		},
+	}
+}
+
+func TestRouteAttachmentCheckAllows(t *testing.T) {
+	gwWithListeners := attachmentTestGateway()
+	var calls int
+	gq := newQueriesWithAttachmentChecks(t, []sdk.RouteAttachmentCheck{vetoListener("other", &calls)}, attachmentTestRoute())
+
+	routes, err := gq.GetRoutesForGateway(krt.TestingDummyContext{}, context.Background(), &ir.Gateway{Obj: gwWithListeners})
+	require.NoError(t, err)
+	assert.Empty(t, routes.RouteErrors)
+	assert.Len(t, routes.GetListenerResult(gwWithListeners, "shared").Routes, 1)
+	assert.Len(t, routes.GetListenerResult(gwWithListeners, "tenant").Routes, 1)
+	assert.Equal(t, 2, calls)
+}
+
+func TestRouteAttachmentCheckVetoes(t *testing.T) {
+	t.Run("vetoed on every listener", func(t *testing.T) {
+		gwWithListeners := attachmentTestGateway()
+		gwWithListeners.Spec.Listeners = gwWithListeners.Spec.Listeners[1:]
+		var calls int
+		gq := newQueriesWithAttachmentChecks(t, []sdk.RouteAttachmentCheck{vetoListener("tenant", &calls)}, attachmentTestRoute())
+
+		routes, err := gq.GetRoutesForGateway(krt.TestingDummyContext{}, context.Background(), &ir.Gateway{Obj: gwWithListeners})
+		require.NoError(t, err)
+		assert.Empty(t, routes.GetListenerResult(gwWithListeners, "tenant").Routes)
+		require.Len(t, routes.RouteErrors, 1)
+		assert.ErrorIs(t, &routes.RouteErrors[0].Error, query.ErrAttachmentVetoed)
+		assert.Equal(t, routeReasonTenantNotAllowed, routes.RouteErrors[0].Error.Reason)
+		assert.Equal(t, "namespace default is not a tenant of test", routes.RouteErrors[0].Error.Message)
+	})
+
+	t.Run("vetoed on some listeners", func(t *testing.T) {
+		gwWithListeners := attachmentTestGateway()
+		var calls int
+		gq := newQueriesWithAttachmentChecks(t, []sdk.RouteAttachmentCheck{vetoListener("tenant", &calls)}, attachmentTestRoute())
+
+		routes, err := gq.GetRoutesForGateway(krt.TestingDummyContext{}, context.Background(), &ir.Gateway{Obj: gwWithListeners})
+		require.NoError(t, err)
+		// the route is accepted by the parent since it attaches to one of its listeners
+		assert.Empty(t, routes.RouteErrors)
+		assert.Len(t, routes.GetListenerResult(gwWithListeners, "shared").Routes, 1)
+		assert.Empty(t, routes.GetListenerResult(gwWithListeners, "tenant").Routes)
+	})
+}
+
+func TestRouteAttachmentCheckCaching(t *testing.T) {
+	gwWithListeners := attachmentTestGateway()
+	var calls int
+	gq := newQueriesWithAttachmentChecks(t, []sdk.RouteAttachmentCheck{vetoListener("tenant", &calls)}, attachmentTestRoute())
+
+	for range 3 {
+		routes, err := gq.GetRoutesForGateway(krt.TestingDummyContext{}, context.Background(), &ir.Gateway{Obj: gwWithListeners})
+		require.NoError(t, err)
+		assert.Empty(t, routes.GetListenerResult(gwWithListeners, "tenant").Routes)
+	}
+	assert.Equal(t, 2, calls, "the results are cached for the generation")
+
+	gwWithListeners.Generation++
+	routes, err := gq.GetRoutesForGateway(krt.TestingDummyContext{}, context.Background(), &ir.Gateway{Obj: gwWithListeners})
+	require.NoError(t, err)
+	assert.Empty(t, routes.GetListenerResult(gwWithListeners, "tenant").Routes)
+	assert.Equal(t, 4, calls, "the results are checked again for a new generation")
+}
+
+func TestRouteAttachmentCheckPanic(t *testing.T) {
+	gwWithListeners := attachmentTestGateway()
+	gwWithListeners.Spec.Listeners = gwWithListeners.Spec.Listeners[:1]
+	var calls int
+	panicking := sdk.RouteAttachmentCheck{
+		Name: "panicking",
+		Check: func(ctx context.Context, attachment sdk.RouteAttachment) *sdk.RouteAttachmentVeto {
+			calls++
+			panic("tenant registry unavailable")
+		},
+	}
+	gq := newQueriesWithAttachmentChecks(t, []sdk.RouteAttachmentCheck{panicking}, attachmentTestRoute())
+
+	for range 2 {
+		routes, err := gq.GetRoutesForGateway(krt.TestingDummyContext{}, context.Background(), &ir.Gateway{Obj: gwWithListeners})
+		require.NoError(t, err)
+		assert.Empty(t, routes.GetListenerResult(gwWithListeners, "shared").Routes)
+		require.Len(t, routes.RouteErrors, 1)
+		assert.Equal(t, query.RouteReasonAttachmentCheckFailed, routes.RouteErrors[0].Error.Reason)
+		assert.Contains(t, routes.RouteErrors[0].Error.Message, "tenant registry unavailable")
+	}
+	assert.Equal(t, 2, calls, "a panic is not cached")
+}
+
+func TestRouteAttachmentCheckCacheEviction(t *testing.T) {
+	t.Run("results of a removed ListenerSet", func(t *testing.T) {
+		gwWithListeners := attachmentTestGateway()
+		allNamespaces := gwv1.NamespacesFromAll
+		gwWithListeners.Spec.AllowedListeners = &gwv1.AllowedListeners{
+			Namespaces: &gwv1.ListenerNamespaces{
+				From: &allNamespaces,
+			},
+		}
+		lsWithListener := ls()
+		lsHR := httpRoute()
+		lsHR.Name = "ls-route"
+		lsKind := gwv1.Kind(wellknown.XListenerSetKind)
+		lsGroup := gwv1.Group(wellknown.XListenerSetGroup)
+		lsHR.Spec.ParentRefs = []gwv1.ParentReference{
+			{
+				Kind:  &lsKind,
+				Group: &lsGroup,
+				Name:  "ls",
+			},
+		}
+		var calls int
+		gq := newQueriesWithAttachmentChecks(t, []sdk.RouteAttachmentCheck{vetoListener("tenant", &calls)}, attachmentTestRoute(), lsHR)
+
+		withListenerSet := &ir.Gateway{
+			Obj: gwWithListeners,
+			AllowedListenerSets: map[schema.GroupVersionKind]ir.ListenerSets{
+				wellknown.XListenerSetGVK: []ir.ListenerSet{{Obj: lsWithListener}},
+			},
+		}
+		for range 2 {
+			routes, err := gq.GetRoutesForGateway(krt.TestingDummyContext{}, context.Background(), withListenerSet)
+			require.NoError(t, err)
+			assert.Len(t, routes.GetListenerResult(lsWithListener, "bar").Routes, 1)
+		}
+		assert.Equal(t, 3, calls)
+
+		_, err := gq.GetRoutesForGateway(krt.TestingDummyContext{}, context.Background(), &ir.Gateway{Obj: gwWithListeners})
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls, "the results of the Gateway are still cached")
+
+		routes, err := gq.GetRoutesForGateway(krt.TestingDummyContext{}, context.Background(), withListenerSet)
+		require.NoError(t, err)
+		assert.Len(t, routes.GetListenerResult(lsWithListener, "bar").Routes, 1)
+		assert.Equal(t, 4, calls, "the results of the ListenerSet were dropped when it was removed")
+	})
+
+	t.Run("results of a deleted Gateway", func(t *testing.T) {
+		gwWithListeners := attachmentTestGateway()
+		irGW := ir.Gateway{Obj: gwWithListeners}
+		gateways := krt.NewStaticCollection(nil, []ir.Gateway{irGW})
+		var calls int
+		gq := newQueriesWithGateways(t, gateways, []sdk.RouteAttachmentCheck{vetoListener("tenant", &calls)}, attachmentTestRoute())
+
+		_, err := gq.GetRoutesForGateway(krt.TestingDummyContext{}, context.Background(), &irGW)
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+
+		// a Gateway recreated with the same name and generation is checked again
+		gateways.DeleteObject(irGW.ResourceName())
+		assert.Eventually(t, func() bool {
+			_, err := gq.GetRoutesForGateway(krt.TestingDummyContext{}, context.Background(), &irGW)
+			require.NoError(t, err)
+			return calls == 4
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, 4, calls)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"slices"
 	"strings"
 
+	"istio.io/istio/pkg/kube/controllers"
 	"istio.io/istio/pkg/kube/krt"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 )
@@ -29,11 +31,15 @@ var (
 	ErrLocalObjRefMissingKind     = fmt.Errorf("localObjRef provided with empty kind")
 	ErrCyclicReference            = fmt.Errorf("cyclic reference detected while evaluating delegated routes")
 	ErrUnresolvedReference        = fmt.Errorf("unresolved reference")
+	ErrAttachmentVetoed           = fmt.Errorf("attachment vetoed")
+	ErrUnsupportedAppProtocol     = fmt.Errorf("unsupported app protocol")
 )
 
 type Error struct {
 	Reason gwv1.RouteConditionReason
 	E      error
+	// Message optionally describes the error in the condition of the route.
+	Message string
 }
 
 var _ error = &Error{}
@@ -147,11 +153,23 @@ type RouteError struct {
 // NewData wraps a _pointer_ to CommonCollections. We take a reference because
 // the queries aren't ready until InitPlugins has been called on the
 // CommonCollections.
+// The routes are only attached to the listeners allowed by the attachmentChecks.
 func NewData(
 	collections *collections.CommonCollections,
+	attachmentChecks ...sdk.RouteAttachmentCheck,
 ) GatewayQueries {
+	checks := newAttachmentChecks(attachmentChecks)
+	if len(attachmentChecks) > 0 && collections.GatewayIndex != nil {
+		// the results of the checks are cached until the Gateway is deleted
+		collections.GatewayIndex.Gateways.Register(func(e krt.Event[ir.Gateway]) {
+			if e.Event == controllers.EventDelete {
+				checks.forget(client.ObjectKeyFromObject(e.Latest().Obj))
+			}
+		})
+	}
 	return &gatewayQueries{
-		collections: collections,
+		collections:      collections,
+		attachmentChecks: checks,
 	}
 }
 
@@ -164,7 +182,8 @@ func NewRoutesForGwResult() *RoutesForGwResult {
 }
 
 type gatewayQueries struct {
-	collections *collections.CommonCollections
+	collections      *collections.CommonCollections
+	attachmentChecks *attachmentChecks
 }
 
 func parentRefMatchListener(ref *gwv1.ParentReference, l *gwv1.Listener) bool {
@@ -203,15 +222,7 @@ func isParentRefForResource(pRef *gwv1.ParentReference, resource client.Object,
 		return false
 	}
 
-	gvk := resource.GetObjectKind().GroupVersionKind()
-	if gvk.Empty() {
-		switch resource.(type) {
-		case *gwv1.Gateway:
-			gvk = wellknown.GatewayGVK
-		case *gwv1.ListenerSet:
-			gvk = wellknown.ListenerSetGVK
-		}
-	}
+	gvk := resourceGVK(resource)
 
 	if pRef.Group != nil && *pRef.Group != gwv1.Group(gvk.Group) {
 		return false
@@ -228,6 +239,21 @@ func isParentRefForResource(pRef *gwv1.ParentReference, resource client.Object,
 	return ns == resource.GetNamespace() && string(pRef.Name) == resource.GetName()
 }
 
+// resourceGVK returns the GroupVersionKind of the provided Gateway or ListenerSet, which is
+// not set on the typed objects read from the informers.
+func resourceGVK(resource client.Object) schema.GroupVersionKind {
+	gvk := resource.GetObjectKind().GroupVersionKind()
+	if gvk.Empty() {
+		switch resource.(type) {
+		case *gwv1.Gateway:
+			gvk = wellknown.GatewayGVK
+		case *gwv1.ListenerSet:
+			gvk = wellknown.ListenerSetGVK
+		}
+	}
+	return gvk
+}
+
 func hostnameIntersect(l *gwv1.Listener, routeHostnames []string) (bool, []string) {
 	if l == nil {
 		return false, nil
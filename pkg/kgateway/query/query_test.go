@@ -1337,6 +1337,14 @@ var SvcGk = schema.GroupKind{
 }
 
 func newQueries(t test.Failer, initObjs ...client.Object) query.GatewayQueries {
+	return newQueriesWithAttachmentChecks(t, nil, initObjs...)
+}
+
+func newQueriesWithAttachmentChecks(t test.Failer, checks []sdk.RouteAttachmentCheck, initObjs ...client.Object) query.GatewayQueries {
+	return newQueriesWithGateways(t, nil, checks, initObjs...)
+}
+
+func newQueriesWithGateways(t test.Failer, gateways krt.Collection[ir.Gateway], checks []sdk.RouteAttachmentCheck, initObjs ...client.Object) query.GatewayQueries {
 	var anys []any
 	for _, obj := range initObjs {
 		anys = append(anys, obj)
@@ -1377,11 +1385,14 @@ func newQueries(t test.Failer, initObjs ...client.Object) query.GatewayQueries {
 	commonCols := &collections.CommonCollections{
 		Routes: rtidx, Secrets: secrets, Namespaces: nsCol,
 	}
+	if gateways != nil {
+		commonCols.GatewayIndex = &krtcollections.GatewayIndex{Gateways: gateways}
+	}
 
 	for !rtidx.HasSynced() || !refgrants.HasSynced() || !secrets.HasSynced() || !upstreams.HasSynced() {
 		time.Sleep(time.Second / 10)
 	}
-	return query.NewData(commonCols)
+	return query.NewData(commonCols, checks...)
 }
 
 func k8sUpstreams(services krt.Collection[*corev1.Service]) krt.Collection[ir.BackendObjectIR] {
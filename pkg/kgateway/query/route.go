@@ -19,6 +19,7 @@ import (
 	delegationutils "github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils/delegation"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 )
 
@@ -329,7 +330,7 @@ func (r *gatewayQueries) fetchRoutesByRef(
 	return refChildren, nil
 }
 
-func (r *gatewayQueries) GetRoutesForResource(kctx krt.HandlerContext, ctx context.Context, resource client.Object) (*RoutesForGwResult, error) {
+func (r *gatewayQueries) GetRoutesForResource(kctx krt.HandlerContext, ctx context.Context, resource client.Object, attachments *attachmentPass) (*RoutesForGwResult, error) {
 	nns := types.NamespacedName{
 		Namespace: resource.GetNamespace(),
 		Name:      resource.GetName(),
@@ -338,20 +339,12 @@ func (r *gatewayQueries) GetRoutesForResource(kctx krt.HandlerContext, ctx conte
 	// Process each route
 	ret := NewRoutesForGwResult()
 
-	gvk := resource.GetObjectKind().GroupVersionKind()
-	if gvk.Empty() {
-		switch resource.(type) {
-		case *gwv1.Gateway:
-			gvk = wellknown.GatewayGVK
-		case *gwv1.ListenerSet:
-			gvk = wellknown.ListenerSetGVK
-		}
-	}
+	gvk := resourceGVK(resource)
 
 	routes := r.collections.Routes.RoutesFor(kctx, nns, gvk.Group, gvk.Kind)
 
 	for _, route := range routes {
-		if err := r.processRoute(kctx, ctx, resource, route, ret); err != nil {
+		if err := r.processRoute(kctx, ctx, resource, route, attachments, ret); err != nil {
 			return nil, err
 		}
 	}
@@ -360,14 +353,17 @@ func (r *gatewayQueries) GetRoutesForResource(kctx krt.HandlerContext, ctx conte
 }
 
 func (r *gatewayQueries) GetRoutesForGateway(kctx krt.HandlerContext, ctx context.Context, gw *ir.Gateway) (*RoutesForGwResult, error) {
-	routes, err := r.GetRoutesForResource(kctx, ctx, gw.Obj)
+	attachments := r.attachmentChecks.start(client.ObjectKeyFromObject(gw.Obj))
+	defer attachments.done()
+
+	routes, err := r.GetRoutesForResource(kctx, ctx, gw.Obj, attachments)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, gvkLS := range gw.AllowedListenerSets {
 		for _, ls := range gvkLS {
-			lsRoutes, err := r.GetRoutesForResource(kctx, ctx, ls.Obj)
+			lsRoutes, err := r.GetRoutesForResource(kctx, ctx, ls.Obj, attachments)
 			if err != nil {
 				return nil, err
 			}
@@ -408,6 +404,7 @@ func (r *gatewayQueries) processRoute(
 	ctx context.Context,
 	resource client.Object,
 	route ir.Route,
+	attachments *attachmentPass,
 	ret *RoutesForGwResult,
 ) error {
 	refs := getParentRefsForResource(resource, route)
@@ -417,6 +414,8 @@ func (r *gatewayQueries) processRoute(
 		anyRoutesAllowed := false
 		anyListenerMatched := false
 		anyHostsMatch := false
+		anyAttached := false
+		var veto *sdk.RouteAttachmentVeto
 
 		listeners, err := getListeners(resource)
 		if err != nil {
@@ -430,6 +429,13 @@ func (r *gatewayQueries) processRoute(
 				ret.setListenerResult(resource, string(l.Name), lr)
 			}
 
+			// Check if the listener matches the route's parent reference, so that routes are only
+			// reported as not allowed by the listeners they reference
+			if !parentRefMatchListener(&ref, &l) {
+				continue
+			}
+			anyListenerMatched = true
+
 			allowedNs, allowedKinds, err := r.allowedRoutes(resource, &l)
 			if err != nil {
 				lr.Error = err
@@ -447,12 +453,6 @@ func (r *gatewayQueries) processRoute(
 			}
 			anyRoutesAllowed = true
 
-			// Check if the listener matches the route's parent reference
-			if !parentRefMatchListener(&ref, &l) {
-				continue
-			}
-			anyListenerMatched = true
-
 			// If the route is an HTTP or TLS Route, check the hostname intersection
 			var hostnames []string
 			if routeKind == wellknown.HTTPRouteKind {
@@ -486,22 +486,31 @@ func (r *gatewayQueries) processRoute(
 				}
 			}
 
+			// Let the plugins veto the attachment
+			if v := attachments.veto(ctx, resource, &l, route); v != nil {
+				if veto == nil {
+					veto = v
+				}
+				continue
+			}
+			anyAttached = true
+
 			// If all checks pass, add the route to the listener result
 			lr.Routes = append(lr.Routes, r.GetRouteChain(kctx, ctx, route, hostnames, ref))
 		}
 
 		// Handle route errors based on checks
-		if !anyRoutesAllowed {
+		if !anyListenerMatched {
 			ret.RouteErrors = append(ret.RouteErrors, &RouteError{
 				Route:     route,
 				ParentRef: ref,
-				Error:     Error{E: ErrNotAllowedByListeners, Reason: gwv1.RouteReasonNotAllowedByListeners},
+				Error:     Error{E: ErrNoMatchingParent, Reason: gwv1.RouteReasonNoMatchingParent},
 			})
-		} else if !anyListenerMatched {
+		} else if !anyRoutesAllowed {
 			ret.RouteErrors = append(ret.RouteErrors, &RouteError{
 				Route:     route,
 				ParentRef: ref,
-				Error:     Error{E: ErrNoMatchingParent, Reason: gwv1.RouteReasonNoMatchingParent},
+				Error:     Error{E: ErrNotAllowedByListeners, Reason: gwv1.RouteReasonNotAllowedByListeners},
 			})
 		} else if (routeKind == wellknown.HTTPRouteKind || routeKind == wellknown.TLSRouteKind || routeKind == wellknown.GRPCRouteKind) && !anyHostsMatch {
 			ret.RouteErrors = append(ret.RouteErrors, &RouteError{
@@ -509,6 +518,12 @@ func (r *gatewayQueries) processRoute(
 				ParentRef: ref,
 				Error:     Error{E: ErrNoMatchingListenerHostname, Reason: gwv1.RouteReasonNoMatchingListenerHostname},
 			})
+		} else if !anyAttached && veto != nil {
+			ret.RouteErrors = append(ret.RouteErrors, &RouteError{
+				Route:     route,
+				ParentRef: ref,
+				Error:     Error{E: ErrAttachmentVetoed, Reason: veto.Reason, Message: veto.Message},
+			})
 		}
 	}
 
@@ -41,6 +41,13 @@ func ProcessBackendError(err error, reporter reports.ParentRefReporter) {
 			Reason:  gwv1.RouteReasonRefNotPermitted,
 			Message: err.Error(),
 		})
+	case errors.Is(err, ErrUnsupportedAppProtocol):
+		reporter.SetCondition(reports.RouteCondition{
+			Type:    gwv1.RouteConditionResolvedRefs,
+			Status:  metav1.ConditionFalse,
+			Reason:  gwv1.RouteReasonUnsupportedProtocol,
+			Message: err.Error(),
+		})
 	case errors.Is(err, ErrUnresolvedReference):
 		reporter.SetCondition(reports.RouteCondition{
 			Type:    gwv1.RouteConditionResolvedRefs,
@@ -27,6 +27,7 @@ import (
 
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/xds"
 	"github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/eventutils"
 )
 
 const (
@@ -95,17 +96,25 @@ func NewControlPlane(
 	authenticators []security.Authenticator,
 	xdsAuth bool,
 	certWatcher *certwatcher.CertWatcher,
+	proxyStatus *xds.ProxyStatusTracker,
+	recorder *eventutils.RateLimitedRecorder,
 ) envoycache.SnapshotCache {
 	baseLogger := slog.Default().With("component", "envoy-controlplane")
 	envoyLoggerAdapter := &slogAdapterForEnvoy{logger: baseLogger}
-	lnc := newLogNackCallback()
-	allCallbacks := chainCallbacks(callbacks, lnc)
+	lnc := newLogNackCallback(recorder)
+	streams := newXdsStreams()
+	allCallbacks := chainCallbacks(callbacks, lnc, newXdsMetricsCallback(), streams, proxyStatus)
 
 	// Create separate gRPC servers for each listener
-	serverOpts := getGRPCServerOpts(authenticators, xdsAuth, certWatcher, baseLogger)
+	serverOpts := getGRPCServerOpts(authenticators, xdsAuth, certWatcher, streams, baseLogger)
 	kgwGRPCServer := grpc.NewServer(serverOpts...)
 
-	snapshotCache := envoycache.NewSnapshotCache(true, xds.NewNodeRoleHasher(), envoyLoggerAdapter)
+	// streams of proxies whose snapshot is removed from the cache are closed, so they reconnect
+	// instead of waiting on the watches that were dropped along with the snapshot
+	snapshotCache := &streamClosingCache{
+		SnapshotCache: proxyStatus.Cache(envoycache.NewSnapshotCache(true, xds.NewNodeRoleHasher(), envoyLoggerAdapter)),
+		streams:       streams,
+	}
 
 	xdsServer := xdsserver.NewServer(ctx, snapshotCache, allCallbacks)
 
@@ -135,6 +144,7 @@ func getGRPCServerOpts(
 	authenticators []security.Authenticator,
 	xdsAuth bool,
 	certWatcher *certwatcher.CertWatcher,
+	streams *xdsStreams,
 	logger *slog.Logger,
 ) []grpc.ServerOption {
 	opts := []grpc.ServerOption{
@@ -164,6 +174,7 @@ func getGRPCServerOpts(
 						return handler(srv, ss)
 					}
 				},
+				streams.interceptor,
 			)),
 	}
 
@@ -8,16 +8,22 @@ import (
 	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	xdsserver "github.com/envoyproxy/go-control-plane/pkg/server/v3"
 	"google.golang.org/genproto/googleapis/rpc/status"
+	corev1 "k8s.io/api/core/v1"
 
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/xds"
 	"github.com/kgateway-dev/kgateway/v2/pkg/logging"
 	"github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/eventutils"
 )
 
 const (
 	gwNameLabel      = "gateway_name"
 	gwNamespaceLabel = "gateway_namespace"
 	typeURLLabel     = "type_url"
+
+	// xdsRejectedReason is the reason of the events emitted on a Gateway whose proxy rejects its xDS config
+	xdsRejectedReason = "XDSRejected"
 )
 
 var (
@@ -56,15 +62,17 @@ func newResourceState() resourceState {
 type logNackCallback struct {
 	xdsserver.CallbackFuncs
 	streamState map[int64]resourceState
+	recorder    *eventutils.RateLimitedRecorder
 
 	lock sync.Mutex
 }
 
 var _ xdsserver.Callbacks = (*logNackCallback)(nil)
 
-func newLogNackCallback() *logNackCallback {
+func newLogNackCallback(recorder *eventutils.RateLimitedRecorder) *logNackCallback {
 	return &logNackCallback{
 		streamState: make(map[int64]resourceState),
+		recorder:    recorder,
 	}
 }
 
@@ -83,18 +91,10 @@ func (l *logNackCallback) OnStreamClosed(streamID int64, node *envoycorev3.Node)
 // OnStreamRequest implements server.Callbacks.
 func (l *logNackCallback) OnStreamRequest(streamID int64, req *discoveryv3.DiscoveryRequest) error {
 	// get gateway and typeURL from request
-	role := req.GetNode().GetMetadata().GetFields()[xds.RoleKey].GetStringValue()
-	parts := strings.SplitN(role, xds.KeyDelimiter, 3)
-	if len(parts) != 3 {
+	namespace, name, ok := proxyGateway(req.GetNode())
+	if !ok {
 		return nil
 	}
-	namespace := parts[1]
-	name := parts[2]
-
-	// note, with locality, name will include name~hash~ns
-	if localityParts := strings.SplitN(name, xds.KeyDelimiter, 3); len(localityParts) == 3 {
-		name = localityParts[0]
-	}
 
 	typeUrl := req.GetTypeUrl()
 	key := resourceKey{
@@ -123,6 +123,12 @@ func (l *logNackCallback) onNewError(key resourceKey, err *status.Status) {
 	xdsRejectsTotal.Inc(labels...)
 	xdsRejectsCurrent.Add(1, labels...)
 	logger.Warn("xds error", "gateway_name", key.Name, "gateway_ns", key.Namespace, "resource", key.ResourceTypeUrl, "error", err.Message)
+	l.recorder.Warningf(&corev1.ObjectReference{
+		APIVersion: wellknown.GatewayGVK.GroupVersion().String(),
+		Kind:       wellknown.GatewayKind,
+		Namespace:  key.Namespace,
+		Name:       key.Name,
+	}, xdsRejectedReason, "Proxy rejected the %s config: %s", key.ResourceTypeUrl, err.Message)
 }
 
 func (l *logNackCallback) onErrorGone(key resourceKey) {
@@ -153,6 +159,23 @@ func (l *logNackCallback) handleError(streamID int64, key resourceKey) bool {
 	return true
 }
 
+// proxyGateway returns the Gateway served to the proxy of the node, from its role.
+func proxyGateway(node *envoycorev3.Node) (namespace, name string, ok bool) {
+	role := node.GetMetadata().GetFields()[xds.RoleKey].GetStringValue()
+	parts := strings.SplitN(role, xds.KeyDelimiter, 3)
+	if len(parts) != 3 {
+		return "", "", false
+	}
+	namespace = parts[1]
+	name = parts[2]
+
+	// note, with locality, name will include name~hash~ns
+	if localityParts := strings.SplitN(name, xds.KeyDelimiter, 3); len(localityParts) == 3 {
+		name = localityParts[0]
+	}
+	return namespace, name, true
+}
+
 func toLabels(key resourceKey) []metrics.Label {
 	return []metrics.Label{
 		{
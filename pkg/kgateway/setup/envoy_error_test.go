@@ -2,16 +2,21 @@ package setup
 
 import (
 	"testing"
+	"time"
 
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/protobuf/types/known/structpb"
+	corev1 "k8s.io/api/core/v1"
 
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/xds"
 	kmetrics "github.com/kgateway-dev/kgateway/v2/pkg/metrics"
 	"github.com/kgateway-dev/kgateway/v2/pkg/metrics/metricstest"
+	"github.com/kgateway-dev/kgateway/v2/pkg/schemes"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/eventutils"
 )
 
 const (
@@ -60,7 +65,7 @@ func expectedGauge(val float64, typeURL string) *metricstest.ExpectedMetric {
 
 func TestSingleErrorLifecycle(t *testing.T) {
 	resetMetrics()
-	cb := newLogNackCallback()
+	cb := newLogNackCallback(nil)
 
 	// First request with an error -> increments total and gauge
 	require.NoError(t, cb.OnStreamRequest(1, dr(fullType, &status.Status{Message: "boom"})))
@@ -87,7 +92,7 @@ func TestSingleErrorLifecycle(t *testing.T) {
 
 func TestMultipleResourcesAndStreams(t *testing.T) {
 	resetMetrics()
-	cb := newLogNackCallback()
+	cb := newLogNackCallback(nil)
 
 	// Stream 1 errors on resource A and B
 	require.NoError(t, cb.OnStreamRequest(1, dr(fullType, &status.Status{Message: "errA"})))
@@ -141,3 +146,29 @@ func TestMultipleResourcesAndStreams(t *testing.T) {
 		})
 	}
 }
+
+func TestNackEvent(t *testing.T) {
+	resetMetrics()
+	recorder := &eventutils.FakeRecorder{}
+	cb := newLogNackCallback(eventutils.NewRateLimitedRecorder(recorder, schemes.DefaultScheme(), time.Minute))
+
+	// the rejection is reported once per interval, even across streams
+	require.NoError(t, cb.OnStreamRequest(1, dr(fullType, &status.Status{Message: "boom"})))
+	require.NoError(t, cb.OnStreamRequest(1, dr(fullType, nil)))
+	require.NoError(t, cb.OnStreamRequest(1, dr(fullType, &status.Status{Message: "boom"})))
+	require.NoError(t, cb.OnStreamRequest(2, dr(fullType, &status.Status{Message: "boom"})))
+
+	events := recorder.Events()
+	require.Len(t, events, 1)
+	require.Equal(t, eventutils.Event{
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: wellknown.GatewayGVK.GroupVersion().String(),
+			Kind:       wellknown.GatewayKind,
+			Namespace:  ns,
+			Name:       name,
+		},
+		Type:    corev1.EventTypeWarning,
+		Reason:  xdsRejectedReason,
+		Message: "Proxy rejected the " + typeURL + " config: boom",
+	}, events[0])
+}
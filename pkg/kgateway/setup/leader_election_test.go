@@ -0,0 +1,265 @@
+package setup_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	istiokube "istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/test/util/retry"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/test/envtestutil"
+)
+
+const (
+	leaderElectionLease = "kgateway-leader-election-test"
+	leaderElectionGw    = "http-gw-leader-election"
+	leaderElectionNs    = "gwtest"
+)
+
+// TestLeaderElection runs several replicas of the controller against the same API server. Only the leader
+// deploys the proxies and writes statuses, while every replica serves xDS, and the leadership moves to a
+// standby replica when the leader shuts down.
+func TestLeaderElection(t *testing.T) {
+	writer.set(t)
+	t.Cleanup(func() {
+		writer.set(nil)
+	})
+	st, err := envtestutil.BuildSettings()
+	require.NoError(t, err)
+
+	testEnv := newTestEnv(t)
+	cfg, err := testEnv.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() { testEnv.Stop() })
+	client, err := istiokube.NewCLIClient(istiokube.NewClientConfigForRestConfig(cfg))
+	require.NoError(t, err)
+
+	ctx := t.Context()
+	require.NoError(t, client.ApplyYAMLFiles("default", "testdata/setup_yaml/setup.yaml"))
+	require.NoError(t, client.ApplyYAMLContents(leaderElectionNs, `kind: GatewayClass
+apiVersion: gateway.networking.k8s.io/v1
+metadata:
+  name: kgateway-managed
+spec:
+  controllerName: kgateway.dev/kgateway`, `kind: Gateway
+apiVersion: gateway.networking.k8s.io/v1
+metadata:
+  name: `+leaderElectionGw+`
+  namespace: `+leaderElectionNs+`
+spec:
+  gatewayClassName: kgateway-managed
+  listeners:
+  - protocol: HTTP
+    port: 8080
+    name: http
+    allowedRoutes:
+      namespaces:
+        from: All`, `apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: route-to-upstream
+  namespace: `+leaderElectionNs+`
+spec:
+  parentRefs:
+    - name: `+leaderElectionGw+`
+  hostnames:
+    - "www.example.com"
+  rules:
+    - backendRefs:
+        - name: static
+          kind: Backend
+          group: gateway.kgateway.dev`, `apiVersion: gateway.kgateway.dev/v1alpha1
+kind: Backend
+metadata:
+  name: static
+  namespace: `+leaderElectionNs+`
+spec:
+  type: Static
+  static:
+    hosts:
+      - host: 1.2.3.4
+        port: 8080`))
+
+	// another replica holds the lease, so the first replica started is a follower
+	leases := client.Kube().CoordinationV1().Leases("default")
+	_, err = leases.Create(ctx, &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: leaderElectionLease, Namespace: "default"},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       new("another-replica"),
+			LeaseDurationSeconds: new(int32(3600)),
+			AcquireTime:          new(metav1.NowMicro()),
+			RenewTime:            new(metav1.NowMicro()),
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	statuses := recordGatewayStatuses(t, ctx, client)
+	first := envtestutil.StartReplica(t, cfg, st, leaderElectionLease)
+
+	t.Log("a follower serves xds")
+	requireServesXds(t, ctx, first)
+
+	t.Log("a follower neither deploys proxies nor writes statuses")
+	assert.Never(t, func() bool {
+		return deploymentExists(t, ctx, client) || gatewayAccepted(t, ctx, client)
+	}, 3*time.Second, 250*time.Millisecond)
+
+	t.Log("the follower deploys proxies and writes statuses once it becomes the leader")
+	require.NoError(t, leases.Delete(ctx, leaderElectionLease, metav1.DeleteOptions{}))
+	require.Eventually(t, func() bool {
+		return deploymentExists(t, ctx, client) && gatewayAccepted(t, ctx, client)
+	}, 20*time.Second, 250*time.Millisecond)
+
+	t.Log("a standby replica serves xds without writing duplicate statuses")
+	standby := envtestutil.StartReplica(t, cfg, st, leaderElectionLease)
+	requireServesXds(t, ctx, standby)
+	written := statuses.count()
+	assert.Never(t, func() bool {
+		return statuses.count() != written
+	}, 3*time.Second, 250*time.Millisecond, "the Gateway was written although nothing changed")
+
+	t.Log("the standby replica takes over when the leader shuts down")
+	first.Stop()
+	generation := addGatewayListener(t, ctx, client)
+	require.NoError(t, client.Kube().AppsV1().Deployments(leaderElectionNs).Delete(ctx, leaderElectionGw, metav1.DeleteOptions{}))
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.True(c, deploymentExists(t, ctx, client), "the proxy wasn't deployed again")
+		gw, err := client.GatewayAPI().GatewayV1().Gateways(leaderElectionNs).Get(ctx, leaderElectionGw, metav1.GetOptions{})
+		require.NoError(c, err)
+		cond := meta.FindStatusCondition(gw.Status.Conditions, string(gwv1.GatewayConditionAccepted))
+		require.NotNil(c, cond)
+		assert.Equal(c, generation, cond.ObservedGeneration)
+		assert.Len(c, gw.Status.Listeners, 2)
+	}, 20*time.Second, 250*time.Millisecond)
+	requireServesXds(t, ctx, standby)
+
+	statuses.requireNoRegression(t)
+}
+
+func requireServesXds(t *testing.T, ctx context.Context, replica *envtestutil.Replica) {
+	t.Helper()
+	retry.UntilSuccessOrFail(t, func() error {
+		dumper := newXdsDumper(t, ctx, replica.XdsPort, leaderElectionGw)
+		defer dumper.Close()
+		dump, err := dumper.Dump(t, ctx)
+		if err != nil {
+			return err
+		}
+		if len(dump.Listeners) == 0 {
+			return errors.New("no listeners served")
+		}
+		return nil
+	}, retry.Timeout(20*time.Second))
+}
+
+func deploymentExists(t *testing.T, ctx context.Context, client istiokube.CLIClient) bool {
+	t.Helper()
+	_, err := client.Kube().AppsV1().Deployments(leaderElectionNs).Get(ctx, leaderElectionGw, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false
+	}
+	require.NoError(t, err)
+	return true
+}
+
+func gatewayAccepted(t *testing.T, ctx context.Context, client istiokube.CLIClient) bool {
+	t.Helper()
+	gw, err := client.GatewayAPI().GatewayV1().Gateways(leaderElectionNs).Get(ctx, leaderElectionGw, metav1.GetOptions{})
+	require.NoError(t, err)
+	return meta.IsStatusConditionTrue(gw.Status.Conditions, string(gwv1.GatewayConditionAccepted))
+}
+
+// addGatewayListener adds a listener to the Gateway and returns its new generation.
+func addGatewayListener(t *testing.T, ctx context.Context, client istiokube.CLIClient) int64 {
+	t.Helper()
+	var generation int64
+	retry.UntilSuccessOrFail(t, func() error {
+		gws := client.GatewayAPI().GatewayV1().Gateways(leaderElectionNs)
+		gw, err := gws.Get(ctx, leaderElectionGw, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		gw.Spec.Listeners = append(gw.Spec.Listeners, gwv1.Listener{
+			Name:     "http-alt",
+			Protocol: gwv1.HTTPProtocolType,
+			Port:     8081,
+		})
+		// a conflict with a status write is retried
+		gw, err = gws.Update(ctx, gw, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+		generation = gw.Generation
+		return nil
+	}, retry.Timeout(10*time.Second))
+	return generation
+}
+
+// gatewayStatusRecorder records every version of the test Gateway written to the API server.
+type gatewayStatusRecorder struct {
+	mu       sync.Mutex
+	statuses []gwv1.GatewayStatus
+}
+
+func recordGatewayStatuses(t *testing.T, ctx context.Context, client istiokube.CLIClient) *gatewayStatusRecorder {
+	t.Helper()
+	w, err := client.GatewayAPI().GatewayV1().Gateways(leaderElectionNs).Watch(ctx, metav1.ListOptions{
+		FieldSelector: "metadata.name=" + leaderElectionGw,
+	})
+	require.NoError(t, err)
+	t.Cleanup(w.Stop)
+
+	r := &gatewayStatusRecorder{}
+	go func() {
+		for e := range w.ResultChan() {
+			gw, ok := e.Object.(*gwv1.Gateway)
+			if !ok {
+				continue
+			}
+			r.mu.Lock()
+			r.statuses = append(r.statuses, gw.Status)
+			r.mu.Unlock()
+		}
+	}()
+	return r
+}
+
+func (r *gatewayStatusRecorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.statuses)
+}
+
+// requireNoRegression fails the test if a status was written for an older generation of the Gateway than
+// a previous one, or if the Gateway stopped being accepted after it was.
+func (r *gatewayStatusRecorder) requireNoRegression(t *testing.T) {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var generation int64
+	accepted := false
+	for i, status := range r.statuses {
+		cond := meta.FindStatusCondition(status.Conditions, string(gwv1.GatewayConditionAccepted))
+		if cond == nil {
+			require.False(t, accepted, "status %d: the Accepted condition was removed", i)
+			continue
+		}
+		require.GreaterOrEqual(t, cond.ObservedGeneration, generation, "status %d: written for an older generation", i)
+		generation = cond.ObservedGeneration
+		if accepted {
+			require.Equal(t, metav1.ConditionTrue, cond.Status, "status %d: no longer accepted: %s", i, cond.Message)
+		}
+		accepted = cond.Status == metav1.ConditionTrue
+	}
+	require.True(t, accepted, "the Gateway was never accepted")
+}
@@ -13,6 +13,7 @@ import (
 	"istio.io/istio/pkg/kube/krt"
 	"istio.io/istio/pkg/kube/kubetypes"
 	"istio.io/istio/pkg/security"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
@@ -21,6 +22,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
 	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient"
@@ -28,7 +31,10 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/pkg/deployer"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/admin"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/controller"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/health"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/proxy_syncer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/sharding"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/validate"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/xds"
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
@@ -39,6 +45,7 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
 	"github.com/kgateway-dev/kgateway/v2/pkg/schemes"
 	"github.com/kgateway-dev/kgateway/v2/pkg/utils/envutils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/eventutils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/utils/namespaces"
 	"github.com/kgateway-dev/kgateway/v2/pkg/validator"
 )
@@ -197,6 +204,7 @@ type setup struct {
 	globalSettings   *apisettings.Settings
 	leaderElectionID string
 	validator        validator.Validator
+	shard            sharding.Shard
 
 	commonCollectionsOptions []collections.Option
 	statusSyncerOptions      []proxy_syncer.StatusSyncerOption
@@ -240,7 +248,17 @@ func New(opts ...func(*setup)) (*setup, error) {
 		s.apiClient = apiClient
 	}
 
-	leaderElectionID := s.leaderElectionID
+	shard, err := sharding.ForSettings(s.globalSettings)
+	if err != nil {
+		return nil, err
+	}
+	s.shard = shard
+
+	if shard.Enabled() {
+		s.commonCollectionsOptions = append(s.commonCollectionsOptions, collections.WithGatewayFilter(func(gw *gwv1.Gateway) bool {
+			return shard.Owns(gw)
+		}))
+	}
 
 	if s.ctrlMgrOptionsInitFunc == nil {
 		s.ctrlMgrOptionsInitFunc = func(ctx context.Context) *ctrl.Options {
@@ -255,7 +273,10 @@ func New(opts ...func(*setup)) (*setup, error) {
 				},
 				LeaderElectionNamespace: namespaces.GetPodNamespace(),
 				LeaderElection:          !s.globalSettings.DisableLeaderElection,
-				LeaderElectionID:        leaderElectionID,
+				LeaderElectionID:        s.leaseName(),
+				// release the lease on shutdown so that another replica can take over
+				// leadership without waiting for the lease to expire.
+				LeaderElectionReleaseOnCancel: true,
 			}
 		}
 	}
@@ -284,6 +305,12 @@ func (s *setup) Start(ctx context.Context) error {
 	slog.Info("starting kgateway")
 
 	mgrOpts := s.ctrlMgrOptionsInitFunc(ctx)
+	if s.globalSettings.EnableValidationWebhook && mgrOpts.WebhookServer == nil {
+		mgrOpts.WebhookServer = webhook.NewServer(webhook.Options{
+			Port:    validate.WebhookPort,
+			CertDir: validate.WebhookCertDir,
+		})
+	}
 
 	metrics.SetRegistry(s.globalSettings.EnableBuiltinDefaultMetrics, nil)
 	metrics.SetActive(!(mgrOpts.Metrics.BindAddress == "" || mgrOpts.Metrics.BindAddress == "0"))
@@ -298,6 +325,17 @@ func (s *setup) Start(ctx context.Context) error {
 		return err
 	}
 
+	if s.globalSettings.EnableValidationWebhook {
+		mgr.GetWebhookServer().Register(validate.WebhookPath, &webhook.Admission{Handler: validate.NewWebhook(mgr.GetScheme())})
+	}
+
+	// events are emitted on the most specific object of a failure, at most once per interval
+	eventRecorder := eventutils.NewRateLimitedRecorder(
+		mgr.GetEventRecorderFor(s.gatewayControllerName), //nolint:staticcheck // the events.k8s.io recorder requires additional RBAC
+		mgr.GetScheme(),
+		eventutils.DefaultInterval,
+	)
+
 	uniqueClientCallbacks, uccBuilder := krtcollections.NewUniquelyConnectedClients(s.extraXDSCallbacks, s.globalSettings.XdsAuth)
 
 	authenticators := []security.Authenticator{
@@ -323,19 +361,52 @@ func (s *setup) Start(ctx context.Context) error {
 
 	// Only create Envoy control plane if Envoy controller is enabled
 	var cache envoycache.SnapshotCache
+	var proxyStatus *xds.ProxyStatusTracker
+	var snapshotStore *xds.SnapshotStore
 	if s.globalSettings.EnableEnvoy {
-		cache = NewControlPlane(ctx, s.xdsListener, uniqueClientCallbacks, authenticators, s.globalSettings.XdsAuth, certWatcher)
+		proxyStatus = xds.NewProxyStatusTracker(s.globalSettings.XdsStaleProxyThreshold)
+		go proxyStatus.Run(ctx)
+		cache = NewControlPlane(ctx, s.xdsListener, uniqueClientCallbacks, authenticators, s.globalSettings.XdsAuth, certWatcher, proxyStatus, eventRecorder)
+		if s.globalSettings.XdsSnapshotPersistence {
+			// the leader of every shard persists the snapshots of its own Gateways
+			snapshotStore = xds.NewSnapshotStore(s.apiClient.Kube(), namespaces.GetPodNamespace(), s.leaseName())
+			cache = snapshotStore.Cache(cache)
+			// proxies are served the persisted snapshots until the fresh ones are translated
+			if err := snapshotStore.Restore(ctx); err != nil {
+				slog.Error("failed to restore persisted xds snapshots", "error", err)
+			}
+		}
 	}
 
 	setupOpts := &controller.SetupOpts{
-		Cache:          cache,
-		KrtDebugger:    s.krtDebugger,
-		GlobalSettings: s.globalSettings,
-		CertWatcher:    certWatcher,
-	}
+		Cache:           cache,
+		ProxyStatus:     proxyStatus,
+		SnapshotStore:   snapshotStore,
+		KrtDebugger:     s.krtDebugger,
+		GlobalSettings:  s.globalSettings,
+		CertWatcher:     certWatcher,
+		Shard:           s.shard,
+		EventRecorder:   eventRecorder,
+		SyncTracker:     health.NewSyncTracker(),
+		GatewayRenderer: controller.NewGatewayRenderer(),
+		InputStaleness: health.NewInputStalenessTracker(
+			s.globalSettings.InputStalenessThreshold,
+			func(ctx context.Context) (string, error) {
+				// a list is served at the latest resource version of the api server
+				namespaces, err := s.apiClient.Kube().CoreV1().Namespaces().List(ctx, metav1.ListOptions{Limit: 1})
+				if err != nil {
+					return "", err
+				}
+				return namespaces.ResourceVersion, nil
+			},
+			s.apiClient.InformerResourceVersions,
+		),
+	}
+	go setupOpts.InputStaleness.Run(ctx)
 
 	slog.Info("creating krt collections")
-	krtOpts := krtutil.NewKrtOptions(ctx.Done(), setupOpts.KrtDebugger)
+	krtOpts := krtutil.NewKrtOptions(ctx.Done(), setupOpts.KrtDebugger).
+		WithCollectionMetrics(setupOpts.GlobalSettings.EnableCollectionMetrics)
 
 	commoncol, err := collections.NewCommonCollections(
 		ctx,
@@ -357,6 +428,13 @@ func (s *setup) Start(ctx context.Context) error {
 		}
 	}
 
+	if s.shard.Enabled() {
+		slog.Info("sharding gateways", "shard", s.shard.Ordinal, "shards", s.shard.Count)
+		if err := mgr.Add(sharding.NewClaimer(s.shard, s.apiClient, s.gatewayControllerName, namespaces.GetPodNamespace(), s.leaderElectionID)); err != nil {
+			return fmt.Errorf("error adding gateway shard claimer to manager: %w", err)
+		}
+	}
+
 	runnablesRegistry := make(map[string]any)
 	for _, runnable := range s.extraRunnables {
 		enabled, r := runnable(ctx, commoncol, s.globalSettings)
@@ -383,6 +461,14 @@ func (s *setup) Start(ctx context.Context) error {
 	return mgr.Start(ctx)
 }
 
+// leaseName returns the name of the leader election lease. Every shard elects its own leader.
+func (s *setup) leaseName() string {
+	if s.shard.Enabled() {
+		return sharding.LeaderElectionID(s.leaderElectionID, s.shard.Ordinal)
+	}
+	return s.leaderElectionID
+}
+
 func newXDSListener(ip string, port uint32) (net.Listener, error) {
 	bindAddr := net.TCPAddr{IP: net.ParseIP(ip), Port: int(port)}
 	return net.Listen(bindAddr.Network(), bindAddr.String())
@@ -396,7 +482,8 @@ func (s *setup) buildKgatewayWithConfig(
 	uccBuilder krtcollections.UniquelyConnectedClientsBulider,
 ) error {
 	slog.Info("creating krt collections")
-	krtOpts := krtutil.NewKrtOptions(ctx.Done(), setupOpts.KrtDebugger)
+	krtOpts := krtutil.NewKrtOptions(ctx.Done(), setupOpts.KrtDebugger).
+		WithCollectionMetrics(setupOpts.GlobalSettings.EnableCollectionMetrics)
 
 	augmentedPods, _ := krtcollections.NewPodsCollection(s.apiClient, krtOpts)
 	augmentedPodsForUcc := augmentedPods
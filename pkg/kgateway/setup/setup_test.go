@@ -481,23 +481,10 @@ func setupEnvTestAndRun(t *testing.T, globalSettings *apisettings.Settings, run
 		writer.set(nil)
 	})
 
-	testEnv := &envtest.Environment{
-		CRDDirectoryPaths: []string{
-			filepath.Join("..", "crds"),
-			filepath.Join("..", "..", "..", "install", "helm", "kgateway-crds", "templates"),
-			filepath.Join("testdata", "istio_crds_setup"),
-		},
-		ErrorIfCRDPathMissing: true,
-		// set assets dir so we can run without the makefile
-		BinaryAssetsDirectory: getAssetsDir(t),
-		// This often hangs (for unknown reasons); we don't need cleanup so just kill it almost instantly
-		ControlPlaneStopTimeout: time.Millisecond,
-		// web hook to add cluster ips to services
-	}
 	envtestutil.RunController(
 		t,
 		globalSettings,
-		testEnv,
+		newTestEnv(t),
 		nil,
 		[][]string{
 			{"default", "testdata/setup_yaml/setup.yaml"},
@@ -509,6 +496,22 @@ func setupEnvTestAndRun(t *testing.T, globalSettings *apisettings.Settings, run
 	)
 }
 
+func newTestEnv(t *testing.T) *envtest.Environment {
+	return &envtest.Environment{
+		CRDDirectoryPaths: []string{
+			filepath.Join("..", "crds"),
+			filepath.Join("..", "..", "..", "install", "helm", "kgateway-crds", "templates"),
+			filepath.Join("testdata", "istio_crds_setup"),
+		},
+		ErrorIfCRDPathMissing: true,
+		// set assets dir so we can run without the makefile
+		BinaryAssetsDirectory: getAssetsDir(t),
+		// This often hangs (for unknown reasons); we don't need cleanup so just kill it almost instantly
+		ControlPlaneStopTimeout: time.Millisecond,
+		// web hook to add cluster ips to services
+	}
+}
+
 func testScenario(
 	t *testing.T,
 	ctx context.Context,
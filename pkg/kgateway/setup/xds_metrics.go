@@ -0,0 +1,143 @@
+package setup
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	xdsserver "github.com/envoyproxy/go-control-plane/pkg/server/v3"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+)
+
+var (
+	xdsConnectedStreams = metrics.NewGauge(
+		metrics.GaugeOpts{
+			Subsystem: envoyXdsSubsystem,
+			Name:      "connected_streams",
+			Help:      "Number of xDS streams of connected envoy proxies",
+		}, []string{gwNamespaceLabel, gwNameLabel, typeURLLabel})
+	xdsAcksTotal = metrics.NewCounter(
+		metrics.CounterOpts{
+			Subsystem: envoyXdsSubsystem,
+			Name:      "acks_total",
+			Help:      "Total number of xDS responses acknowledged by envoy proxy",
+		}, []string{gwNamespaceLabel, gwNameLabel, typeURLLabel})
+	xdsPushDuration = metrics.NewHistogram(
+		metrics.HistogramOpts{
+			Subsystem:                       envoyXdsSubsystem,
+			Name:                            "push_duration_seconds",
+			Help:                            "Time between sending an xDS response and envoy proxy acknowledging or rejecting it",
+			Buckets:                         []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+			NativeHistogramBucketFactor:     1.1,
+			NativeHistogramMaxBucketNumber:  100,
+			NativeHistogramMinResetDuration: time.Hour,
+		}, []string{typeURLLabel})
+)
+
+// sentResponse is the last response sent on a stream for a type URL, waiting for an ACK or a NACK.
+type sentResponse struct {
+	nonce string
+	sent  time.Time
+}
+
+type streamMetrics struct {
+	// subscriptions are the gateway and type URLs requested on the stream, counted as connected streams
+	subscriptions map[resourceKey]struct{}
+	// pending are the responses not yet acknowledged or rejected, by type URL
+	pending map[string]sentResponse
+}
+
+// xdsMetricsCallback records the connected streams of proxies, their ACKs and the latency of the pushes.
+// NACKs are recorded by the logNackCallback.
+type xdsMetricsCallback struct {
+	xdsserver.CallbackFuncs
+	streams map[int64]*streamMetrics
+	now     func() time.Time
+
+	lock sync.Mutex
+}
+
+var _ xdsserver.Callbacks = (*xdsMetricsCallback)(nil)
+
+func newXdsMetricsCallback() *xdsMetricsCallback {
+	return &xdsMetricsCallback{
+		streams: make(map[int64]*streamMetrics),
+		now:     time.Now,
+	}
+}
+
+// OnStreamRequest implements server.Callbacks.
+func (x *xdsMetricsCallback) OnStreamRequest(streamID int64, req *discoveryv3.DiscoveryRequest) error {
+	namespace, name, ok := proxyGateway(req.GetNode())
+	if !ok {
+		return nil
+	}
+	typeURL := strings.TrimPrefix(req.GetTypeUrl(), "type.googleapis.com/")
+	key := resourceKey{
+		Namespace:       namespace,
+		Name:            name,
+		ResourceTypeUrl: typeURL,
+	}
+
+	x.lock.Lock()
+	defer x.lock.Unlock()
+	stream := x.streams[streamID]
+	if stream == nil {
+		stream = &streamMetrics{
+			subscriptions: make(map[resourceKey]struct{}),
+			pending:       make(map[string]sentResponse),
+		}
+		x.streams[streamID] = stream
+	}
+	if _, ok := stream.subscriptions[key]; !ok {
+		stream.subscriptions[key] = struct{}{}
+		xdsConnectedStreams.Add(1, toLabels(key)...)
+	}
+
+	// the initial request of a type URL has no nonce, every later one acknowledges or rejects a response
+	if req.GetResponseNonce() == "" {
+		return nil
+	}
+	if req.GetErrorDetail() == nil {
+		xdsAcksTotal.Inc(toLabels(key)...)
+	}
+	if pending, ok := stream.pending[req.GetTypeUrl()]; ok && pending.nonce == req.GetResponseNonce() {
+		delete(stream.pending, req.GetTypeUrl())
+		xdsPushDuration.Observe(x.now().Sub(pending.sent).Seconds(), metrics.Label{Name: typeURLLabel, Value: typeURL})
+	}
+	return nil
+}
+
+// OnStreamResponse implements server.Callbacks.
+func (x *xdsMetricsCallback) OnStreamResponse(_ context.Context, streamID int64, _ *discoveryv3.DiscoveryRequest, resp *discoveryv3.DiscoveryResponse) {
+	x.lock.Lock()
+	defer x.lock.Unlock()
+	stream := x.streams[streamID]
+	if stream == nil {
+		return
+	}
+	// a newer response supersedes the pending one, whose ACK is no longer expected
+	stream.pending[resp.GetTypeUrl()] = sentResponse{
+		nonce: resp.GetNonce(),
+		sent:  x.now(),
+	}
+}
+
+// OnStreamClosed implements server.Callbacks.
+func (x *xdsMetricsCallback) OnStreamClosed(streamID int64, _ *envoycorev3.Node) {
+	x.lock.Lock()
+	stream := x.streams[streamID]
+	delete(x.streams, streamID)
+	x.lock.Unlock()
+
+	if stream == nil {
+		return
+	}
+	for key := range stream.subscriptions {
+		xdsConnectedStreams.Sub(1, toLabels(key)...)
+	}
+}
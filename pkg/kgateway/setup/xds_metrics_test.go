@@ -0,0 +1,201 @@
+package setup
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	xdsserver "github.com/envoyproxy/go-control-plane/pkg/server/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/xds"
+	kmetrics "github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics/metricstest"
+	"github.com/kgateway-dev/kgateway/v2/pkg/schemes"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/eventutils"
+)
+
+// xdsTestClient is an in-memory ADS client that acknowledges or rejects the responses of the xDS server.
+type xdsTestClient struct {
+	t      *testing.T
+	stream discoveryv3.AggregatedDiscoveryService_StreamAggregatedResourcesClient
+	node   *envoycorev3.Node
+}
+
+func (c *xdsTestClient) send(version, nonce string, errorDetail *status.Status) {
+	require.NoError(c.t, c.stream.Send(&discoveryv3.DiscoveryRequest{
+		Node:          c.node,
+		TypeUrl:       resource.ClusterType,
+		VersionInfo:   version,
+		ResponseNonce: nonce,
+		ErrorDetail:   errorDetail,
+	}))
+}
+
+func (c *xdsTestClient) recv() *discoveryv3.DiscoveryResponse {
+	resp, err := c.stream.Recv()
+	require.NoError(c.t, err)
+	return resp
+}
+
+func newXdsTestServer(t *testing.T, ctx context.Context, recorder *eventutils.RateLimitedRecorder) (envoycache.SnapshotCache, *grpc.ClientConn) {
+	snapshotCache := envoycache.NewSnapshotCache(true, xds.NewNodeRoleHasher(), nil)
+	metricsCallback := newXdsMetricsCallback()
+	// a frozen clock makes the push durations deterministic
+	now := time.Now()
+	metricsCallback.now = func() time.Time { return now }
+	xdsServer := xdsserver.NewServer(ctx, snapshotCache, chainCallbacks(newLogNackCallback(recorder), metricsCallback))
+
+	lis := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	discoveryv3.RegisterAggregatedDiscoveryServiceServer(grpcServer, xdsServer)
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return snapshotCache, conn
+}
+
+func setClusterSnapshot(t *testing.T, ctx context.Context, snapshotCache envoycache.SnapshotCache, role, version string) {
+	snapshot, err := envoycache.NewSnapshot(version, map[resource.Type][]types.Resource{
+		resource.ClusterType: {&envoyclusterv3.Cluster{Name: "cluster-" + version}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, snapshotCache.SetSnapshot(ctx, role, snapshot))
+}
+
+func resetXdsMetrics() {
+	resetMetrics()
+	xdsConnectedStreams.Reset()
+	xdsAcksTotal.Reset()
+	xdsPushDuration.Reset()
+}
+
+func TestXdsMetricsAckNack(t *testing.T) {
+	resetXdsMetrics()
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	recorder := &eventutils.FakeRecorder{}
+	snapshotCache, conn := newXdsTestServer(t, ctx, eventutils.NewRateLimitedRecorder(recorder, schemes.DefaultScheme(), time.Minute))
+
+	role := owner + xds.KeyDelimiter + ns + xds.KeyDelimiter + name
+	setClusterSnapshot(t, ctx, snapshotCache, role, "1")
+
+	streamCtx, closeStream := context.WithCancel(ctx)
+	defer closeStream()
+	stream, err := discoveryv3.NewAggregatedDiscoveryServiceClient(conn).StreamAggregatedResources(streamCtx)
+	require.NoError(t, err)
+	client := &xdsTestClient{
+		t:      t,
+		stream: stream,
+		node: &envoycorev3.Node{
+			Id: "proxy",
+			Metadata: &structpb.Struct{Fields: map[string]*structpb.Value{
+				xds.RoleKey: structpb.NewStringValue(role),
+			}},
+		},
+	}
+
+	// the proxy acknowledges the first version
+	client.send("", "", nil)
+	resp := client.recv()
+	require.Equal(t, "1", resp.GetVersionInfo())
+	client.send(resp.GetVersionInfo(), resp.GetNonce(), nil)
+
+	// and rejects the second one
+	setClusterSnapshot(t, ctx, snapshotCache, role, "2")
+	resp = client.recv()
+	require.Equal(t, "2", resp.GetVersionInfo())
+	client.send("1", resp.GetNonce(), &status.Status{Message: "boom"})
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		gathered := metricstest.MustGatherMetrics(c)
+		gathered.AssertMetricsInclude("kgateway_envoy_xds_connected_streams", []metricstest.ExpectMetric{
+			&metricstest.ExpectedMetric{Labels: labels(ns, name, typeURL), Value: 1},
+		})
+		gathered.AssertMetricsInclude("kgateway_envoy_xds_acks_total", []metricstest.ExpectMetric{
+			&metricstest.ExpectedMetric{Labels: labels(ns, name, typeURL), Value: 1},
+		})
+		gathered.AssertMetricsInclude("kgateway_envoy_xds_rejects_total", []metricstest.ExpectMetric{
+			&metricstest.ExpectedMetric{Labels: labels(ns, name, typeURL), Value: 1},
+		})
+		gathered.AssertMetricLabels("kgateway_envoy_xds_push_duration_seconds", []kmetrics.Label{
+			{Name: typeURLLabel, Value: typeURL},
+		})
+		gathered.AssertMetricHistogramValue("kgateway_envoy_xds_push_duration_seconds", metricstest.HistogramMetricOutput{
+			SampleCount: 2,
+		})
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Equal(t, []eventutils.Event{{
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: wellknown.GatewayGVK.GroupVersion().String(),
+			Kind:       wellknown.GatewayKind,
+			Namespace:  ns,
+			Name:       name,
+		},
+		Type:    corev1.EventTypeWarning,
+		Reason:  xdsRejectedReason,
+		Message: "Proxy rejected the " + typeURL + " config: boom",
+	}}, recorder.Events())
+
+	// the stream is no longer counted once the proxy disconnects
+	closeStream()
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		metricstest.MustGatherMetrics(c).AssertMetricsInclude("kgateway_envoy_xds_connected_streams", []metricstest.ExpectMetric{
+			&metricstest.ExpectedMetric{Labels: labels(ns, name, typeURL), Value: 0},
+		})
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestXdsMetricsPushDuration(t *testing.T) {
+	resetXdsMetrics()
+	cb := newXdsMetricsCallback()
+	now := time.Now()
+	cb.now = func() time.Time { return now }
+
+	require.NoError(t, cb.OnStreamRequest(1, dr(fullType, nil)))
+	cb.OnStreamResponse(t.Context(), 1, nil, &discoveryv3.DiscoveryResponse{TypeUrl: fullType, Nonce: "a"})
+	// a newer response supersedes the pending one
+	now = now.Add(time.Second)
+	cb.OnStreamResponse(t.Context(), 1, nil, &discoveryv3.DiscoveryResponse{TypeUrl: fullType, Nonce: "b"})
+
+	now = now.Add(time.Second)
+	ack := dr(fullType, nil)
+	ack.ResponseNonce = "a"
+	require.NoError(t, cb.OnStreamRequest(1, ack))
+	ack.ResponseNonce = "b"
+	require.NoError(t, cb.OnStreamRequest(1, ack))
+
+	gathered := metricstest.MustGatherMetrics(t)
+	gathered.AssertMetricHistogramValue("kgateway_envoy_xds_push_duration_seconds", metricstest.HistogramMetricOutput{
+		SampleCount: 1,
+		SampleSum:   1,
+	})
+	gathered.AssertMetricsInclude("kgateway_envoy_xds_acks_total", []metricstest.ExpectMetric{
+		&metricstest.ExpectedMetric{Labels: labels(ns, name, typeURL), Value: 2},
+	})
+}
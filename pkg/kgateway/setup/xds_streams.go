@@ -0,0 +1,142 @@
+package setup
+
+import (
+	"context"
+	"sync"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	xdsserver "github.com/envoyproxy/go-control-plane/pkg/server/v3"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/xds"
+)
+
+// xdsStreams tracks the open xDS streams by the cache key of the node they serve, so that the
+// streams of a proxy can be closed once its snapshot is removed from the cache. Clearing a
+// snapshot also drops the watches of its streams, which would otherwise never get a response.
+type xdsStreams struct {
+	xdsserver.CallbackFuncs
+	nodeHash envoycache.NodeHash
+
+	mu      sync.Mutex
+	streams map[int64]*xdsStream
+}
+
+type xdsStream struct {
+	key    string
+	cancel context.CancelFunc
+	closed bool
+}
+
+type xdsStreamCtxKey struct{}
+
+var errXdsStreamClosed = status.Error(codes.Unavailable, "xds stream closed: proxy configuration was removed")
+
+var _ xdsserver.Callbacks = (*xdsStreams)(nil)
+
+func newXdsStreams() *xdsStreams {
+	return &xdsStreams{
+		nodeHash: xds.NewNodeRoleHasher(),
+		streams:  make(map[int64]*xdsStream),
+	}
+}
+
+// interceptor makes the streams closable. The stream is served until the handler returns or
+// the stream is closed by closeStreams, in which case the client is asked to reconnect.
+func (s *xdsStreams) interceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, cancel := context.WithCancel(ss.Context())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- handler(srv, &grpc_middleware.WrappedServerStream{
+			ServerStream:   ss,
+			WrappedContext: context.WithValue(ctx, xdsStreamCtxKey{}, &xdsStream{cancel: cancel}),
+		})
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if ss.Context().Err() != nil {
+			// the client went away, the handler returns on its own
+			return <-done
+		}
+		// returning ends the RPC, which in turn makes the handler return
+		return errXdsStreamClosed
+	}
+}
+
+// OnStreamOpen implements server.Callbacks.
+func (s *xdsStreams) OnStreamOpen(ctx context.Context, streamID int64, _ string) error {
+	stream, ok := ctx.Value(xdsStreamCtxKey{}).(*xdsStream)
+	if !ok {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.streams[streamID] = stream
+	return nil
+}
+
+// OnStreamRequest implements server.Callbacks.
+func (s *xdsStreams) OnStreamRequest(streamID int64, req *discoveryv3.DiscoveryRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stream, ok := s.streams[streamID]
+	if !ok {
+		return nil
+	}
+	if stream.closed {
+		// requests still in flight must not create watches for the removed snapshot
+		return errXdsStreamClosed
+	}
+	if req.GetNode() != nil {
+		// must run after the unique clients callbacks, which rewrite the role of the node
+		// to the cache key of the client
+		stream.key = s.nodeHash.ID(req.GetNode())
+	}
+	return nil
+}
+
+// OnStreamClosed implements server.Callbacks.
+func (s *xdsStreams) OnStreamClosed(streamID int64, _ *envoycorev3.Node) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.streams, streamID)
+}
+
+// closeStreams closes the streams serving the given cache key and returns how many were closed.
+func (s *xdsStreams) closeStreams(key string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	closed := 0
+	for _, stream := range s.streams {
+		if stream.key == key && !stream.closed {
+			stream.closed = true
+			stream.cancel()
+			closed++
+		}
+	}
+	return closed
+}
+
+// streamClosingCache is a snapshot cache that closes the streams of a node when its snapshot is cleared.
+type streamClosingCache struct {
+	envoycache.SnapshotCache
+	streams *xdsStreams
+}
+
+// ClearSnapshot removes the snapshot of the node and closes its streams.
+func (c *streamClosingCache) ClearSnapshot(node string) {
+	c.SnapshotCache.ClearSnapshot(node)
+	if closed := c.streams.closeStreams(node); closed > 0 {
+		logger.Info("closed xds streams of removed proxy", "proxy_key", node, "streams", closed)
+	}
+}
@@ -0,0 +1,151 @@
+package setup
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"runtime"
+	"testing"
+	"time"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	envoycachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	xdsserver "github.com/envoyproxy/go-control-plane/pkg/server/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/xds"
+)
+
+type xdsStreamsTest struct {
+	cache *streamClosingCache
+	srv   *grpc.Server
+	conn  *grpc.ClientConn
+}
+
+func newXdsStreamsTest(t *testing.T, ctx context.Context) *xdsStreamsTest {
+	t.Helper()
+
+	streams := newXdsStreams()
+	cache := &streamClosingCache{
+		SnapshotCache: envoycache.NewSnapshotCache(true, xds.NewNodeRoleHasher(), nil),
+		streams:       streams,
+	}
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer(grpc.StreamInterceptor(streams.interceptor))
+	discoveryv3.RegisterAggregatedDiscoveryServiceServer(srv, xdsserver.NewServer(ctx, cache, chainCallbacks(streams)))
+	go srv.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	return &xdsStreamsTest{cache: cache, srv: srv, conn: conn}
+}
+
+func (x *xdsStreamsTest) close() {
+	x.conn.Close()
+	x.srv.Stop()
+}
+
+func (x *xdsStreamsTest) setSnapshot(t *testing.T, key, version string) {
+	t.Helper()
+	snap, err := envoycache.NewSnapshot(version, map[resource.Type][]envoycachetypes.Resource{
+		resource.ListenerType: {&envoylistenerv3.Listener{Name: "listener"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, x.cache.SetSnapshot(context.Background(), key, snap))
+}
+
+// connect opens an ADS stream for the proxy with the given key and acks its listeners.
+func (x *xdsStreamsTest) connect(t *testing.T, ctx context.Context, key string) discoveryv3.AggregatedDiscoveryService_StreamAggregatedResourcesClient {
+	t.Helper()
+	stream, err := discoveryv3.NewAggregatedDiscoveryServiceClient(x.conn).StreamAggregatedResources(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&discoveryv3.DiscoveryRequest{
+		Node: &envoycorev3.Node{
+			Id: key,
+			Metadata: &structpb.Struct{Fields: map[string]*structpb.Value{
+				xds.RoleKey: structpb.NewStringValue(key),
+			}},
+		},
+		TypeUrl: resource.ListenerType,
+	}))
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Len(t, resp.GetResources(), 1)
+	// ack the response, so that the stream watches the next version
+	require.NoError(t, stream.Send(&discoveryv3.DiscoveryRequest{
+		VersionInfo:   resp.GetVersionInfo(),
+		ResponseNonce: resp.GetNonce(),
+		TypeUrl:       resource.ListenerType,
+	}))
+	require.Eventually(t, func() bool {
+		info := x.cache.GetStatusInfo(key)
+		return info != nil && info.GetNumWatches() == 1
+	}, 5*time.Second, time.Millisecond, "the ack should be processed")
+	return stream
+}
+
+func TestClearSnapshotClosesStreams(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		x := newXdsStreamsTest(t, ctx)
+		defer x.close()
+
+		// a proxy that stays connected while other gateways come and go
+		survivorKey := "kgateway-kube-gateway-api~default~survivor"
+		x.setSnapshot(t, survivorKey, "1")
+		survivor := x.connect(t, ctx, survivorKey)
+
+		for i := range 100 {
+			key := fmt.Sprintf("kgateway-kube-gateway-api~default~gw-%d", i)
+			x.setSnapshot(t, key, "1")
+			stream := x.connect(t, ctx, key)
+
+			x.cache.ClearSnapshot(key)
+
+			_, err := stream.Recv()
+			require.Error(t, err, "stream of gateway %d should be closed", i)
+			assert.Equal(t, codes.Unavailable, status.Code(err), "proxies should be asked to reconnect")
+		}
+		assert.Equal(t, []string{survivorKey}, x.cache.GetStatusKeys())
+
+		// the streams of other proxies are unaffected
+		x.setSnapshot(t, survivorKey, "2")
+		resp, err := survivor.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, "2", resp.GetVersionInfo())
+
+		x.cache.ClearSnapshot(survivorKey)
+		_, err = survivor.Recv()
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Empty(t, x.cache.GetStatusKeys())
+
+		x.cache.streams.mu.Lock()
+		assert.Empty(t, x.cache.streams.streams, "closed streams should be forgotten")
+		x.cache.streams.mu.Unlock()
+	}()
+
+	// not using Eventually, as it runs the condition in a goroutine of its own
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), baseline, "goroutines should return to baseline")
+}
@@ -0,0 +1,183 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"istio.io/istio/pkg/config/schema/gvr"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/kube/kclient"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient"
+	"github.com/kgateway-dev/kgateway/v2/pkg/logging"
+)
+
+var logger = logging.New("sharding")
+
+// removedShardsResyncInterval is how often the Gateways owned by removed shards are reconciled, as the
+// leader lease of a removed shard expires without an event.
+const removedShardsResyncInterval = 30 * time.Second
+
+// Claimer claims unowned Gateways assigned to this shard and hands off the Gateways this shard
+// no longer is assigned to by updating their ShardAnnotation. It releases the Gateways this shard
+// owns by removing their ShardAnnotation when it shuts down.
+//
+// A Gateway owned by a removed shard is also claimed once the leader lease of that shard is no longer
+// held, in case the removed shard stopped without releasing it.
+type Claimer struct {
+	shard          Shard
+	controllerName string
+	leaseNamespace string
+	leaseBase      string
+
+	kube          kubernetes.Interface
+	gwClient      kclient.Client[*gwv1.Gateway]
+	gwClassClient kclient.Client[*gwv1.GatewayClass]
+
+	queue          controllers.Queue
+	resyncInterval time.Duration
+}
+
+// NewClaimer returns a Claimer for the Gateways of the given controller. The leader leases of the shards
+// are named after leaseBase (see LeaderElectionID) in leaseNamespace.
+func NewClaimer(shard Shard, client apiclient.Client, controllerName, leaseNamespace, leaseBase string) *Claimer {
+	filter := kclient.Filter{ObjectFilter: client.ObjectFilter()}
+	c := &Claimer{
+		shard:          shard,
+		controllerName: controllerName,
+		leaseNamespace: leaseNamespace,
+		leaseBase:      leaseBase,
+		kube:           client.Kube(),
+		gwClient:       kclient.NewFilteredDelayed[*gwv1.Gateway](client, gvr.KubernetesGateway, filter),
+		gwClassClient:  kclient.NewFilteredDelayed[*gwv1.GatewayClass](client, gvr.GatewayClass, filter),
+		resyncInterval: removedShardsResyncInterval,
+	}
+	c.queue = controllers.NewQueue("GatewayShardClaimer", controllers.WithReconciler(c.reconcile), controllers.WithMaxAttempts(math.MaxInt))
+	c.gwClient.AddEventHandler(controllers.ObjectHandler(c.queue.AddObject))
+	return c
+}
+
+// NeedLeaderElection returns true to ensure that only the leader of the shard updates Gateway ownership
+func (c *Claimer) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs the Claimer until the context is canceled.
+func (c *Claimer) Start(ctx context.Context) error {
+	kube.WaitForCacheSync("GatewayShardClaimer", ctx.Done(), c.gwClient.HasSynced, c.gwClassClient.HasSynced)
+	go c.resyncRemovedShards(ctx)
+	c.queue.Run(ctx.Done())
+	c.release()
+	controllers.ShutdownAll(c.gwClient, c.gwClassClient)
+	return nil
+}
+
+// release removes the ShardAnnotation from the Gateways this shard owns. This shard may be shutting down
+// because it was removed on scale-down, in which case its Gateways are claimed by the shards they are now
+// assigned to. If this replica is only restarted, it claims them back once it's up again.
+func (c *Claimer) release() {
+	for _, gw := range c.gwClient.List(metav1.NamespaceAll, klabels.Everything()) {
+		if !c.shard.Owns(gw) {
+			continue
+		}
+		// the patch only applies while this shard still owns the Gateway, and doesn't conflict with
+		// the status updates made in the meantime
+		path := "/metadata/annotations/" + strings.ReplaceAll(ShardAnnotation, "/", "~1")
+		patch := fmt.Sprintf(`[{"op":"test","path":%q,"value":%q},{"op":"remove","path":%q}]`,
+			path, strconv.Itoa(c.shard.Ordinal), path)
+		ref := types.NamespacedName{Namespace: gw.Namespace, Name: gw.Name}
+		if _, err := c.gwClient.Patch(gw.Name, gw.Namespace, types.JSONPatchType, []byte(patch)); err != nil {
+			logger.Error("failed to release gateway", "ref", ref, "error", err)
+			continue
+		}
+		logger.Info("released gateway", "ref", ref, "shard", c.shard.Ordinal)
+	}
+}
+
+// resyncRemovedShards periodically requeues the Gateways owned by removed shards until the context is
+// canceled, so that they are claimed once the leases of the removed shards expired.
+func (c *Claimer) resyncRemovedShards(ctx context.Context) {
+	ticker := time.NewTicker(c.resyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for _, gw := range c.gwClient.List(metav1.NamespaceAll, klabels.Everything()) {
+			if owner, ok := ownerOf(gw); ok && owner >= c.shard.Count {
+				c.queue.AddObject(gw)
+			}
+		}
+	}
+}
+
+// shardAlive returns true if the leader lease of the shard is held.
+func (c *Claimer) shardAlive(ordinal int) (bool, error) {
+	lease, err := c.kube.CoordinationV1().Leases(c.leaseNamespace).Get(context.Background(), LeaderElectionID(c.leaseBase, ordinal), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return leaseHeld(lease, time.Now()), nil
+}
+
+func (c *Claimer) reconcile(req types.NamespacedName) error {
+	gw := c.gwClient.Get(req.Name, req.Namespace)
+	if gw == nil || gw.GetDeletionTimestamp() != nil {
+		return nil
+	}
+	gwc := c.gwClassClient.Get(string(gw.Spec.GatewayClassName), "")
+	if gwc == nil || gwc.Spec.ControllerName != gwv1.GatewayController(c.controllerName) {
+		return nil
+	}
+
+	var leaseErr error
+	action, target := c.shard.Reconcile(gw, func(ordinal int) bool {
+		alive, err := c.shardAlive(ordinal)
+		if err != nil {
+			leaseErr = err
+			return true
+		}
+		return alive
+	})
+	if leaseErr != nil {
+		return fmt.Errorf("failed to get the leader lease of the owner of gateway %s: %w", req, leaseErr)
+	}
+	if action == ActionNone {
+		return nil
+	}
+
+	updated := gw.DeepCopy()
+	updated.Annotations = maps.Clone(gw.Annotations)
+	if updated.Annotations == nil {
+		updated.Annotations = map[string]string{}
+	}
+	updated.Annotations[ShardAnnotation] = strconv.Itoa(target)
+	// the update carries the resourceVersion of the Gateway we read, so that it fails with a conflict
+	// if another shard changed the ownership in the meantime
+	if _, err := c.gwClient.Update(updated); err != nil {
+		return err
+	}
+	if action == ActionClaim {
+		logger.Info("claimed gateway", "ref", req, "shard", target)
+	} else {
+		logger.Info("handed off gateway", "ref", req, "shard", target)
+	}
+	return nil
+}
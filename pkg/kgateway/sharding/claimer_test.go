@@ -0,0 +1,143 @@
+package sharding
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient/fake"
+)
+
+const (
+	testControllerName = "kgateway.dev/kgateway"
+	testLeaseNamespace = "kgateway-system"
+	testLeaseBase      = "kgateway"
+)
+
+func TestClaimerReleasesGatewaysOnShutdown(t *testing.T) {
+	shard := Shard{Ordinal: 1, Count: 2}
+	objs := []client.Object{&gwv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "kgateway"},
+		Spec:       gwv1.GatewayClassSpec{ControllerName: testControllerName},
+	}}
+	// gateways already owned by the shard they are assigned to, so that the claimer leaves them alone
+	owners := map[types.NamespacedName]int{}
+	for _, gw := range testGateways(10) {
+		owner := Assign(gw.Namespace, gw.Name, shard.Count)
+		gw.Spec.GatewayClassName = "kgateway"
+		gw.Annotations = map[string]string{ShardAnnotation: strconv.Itoa(owner)}
+		owners[types.NamespacedName{Namespace: gw.Namespace, Name: gw.Name}] = owner
+		objs = append(objs, gw)
+	}
+
+	cli := fake.NewClient(t, objs...)
+	claimer := NewClaimer(shard, cli, testControllerName, testLeaseNamespace, testLeaseBase)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		claimer.Start(ctx)
+	}()
+	cli.RunAndWait(ctx.Done())
+	require.Eventually(t, claimer.queue.HasSynced, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-stopped
+
+	released := 0
+	for ref, owner := range owners {
+		gw, err := cli.GatewayAPI().GatewayV1().Gateways(ref.Namespace).Get(context.Background(), ref.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		if owner == shard.Ordinal {
+			assert.NotContains(t, gw.Annotations, ShardAnnotation, "gateway %s should be released", ref)
+			released++
+		} else {
+			assert.Equal(t, strconv.Itoa(owner), gw.Annotations[ShardAnnotation], "gateway %s of another shard should be kept", ref)
+		}
+	}
+	assert.Positive(t, released)
+}
+
+func TestClaimerClaimsGatewaysOfCrashedRemovedShard(t *testing.T) {
+	shard := Shard{Ordinal: 0, Count: 2}
+	// the lease of the removed shard 2 is still held
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: LeaderElectionID(testLeaseBase, 2), Namespace: testLeaseNamespace},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       new("kgateway-2"),
+			LeaseDurationSeconds: new(int32(15)),
+			RenewTime:            new(metav1.NowMicro()),
+		},
+	}
+	objs := []client.Object{lease, &gwv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "kgateway"},
+		Spec:       gwv1.GatewayClassSpec{ControllerName: testControllerName},
+	}}
+	// gateways of the removed shard, which stopped without releasing them
+	targets := map[types.NamespacedName]int{}
+	for _, gw := range testGateways(10) {
+		gw.Spec.GatewayClassName = "kgateway"
+		gw.Annotations = map[string]string{ShardAnnotation: "2"}
+		targets[types.NamespacedName{Namespace: gw.Namespace, Name: gw.Name}] = Assign(gw.Namespace, gw.Name, shard.Count)
+		objs = append(objs, gw)
+	}
+
+	cli := fake.NewClient(t, objs...)
+	claimer := NewClaimer(shard, cli, testControllerName, testLeaseNamespace, testLeaseBase)
+	claimer.resyncInterval = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go claimer.Start(ctx)
+	cli.RunAndWait(ctx.Done())
+	require.Eventually(t, claimer.queue.HasSynced, 5*time.Second, 10*time.Millisecond)
+
+	owners := func() map[types.NamespacedName]string {
+		owners := map[types.NamespacedName]string{}
+		for ref := range targets {
+			gw, err := cli.GatewayAPI().GatewayV1().Gateways(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+			owners[ref] = gw.Annotations[ShardAnnotation]
+		}
+		return owners
+	}
+
+	// the removed shard may still be running with the previous shard count
+	assert.Never(t, func() bool {
+		for _, owner := range owners() {
+			if owner != "2" {
+				return true
+			}
+		}
+		return false
+	}, 200*time.Millisecond, 20*time.Millisecond)
+
+	// the lease expires as the removed shard doesn't renew it
+	lease.Spec.RenewTime = new(metav1.NewMicroTime(time.Now().Add(-time.Minute)))
+	_, err := cli.Kube().CoordinationV1().Leases(testLeaseNamespace).Update(ctx, lease, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	claimed := 0
+	for ref, target := range targets {
+		if target == shard.Ordinal {
+			claimed++
+		}
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			owner := owners()[ref]
+			if target == shard.Ordinal {
+				assert.Equal(c, "0", owner, "gateway %s should be claimed", ref)
+			} else {
+				assert.Equal(c, "2", owner, "gateway %s is claimed by the shard it's assigned to", ref)
+			}
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+	assert.Positive(t, claimed)
+}
@@ -0,0 +1,184 @@
+// Package sharding splits the Gateways managed by kgateway across multiple controller replicas.
+//
+// When sharding is enabled, the controller runs as a StatefulSet and each replica serves one shard,
+// identified by its StatefulSet ordinal. Every Gateway is assigned to exactly one shard using a
+// consistent hash of its namespace and name, so that scaling from N to N+1 shards only moves the
+// Gateways that are assigned to the new shard.
+//
+// The assignment is only a target: a shard owns a Gateway once the Gateway carries the ShardAnnotation
+// with the ordinal of the shard. Ownership moves between shards through the annotation only (see
+// Shard.Reconcile), which guarantees that a Gateway is never owned by two shards at the same time,
+// even while replicas are being rolled and disagree on the number of shards. A shard releases the
+// Gateways it owns when it shuts down, so that the Gateways of a shard removed on scale-down are
+// claimed by the remaining shards. If a removed shard stopped without releasing them, they are claimed
+// once its leader lease expired.
+package sharding
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
+)
+
+// ShardAnnotation is set on Gateways to record the ordinal of the shard that owns them.
+const ShardAnnotation = "kgateway.dev/shard"
+
+// Shard identifies the shard served by this controller replica.
+type Shard struct {
+	// Ordinal is the StatefulSet ordinal of this replica.
+	Ordinal int
+	// Count is the total number of shards. Sharding is disabled if it is less than 2.
+	Count int
+}
+
+// ForSettings returns the shard served by this replica. The ordinal is derived from the hostname,
+// which is the pod name for StatefulSet pods.
+func ForSettings(s *apisettings.Settings) (Shard, error) {
+	if s.GatewayShards < 2 {
+		return Shard{}, nil
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return Shard{}, fmt.Errorf("unable to determine shard ordinal: %w", err)
+	}
+	return FromPodName(hostname, int(s.GatewayShards))
+}
+
+// FromPodName returns the shard for a StatefulSet pod name of the form <statefulset>-<ordinal>.
+func FromPodName(podName string, count int) (Shard, error) {
+	idx := strings.LastIndex(podName, "-")
+	if idx < 0 {
+		return Shard{}, fmt.Errorf("unable to determine shard ordinal from pod name %q: not a StatefulSet pod", podName)
+	}
+	ordinal, err := strconv.Atoi(podName[idx+1:])
+	if err != nil || ordinal < 0 {
+		return Shard{}, fmt.Errorf("unable to determine shard ordinal from pod name %q: not a StatefulSet pod", podName)
+	}
+	if ordinal >= count {
+		return Shard{}, fmt.Errorf("shard ordinal %d of pod %q is out of range for %d shards", ordinal, podName, count)
+	}
+	return Shard{Ordinal: ordinal, Count: count}, nil
+}
+
+// Enabled returns true if Gateways are sharded across replicas.
+func (s Shard) Enabled() bool {
+	return s.Count > 1
+}
+
+// Owns returns true if the Gateway is owned by this shard and must be translated, deployed and
+// served by this replica. All Gateways are owned when sharding is disabled.
+func (s Shard) Owns(gw metav1.Object) bool {
+	if !s.Enabled() {
+		return true
+	}
+	owner, ok := ownerOf(gw)
+	return ok && owner == s.Ordinal
+}
+
+// Action is the change a shard must make to the ownership of a Gateway.
+type Action int
+
+const (
+	// ActionNone means that the ownership of the Gateway must not be changed by this shard.
+	ActionNone Action = iota
+	// ActionClaim means that this shard must take ownership of a Gateway without an owner.
+	ActionClaim
+	// ActionHandOff means that this shard must hand the Gateway it owns off to another shard.
+	ActionHandOff
+)
+
+// Reconcile returns the ownership change this shard must make for the Gateway, along with the
+// ordinal of the shard that must own the Gateway after the change.
+//
+// Only the current owner may hand a Gateway off, and only unowned Gateways may be claimed.
+// While resharding, the target shard therefore only starts serving a Gateway once the previous owner,
+// running with the new shard count, has stopped serving it. Envoy keeps the last configuration it
+// received until its proxy is redirected to the new shard's xDS Service, so the handoff does not
+// cause a config gap.
+//
+// Gateways owned by a shard that was removed on scale-down are not claimed while ownerAlive reports
+// the removed shard as running, as it may still be running with the previous shard count. They are
+// claimed once it released them on shutdown, or once it is gone if it stopped without releasing them.
+func (s Shard) Reconcile(gw metav1.Object, ownerAlive func(ordinal int) bool) (Action, int) {
+	if !s.Enabled() {
+		return ActionNone, 0
+	}
+	target := Assign(gw.GetNamespace(), gw.GetName(), s.Count)
+	owner, ok := ownerOf(gw)
+	switch {
+	case !ok || (owner >= s.Count && !ownerAlive(owner)):
+		if target == s.Ordinal {
+			return ActionClaim, target
+		}
+	case owner == s.Ordinal && target != s.Ordinal:
+		return ActionHandOff, target
+	}
+	return ActionNone, 0
+}
+
+// Assign returns the ordinal of the shard a Gateway is assigned to.
+func Assign(namespace, name string, count int) int {
+	if count < 2 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(namespace))
+	h.Write([]byte{'/'})
+	h.Write([]byte(name))
+	return jumpHash(h.Sum64(), count)
+}
+
+// XdsServiceName returns the name of the Service that serves xDS config for a shard.
+// It must select the shard's pod by its statefulset.kubernetes.io/pod-name label.
+func XdsServiceName(base string, ordinal int) string {
+	return fmt.Sprintf("%s-%d", base, ordinal)
+}
+
+// LeaderElectionID returns the leader lease name for a shard, so that every shard elects its own leader.
+func LeaderElectionID(base string, ordinal int) string {
+	return fmt.Sprintf("%s-shard-%d", base, ordinal)
+}
+
+// leaseHeld returns true if the leader lease of a shard is held and was renewed recently enough for its
+// holder to still be running. The holder is given twice the lease duration to tolerate clock skew
+// between replicas, as the renew time is set from the clock of the holder.
+func leaseHeld(lease *coordinationv1.Lease, now time.Time) bool {
+	spec := lease.Spec
+	if spec.HolderIdentity == nil || *spec.HolderIdentity == "" || spec.RenewTime == nil || spec.LeaseDurationSeconds == nil {
+		return false
+	}
+	duration := time.Duration(*spec.LeaseDurationSeconds) * time.Second
+	return now.Before(spec.RenewTime.Add(2 * duration))
+}
+
+func ownerOf(gw metav1.Object) (int, bool) {
+	v, ok := gw.GetAnnotations()[ShardAnnotation]
+	if !ok {
+		return 0, false
+	}
+	owner, err := strconv.Atoi(v)
+	if err != nil || owner < 0 {
+		return 0, false
+	}
+	return owner, true
+}
+
+// jumpHash implements the jump consistent hash from "A Fast, Minimal Memory, Consistent Hash Algorithm"
+// (Lamping and Veach). When the number of buckets grows from n to n+1, keys only move to the new bucket.
+func jumpHash(key uint64, buckets int) int {
+	var b, j int64 = -1, 0
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
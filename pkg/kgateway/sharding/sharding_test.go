@@ -0,0 +1,297 @@
+package sharding
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// alive reports every shard as running.
+func alive(int) bool { return true }
+
+func testGateways(n int) []*gwv1.Gateway {
+	gws := make([]*gwv1.Gateway, 0, n)
+	for i := range n {
+		gws = append(gws, &gwv1.Gateway{ObjectMeta: metav1.ObjectMeta{
+			Namespace: fmt.Sprintf("ns-%d", i%7),
+			Name:      fmt.Sprintf("gw-%d", i),
+		}})
+	}
+	return gws
+}
+
+func TestFromPodName(t *testing.T) {
+	shard, err := FromPodName("kgateway-2", 3)
+	require.NoError(t, err)
+	assert.Equal(t, Shard{Ordinal: 2, Count: 3}, shard)
+	assert.True(t, shard.Enabled())
+
+	_, err = FromPodName("kgateway-7d9f8b6c4-x2v9k", 3)
+	require.EqualError(t, err, `unable to determine shard ordinal from pod name "kgateway-7d9f8b6c4-x2v9k": not a StatefulSet pod`)
+
+	_, err = FromPodName("kgateway-3", 3)
+	require.EqualError(t, err, `shard ordinal 3 of pod "kgateway-3" is out of range for 3 shards`)
+}
+
+func TestAssignIsStable(t *testing.T) {
+	gws := testGateways(500)
+
+	for count := 2; count <= 8; count++ {
+		perShard := make([]int, count)
+		for _, gw := range gws {
+			shard := Assign(gw.Namespace, gw.Name, count)
+			require.Equal(t, shard, Assign(gw.Namespace, gw.Name, count), "assignment must be deterministic")
+			require.GreaterOrEqual(t, shard, 0)
+			require.Less(t, shard, count)
+			perShard[shard]++
+
+			// on scale-up, a gateway either stays on its shard or moves to the new shard
+			next := Assign(gw.Namespace, gw.Name, count+1)
+			if next != shard {
+				assert.Equal(t, count, next, "gateway %s/%s moved between existing shards", gw.Namespace, gw.Name)
+			}
+		}
+		for i, n := range perShard {
+			assert.InDelta(t, len(gws)/count, n, float64(len(gws))/float64(count)/2, "shard %d of %d is unbalanced", i, count)
+		}
+	}
+}
+
+func TestOwns(t *testing.T) {
+	gw := &gwv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw"}}
+	assert.True(t, Shard{}.Owns(gw), "all gateways are owned when sharding is disabled")
+	assert.False(t, Shard{Ordinal: 0, Count: 2}.Owns(gw), "unclaimed gateways are not owned")
+
+	gw.Annotations = map[string]string{ShardAnnotation: "1"}
+	assert.False(t, Shard{Ordinal: 0, Count: 2}.Owns(gw))
+	assert.True(t, Shard{Ordinal: 1, Count: 2}.Owns(gw))
+}
+
+func TestHandOffSequencing(t *testing.T) {
+	// find a gateway that moves from an existing shard to the new shard when scaling from 3 to 4 shards
+	var gw *gwv1.Gateway
+	for _, candidate := range testGateways(100) {
+		if Assign(candidate.Namespace, candidate.Name, 4) == 3 {
+			gw = candidate
+			break
+		}
+	}
+	require.NotNil(t, gw)
+	from := Assign(gw.Namespace, gw.Name, 3)
+
+	oldOwner := Shard{Ordinal: from, Count: 3}
+	action, target := oldOwner.Reconcile(gw, alive)
+	require.Equal(t, ActionClaim, action)
+	gw.Annotations = map[string]string{ShardAnnotation: strconv.Itoa(target)}
+	require.True(t, oldOwner.Owns(gw))
+
+	// the new shard must not take over while the owner still runs with the old shard count
+	newShard := Shard{Ordinal: 3, Count: 4}
+	action, _ = newShard.Reconcile(gw, alive)
+	assert.Equal(t, ActionNone, action)
+	assert.False(t, newShard.Owns(gw))
+	action, _ = oldOwner.Reconcile(gw, alive)
+	assert.Equal(t, ActionNone, action)
+
+	// once restarted with the new shard count, the owner hands the gateway off
+	restartedOwner := Shard{Ordinal: from, Count: 4}
+	action, target = restartedOwner.Reconcile(gw, alive)
+	require.Equal(t, ActionHandOff, action)
+	require.Equal(t, 3, target)
+	gw.Annotations[ShardAnnotation] = strconv.Itoa(target)
+
+	assert.False(t, restartedOwner.Owns(gw))
+	assert.True(t, newShard.Owns(gw))
+	action, _ = newShard.Reconcile(gw, alive)
+	assert.Equal(t, ActionNone, action)
+}
+
+func TestGatewaysOfRemovedShardAreClaimedOnceReleased(t *testing.T) {
+	for _, gw := range testGateways(50) {
+		gw.Annotations = map[string]string{ShardAnnotation: "3"}
+		target := Assign(gw.Namespace, gw.Name, 3)
+		for ordinal := range 3 {
+			// the removed shard may still be running with the previous shard count
+			action, _ := Shard{Ordinal: ordinal, Count: 3}.Reconcile(gw, alive)
+			assert.Equal(t, ActionNone, action)
+		}
+
+		delete(gw.Annotations, ShardAnnotation)
+		for ordinal := range 3 {
+			action, claimed := Shard{Ordinal: ordinal, Count: 3}.Reconcile(gw, alive)
+			if ordinal == target {
+				assert.Equal(t, ActionClaim, action)
+				assert.Equal(t, target, claimed)
+			} else {
+				assert.Equal(t, ActionNone, action)
+			}
+		}
+	}
+}
+
+func TestGatewaysOfCrashedRemovedShardAreClaimed(t *testing.T) {
+	gone := func(int) bool { return false }
+	for _, gw := range testGateways(50) {
+		// the removed shard stopped without releasing the gateway
+		gw.Annotations = map[string]string{ShardAnnotation: "3"}
+		target := Assign(gw.Namespace, gw.Name, 3)
+		for ordinal := range 3 {
+			action, claimed := Shard{Ordinal: ordinal, Count: 3}.Reconcile(gw, gone)
+			if ordinal == target {
+				assert.Equal(t, ActionClaim, action)
+				assert.Equal(t, target, claimed)
+			} else {
+				assert.Equal(t, ActionNone, action)
+			}
+		}
+	}
+}
+
+// ownershipSim simulates the shards of a StatefulSet reconciling the ownership of Gateways, and checks
+// that every gateway is owned by at most one running shard after every write.
+type ownershipSim struct {
+	t   *testing.T
+	gws []*gwv1.Gateway
+	// replicas by ordinal
+	replicas map[int]Shard
+}
+
+func newOwnershipSim(t *testing.T, gws []*gwv1.Gateway, count int) *ownershipSim {
+	sim := &ownershipSim{t: t, gws: gws, replicas: map[int]Shard{}}
+	for i := range count {
+		sim.replicas[i] = Shard{Ordinal: i, Count: count}
+	}
+	return sim
+}
+
+// assertSingleOwner checks that every gateway is owned by at most one shard, and by exactly one shard
+// once claimed.
+func (sim *ownershipSim) assertSingleOwner(step string, claimed bool) {
+	for _, gw := range sim.gws {
+		owners := 0
+		for _, r := range sim.replicas {
+			if r.Owns(gw) {
+				owners++
+			}
+		}
+		require.LessOrEqual(sim.t, owners, 1, "%s: gateway %s/%s has %d owners", step, gw.Namespace, gw.Name, owners)
+		if claimed {
+			require.Equal(sim.t, 1, owners, "%s: gateway %s/%s is not owned", step, gw.Namespace, gw.Name)
+		}
+	}
+}
+
+// reconcile applies ownership changes one at a time, checking the invariant after every write. Once
+// the shards settled, every gateway must be owned unless some are owned by a stopped shard.
+func (sim *ownershipSim) reconcile(step string, settled bool) {
+	for changed := true; changed; {
+		changed = false
+		for _, r := range sim.replicas {
+			for _, gw := range sim.gws {
+				action, target := r.Reconcile(gw, sim.running)
+				if action == ActionNone {
+					continue
+				}
+				if gw.Annotations == nil {
+					gw.Annotations = map[string]string{}
+				}
+				gw.Annotations[ShardAnnotation] = strconv.Itoa(target)
+				changed = true
+				sim.assertSingleOwner(step, false)
+			}
+		}
+	}
+	sim.assertSingleOwner(step, settled)
+}
+
+// running returns true if the replica of the ordinal is running, like a held leader lease does.
+func (sim *ownershipSim) running(ordinal int) bool {
+	_, ok := sim.replicas[ordinal]
+	return ok
+}
+
+// crash stops a replica without releasing the gateways it owns.
+func (sim *ownershipSim) crash(ordinal int) {
+	delete(sim.replicas, ordinal)
+}
+
+// stop shuts a replica down, releasing the gateways it owns like the Claimer does.
+func (sim *ownershipSim) stop(ordinal int) {
+	for _, gw := range sim.gws {
+		if sim.replicas[ordinal].Owns(gw) {
+			delete(gw.Annotations, ShardAnnotation)
+		}
+	}
+	delete(sim.replicas, ordinal)
+}
+
+// restart restarts a replica with a new shard count. Gateways of a restarting replica are not served
+// until it's back, but its proxies keep their config.
+func (sim *ownershipSim) restart(ordinal, count int) {
+	sim.stop(ordinal)
+	sim.reconcile(fmt.Sprintf("replica %d stopped", ordinal), false)
+	sim.replicas[ordinal] = Shard{Ordinal: ordinal, Count: count}
+	sim.reconcile(fmt.Sprintf("replica %d restarted", ordinal), true)
+}
+
+func (sim *ownershipSim) assertAssigned(count int) {
+	for _, gw := range sim.gws {
+		assert.True(sim.t, sim.replicas[Assign(gw.Namespace, gw.Name, count)].Owns(gw))
+	}
+}
+
+// TestNeverOwnedByTwoShards simulates a rolling scale-up from 3 to 4 shards, during which replicas
+// disagree on the number of shards, and checks that every gateway is owned by exactly one running
+// shard at every step.
+func TestNeverOwnedByTwoShards(t *testing.T) {
+	sim := newOwnershipSim(t, testGateways(200), 3)
+	sim.reconcile("initial", true)
+
+	// the StatefulSet creates the new replica first, then restarts the others from the highest ordinal
+	sim.replicas[3] = Shard{Ordinal: 3, Count: 4}
+	sim.reconcile("new replica added", true)
+	for i := 2; i >= 0; i-- {
+		sim.restart(i, 4)
+	}
+
+	sim.assertAssigned(4)
+}
+
+// TestNeverOwnedByTwoShardsOnScaleDown simulates a scale-down from 4 to 3 shards, during which the
+// remaining replicas are restarted with the new shard count while the removed one is still running.
+func TestNeverOwnedByTwoShardsOnScaleDown(t *testing.T) {
+	sim := newOwnershipSim(t, testGateways(200), 4)
+	sim.reconcile("initial", true)
+
+	for i := 2; i >= 0; i-- {
+		sim.restart(i, 3)
+	}
+	for _, gw := range sim.gws {
+		if Assign(gw.Namespace, gw.Name, 4) == 3 {
+			require.True(t, sim.replicas[3].Owns(gw), "gateways of the removed shard must not be claimed while it's running")
+		}
+	}
+
+	sim.stop(3)
+	sim.reconcile("removed replica stopped", true)
+	sim.assertAssigned(3)
+}
+
+// TestNeverOwnedByTwoShardsWhenRemovedShardCrashes simulates a scale-down from 4 to 3 shards, during
+// which the removed replica stops without releasing its gateways.
+func TestNeverOwnedByTwoShardsWhenRemovedShardCrashes(t *testing.T) {
+	sim := newOwnershipSim(t, testGateways(200), 4)
+	sim.reconcile("initial", true)
+
+	for i := 2; i >= 0; i-- {
+		sim.restart(i, 3)
+	}
+	sim.crash(3)
+	sim.reconcile("removed replica crashed", true)
+	sim.assertAssigned(3)
+}
@@ -0,0 +1,137 @@
+package gateway_test
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/fsutils"
+	translatortest "github.com/kgateway-dev/kgateway/v2/test/translator"
+)
+
+// TestErrorIsolation verifies that a broken resource only takes down its own part of the
+// translated configuration, that the failure is reported on the status of the broken resource
+// alone, and that the output never contains configuration Envoy would reject.
+func TestErrorIsolation(t *testing.T) {
+	gwNN := types.NamespacedName{Namespace: "default", Name: "example-gateway"}
+
+	translate := func(t *testing.T, inputFile string, settingOpts ...translatortest.SettingsOpts) *translatortest.TranslationOutput {
+		dir := fsutils.MustGetThisDir()
+		settingOpts = append([]translatortest.SettingsOpts{
+			func(s *apisettings.Settings) {
+				s.EnableExperimentalGatewayAPIFeatures = true
+			},
+		}, settingOpts...)
+		tc := translatortest.TestCase{
+			InputFiles: []string{filepath.Join(dir, "testutils/inputs/", inputFile)},
+		}
+		results, err := tc.Run(t, t.Context(), translatortest.NewScheme(nil), translatortest.ExtraConfig{}, settingOpts...)
+		require.NoError(t, err)
+		require.Contains(t, results, gwNN)
+		output := translatortest.NewTranslationOutput(results[gwNN], time.Time{})
+		requireNoNACKableOutput(t, output)
+		return output
+	}
+
+	t.Run("broken route among siblings", func(t *testing.T) {
+		output := translate(t, "route-replacement/broken-route-siblings.yaml", func(s *apisettings.Settings) {
+			s.RouteReplacementStatusCode = http.StatusServiceUnavailable
+		})
+
+		require.Len(t, output.Routes, 1)
+		routes := map[string]*envoyroutev3.Route{}
+		for _, vh := range output.Routes[0].GetVirtualHosts() {
+			require.Len(t, vh.GetRoutes(), 1, "virtual host %s", vh.GetName())
+			routes[vh.GetDomains()[0]] = vh.GetRoutes()[0]
+		}
+		require.Len(t, routes, 3)
+
+		broken := routes["broken.example.com"]
+		require.NotNil(t, broken.GetDirectResponse(), "the broken route should be replaced with a direct response")
+		require.EqualValues(t, http.StatusServiceUnavailable, broken.GetDirectResponse().GetStatus())
+		for _, host := range []string{"first.example.com", "last.example.com"} {
+			require.Equal(t, "kube_default_example-svc_80", routes[host].GetRoute().GetCluster(), "sibling route %s", host)
+		}
+	})
+
+	t.Run("invalid certificateRef only drops its own filter chain", func(t *testing.T) {
+		output := translate(t, "invalid-filter-chains/https-same-port-invalid-secret.yaml")
+
+		require.Len(t, output.Listeners, 1)
+		filterChains := output.Listeners[0].GetFilterChains()
+		require.Len(t, filterChains, 1)
+		require.Equal(t, "https-valid-secret", filterChains[0].GetName())
+		require.Equal(t, []string{"valid.example.com"}, filterChains[0].GetFilterChainMatch().GetServerNames())
+
+		require.Len(t, output.Routes, 1)
+		require.Equal(t, "https-valid-secret", output.Routes[0].GetName())
+	})
+
+	t.Run("status is only reported on the broken resources", func(t *testing.T) {
+		output := translate(t, "route-replacement/broken-route-siblings.yaml")
+
+		broken := routeParentConditions(t, output.Statuses, "default/broken-route")
+		requireCondition(t, broken, string(gwv1.RouteConditionResolvedRefs), metav1.ConditionFalse, string(gwv1.RouteReasonBackendNotFound))
+		requireCondition(t, broken, string(gwv1.RouteConditionAccepted), metav1.ConditionTrue, string(gwv1.RouteReasonAccepted))
+		for _, sibling := range []string{"default/first-route", "default/last-route"} {
+			conditions := routeParentConditions(t, output.Statuses, sibling)
+			requireCondition(t, conditions, string(gwv1.RouteConditionAccepted), metav1.ConditionTrue, string(gwv1.RouteReasonAccepted))
+			requireCondition(t, conditions, string(gwv1.RouteConditionResolvedRefs), metav1.ConditionTrue, string(gwv1.RouteReasonResolvedRefs))
+		}
+
+		output = translate(t, "invalid-filter-chains/https-same-port-invalid-secret.yaml")
+
+		gwStatus := output.Statuses.Gateways[gwNN.String()]
+		require.NotNil(t, gwStatus)
+		requireCondition(t, gwStatus.Conditions, string(gwv1.GatewayConditionProgrammed), metav1.ConditionTrue, string(gwv1.GatewayReasonProgrammed))
+		listeners := map[gwv1.SectionName][]metav1.Condition{}
+		for _, l := range gwStatus.Listeners {
+			listeners[l.Name] = l.Conditions
+		}
+		requireCondition(t, listeners["https-invalid-secret"], string(gwv1.ListenerConditionResolvedRefs), metav1.ConditionFalse, string(gwv1.ListenerReasonInvalidCertificateRef))
+		requireCondition(t, listeners["https-invalid-secret"], string(gwv1.ListenerConditionProgrammed), metav1.ConditionFalse, string(gwv1.ListenerReasonInvalid))
+		requireCondition(t, listeners["https-valid-secret"], string(gwv1.ListenerConditionResolvedRefs), metav1.ConditionTrue, string(gwv1.ListenerReasonResolvedRefs))
+		requireCondition(t, listeners["https-valid-secret"], string(gwv1.ListenerConditionProgrammed), metav1.ConditionTrue, string(gwv1.ListenerReasonProgrammed))
+	})
+}
+
+// requireNoNACKableOutput fails the test if any translated resource violates the constraints Envoy
+// validates before accepting it, or if a listener has no filter chains to serve.
+func requireNoNACKableOutput(t *testing.T, output *translatortest.TranslationOutput) {
+	t.Helper()
+	for _, l := range output.Listeners {
+		require.NoError(t, l.ValidateAll(), "listener %s", l.GetName())
+		require.NotEmpty(t, l.GetFilterChains(), "listener %s", l.GetName())
+	}
+	for _, rc := range output.Routes {
+		require.NoError(t, rc.ValidateAll(), "route configuration %s", rc.GetName())
+	}
+	for _, c := range append(output.Clusters, output.ExtraClusters...) {
+		require.NoError(t, c.ValidateAll(), "cluster %s", c.GetName())
+	}
+}
+
+func routeParentConditions(t *testing.T, statuses *translatortest.Statuses, route string) []metav1.Condition {
+	t.Helper()
+	status := statuses.HTTPRoutes[route]
+	require.NotNil(t, status, "no status for HTTPRoute %s", route)
+	require.Len(t, status.Parents, 1)
+	return status.Parents[0].Conditions
+}
+
+func requireCondition(t *testing.T, conditions []metav1.Condition, condType string, status metav1.ConditionStatus, reason string) {
+	t.Helper()
+	cond := meta.FindStatusCondition(conditions, condType)
+	require.NotNil(t, cond, "no %s condition", condType)
+	require.Equal(t, status, cond.Status, "%s condition: %s", condType, cond.Message)
+	require.Equal(t, reason, cond.Reason, "%s condition: %s", condType, cond.Message)
+}
@@ -5,6 +5,7 @@ import (
 
 	"istio.io/istio/pkg/kube/krt"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/query"
@@ -63,10 +64,10 @@ func (t *translator) Translate(
 
 	for _, rErr := range routesForGw.RouteErrors {
 		reporter.Route(rErr.Route.GetSourceObject()).ParentRef(&rErr.ParentRef).SetCondition(reports.RouteCondition{
-			Type:   gwv1.RouteConditionAccepted,
-			Status: metav1.ConditionFalse,
-			Reason: rErr.Error.Reason,
-			// TODO message
+			Type:    gwv1.RouteConditionAccepted,
+			Status:  metav1.ConditionFalse,
+			Reason:  rErr.Error.Reason,
+			Message: rErr.Error.Message,
 		})
 	}
 
@@ -88,6 +89,7 @@ func (t *translator) Translate(
 		AttachedPolicies:              gateway.AttachedListenerPolicies,
 		AttachedHttpPolicies:          gateway.AttachedHttpPolicies,
 		PerConnectionBufferLimitBytes: gateway.PerConnectionBufferLimitBytes,
+		Draining:                      gateway.Draining,
 	}
 }
 
@@ -98,8 +100,39 @@ func setAttachedRoutes(gateway *ir.Gateway, routesForGw *query.RoutesForGwResult
 		availRoutes := 0
 		if res := routesForGw.GetListenerResult(listener.Parent, string(listener.Name)); res != nil {
 			// TODO we've never checked if the ListenerResult has an error.. is it already on RouteErrors?
-			availRoutes = len(res.Routes)
+			availRoutes = countAttachedRoutes(res.Routes)
 		}
 		parentReporter.Listener(&listener.Listener).SetAttachedRoutes(uint(availRoutes)) //nolint:gosec // G115: availRoutes is a count of routes, always non-negative
 	}
 }
+
+// countAttachedRoutes returns the number of distinct routes accepted by a listener. A route whose parentRefs
+// select the listener more than once, e.g. by sectionName and by port, is attached once.
+func countAttachedRoutes(routes []*query.RouteInfo) int {
+	attached := sets.New[ir.ObjectSource]()
+	for _, r := range routes {
+		if rejectedByListener(r.Object) {
+			continue
+		}
+		gk := r.Object.GetGroupKind()
+		attached.Insert(ir.ObjectSource{
+			Group:     gk.Group,
+			Kind:      gk.Kind,
+			Namespace: r.Object.GetNamespace(),
+			Name:      r.Object.GetName(),
+		})
+	}
+	return attached.Len()
+}
+
+// rejectedByListener returns true for the routes the listener translator reports as not accepted:
+// TCPRoutes and TLSRoutes are only supported with a single rule.
+func rejectedByListener(route ir.Route) bool {
+	switch r := route.(type) {
+	case *ir.TcpRouteIR:
+		return len(r.SourceObject.Spec.Rules) != 1
+	case *ir.TlsRouteIR:
+		return len(r.SourceObject.Spec.Rules) != 1
+	}
+	return false
+}
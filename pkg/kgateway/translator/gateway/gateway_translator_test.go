@@ -3,6 +3,7 @@ package gateway_test
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -146,6 +147,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("http gateway with weighted backends", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "http-routing/weighted-backends.yaml",
+			outputFile: "http-routing-weighted-backends.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("http gateway with custom class", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "custom-gateway-class",
@@ -549,6 +561,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("TrafficPolicy with local rate limiting per client IP", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "traffic-policy/local-rate-limit-per-client-ip.yaml",
+			outputFile: "traffic-policy/local-rate-limit-per-client-ip.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("TrafficPolicy with local and global rate limiting combined", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "traffic-policy/local-and-global-combined",
@@ -753,6 +776,8 @@ func TestBasic(t *testing.T) {
 				Namespace: "default",
 				Name:      "example-gateway",
 			},
+		}, func(s *apisettings.Settings) {
+			s.EnableFaultInjection = true
 		})
 	})
 
@@ -764,6 +789,8 @@ func TestBasic(t *testing.T) {
 				Namespace: "default",
 				Name:      "example-gateway",
 			},
+		}, func(s *apisettings.Settings) {
+			s.EnableFaultInjection = true
 		})
 	})
 
@@ -775,6 +802,30 @@ func TestBasic(t *testing.T) {
 				Namespace: "default",
 				Name:      "example-gateway",
 			},
+		}, func(s *apisettings.Settings) {
+			s.EnableFaultInjection = true
+		})
+	})
+
+	t.Run("TrafficPolicy with inline lua filters attached to route", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "traffic-policy/lua-inline.yaml",
+			outputFile: "traffic-policy/lua-inline.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("TrafficPolicy with ConfigMap referenced lua filters attached to gateway", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "traffic-policy/lua-configmap.yaml",
+			outputFile: "traffic-policy/lua-configmap.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
 		})
 	})
 
@@ -810,6 +861,17 @@ func TestBasic(t *testing.T) {
 			},
 		})
 	})
+
+	t.Run("TrafficPolicy with header modifiers with an invalid header name", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "traffic-policy/header-modifiers-invalid-name.yaml",
+			outputFile: "traffic-policy/header-modifiers-invalid-name.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
 	t.Run("TrafficPolicy with compression Policy", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "traffic-policy/compression-route.yaml",
@@ -820,6 +882,39 @@ func TestBasic(t *testing.T) {
 			},
 		})
 	})
+	t.Run("TrafficPolicy with gzip compression with min content length and content types", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "traffic-policy/compression-gzip.yaml",
+			outputFile: "traffic-policy/compression-gzip.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("TrafficPolicy with gzip and brotli compression", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "traffic-policy/compression-gzip-brotli.yaml",
+			outputFile: "traffic-policy/compression-gzip-brotli.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("TrafficPolicy disabling compression inherited from a brotli policy", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "traffic-policy/compression-disable-inherited.yaml",
+			outputFile: "traffic-policy/compression-disable-inherited.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("TrafficPolicy with decompression Policy", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "traffic-policy/decompression-route.yaml",
@@ -842,6 +937,28 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("TrafficPolicy with url rewrite capture groups", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "traffic-policy/url-rewrite-capture-groups.yaml",
+			outputFile: "traffic-policy/url-rewrite-capture-groups.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("TrafficPolicy with url rewrite overlapping a URLRewrite filter", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "traffic-policy/url-rewrite-filter-conflict.yaml",
+			outputFile: "traffic-policy/url-rewrite-filter-conflict.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("TrafficPolicy with route tracing sampling overrides", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "traffic-policy/route-tracing-sampling.yaml",
@@ -886,6 +1003,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("tcproute attached to an http listener is not allowed", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "tcp-routing/http-listener.yaml",
+			outputFile: "tcp-routing/http-listener.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("tcproute with missing backend reports correctly", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "tcp-routing/missing-backend.yaml",
@@ -952,6 +1080,28 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("tls gateway routing by sni to passthrough backends", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "tls-routing/sni-matching.yaml",
+			outputFile: "tls-routing/sni-matching.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("tlsroute attached to an https listener is not allowed", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "tls-routing/https-listener.yaml",
+			outputFile: "tls-routing/https-listener.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("tlsroute with missing backend reports correctly", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "tls-routing/missing-backend.yaml",
@@ -1084,6 +1234,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("DNS backend", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "backends/dns_backend.yaml",
+			outputFile: "backends/dns_backend.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("DFP Backend with TLS", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "dfp/tls.yaml",
@@ -1238,6 +1399,28 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("TrafficPolicy upgradeConfigs", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "traffic-policy/upgrade-configs.yaml",
+			outputFile: "traffic-policy/upgrade-configs.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("TrafficPolicy streamIdle overrides the listener streamIdleTimeout", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "traffic-policy/stream-idle-timeout.yaml",
+			outputFile: "traffic-policy/stream-idle-timeout.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("TrafficPolicy timeout and retry", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "traffic-policy/timeout-retry.yaml",
@@ -1260,6 +1443,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("TrafficPolicy with gRPC-Web and gRPC timeouts attached to GRPCRoute", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "traffic-policy/grpc.yaml",
+			outputFile: "traffic-policy/grpc.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("http gateway with session persistence (cookie)", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "session-persistence/cookie.yaml",
@@ -1282,6 +1476,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("http gateway with session persistence overriding a BackendConfigPolicy hash policy", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "session-persistence/hash-policy-conflict.yaml",
+			outputFile: "session-persistence/hash-policy-conflict.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("HTTPListenerPolicy with upgrades", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "https-listener-pol/upgrades.yaml",
@@ -1689,6 +1894,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("ListenerPolicy with maxRequestHeadersCount", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "listener-policy-http/max-request-headers-count.yaml",
+			outputFile: "listener-policy-http/max-request-headers-count.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("ListenerPolicy with uuidRequestIdConfig explicit false", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "listener-policy-http/request-id-config-explicit.yaml",
@@ -2052,6 +2268,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("listener set in a namespace excluded by allowed listeners", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "listener-sets/same-namespace-only.yaml",
+			outputFile: "listener-sets/same-namespace-only.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("listener set accepted with rejected individual listener", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "listener-sets/accepted-ls-rejected-listener.yaml",
@@ -2239,6 +2466,19 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("HTTPS mixed listeners - invalid secret ref and replaced route rule with custom status code", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "invalid-filter-chains/https-mixed-listeners-route-replacement.yaml",
+			outputFile: "invalid-filter-chains/https-mixed-listeners-route-replacement.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		}, func(s *apisettings.Settings) {
+			s.RouteReplacementStatusCode = http.StatusServiceUnavailable
+		})
+	})
+
 	t.Run("Gateway empty with ListenerSet TCP listener no routes", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "invalid-filter-chains/gateway-empty-listenerset-tcp-no-routes.yaml",
@@ -2327,6 +2567,28 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("HTTP URLRewrite filter with prefix rewrite", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "http-routing/url-rewrite.yaml",
+			outputFile: "http-routing/url-rewrite.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("HTTP RequestMirror filter", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "http-routing/request-mirror.yaml",
+			outputFile: "http-routing/request-mirror.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("ListenerPolicy with proxy protocol on HTTP listener", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "listener-policy/http-proxy-protocol.yaml",
@@ -2371,6 +2633,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("ListenerPolicy with idle timeout", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "listener-policy/idle-timeout.yaml",
+			outputFile: "listener-policy/idle-timeout.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("ListenerPolicy with per port settings", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "listener-policy/per-port.yaml",
@@ -2415,6 +2688,28 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("ListenerPolicy with per-listener mTLS subject alt names", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "listener-policy/per-listener-mtls-sans.yaml",
+			outputFile: "listener-policy/per-listener-mtls-sans.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("ListenerPolicy with per-listener mTLS invalid CA certificate", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFile:  "listener-policy/per-listener-mtls-invalid-ca.yaml",
+			outputFile: "listener-policy/per-listener-mtls-invalid-ca.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("ListenerPolicy merge happens in the default and perPort fields", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFile:  "listener-policy/deep-merge.yaml",
@@ -2668,6 +2963,12 @@ func TestValidation(t *testing.T) {
 			inputFile: "matcher-query-regex-invalid.yaml",
 			minMode:   apisettings.ValidationStrict,
 		},
+		{
+			name:      "URLRewrite Regex Invalid",
+			category:  "policy",
+			inputFile: "policy-urlrewrite-regex-invalid.yaml",
+			minMode:   apisettings.ValidationStandard,
+		},
 		{
 			name:      "CSRF Regex Invalid",
 			category:  "policy",
@@ -31,4 +31,24 @@ func TestStatuses(t *testing.T) {
 			settingOpt,
 		)
 	})
+
+	t.Run("AttachedRoutes", func(t *testing.T) {
+		dir := fsutils.MustGetThisDir()
+		settingOpt := func(s *apisettings.Settings) {
+			s.EnableExperimentalGatewayAPIFeatures = true
+		}
+		translatortest.TestTranslation(
+			t,
+			t.Context(),
+			[]string{
+				filepath.Join(dir, "testutils/inputs/status/attached-routes.yaml"),
+			},
+			filepath.Join(dir, "testutils/outputs/status/attached-routes.yaml"),
+			types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+			settingOpt,
+		)
+	})
 }
@@ -2,11 +2,15 @@ package httproute
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/query"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/translator/metrics"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
@@ -116,6 +120,17 @@ func translateGatewayHTTPRouteRule(
 ) []ir.HttpRouteRuleMatchIR {
 	routes := make([]ir.HttpRouteRuleMatchIR, 0, len(rule.Matches))
 
+	// a rule none of whose backends resolve can't serve any request, so it is replaced with a direct response
+	unresolvedErr := unresolvedBackendsError(rule)
+	if unresolvedErr != nil {
+		reporter.AddInvalidRule(reports.InvalidRule{
+			Index:          ruleIdx,
+			RuleCount:      len(parent.Rules),
+			Reason:         unresolvedErr.Error(),
+			UnresolvedRefs: true,
+		})
+	}
+
 	for idx, match := range rule.Matches {
 		// HTTPRoute names are being introduced to upstream as part of https://github.com/kubernetes-sigs/gateway-api/issues/995
 		// For now, the HTTPRoute needs a unique name for each Route to support features that require the route name
@@ -132,12 +147,17 @@ func translateGatewayHTTPRouteRule(
 			Name:                 uniqueRouteName,
 			Backends:             nil,
 			MatchIndex:           idx,
+			RuleIndex:            ruleIdx,
 			Match:                match,
 			DelegatingParent:     delegatingParent,
 			PrecedenceWeight:     parent.PrecedenceWeight,
 			RouteAcceptanceError: rule.Err,
 		}
 
+		if unresolvedErr != nil {
+			outputRoute.RouteReplacementError = unresolvedErr
+		}
+
 		if len(rule.Backends) > 0 {
 			setRouteAction(
 				ctx,
@@ -174,6 +194,32 @@ func translateGatewayHTTPRouteRule(
 	return routes
 }
 
+// unresolvedBackendsError returns an error describing the backendRefs of the rule when none of them
+// resolve. Delegated routes are resolved during flattening, so rules that delegate are not considered.
+func unresolvedBackendsError(rule ir.HttpRouteRuleIR) error {
+	if len(rule.Backends) == 0 {
+		return nil
+	}
+	var reasons []string
+	for _, backend := range rule.Backends {
+		if backend.Delegate != nil || backend.Backend == nil || backend.Backend.Err == nil {
+			return nil
+		}
+		reasons = append(reasons, backend.Backend.Err.Error())
+	}
+	return errors.New(strings.Join(reasons, ", "))
+}
+
+// unsupportedAppProtocolError returns an error if the app protocol of the backend can't be handled by the
+// Envoy data plane. A2A backends need the A2A handling of agentgateway, whose control plane is not part of kgateway.
+func unsupportedAppProtocolError(backend *ir.BackendObjectIR) error {
+	if backend == nil || backend.AppProtocol != ir.A2AAppProtocol {
+		return nil
+	}
+	return fmt.Errorf("%w: backend %s/%s uses the %s app protocol, which requires an agentgateway Gateway",
+		query.ErrUnsupportedAppProtocol, backend.GetNamespace(), backend.GetName(), kgateway.AppProtocolA2A)
+}
+
 func setRouteAction(
 	ctx context.Context,
 	gwroute *query.RouteInfo,
@@ -210,13 +256,20 @@ func setRouteAction(
 			continue
 		}
 
-		if err := backend.Backend.Err; err != nil {
+		backendRef := *backend.Backend // TODO: Nil check?
+		if err := unsupportedAppProtocolError(backendRef.BackendObject); err != nil && backendRef.Err == nil {
+			// the backend is invalid for this gateway, so its share of the traffic is rejected
+			backendRef.BackendObject = nil
+			backendRef.ClusterName = "blackhole-cluster"
+			backendRef.Err = err
+		}
+		if err := backendRef.Err; err != nil {
 			query.ProcessBackendError(err, reporter)
 			logger.Debug("error on backend upstream", "error", err)
 		}
 
 		httpBackend := ir.HttpBackend{
-			Backend:          *backend.Backend, // TODO: Nil check?
+			Backend:          backendRef,
 			AttachedPolicies: backend.AttachedPolicies,
 		}
 		outputRoute.Backends = append(outputRoute.Backends, httpBackend)
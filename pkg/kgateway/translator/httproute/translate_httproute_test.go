@@ -14,6 +14,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/query"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/translator/httproute"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
@@ -265,6 +266,80 @@ var _ = Describe("GatewayHttpRouteTranslator", func() {
 				Expect(resolvedRefs.Reason).To(BeEquivalentTo(gwv1.RouteReasonBackendNotFound))
 			})
 		})
+
+		When("referencing an A2A backing service", func() {
+			BeforeEach(func() {
+				backingSvc = &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "agent",
+						Namespace: "bar",
+					},
+					Spec: corev1.ServiceSpec{
+						Ports: []corev1.ServicePort{{
+							Name:        "a2a",
+							Port:        8080,
+							AppProtocol: new(string(kgateway.AppProtocolA2A)),
+						}},
+					},
+				}
+				up = &ir.BackendObjectIR{
+					ObjectSource: ir.ObjectSource{
+						Namespace: backingSvc.Namespace,
+						Name:      backingSvc.Name,
+						Kind:      "Service",
+						Group:     "",
+					},
+					Port:        8080,
+					Obj:         backingSvc,
+					AppProtocol: ir.ParseAppProtocol(backingSvc.Spec.Ports[0].AppProtocol),
+				}
+				route.Spec.Rules = []gwv1.HTTPRouteRule{
+					{
+						Matches: []gwv1.HTTPRouteMatch{
+							{Path: &gwv1.HTTPPathMatch{
+								Type:  ptr.To(gwv1.PathMatchPathPrefix),
+								Value: new("/"),
+							}},
+						},
+					},
+				}
+				routeir.Rules = []ir.HttpRouteRuleIR{
+					{
+						Matches: route.Spec.Rules[0].Matches,
+						Backends: []ir.HttpBackendOrDelegate{
+							{
+								Backend: &ir.BackendRefIR{
+									BackendObject: up,
+									ClusterName:   up.ClusterName(),
+								},
+							},
+						},
+					},
+				}
+				routeInfo = &query.RouteInfo{
+					Object: routeir,
+				}
+			})
+
+			It("rejects the backend since the gateway is not an agentgateway", func() {
+				routes := httproute.TranslateGatewayHTTPRouteRules(ctx, routeInfo, parentRefReporter, baseReporter)
+
+				Expect(routes).To(HaveLen(1))
+				Expect(routes[0].Backends[0].Backend.ClusterName).To(Equal("blackhole-cluster"))
+				Expect(routes[0].Backends[0].Backend.Err).To(MatchError(query.ErrUnsupportedAppProtocol))
+				// the backend of the route IR is shared by all the parents of the route and must not be modified
+				Expect(routeir.Rules[0].Backends[0].Backend.Err).NotTo(HaveOccurred())
+
+				routeStatus := reportsMap.BuildRouteStatus(ctx, route, wellknown.DefaultGatewayClassName)
+				Expect(routeStatus).NotTo(BeNil())
+				Expect(routeStatus.Parents).To(HaveLen(1))
+				resolvedRefs := meta.FindStatusCondition(routeStatus.Parents[0].Conditions, string(gwv1.RouteConditionResolvedRefs))
+				Expect(resolvedRefs).NotTo(BeNil())
+				Expect(resolvedRefs.Status).To(Equal(metav1.ConditionFalse))
+				Expect(resolvedRefs.Reason).To(BeEquivalentTo(gwv1.RouteReasonUnsupportedProtocol))
+				Expect(resolvedRefs.Message).To(Equal("unsupported app protocol: backend bar/agent uses the kgateway.dev/a2a app protocol, which requires an agentgateway Gateway"))
+			})
+		})
 	})
 
 	Context("multiple route actions", func() {
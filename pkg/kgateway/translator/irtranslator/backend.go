@@ -13,6 +13,7 @@ import (
 	envoy_upstreams_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
 	"istio.io/istio/pkg/kube/krt"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -31,11 +32,14 @@ import (
 const (
 	clusterConnectionTimeout = time.Second * 5
 	dnsClusterExtensionName  = "envoy.clusters.dns"
+	// clusterMetadataKeyPrefix prefixes the metadata namespaces of the plugins, followed by the plugin name
+	clusterMetadataKeyPrefix = "plugins."
 )
 
 type BackendTranslator struct {
 	ContributedBackends map[schema.GroupKind]ir.BackendInit
 	ContributedPolicies map[schema.GroupKind]sdk.PolicyPlugin
+	ClusterMetadata     []sdk.ClusterMetadataContributor
 	CommonCols          *collections.CommonCollections
 	Validator           validator.Validator
 	Mode                apisettings.ValidationMode
@@ -82,6 +86,7 @@ func (t *BackendTranslator) TranslateBackend(
 		logger.Error("failed to apply policies to cluster", "cluster", out.GetName(), "error", err)
 		return buildBlackholeCluster(backend), err
 	}
+	t.applyClusterMetadata(backend, out)
 
 	// In strict mode, validate the final cluster configuration using Envoy
 	if t.Mode == apisettings.ValidationStrict && t.Validator != nil {
@@ -94,6 +99,36 @@ func (t *BackendTranslator) TranslateBackend(
 	return out, nil
 }
 
+// applyClusterMetadata attaches the metadata of the plugins to the cluster, under namespaces
+// prefixed with the plugin name.
+func (t *BackendTranslator) applyClusterMetadata(backend *ir.BackendObjectIR, out *envoyclusterv3.Cluster) {
+	for _, contributor := range t.ClusterMetadata {
+		if contributor.Metadata == nil {
+			continue
+		}
+		md := contributor.Metadata(*backend)
+		if md == nil {
+			continue
+		}
+		if out.GetMetadata() == nil {
+			out.Metadata = &envoycorev3.Metadata{}
+		}
+		prefix := clusterMetadataKeyPrefix + contributor.Name + "."
+		for ns, v := range md.GetFilterMetadata() {
+			if out.GetMetadata().GetFilterMetadata() == nil {
+				out.Metadata.FilterMetadata = map[string]*structpb.Struct{}
+			}
+			out.Metadata.FilterMetadata[prefix+ns] = v
+		}
+		for ns, v := range md.GetTypedFilterMetadata() {
+			if out.GetMetadata().GetTypedFilterMetadata() == nil {
+				out.Metadata.TypedFilterMetadata = map[string]*anypb.Any{}
+			}
+			out.Metadata.TypedFilterMetadata[prefix+ns] = v
+		}
+	}
+}
+
 func (t *BackendTranslator) runPolicies(
 	kctx krt.HandlerContext,
 	ctx context.Context,
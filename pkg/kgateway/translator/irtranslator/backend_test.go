@@ -7,11 +7,13 @@ import (
 
 	envoybootstrapv3 "github.com/envoyproxy/go-control-plane/envoy/config/bootstrap/v3"
 	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoycommondnsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/common/dns/v3"
 	envoydnsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/dns/v3"
 	envoy_upstreams_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
 	"istio.io/istio/pkg/kube/krt"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -285,6 +287,110 @@ func TestBackendTranslatorHandlesXDSValidationErrors(t *testing.T) {
 	assert.Empty(t, backend.Errors)
 }
 
+func TestBackendTranslatorAppliesClusterMetadata(t *testing.T) {
+	teamMetadata := func(backend ir.BackendObjectIR) *envoycorev3.Metadata {
+		team, err := structpb.NewStruct(map[string]any{"team": backend.GetObjectSource().Namespace})
+		require.NoError(t, err)
+		return &envoycorev3.Metadata{
+			FilterMetadata: map[string]*structpb.Struct{"owner": team},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		backend *ir.BackendObjectIR
+	}{
+		{
+			name: "service",
+			backend: &ir.BackendObjectIR{
+				ObjectSource: ir.ObjectSource{
+					Group:     "",
+					Kind:      "Service",
+					Name:      "svc",
+					Namespace: "team-a",
+				},
+				Port: 80,
+			},
+		},
+		{
+			name: "backend",
+			backend: &ir.BackendObjectIR{
+				ObjectSource: ir.ObjectSource{
+					Group:     "gateway.kgateway.dev",
+					Kind:      "Backend",
+					Name:      "static",
+					Namespace: "team-a",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bt irtranslator.BackendTranslator
+			bt.ContributedBackends = map[schema.GroupKind]ir.BackendInit{
+				{Group: tt.backend.Group, Kind: tt.backend.Kind}: {
+					InitEnvoyBackend: func(ctx context.Context, in ir.BackendObjectIR, out *envoyclusterv3.Cluster) *ir.EndpointsForBackend {
+						return nil
+					},
+				},
+			}
+			bt.ContributedPolicies = map[schema.GroupKind]sdk.PolicyPlugin{}
+			bt.ClusterMetadata = []sdk.ClusterMetadataContributor{
+				{Name: "ownership", Metadata: teamMetadata},
+				{Name: "audit", Metadata: teamMetadata},
+			}
+
+			var ucc ir.UniqlyConnectedClient
+			var kctx krt.TestingDummyContext
+			cluster, err := bt.TranslateBackend(context.Background(), kctx, ucc, tt.backend)
+			require.NoError(t, err)
+
+			md := cluster.GetMetadata().GetFilterMetadata()
+			require.Len(t, md, 2)
+			assert.Equal(t, "team-a", md["plugins.ownership.owner"].GetFields()["team"].GetStringValue())
+			assert.Equal(t, "team-a", md["plugins.audit.owner"].GetFields()["team"].GetStringValue())
+			assert.NotContains(t, md, "owner")
+		})
+	}
+}
+
+func TestBackendTranslatorSkipsNilClusterMetadata(t *testing.T) {
+	backend := &ir.BackendObjectIR{
+		ObjectSource: ir.ObjectSource{
+			Group:     "group",
+			Kind:      "kind",
+			Name:      "name",
+			Namespace: "namespace",
+		},
+	}
+
+	var bt irtranslator.BackendTranslator
+	bt.ContributedBackends = map[schema.GroupKind]ir.BackendInit{
+		{Group: "group", Kind: "kind"}: {
+			InitEnvoyBackend: func(ctx context.Context, in ir.BackendObjectIR, out *envoyclusterv3.Cluster) *ir.EndpointsForBackend {
+				return nil
+			},
+		},
+	}
+	bt.ContributedPolicies = map[schema.GroupKind]sdk.PolicyPlugin{}
+	bt.ClusterMetadata = []sdk.ClusterMetadataContributor{
+		{Name: "no-func"},
+		{
+			Name: "nil-metadata",
+			Metadata: func(backend ir.BackendObjectIR) *envoycorev3.Metadata {
+				return nil
+			},
+		},
+	}
+
+	var ucc ir.UniqlyConnectedClient
+	var kctx krt.TestingDummyContext
+	cluster, err := bt.TranslateBackend(context.Background(), kctx, ucc, backend)
+	require.NoError(t, err)
+	assert.Empty(t, cluster.GetMetadata().GetFilterMetadata())
+	assert.Empty(t, cluster.GetMetadata().GetTypedFilterMetadata())
+}
+
 // mockValidator is a test implementation of validator.Validator for testing xDS validation errors
 type mockValidator struct {
 	validateFunc func(ctx context.Context, config *envoybootstrapv3.Bootstrap) error
@@ -2,7 +2,9 @@ package irtranslator
 
 import (
 	"fmt"
+	"slices"
 	"sort"
+	"time"
 
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
@@ -14,6 +16,7 @@ import (
 	envoymatcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -28,6 +31,11 @@ import (
 const (
 	DefaultHttpStatPrefix  = "http"
 	UpstreamCodeFilterName = "envoy.filters.http.upstream_codec"
+
+	// drainMaxConnectionDuration is how long the connections of a draining gateway last before they're drained
+	drainMaxConnectionDuration = time.Second
+	// drainTimeout is how long connections being drained get to finish their in-flight requests
+	drainTimeout = 5 * time.Second
 )
 
 var defaultDownstreamAlpnProtocols = []string{"h2", "http/1.1"}
@@ -139,7 +147,7 @@ func (n *filterChainTranslator) computeCustomFilters(
 ) []filters.StagedNetworkFilter {
 	var networkFilters []filters.StagedNetworkFilter
 	// Process the network filters.
-	for _, plug := range n.pluginPass {
+	for gk, plug := range n.pluginPass {
 		stagedFilters, err := plug.NetworkFilters()
 		if err != nil {
 			listenerReporter.SetCondition(sdkreporter.ListenerCondition{
@@ -155,6 +163,7 @@ func (n *filterChainTranslator) computeCustomFilters(
 			if nf.Filter == nil {
 				continue
 			}
+			nf.Plugin = plug.pluginName(gk)
 			networkFilters = append(networkFilters, nf)
 		}
 	}
@@ -239,7 +248,12 @@ func (h *hcmNetworkFilterTranslator) computeNetworkFilters(l ir.HttpFilterChainI
 
 	// TODO: should we enable websockets by default?
 
-	// 4. Generate the typedConfig for the HCM
+	// 4. Close the connections of draining gateways, overriding the policies
+	if h.gateway.Draining {
+		drainHCM(httpConnectionManager)
+	}
+
+	// 5. Generate the typedConfig for the HCM
 	hcmFilter, err := NewFilterWithTypedConfig(wellknown.HTTPConnectionManager, httpConnectionManager)
 	if err != nil {
 		logger.Error("failed to convert proto message to any", "error", err)
@@ -249,6 +263,17 @@ func (h *hcmNetworkFilterTranslator) computeNetworkFilters(l ir.HttpFilterChainI
 	return hcmFilter, nil
 }
 
+// drainHCM makes the connections of the HCM end shortly: HTTP/1 responses carry `Connection: close`
+// and HTTP/2 connections are sent a GOAWAY, so that clients reconnect, and the connections still open
+// after the drain timeout are closed.
+func drainHCM(hcm *envoyhttp.HttpConnectionManager) {
+	if hcm.GetCommonHttpProtocolOptions() == nil {
+		hcm.CommonHttpProtocolOptions = &envoycorev3.HttpProtocolOptions{}
+	}
+	hcm.CommonHttpProtocolOptions.MaxConnectionDuration = durationpb.New(drainMaxConnectionDuration)
+	hcm.DrainTimeout = durationpb.New(drainTimeout)
+}
+
 func (h *hcmNetworkFilterTranslator) initializeHCM() *envoyhttp.HttpConnectionManager {
 	statPrefix := h.listener.FilterChainName
 	if statPrefix == "" {
@@ -281,7 +306,7 @@ func (h *hcmNetworkFilterTranslator) computeHttpFilters(l ir.HttpFilterChainIR)
 		ListenerPort: h.lis.BindPort,
 	}
 	// run the HttpFilter Plugins
-	for _, plug := range h.pluginPass {
+	for gk, plug := range h.pluginPass {
 		stagedFilters, err := plug.HttpFilters(hCtx, l.FilterChainCommon)
 		if err != nil {
 			// what to do with errors here? ignore the listener??
@@ -298,6 +323,7 @@ func (h *hcmNetworkFilterTranslator) computeHttpFilters(l ir.HttpFilterChainIR)
 				logger.Warn("got nil Filter from HttpFilters()", "plugin", plug.Name)
 				continue
 			}
+			httpFilter.Plugin = plug.pluginName(gk)
 			httpFilters = append(httpFilters, httpFilter)
 		}
 	}
@@ -365,8 +391,10 @@ func sortHttpFilters(filters filters.StagedHttpFilterList) []*envoyhttp.HttpFilt
 	sort.Sort(filters)
 	var sortedFilters []*envoyhttp.HttpFilter
 	for _, filter := range filters {
-		if len(sortedFilters) > 0 && proto.Equal(sortedFilters[len(sortedFilters)-1], filter.Filter) {
-			// skip repeated equal filters
+		if slices.ContainsFunc(sortedFilters, func(f *envoyhttp.HttpFilter) bool {
+			return proto.Equal(f, filter.Filter)
+		}) {
+			// skip repeated equal filters, which may be contributed by different plugins
 			continue
 		}
 		sortedFilters = append(sortedFilters, filter.Filter)
@@ -401,6 +429,26 @@ func (h *filterChainTranslator) computeTcpFilters(l ir.TcpIR, reporter sdkreport
 		}
 	}
 
+	// Allow any TCP proxy plugins to make their changes
+	pctx := &ir.TcpProxyContext{
+		ListenerPort:    h.listener.BindPort,
+		FilterChainName: l.FilterChainName,
+	}
+	for _, plug := range h.pluginPass {
+		tcpPlug, ok := plug.ProxyTranslationPass.(ir.TcpProxyTranslationPass)
+		if !ok {
+			continue
+		}
+		if err := tcpPlug.ApplyTcpProxy(pctx, cfg); err != nil {
+			reporter.SetCondition(sdkreporter.ListenerCondition{
+				Type:    gwv1.ListenerConditionProgrammed,
+				Reason:  gwv1.ListenerReasonInvalid,
+				Status:  metav1.ConditionFalse,
+				Message: "Error processing TCP proxy plugin: " + err.Error(),
+			})
+		}
+	}
+
 	tcpFilter, _ := NewFilterWithTypedConfig(wellknown.TCPProxy, cfg)
 
 	return append(networkFilters, tcpFilter)
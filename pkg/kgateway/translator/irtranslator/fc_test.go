@@ -3,9 +3,14 @@ package irtranslator_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoyhttp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoytcp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"istio.io/istio/pkg/ptr"
 	"istio.io/istio/pkg/slices"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -109,3 +114,200 @@ func TestFilterChains(t *testing.T) {
 		}
 	}
 }
+
+func TestDrainingGatewayClosesConnections(t *testing.T) {
+	listener := ir.ListenerIR{
+		HttpFilterChain: []ir.HttpFilterChainIR{{
+			FilterChainCommon: ir.FilterChainCommon{FilterChainName: "httpchain"},
+		}},
+	}
+	computeHCM := func(t *testing.T, draining bool) *envoyhttp.HttpConnectionManager {
+		t.Helper()
+		reportMap := reports.NewReportMap()
+		gateway := ir.GatewayIR{
+			SourceObject: &ir.Gateway{Obj: &gwv1.Gateway{}},
+			Draining:     draining,
+		}
+		envoyListener, _ := (&irtranslator.Translator{}).ComputeListener(
+			context.Background(), irtranslator.TranslationPassPlugins{}, gateway, listener, reports.NewReporter(&reportMap))
+		require.Len(t, envoyListener.GetFilterChains(), 1)
+		filters := envoyListener.GetFilterChains()[0].GetFilters()
+		require.NotEmpty(t, filters)
+		hcm := &envoyhttp.HttpConnectionManager{}
+		require.NoError(t, filters[len(filters)-1].GetTypedConfig().UnmarshalTo(hcm))
+		return hcm
+	}
+
+	t.Run("draining", func(t *testing.T) {
+		hcm := computeHCM(t, true)
+		assert.Equal(t, time.Second, hcm.GetCommonHttpProtocolOptions().GetMaxConnectionDuration().AsDuration())
+		assert.Equal(t, 5*time.Second, hcm.GetDrainTimeout().AsDuration())
+	})
+
+	t.Run("not draining", func(t *testing.T) {
+		hcm := computeHCM(t, false)
+		assert.Nil(t, hcm.GetCommonHttpProtocolOptions().GetMaxConnectionDuration())
+		assert.Nil(t, hcm.GetDrainTimeout())
+	})
+}
+
+// idleTcpProxy implements a test translation pass that sets the idle timeout of TCP proxies
+type idleTcpProxy struct {
+	ir.UnimplementedProxyTranslationPass
+}
+
+func (a idleTcpProxy) ApplyTcpProxy(pCtx *ir.TcpProxyContext, out *envoytcp.TcpProxy) error {
+	out.IdleTimeout = durationpb.New(time.Duration(pCtx.ListenerPort) * time.Second)
+	return nil
+}
+
+func TestTcpProxyPlugins(t *testing.T) {
+	listener := ir.ListenerIR{
+		BindPort: 9000,
+		TcpFilterChain: []ir.TcpIR{{
+			FilterChainCommon: ir.FilterChainCommon{FilterChainName: "tcpchain"},
+			BackendRefs:       []ir.BackendRefIR{{ClusterName: "backend"}},
+		}},
+	}
+	gateway := ir.GatewayIR{SourceObject: &ir.Gateway{Obj: &gwv1.Gateway{}}}
+	reportMap := reports.NewReportMap()
+
+	envoyListener, _ := (&irtranslator.Translator{}).ComputeListener(
+		context.Background(),
+		irtranslator.TranslationPassPlugins{
+			addFiltersGK: &irtranslator.TranslationPass{ProxyTranslationPass: idleTcpProxy{}},
+		},
+		gateway,
+		listener,
+		reports.NewReporter(&reportMap),
+	)
+
+	require.Len(t, envoyListener.GetFilterChains(), 1)
+	filters := envoyListener.GetFilterChains()[0].GetFilters()
+	require.NotEmpty(t, filters)
+	tcpProxy := &envoytcp.TcpProxy{}
+	require.NoError(t, filters[len(filters)-1].GetTypedConfig().UnmarshalTo(tcpProxy))
+	assert.Equal(t, "backend", tcpProxy.GetCluster())
+	assert.Equal(t, 9000*time.Second, tcpProxy.GetIdleTimeout().AsDuration())
+}
+
+// stagedHttpFilters implements a test translation pass that adds http filters
+type stagedHttpFilters struct {
+	ir.UnimplementedProxyTranslationPass
+	filters []filters.StagedHttpFilter
+}
+
+func (s stagedHttpFilters) HttpFilters(ir.HttpFiltersContext, ir.FilterChainCommon) ([]filters.StagedHttpFilter, error) {
+	return s.filters, nil
+}
+
+func TestHttpFilterOrdering(t *testing.T) {
+	listener := ir.ListenerIR{
+		HttpFilterChain: []ir.HttpFilterChainIR{{
+			FilterChainCommon: ir.FilterChainCommon{FilterChainName: "httpchain"},
+		}},
+	}
+	gateway := ir.GatewayIR{SourceObject: &ir.Gateway{Obj: &gwv1.Gateway{}}}
+	passes := irtranslator.TranslationPassPlugins{
+		{Group: "test.kgateway.dev", Kind: "Transform"}: {
+			Name: "transform",
+			ProxyTranslationPass: stagedHttpFilters{filters: []filters.StagedHttpFilter{
+				filters.MustNewStagedFilter("transform", &envoyhttp.HttpFilter{}, filters.AfterStage(filters.AuthZStage)),
+			}},
+		},
+		{Group: "test.kgateway.dev", Kind: "Auth"}: {
+			Name: "auth",
+			ProxyTranslationPass: stagedHttpFilters{filters: []filters.StagedHttpFilter{
+				filters.MustNewStagedFilter("authz", &envoyhttp.HttpFilter{}, filters.DuringStage(filters.AuthZStage)),
+				filters.MustNewStagedFilter("authn", &envoyhttp.HttpFilter{}, filters.DuringStage(filters.AuthNStage)),
+			}},
+		},
+		{Group: "test.kgateway.dev", Kind: "RateLimit"}: {
+			Name: "ratelimit",
+			ProxyTranslationPass: stagedHttpFilters{filters: []filters.StagedHttpFilter{
+				filters.MustNewStagedFilter("ratelimit", &envoyhttp.HttpFilter{}, filters.DuringStage(filters.RateLimitStage)),
+				// relative weights order filters within a stage: 2 is after AfterStage (1), -2 is before BeforeStage (-1)
+				filters.MustNewStagedFilter("z-late-authz", &envoyhttp.HttpFilter{}, filters.RelativeToStage(filters.AuthZStage, 2)),
+				filters.MustNewStagedFilter("a-early-authz", &envoyhttp.HttpFilter{}, filters.RelativeToStage(filters.AuthZStage, -2)),
+			}},
+		},
+		// the filters of plugins without a name are ordered by the GroupKind of the plugin
+		{Group: "test.kgateway.dev", Kind: "Unnamed"}: {
+			ProxyTranslationPass: stagedHttpFilters{filters: []filters.StagedHttpFilter{
+				filters.MustNewStagedFilter("unnamed", &envoyhttp.HttpFilter{}, filters.AfterStage(filters.AuthZStage)),
+			}},
+		},
+		// the same stage and weight is ordered by plugin name rather than filter name
+		{Group: "test.kgateway.dev", Kind: "Body"}: {
+			Name: "body",
+			ProxyTranslationPass: stagedHttpFilters{filters: []filters.StagedHttpFilter{
+				filters.MustNewStagedFilter("z-body", &envoyhttp.HttpFilter{}, filters.AfterStage(filters.AuthZStage)),
+			}},
+		},
+	}
+
+	computeFilterNames := func() []string {
+		reportMap := reports.NewReportMap()
+		envoyListener, _ := (&irtranslator.Translator{}).ComputeListener(
+			context.Background(), passes, gateway, listener, reports.NewReporter(&reportMap))
+		require.Len(t, envoyListener.GetFilterChains(), 1)
+		filters := envoyListener.GetFilterChains()[0].GetFilters()
+		require.NotEmpty(t, filters)
+		hcm := &envoyhttp.HttpConnectionManager{}
+		require.NoError(t, filters[len(filters)-1].GetTypedConfig().UnmarshalTo(hcm))
+		return slices.Map(hcm.GetHttpFilters(), (*envoyhttp.HttpFilter).GetName)
+	}
+
+	expected := []string{
+		"authn",
+		"a-early-authz",
+		"authz",
+		"unnamed", // Unnamed.test.kgateway.dev
+		"z-body",
+		"transform",
+		"z-late-authz",
+		"ratelimit",
+		"envoy.filters.http.router",
+	}
+	assert.Equal(t, expected, computeFilterNames())
+
+	// the order does not depend on the order the plugins are iterated in
+	for range 20 {
+		require.Equal(t, expected, computeFilterNames())
+	}
+}
+
+func TestHttpFilterOrderingSkipsDuplicates(t *testing.T) {
+	listener := ir.ListenerIR{
+		HttpFilterChain: []ir.HttpFilterChainIR{{
+			FilterChainCommon: ir.FilterChainCommon{FilterChainName: "httpchain"},
+		}},
+	}
+	gateway := ir.GatewayIR{SourceObject: &ir.Gateway{Obj: &gwv1.Gateway{}}}
+	shared := filters.MustNewStagedFilter("shared", &envoyhttp.HttpFilter{}, filters.DuringStage(filters.AuthZStage))
+	passes := irtranslator.TranslationPassPlugins{
+		{Group: "test.kgateway.dev", Kind: "A"}: {
+			Name:                 "a",
+			ProxyTranslationPass: stagedHttpFilters{filters: []filters.StagedHttpFilter{shared}},
+		},
+		{Group: "test.kgateway.dev", Kind: "B"}: {
+			Name: "b",
+			ProxyTranslationPass: stagedHttpFilters{filters: []filters.StagedHttpFilter{
+				filters.MustNewStagedFilter("other", &envoyhttp.HttpFilter{}, filters.DuringStage(filters.AuthZStage)),
+			}},
+		},
+		{Group: "test.kgateway.dev", Kind: "C"}: {
+			Name:                 "c",
+			ProxyTranslationPass: stagedHttpFilters{filters: []filters.StagedHttpFilter{shared}},
+		},
+	}
+
+	reportMap := reports.NewReportMap()
+	envoyListener, _ := (&irtranslator.Translator{}).ComputeListener(
+		context.Background(), passes, gateway, listener, reports.NewReporter(&reportMap))
+	require.Len(t, envoyListener.GetFilterChains(), 1)
+	networkFilters := envoyListener.GetFilterChains()[0].GetFilters()
+	hcm := &envoyhttp.HttpConnectionManager{}
+	require.NoError(t, networkFilters[len(networkFilters)-1].GetTypedConfig().UnmarshalTo(hcm))
+	assert.Equal(t, []string{"shared", "other", "envoy.filters.http.router"}, slices.Map(hcm.GetHttpFilters(), (*envoyhttp.HttpFilter).GetName))
+}
@@ -30,8 +30,18 @@ type Translator struct {
 	ContributedPolicies map[schema.GroupKind]sdk.PolicyPlugin
 	ValidationLevel     apisettings.ValidationMode
 	Validator           validator.Validator
+	// RouteReplacementStatusCode is the status code of the direct response that replaces
+	// invalid routes. Defaults to 500 when unset.
+	RouteReplacementStatusCode uint32
+
+	// backendPolicies looks up the policies attached to the backends of the routes
+	backendPolicies BackendPoliciesFunc
 }
 
+// BackendPoliciesFunc returns the policies attached to a backend. The backends of the routes in
+// the IR don't include their attached policies, as they are applied to the clusters instead.
+type BackendPoliciesFunc func(backend *ir.BackendObjectIR) ir.AttachedPolicies
+
 type TranslationPassPlugins map[schema.GroupKind]*TranslationPass
 
 type TranslationResult struct {
@@ -41,8 +51,19 @@ type TranslationResult struct {
 	Secrets       []*envoytlsv3.Secret
 }
 
-// Translate IR to gateway. IR is self contained, so no need for krt context
-func (t *Translator) Translate(ctx context.Context, gw ir.GatewayIR, reporter sdkreporter.Reporter) TranslationResult {
+// Translate IR to gateway. IR is self contained, so no need for krt context.
+// backendPolicies is optional and only used to report the conflicts between routes and the
+// policies attached to their backends.
+func (t *Translator) Translate(
+	ctx context.Context,
+	gw ir.GatewayIR,
+	reporter sdkreporter.Reporter,
+	backendPolicies BackendPoliciesFunc,
+) TranslationResult {
+	withBackendPolicies := *t
+	withBackendPolicies.backendPolicies = backendPolicies
+	t = &withBackendPolicies
+
 	pass := t.newPass(reporter)
 	var res TranslationResult
 
@@ -134,6 +155,8 @@ func (t *Translator) ComputeListener(
 			logger:                   logger.With("route_config_name", hfc.FilterChainName),
 			validationLevel:          t.ValidationLevel,
 			validator:                t.Validator,
+			replacementStatusCode:    t.RouteReplacementStatusCode,
+			backendPolicies:          t.backendPolicies,
 		}
 		rc := hr.ComputeRouteConfiguration(ctx, hfc.Vhosts)
 		if rc != nil {
@@ -215,8 +238,9 @@ func (t *Translator) runListenerPlugins(
 		policies, mergeOrigins := mergePolicies(pass, pols)
 		for _, pol := range policies {
 			pctx := &ir.ListenerContext{
-				Port:   l.BindPort,
-				Policy: pol.PolicyIr,
+				Port:      l.BindPort,
+				Policy:    pol.PolicyIr,
+				HasRoutes: hasRoutes(l),
 				PolicyAncestorRef: gwv1.ParentReference{
 					Group:     new(gwv1.Group(wellknown.GatewayGVK.Group)),
 					Kind:      new(gwv1.Kind(wellknown.GatewayGVK.Kind)),
@@ -231,6 +255,21 @@ func (t *Translator) runListenerPlugins(
 	}
 }
 
+// hasRoutes returns true if any route is attached to the listener
+func hasRoutes(l ir.ListenerIR) bool {
+	if len(l.TcpFilterChain) > 0 {
+		return true
+	}
+	for _, hfc := range l.HttpFilterChain {
+		for _, vh := range hfc.Vhosts {
+			if len(vh.Rules) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (t *Translator) newPass(reporter sdkreporter.Reporter) TranslationPassPlugins {
 	ret := TranslationPassPlugins{}
 	for k, v := range t.ContributedPolicies {
@@ -257,3 +296,12 @@ type TranslationPass struct {
 	// and within the same hierarchy, are Merged into a single Policy
 	MergePolicies func(policies []ir.PolicyAtt) ir.PolicyAtt
 }
+
+// pluginName returns the name the filters of the pass are ordered by, falling back to the GroupKind
+// of the policies of the plugin when it has no name.
+func (p *TranslationPass) pluginName(gk schema.GroupKind) string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return gk.String()
+}
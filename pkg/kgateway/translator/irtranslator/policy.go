@@ -90,6 +90,9 @@ func reportPolicyAttachmentStatus(
 		switch mergeOrigins.GetRefCount(policy.PolicyRef) {
 		case ir.MergeOriginsRefCountNone:
 			r.SetAttachmentState(reporter.PolicyAttachmentStateOverridden)
+			winners := mergeOrigins.PolicyIDs()
+			winners.Delete(policy.PolicyRef.ID())
+			r.AddOverridingPolicies(winners.UnsortedList()...)
 
 		case ir.MergeOriginsRefCountPartial:
 			r.SetAttachmentState(reporter.PolicyAttachmentStateMerged)
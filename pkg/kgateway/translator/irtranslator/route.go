@@ -40,6 +40,11 @@ type httpRouteConfigurationTranslator struct {
 	logger                   *slog.Logger
 	validationLevel          apisettings.ValidationMode
 	validator                validator.Validator
+	// replacementStatusCode is the status code of the direct response used for replaced
+	// routes and virtual hosts. Defaults to 500 when unset.
+	replacementStatusCode uint32
+	// backendPolicies looks up the policies attached to the backends of the routes, if set
+	backendPolicies BackendPoliciesFunc
 }
 
 const (
@@ -104,9 +109,9 @@ func (h *httpRouteConfigurationTranslator) ComputeRouteConfiguration(
 	if len(errs) > 0 {
 		// Anytime we encounter any errors while computing the RC or there's invalid policy
 		// attached to the RC (via Gateway or HTTPS listener), we need to replace the entire
-		// RC with a synthetic vhost that returns a 500 error for all traffic.
+		// RC with a synthetic vhost that returns an error for all traffic.
 		h.logger.Error("error applying route config plugins", "error", errors.Join(errs...))
-		cfg.VirtualHosts = []*envoyroutev3.VirtualHost{setFallBackConfig("default", "*")}
+		cfg.VirtualHosts = []*envoyroutev3.VirtualHost{setFallBackConfig("default", "*", h.directResponseStatus())}
 		return cfg
 	}
 	cfg.TypedPerFilterConfig = typedPerFilterConfigRoute.ToAnyMap()
@@ -128,7 +133,7 @@ func (h *httpRouteConfigurationTranslator) computeVirtualHosts(
 // computeVirtualHost translates one IR virtual host into an Envoy virtual host and
 // applies HTTP-listener attached policies at vhost scope. In the case of shared HTTP
 // ports, this is the isolation boundary: failures here replace only this vhost with
-// a 500 direct response while preserving name and domains.
+// a direct response while preserving name and domains.
 func (h *httpRouteConfigurationTranslator) computeVirtualHost(
 	ctx context.Context,
 	virtualHost *ir.VirtualHost,
@@ -179,16 +184,25 @@ func (h *httpRouteConfigurationTranslator) computeVirtualHost(
 			Message: err.Error(),
 		})
 		// replace the computed vhost with a fallback that preserves the original vhost identity
-		return setFallBackConfig(sanitizedName, domains[0])
+		return setFallBackConfig(sanitizedName, domains[0], h.directResponseStatus())
 	}
 	out.TypedPerFilterConfig = typedPerFilterConfigRoute.ToAnyMap()
 
 	return out
 }
 
-// setFallBackConfig creates a synthetic, catch-all virtual host that returns 500 errors
-// for all traffic that references this vhost.
-func setFallBackConfig(name, domain string) *envoyroutev3.VirtualHost {
+// directResponseStatus returns the status code of the direct response that replaces
+// invalid routes and virtual hosts.
+func (h *httpRouteConfigurationTranslator) directResponseStatus() uint32 {
+	if h.replacementStatusCode == 0 {
+		return http.StatusInternalServerError
+	}
+	return h.replacementStatusCode
+}
+
+// setFallBackConfig creates a synthetic, catch-all virtual host that returns the given
+// status for all traffic that references this vhost.
+func setFallBackConfig(name, domain string, status uint32) *envoyroutev3.VirtualHost {
 	return &envoyroutev3.VirtualHost{
 		Domains: []string{domain},
 		Name:    name,
@@ -200,7 +214,7 @@ func setFallBackConfig(name, domain string) *envoyroutev3.VirtualHost {
 			},
 			Action: &envoyroutev3.Route_DirectResponse{
 				DirectResponse: &envoyroutev3.DirectResponseAction{
-					Status: http.StatusInternalServerError,
+					Status: status,
 					Body: &envoycorev3.DataSource{
 						Specifier: &envoycorev3.DataSource_InlineString{
 							InlineString: directResponseActionBody,
@@ -236,6 +250,9 @@ func (h *httpRouteConfigurationTranslator) envoyRoutes(
 		// If there is more than one backend, we translate the backends as WeightedClusters and each weighted cluster
 		// will have a TypedPerFilterConfig that overrides the parent route-level config.
 		out.Action = h.translateRouteAction(in, out, nil)
+		if allBackendWeightsZero(in) {
+			routeReport.AddWarnings(fmt.Sprintf("all backendRefs of rule %d have weight 0, so traffic is distributed evenly among them", in.RuleIndex))
+		}
 	}
 
 	// Run plugins here that may set action. Handle the routeProcessingErr error later.
@@ -300,14 +317,17 @@ func (h *httpRouteConfigurationTranslator) envoyRoutes(
 	if routeReplacementErr != nil {
 		h.logger.Debug("invalid route", "error", routeReplacementErr)
 
-		// If routeAcceptanceErr is set, report Accepted=False with Reason=RouteRuleReplaced
+		// If routeAcceptanceErr is set, report the rule as invalid; this results in PartiallyInvalid=True,
+		// or Accepted=False with Reason=RouteRuleReplaced when all the rules of the route are invalid
 		if routeAcceptanceErr != nil {
-			routeReport.SetCondition(reportssdk.RouteCondition{
-				Type:    gwv1.RouteConditionAccepted,
-				Status:  metav1.ConditionFalse,
-				Reason:  gwv1.RouteConditionReason(reportssdk.RouteRuleReplacedReason),
-				Message: fmt.Sprintf("Replaced Rule (%d): %v", in.MatchIndex, routeAcceptanceErr),
-			})
+			invalidRule := reportssdk.InvalidRule{
+				Index:  in.RuleIndex,
+				Reason: routeAcceptanceErr.Error(),
+			}
+			if in.Parent != nil {
+				invalidRule.RuleCount = len(in.Parent.Rules)
+			}
+			routeReport.AddInvalidRule(invalidRule)
 		}
 
 		if h.validationLevel == apisettings.ValidationStandard || h.validationLevel == apisettings.ValidationStrict {
@@ -320,7 +340,7 @@ func (h *httpRouteConfigurationTranslator) envoyRoutes(
 			// Replace invalid route with a direct response
 			out.Action = &envoyroutev3.Route_DirectResponse{
 				DirectResponse: &envoyroutev3.DirectResponseAction{
-					Status: http.StatusInternalServerError,
+					Status: h.directResponseStatus(),
 					Body: &envoycorev3.DataSource{
 						Specifier: &envoycorev3.DataSource_InlineString{
 							InlineString: directResponseActionBody,
@@ -332,6 +352,10 @@ func (h *httpRouteConfigurationTranslator) envoyRoutes(
 		}
 	}
 
+	if routeReplacementErr == nil && h.backendPolicies != nil {
+		reportBackendCapabilityConflicts(routeReport, in, nativeRouteCapabilities(in), out, h.backendPolicies)
+	}
+
 	return out
 }
 
@@ -403,6 +427,9 @@ func (h *httpRouteConfigurationTranslator) runRoutePlugins(
 		delegatingParent = delegatingParent.DelegatingParent
 	}
 
+	// capabilities configured natively on the route rule take precedence over attached policies
+	native := nativeRouteCapabilities(in)
+
 	var errs []error
 	for _, gk := range attachedPolicies.ApplyOrderedGroupKinds() {
 		pols := attachedPolicies.Policies[gk]
@@ -431,7 +458,7 @@ func (h *httpRouteConfigurationTranslator) runRoutePlugins(
 				continue
 			}
 
-			pctx.Policy = pol.PolicyIr
+			pctx.Policy = resolveRouteCapabilityConflicts(h.reporter, h.listener.PolicyAncestorRef, in, native, pols, pol)
 			err := pass.ApplyForRoute(pctx, out)
 			if err != nil {
 				errs = append(errs, err)
@@ -552,6 +579,15 @@ func (h *httpRouteConfigurationTranslator) translateRouteAction(
 		clusters = append(clusters, cw)
 	}
 
+	// Per the Gateway API, a backendRef with weight 0 should not receive traffic. When all the
+	// backendRefs of a rule have weight 0, distribute the traffic evenly instead of rejecting the route;
+	// envoyRoutes warns about this on the route status.
+	if allBackendWeightsZero(in) {
+		for _, cw := range clusters {
+			cw.Weight = wrapperspb.UInt32(1)
+		}
+	}
+
 	action := outRoute.GetRoute()
 	if action == nil {
 		action = &envoyroutev3.RouteAction{
@@ -751,3 +787,10 @@ func envoyQueryMatcher(in []gwv1.HTTPQueryParamMatch) []*envoyroutev3.QueryParam
 	}
 	return out
 }
+
+// allBackendWeightsZero returns whether the rule splits traffic between several backends that all have weight 0.
+func allBackendWeightsZero(in ir.HttpRouteRuleMatchIR) bool {
+	return len(in.Backends) > 1 && !slices.ContainsFunc(in.Backends, func(b ir.HttpBackend) bool {
+		return b.Backend.Weight > 0
+	})
+}
@@ -0,0 +1,140 @@
+package irtranslator
+
+import (
+	"fmt"
+	"strings"
+
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"k8s.io/apimachinery/pkg/util/sets"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/reporter"
+)
+
+// nativeRouteCapabilities returns the capabilities configured natively by the HTTPRoute rule
+// the given match belongs to.
+func nativeRouteCapabilities(in ir.HttpRouteRuleMatchIR) sets.Set[ir.RouteCapability] {
+	if in.Parent == nil {
+		return nil
+	}
+	route, ok := in.Parent.SourceObject.(*gwv1.HTTPRoute)
+	if !ok || in.RuleIndex < 0 || in.RuleIndex >= len(route.Spec.Rules) {
+		return nil
+	}
+	return ir.NativeRouteCapabilities(route.Spec.Rules[in.RuleIndex])
+}
+
+// resolveRouteCapabilityConflicts returns the policy to apply to the route after removing the
+// fields that overlap with capabilities configured natively on the route rule, as native
+// HTTPRoute configuration always takes precedence. Each policy contributing an overlapping
+// field is reported as conflicted.
+func resolveRouteCapabilityConflicts(
+	rp reporter.Reporter,
+	ancestorRef gwv1.ParentReference,
+	in ir.HttpRouteRuleMatchIR,
+	native sets.Set[ir.RouteCapability],
+	attached []ir.PolicyAtt,
+	pol ir.PolicyAtt,
+) ir.PolicyIR {
+	p, ok := pol.PolicyIr.(ir.RouteCapabilityPolicy)
+	if !ok || native.Len() == 0 {
+		return pol.PolicyIr
+	}
+	overlaps := p.RouteCapabilityOverlaps(native)
+	if len(overlaps) == 0 {
+		return pol.PolicyIr
+	}
+
+	fields := make([]string, 0, len(overlaps))
+	for _, overlap := range overlaps {
+		fields = append(fields, overlap.Field)
+		conflict := fmt.Sprintf("%s overlaps with %s on HTTPRoute %s/%s rule %d",
+			overlap.Field, describeRouteCapability(overlap.Capability), in.Parent.Namespace, in.Parent.Name, in.RuleIndex)
+		for _, origin := range routeCapabilityOrigins(attached, pol, overlap.Field) {
+			key := reporter.PolicyKey{
+				Group:     origin.PolicyRef.Group,
+				Kind:      origin.PolicyRef.Kind,
+				Namespace: origin.PolicyRef.Namespace,
+				Name:      origin.PolicyRef.Name,
+			}
+			rp.Policy(key, origin.Generation).AncestorRef(ancestorRef).AddConflicts(conflict)
+		}
+	}
+	return p.WithoutFields(fields)
+}
+
+// routeCapabilityOrigins returns the attached policies that contributed the given field to the
+// policy applied to the route, which may be the result of merging the attached policies.
+func routeCapabilityOrigins(attached []ir.PolicyAtt, pol ir.PolicyAtt, field string) []ir.PolicyAtt {
+	if !pol.MergeOrigins.IsSet() {
+		if pol.PolicyRef == nil {
+			return nil
+		}
+		return []ir.PolicyAtt{pol}
+	}
+
+	root, _, _ := strings.Cut(field, ".")
+	ids := sets.New(pol.MergeOrigins.Get(root)...)
+	var origins []ir.PolicyAtt
+	for _, att := range attached {
+		if att.PolicyRef != nil && ids.Has(att.PolicyRef.ID()) {
+			origins = append(origins, att)
+		}
+	}
+	return origins
+}
+
+// reportBackendCapabilityConflicts reports the fields of the policies attached to the backends of
+// the route that overlap with capabilities configured natively on the route rule. The backend
+// policies still apply to the backends, but the native route configuration takes precedence for
+// the traffic of the rule, so the overlaps are reported with the Conflicted condition on the route.
+// Session persistence is the only such capability. It only takes precedence once the stateful
+// session filter is configured on the route, as it is skipped while the experimental Gateway API
+// features are disabled. The backend policies are only looked up in that case, so that the other
+// routes are not translated again when the policies of their backends change.
+func reportBackendCapabilityConflicts(
+	routeReport reporter.ParentRefReporter,
+	in ir.HttpRouteRuleMatchIR,
+	native sets.Set[ir.RouteCapability],
+	out *envoyroutev3.Route,
+	backendPolicies BackendPoliciesFunc,
+) {
+	if !native.Has(ir.RouteCapabilitySessionPersistence) || out.GetTypedPerFilterConfig()[wellknown.StatefulSessionFilterName] == nil {
+		return
+	}
+	native = sets.New(ir.RouteCapabilitySessionPersistence)
+
+	for _, backend := range in.Backends {
+		backendObj := backend.Backend.BackendObject
+		if backendObj == nil {
+			continue
+		}
+		attached := backendPolicies(backendObj)
+		for _, gk := range attached.ApplyOrderedGroupKinds() {
+			for _, pol := range attached.Policies[gk] {
+				p, ok := pol.PolicyIr.(ir.BackendRouteCapabilityPolicy)
+				if !ok || pol.PolicyRef == nil {
+					continue
+				}
+				for _, overlap := range p.RouteCapabilityOverlaps(native) {
+					routeReport.AddConflicts(fmt.Sprintf("%s of %s %s/%s attached to %s %s/%s is overridden by %s of rule %d",
+						overlap.Field, pol.PolicyRef.Kind, pol.PolicyRef.Namespace, pol.PolicyRef.Name,
+						backendObj.Kind, backendObj.Namespace, backendObj.Name,
+						describeRouteCapability(overlap.Capability), in.RuleIndex))
+				}
+			}
+		}
+	}
+}
+
+func describeRouteCapability(c ir.RouteCapability) string {
+	switch c {
+	case ir.RouteCapabilityTimeouts:
+		return "the timeouts"
+	case ir.RouteCapabilitySessionPersistence:
+		return "the session persistence"
+	}
+	return fmt.Sprintf("the %s filter", c)
+}
@@ -0,0 +1,234 @@
+package irtranslator
+
+import (
+	"testing"
+	"time"
+
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/reporter"
+	"github.com/kgateway-dev/kgateway/v2/pkg/reports"
+)
+
+// capabilityPolicy is a policy that sets the fields of its overlaps.
+type capabilityPolicy struct {
+	overlaps []ir.RouteCapabilityOverlap
+}
+
+func (p *capabilityPolicy) CreationTime() time.Time { return time.Time{} }
+
+func (p *capabilityPolicy) Equals(in any) bool { return false }
+
+func (p *capabilityPolicy) RouteCapabilityOverlaps(native sets.Set[ir.RouteCapability]) []ir.RouteCapabilityOverlap {
+	var out []ir.RouteCapabilityOverlap
+	for _, o := range p.overlaps {
+		if native.Has(o.Capability) {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+func (p *capabilityPolicy) WithoutFields(fields []string) ir.PolicyIR {
+	out := &capabilityPolicy{}
+	for _, o := range p.overlaps {
+		if !sets.New(fields...).Has(o.Field) {
+			out.overlaps = append(out.overlaps, o)
+		}
+	}
+	return out
+}
+
+func TestResolveRouteCapabilityConflicts(t *testing.T) {
+	gatewayRef := gwv1.ParentReference{
+		Group:     new(gwv1.Group(gwv1.GroupName)),
+		Kind:      new(gwv1.Kind("Gateway")),
+		Namespace: new(gwv1.Namespace("default")),
+		Name:      "gw",
+	}
+	policyRef := func(name string) *ir.AttachedPolicyRef {
+		return &ir.AttachedPolicyRef{Group: "gateway.kgateway.dev", Kind: "TrafficPolicy", Namespace: "default", Name: name}
+	}
+	policyKey := func(name string) reporter.PolicyKey {
+		return reporter.PolicyKey{Group: "gateway.kgateway.dev", Kind: "TrafficPolicy", Namespace: "default", Name: name}
+	}
+	route := &gwv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default"},
+		Spec: gwv1.HTTPRouteSpec{
+			Rules: []gwv1.HTTPRouteRule{
+				{},
+				{
+					Filters: []gwv1.HTTPRouteFilter{{
+						Type: gwv1.HTTPRouteFilterCORS,
+						CORS: &gwv1.HTTPCORSFilter{},
+					}},
+				},
+			},
+		},
+	}
+	match := func(ruleIdx int) ir.HttpRouteRuleMatchIR {
+		return ir.HttpRouteRuleMatchIR{
+			Parent: &ir.HttpRouteIR{
+				ObjectSource: ir.ObjectSource{Namespace: route.Namespace, Name: route.Name},
+				SourceObject: route,
+			},
+			RuleIndex: ruleIdx,
+		}
+	}
+	conflicted := func(rm reports.ReportMap, name string) *metav1.Condition {
+		status := rm.BuildPolicyStatus(t.Context(), policyKey(name), "example-controller", gwv1.PolicyStatus{})
+		require.NotNil(t, status)
+		require.Len(t, status.Ancestors, 1)
+		return meta.FindStatusCondition(status.Ancestors[0].Conditions, string(shared.PolicyConditionConflicted))
+	}
+	newPolicy := func() *capabilityPolicy {
+		return &capabilityPolicy{overlaps: []ir.RouteCapabilityOverlap{
+			{Field: "cors", Capability: ir.RouteCapabilityCORS},
+			{Field: "timeouts.request", Capability: ir.RouteCapabilityTimeouts},
+		}}
+	}
+
+	t.Run("overlapping field is removed and reported", func(t *testing.T) {
+		rm := reports.NewReportMap()
+		in := match(1)
+		pol := ir.PolicyAtt{PolicyIr: newPolicy(), PolicyRef: policyRef("cors"), Generation: 1}
+
+		got := resolveRouteCapabilityConflicts(reports.NewReporter(&rm), gatewayRef, in, nativeRouteCapabilities(in), []ir.PolicyAtt{pol}, pol)
+
+		assert.Equal(t, []ir.RouteCapabilityOverlap{{Field: "timeouts.request", Capability: ir.RouteCapabilityTimeouts}}, got.(*capabilityPolicy).overlaps)
+		cond := conflicted(rm, "cors")
+		require.NotNil(t, cond)
+		assert.Equal(t, metav1.ConditionTrue, cond.Status)
+		assert.Equal(t, string(shared.PolicyReasonRouteFilterOverlap), cond.Reason)
+		assert.Equal(t, reporter.PolicyConflictedMsg+": cors overlaps with the CORS filter on HTTPRoute default/route rule 1", cond.Message)
+	})
+
+	t.Run("merged policy reports the contributing policy only", func(t *testing.T) {
+		rm := reports.NewReportMap()
+		in := match(1)
+		attached := []ir.PolicyAtt{
+			{PolicyIr: newPolicy(), PolicyRef: policyRef("cors"), Generation: 1},
+			{PolicyIr: newPolicy(), PolicyRef: policyRef("other"), Generation: 1},
+		}
+		merged := ir.PolicyAtt{
+			PolicyIr: newPolicy(),
+			MergeOrigins: ir.MergeOrigins{
+				"cors":     sets.New(policyRef("cors").ID()),
+				"timeouts": sets.New(policyRef("other").ID()),
+			},
+		}
+		rp := reports.NewReporter(&rm)
+		rp.Policy(policyKey("other"), 1).AncestorRef(gatewayRef)
+
+		resolveRouteCapabilityConflicts(rp, gatewayRef, in, nativeRouteCapabilities(in), attached, merged)
+
+		assert.NotNil(t, conflicted(rm, "cors"))
+		assert.Nil(t, conflicted(rm, "other"))
+	})
+
+	t.Run("rule without overlapping filters is not flagged", func(t *testing.T) {
+		rm := reports.NewReportMap()
+		in := match(0)
+		policy := newPolicy()
+		pol := ir.PolicyAtt{PolicyIr: policy, PolicyRef: policyRef("cors"), Generation: 1}
+		rp := reports.NewReporter(&rm)
+		rp.Policy(policyKey("cors"), 1).AncestorRef(gatewayRef)
+
+		got := resolveRouteCapabilityConflicts(rp, gatewayRef, in, nativeRouteCapabilities(in), []ir.PolicyAtt{pol}, pol)
+
+		assert.Same(t, policy, got)
+		assert.Nil(t, conflicted(rm, "cors"))
+	})
+}
+
+func TestReportBackendCapabilityConflicts(t *testing.T) {
+	gatewayRef := gwv1.ParentReference{Name: "gw"}
+	route := &gwv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default"},
+		Spec: gwv1.HTTPRouteSpec{
+			CommonRouteSpec: gwv1.CommonRouteSpec{ParentRefs: []gwv1.ParentReference{gatewayRef}},
+			Rules: []gwv1.HTTPRouteRule{
+				{},
+				{SessionPersistence: &gwv1.SessionPersistence{SessionName: new("session")}},
+			},
+		},
+	}
+	backendPolicy := &capabilityPolicy{overlaps: []ir.RouteCapabilityOverlap{
+		{Field: "loadBalancer.ringHash", Capability: ir.RouteCapabilitySessionPersistence},
+	}}
+	backendPolicies := func(*ir.BackendObjectIR) ir.AttachedPolicies {
+		return ir.AttachedPolicies{Policies: map[schema.GroupKind][]ir.PolicyAtt{
+			{Group: "gateway.kgateway.dev", Kind: "BackendConfigPolicy"}: {{
+				PolicyIr:  backendPolicy,
+				PolicyRef: &ir.AttachedPolicyRef{Group: "gateway.kgateway.dev", Kind: "BackendConfigPolicy", Namespace: "default", Name: "ring-hash"},
+			}},
+		}}
+	}
+	match := func(ruleIdx int) ir.HttpRouteRuleMatchIR {
+		backend := &ir.BackendObjectIR{
+			ObjectSource: ir.ObjectSource{Kind: "Service", Namespace: "default", Name: "backend"},
+		}
+		return ir.HttpRouteRuleMatchIR{
+			Parent: &ir.HttpRouteIR{
+				ObjectSource: ir.ObjectSource{Namespace: route.Namespace, Name: route.Name},
+				SourceObject: route,
+			},
+			RuleIndex: ruleIdx,
+			Backends:  []ir.HttpBackend{{Backend: ir.BackendRefIR{BackendObject: backend}}},
+		}
+	}
+	statefulSession := &envoyroutev3.Route{TypedPerFilterConfig: map[string]*anypb.Any{
+		wellknown.StatefulSessionFilterName: {},
+	}}
+	conflicted := func(rm reports.ReportMap) *metav1.Condition {
+		status := rm.BuildRouteStatus(t.Context(), route, "example-controller")
+		require.NotNil(t, status)
+		require.Len(t, status.Parents, 1)
+		return meta.FindStatusCondition(status.Parents[0].Conditions, reporter.RouteConditionConflicted)
+	}
+
+	t.Run("session persistence overrides the hash based load balancer", func(t *testing.T) {
+		rm := reports.NewReportMap()
+		in := match(1)
+		routeReport := reports.NewReporter(&rm).Route(route).ParentRef(&gatewayRef)
+
+		reportBackendCapabilityConflicts(routeReport, in, nativeRouteCapabilities(in), statefulSession, backendPolicies)
+
+		cond := conflicted(rm)
+		require.NotNil(t, cond)
+		assert.Equal(t, metav1.ConditionTrue, cond.Status)
+		assert.Equal(t, reporter.RouteReasonBackendPolicyOverlap, cond.Reason)
+		assert.Equal(t, reporter.RouteConflictedMsg+": loadBalancer.ringHash of BackendConfigPolicy default/ring-hash attached to Service default/backend is overridden by the session persistence of rule 1", cond.Message)
+	})
+
+	t.Run("session persistence that is not configured on the route is not reported", func(t *testing.T) {
+		rm := reports.NewReportMap()
+		in := match(1)
+		routeReport := reports.NewReporter(&rm).Route(route).ParentRef(&gatewayRef)
+
+		reportBackendCapabilityConflicts(routeReport, in, nativeRouteCapabilities(in), &envoyroutev3.Route{}, backendPolicies)
+
+		assert.Nil(t, conflicted(rm))
+	})
+
+	t.Run("rule without session persistence is not reported", func(t *testing.T) {
+		rm := reports.NewReportMap()
+		in := match(0)
+		routeReport := reports.NewReporter(&rm).Route(route).ParentRef(&gatewayRef)
+
+		reportBackendCapabilityConflicts(routeReport, in, nativeRouteCapabilities(in), statefulSession, backendPolicies)
+
+		assert.Nil(t, conflicted(rm))
+	})
+}
@@ -1057,7 +1057,7 @@ func buildCaCertificateReference(
 		// Extract CA certificate from ConfigMap
 		caCertData, err := sslutils.GetCACertFromConfigMap(configMap)
 		if err != nil {
-			return "", fmt.Errorf("failed to extract CA certificate from ConfigMap %s/%s: %w", configMap.Namespace, configMap.Name, err)
+			return "", fmt.Errorf("failed to extract CA certificate from ConfigMap %s/%s: %v: %w", configMap.Namespace, configMap.Name, err, sslutils.ErrInvalidCACertificateRef)
 		}
 		return caCertData, nil
 
@@ -1090,7 +1090,7 @@ func buildCaCertificateReference(
 		// Extract CA certificate from Secret
 		caCertData, err := sslutils.GetCACertFromSecret(secret)
 		if err != nil {
-			return "", fmt.Errorf("failed to extract CA certificate from Secret %s/%s: %w", secret.Namespace, secret.Name, err)
+			return "", fmt.Errorf("failed to extract CA certificate from Secret %s/%s: %v: %w", secret.Namespace, secret.Name, err, sslutils.ErrInvalidCACertificateRef)
 		}
 		return caCertData, nil
 
@@ -1114,9 +1114,11 @@ func getCertValidationFromAttached(listener ir.Listener) *ir.ClientCertificateVa
 	}
 
 	// Aggregate ClientCertificateValidation from all attached ListenerPolicies.
-	// Collect unique CA cert refs and mark required if any policy requires it.
+	// Collect unique CA cert refs and SANs, and mark required if any policy requires it.
 	var aggregatedCerts []gwv1.ObjectReference
 	certSet := make(map[string]bool)
+	var aggregatedSANs []string
+	sanSet := make(map[string]bool)
 	requireClientCert := false
 
 	for _, polAtt := range polAttachments {
@@ -1141,6 +1143,13 @@ func getCertValidationFromAttached(listener ir.Listener) *ir.ClientCertificateVa
 			}
 		}
 
+		for _, san := range certVal.VerifySubjectAltNames {
+			if !sanSet[san] {
+				sanSet[san] = true
+				aggregatedSANs = append(aggregatedSANs, san)
+			}
+		}
+
 		requireClientCert = requireClientCert || certVal.RequireClientCertificate
 	}
 
@@ -1152,6 +1161,7 @@ func getCertValidationFromAttached(listener ir.Listener) *ir.ClientCertificateVa
 	return &ir.ClientCertificateValidationIR{
 		CACertificateRefs:        aggregatedCerts,
 		RequireClientCertificate: requireClientCert,
+		VerifySubjectAltNames:    aggregatedSANs,
 	}
 }
 
@@ -1213,6 +1223,9 @@ func applyClientCertificateValidation(
 		RequireClientCertificate: validationConfig.RequireClientCertificate,
 		AllowInsecureFallback:    validationConfig.AllowInsecureFallback,
 	}
+	if len(validationConfig.VerifySubjectAltNames) > 0 {
+		tlsConfig.VerifySubjectAltNames = validationConfig.VerifySubjectAltNames
+	}
 
 	return true, certErr
 }
@@ -64,7 +64,7 @@ func NewCombinedTranslator(
 }
 
 func (s *CombinedTranslator) Init(ctx context.Context) {
-	queries := query.NewData(s.commonCols)
+	queries := query.NewData(s.commonCols, s.extensions.ContributesRouteAttachmentChecks...)
 
 	listenerTranslatorConfig := gwtranslator.TranslatorConfig{
 		ListenerTranslatorConfig: listener.ListenerTranslatorConfig{
@@ -78,10 +78,13 @@ func (s *CombinedTranslator) Init(ctx context.Context) {
 		ContributedPolicies: s.extensions.ContributesPolicies,
 		ValidationLevel:     s.commonCols.Settings.ValidationMode,
 		Validator:           s.validator,
+
+		RouteReplacementStatusCode: uint32(s.commonCols.Settings.RouteReplacementStatusCode),
 	}
 	s.backendTranslator = &irtranslator.BackendTranslator{
 		ContributedBackends: make(map[schema.GroupKind]ir.BackendInit),
 		ContributedPolicies: s.extensions.ContributesPolicies,
+		ClusterMetadata:     s.extensions.ContributesClusterMetadata,
 		CommonCols:          s.commonCols,
 		Validator:           s.validator,
 		Mode:                s.commonCols.Settings.ValidationMode,
@@ -140,7 +143,10 @@ func (s *CombinedTranslator) TranslateGateway(kctx krt.HandlerContext, ctx conte
 	}
 
 	// we are recomputing xds snapshots as proxies have changed, signal that we need to sync xds with these new snapshots
-	xdsSnap := s.irtranslator.Translate(ctx, *gwir, r)
+	backendPolicies := func(backend *ir.BackendObjectIR) ir.AttachedPolicies {
+		return s.commonCols.BackendIndex.AttachedPolicies(kctx, backend)
+	}
+	xdsSnap := s.irtranslator.Translate(ctx, *gwir, r, backendPolicies)
 
 	return &xdsSnap, rm
 }
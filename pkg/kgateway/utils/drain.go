@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"strconv"
+
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	apiannotations "github.com/kgateway-dev/kgateway/v2/api/annotations"
+)
+
+// IsDraining returns whether the gateway is annotated to be drained.
+// Values of the annotation that aren't booleans are an error, and don't drain the gateway.
+func IsDraining(gw *gwv1.Gateway) (bool, error) {
+	v, ok := gw.GetAnnotations()[string(apiannotations.Drain)]
+	if !ok || v == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(v)
+}
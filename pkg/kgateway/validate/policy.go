@@ -0,0 +1,96 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+)
+
+// backrefRegex matches the numbered capture group references (e.g. \1) of a regex substitution.
+var backrefRegex = regexp.MustCompile(`\\([0-9])`)
+
+// TrafficPolicy validates the parts of a TrafficPolicy spec that can't be expressed as CEL rules
+// in its CRD schema, e.g. that its regular expressions compile.
+func TrafficPolicy(spec *kgateway.TrafficPolicySpec) error {
+	var errs []error
+	if spec.UrlRewrite != nil && spec.UrlRewrite.PathRegex != nil {
+		if err := pathRegexRewrite(spec.UrlRewrite.PathRegex); err != nil {
+			errs = append(errs, fmt.Errorf("urlRewrite.pathRegex: %w", err))
+		}
+	}
+	if spec.Csrf != nil {
+		for i, origin := range spec.Csrf.AdditionalOrigins {
+			if err := stringMatcher(origin); err != nil {
+				errs = append(errs, fmt.Errorf("csrf.additionalOrigins[%d]: %w", i, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Backend validates the parts of a Backend spec that can't be expressed as CEL rules in its CRD
+// schema, e.g. that its endpoint URLs can be translated to a cluster address.
+func Backend(spec *kgateway.BackendSpec) error {
+	var errs []error
+	if spec.Aws != nil && spec.Aws.Lambda.EndpointURL != nil {
+		if err := lambdaEndpointURL(*spec.Aws.Lambda.EndpointURL); err != nil {
+			errs = append(errs, fmt.Errorf("aws.lambda.endpointURL: %w", err))
+		}
+	}
+	if spec.Static != nil {
+		seen := make(map[kgateway.Host]struct{}, len(spec.Static.Hosts))
+		for i, host := range spec.Static.Hosts {
+			if _, ok := seen[host]; ok {
+				errs = append(errs, fmt.Errorf("static.hosts[%d]: duplicate host %s:%d", i, host.Host, host.Port))
+			}
+			seen[host] = struct{}{}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func pathRegexRewrite(rewrite *kgateway.PathRegexRewrite) error {
+	re, err := regexp.Compile(rewrite.Pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+	for _, ref := range backrefRegex.FindAllStringSubmatch(rewrite.Substitution, -1) {
+		// the regex only matches a single digit, so the conversion can't fail
+		group, _ := strconv.Atoi(ref[1])
+		if group > re.NumSubexp() {
+			return fmt.Errorf("substitution references group %d, but the pattern only has %d capture groups", group, re.NumSubexp())
+		}
+	}
+	return nil
+}
+
+func stringMatcher(m shared.StringMatcher) error {
+	if m.SafeRegex == nil {
+		return nil
+	}
+	if _, err := regexp.Compile(*m.SafeRegex); err != nil {
+		return fmt.Errorf("invalid safeRegex: %w", err)
+	}
+	return nil
+}
+
+// lambdaEndpointURL validates that the URL has a host and an explicit port, which the AWS Lambda
+// backend requires to build its cluster.
+func lambdaEndpointURL(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("%q must include a host", endpoint)
+	}
+	if _, err := strconv.ParseUint(u.Port(), 10, 16); err != nil {
+		return fmt.Errorf("%q must include a port", endpoint)
+	}
+	return nil
+}
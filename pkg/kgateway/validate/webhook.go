@@ -0,0 +1,86 @@
+package validate
+
+import (
+	"context"
+	"log/slog"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+)
+
+const (
+	// WebhookPath is the path the validation webhook is served on.
+	WebhookPath = "/validate"
+	// WebhookPort is the port the validation webhook is served on.
+	// If you change the port here, also change the port "webhook" in the helmchart.
+	WebhookPort = 9443
+	// WebhookCertDir is the directory of the TLS certificate the validation webhook is served with.
+	WebhookCertDir = "/etc/webhook-tls"
+)
+
+// Webhook validates TrafficPolicies and Backends on admission. It fails open: requests it can't
+// decode, or for kinds it doesn't validate, are allowed, so that an outdated webhook never blocks
+// changes to the API. Deletes and updates that leave the spec unchanged, e.g. status updates,
+// are always allowed.
+type Webhook struct {
+	decoder admission.Decoder
+}
+
+var _ admission.Handler = &Webhook{}
+
+// NewWebhook returns a Webhook that decodes the admitted objects with scheme.
+func NewWebhook(scheme *runtime.Scheme) *Webhook {
+	return &Webhook{decoder: admission.NewDecoder(scheme)}
+}
+
+func (w *Webhook) Handle(_ context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	var err error
+	switch req.Kind.Kind {
+	case wellknown.TrafficPolicyGVK.Kind:
+		err = validateRequest(w.decoder, req, &kgateway.TrafficPolicy{}, &kgateway.TrafficPolicy{},
+			func(tp *kgateway.TrafficPolicy) any { return tp.Spec },
+			func(tp *kgateway.TrafficPolicy) error { return TrafficPolicy(&tp.Spec) })
+	case wellknown.BackendGVK.Kind:
+		err = validateRequest(w.decoder, req, &kgateway.Backend{}, &kgateway.Backend{},
+			func(b *kgateway.Backend) any { return b.Spec },
+			func(b *kgateway.Backend) error { return Backend(&b.Spec) })
+	default:
+		return admission.Allowed("")
+	}
+
+	if err != nil {
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+// validateRequest decodes the admitted object into obj and validates it, unless the request is an
+// update that leaves the spec of old unchanged. Objects that can't be decoded are not validated.
+func validateRequest[T runtime.Object](
+	decoder admission.Decoder,
+	req admission.Request,
+	obj, old T,
+	spec func(T) any,
+	validate func(T) error,
+) error {
+	if err := decoder.Decode(req, obj); err != nil {
+		slog.Warn("unable to decode the admitted object, skipping validation",
+			"kind", req.Kind.Kind, "namespace", req.Namespace, "name", req.Name, "error", err)
+		return nil
+	}
+	if req.Operation == admissionv1.Update {
+		if err := decoder.DecodeRaw(req.OldObject, old); err == nil && equality.Semantic.DeepEqual(spec(old), spec(obj)) {
+			return nil
+		}
+	}
+	return validate(obj)
+}
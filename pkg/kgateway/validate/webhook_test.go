@@ -0,0 +1,212 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/schemes"
+)
+
+func TestTrafficPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    kgateway.TrafficPolicySpec
+		wantErr string
+	}{
+		{
+			name: "valid path regex rewrite",
+			spec: kgateway.TrafficPolicySpec{UrlRewrite: &kgateway.URLRewrite{
+				PathRegex: &kgateway.PathRegexRewrite{Pattern: "^/api/(v[0-9]+)/(.*)$", Substitution: `/\2?version=\1`},
+			}},
+		},
+		{
+			name: "invalid path regex pattern",
+			spec: kgateway.TrafficPolicySpec{UrlRewrite: &kgateway.URLRewrite{
+				PathRegex: &kgateway.PathRegexRewrite{Pattern: "^/api/(v[0-9]+", Substitution: "/"},
+			}},
+			wantErr: "urlRewrite.pathRegex: invalid pattern",
+		},
+		{
+			name: "path regex substitution references a missing group",
+			spec: kgateway.TrafficPolicySpec{UrlRewrite: &kgateway.URLRewrite{
+				PathRegex: &kgateway.PathRegexRewrite{Pattern: "^/api/(.*)$", Substitution: `/\2`},
+			}},
+			wantErr: "substitution references group 2, but the pattern only has 1 capture groups",
+		},
+		{
+			name: "valid csrf origin regex",
+			spec: kgateway.TrafficPolicySpec{Csrf: &kgateway.CSRFPolicy{
+				AdditionalOrigins: []shared.StringMatcher{{SafeRegex: ptr.To(`^https://.*\.example\.com$`)}},
+			}},
+		},
+		{
+			name: "invalid csrf origin regex",
+			spec: kgateway.TrafficPolicySpec{Csrf: &kgateway.CSRFPolicy{
+				AdditionalOrigins: []shared.StringMatcher{{Exact: ptr.To("example.com")}, {SafeRegex: ptr.To("*.example.com")}},
+			}},
+			wantErr: "csrf.additionalOrigins[1]: invalid safeRegex",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := TrafficPolicy(&tt.spec)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestBackend(t *testing.T) {
+	lambda := func(endpoint string) kgateway.BackendSpec {
+		return kgateway.BackendSpec{Aws: &kgateway.AwsBackend{
+			Lambda: kgateway.AwsLambda{EndpointURL: ptr.To(endpoint), FunctionName: "fn"},
+		}}
+	}
+	tests := []struct {
+		name    string
+		spec    kgateway.BackendSpec
+		wantErr string
+	}{
+		{
+			name: "lambda endpoint with port",
+			spec: lambda("http://localstack:4566"),
+		},
+		{
+			name:    "lambda endpoint without port",
+			spec:    lambda("https://lambda.example.com"),
+			wantErr: `aws.lambda.endpointURL: "https://lambda.example.com" must include a port`,
+		},
+		{
+			name: "distinct static hosts",
+			spec: kgateway.BackendSpec{Static: &kgateway.StaticBackend{
+				Hosts: []kgateway.Host{{Host: "a.example.com", Port: 80}, {Host: "a.example.com", Port: 8080}},
+			}},
+		},
+		{
+			name: "duplicate static hosts",
+			spec: kgateway.BackendSpec{Static: &kgateway.StaticBackend{
+				Hosts: []kgateway.Host{{Host: "a.example.com", Port: 80}, {Host: "a.example.com", Port: 80}},
+			}},
+			wantErr: "static.hosts[1]: duplicate host a.example.com:80",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Backend(&tt.spec)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	invalid := &kgateway.TrafficPolicy{
+		TypeMeta:   metav1.TypeMeta{APIVersion: kgateway.GroupVersion.String(), Kind: wellknown.TrafficPolicyGVK.Kind},
+		ObjectMeta: metav1.ObjectMeta{Name: "tp", Namespace: "default"},
+		Spec: kgateway.TrafficPolicySpec{UrlRewrite: &kgateway.URLRewrite{
+			PathRegex: &kgateway.PathRegexRewrite{Pattern: "(", Substitution: "/"},
+		}},
+	}
+	withStatus := invalid.DeepCopy()
+	withStatus.Status.Ancestors = []gwv1.PolicyAncestorStatus{{
+		AncestorRef:    gwv1.ParentReference{Name: "gw"},
+		ControllerName: wellknown.DefaultGatewayControllerName,
+	}}
+	withStatus.Annotations = map[string]string{"example.com/touched": "true"}
+	valid := invalid.DeepCopy()
+	valid.Spec.UrlRewrite.PathRegex.Pattern = "(.*)"
+
+	request := func(op admissionv1.Operation, kind string, obj, old runtime.Object) admission.Request {
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: op,
+			Kind:      metav1.GroupVersionKind{Group: kgateway.GroupName, Version: kgateway.GroupVersion.Version, Kind: kind},
+			Namespace: "default",
+			Name:      "tp",
+		}}
+		if obj != nil {
+			req.Object = raw(t, obj)
+		}
+		if old != nil {
+			req.OldObject = raw(t, old)
+		}
+		return req
+	}
+
+	tests := []struct {
+		name    string
+		req     admission.Request
+		allowed bool
+	}{
+		{
+			name: "create with an invalid spec is denied",
+			req:  request(admissionv1.Create, wellknown.TrafficPolicyGVK.Kind, invalid, nil),
+		},
+		{
+			name:    "update that fixes the spec is allowed",
+			req:     request(admissionv1.Update, wellknown.TrafficPolicyGVK.Kind, valid, invalid),
+			allowed: true,
+		},
+		{
+			name: "update that breaks the spec is denied",
+			req:  request(admissionv1.Update, wellknown.TrafficPolicyGVK.Kind, invalid, valid),
+		},
+		{
+			name:    "update that only touches status and metadata is allowed",
+			req:     request(admissionv1.Update, wellknown.TrafficPolicyGVK.Kind, withStatus, invalid),
+			allowed: true,
+		},
+		{
+			name:    "delete is allowed",
+			req:     request(admissionv1.Delete, wellknown.TrafficPolicyGVK.Kind, nil, invalid),
+			allowed: true,
+		},
+		{
+			name: "undecodable object fails open",
+			req: admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Kind:      metav1.GroupVersionKind{Group: kgateway.GroupName, Version: kgateway.GroupVersion.Version, Kind: wellknown.TrafficPolicyGVK.Kind},
+				Object:    runtime.RawExtension{Raw: []byte(`{"spec":`)},
+			}},
+			allowed: true,
+		},
+		{
+			name:    "unknown kind fails open",
+			req:     request(admissionv1.Create, "Unknown", invalid, nil),
+			allowed: true,
+		},
+	}
+
+	w := NewWebhook(schemes.DefaultScheme())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := w.Handle(context.Background(), tt.req)
+			assert.Equal(t, tt.allowed, resp.Allowed, "result: %v", resp.Result)
+		})
+	}
+}
+
+func raw(t *testing.T, obj runtime.Object) runtime.RawExtension {
+	t.Helper()
+	b, err := json.Marshal(obj)
+	require.NoError(t, err)
+	return runtime.RawExtension{Raw: b}
+}
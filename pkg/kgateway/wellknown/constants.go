@@ -37,8 +37,9 @@ const (
 )
 
 const (
-	SetMetadataFilterName = "envoy.filters.http.set_filter_state"
-	ExtprocFilterName     = "envoy.filters.http.ext_proc"
+	SetMetadataFilterName     = "envoy.filters.http.set_filter_state"
+	ExtprocFilterName         = "envoy.filters.http.ext_proc"
+	StatefulSessionFilterName = "envoy.filters.http.stateful_session"
 )
 
 const (
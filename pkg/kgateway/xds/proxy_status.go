@@ -0,0 +1,305 @@
+package xds
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	xdsserver "github.com/envoyproxy/go-control-plane/pkg/server/v3"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+)
+
+const (
+	xdsSubsystem     = "xds"
+	gwNameLabel      = "gateway_name"
+	gwNamespaceLabel = "gateway_namespace"
+
+	// staleCheckInterval is how often the stale proxies gauge is refreshed
+	staleCheckInterval = 5 * time.Second
+)
+
+var staleProxiesGauge = metrics.NewGauge(
+	metrics.GaugeOpts{
+		Subsystem: xdsSubsystem,
+		Name:      "stale_proxies",
+		Help:      "Number of connected proxies whose acknowledged configuration lags the latest xDS snapshot beyond the stale proxy threshold",
+	}, []string{gwNamespaceLabel, gwNameLabel})
+
+// ProxyStatus is the xDS sync state of a single connected proxy.
+type ProxyStatus struct {
+	// CacheKey is the key of the snapshot served to the proxy
+	CacheKey string `json:"cacheKey"`
+	NodeID   string `json:"nodeId"`
+	PodName  string `json:"podName,omitempty"`
+	PodUID   string `json:"podUid,omitempty"`
+	// AckedVersions are the last versions acknowledged by the proxy, by type URL
+	AckedVersions map[string]string `json:"ackedVersions"`
+	// LatestVersions are the versions of the latest snapshot, by type URL
+	LatestVersions map[string]string `json:"latestVersions,omitempty"`
+	// BehindSince is when the proxy started lagging the latest snapshot, if it does
+	BehindSince *time.Time `json:"behindSince,omitempty"`
+	// Stale is set when the proxy lags the latest snapshot for longer than the threshold
+	Stale bool `json:"stale"`
+}
+
+// ProxyStatusTracker tracks the versions acknowledged by each connected proxy and detects
+// proxies that keep running configuration older than the latest snapshot for their cache key,
+// e.g. a pod that rejects the configuration after a bad rollout.
+// It must be chained after the callbacks that rewrite the role of the node to its cache key,
+// and snapshots must be set through the cache returned by Cache.
+type ProxyStatusTracker struct {
+	xdsserver.CallbackFuncs
+	threshold time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	snapshots map[string]envoycache.ResourceSnapshot
+	proxies   map[int64]*proxyState
+}
+
+type proxyState struct {
+	node        *envoycorev3.Node
+	key         string
+	acked       map[string]string
+	behindSince time.Time
+}
+
+var _ xdsserver.Callbacks = (*ProxyStatusTracker)(nil)
+
+// NewProxyStatusTracker returns a tracker that considers proxies stale once they lag the latest
+// snapshot for longer than the threshold. A threshold of 0 disables stale proxy detection.
+func NewProxyStatusTracker(threshold time.Duration) *ProxyStatusTracker {
+	return &ProxyStatusTracker{
+		threshold: threshold,
+		now:       time.Now,
+		snapshots: make(map[string]envoycache.ResourceSnapshot),
+		proxies:   make(map[int64]*proxyState),
+	}
+}
+
+// OnStreamRequest implements server.Callbacks.
+func (t *ProxyStatusTracker) OnStreamRequest(streamID int64, req *discoveryv3.DiscoveryRequest) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p, ok := t.proxies[streamID]
+	if !ok {
+		p = &proxyState{acked: make(map[string]string)}
+		t.proxies[streamID] = p
+	}
+	if req.GetNode() != nil {
+		p.node = req.GetNode()
+		p.key = NewNodeRoleHasher().ID(req.GetNode())
+	}
+	switch {
+	case req.GetResponseNonce() == "":
+		// the first request for the type; the version is whatever the proxy had before
+		// connecting to this stream, so it only counts once acknowledged again
+		if _, ok := p.acked[req.GetTypeUrl()]; !ok {
+			p.acked[req.GetTypeUrl()] = ""
+		}
+	case req.GetErrorDetail() == nil:
+		p.acked[req.GetTypeUrl()] = req.GetVersionInfo()
+	}
+	t.update(p)
+	return nil
+}
+
+// OnStreamClosed implements server.Callbacks.
+func (t *ProxyStatusTracker) OnStreamClosed(streamID int64, _ *envoycorev3.Node) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.proxies, streamID)
+}
+
+// Cache wraps the snapshot cache, so that the tracker knows the latest snapshot of every proxy.
+func (t *ProxyStatusTracker) Cache(cache envoycache.SnapshotCache) envoycache.SnapshotCache {
+	return &proxyStatusCache{SnapshotCache: cache, tracker: t}
+}
+
+// Run periodically refreshes the stale proxies gauge until the context is done.
+func (t *ProxyStatusTracker) Run(ctx context.Context) {
+	if t.threshold <= 0 {
+		return
+	}
+	ticker := time.NewTicker(staleCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.refreshGauge()
+		}
+	}
+}
+
+// ProxyStatuses returns the status of every connected proxy, sorted by cache key and node ID.
+func (t *ProxyStatusTracker) ProxyStatuses() []ProxyStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	statuses := make([]ProxyStatus, 0, len(t.proxies))
+	for _, p := range t.proxies {
+		status := ProxyStatus{
+			CacheKey:      p.key,
+			NodeID:        p.node.GetId(),
+			PodName:       p.node.GetMetadata().GetFields()[PodNameKey].GetStringValue(),
+			PodUID:        p.node.GetMetadata().GetFields()[PodUIDKey].GetStringValue(),
+			AckedVersions: maps.Clone(p.acked),
+			Stale:         t.isStale(p, now),
+		}
+		if snap := t.snapshots[p.key]; snap != nil {
+			status.LatestVersions = make(map[string]string, len(p.acked))
+			for typeURL := range p.acked {
+				status.LatestVersions[typeURL] = snap.GetVersion(typeURL)
+			}
+		}
+		if !p.behindSince.IsZero() {
+			behindSince := p.behindSince
+			status.BehindSince = &behindSince
+		}
+		statuses = append(statuses, status)
+	}
+	slices.SortFunc(statuses, func(a, b ProxyStatus) int {
+		if c := strings.Compare(a.CacheKey, b.CacheKey); c != 0 {
+			return c
+		}
+		return strings.Compare(a.NodeID, b.NodeID)
+	})
+	return statuses
+}
+
+// Applied returns true if every proxy connected for the cache key acknowledged the versions of the
+// snapshot for the types it subscribed to. It is true when no proxy is connected for the key.
+func (t *ProxyStatusTracker) Applied(key string, snap envoycache.ResourceSnapshot) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, p := range t.proxies {
+		if p.key != key {
+			continue
+		}
+		for typeURL, version := range p.acked {
+			if snap.GetVersion(typeURL) != version {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// staleProxies returns the number of stale proxies by gateway.
+func (t *ProxyStatusTracker) staleProxies() map[gatewayRef]int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	stale := make(map[gatewayRef]int)
+	for _, p := range t.proxies {
+		if t.isStale(p, now) {
+			stale[gatewayOfCacheKey(p.key)]++
+		}
+	}
+	return stale
+}
+
+func (t *ProxyStatusTracker) refreshGauge() {
+	stale := t.staleProxies()
+	staleProxiesGauge.Reset()
+	for gw, count := range stale {
+		staleProxiesGauge.Set(float64(count),
+			metrics.Label{Name: gwNamespaceLabel, Value: gw.namespace},
+			metrics.Label{Name: gwNameLabel, Value: gw.name},
+		)
+	}
+}
+
+func (t *ProxyStatusTracker) isStale(p *proxyState, now time.Time) bool {
+	return t.threshold > 0 && !p.behindSince.IsZero() && now.Sub(p.behindSince) > t.threshold
+}
+
+func (t *ProxyStatusTracker) onSnapshot(key string, snap envoycache.ResourceSnapshot) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.snapshots[key] = snap
+	for _, p := range t.proxies {
+		if p.key == key {
+			t.update(p)
+		}
+	}
+}
+
+func (t *ProxyStatusTracker) onClear(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.snapshots, key)
+	for _, p := range t.proxies {
+		if p.key == key {
+			t.update(p)
+		}
+	}
+}
+
+// update records when the proxy started lagging the latest snapshot. Must be called with the lock held.
+func (t *ProxyStatusTracker) update(p *proxyState) {
+	if t.inSync(p) {
+		p.behindSince = time.Time{}
+	} else if p.behindSince.IsZero() {
+		p.behindSince = t.now()
+	}
+}
+
+func (t *ProxyStatusTracker) inSync(p *proxyState) bool {
+	snap := t.snapshots[p.key]
+	if snap == nil {
+		// nothing to lag behind
+		return true
+	}
+	for typeURL, version := range p.acked {
+		if snap.GetVersion(typeURL) != version {
+			return false
+		}
+	}
+	return true
+}
+
+type gatewayRef struct {
+	namespace string
+	name      string
+}
+
+// gatewayOfCacheKey returns the gateway of a cache key in the <owner>~<namespace>~<name> format,
+// which may be suffixed by the unique client segments.
+func gatewayOfCacheKey(key string) gatewayRef {
+	parts := strings.SplitN(key, KeyDelimiter, 4)
+	if len(parts) < 3 {
+		return gatewayRef{name: key}
+	}
+	return gatewayRef{namespace: parts[1], name: parts[2]}
+}
+
+// proxyStatusCache is a snapshot cache that keeps the tracker informed of the latest snapshots.
+type proxyStatusCache struct {
+	envoycache.SnapshotCache
+	tracker *ProxyStatusTracker
+}
+
+// SetSnapshot sets the snapshot of the node and records it as the latest one.
+func (c *proxyStatusCache) SetSnapshot(ctx context.Context, node string, snapshot envoycache.ResourceSnapshot) error {
+	if err := c.SnapshotCache.SetSnapshot(ctx, node, snapshot); err != nil {
+		return err
+	}
+	c.tracker.onSnapshot(node, snapshot)
+	return nil
+}
+
+// ClearSnapshot removes the snapshot of the node.
+func (c *proxyStatusCache) ClearSnapshot(node string) {
+	c.SnapshotCache.ClearSnapshot(node)
+	c.tracker.onClear(node)
+}
@@ -0,0 +1,145 @@
+package xds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	envoycachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics/metricstest"
+)
+
+const testCacheKey = "kgateway-kube-gateway-api~default~gw~1234~default"
+
+func testNode(podName, podUID string) *envoycorev3.Node {
+	return &envoycorev3.Node{
+		Id: podName + ".default",
+		Metadata: &structpb.Struct{Fields: map[string]*structpb.Value{
+			RoleKey:    structpb.NewStringValue(testCacheKey),
+			PodNameKey: structpb.NewStringValue(podName),
+			PodUIDKey:  structpb.NewStringValue(podUID),
+		}},
+	}
+}
+
+func setTestSnapshot(t *testing.T, cache envoycache.SnapshotCache, version string) {
+	t.Helper()
+	snap, err := envoycache.NewSnapshot(version, map[resource.Type][]envoycachetypes.Resource{
+		resource.ListenerType: {&envoylistenerv3.Listener{Name: "listener"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, cache.SetSnapshot(context.Background(), testCacheKey, snap))
+}
+
+func ack(t *testing.T, tracker *ProxyStatusTracker, streamID int64, node *envoycorev3.Node, version string) {
+	t.Helper()
+	require.NoError(t, tracker.OnStreamRequest(streamID, &discoveryv3.DiscoveryRequest{
+		Node:          node,
+		VersionInfo:   version,
+		ResponseNonce: "nonce-" + version,
+		TypeUrl:       resource.ListenerType,
+	}))
+}
+
+func nack(t *testing.T, tracker *ProxyStatusTracker, streamID int64, node *envoycorev3.Node, ackedVersion string) {
+	t.Helper()
+	require.NoError(t, tracker.OnStreamRequest(streamID, &discoveryv3.DiscoveryRequest{
+		Node:          node,
+		VersionInfo:   ackedVersion,
+		ResponseNonce: "nonce-rejected",
+		TypeUrl:       resource.ListenerType,
+		ErrorDetail:   &status.Status{Message: "invalid listener"},
+	}))
+}
+
+func TestProxyStatusTrackerStaleness(t *testing.T) {
+	staleProxiesGauge.Reset()
+
+	now := time.Unix(0, 0)
+	tracker := NewProxyStatusTracker(time.Minute)
+	tracker.now = func() time.Time { return now }
+	cache := tracker.Cache(envoycache.NewSnapshotCache(true, NewNodeRoleHasher(), nil))
+
+	podA := testNode("gw-a", "uid-a")
+	podB := testNode("gw-b", "uid-b")
+
+	setTestSnapshot(t, cache, "1")
+	for streamID, node := range map[int64]*envoycorev3.Node{1: podA, 2: podB} {
+		require.NoError(t, tracker.OnStreamRequest(streamID, &discoveryv3.DiscoveryRequest{
+			Node:    node,
+			TypeUrl: resource.ListenerType,
+		}))
+		ack(t, tracker, streamID, node, "1")
+	}
+	assert.Empty(t, tracker.staleProxies(), "proxies running the latest snapshot are not stale")
+
+	// a rollout: one pod applies the new configuration while the other rejects it
+	now = now.Add(time.Second)
+	setTestSnapshot(t, cache, "2")
+	ack(t, tracker, 1, podA, "2")
+	nack(t, tracker, 2, podB, "1")
+
+	now = now.Add(30 * time.Second)
+	assert.Empty(t, tracker.staleProxies(), "lagging within the threshold is not stale")
+
+	now = now.Add(31 * time.Second)
+	assert.Equal(t, map[gatewayRef]int{{namespace: "default", name: "gw"}: 1}, tracker.staleProxies())
+
+	statuses := tracker.ProxyStatuses()
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "gw-a", statuses[0].PodName)
+	assert.Equal(t, "uid-a", statuses[0].PodUID)
+	assert.False(t, statuses[0].Stale)
+	assert.Nil(t, statuses[0].BehindSince)
+	assert.Equal(t, map[string]string{resource.ListenerType: "2"}, statuses[0].AckedVersions)
+
+	assert.Equal(t, "gw-b", statuses[1].PodName)
+	assert.Equal(t, "uid-b", statuses[1].PodUID)
+	assert.True(t, statuses[1].Stale)
+	require.NotNil(t, statuses[1].BehindSince)
+	assert.Equal(t, time.Unix(1, 0), *statuses[1].BehindSince)
+	assert.Equal(t, map[string]string{resource.ListenerType: "1"}, statuses[1].AckedVersions)
+	assert.Equal(t, map[string]string{resource.ListenerType: "2"}, statuses[1].LatestVersions)
+
+	tracker.refreshGauge()
+	gathered := metricstest.MustGatherMetrics(t)
+	gathered.AssertMetrics("kgateway_xds_stale_proxies", []metricstest.ExpectMetric{
+		&metricstest.ExpectedMetric{
+			Labels: []metrics.Label{
+				{Name: gwNameLabel, Value: "gw"},
+				{Name: gwNamespaceLabel, Value: "default"},
+			},
+			Value: 1,
+		},
+	})
+
+	// a fix is rolled out: the stale pod catches up, and the other one only just started lagging
+	setTestSnapshot(t, cache, "3")
+	ack(t, tracker, 2, podB, "3")
+	assert.Empty(t, tracker.staleProxies())
+
+	// proxies whose snapshot was removed have nothing to lag behind
+	now = now.Add(2 * time.Minute)
+	require.Equal(t, map[gatewayRef]int{{namespace: "default", name: "gw"}: 1}, tracker.staleProxies())
+	cache.ClearSnapshot(testCacheKey)
+	assert.Empty(t, tracker.staleProxies())
+
+	tracker.OnStreamClosed(1, podA)
+	tracker.OnStreamClosed(2, podB)
+	assert.Empty(t, tracker.ProxyStatuses())
+
+	tracker.refreshGauge()
+	gathered = metricstest.MustGatherMetrics(t)
+	gathered.AssertMetricNotExists("kgateway_xds_stale_proxies")
+}
@@ -0,0 +1,346 @@
+package xds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sync"
+	"time"
+
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	envoycachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/logging"
+)
+
+const (
+	// SnapshotLabel labels the ConfigMaps holding persisted xDS snapshots with the ID of the store
+	// persisting them
+	SnapshotLabel = "kgateway.dev/xds-snapshot"
+
+	// SnapshotCacheKeyAnnotation is the annotation of a persisted snapshot ConfigMap holding the cache key
+	// the snapshot is served for
+	SnapshotCacheKeyAnnotation = "kgateway.dev/xds-cache-key"
+
+	snapshotConfigMapPrefix = "kgateway-xds-snapshot-"
+
+	// defaultSnapshotFlushInterval is how often updated snapshots are written to their ConfigMaps
+	defaultSnapshotFlushInterval = 10 * time.Second
+
+	// maxPersistedSnapshotSize keeps persisted snapshots below the size limit of a ConfigMap
+	maxPersistedSnapshotSize = 900 * 1024
+)
+
+// persistedTypes are the xDS types of the persisted snapshots, by ConfigMap key.
+// Secrets are never persisted.
+var persistedTypes = map[string]resource.Type{
+	"clusters":  resource.ClusterType,
+	"endpoints": resource.EndpointType,
+	"listeners": resource.ListenerType,
+	"routes":    resource.RouteType,
+}
+
+var storeLogger = logging.New("xds/snapshot_store")
+
+// SnapshotStore persists the xDS snapshots set in the cache to ConfigMaps, so that a restarted control plane
+// can serve the last snapshots to the proxies right away instead of only once its collections synced and the
+// translation completed. Secrets are excluded from the persisted snapshots.
+//
+// Restored snapshots are replaced as soon as a fresh snapshot is set for their cache key; the ones that
+// are still there once the fresh translation is ready are removed.
+//
+// Every replica restores the persisted snapshots, but only the leader writes and deletes the ConfigMaps, as
+// all the replicas translate the same snapshots. The ConfigMaps are scoped by the ID of the store, so that
+// the leaders of different shards don't remove each other's snapshots.
+type SnapshotStore struct {
+	client        kubernetes.Interface
+	namespace     string
+	id            string
+	flushInterval time.Duration
+
+	mu    sync.Mutex
+	cache envoycache.SnapshotCache
+	// restored are the cache keys still served a restored snapshot
+	restored map[string]bool
+	// pending are the snapshots to write on the next flush, by cache key; nil deletes the persisted snapshot
+	pending map[string]envoycache.ResourceSnapshot
+}
+
+// NewSnapshotStore returns a store persisting snapshots to ConfigMaps of the namespace, labeled with the ID.
+func NewSnapshotStore(client kubernetes.Interface, namespace, id string) *SnapshotStore {
+	return &SnapshotStore{
+		client:        client,
+		namespace:     namespace,
+		id:            id,
+		flushInterval: defaultSnapshotFlushInterval,
+		restored:      make(map[string]bool),
+		pending:       make(map[string]envoycache.ResourceSnapshot),
+	}
+}
+
+// Cache wraps the snapshot cache, so that the snapshots set in it are persisted.
+func (s *SnapshotStore) Cache(cache envoycache.SnapshotCache) envoycache.SnapshotCache {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache = cache
+	return &persistingCache{SnapshotCache: cache, store: s}
+}
+
+// Restore sets the persisted snapshots in the cache, for the cache keys without a snapshot yet.
+// Persisted snapshots that can't be decoded are ignored.
+func (s *SnapshotStore) Restore(ctx context.Context) error {
+	cms, err := s.client.CoreV1().ConfigMaps(s.namespace).List(ctx, metav1.ListOptions{LabelSelector: SnapshotLabel + "=" + s.id})
+	if err != nil {
+		return fmt.Errorf("listing persisted xds snapshots: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range cms.Items {
+		cm := &cms.Items[i]
+		key := cm.Annotations[SnapshotCacheKeyAnnotation]
+		if key == "" || cm.Name != snapshotConfigMapName(key) {
+			storeLogger.Warn("ignoring persisted xds snapshot without a matching cache key", "configmap", cm.Name)
+			continue
+		}
+		snap, err := decodeSnapshot(cm)
+		if err != nil {
+			storeLogger.Warn("ignoring corrupted persisted xds snapshot", "configmap", cm.Name, "cache_key", key, "error", err)
+			continue
+		}
+		if _, err := s.cache.GetSnapshot(key); err == nil {
+			// a fresh snapshot was set already
+			continue
+		}
+		if err := s.cache.SetSnapshot(ctx, key, snap); err != nil {
+			storeLogger.Warn("failed to restore persisted xds snapshot", "cache_key", key, "error", err)
+			continue
+		}
+		s.restored[key] = true
+		storeLogger.Info("serving persisted xds snapshot", "cache_key", key)
+	}
+	return nil
+}
+
+// Run writes the updated snapshots to their ConfigMaps until the context is done, once elected is closed.
+// Until then, the updates are kept to be written if this replica becomes the leader. Once synced returns
+// true, the restored snapshots that weren't replaced by a fresh one are removed along with their ConfigMaps.
+func (s *SnapshotStore) Run(ctx context.Context, synced func() bool, elected <-chan struct{}) {
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+	warm := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if !warm && synced() {
+			s.dropRestored()
+			warm = true
+		}
+		select {
+		case <-elected:
+			s.flush(ctx)
+		default:
+		}
+	}
+}
+
+func (s *SnapshotStore) onSnapshot(key string, snap envoycache.ResourceSnapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.restored, key)
+	s.pending[key] = snap
+}
+
+func (s *SnapshotStore) onClear(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.restored, key)
+	s.pending[key] = nil
+}
+
+// dropRestored removes the restored snapshots no fresh snapshot replaced, e.g. of deleted Gateways.
+func (s *SnapshotStore) dropRestored() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key := range s.restored {
+		storeLogger.Info("removing persisted xds snapshot that wasn't replaced", "cache_key", key)
+		s.cache.ClearSnapshot(key)
+		s.pending[key] = nil
+	}
+	clear(s.restored)
+}
+
+func (s *SnapshotStore) flush(ctx context.Context) {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[string]envoycache.ResourceSnapshot)
+	s.mu.Unlock()
+
+	for key, snap := range pending {
+		var err error
+		if snap == nil {
+			err = s.delete(ctx, key)
+		} else {
+			err = s.write(ctx, key, snap)
+		}
+		if err != nil {
+			storeLogger.Warn("failed to persist xds snapshot", "cache_key", key, "error", err)
+			s.mu.Lock()
+			// retry on the next flush unless the snapshot changed in the meantime
+			if _, ok := s.pending[key]; !ok {
+				s.pending[key] = snap
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (s *SnapshotStore) write(ctx context.Context, key string, snap envoycache.ResourceSnapshot) error {
+	cm, err := encodeSnapshot(s.namespace, s.id, key, snap)
+	if err != nil {
+		return err
+	}
+	size := 0
+	for _, b := range cm.BinaryData {
+		size += len(b)
+	}
+	if size > maxPersistedSnapshotSize {
+		storeLogger.Warn("xds snapshot too large to persist", "cache_key", key, "size", size)
+		// a previously persisted snapshot would be outdated
+		return s.delete(ctx, key)
+	}
+
+	cms := s.client.CoreV1().ConfigMaps(s.namespace)
+	_, err = cms.Update(ctx, cm, metav1.UpdateOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = cms.Create(ctx, cm, metav1.CreateOptions{})
+	}
+	return err
+}
+
+func (s *SnapshotStore) delete(ctx context.Context, key string) error {
+	err := s.client.CoreV1().ConfigMaps(s.namespace).Delete(ctx, snapshotConfigMapName(key), metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// snapshotConfigMapName returns the name of the ConfigMap persisting the snapshot of the cache key.
+// Cache keys aren't valid object names, so the name is derived from their hash.
+func snapshotConfigMapName(key string) string {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return fmt.Sprintf("%s%016x", snapshotConfigMapPrefix, h.Sum64())
+}
+
+// encodeSnapshot encodes the resources of every persisted type as a DiscoveryResponse carrying their version.
+func encodeSnapshot(namespace, id, key string, snap envoycache.ResourceSnapshot) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        snapshotConfigMapName(key),
+			Namespace:   namespace,
+			Labels:      map[string]string{SnapshotLabel: id},
+			Annotations: map[string]string{SnapshotCacheKeyAnnotation: key},
+		},
+		BinaryData: make(map[string][]byte, len(persistedTypes)),
+	}
+	for dataKey, typeURL := range persistedTypes {
+		resources := snap.GetResources(typeURL)
+		names := make([]string, 0, len(resources))
+		for name := range resources {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		resp := &discoveryv3.DiscoveryResponse{
+			VersionInfo: snap.GetVersion(typeURL),
+			TypeUrl:     typeURL,
+			Resources:   make([]*anypb.Any, 0, len(names)),
+		}
+		for _, name := range names {
+			a, err := anypb.New(resources[name])
+			if err != nil {
+				return nil, fmt.Errorf("encoding %s %s: %w", typeURL, name, err)
+			}
+			resp.Resources = append(resp.Resources, a)
+		}
+		b, err := proto.MarshalOptions{Deterministic: true}.Marshal(resp)
+		if err != nil {
+			return nil, fmt.Errorf("encoding %s: %w", typeURL, err)
+		}
+		cm.BinaryData[dataKey] = b
+	}
+	return cm, nil
+}
+
+func decodeSnapshot(cm *corev1.ConfigMap) (*envoycache.Snapshot, error) {
+	snap := &envoycache.Snapshot{}
+	for dataKey, typeURL := range persistedTypes {
+		b, ok := cm.BinaryData[dataKey]
+		if !ok {
+			return nil, fmt.Errorf("missing %s", dataKey)
+		}
+		resp := &discoveryv3.DiscoveryResponse{}
+		if err := proto.Unmarshal(b, resp); err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", dataKey, err)
+		}
+		if resp.GetTypeUrl() != typeURL {
+			return nil, fmt.Errorf("%s has type %q", dataKey, resp.GetTypeUrl())
+		}
+		items := make([]envoycachetypes.Resource, 0, len(resp.GetResources()))
+		for _, a := range resp.GetResources() {
+			if a.GetTypeUrl() != typeURL {
+				return nil, fmt.Errorf("%s has a resource of type %q", dataKey, a.GetTypeUrl())
+			}
+			m, err := a.UnmarshalNew()
+			if err != nil {
+				return nil, fmt.Errorf("decoding %s: %w", dataKey, err)
+			}
+			if v, ok := m.(interface{ Validate() error }); ok {
+				if err := v.Validate(); err != nil {
+					return nil, fmt.Errorf("invalid resource in %s: %w", dataKey, err)
+				}
+			}
+			if envoycache.GetResourceName(m) == "" {
+				return nil, errors.New("resource without a name in " + dataKey)
+			}
+			items = append(items, m)
+		}
+		snap.Resources[envoycache.GetResponseType(typeURL)] = envoycache.NewResources(resp.GetVersionInfo(), items)
+	}
+	return snap, nil
+}
+
+// persistingCache is a snapshot cache that queues the snapshots set in it to be persisted.
+type persistingCache struct {
+	envoycache.SnapshotCache
+	store *SnapshotStore
+}
+
+// SetSnapshot sets the snapshot of the node and queues it to be persisted.
+func (c *persistingCache) SetSnapshot(ctx context.Context, node string, snapshot envoycache.ResourceSnapshot) error {
+	if err := c.SnapshotCache.SetSnapshot(ctx, node, snapshot); err != nil {
+		return err
+	}
+	c.store.onSnapshot(node, snapshot)
+	return nil
+}
+
+// ClearSnapshot removes the snapshot of the node along with its persisted copy.
+func (c *persistingCache) ClearSnapshot(node string) {
+	c.SnapshotCache.ClearSnapshot(node)
+	c.store.onClear(node)
+}
@@ -0,0 +1,305 @@
+package xds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	envoycachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	envoycache "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/server/stream/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const (
+	testStoreNamespace = "kgateway-system"
+	testStoreID        = "kgateway"
+	otherCacheKey      = "kgateway-kube-gateway-api~default~other-gw~5678~default"
+)
+
+// persistTestSnapshot persists a snapshot of the given version for the cache key, like a control
+// plane running before a restart.
+func persistTestSnapshot(t *testing.T, client kubernetes.Interface, key, version string) {
+	t.Helper()
+	persistTestSnapshotAs(t, client, testStoreID, key, version)
+}
+
+func persistTestSnapshotAs(t *testing.T, client kubernetes.Interface, id, key, version string) {
+	t.Helper()
+	store := NewSnapshotStore(client, testStoreNamespace, id)
+	cache := store.Cache(envoycache.NewSnapshotCache(true, NewNodeRoleHasher(), nil))
+	snap, err := envoycache.NewSnapshot(version, map[resource.Type][]envoycachetypes.Resource{
+		resource.ListenerType: {&envoylistenerv3.Listener{Name: "listener"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, cache.SetSnapshot(context.Background(), key, snap))
+	store.flush(context.Background())
+}
+
+// restartedStore returns a store and cache of a restarted control plane, with the persisted
+// snapshots restored.
+func restartedStore(t *testing.T, client kubernetes.Interface) (*SnapshotStore, envoycache.SnapshotCache) {
+	t.Helper()
+	store := NewSnapshotStore(client, testStoreNamespace, testStoreID)
+	store.flushInterval = 10 * time.Millisecond
+	cache := store.Cache(envoycache.NewSnapshotCache(true, NewNodeRoleHasher(), nil))
+	require.NoError(t, store.Restore(context.Background()))
+	return store, cache
+}
+
+func persistedListenerVersion(t *testing.T, client kubernetes.Interface, key string) string {
+	t.Helper()
+	cm, err := client.CoreV1().ConfigMaps(testStoreNamespace).Get(context.Background(), snapshotConfigMapName(key), metav1.GetOptions{})
+	require.NoError(t, err)
+	snap, err := decodeSnapshot(cm)
+	require.NoError(t, err)
+	return snap.GetVersion(resource.ListenerType)
+}
+
+// closedChan returns a closed channel, for a store that is elected from the start.
+func closedChan() <-chan struct{} {
+	c := make(chan struct{})
+	close(c)
+	return c
+}
+
+// syncedWhenClosed returns a synced func reporting true once the channel is closed.
+func syncedWhenClosed(synced <-chan struct{}) func() bool {
+	return func() bool {
+		select {
+		case <-synced:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+func TestSnapshotStoreServesPersistedSnapshotAfterRestart(t *testing.T) {
+	client := fake.NewClientset()
+	persistTestSnapshot(t, client, testCacheKey, "1")
+
+	// no snapshot was translated yet after the restart
+	_, cache := restartedStore(t, client)
+
+	req := &discoveryv3.DiscoveryRequest{Node: testNode("gw-a", "uid-a"), TypeUrl: resource.ListenerType}
+	responses := make(chan envoycache.Response, 1)
+	_, err := cache.CreateWatch(req, stream.NewSotwSubscription(nil, true), responses)
+	require.NoError(t, err)
+
+	select {
+	case resp := <-responses:
+		discoveryResp, err := resp.GetDiscoveryResponse()
+		require.NoError(t, err)
+		assert.Equal(t, "1", discoveryResp.GetVersionInfo())
+		require.Len(t, discoveryResp.GetResources(), 1)
+		listener := &envoylistenerv3.Listener{}
+		require.NoError(t, discoveryResp.GetResources()[0].UnmarshalTo(listener))
+		assert.Equal(t, "listener", listener.GetName())
+	case <-time.After(time.Second):
+		t.Fatal("the persisted snapshot wasn't served")
+	}
+}
+
+func TestSnapshotStoreSwapsToFreshSnapshots(t *testing.T) {
+	client := fake.NewClientset()
+	persistTestSnapshot(t, client, testCacheKey, "1")
+	persistTestSnapshot(t, client, otherCacheKey, "1")
+
+	store, cache := restartedStore(t, client)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	synced := make(chan struct{})
+	go store.Run(ctx, syncedWhenClosed(synced), closedChan())
+
+	// the fresh translation replaces the restored snapshot of its cache key
+	setTestSnapshot(t, cache, "2")
+	snap, err := cache.GetSnapshot(testCacheKey)
+	require.NoError(t, err)
+	assert.Equal(t, "2", snap.GetVersion(resource.ListenerType))
+	assert.Eventually(t, func() bool {
+		return persistedListenerVersion(t, client, testCacheKey) == "2"
+	}, time.Second, 10*time.Millisecond)
+
+	// snapshots that weren't replaced are served until the fresh translation is ready
+	_, err = cache.GetSnapshot(otherCacheKey)
+	require.NoError(t, err)
+
+	close(synced)
+	assert.Eventually(t, func() bool {
+		_, err := cache.GetSnapshot(otherCacheKey)
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		cms, err := client.CoreV1().ConfigMaps(testStoreNamespace).List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		return len(cms.Items) == 1 && cms.Items[0].Name == snapshotConfigMapName(testCacheKey)
+	}, time.Second, 10*time.Millisecond)
+
+	snap, err = cache.GetSnapshot(testCacheKey)
+	require.NoError(t, err)
+	assert.Equal(t, "2", snap.GetVersion(resource.ListenerType))
+}
+
+func TestSnapshotStoreIgnoresCorruptedSnapshots(t *testing.T) {
+	client := fake.NewClientset()
+	persistTestSnapshot(t, client, testCacheKey, "1")
+	persistTestSnapshot(t, client, otherCacheKey, "1")
+
+	cms := client.CoreV1().ConfigMaps(testStoreNamespace)
+	cm, err := cms.Get(context.Background(), snapshotConfigMapName(otherCacheKey), metav1.GetOptions{})
+	require.NoError(t, err)
+	cm.BinaryData["listeners"] = []byte("not a discovery response")
+	_, err = cms.Update(context.Background(), cm, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// a snapshot persisted under a name that doesn't match its cache key is ignored as well
+	_, err = cms.Create(context.Background(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "kgateway-xds-snapshot-renamed",
+			Namespace:   testStoreNamespace,
+			Labels:      map[string]string{SnapshotLabel: testStoreID},
+			Annotations: map[string]string{SnapshotCacheKeyAnnotation: "kgateway-kube-gateway-api~default~renamed"},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	store, cache := restartedStore(t, client)
+
+	snap, err := cache.GetSnapshot(testCacheKey)
+	require.NoError(t, err)
+	assert.Equal(t, "1", snap.GetVersion(resource.ListenerType))
+	_, err = cache.GetSnapshot(otherCacheKey)
+	assert.Error(t, err)
+	_, err = cache.GetSnapshot("kgateway-kube-gateway-api~default~renamed")
+	assert.Error(t, err)
+	assert.Equal(t, map[string]bool{testCacheKey: true}, store.restored)
+}
+
+func TestSnapshotStoreOnlyLeaderWritesConfigMaps(t *testing.T) {
+	client := fake.NewClientset()
+	persistTestSnapshot(t, client, testCacheKey, "1")
+	persistTestSnapshot(t, client, otherCacheKey, "1")
+	client.ClearActions()
+
+	// two replicas of the control plane restart, and the first one is elected
+	leader, leaderCache := restartedStore(t, client)
+	follower, followerCache := restartedStore(t, client)
+	leaderCtx, stopLeader := context.WithCancel(context.Background())
+	defer stopLeader()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	synced := make(chan struct{})
+	followerElected := make(chan struct{})
+	go leader.Run(leaderCtx, syncedWhenClosed(synced), closedChan())
+	go follower.Run(ctx, syncedWhenClosed(synced), followerElected)
+
+	setTestSnapshot(t, leaderCache, "2")
+	setTestSnapshot(t, followerCache, "3")
+	close(synced)
+
+	// both replicas drop the restored snapshot locally, but only the leader removes its ConfigMap
+	for _, cache := range []envoycache.SnapshotCache{leaderCache, followerCache} {
+		assert.Eventually(t, func() bool {
+			_, err := cache.GetSnapshot(otherCacheKey)
+			return err != nil
+		}, time.Second, 10*time.Millisecond)
+	}
+	assert.Eventually(t, func() bool {
+		cms, err := client.CoreV1().ConfigMaps(testStoreNamespace).List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		return len(cms.Items) == 1 && persistedListenerVersion(t, client, testCacheKey) == "2"
+	}, time.Second, 10*time.Millisecond)
+
+	// the follower never wrote its snapshot
+	time.Sleep(5 * follower.flushInterval)
+	for _, action := range client.Actions() {
+		if action.GetVerb() != "update" && action.GetVerb() != "create" {
+			continue
+		}
+		cm := action.(k8stesting.CreateAction).GetObject().(*corev1.ConfigMap)
+		snap, err := decodeSnapshot(cm)
+		require.NoError(t, err)
+		assert.Equal(t, "2", snap.GetVersion(resource.ListenerType), "only the leader should write snapshots")
+	}
+
+	// the follower persists its pending snapshots once it takes over
+	stopLeader()
+	close(followerElected)
+	assert.Eventually(t, func() bool {
+		return persistedListenerVersion(t, client, testCacheKey) == "3"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSnapshotStoreLeavesSnapshotsOfOtherStores(t *testing.T) {
+	client := fake.NewClientset()
+	persistTestSnapshot(t, client, testCacheKey, "1")
+	// the leader of another shard persisted a snapshot of its own Gateway
+	persistTestSnapshotAs(t, client, "kgateway-shard-1", otherCacheKey, "1")
+
+	store, cache := restartedStore(t, client)
+	assert.Equal(t, map[string]bool{testCacheKey: true}, store.restored)
+	_, err := cache.GetSnapshot(otherCacheKey)
+	assert.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go store.Run(ctx, func() bool { return true }, closedChan())
+
+	// the restored snapshot that wasn't replaced is dropped, the other store's one is kept
+	assert.Eventually(t, func() bool {
+		cms, err := client.CoreV1().ConfigMaps(testStoreNamespace).List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		return len(cms.Items) == 1 && cms.Items[0].Name == snapshotConfigMapName(otherCacheKey)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestDecodeSnapshotRejectsInvalidData(t *testing.T) {
+	snap, err := envoycache.NewSnapshot("1", map[resource.Type][]envoycachetypes.Resource{
+		resource.ListenerType: {&envoylistenerv3.Listener{Name: "listener"}},
+	})
+	require.NoError(t, err)
+	valid, err := encodeSnapshot(testStoreNamespace, testStoreID, testCacheKey, snap)
+	require.NoError(t, err)
+
+	decoded, err := decodeSnapshot(valid)
+	require.NoError(t, err)
+	assert.Equal(t, "1", decoded.GetVersion(resource.ListenerType))
+	assert.Contains(t, decoded.GetResources(resource.ListenerType), "listener")
+
+	routes := valid.BinaryData["routes"]
+	tests := []struct {
+		name   string
+		mutate func(cm *corev1.ConfigMap)
+	}{
+		{
+			name:   "missing type",
+			mutate: func(cm *corev1.ConfigMap) { delete(cm.BinaryData, "clusters") },
+		},
+		{
+			name:   "undecodable data",
+			mutate: func(cm *corev1.ConfigMap) { cm.BinaryData["clusters"] = []byte{0xff, 0xff} },
+		},
+		{
+			name:   "resources of another type",
+			mutate: func(cm *corev1.ConfigMap) { cm.BinaryData["listeners"] = routes },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := valid.DeepCopy()
+			tt.mutate(cm)
+			_, err := decodeSnapshot(cm)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -20,6 +20,12 @@ const (
 	// RoleKey is the name of the ket in the node.metadata used to store the role
 	RoleKey = "role"
 
+	// PodNameKey is the name of the key in the node.metadata used to store the name of the proxy pod
+	PodNameKey = "pod_name"
+
+	// PodUIDKey is the name of the key in the node.metadata used to store the UID of the proxy pod
+	PodUIDKey = "pod_uid"
+
 	// PeerCtxKey is the key used to store the peer information in the context
 	PeerCtxKey = "peer"
 
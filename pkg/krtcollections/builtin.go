@@ -30,6 +30,7 @@ import (
 	apiannotations "github.com/kgateway-dev/kgateway/v2/api/annotations"
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/filters"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
@@ -38,7 +39,7 @@ import (
 )
 
 const (
-	statefulSessionFilterName = "envoy.filters.http.stateful_session"
+	statefulSessionFilterName = wellknown.StatefulSessionFilterName
 
 	httpRedirectStatusCodesAllowedMsg = "must be one of 301, 302, 303, 307, 308"
 )
@@ -349,6 +350,8 @@ func convertSessionPersistence(sessionPersistence *gwv1.SessionPersistence) *sta
 		if sessionPersistence.AbsoluteTimeout != nil {
 			if parsed, err := time.ParseDuration(string(*sessionPersistence.AbsoluteTimeout)); err == nil {
 				ttl = durationpb.New(parsed)
+			} else {
+				logger.Warn("ignoring invalid SessionPersistence absoluteTimeout", "absolute_timeout", *sessionPersistence.AbsoluteTimeout, "error", err)
 			}
 		}
 		cookie := &httpv3.Cookie{
@@ -560,23 +563,40 @@ func convertMirrorIR(
 	fromns string,
 	refgrants *RefGrantIndex,
 	ups *BackendIndex,
-) *mirrorIr {
+) (*mirrorIr, error) {
 	if f == nil {
-		return nil
+		return nil, nil
+	}
+	if err := validateMirrorFraction(*f); err != nil {
+		return nil, err
 	}
 	to := toFromBackendRef(fromns, f.BackendRef)
-	if !refgrants.ReferenceAllowed(kctx, fromgk, fromns, to) {
-		return nil
+	if err := refgrants.CheckReference(kctx, fromgk, fromns, to); err != nil {
+		return nil, fmt.Errorf("invalid request mirror backendRef %s: %w", to.ResourceName(), err)
 	}
 	up, err := ups.getBackendFromRef(kctx, fromns, f.BackendRef)
 	if err != nil {
-		return nil
+		return nil, fmt.Errorf("invalid request mirror backendRef %s: %w", to.ResourceName(), err)
 	}
 	fraction := getFractionPercent(*f)
 	return &mirrorIr{
 		Cluster:         up.ClusterName(),
 		RuntimeFraction: fraction,
+	}, nil
+}
+
+// validateMirrorFraction validates that the percentage of requests to mirror is between 0 and 100.
+func validateMirrorFraction(f gwv1.HTTPRequestMirrorFilter) error {
+	if f.Percent != nil && (*f.Percent < 0 || *f.Percent > 100) {
+		return fmt.Errorf("invalid request mirror percent %d: must be between 0 and 100", *f.Percent)
+	}
+	if f.Fraction != nil {
+		denom := ptr.Deref(f.Fraction.Denominator, 100)
+		if denom <= 0 || f.Fraction.Numerator < 0 || f.Fraction.Numerator > denom {
+			return fmt.Errorf("invalid request mirror fraction %d/%d: must be between 0 and 100 percent", f.Fraction.Numerator, denom)
+		}
 	}
+	return nil
 }
 
 // HEADER MODIFIER IR
@@ -781,7 +801,10 @@ func (h *RoutesIndex) convertfilterIR(
 	var policy applyToRoute
 	switch f.Type {
 	case gwv1.HTTPRouteFilterRequestMirror:
-		mir := convertMirrorIR(kctx, f.RequestMirror, fromgk, fromns, refgrants, ups)
+		mir, err := convertMirrorIR(kctx, f.RequestMirror, fromgk, fromns, refgrants, ups)
+		if err != nil {
+			return nil, err
+		}
 		if mir != nil {
 			policy = mir
 		}
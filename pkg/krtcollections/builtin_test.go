@@ -1,15 +1,22 @@
 package krtcollections
 
 import (
+	"strconv"
 	"testing"
+	"time"
 
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	stateful_sessionv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/stateful_session/v3"
+	stateful_cookie "github.com/envoyproxy/go-control-plane/envoy/extensions/http/stateful_session/cookie/v3"
+	httpv3 "github.com/envoyproxy/go-control-plane/envoy/type/http/v3"
 	envoy_type_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	envoytype "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/ptr"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
@@ -391,6 +398,39 @@ func TestGetFractionPercent(t *testing.T) {
 	}
 }
 
+func TestRequestMirrorInvalidFraction(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter gwv1.HTTPRequestMirrorFilter
+	}{
+		{
+			name:   "negative percent",
+			filter: gwv1.HTTPRequestMirrorFilter{Percent: new(int32(-1))},
+		},
+		{
+			name:   "percent over 100",
+			filter: gwv1.HTTPRequestMirrorFilter{Percent: new(int32(101))},
+		},
+		{
+			name:   "fraction over 100%",
+			filter: gwv1.HTTPRequestMirrorFilter{Fraction: &gwv1.Fraction{Numerator: 3, Denominator: new(int32(2))}},
+		},
+		{
+			name:   "zero denominator",
+			filter: gwv1.HTTPRequestMirrorFilter{Fraction: &gwv1.Fraction{Numerator: 0, Denominator: new(int32(0))}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mir, err := convertMirrorIR(nil, &tc.filter, schema.GroupKind{}, "default", nil, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid request mirror")
+			assert.Nil(t, mir)
+		})
+	}
+}
+
 func TestRequestMirror(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -857,3 +897,79 @@ func TestRequestRedirect(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestRedirectInvalidStatusCode(t *testing.T) {
+	for _, code := range []int{200, 304, 404} {
+		t.Run(strconv.Itoa(code), func(t *testing.T) {
+			_, err := convertRequestRedirectIR(nil, &gwv1.HTTPRequestRedirectFilter{StatusCode: new(code)}, nil, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), httpRedirectStatusCodesAllowedMsg)
+		})
+	}
+}
+
+func TestConvertSessionPersistence(t *testing.T) {
+	cookie := func(t *testing.T, sp *stateful_sessionv3.StatefulSessionPerRoute) *httpv3.Cookie {
+		sessionState := sp.GetStatefulSession().GetSessionState()
+		require.Equal(t, "envoy.http.stateful_session.cookie", sessionState.GetName())
+		state := &stateful_cookie.CookieBasedSessionState{}
+		require.NoError(t, sessionState.GetTypedConfig().UnmarshalTo(state))
+		return state.GetCookie()
+	}
+
+	tests := []struct {
+		name       string
+		in         *gwv1.SessionPersistence
+		wantCookie *httpv3.Cookie
+	}{
+		{
+			name: "permanent cookie expires after the absolute timeout",
+			in: &gwv1.SessionPersistence{
+				SessionName:     new("Session-A"),
+				AbsoluteTimeout: new(gwv1.Duration("1h30m")),
+				CookieConfig:    &gwv1.CookieConfig{LifetimeType: new(gwv1.PermanentCookieLifetimeType)},
+			},
+			wantCookie: &httpv3.Cookie{Name: "Session-A", Ttl: durationpb.New(90 * time.Minute)},
+		},
+		{
+			name: "permanent cookie without absolute timeout expires after a year",
+			in: &gwv1.SessionPersistence{
+				SessionName:  new("Session-A"),
+				CookieConfig: &gwv1.CookieConfig{LifetimeType: new(gwv1.PermanentCookieLifetimeType)},
+			},
+			wantCookie: &httpv3.Cookie{Name: "Session-A", Ttl: durationpb.New(365 * 24 * time.Hour)},
+		},
+		{
+			name: "session cookie has no expiry",
+			in: &gwv1.SessionPersistence{
+				SessionName:     new("Session-A"),
+				AbsoluteTimeout: new(gwv1.Duration("10s")),
+				CookieConfig:    &gwv1.CookieConfig{LifetimeType: new(gwv1.SessionCookieLifetimeType)},
+			},
+			wantCookie: &httpv3.Cookie{Name: "Session-A"},
+		},
+		{
+			name: "absolute timeout without cookie config",
+			in: &gwv1.SessionPersistence{
+				Type:            new(gwv1.CookieBasedSessionPersistence),
+				AbsoluteTimeout: new(gwv1.Duration("10s")),
+			},
+			wantCookie: &httpv3.Cookie{Name: "sessionPersistence", Ttl: durationpb.New(10 * time.Second)},
+		},
+		{
+			name: "invalid absolute timeout is ignored",
+			in: &gwv1.SessionPersistence{
+				SessionName:     new("Session-A"),
+				AbsoluteTimeout: new(gwv1.Duration("forever")),
+			},
+			wantCookie: &httpv3.Cookie{Name: "Session-A"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := convertSessionPersistence(tt.in)
+			require.NotNil(t, got)
+			assert.True(t, proto.Equal(tt.wantCookie, cookie(t, got)), "got cookie %v", cookie(t, got))
+		})
+	}
+}
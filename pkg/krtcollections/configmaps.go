@@ -45,8 +45,8 @@ func (c *ConfigMapIndex) GetConfigMap(kctx krt.HandlerContext, from From, config
 		Name:      string(configMapRef.Name),
 	}
 
-	if !c.refgrants.ReferenceAllowed(kctx, from.GroupKind, from.Namespace, to) {
-		return nil, ErrMissingReferenceGrant
+	if err := c.refgrants.CheckReference(kctx, from.GroupKind, from.Namespace, to); err != nil {
+		return nil, err
 	}
 
 	nn := types.NamespacedName{
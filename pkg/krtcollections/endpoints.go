@@ -162,8 +162,8 @@ func transformK8sEndpoints(inputs EndpointsInputs,
 			}
 
 			for _, endpoint := range endpointSlice.Endpoints {
-				// Skip endpoints that are not ready
-				if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				healthStatus, ok := endpointHealthStatus(endpoint.Conditions)
+				if !ok {
 					continue
 				}
 				// Get the addresses
@@ -198,12 +198,19 @@ func transformK8sEndpoints(inputs EndpointsInputs,
 							augmentedLabels = maybePod.AugmentedLabels
 						}
 					}
+					// fall back to the zone set by the EndpointSlice controller when the pod is not known,
+					// e.g. when the endpoints are not backed by pods or the pod was not synced yet
+					if l == (ir.PodLocality{}) && endpoint.Zone != nil {
+						l.Zone = *endpoint.Zone
+					}
 					ep := CreateLBEndpoint(addr, port, augmentedLabels, enableAutoMtls)
+					ep.HealthStatus = healthStatus
 
 					ret.Add(l, ir.EndpointWithMd{
 						LbEndpoint: ep,
 						EndpointMd: ir.EndpointMetadata{
-							Labels: augmentedLabels,
+							Labels:   augmentedLabels,
+							ForZones: zoneHints(endpoint.Hints),
 						},
 					})
 				}
@@ -216,6 +223,44 @@ func transformK8sEndpoints(inputs EndpointsInputs,
 	}
 }
 
+// zoneHints returns the zones of the topology hints of an EndpointSlice endpoint.
+func zoneHints(hints *discoveryv1.EndpointHints) []string {
+	if hints == nil || len(hints.ForZones) == 0 {
+		return nil
+	}
+	zones := make([]string, 0, len(hints.ForZones))
+	for _, z := range hints.ForZones {
+		zones = append(zones, z.Name)
+	}
+	return zones
+}
+
+// endpointHealthStatus returns the health status of an EndpointSlice endpoint, and false if the
+// endpoint must not be added to the backend endpoints at all:
+//   - ready endpoints are left with an unknown health status, which Envoy considers healthy.
+//   - terminating endpoints that are still serving are degraded, so that Envoy only sends them
+//     traffic when there are not enough ready endpoints, e.g. while new pods of a rollout start up.
+//   - terminating endpoints that stopped serving are removed.
+//   - other unready endpoints are unhealthy. They are only sent to Envoy when a backend has no
+//     serving endpoints and opts into the fallback to unready endpoints, see endpoints.PrioritizeEndpoints.
+func endpointHealthStatus(conds discoveryv1.EndpointConditions) (envoycorev3.HealthStatus, bool) {
+	// a nil condition means the state is unknown, in which case the endpoint must be considered ready
+	ready := conds.Ready == nil || *conds.Ready
+	if ready {
+		return envoycorev3.HealthStatus_UNKNOWN, true
+	}
+	serving := conds.Serving != nil && *conds.Serving
+	terminating := conds.Terminating != nil && *conds.Terminating
+	switch {
+	case terminating && serving:
+		return envoycorev3.HealthStatus_DEGRADED, true
+	case terminating:
+		return envoycorev3.HealthStatus_UNKNOWN, false
+	default:
+		return envoycorev3.HealthStatus_UNHEALTHY, true
+	}
+}
+
 func CreateLBEndpoint(address string, port uint32, podLabels map[string]string, enableAutoMtls bool) *envoyendpointv3.LbEndpoint {
 	// Don't get the metadata labels and filter metadata for the envoy load balancer based on the backend, as this is not used
 	// metadata := getLbMetadata(upstream, labels, "")
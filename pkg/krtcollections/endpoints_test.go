@@ -1073,7 +1073,7 @@ func TestEndpoints(t *testing.T) {
 			},
 		},
 		{
-			name: "filter out unready endpoints",
+			name: "unready endpoints are unhealthy",
 			inputs: []any{
 				&corev1.Pod{
 					TypeMeta: metav1.TypeMeta{},
@@ -1156,8 +1156,18 @@ func TestEndpoints(t *testing.T) {
 				},
 			}),
 			result: func(us ir.BackendObjectIR) *ir.EndpointsForBackend {
-				// The result should be empty since no ready endpoints are available.
+				// The unready endpoint is kept as unhealthy, so that it is only sent to Envoy
+				// when the backend falls back to unready endpoints.
 				result := ir.NewEndpointsForBackend(us)
+				result.Add(ir.PodLocality{
+					Region: "region1",
+					Zone:   "zone1",
+				}, testEndpoint("1.2.3.4", envoycorev3.HealthStatus_UNHEALTHY, map[string]string{
+					corev1.LabelTopologyRegion: "region1",
+					corev1.LabelTopologyZone:   "zone1",
+					corev1.LabelHostname:       "node1",
+					"app":                      "test",
+				}))
 				return result
 			},
 		},
@@ -1344,3 +1354,264 @@ func TestEndpoints(t *testing.T) {
 		})
 	}
 }
+
+func testEndpoint(addr string, healthStatus envoycorev3.HealthStatus, labels map[string]string) ir.EndpointWithMd {
+	ep := CreateLBEndpoint(addr, 8080, labels, false)
+	ep.HealthStatus = healthStatus
+	return ir.EndpointWithMd{
+		LbEndpoint: ep,
+		EndpointMd: ir.EndpointMetadata{
+			Labels: labels,
+		},
+	}
+}
+
+func TestEndpointsDuringRollout(t *testing.T) {
+	g := NewWithT(t)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "ns"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: "http", Port: 8080}},
+		},
+	}
+	upstream := newBackendObjectIR(ir.BackendObjectIR{
+		ObjectSource: ir.ObjectSource{Namespace: "ns", Name: "svc", Kind: "Service"},
+		Port:         8080,
+		Obj:          svc,
+	})
+	endpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "svc-abcde",
+			Namespace: "ns",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "svc"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{
+			{
+				// new pod that is ready
+				Addresses:  []string{"10.0.0.1"},
+				Conditions: discoveryv1.EndpointConditions{Ready: new(true), Serving: new(true), Terminating: new(false)},
+			},
+			{
+				// new pod that is starting up
+				Addresses:  []string{"10.0.0.2"},
+				Conditions: discoveryv1.EndpointConditions{Ready: new(false), Serving: new(false), Terminating: new(false)},
+			},
+			{
+				// old pod that is terminating but still serving
+				Addresses:  []string{"10.0.0.3"},
+				Conditions: discoveryv1.EndpointConditions{Ready: new(false), Serving: new(true), Terminating: new(true)},
+			},
+			{
+				// old pod that is terminating and stopped serving
+				Addresses:  []string{"10.0.0.4"},
+				Conditions: discoveryv1.EndpointConditions{Ready: new(false), Serving: new(false), Terminating: new(true)},
+			},
+		},
+		Ports: []discoveryv1.EndpointPort{{Name: new("http"), Port: new(int32(8080))}},
+	}
+
+	mock := krttest.NewMock(t, []any{endpointSlice})
+	nodes := NewNodeMetadataCollection(krttest.GetMockCollection[*corev1.Node](mock))
+	pods := NewLocalityPodsCollection(nodes, krttest.GetMockCollection[*corev1.Pod](mock), krtutil.KrtOptions{})
+	pods.WaitUntilSynced(context.Background().Done())
+	endpointSlices := krttest.GetMockCollection[*discoveryv1.EndpointSlice](mock)
+	builder := transformK8sEndpoints(EndpointsInputs{
+		Backends:       krttest.GetMockCollection[ir.BackendObjectIR](mock),
+		EndpointSlices: endpointSlices,
+		EndpointSlicesByService: krtpkg.UnnamedIndex(endpointSlices, func(es *discoveryv1.EndpointSlice) []types.NamespacedName {
+			return []types.NamespacedName{{Namespace: es.Namespace, Name: es.Labels[discoveryv1.LabelServiceName]}}
+		}),
+		Pods: pods,
+	})
+
+	eps := builder(krt.TestingDummyContext{}, upstream)
+
+	expected := ir.NewEndpointsForBackend(upstream)
+	expected.Add(ir.PodLocality{}, testEndpoint("10.0.0.1", envoycorev3.HealthStatus_UNKNOWN, nil))
+	expected.Add(ir.PodLocality{}, testEndpoint("10.0.0.2", envoycorev3.HealthStatus_UNHEALTHY, nil))
+	expected.Add(ir.PodLocality{}, testEndpoint("10.0.0.3", envoycorev3.HealthStatus_DEGRADED, nil))
+	g.Expect(eps.Equals(*expected)).To(BeTrue(), "expected %v, got %v", expected, eps)
+}
+
+func TestEndpointsLocalityFromEndpointSlice(t *testing.T) {
+	g := NewWithT(t)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "ns"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: "http", Port: 8080}},
+		},
+	}
+	upstream := newBackendObjectIR(ir.BackendObjectIR{
+		ObjectSource: ir.ObjectSource{Namespace: "ns", Name: "svc", Kind: "Service"},
+		Port:         8080,
+		Obj:          svc,
+	})
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns"},
+		Spec:       corev1.PodSpec{NodeName: "node"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.1"},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node",
+			Labels: map[string]string{
+				corev1.LabelTopologyRegion: "region",
+				corev1.LabelTopologyZone:   "zone-a",
+			},
+		},
+	}
+	endpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "svc-abcde",
+			Namespace: "ns",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "svc"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{
+			{
+				// the locality of known pods is taken from their node, which also has the region
+				Addresses: []string{"10.0.0.1"},
+				Zone:      new("zone-b"),
+				TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "pod", Namespace: "ns"},
+			},
+			{
+				// unknown pod
+				Addresses: []string{"10.0.0.2"},
+				Zone:      new("zone-b"),
+				TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "missing", Namespace: "ns"},
+			},
+			{
+				// endpoint that is not backed by a pod
+				Addresses: []string{"10.0.0.3"},
+				Zone:      new("zone-c"),
+			},
+			{
+				// endpoint without topology information
+				Addresses: []string{"10.0.0.4"},
+			},
+		},
+		Ports: []discoveryv1.EndpointPort{{Name: new("http"), Port: new(int32(8080))}},
+	}
+
+	mock := krttest.NewMock(t, []any{pod, node, endpointSlice})
+	nodes := NewNodeMetadataCollection(krttest.GetMockCollection[*corev1.Node](mock))
+	pods := NewLocalityPodsCollection(nodes, krttest.GetMockCollection[*corev1.Pod](mock), krtutil.KrtOptions{})
+	pods.WaitUntilSynced(context.Background().Done())
+	endpointSlices := krttest.GetMockCollection[*discoveryv1.EndpointSlice](mock)
+	builder := transformK8sEndpoints(EndpointsInputs{
+		Backends:       krttest.GetMockCollection[ir.BackendObjectIR](mock),
+		EndpointSlices: endpointSlices,
+		EndpointSlicesByService: krtpkg.UnnamedIndex(endpointSlices, func(es *discoveryv1.EndpointSlice) []types.NamespacedName {
+			return []types.NamespacedName{{Namespace: es.Namespace, Name: es.Labels[discoveryv1.LabelServiceName]}}
+		}),
+		Pods: pods,
+	})
+
+	eps := builder(krt.TestingDummyContext{}, upstream)
+
+	addressesByLocality := map[ir.PodLocality][]string{}
+	for locality, lbEps := range eps.LbEps {
+		for _, ep := range lbEps {
+			addressesByLocality[locality] = append(addressesByLocality[locality], ep.GetEndpoint().GetAddress().GetSocketAddress().GetAddress())
+		}
+	}
+	g.Expect(addressesByLocality).To(Equal(map[ir.PodLocality][]string{
+		{Region: "region", Zone: "zone-a"}: {"10.0.0.1"},
+		{Zone: "zone-b"}:                   {"10.0.0.2"},
+		{Zone: "zone-c"}:                   {"10.0.0.3"},
+		{}:                                 {"10.0.0.4"},
+	}))
+}
+
+func TestEndpointsFromMultipleSlicesWithTopologyHints(t *testing.T) {
+	g := NewWithT(t)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "ns"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: "http", Port: 8080}},
+		},
+	}
+	upstream := newBackendObjectIR(ir.BackendObjectIR{
+		ObjectSource: ir.ObjectSource{Namespace: "ns", Name: "svc", Kind: "Service"},
+		Port:         8080,
+		Obj:          svc,
+	})
+	hintedEndpoint := func(addr, zone string, forZones ...string) discoveryv1.Endpoint {
+		ep := discoveryv1.Endpoint{
+			Addresses: []string{addr},
+			Zone:      new(zone),
+		}
+		if len(forZones) > 0 {
+			ep.Hints = &discoveryv1.EndpointHints{}
+			for _, z := range forZones {
+				ep.Hints.ForZones = append(ep.Hints.ForZones, discoveryv1.ForZone{Name: z})
+			}
+		}
+		return ep
+	}
+	slice := func(name string, endpoints ...discoveryv1.Endpoint) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "ns",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "svc"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints:   endpoints,
+			Ports:       []discoveryv1.EndpointPort{{Name: new("http"), Port: new(int32(8080))}},
+		}
+	}
+
+	mock := krttest.NewMock(t, []any{
+		slice("svc-abcde",
+			hintedEndpoint("10.0.0.1", "zone-a", "zone-a"),
+			hintedEndpoint("10.0.0.2", "zone-b", "zone-b", "zone-c"),
+		),
+		slice("svc-fghij",
+			hintedEndpoint("10.0.0.3", "zone-a", "zone-a"),
+			// the address is also in the first slice while it moves between slices
+			hintedEndpoint("10.0.0.1", "zone-a", "zone-a"),
+			hintedEndpoint("10.0.0.4", "zone-c"),
+		),
+	})
+	nodes := NewNodeMetadataCollection(krttest.GetMockCollection[*corev1.Node](mock))
+	pods := NewLocalityPodsCollection(nodes, krttest.GetMockCollection[*corev1.Pod](mock), krtutil.KrtOptions{})
+	pods.WaitUntilSynced(context.Background().Done())
+	endpointSlices := krttest.GetMockCollection[*discoveryv1.EndpointSlice](mock)
+	builder := transformK8sEndpoints(EndpointsInputs{
+		Backends:       krttest.GetMockCollection[ir.BackendObjectIR](mock),
+		EndpointSlices: endpointSlices,
+		EndpointSlicesByService: krtpkg.UnnamedIndex(endpointSlices, func(es *discoveryv1.EndpointSlice) []types.NamespacedName {
+			return []types.NamespacedName{{Namespace: es.Namespace, Name: es.Labels[discoveryv1.LabelServiceName]}}
+		}),
+		Pods: pods,
+	})
+
+	eps := builder(krt.TestingDummyContext{}, upstream)
+
+	hintsByAddress := map[string][]string{}
+	for _, lbEps := range eps.LbEps {
+		for _, ep := range lbEps {
+			hintsByAddress[ep.GetEndpoint().GetAddress().GetSocketAddress().GetAddress()] = ep.EndpointMd.ForZones
+		}
+	}
+	g.Expect(hintsByAddress).To(Equal(map[string][]string{
+		"10.0.0.1": {"zone-a"},
+		"10.0.0.2": {"zone-b", "zone-c"},
+		"10.0.0.3": {"zone-a"},
+		"10.0.0.4": nil,
+	}))
+
+	// the hints are part of the endpoints equality
+	unhinted := ir.NewEndpointsForBackend(upstream)
+	for loc, lbEps := range eps.LbEps {
+		for _, ep := range lbEps {
+			unhinted.Add(loc, ir.EndpointWithMd{LbEndpoint: ep.LbEndpoint})
+		}
+	}
+	g.Expect(eps.Equals(*unhinted)).To(BeFalse())
+}
@@ -2,6 +2,7 @@ package krtcollections
 
 import (
 	"fmt"
+	"regexp"
 
 	"istio.io/istio/pkg/kube/krt"
 	"k8s.io/utils/ptr"
@@ -53,6 +54,11 @@ func (h *RoutesIndex) transformGRPCRulesToHttp(
 		rulePolicies := h.getBuiltInRulePolicies(convertRulesToHTTP(r))
 		policies.Append(rulePolicies)
 
+		weights := make([]*int32, 0, len(r.BackendRefs))
+		for _, ref := range r.BackendRefs {
+			weights = append(weights, ref.Weight)
+		}
+
 		httpRules = append(httpRules, ir.HttpRouteRuleIR{
 			Backends: httpBackends,
 			Matches:  httpMatches,
@@ -60,6 +66,7 @@ func (h *RoutesIndex) transformGRPCRulesToHttp(
 
 			AttachedPolicies: policies,
 			ExtensionRefs:    extensionRefs,
+			Err:              validateBackendWeights(weights),
 		})
 	}
 	return httpRules
@@ -105,10 +112,10 @@ func grpcToHTTPRouteMatch(match gwv1.GRPCRouteMatch) gwv1.HTTPRouteMatch {
 	}
 }
 
-func buildGRPCPathMatch(method *gwv1.GRPCMethodMatch) (string, gwv1.PathMatchType) {
-	var path string
-	var pathType gwv1.PathMatchType
+// grpcPathSegment matches any service or method name in the path of a gRPC request, i.e. /<service>/<method>.
+const grpcPathSegment = "[^/]+"
 
+func buildGRPCPathMatch(method *gwv1.GRPCMethodMatch) (string, gwv1.PathMatchType) {
 	if method == nil {
 		// If no method match, match all paths
 		return "/", gwv1.PathMatchPathPrefix
@@ -116,34 +123,30 @@ func buildGRPCPathMatch(method *gwv1.GRPCMethodMatch) (string, gwv1.PathMatchTyp
 
 	switch ptr.Deref(method.Type, gwv1.GRPCMethodMatchExact) {
 	case gwv1.GRPCMethodMatchRegularExpression:
-		pathType = gwv1.PathMatchRegularExpression
-		switch {
-		case method.Service != nil && method.Method != nil:
-			path = fmt.Sprintf("/%s/%s", string(*method.Service), string(*method.Method))
-		case method.Service != nil:
-			// Match any valid method name within the service
-			path = fmt.Sprintf("/%s/.+", string(*method.Service))
-		case method.Method != nil:
-			// Match any valid service name before the method
-			path = fmt.Sprintf("/.+/%s", string(*method.Method))
+		// The regexes are grouped so that they only match within their own path segment,
+		// e.g. an alternation in the service doesn't extend to the method.
+		service, m := grpcPathSegment, grpcPathSegment
+		if method.Service != nil {
+			service = fmt.Sprintf("(?:%s)", *method.Service)
 		}
+		if method.Method != nil {
+			m = fmt.Sprintf("(?:%s)", *method.Method)
+		}
+		return fmt.Sprintf("/%s/%s", service, m), gwv1.PathMatchRegularExpression
 	default: // gwv1.GRPCMethodMatchExact
 		switch {
 		case method.Service != nil && method.Method != nil:
-			path = fmt.Sprintf("/%s/%s", string(*method.Service), string(*method.Method))
-			pathType = gwv1.PathMatchExact
+			return fmt.Sprintf("/%s/%s", *method.Service, *method.Method), gwv1.PathMatchExact
 		case method.Service != nil:
-			// Exact service match maps to prefix /service
-			path = fmt.Sprintf("/%s", string(*method.Service))
-			pathType = gwv1.PathMatchPathPrefix
+			// Exact service match maps to prefix /service, which only matches whole path segments
+			return fmt.Sprintf("/%s", *method.Service), gwv1.PathMatchPathPrefix
 		case method.Method != nil:
 			// Exact method without service isn't directly mappable, use regex
-			path = fmt.Sprintf("/.+/%s", string(*method.Method))
-			pathType = gwv1.PathMatchRegularExpression
+			return fmt.Sprintf("/%s/%s", grpcPathSegment, regexp.QuoteMeta(*method.Method)), gwv1.PathMatchRegularExpression
+		default:
+			return "/", gwv1.PathMatchPathPrefix
 		}
 	}
-
-	return path, pathType
 }
 
 func convertGRPCHeadersToHTTP(headers []gwv1.GRPCHeaderMatch) []gwv1.HTTPHeaderMatch {
@@ -0,0 +1,123 @@
+package krtcollections
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/translator/routeutils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestBuildGRPCPathMatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     *gwv1.GRPCMethodMatch
+		wantPath   string
+		wantType   gwv1.PathMatchType
+		matches    []string
+		mismatches []string
+	}{
+		{
+			name:     "no method",
+			wantPath: "/",
+			wantType: gwv1.PathMatchPathPrefix,
+		},
+		{
+			name:     "exact service and method",
+			method:   &gwv1.GRPCMethodMatch{Service: new("foo.Bar"), Method: new("Get")},
+			wantPath: "/foo.Bar/Get",
+			wantType: gwv1.PathMatchExact,
+		},
+		{
+			name:     "exact service",
+			method:   &gwv1.GRPCMethodMatch{Service: new("foo.Bar")},
+			wantPath: "/foo.Bar",
+			wantType: gwv1.PathMatchPathPrefix,
+		},
+		{
+			name:       "exact method of any service",
+			method:     &gwv1.GRPCMethodMatch{Method: new("Get")},
+			wantPath:   "/[^/]+/Get",
+			wantType:   gwv1.PathMatchRegularExpression,
+			matches:    []string{"/foo.Bar/Get", "/Baz/Get"},
+			mismatches: []string{"/foo.Bar/GetAll", "/Get", "/foo/Bar/Get"},
+		},
+		{
+			name:       "regex service and method",
+			method:     &gwv1.GRPCMethodMatch{Type: ptr.To(gwv1.GRPCMethodMatchRegularExpression), Service: new("foo\\.(Bar|Baz)"), Method: new("Get|List")},
+			wantPath:   "/(?:foo\\.(Bar|Baz))/(?:Get|List)",
+			wantType:   gwv1.PathMatchRegularExpression,
+			matches:    []string{"/foo.Bar/Get", "/foo.Baz/List"},
+			mismatches: []string{"/foo.Qux/Get", "/foo.Bar/Delete", "/fooXBar/Get"},
+		},
+		{
+			name:       "regex service",
+			method:     &gwv1.GRPCMethodMatch{Type: ptr.To(gwv1.GRPCMethodMatchRegularExpression), Service: new("a|foo\\.Bar")},
+			wantPath:   "/(?:a|foo\\.Bar)/[^/]+",
+			wantType:   gwv1.PathMatchRegularExpression,
+			matches:    []string{"/a/Get", "/foo.Bar/List"},
+			mismatches: []string{"/a", "/b/Get", "/foo.Bar/Get/Extra"},
+		},
+		{
+			name:       "regex method",
+			method:     &gwv1.GRPCMethodMatch{Type: ptr.To(gwv1.GRPCMethodMatchRegularExpression), Method: new("Get.*")},
+			wantPath:   "/[^/]+/(?:Get.*)",
+			wantType:   gwv1.PathMatchRegularExpression,
+			matches:    []string{"/foo.Bar/Get", "/foo.Bar/GetAll"},
+			mismatches: []string{"/foo.Bar/List", "/Get"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, pathType := buildGRPCPathMatch(tt.method)
+			assert.Equal(t, tt.wantPath, path)
+			assert.Equal(t, tt.wantType, pathType)
+
+			if pathType != gwv1.PathMatchRegularExpression {
+				return
+			}
+			// Envoy matches the regex against the whole path
+			re := regexp.MustCompile("^(?:" + path + ")$")
+			for _, p := range tt.matches {
+				assert.True(t, re.MatchString(p), "expected %s to match %s", path, p)
+			}
+			for _, p := range tt.mismatches {
+				assert.False(t, re.MatchString(p), "expected %s not to match %s", path, p)
+			}
+		})
+	}
+}
+
+func TestGRPCRouteMatchPrecedence(t *testing.T) {
+	headerMatch := []gwv1.GRPCHeaderMatch{{Name: "x-tenant", Value: "a"}}
+	matches := []gwv1.GRPCRouteMatch{
+		{},
+		{Method: &gwv1.GRPCMethodMatch{Service: new("foo.Bar")}},
+		{Method: &gwv1.GRPCMethodMatch{Service: new("foo.Bar"), Method: new("Get")}},
+		{Method: &gwv1.GRPCMethodMatch{Service: new("foo.Bar"), Method: new("Get")}, Headers: headerMatch},
+	}
+
+	route := &gwv1.GRPCRoute{ObjectMeta: metav1.ObjectMeta{Name: "grpc", Namespace: "default"}}
+	var rules []ir.HttpRouteRuleMatchIR
+	for _, m := range matches {
+		rules = append(rules, ir.HttpRouteRuleMatchIR{Match: grpcToHTTPRouteMatch(m)})
+	}
+	sortable := routeutils.ToSortable(route, rules)
+	sort.Stable(sortable)
+
+	got := make([]int, 0, len(sortable))
+	for _, r := range sortable {
+		got = append(got, r.Idx)
+	}
+	// method matches with headers first, then the method, the service and the catch-all
+	require.Equal(t, []int{3, 2, 1, 0}, got)
+	assert.Len(t, sortable[0].Route.Match.Headers, 1)
+}
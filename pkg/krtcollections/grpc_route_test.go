@@ -200,7 +200,7 @@ func TestTransformGRPCRoute(t *testing.T) {
 				match := rule.Matches[0]
 				if match.Path == nil ||
 					*match.Path.Type != gwv1.PathMatchRegularExpression ||
-					*match.Path.Value != "/TestService/.+" {
+					*match.Path.Value != "/(?:TestService)/[^/]+" {
 					return false
 				}
 
@@ -18,13 +18,16 @@ import (
 )
 
 const (
-	resourcesSubsystem   = "resources"
-	snapshotSubsystem    = "xds_snapshot"
-	snapshotResourceType = "XDSSnapshot"
-	gatewayLabel         = "gateway"
-	parentLabel          = "parent"
-	namespaceLabel       = "namespace"
-	resourceLabel        = "resource"
+	resourcesSubsystem      = "resources"
+	referenceGrantSubsystem = "reference_grant"
+	snapshotSubsystem       = "xds_snapshot"
+	snapshotResourceType    = "XDSSnapshot"
+	gatewayLabel            = "gateway"
+	parentLabel             = "parent"
+	namespaceLabel          = "namespace"
+	resourceLabel           = "resource"
+	fromNamespaceLabel      = "from_namespace"
+	toNamespaceLabel        = "to_namespace"
 )
 
 var logger = logging.New("translator.metrics")
@@ -89,6 +92,13 @@ var (
 		Name:      "updates_dropped_total",
 		Help:      "Total number of resources metrics updates dropped. If this metric is ever greater than 0, all resources subsystem metrics should be considered invalid until process restart",
 	}, nil)
+
+	referenceGrantDeniedReferencesTotal = metrics.NewCounter(metrics.CounterOpts{
+		Subsystem: referenceGrantSubsystem,
+		Name:      "denied_references_total",
+		Help:      "Total number of cross-namespace references denied for lack of a ReferenceGrant",
+	},
+		[]string{fromNamespaceLabel, toNamespaceLabel})
 )
 
 type resourceMetricLabels struct {
@@ -637,6 +647,15 @@ func GetResourceMetricEventHandler[T any]() func(krt.Event[T]) {
 	}
 }
 
+// IncReferenceGrantDenied counts a reference from the fromNs namespace to the toNs namespace that was denied
+// because no ReferenceGrant in toNs permits it.
+func IncReferenceGrantDenied(fromNs, toNs string) {
+	referenceGrantDeniedReferencesTotal.Inc(
+		metrics.Label{Name: fromNamespaceLabel, Value: fromNs},
+		metrics.Label{Name: toNamespaceLabel, Value: toNs},
+	)
+}
+
 // ResetMetrics resets the metrics from this package.
 // This is provided for testing purposes only.
 func ResetMetrics() {
@@ -647,6 +666,7 @@ func ResetMetrics() {
 	resourcesStatusSyncsCompletedTotal.Reset()
 	resourcesStatusSyncDuration.Reset()
 	resourcesUpdatesDroppedTotal.Reset()
+	referenceGrantDeniedReferencesTotal.Reset()
 
 	startTimes.Lock()
 	defer startTimes.Unlock()
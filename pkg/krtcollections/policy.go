@@ -25,6 +25,7 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/translator/backendref"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/translator/sslutils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/translator/utils"
+	kgwutils "github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils/delegation"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections/metrics"
@@ -52,6 +53,30 @@ func (n *NotFoundError) Error() string {
 	return fmt.Sprintf("%s %s/%s not found", n.NotFoundObj.Kind, n.NotFoundObj.Namespace, n.NotFoundObj.Name)
 }
 
+// MissingReferenceGrantError is returned when a cross-namespace reference is not permitted by any ReferenceGrant.
+// It matches ErrMissingReferenceGrant with errors.Is.
+type MissingReferenceGrantError struct {
+	FromGK schema.GroupKind
+	FromNs string
+	To     ir.ObjectSource
+}
+
+// Error describes the ReferenceGrant that would permit the reference.
+func (e *MissingReferenceGrantError) Error() string {
+	return fmt.Sprintf(
+		"%v: %s %s/%s is not permitted by any ReferenceGrant; create a ReferenceGrant in namespace %s with from {group: %q, kind: %s, namespace: %s} and to {group: %q, kind: %s, name: %s}",
+		ErrMissingReferenceGrant,
+		e.To.Kind, e.To.Namespace, e.To.Name,
+		e.To.Namespace,
+		e.FromGK.Group, e.FromGK.Kind, e.FromNs,
+		e.To.Group, e.To.Kind, e.To.Name,
+	)
+}
+
+func (e *MissingReferenceGrantError) Is(target error) bool {
+	return target == ErrMissingReferenceGrant
+}
+
 type BackendPortNotAllowedError struct {
 	BackendName string
 }
@@ -145,13 +170,24 @@ func (i *BackendIndex) BackendsWithPolicyRequiringStatus() []krt.Collection[*ir.
 	return i.backendsRequiringPolicyStatus
 }
 
+// AttachedPolicies returns the policies attached to the given backend, which are not included
+// in the backends resolved for routes.
+func (i *BackendIndex) AttachedPolicies(kctx krt.HandlerContext, backend *ir.BackendObjectIR) ir.AttachedPolicies {
+	for _, col := range i.availableBackendsWithPolicy {
+		if b := krt.FetchOne(kctx, col, krt.FilterKey(backend.ResourceName())); b != nil {
+			return (*b).AttachedPolicies
+		}
+	}
+	return ir.AttachedPolicies{}
+}
+
 // AddBackends builds the backends stored in this BackendIndex by deriving a new BackendObjIR collection
 // based on the provided `col` with all Backend-attached policies included on the new BackendObjIR.
 // The BackendIndex will then store this collection of backendWithPolicies in its internal map, keyed by the
 // provied gk. I.e. for the provided gk, it will carry the collection of backends derived from it, with all
 // policies attached.
 func (i *BackendIndex) AddBackends(gk schema.GroupKind, col krt.Collection[ir.BackendObjectIR], aliasKinds ...schema.GroupKind) {
-	backendsWithPoliciesCol := krt.NewCollection(col, func(kctx krt.HandlerContext, backendObj ir.BackendObjectIR) **ir.BackendObjectIR {
+	backendsWithPoliciesCol := krtutil.NewCollection(col, func(kctx krt.HandlerContext, backendObj ir.BackendObjectIR) **ir.BackendObjectIR {
 		// Look up service-wide policies (no sectionName)
 		policies := i.policies.getTargetingPoliciesForBackends(kctx, backendObj.ObjectSource, "", backendObj.GetObjectLabels(), false)
 		// Also look up port specific policies if the backend has a port name (e.g., BackendTLSPolicy with sectionName).
@@ -192,7 +228,7 @@ func (i *BackendIndex) AddBackends(gk schema.GroupKind, col krt.Collection[ir.Ba
 		backendObj.RequiresPolicyStatus = anyHasRef
 		backendObj.AttachedPolicies = ToAttachedPolicies(policies)
 		return new(&backendObj)
-	}, i.krtopts.ToOptions("")...)
+	}, i.krtopts, fmt.Sprintf("%s-backends-with-policies", gk.String()))
 	backendsRequiringPolicyStatus := krt.NewCollection(backendsWithPoliciesCol, func(ctx krt.HandlerContext, i *ir.BackendObjectIR) **ir.BackendObjectIR {
 		if i.RequiresPolicyStatus {
 			return &i
@@ -314,8 +350,8 @@ func (i *BackendIndex) GetBackendFromRef(kctx krt.HandlerContext, src ir.ObjectS
 	fromNs := src.Namespace
 	fromGK := schema.GroupKind{Group: src.Group, Kind: src.Kind}
 	to := toFromBackendRef(fromNs, ref)
-	if !i.refgrants.ReferenceAllowed(kctx, fromGK, fromNs, to) {
-		return nil, ErrMissingReferenceGrant
+	if err := i.refgrants.CheckReference(kctx, fromGK, fromNs, to); err != nil {
+		return nil, err
 	}
 
 	return i.getBackendFromRef(kctx, src.Namespace, ref)
@@ -331,6 +367,8 @@ func (i *BackendIndex) GetBackendFromRefWithoutRefGrantValidation(kctx krt.Handl
 type GatewayIndex struct {
 	Gateways            krt.Collection[ir.Gateway]
 	GatewaysForDeployer krt.Collection[ir.GatewayForDeployer]
+	// KubeGateways holds all Gateway objects, regardless of the controller of their GatewayClass
+	KubeGateways krt.Collection[*gwv1.Gateway]
 }
 
 type GatewayIndexConfig struct {
@@ -351,7 +389,7 @@ type GatewayIndexConfig struct {
 func NewGatewayIndex(config GatewayIndexConfig, opts ...GatewayIndexConfigOption) *GatewayIndex {
 	processGatewayIndexConfig(&config, opts...)
 
-	h := &GatewayIndex{}
+	h := &GatewayIndex{KubeGateways: config.Gateways}
 	h.GatewaysForDeployer = krt.NewCollection(config.Gateways, config.gatewaysForDeployerTransformationFunc(&config))
 	if config.PolicyIndex == nil {
 		return h
@@ -371,6 +409,10 @@ func GatewaysForDeployerTransformationFunc(config *GatewayIndexConfig) func(kctx
 		}
 		ports := sets.New[int32]()
 		for _, l := range gw.Spec.Listeners {
+			// UDP listeners are rejected during translation and must not open ports on the proxy
+			if l.Protocol == gwv1.UDPProtocolType {
+				continue
+			}
 			ports.Insert(l.Port)
 		}
 
@@ -381,13 +423,26 @@ func GatewaysForDeployerTransformationFunc(config *GatewayIndexConfig) func(kctx
 			Namespace: gw.GetNamespace(),
 		}))
 
+		// Only expose ports for listener sets the gateway accepts; denied ones are
+		// rejected during translation and must not open ports on the proxy.
+		allowedNs, err := AllowedListenerSet(gw.Spec.AllowedListeners, gw.GetNamespace(), config.Namespaces)
+		if err != nil || gw.Spec.AllowedListeners == nil {
+			listenerSets = nil
+		}
+
 		for _, ls := range listenerSets {
+			if !allowedNs(kctx, ls.GetNamespace()) {
+				continue
+			}
 			for _, l := range ls.Spec.Listeners {
 				port, portErr := kubeutils.DetectListenerPortNumber(l.Protocol, l.Port)
 				// Don't need to log an error for the deployer as it will be reflected in the listener status during reconciliation
 				if portErr != nil {
 					continue
 				}
+				if l.Protocol == gwv1.UDPProtocolType {
+					continue
+				}
 				ports.Insert(port)
 			}
 		}
@@ -438,6 +493,12 @@ func GatewaysForEnvoyTransformationFunc(config *GatewayIndexConfig) func(kctx kr
 			}
 		}
 
+		draining, err := kgwutils.IsDraining(gw)
+		if err != nil {
+			logger.Error("failed to parse drain annotation", "gateway", fmt.Sprintf("%s/%s", gw.Namespace, gw.Name), "error", err)
+		}
+		gwIR.Draining = draining
+
 		// TODO: http polic
 		//		panic("TODO: implement http policies not just listener")
 		gwIR.AttachedListenerPolicies = ToAttachedPolicies(
@@ -676,12 +737,12 @@ func NewPolicyIndex(
 		if plugin.Policies != nil {
 			policies := plugin.Policies
 			forBackends := plugin.ProcessBackend != nil
-			policiesByTargetRef := krt.NewCollection(policies, func(kctx krt.HandlerContext, a ir.PolicyWrapper) *ir.PolicyWrapper {
+			policiesByTargetRef := krtutil.NewCollection(policies, func(kctx krt.HandlerContext, a ir.PolicyWrapper) *ir.PolicyWrapper {
 				if len(a.TargetRefs) == 0 {
 					return nil
 				}
 				return &a
-			}, krtopts.ToOptions(fmt.Sprintf("%s-policiesByTargetRef", gk.String()))...)
+			}, krtopts, fmt.Sprintf("%s-policiesByTargetRef", gk.String()))
 
 			targetRefIndex := krtpkg.UnnamedIndex(policiesByTargetRef, func(p ir.PolicyWrapper) []TargetRefIndexKey {
 				// Every policy is indexed by PolicyRef and PolicyRef without Name (by Group+Kind+Namespace)
@@ -980,6 +1041,21 @@ func (r *RefGrantIndex) ReferenceAllowed(kctx krt.HandlerContext, fromgk schema.
 	return len(krt.Fetch(kctx, r.refgrants, krt.FilterIndex(r.refGrantIndex, key))) != 0
 }
 
+// CheckReference returns a *MissingReferenceGrantError, and counts the denied reference, if the reference is not
+// permitted by any ReferenceGrant. The ReferenceGrants are fetched through kctx, so creating a ReferenceGrant that
+// permits the reference retriggers the collection that checked it.
+func (r *RefGrantIndex) CheckReference(kctx krt.HandlerContext, fromgk schema.GroupKind, fromns string, to ir.ObjectSource) error {
+	if r.ReferenceAllowed(kctx, fromgk, fromns, to) {
+		return nil
+	}
+	metrics.IncReferenceGrantDenied(fromns, to.Namespace)
+	return &MissingReferenceGrantError{
+		FromGK: schema.GroupKind{Group: emptyIfCore(fromgk.Group), Kind: fromgk.Kind},
+		FromNs: fromns,
+		To:     ir.ObjectSource{Group: emptyIfCore(to.Group), Kind: to.Kind, Namespace: to.Namespace, Name: to.Name},
+	}
+}
+
 type RouteWrapper struct {
 	Route ir.Route
 }
@@ -1095,23 +1171,23 @@ func NewRoutesIndex(
 		return h.transformHttpRoute(kctx, i, controllerName)
 	}, krtopts.ToOptions("http-routes-with-policy")...)
 
-	httpRouteCollection := krt.NewCollection(h.httpRoutes, func(kctx krt.HandlerContext, i ir.HttpRouteIR) *RouteWrapper {
+	httpRouteCollection := krtutil.NewCollection(h.httpRoutes, func(kctx krt.HandlerContext, i ir.HttpRouteIR) *RouteWrapper {
 		return &RouteWrapper{Route: &i}
-	}, krtopts.ToOptions("routes-http-routes-with-policy")...)
+	}, krtopts, "routes-http-routes-with-policy")
 
-	tcpRoutesCollection := krt.NewCollection(tcproutes, func(kctx krt.HandlerContext, i *gwv1a2.TCPRoute) *RouteWrapper {
+	tcpRoutesCollection := krtutil.NewCollection(tcproutes, func(kctx krt.HandlerContext, i *gwv1a2.TCPRoute) *RouteWrapper {
 		t := h.transformTcpRoute(kctx, i)
 		return &RouteWrapper{Route: t}
-	}, krtopts.ToOptions("routes-tcp-routes-with-policy")...)
+	}, krtopts, "routes-tcp-routes-with-policy")
 
-	tlsRoutesCollection := krt.NewCollection(tlsroutes, func(kctx krt.HandlerContext, i *gwv1a2.TLSRoute) *RouteWrapper {
+	tlsRoutesCollection := krtutil.NewCollection(tlsroutes, func(kctx krt.HandlerContext, i *gwv1a2.TLSRoute) *RouteWrapper {
 		t := h.transformTlsRoute(kctx, i)
 		return &RouteWrapper{Route: t}
-	}, krtopts.ToOptions("routes-tls-routes-with-policy")...)
-	grpcRoutesCollection := krt.NewCollection(grpcroutes, func(kctx krt.HandlerContext, i *gwv1.GRPCRoute) *RouteWrapper {
+	}, krtopts, "routes-tls-routes-with-policy")
+	grpcRoutesCollection := krtutil.NewCollection(grpcroutes, func(kctx krt.HandlerContext, i *gwv1.GRPCRoute) *RouteWrapper {
 		t := h.transformGRPCRoute(kctx, i)
 		return &RouteWrapper{Route: t}
-	}, krtopts.ToOptions("routes-grpc-routes-with-policy")...)
+	}, krtopts, "routes-grpc-routes-with-policy")
 	h.routes = krt.JoinCollection([]krt.Collection[RouteWrapper]{httpRouteCollection, grpcRoutesCollection, tcpRoutesCollection, tlsRoutesCollection}, krtopts.ToOptions("all-routes-with-policy")...)
 
 	httpBySelector := krtpkg.UnnamedIndex(h.httpRoutes, func(i ir.HttpRouteIR) []HTTPRouteSelector {
@@ -1325,6 +1401,12 @@ func (h *RoutesIndex) transformRules(
 		rulePolicies := h.getBuiltInRulePolicies(r, opts...)
 		policies.Append(rulePolicies)
 
+		weights := make([]*int32, 0, len(r.BackendRefs))
+		for _, ref := range r.BackendRefs {
+			weights = append(weights, ref.Weight)
+		}
+		err = errors.Join(err, validateBackendWeights(weights))
+
 		ruleOut := ir.HttpRouteRuleIR{
 			ExtensionRefs:    extensionRefs,
 			AttachedPolicies: policies,
@@ -1537,7 +1619,22 @@ func weight(w *int32) uint32 {
 	if w == nil {
 		return 1
 	}
-	return uint32(*w) //nolint:gosec // G115: weight values are validated to be non-negative in Gateway API
+	if *w < 0 {
+		// negative weights are rejected by validateBackendWeights; don't let them wrap around
+		return 0
+	}
+	return uint32(*w)
+}
+
+// validateBackendWeights returns an error if any of the given backendRef weights is negative.
+func validateBackendWeights(weights []*int32) error {
+	var errs []error
+	for i, w := range weights {
+		if w != nil && *w < 0 {
+			errs = append(errs, fmt.Errorf("backendRef %d has invalid weight %d: weight must be non-negative", i, *w))
+		}
+	}
+	return errors.Join(errs...)
 }
 
 func ToAttachedPolicies(policies []ir.PolicyAtt, opts ...ir.PolicyAttachmentOpts) ir.AttachedPolicies {
@@ -23,6 +23,9 @@ import (
 	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections/metrics"
+	metricsutil "github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics/metricstest"
 	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
@@ -209,6 +212,86 @@ func TestFailWithWrongNs(t *testing.T) {
 	}
 }
 
+func TestMissingReferenceGrantError(t *testing.T) {
+	ir := translateRoute(t, []any{svc("default2"), httpRouteWithSvcBackendRef("default2")})
+	require.NotNil(t, ir)
+	backends := getBackends(ir)
+	require.NotEmpty(t, backends)
+
+	err := backends[0].Err
+	require.ErrorIs(t, err, ErrMissingReferenceGrant)
+	var missingErr *MissingReferenceGrantError
+	require.ErrorAs(t, err, &missingErr)
+	assert.Equal(t, "default", missingErr.FromNs)
+	assert.Equal(t, schema.GroupKind{Group: gwv1.GroupName, Kind: "HTTPRoute"}, missingErr.FromGK)
+	assert.Equal(t,
+		`missing reference grant: Service default2/foo is not permitted by any ReferenceGrant; `+
+			`create a ReferenceGrant in namespace default2 with `+
+			`from {group: "gateway.networking.k8s.io", kind: HTTPRoute, namespace: default} and `+
+			`to {group: "", kind: Service, name: foo}`,
+		err.Error())
+}
+
+func TestCheckReferenceRetriggersOnReferenceGrantCreation(t *testing.T) {
+	krtOpts := krtutil.NewKrtOptions(t.Context().Done(), nil)
+	refgrantCol := krt.NewStaticCollection[*gwv1b1.ReferenceGrant](nil, nil, krtOpts.ToOptions("ReferenceGrants")...)
+	refgrants := NewRefGrantIndex(refgrantCol)
+
+	fromGK := schema.GroupKind{Group: gwv1.GroupName, Kind: "HTTPRoute"}
+	to := ir.ObjectSource{Group: "core", Kind: "Service", Namespace: "default2", Name: "foo"}
+	checked := krt.NewSingleton(func(kctx krt.HandlerContext) *string {
+		result := "allowed"
+		if err := refgrants.CheckReference(kctx, fromGK, "default", to); err != nil {
+			result = err.Error()
+		}
+		return &result
+	}, krtOpts.ToOptions("CheckedReference")...)
+	checked.AsCollection().WaitUntilSynced(t.Context().Done())
+
+	require.Contains(t, *checked.Get(), "missing reference grant")
+
+	refgrantCol.UpdateObject(refGrant())
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, "allowed", *checked.Get())
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestCheckReferenceDeniedMetric(t *testing.T) {
+	metrics.ResetMetrics()
+	t.Cleanup(metrics.ResetMetrics)
+
+	refgrants := NewRefGrantIndex(krt.NewStaticCollection[*gwv1b1.ReferenceGrant](nil, []*gwv1b1.ReferenceGrant{refGrant()}))
+	fromGK := schema.GroupKind{Group: gwv1.GroupName, Kind: "HTTPRoute"}
+	svcIn := func(ns string) ir.ObjectSource {
+		return ir.ObjectSource{Group: "core", Kind: "Service", Namespace: ns, Name: "foo"}
+	}
+
+	// same namespace and granted references are not counted
+	require.NoError(t, refgrants.CheckReference(krt.TestingDummyContext{}, fromGK, "default", svcIn("default")))
+	require.NoError(t, refgrants.CheckReference(krt.TestingDummyContext{}, fromGK, "default", svcIn("default2")))
+	require.Error(t, refgrants.CheckReference(krt.TestingDummyContext{}, fromGK, "default", svcIn("default3")))
+	require.Error(t, refgrants.CheckReference(krt.TestingDummyContext{}, fromGK, "default", svcIn("default3")))
+	require.Error(t, refgrants.CheckReference(krt.TestingDummyContext{}, fromGK, "other", svcIn("default2")))
+
+	gathered := metricstest.MustGatherMetrics(t)
+	gathered.AssertMetricsInclude("kgateway_reference_grant_denied_references_total", []metricstest.ExpectMetric{
+		&metricstest.ExpectedMetric{
+			Labels: []metricsutil.Label{
+				{Name: "from_namespace", Value: "default"},
+				{Name: "to_namespace", Value: "default3"},
+			},
+			Value: 2,
+		},
+		&metricstest.ExpectedMetric{
+			Labels: []metricsutil.Label{
+				{Name: "from_namespace", Value: "other"},
+				{Name: "to_namespace", Value: "default2"},
+			},
+			Value: 1,
+		},
+	})
+}
+
 func TestBackendPortNotAllowed(t *testing.T) {
 	cases := []struct {
 		name        string
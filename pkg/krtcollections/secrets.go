@@ -62,8 +62,8 @@ func (s *SecretIndex) GetSecret(kctx krt.HandlerContext, from From, secretRef gw
 		return nil, fmt.Errorf("internal error looking up secret %s", to.NamespacedName())
 	}
 
-	if !s.refgrants.ReferenceAllowed(kctx, from.GroupKind, from.Namespace, to) {
-		return nil, fmt.Errorf("cannot reference secret %s : %w", to.NamespacedName(), ErrMissingReferenceGrant)
+	if err := s.refgrants.CheckReference(kctx, from.GroupKind, from.Namespace, to); err != nil {
+		return nil, fmt.Errorf("cannot reference secret %s : %w", to.NamespacedName(), err)
 	}
 	secret := krt.FetchOne(kctx, col, krt.FilterKey(to.ResourceName()))
 	if secret == nil {
@@ -129,7 +129,7 @@ func (s *SecretIndex) GetSecretsBySelector(
 
 	// Validate ReferenceGrant for cross-namespace secrets and collect allowed ones
 	var allowedSecrets []ir.Secret
-	var hasMissingGrants bool
+	var missingGrantErr error
 	for _, secret := range labelMatchedSecrets {
 		// Only check ReferenceGrant if this is a cross-namespace reference
 		if from.Namespace != secret.Namespace {
@@ -139,8 +139,8 @@ func (s *SecretIndex) GetSecretsBySelector(
 				Namespace: secret.Namespace,
 				Name:      secret.Name,
 			}
-			if !s.refgrants.ReferenceAllowed(kctx, from.GroupKind, from.Namespace, to) {
-				hasMissingGrants = true
+			if err := s.refgrants.CheckReference(kctx, from.GroupKind, from.Namespace, to); err != nil {
+				missingGrantErr = err
 				continue
 			}
 		}
@@ -150,8 +150,8 @@ func (s *SecretIndex) GetSecretsBySelector(
 	// Only return an error if no allowed secrets were found and there were missing grants.
 	// We don't want to list all the secrets that were skipped. We only want to hint
 	// the user that it might be a configuration issue.
-	if len(allowedSecrets) == 0 && hasMissingGrants {
-		return allowedSecrets, ErrMissingReferenceGrant
+	if len(allowedSecrets) == 0 && missingGrantErr != nil {
+		return allowedSecrets, missingGrantErr
 	}
 
 	return allowedSecrets, nil
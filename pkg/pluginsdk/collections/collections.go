@@ -16,6 +16,7 @@ import (
 
 	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
 	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/health"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
@@ -50,22 +51,37 @@ type CommonCollections struct {
 	ControllerName string
 
 	options *option
+	// customCollections are the collections of the CRDs requested with For.
+	customCollections customCollections
 }
 
 func (c *CommonCollections) HasSynced() bool {
+	for _, check := range c.SyncChecks() {
+		if !check.Synced() {
+			return false
+		}
+	}
+	return true
+}
+
+// SyncChecks returns the sync state of each of the common collections.
+func (c *CommonCollections) SyncChecks() []health.Check {
 	// we check nil as well because some of the inner
 	// collections aren't initialized until we call InitPlugins
-	return c.Secrets != nil && c.Secrets.HasSynced() &&
-		c.ConfigMaps != nil && c.ConfigMaps.HasSynced() &&
-		c.BackendIndex != nil && c.BackendIndex.HasSynced() &&
-		c.Routes != nil && c.Routes.HasSynced() &&
-		c.WrappedPods != nil && c.WrappedPods.HasSynced() &&
-		c.LocalityPods != nil && c.LocalityPods.HasSynced() &&
-		c.RefGrants != nil && c.RefGrants.HasSynced() &&
-		c.GatewayExtensions != nil && c.GatewayExtensions.HasSynced() &&
-		c.Services != nil && c.Services.HasSynced() &&
-		c.ServiceEntries != nil && c.ServiceEntries.HasSynced() &&
-		c.GatewayIndex != nil && c.GatewayIndex.Gateways.HasSynced()
+	return []health.Check{
+		{Name: "Secrets", Synced: func() bool { return c.Secrets != nil && c.Secrets.HasSynced() }},
+		{Name: "ConfigMaps", Synced: func() bool { return c.ConfigMaps != nil && c.ConfigMaps.HasSynced() }},
+		{Name: "Backends", Synced: func() bool { return c.BackendIndex != nil && c.BackendIndex.HasSynced() }},
+		{Name: "Routes", Synced: func() bool { return c.Routes != nil && c.Routes.HasSynced() }},
+		{Name: "Pods", Synced: func() bool { return c.WrappedPods != nil && c.WrappedPods.HasSynced() }},
+		{Name: "LocalityPods", Synced: func() bool { return c.LocalityPods != nil && c.LocalityPods.HasSynced() }},
+		{Name: "ReferenceGrants", Synced: func() bool { return c.RefGrants != nil && c.RefGrants.HasSynced() }},
+		{Name: "GatewayExtensions", Synced: func() bool { return c.GatewayExtensions != nil && c.GatewayExtensions.HasSynced() }},
+		{Name: "Services", Synced: func() bool { return c.Services != nil && c.Services.HasSynced() }},
+		{Name: "ServiceEntries", Synced: func() bool { return c.ServiceEntries != nil && c.ServiceEntries.HasSynced() }},
+		{Name: "Gateways", Synced: func() bool { return c.GatewayIndex != nil && c.GatewayIndex.Gateways.HasSynced() }},
+		{Name: "CustomCollections", Synced: c.customCollections.HasSynced},
+	}
 }
 
 // NewCommonCollections initializes the core krt collections.
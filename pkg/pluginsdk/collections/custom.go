@@ -0,0 +1,105 @@
+package collections
+
+import (
+	"log/slog"
+	"reflect"
+	"sync"
+
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/kube/krt"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
+)
+
+// customCollections are the collections of the CRDs requested with For. The objects of a kind
+// are watched by a single informer, shared by the collections of all the types they convert to.
+type customCollections struct {
+	mu        sync.Mutex
+	informers map[schema.GroupVersionKind]krt.Collection[*unstructured.Unstructured]
+	typed     map[customCollectionKey]any
+	synced    []func() bool
+}
+
+type customCollectionKey struct {
+	gvk schema.GroupVersionKind
+	typ reflect.Type
+}
+
+// HasSynced returns whether all the collections requested with For are synced.
+func (c *customCollections) HasSynced() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, synced := range c.synced {
+		if !synced() {
+			return false
+		}
+	}
+	return true
+}
+
+// For returns the collection of the objects of the CRD of the kind, converted to *T. It lets
+// plugins watch CRDs kgateway has no types for, without building their own informers.
+//
+// The collections are shared: calling For again with the same kind and type returns the same
+// collection, and the objects of a kind are watched by a single dynamic informer whatever the
+// types they convert to. The collections gate the sync of the common collections, and their
+// collection metrics are recorded when enabled.
+// When the CRD is not installed yet, the collection is empty and synced, and the objects are
+// added once the CRD is installed.
+// The resource of the kind is its lowercase plural, e.g. `wafpolicies` for `WafPolicy`.
+// Objects that fail to convert to T are dropped.
+func For[T any, PT interface {
+	*T
+	controllers.Object
+}](c *CommonCollections, gvk schema.GroupVersionKind) krt.Collection[PT] {
+	cc := &c.customCollections
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	key := customCollectionKey{gvk: gvk, typ: reflect.TypeFor[T]()}
+	if col, ok := cc.typed[key]; ok {
+		return col.(krt.Collection[PT])
+	}
+
+	raw, ok := cc.informers[gvk]
+	if !ok {
+		gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+		inf := NewDelayedDynamicInformer(c.Client, gvr, kclient.Filter{ObjectFilter: c.Client.ObjectFilter()})
+		if delayed, isDelayed := inf.(*delayedUnstructuredInformer); isDelayed {
+			// the informer of a CRD installed later is not started with the other informers
+			// of the client, start polling for the CRD right away
+			delayed.Start(c.KrtOpts.Stop)
+		}
+		raw = krt.WrapClient(inf, c.KrtOpts.ToOptions("Custom/"+gvk.GroupKind().String())...)
+		if cc.informers == nil {
+			cc.informers = make(map[schema.GroupVersionKind]krt.Collection[*unstructured.Unstructured])
+		}
+		cc.informers[gvk] = raw
+	}
+
+	col := krtutil.NewCollection(raw, func(kctx krt.HandlerContext, i *unstructured.Unstructured) *PT {
+		out := PT(new(T))
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(i.Object, out); err != nil {
+			slog.Warn("ignoring custom resource with invalid payload",
+				"kind", gvk.Kind,
+				"name", i.GetName(),
+				"namespace", i.GetNamespace(),
+				"error", err,
+			)
+			return nil
+		}
+		return &out
+	}, c.KrtOpts, "Custom/"+gvk.GroupKind().String()+"/"+key.typ.Name())
+
+	if cc.typed == nil {
+		cc.typed = make(map[customCollectionKey]any)
+	}
+	cc.typed[key] = col
+	cc.synced = append(cc.synced, col.HasSynced)
+	return col
+}
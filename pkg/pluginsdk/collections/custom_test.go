@@ -0,0 +1,144 @@
+package collections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/test"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient"
+	apiclientfake "github.com/kgateway-dev/kgateway/v2/pkg/apiclient/fake"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
+)
+
+var (
+	widgetGVK = schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	widgetGVR = schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+)
+
+func init() {
+	kube.FakeIstioScheme.AddKnownTypeWithName(widgetGVK.GroupVersion().WithKind("WidgetList"), &unstructured.UnstructuredList{})
+}
+
+type widget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		Size int `json:"size"`
+	} `json:"spec"`
+}
+
+func (w *widget) DeepCopyObject() runtime.Object {
+	out := *w
+	w.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	return &out
+}
+
+// widgetName converts the widgets to another type, keeping only their metadata.
+type widgetName struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+}
+
+func (w *widgetName) DeepCopyObject() runtime.Object {
+	out := *w
+	w.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	return &out
+}
+
+func createWidget(t *testing.T, client apiclient.Client, name string, size int64) {
+	t.Helper()
+	_, err := client.Dynamic().Resource(widgetGVR).Namespace("default").Create(context.Background(), &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": widgetGVK.GroupVersion().String(),
+			"kind":       widgetGVK.Kind,
+			"metadata": map[string]any{
+				"name":      name,
+				"namespace": "default",
+			},
+			"spec": map[string]any{
+				"size": size,
+			},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+}
+
+func customCollectionsSynced(c *CommonCollections) bool {
+	for _, check := range c.SyncChecks() {
+		if check.Name == "CustomCollections" {
+			return check.Synced()
+		}
+	}
+	return false
+}
+
+func TestForSharesInformer(t *testing.T) {
+	stop := test.NewStop(t)
+	client := apiclientfake.NewClientWithExtraGVRs(t, []schema.GroupVersionResource{widgetGVR})
+	createWidget(t, client, "small", 1)
+	c := &CommonCollections{Client: client, KrtOpts: krtutil.NewKrtOptions(stop, nil)}
+
+	widgets := For[widget](c, widgetGVK)
+	assert.Equal(t, widgets, For[widget](c, widgetGVK), "the same kind and type should return the same collection")
+	names := For[widgetName](c, widgetGVK)
+	assert.Len(t, c.customCollections.informers, 1, "the collections of a kind should share its informer")
+	assert.Len(t, c.customCollections.typed, 2)
+
+	client.RunAndWait(stop)
+	widgets.WaitUntilSynced(stop)
+	names.WaitUntilSynced(stop)
+
+	w := widgets.GetKey("default/small")
+	require.NotNil(t, w)
+	assert.Equal(t, 1, (*w).Spec.Size)
+	n := names.GetKey("default/small")
+	require.NotNil(t, n)
+	assert.Equal(t, "small", (*n).GetName())
+}
+
+func TestForGatesSync(t *testing.T) {
+	stop := test.NewStop(t)
+	client := apiclientfake.NewClientWithExtraGVRs(t, []schema.GroupVersionResource{widgetGVR})
+	createWidget(t, client, "small", 1)
+	c := &CommonCollections{Client: client, KrtOpts: krtutil.NewKrtOptions(stop, nil)}
+	require.True(t, customCollectionsSynced(c), "no custom collections should not block the sync")
+
+	widgets := For[widget](c, widgetGVK)
+	assert.False(t, customCollectionsSynced(c), "the custom collections should block the sync until their informer syncs")
+
+	client.RunAndWait(stop)
+	assert.Eventually(t, func() bool { return customCollectionsSynced(c) }, 5*time.Second, 10*time.Millisecond)
+	assert.Len(t, widgets.List(), 1)
+}
+
+func TestForActivatesLateCRD(t *testing.T) {
+	stop := test.NewStop(t)
+	client := apiclientfake.NewClient(t)
+	c := &CommonCollections{Client: client, KrtOpts: krtutil.NewKrtOptions(stop, nil)}
+
+	widgets := For[widget](c, widgetGVK)
+	client.RunAndWait(stop)
+	assert.Eventually(t, func() bool { return customCollectionsSynced(c) }, 5*time.Second, 10*time.Millisecond,
+		"a missing CRD should not block the sync")
+	assert.Empty(t, widgets.List())
+
+	makeServedCRD(t, client, widgetGVR, "v1.4.1")
+	createWidget(t, client, "late", 3)
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		w := widgets.GetKey("default/late")
+		if assert.NotNil(c, w) {
+			assert.Equal(c, 3, (*w).Spec.Size)
+		}
+	}, 10*time.Second, 50*time.Millisecond)
+	assert.True(t, customCollectionsSynced(c))
+}
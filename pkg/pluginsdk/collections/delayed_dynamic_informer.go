@@ -107,6 +107,17 @@ func newDelayedTypedInformer[T controllers.ComparableObject](
 	return delayed
 }
 
+// NewDelayedDynamicInformer returns an informer of the resource through the dynamic client, for the CRDs
+// kgateway has no types for, e.g. the CRDs of out-of-tree plugins. When the CRD is not served yet, the
+// informer starts once it is.
+func NewDelayedDynamicInformer(
+	c kube.Client,
+	gvr schema.GroupVersionResource,
+	filter kclient.Filter,
+) kclient.Informer[*unstructured.Unstructured] {
+	return newDelayedDynamicUnstructuredInformer(c, gvr, filter)
+}
+
 func newDelayedDynamicUnstructuredInformer(
 	c kube.Client,
 	gvr schema.GroupVersionResource,
@@ -13,6 +13,7 @@ type Option func(*option)
 type option struct {
 	gatewayForDeployerTransformationFunc krtcollections.GatewaysForDeployerTransformationFunction
 	gatewayForEnvoyTransformationFunc    krtcollections.GatewaysForEnvoyTransformationFunction
+	gatewayFilter                        func(gw *gwv1.Gateway) bool
 }
 
 func WithGatewayForDeployerTransformationFunc(f func(config *krtcollections.GatewayIndexConfig) func(kctx krt.HandlerContext, gw *gwv1.Gateway) *ir.GatewayForDeployer) Option {
@@ -30,3 +31,11 @@ func WithGatewayForEnvoyTransformationFunc(f func(config *krtcollections.Gateway
 		}
 	}
 }
+
+// WithGatewayFilter restricts the Gateways that are deployed, translated and served by this
+// controller to the ones for which the filter returns true.
+func WithGatewayFilter(f func(gw *gwv1.Gateway) bool) Option {
+	return func(o *option) {
+		o.gatewayFilter = f
+	}
+}
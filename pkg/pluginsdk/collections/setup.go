@@ -43,6 +43,14 @@ func (c *CommonCollections) InitCollections(
 
 	kubeRawGateways := krt.WrapClient(kclient.NewFilteredDelayed[*gwv1.Gateway](c.Client, wellknown.GatewayGVR, filter), c.KrtOpts.ToOptions("KubeGateways")...)
 	metrics.RegisterEvents(kubeRawGateways, kmetrics.GetResourceMetricEventHandler[*gwv1.Gateway]())
+	if gatewayFilter := c.options.gatewayFilter; gatewayFilter != nil {
+		kubeRawGateways = krt.NewCollection(kubeRawGateways, func(kctx krt.HandlerContext, gw *gwv1.Gateway) **gwv1.Gateway {
+			if !gatewayFilter(gw) {
+				return nil
+			}
+			return &gw
+		}, c.KrtOpts.ToOptions("FilteredKubeGateways")...)
+	}
 
 	var kubeRawListenerSets krt.Collection[*gwv1.ListenerSet]
 	promotedListenerSets := krt.WrapClient(
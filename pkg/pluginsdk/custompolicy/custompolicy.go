@@ -0,0 +1,220 @@
+// Package custompolicy registers policy kinds defined by CRDs that kgateway has no types for, e.g. CRDs
+// maintained out of tree. The policies are watched through the dynamic client and attached through their
+// target references. The plugin translates them, and their status is reported by the status syncer, like
+// the status of the built-in policies.
+package custompolicy
+
+import (
+	"context"
+	"fmt"
+
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/kube/krt"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/logging"
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/reporter"
+	pluginsdkutils "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/utils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/reports"
+)
+
+var logger = logging.New("plugin/custompolicy")
+
+// Definition defines a custom policy kind.
+type Definition struct {
+	// GVR is the resource of the CRD of the policy. The CRD must have a status subresource
+	// following the Gateway API PolicyStatus.
+	GVR schema.GroupVersionResource
+	// Kind is the kind of the policy.
+	Kind string
+
+	// TargetRefs resolves the resources the policy attaches to.
+	// Defaults to DefaultTargetRefs.
+	TargetRefs func(obj *unstructured.Unstructured) ([]ir.PolicyRef, error)
+	// Translate converts the policy to its IR. The errors are reported on the status of the policy,
+	// for each of the ancestors of the resources it attaches to.
+	Translate func(krtctx krt.HandlerContext, obj *unstructured.Unstructured) (ir.PolicyIR, []error)
+	// NewGatewayTranslationPass returns the pass translating the IR of the policies attached to
+	// the resources of a Gateway into its xDS configuration.
+	NewGatewayTranslationPass func(tctx ir.GwTranslationCtx, reporter reporter.Reporter) ir.ProxyTranslationPass
+	// MergePolicies optionally merges the policies attached to the same resource.
+	MergePolicies func(pols []ir.PolicyAtt) ir.PolicyAtt
+}
+
+// GroupKind returns the group kind of the policy.
+func (d Definition) GroupKind() schema.GroupKind {
+	return schema.GroupKind{Group: d.GVR.Group, Kind: d.Kind}
+}
+
+// NewPlugin returns the plugin contributing the policy kind of the definition.
+func NewPlugin(commoncol *collections.CommonCollections, def Definition) sdk.Plugin {
+	cli := collections.NewDelayedDynamicInformer(
+		commoncol.Client,
+		def.GVR,
+		kclient.Filter{ObjectFilter: commoncol.Client.ObjectFilter()},
+	)
+	col := krt.WrapClient(cli, commoncol.KrtOpts.ToOptions(def.Kind)...)
+	policyStatusMarker, policyCol := newPolicyCollection(col, def, commoncol.ControllerName, commoncol.KrtOpts)
+	gk := def.GroupKind()
+
+	// processMarkers for policies that have existing status but no current report
+	processMarkers := func(kctx krt.HandlerContext, reportMap *reports.ReportMap) {
+		objStatus := krt.Fetch(kctx, policyStatusMarker)
+		for _, status := range objStatus {
+			policyKey := reporter.PolicyKey{
+				Group:     gk.Group,
+				Kind:      gk.Kind,
+				Namespace: status.Obj.GetNamespace(),
+				Name:      status.Obj.GetName(),
+			}
+
+			// Add empty status to clear stale status for policies with no valid targets
+			if reportMap.Policies[policyKey] == nil {
+				rp := reports.NewReporter(reportMap)
+				// create empty policy report entry with no ancestor refs
+				rp.Policy(policyKey, 0)
+			}
+		}
+	}
+
+	return sdk.Plugin{
+		ExtraHasSynced: col.HasSynced,
+		ContributesPolicies: map[schema.GroupKind]sdk.PolicyPlugin{
+			gk: {
+				Name:                            def.Kind,
+				NewGatewayTranslationPass:       def.NewGatewayTranslationPass,
+				Policies:                        policyCol,
+				ProcessPolicyStaleStatusMarkers: processMarkers,
+				GetPolicyStatus:                 getPolicyStatusFn(cli.Get),
+				PatchPolicyStatus:               patchPolicyStatusFn(cli.Get, commoncol.Client.Dynamic(), def.GVR),
+				MergePolicies:                   def.MergePolicies,
+			},
+		},
+	}
+}
+
+func newPolicyCollection(
+	col krt.Collection[*unstructured.Unstructured],
+	def Definition,
+	controllerName string,
+	krtOpts krtutil.KrtOptions,
+) (krt.StatusCollection[*unstructured.Unstructured, krtcollections.StatusMarker], krt.Collection[ir.PolicyWrapper]) {
+	gk := def.GroupKind()
+	targetRefs := def.TargetRefs
+	if targetRefs == nil {
+		targetRefs = DefaultTargetRefs
+	}
+
+	return krt.NewStatusCollection(col, func(krtctx krt.HandlerContext, i *unstructured.Unstructured) (*krtcollections.StatusMarker, *ir.PolicyWrapper) {
+		// Create status marker if existing status has kgateway controller
+		var statusMarker *krtcollections.StatusMarker
+		if status, err := policyStatus(i); err == nil {
+			for _, ancestor := range status.Ancestors {
+				if string(ancestor.ControllerName) == controllerName {
+					statusMarker = &krtcollections.StatusMarker{}
+					break
+				}
+			}
+		}
+
+		polIr, errs := def.Translate(krtctx, i)
+		refs, err := targetRefs(i)
+		if err != nil {
+			logger.Error("failed to resolve the targets of the policy", "kind", gk.Kind, "namespace", i.GetNamespace(), "name", i.GetName(), "error", err)
+			errs = append(errs, err)
+		}
+		pol := &ir.PolicyWrapper{
+			ObjectSource: ir.ObjectSource{
+				Group:     gk.Group,
+				Kind:      gk.Kind,
+				Namespace: i.GetNamespace(),
+				Name:      i.GetName(),
+			},
+			Policy:     i,
+			PolicyIR:   polIr,
+			TargetRefs: refs,
+			Errors:     errs,
+		}
+		return statusMarker, pol
+	}, krtOpts.ToOptions(def.Kind+"Wrapper")...)
+}
+
+// DefaultTargetRefs returns the resources referenced by the targetRefs and targetSelectors of the
+// spec of the policy, which have the same schema as the ones of the kgateway policies.
+func DefaultTargetRefs(obj *unstructured.Unstructured) ([]ir.PolicyRef, error) {
+	spec, _, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+	var targets struct {
+		TargetRefs      []shared.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs,omitempty"`
+		TargetSelectors []shared.LocalPolicyTargetSelectorWithSectionName  `json:"targetSelectors,omitempty"`
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(spec, &targets); err != nil {
+		return nil, fmt.Errorf("invalid targetRefs or targetSelectors: %w", err)
+	}
+	return pluginsdkutils.TargetRefsToPolicyRefsWithSectionName(targets.TargetRefs, targets.TargetSelectors), nil
+}
+
+// policyStatus returns the status of the policy.
+func policyStatus(obj *unstructured.Unstructured) (gwv1.PolicyStatus, error) {
+	var status gwv1.PolicyStatus
+	raw, _, err := unstructured.NestedMap(obj.Object, "status")
+	if err != nil {
+		return status, err
+	}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(raw, &status)
+	return status, err
+}
+
+func getPolicyStatusFn(
+	get func(name, namespace string) *unstructured.Unstructured,
+) sdk.GetPolicyStatusFn {
+	return func(ctx context.Context, nn types.NamespacedName) (gwv1.PolicyStatus, error) {
+		res := get(nn.Name, nn.Namespace)
+		if res == nil {
+			return gwv1.PolicyStatus{}, sdk.ErrNotFound
+		}
+		return policyStatus(res)
+	}
+}
+
+func patchPolicyStatusFn(
+	get func(name, namespace string) *unstructured.Unstructured,
+	cli dynamic.Interface,
+	gvr schema.GroupVersionResource,
+) sdk.PatchPolicyStatusFn {
+	return func(ctx context.Context, nn types.NamespacedName, policyStatus gwv1.PolicyStatus) error {
+		cur := get(nn.Name, nn.Namespace)
+		if cur == nil {
+			return sdk.ErrNotFound
+		}
+		status, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&policyStatus)
+		if err != nil {
+			return fmt.Errorf("error converting status for %s %s: %w", cur.GetKind(), nn.String(), err)
+		}
+		obj := cur.DeepCopy()
+		obj.Object["status"] = status
+		if _, err := cli.Resource(gvr).Namespace(nn.Namespace).UpdateStatus(ctx, obj, metav1.UpdateOptions{}); err != nil {
+			if apierrors.IsConflict(err) {
+				logger.Debug("error updating stale status", "ref", nn, "error", err)
+				return nil // let the conflicting Status update trigger a KRT event to requeue the updated object
+			}
+			return fmt.Errorf("error updating status for %s %s: %w", cur.GetKind(), nn.String(), err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,230 @@
+package custompolicy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"istio.io/istio/pkg/kube/krt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
+)
+
+const controllerName = "kgateway.dev/kgateway"
+
+var wafPolicyGVR = schema.GroupVersionResource{Group: "waf.example.com", Version: "v1alpha1", Resource: "wafpolicies"}
+
+type wafIr struct {
+	mode string
+}
+
+func (w *wafIr) CreationTime() time.Time {
+	return time.Time{}
+}
+
+func (w *wafIr) Equals(in any) bool {
+	w2, ok := in.(*wafIr)
+	return ok && w.mode == w2.mode
+}
+
+func wafDefinition() Definition {
+	return Definition{
+		GVR:  wafPolicyGVR,
+		Kind: "WafPolicy",
+		Translate: func(_ krt.HandlerContext, obj *unstructured.Unstructured) (ir.PolicyIR, []error) {
+			mode, _, _ := unstructured.NestedString(obj.Object, "spec", "mode")
+			if mode != "Block" && mode != "Detect" {
+				return nil, []error{errors.New("invalid mode " + mode)}
+			}
+			return &wafIr{mode: mode}, nil
+		},
+	}
+}
+
+func wafPolicy(name string, spec map[string]any, status map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "waf.example.com/v1alpha1",
+		"kind":       "WafPolicy",
+		"metadata": map[string]any{
+			"name":            name,
+			"namespace":       "default",
+			"resourceVersion": "1",
+		},
+		"spec": spec,
+	}}
+	if status != nil {
+		obj.Object["status"] = status
+	}
+	return obj
+}
+
+func TestDefaultTargetRefs(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    map[string]any
+		want    []ir.PolicyRef
+		wantErr bool
+	}{
+		{
+			name: "targetRefs and targetSelectors",
+			spec: map[string]any{
+				"targetRefs": []any{
+					map[string]any{"group": "gateway.networking.k8s.io", "kind": "HTTPRoute", "name": "route"},
+					map[string]any{"group": "gateway.networking.k8s.io", "kind": "Gateway", "name": "gw", "sectionName": "http"},
+				},
+				"targetSelectors": []any{
+					map[string]any{"group": "gateway.networking.k8s.io", "kind": "HTTPRoute", "matchLabels": map[string]any{"app": "waf"}},
+				},
+			},
+			want: []ir.PolicyRef{
+				{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Name: "route"},
+				{Group: "gateway.networking.k8s.io", Kind: "Gateway", Name: "gw", SectionName: "http"},
+				{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", MatchLabels: map[string]string{"app": "waf"}},
+			},
+		},
+		{
+			name: "no targets",
+			spec: map[string]any{"mode": "Block"},
+			want: []ir.PolicyRef{},
+		},
+		{
+			name:    "invalid targetRefs",
+			spec:    map[string]any{"targetRefs": "route"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DefaultTargetRefs(wafPolicy("waf", tt.spec, nil))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPolicyCollection(t *testing.T) {
+	targetRefs := []any{
+		map[string]any{"group": "gateway.networking.k8s.io", "kind": "HTTPRoute", "name": "route"},
+	}
+	kgatewayStatus := map[string]any{
+		"ancestors": []any{
+			map[string]any{
+				"ancestorRef":    map[string]any{"name": "gw"},
+				"controllerName": controllerName,
+				"conditions":     []any{},
+			},
+		},
+	}
+	otherStatus := map[string]any{
+		"ancestors": []any{
+			map[string]any{
+				"ancestorRef":    map[string]any{"name": "gw"},
+				"controllerName": "example.com/other",
+				"conditions":     []any{},
+			},
+		},
+	}
+
+	krtOpts := krtutil.NewKrtOptions(t.Context().Done(), nil)
+	col := krt.NewStaticCollection(nil, []*unstructured.Unstructured{
+		wafPolicy("block", map[string]any{"mode": "Block", "targetRefs": targetRefs}, kgatewayStatus),
+		wafPolicy("invalid", map[string]any{"mode": "Allow", "targetRefs": targetRefs}, otherStatus),
+	}, krtOpts.ToOptions("WafPolicies")...)
+	statusCol, policyCol := newPolicyCollection(col, wafDefinition(), controllerName, krtOpts)
+	policyCol.WaitUntilSynced(t.Context().Done())
+	statusCol.WaitUntilSynced(t.Context().Done())
+
+	block := policyCol.GetKey("waf.example.com/WafPolicy/default/block")
+	require.NotNil(t, block)
+	assert.Equal(t, ir.ObjectSource{Group: "waf.example.com", Kind: "WafPolicy", Namespace: "default", Name: "block"}, block.ObjectSource)
+	assert.Equal(t, []ir.PolicyRef{{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Name: "route"}}, block.TargetRefs)
+	assert.Equal(t, &wafIr{mode: "Block"}, block.PolicyIR)
+	assert.Empty(t, block.Errors)
+
+	invalid := policyCol.GetKey("waf.example.com/WafPolicy/default/invalid")
+	require.NotNil(t, invalid)
+	assert.Equal(t, []ir.PolicyRef{{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Name: "route"}}, invalid.TargetRefs)
+	assert.Nil(t, invalid.PolicyIR)
+	assert.Len(t, invalid.Errors, 1)
+
+	// only the policy with a status written by kgateway has a stale status marker
+	markers := statusCol.List()
+	require.Len(t, markers, 1)
+	assert.Equal(t, "block", markers[0].Obj.GetName())
+}
+
+func TestPolicyStatus(t *testing.T) {
+	nn := types.NamespacedName{Namespace: "default", Name: "waf"}
+	pol := wafPolicy("waf", map[string]any{"mode": "Block"}, map[string]any{
+		"ancestors": []any{
+			map[string]any{
+				"ancestorRef":    map[string]any{"name": "gw"},
+				"controllerName": controllerName,
+				"conditions":     []any{},
+			},
+		},
+	})
+	scheme := runtime.NewScheme()
+	cli := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
+		wafPolicyGVR: "WafPolicyList",
+	}, pol)
+	get := func(name, namespace string) *unstructured.Unstructured {
+		obj, err := cli.Resource(wafPolicyGVR).Namespace(namespace).Get(t.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			return nil
+		}
+		return obj
+	}
+
+	getStatus := getPolicyStatusFn(get)
+	status, err := getStatus(t.Context(), nn)
+	require.NoError(t, err)
+	require.Len(t, status.Ancestors, 1)
+	assert.Equal(t, gwv1.GatewayController(controllerName), status.Ancestors[0].ControllerName)
+
+	_, err = getStatus(t.Context(), types.NamespacedName{Namespace: "default", Name: "missing"})
+	assert.ErrorIs(t, err, sdk.ErrNotFound)
+
+	newStatus := gwv1.PolicyStatus{
+		Ancestors: []gwv1.PolicyAncestorStatus{{
+			AncestorRef:    gwv1.ParentReference{Name: "gw"},
+			ControllerName: controllerName,
+			Conditions: []metav1.Condition{{
+				Type:               "Accepted",
+				Status:             metav1.ConditionFalse,
+				Reason:             "Invalid",
+				Message:            "invalid mode Allow",
+				LastTransitionTime: metav1.NewTime(time.Unix(0, 0).UTC()),
+			}},
+		}},
+	}
+	patchStatus := patchPolicyStatusFn(get, cli, wafPolicyGVR)
+	require.NoError(t, patchStatus(t.Context(), nn, newStatus))
+
+	status, err = getStatus(t.Context(), nn)
+	require.NoError(t, err)
+	require.Len(t, status.Ancestors, 1)
+	require.Len(t, status.Ancestors[0].Conditions, 1)
+	assert.Equal(t, "invalid mode Allow", status.Ancestors[0].Conditions[0].Message)
+	// the spec is left untouched
+	mode, _, _ := unstructured.NestedString(get(nn.Name, nn.Namespace).Object, "spec", "mode")
+	assert.Equal(t, "Block", mode)
+
+	err = patchStatus(t.Context(), types.NamespacedName{Namespace: "default", Name: "missing"}, newStatus)
+	assert.ErrorIs(t, err, sdk.ErrNotFound)
+}
@@ -99,10 +99,16 @@ type Filter interface {
 	GetTypedConfig() *anypb.Any
 }
 
+// StagedFilter is a filter with its position in the filter chain. Filters are ordered by their stage
+// first, i.e. the well-known stage and the relative weight within that stage, so plugins control
+// the order of their filters relative to the filters of other plugins with the stage alone.
 type StagedFilter[WellKnown ~int, FilterType Filter] struct {
 	Filter FilterType
 	Stage  FilterStage[WellKnown]
 	Weight int32
+	// Plugin is the name of the plugin that contributed the filter. It is set by the translator,
+	// and orders the filters of different plugins in the same stage.
+	Plugin string
 }
 
 type StagedFilterList[WellKnown ~int, FilterType Filter] []StagedFilter[WellKnown, FilterType]
@@ -111,9 +117,9 @@ func (s StagedFilterList[WellKnown, FilterType]) Len() int {
 	return len(s)
 }
 
-// filters by Relative Stage, Weighting, Name, Config Type-Url, Config Value, and (to ensure stability) index.
+// filters by Relative Stage, Weighting, Plugin, Name, Config Type-Url, Config Value, and (to ensure stability) index.
 // The assumption is that if two filters are in the same stage, their order doesn't matter, and we
-// just need to make sure it is stable.
+// just need to make sure it is stable, regardless of the order the plugins contributed them in.
 func (s StagedFilterList[WellKnown, FilterType]) Less(i, j int) bool {
 	if compare := FilterStageComparison(s[i].Stage, s[j].Stage); compare != 0 {
 		return compare < 0
@@ -129,6 +135,10 @@ func (s StagedFilterList[WellKnown, FilterType]) Less(i, j int) bool {
 		}
 	}
 
+	if compare := strings.Compare(s[i].Plugin, s[j].Plugin); compare != 0 {
+		return compare < 0
+	}
+
 	if compare := strings.Compare(s[i].Filter.GetName(), s[j].Filter.GetName()); compare != 0 {
 		return compare < 0
 	}
@@ -78,10 +78,11 @@ const (
 	DefaultAppProtocol   AppProtocol = ""
 	HTTP2AppProtocol     AppProtocol = "http2"
 	WebSocketAppProtocol AppProtocol = "ws"
+	A2AAppProtocol       AppProtocol = "a2a"
 )
 
 // ParseAppProtocol takes an app protocol string provided on a Backend or Kubernetes Service, and maps it
-// to one of the app protocol types supported by kgateway (http2, websocket, or default).
+// to one of the app protocol types supported by kgateway (http2, websocket, a2a, or default).
 // Recognizes http2 app protocols defined by istio (https://istio.io/latest/docs/ops/configuration/traffic-management/protocol-selection/)
 // and GEP-1911 (https://gateway-api.sigs.k8s.io/geps/gep-1911/#api-semantics).
 func ParseAppProtocol(appProtocol *string) AppProtocol {
@@ -96,6 +97,8 @@ func ParseAppProtocol(appProtocol *string) AppProtocol {
 		return HTTP2AppProtocol
 	case string(kgateway.AppProtocolKubernetesWs):
 		return WebSocketAppProtocol
+	case string(kgateway.AppProtocolA2A):
+		return A2AAppProtocol
 	default:
 		return DefaultAppProtocol
 	}
@@ -316,7 +319,7 @@ type GatewayForDeployer struct {
 	ObjectSource
 	// Controller name for the gateway
 	ControllerName string
-	// All ports from all listeners
+	// All ports from the listeners using TCP-based protocols
 	Ports smallset.Set[int32]
 }
 
@@ -349,6 +352,9 @@ type Gateway struct {
 
 	PerConnectionBufferLimitBytes *uint32
 	FrontendTLSConfig             *FrontendTLSConfigIR
+
+	// Draining is set when the gateway is annotated to be drained
+	Draining bool
 }
 
 // FrontendTLSConfigIR represents the Gateway-level frontend TLS configuration
@@ -377,6 +383,9 @@ type ClientCertificateValidationIR struct {
 	// still be accepted at the TLS handshake layer.
 	// +noKrtEquals
 	AllowInsecureFallback bool
+	// VerifySubjectAltNames restricts accepted client certificates to those with a matching SAN
+	// +noKrtEquals
+	VerifySubjectAltNames []string
 }
 
 func (c *ClientCertificateValidationIR) Equals(in any) bool {
@@ -394,6 +403,7 @@ func (c Gateway) ResourceName() string {
 func (c Gateway) Equals(in Gateway) bool {
 	return c.ObjectSource.Equals(in.ObjectSource) &&
 		ptrEquals(c.PerConnectionBufferLimitBytes, in.PerConnectionBufferLimitBytes) &&
+		c.Draining == in.Draining &&
 		versionEquals(c.Obj, in.Obj) &&
 		c.AttachedListenerPolicies.Equals(in.AttachedListenerPolicies) &&
 		c.AttachedHttpPolicies.Equals(in.AttachedHttpPolicies) &&
@@ -432,6 +442,9 @@ func equalsClientCertValidationIR(a, b *ClientCertificateValidationIR) bool {
 	if a.AllowInsecureFallback != b.AllowInsecureFallback {
 		return false
 	}
+	if !slices.Equal(a.VerifySubjectAltNames, b.VerifySubjectAltNames) {
+		return false
+	}
 	if len(a.CACertificateRefs) != len(b.CACertificateRefs) {
 		return false
 	}
@@ -41,6 +41,11 @@ func TestParseAppProtocol(t *testing.T) {
 			input:    new("kubernetes.io/ws"),
 			expected: WebSocketAppProtocol,
 		},
+		{
+			name:     "kgateway.dev/a2a",
+			input:    new("kgateway.dev/a2a"),
+			expected: A2AAppProtocol,
+		},
 		{
 			name:     "HTTP2",
 			input:    new("HTTP2"),
@@ -37,7 +37,9 @@ type HttpRouteRuleMatchIR struct {
 	Backends   []HttpBackend
 	Match      gwv1.HTTPRouteMatch
 	MatchIndex int
-	Name       string
+	// RuleIndex is the index of the rule in the route this match belongs to.
+	RuleIndex int
+	Name      string
 
 	// PrecedenceWeight specifies the weight of this route rule relative to other route rules.
 	// Higher weight means higher priority, and are evaluated before routes with lower weight
@@ -154,6 +156,9 @@ type GatewayIR struct {
 	// PerConnectionBufferLimitBytes is the listener-level per connection buffer limit.
 	// Applied to all listeners in the gateway.
 	PerConnectionBufferLimitBytes *uint32
+
+	// Draining is set when the connections of all the listeners of the gateway are being drained.
+	Draining bool
 }
 
 // this assumes that GatewayIR was constructed correctly and SourceObject !nil and Obj contained within it is also !nil
@@ -11,6 +11,7 @@ import (
 	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoy_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoytcp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
 	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -34,6 +35,8 @@ type ListenerContext struct {
 	Port              uint32
 	Policy            PolicyIR
 	PolicyAncestorRef gwv1.ParentReference
+	// HasRoutes is true when routes are attached to the listener
+	HasRoutes bool
 }
 
 type HttpFiltersContext struct {
@@ -122,6 +125,11 @@ type HcmContext struct {
 	Gateway      GatewayIR
 }
 
+type TcpProxyContext struct {
+	ListenerPort    uint32
+	FilterChainName string
+}
+
 // ProxyTranslationPass represents a single translation pass for a gateway using envoy. It can hold state
 // for the duration of the translation.
 // Each of the functions here will be called in the order they appear in the interface.
@@ -193,6 +201,15 @@ type ProxyTranslationPass interface {
 	ResourcesToAdd() Resources
 }
 
+// TcpProxyTranslationPass is implemented by the translation passes that tweak the TCP proxy settings
+// of TCP and TLS filter chains.
+type TcpProxyTranslationPass interface {
+	// called 1 time per TCP filter chain after listeners and allows tweaking TCP proxy settings.
+	ApplyTcpProxy(
+		pCtx *TcpProxyContext,
+		out *envoytcp.TcpProxy) error
+}
+
 type UnimplementedProxyTranslationPass struct{}
 
 var _ ProxyTranslationPass = UnimplementedProxyTranslationPass{}
@@ -96,6 +96,15 @@ func (m MergeOrigins) IsSet() bool {
 	return len(m) > 0
 }
 
+// PolicyIDs returns the IDs of all policy refs that contributed to any field
+func (m MergeOrigins) PolicyIDs() sets.Set[string] {
+	ids := sets.New[string]()
+	for _, refs := range m {
+		ids = ids.Union(refs)
+	}
+	return ids
+}
+
 func (m MergeOrigins) ToProtoStruct() *structpb.Struct {
 	if !m.IsSet() {
 		return nil
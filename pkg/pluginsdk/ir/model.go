@@ -70,6 +70,9 @@ func labeledRole(role string, labels map[string]string) string {
 
 type EndpointMetadata struct {
 	Labels map[string]string
+	// ForZones are the zones the EndpointSlice controller hints the endpoint should serve
+	// with topology aware routing. Empty when the endpoint has no hints.
+	ForZones []string
 }
 type EndpointWithMd struct {
 	*envoyendpointv3.LbEndpoint
@@ -174,6 +177,10 @@ func hashEndpoints(l PodLocality, emd EndpointWithMd) uint64 {
 	hasher.Write([]byte(l.Subzone))
 
 	utils.HashUint64(hasher, utils.HashLabels(emd.EndpointMd.Labels))
+	for _, zone := range emd.EndpointMd.ForZones {
+		hasher.Write([]byte{0})
+		hasher.Write([]byte(zone))
+	}
 	utils.HashProtoWithHasher(hasher, emd.LbEndpoint)
 	return hasher.Sum64()
 }
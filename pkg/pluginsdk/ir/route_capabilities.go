@@ -0,0 +1,70 @@
+package ir
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// RouteCapability is a capability that an HTTPRoute rule can configure natively, either
+// through one of its filters or through its own fields.
+type RouteCapability string
+
+const (
+	RouteCapabilityCORS                   = RouteCapability(gwv1.HTTPRouteFilterCORS)
+	RouteCapabilityRequestHeaderModifier  = RouteCapability(gwv1.HTTPRouteFilterRequestHeaderModifier)
+	RouteCapabilityResponseHeaderModifier = RouteCapability(gwv1.HTTPRouteFilterResponseHeaderModifier)
+	RouteCapabilityRequestRedirect        = RouteCapability(gwv1.HTTPRouteFilterRequestRedirect)
+	RouteCapabilityURLRewrite             = RouteCapability(gwv1.HTTPRouteFilterURLRewrite)
+	// RouteCapabilityTimeouts is configured by the timeouts field of an HTTPRoute rule.
+	RouteCapabilityTimeouts RouteCapability = "Timeouts"
+	// RouteCapabilitySessionPersistence is configured by the sessionPersistence field of an HTTPRoute rule.
+	RouteCapabilitySessionPersistence RouteCapability = "SessionPersistence"
+)
+
+// RouteCapabilityOverlap describes a policy field that overlaps with a capability
+// configured natively on a route rule.
+type RouteCapabilityOverlap struct {
+	// Field is the name of the policy field, e.g. cors or headerModifiers.request.
+	// The part before the first '.' must match the field name used in MergeOrigins.
+	Field string
+	// Capability is the native route capability the field overlaps with.
+	Capability RouteCapability
+}
+
+// RouteCapabilityPolicy is implemented by a PolicyIR that configures capabilities an HTTPRoute
+// rule can also configure natively. The native route configuration always takes precedence,
+// so the overlapping fields are removed from the policy before it is applied to the route.
+type RouteCapabilityPolicy interface {
+	PolicyIR
+	// RouteCapabilityOverlaps returns the fields of the policy that overlap with the given
+	// capabilities configured natively on a route rule.
+	RouteCapabilityOverlaps(native sets.Set[RouteCapability]) []RouteCapabilityOverlap
+	// WithoutFields returns a copy of the policy with the given fields unset.
+	WithoutFields(fields []string) PolicyIR
+}
+
+// BackendRouteCapabilityPolicy is implemented by a PolicyIR attached to a backend that configures
+// capabilities an HTTPRoute rule can also configure natively. A backend policy applies to every
+// route of the backend, so the overlapping fields are kept, and the native route configuration
+// takes precedence for the traffic of the rule that configures it.
+type BackendRouteCapabilityPolicy interface {
+	PolicyIR
+	// RouteCapabilityOverlaps returns the fields of the policy that overlap with the given
+	// capabilities configured natively on a route rule.
+	RouteCapabilityOverlaps(native sets.Set[RouteCapability]) []RouteCapabilityOverlap
+}
+
+// NativeRouteCapabilities returns the capabilities configured natively by the given HTTPRoute rule.
+func NativeRouteCapabilities(rule gwv1.HTTPRouteRule) sets.Set[RouteCapability] {
+	capabilities := sets.New[RouteCapability]()
+	for _, f := range rule.Filters {
+		capabilities.Insert(RouteCapability(f.Type))
+	}
+	if rule.Timeouts != nil && (rule.Timeouts.Request != nil || rule.Timeouts.BackendRequest != nil) {
+		capabilities.Insert(RouteCapabilityTimeouts)
+	}
+	if rule.SessionPersistence != nil {
+		capabilities.Insert(RouteCapabilitySessionPersistence)
+	}
+	return capabilities
+}
@@ -0,0 +1,112 @@
+package krtutil
+
+import (
+	"time"
+
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/kube/krt"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+)
+
+const (
+	collectionSubsystem = "krt_collection"
+	collectionLabel     = "collection"
+)
+
+var (
+	collectionObjects = metrics.NewGauge(
+		metrics.GaugeOpts{
+			Subsystem: collectionSubsystem,
+			Name:      "objects",
+			Help:      "Current number of objects in a krt collection",
+		},
+		[]string{collectionLabel},
+	)
+	collectionTransformDuration = metrics.NewHistogram(
+		metrics.HistogramOpts{
+			Subsystem:                       collectionSubsystem,
+			Name:                            "transform_duration_seconds",
+			Help:                            "Duration of the transformation of a single input object of a krt collection",
+			Buckets:                         []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1},
+			NativeHistogramBucketFactor:     1.1,
+			NativeHistogramMaxBucketNumber:  100,
+			NativeHistogramMinResetDuration: time.Hour,
+		},
+		[]string{collectionLabel},
+	)
+	collectionRecomputesTotal = metrics.NewCounter(
+		metrics.CounterOpts{
+			Subsystem: collectionSubsystem,
+			Name:      "recomputes_total",
+			Help:      "Total number of transformations of a krt collection, triggered by changes to its input or its dependencies",
+		},
+		[]string{collectionLabel},
+	)
+)
+
+// NewCollection is krt.NewCollection with the options of ToOptions(name). If collection metrics
+// are enabled, the object count, transformation latency and recomputes of the collection are recorded.
+func NewCollection[I, O any](
+	c krt.Collection[I],
+	f krt.TransformationSingle[I, O],
+	k KrtOptions,
+	name string,
+) krt.Collection[O] {
+	if !k.collectionMetrics {
+		return krt.NewCollection(c, f, k.ToOptions(name)...)
+	}
+	fullName := k.name(name)
+	out := krt.NewCollection(c, func(kctx krt.HandlerContext, i I) *O {
+		defer observeTransformation(fullName, time.Now())
+		return f(kctx, i)
+	}, k.ToOptions(name)...)
+	registerObjectCount(fullName, out)
+	return out
+}
+
+// NewManyCollection is krt.NewManyCollection with the options of ToOptions(name). If collection metrics
+// are enabled, the object count, transformation latency and recomputes of the collection are recorded.
+func NewManyCollection[I, O any](
+	c krt.Collection[I],
+	f krt.TransformationMulti[I, O],
+	k KrtOptions,
+	name string,
+) krt.Collection[O] {
+	if !k.collectionMetrics {
+		return krt.NewManyCollection(c, f, k.ToOptions(name)...)
+	}
+	fullName := k.name(name)
+	out := krt.NewManyCollection(c, func(kctx krt.HandlerContext, i I) []O {
+		defer observeTransformation(fullName, time.Now())
+		return f(kctx, i)
+	}, k.ToOptions(name)...)
+	registerObjectCount(fullName, out)
+	return out
+}
+
+func observeTransformation(name string, start time.Time) {
+	label := metrics.Label{Name: collectionLabel, Value: name}
+	collectionRecomputesTotal.Inc(label)
+	collectionTransformDuration.Observe(time.Since(start).Seconds(), label)
+}
+
+func registerObjectCount[T any](name string, c krt.Collection[T]) {
+	label := metrics.Label{Name: collectionLabel, Value: name}
+	collectionObjects.Set(0, label)
+	metrics.RegisterEvents(c, func(o krt.Event[T]) {
+		switch o.Event {
+		case controllers.EventAdd:
+			collectionObjects.Add(1, label)
+		case controllers.EventDelete:
+			collectionObjects.Sub(1, label)
+		}
+	})
+}
+
+// ResetCollectionMetrics resets the krt collection metrics.
+func ResetCollectionMetrics() {
+	collectionObjects.Reset()
+	collectionTransformDuration.Reset()
+	collectionRecomputesTotal.Reset()
+}
@@ -0,0 +1,105 @@
+package krtutil_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"istio.io/istio/pkg/kube/krt"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics/metricstest"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
+)
+
+type testObj struct {
+	Name  string
+	Value int
+}
+
+func (o testObj) ResourceName() string {
+	return o.Name
+}
+
+func collectionLabel(name string) []metrics.Label {
+	return []metrics.Label{{Name: "collection", Value: name}}
+}
+
+func TestCollectionMetrics(t *testing.T) {
+	krtutil.ResetCollectionMetrics()
+
+	stop := make(chan struct{})
+	t.Cleanup(func() { close(stop) })
+	krtOpts := krtutil.NewKrtOptions(stop, nil).WithCollectionMetrics(true)
+
+	inputs := krt.NewStaticCollection[testObj](nil, []testObj{{Name: "a", Value: 1}, {Name: "b", Value: 2}}, krtOpts.ToOptions("inputs")...)
+	doubled := krtutil.NewCollection(inputs, func(_ krt.HandlerContext, o testObj) *testObj {
+		return &testObj{Name: o.Name, Value: o.Value * 2}
+	}, krtOpts, "doubled")
+	evens := krtutil.NewManyCollection(inputs, func(_ krt.HandlerContext, o testObj) []testObj {
+		if o.Value%2 != 0 {
+			return nil
+		}
+		return []testObj{o}
+	}, krtOpts, "evens")
+	doubled.WaitUntilSynced(stop)
+	evens.WaitUntilSynced(stop)
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		gathered := metricstest.MustGatherMetrics(c)
+		gathered.AssertMetricsInclude("kgateway_krt_collection_objects", []metricstest.ExpectMetric{
+			&metricstest.ExpectedMetric{Labels: collectionLabel("doubled"), Value: 2},
+			&metricstest.ExpectedMetric{Labels: collectionLabel("evens"), Value: 1},
+		})
+		gathered.AssertMetricsInclude("kgateway_krt_collection_recomputes_total", []metricstest.ExpectMetric{
+			&metricstest.ExpectedMetric{Labels: collectionLabel("doubled"), Value: 2},
+			&metricstest.ExpectedMetric{Labels: collectionLabel("evens"), Value: 2},
+		})
+		gathered.AssertHistogramPopulated("kgateway_krt_collection_transform_duration_seconds")
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// changing an input recomputes its outputs in both collections
+	inputs.UpdateObject(testObj{Name: "a", Value: 4})
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		gathered := metricstest.MustGatherMetrics(c)
+		gathered.AssertMetricsInclude("kgateway_krt_collection_recomputes_total", []metricstest.ExpectMetric{
+			&metricstest.ExpectedMetric{Labels: collectionLabel("doubled"), Value: 3},
+			&metricstest.ExpectedMetric{Labels: collectionLabel("evens"), Value: 3},
+		})
+		gathered.AssertMetricsInclude("kgateway_krt_collection_objects", []metricstest.ExpectMetric{
+			&metricstest.ExpectedMetric{Labels: collectionLabel("doubled"), Value: 2},
+			&metricstest.ExpectedMetric{Labels: collectionLabel("evens"), Value: 2},
+		})
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Equal(t, 8, ptrValue(doubled.GetKey("a")))
+}
+
+func TestCollectionMetricsDisabled(t *testing.T) {
+	krtutil.ResetCollectionMetrics()
+
+	stop := make(chan struct{})
+	t.Cleanup(func() { close(stop) })
+	krtOpts := krtutil.NewKrtOptions(stop, nil)
+
+	inputs := krt.NewStaticCollection[testObj](nil, []testObj{{Name: "a", Value: 1}}, krtOpts.ToOptions("inputs")...)
+	out := krtutil.NewCollection(inputs, func(_ krt.HandlerContext, o testObj) *testObj {
+		return &o
+	}, krtOpts, "disabled")
+	out.WaitUntilSynced(stop)
+	require.Len(t, out.List(), 1)
+
+	// only the series initialized by the reset exists
+	gathered := metricstest.MustGatherMetrics(t)
+	gathered.AssertMetrics("kgateway_krt_collection_recomputes_total", []metricstest.ExpectMetric{
+		&metricstest.ExpectedMetric{Labels: collectionLabel(""), Value: 0},
+	})
+}
+
+func ptrValue(o *testObj) int {
+	if o == nil {
+		return 0
+	}
+	return o.Value
+}
@@ -8,6 +8,8 @@ type KrtOptions struct {
 	// namePrefix, if set, will prefix every name with the common prefix.
 	// For example `<namePrefix>/<name>`.
 	namePrefix string
+	// collectionMetrics enables the metrics of collections created with NewCollection and NewManyCollection.
+	collectionMetrics bool
 }
 
 func NewKrtOptions(stop <-chan struct{}, debugger *krt.DebugHandler) KrtOptions {
@@ -26,12 +28,23 @@ func (k KrtOptions) WithPrefix(name string) KrtOptions {
 	return k
 }
 
-func (k KrtOptions) ToOptions(name string) []krt.CollectionOption {
+// WithCollectionMetrics enables per-collection metrics. They're off by default as they add
+// overhead to every transformation.
+func (k KrtOptions) WithCollectionMetrics(enabled bool) KrtOptions {
+	k.collectionMetrics = enabled
+	return k
+}
+
+func (k KrtOptions) name(name string) string {
 	if k.namePrefix != "" {
-		name = k.namePrefix + "/" + name
+		return k.namePrefix + "/" + name
 	}
+	return name
+}
+
+func (k KrtOptions) ToOptions(name string) []krt.CollectionOption {
 	return []krt.CollectionOption{
-		krt.WithName(name),
+		krt.WithName(k.name(name)),
 		krt.WithDebugging(k.Debugger),
 		krt.WithStop(k.Stop),
 	}
@@ -0,0 +1,114 @@
+package policy
+
+import (
+	"log/slog"
+	"slices"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+// FieldAccessor gets and sets a field of type F on a policy IR of type T.
+// It lets the merge helpers below operate on any field of any policy.
+type FieldAccessor[T, F any] struct {
+	Get func(*T) F
+	Set func(*T, F)
+}
+
+// MergeField merges a field of p2 into p1 treating the field as opaque, and records the origin of the
+// field in mergeOrigins so that the status of the merged policies can be reported.
+// With shallow strategies, the field is taken from p2 whenever IsMergeable allows it. With deep strategies,
+// the field is only taken from p2 if p1 does not set it, since an opaque field cannot be merged.
+// This is the merge that should be used for fields without custom merge semantics.
+func MergeField[T, F any](
+	p1, p2 *T,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+	accessor FieldAccessor[T, F],
+	fieldName string,
+) {
+	p1Field := accessor.Get(p1)
+	p2Field := accessor.Get(p2)
+
+	if !IsMergeable(p1Field, p2Field, opts) {
+		return
+	}
+
+	switch opts.Strategy {
+	case AugmentedDeepMerge, OverridableDeepMerge:
+		if !isNil(p1Field) {
+			return
+		}
+		fallthrough // can override p1 if it is unset
+
+	case AugmentedShallowMerge, OverridableShallowMerge:
+		accessor.Set(p1, p2Field)
+		mergeOrigins.SetOne(fieldName, p2Ref, p2MergeOrigins)
+
+	default:
+		slog.Warn("unsupported merge strategy for policy", "strategy", opts.Strategy, "policy", p2Ref, "field", fieldName)
+	}
+}
+
+// DeepMergeField merges a field of p2 into p1 like MergeField, except that deep strategies combine the
+// field of both policies when both set it. deepMerge(preferred, other) must return a new value that
+// combines both values, giving priority to preferred, without modifying either of them.
+// p1 is preferred with AugmentedDeepMerge and p2 is preferred with OverridableDeepMerge.
+func DeepMergeField[T, F any](
+	p1, p2 *T,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+	accessor FieldAccessor[T, F],
+	fieldName string,
+	deepMerge func(preferred, other F) F,
+) {
+	p1Field := accessor.Get(p1)
+	p2Field := accessor.Get(p2)
+
+	if !IsMergeable(p1Field, p2Field, opts) {
+		return
+	}
+
+	switch opts.Strategy {
+	case AugmentedDeepMerge:
+		if isNil(p1Field) {
+			accessor.Set(p1, p2Field)
+		} else {
+			accessor.Set(p1, deepMerge(p1Field, p2Field))
+		}
+		mergeOrigins.Append(fieldName, p2Ref, p2MergeOrigins)
+
+	case OverridableDeepMerge:
+		if isNil(p1Field) {
+			accessor.Set(p1, p2Field)
+		} else {
+			accessor.Set(p1, deepMerge(p2Field, p1Field))
+		}
+		mergeOrigins.Append(fieldName, p2Ref, p2MergeOrigins)
+
+	default:
+		MergeField(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, fieldName)
+	}
+}
+
+// AppendListField merges a list field of p2 into p1 like MergeField, except that deep strategies
+// concatenate the lists of both policies, with the items of the preferred policy first.
+// p1 is preferred with AugmentedDeepMerge and p2 is preferred with OverridableDeepMerge.
+// The lists of the policies are never modified.
+func AppendListField[T, E any](
+	p1, p2 *T,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+	accessor FieldAccessor[T, []E],
+	fieldName string,
+) {
+	DeepMergeField(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, fieldName, func(preferred, other []E) []E {
+		// Always Concat so that the original slices in the IR are never modified
+		return slices.Concat(preferred, other)
+	})
+}
@@ -0,0 +1,255 @@
+package policy
+
+import (
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	apiannotations "github.com/kgateway-dev/kgateway/v2/api/annotations"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+type testPolicy struct {
+	timeout *int
+	hosts   []string
+	headers map[string]string
+}
+
+func (p *testPolicy) CreationTime() time.Time {
+	return time.Time{}
+}
+
+func (p *testPolicy) Equals(in any) bool {
+	return false
+}
+
+var (
+	timeoutAccessor = FieldAccessor[testPolicy, *int]{
+		Get: func(p *testPolicy) *int { return p.timeout },
+		Set: func(p *testPolicy, v *int) { p.timeout = v },
+	}
+	hostsAccessor = FieldAccessor[testPolicy, []string]{
+		Get: func(p *testPolicy) []string { return p.hosts },
+		Set: func(p *testPolicy, v []string) { p.hosts = v },
+	}
+	headersAccessor = FieldAccessor[testPolicy, map[string]string]{
+		Get: func(p *testPolicy) map[string]string { return p.headers },
+		Set: func(p *testPolicy, v map[string]string) { p.headers = v },
+	}
+)
+
+// mergeHeaders combines the headers of both maps, giving priority to preferred
+func mergeHeaders(preferred, other map[string]string) map[string]string {
+	out := maps.Clone(other)
+	maps.Copy(out, preferred)
+	return out
+}
+
+func mergeTestPolicies(
+	p1, p2 *testPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+	_ string,
+) {
+	MergeField(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, timeoutAccessor, "timeout")
+	AppendListField(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, hostsAccessor, "hosts")
+	DeepMergeField(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, headersAccessor, "headers", mergeHeaders)
+}
+
+func testRef(name string) *ir.AttachedPolicyRef {
+	return &ir.AttachedPolicyRef{Group: "test.io", Kind: "TestPolicy", Namespace: "default", Name: name}
+}
+
+func TestMergeField(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy MergeStrategy
+		p1, p2   *int
+		want     *int
+		wantSet  bool
+	}{
+		{name: "augmented shallow keeps p1", strategy: AugmentedShallowMerge, p1: new(1), p2: new(2), want: new(1)},
+		{name: "augmented shallow fills unset p1", strategy: AugmentedShallowMerge, p2: new(2), want: new(2), wantSet: true},
+		{name: "overridable shallow overrides p1", strategy: OverridableShallowMerge, p1: new(1), p2: new(2), want: new(2), wantSet: true},
+		{name: "overridable shallow ignores unset p2", strategy: OverridableShallowMerge, p1: new(1), want: new(1)},
+		{name: "augmented deep keeps p1", strategy: AugmentedDeepMerge, p1: new(1), p2: new(2), want: new(1)},
+		{name: "overridable deep keeps opaque p1", strategy: OverridableDeepMerge, p1: new(1), p2: new(2), want: new(1)},
+		{name: "overridable deep fills unset p1", strategy: OverridableDeepMerge, p2: new(2), want: new(2), wantSet: true},
+		{name: "unsupported strategy", strategy: "Unknown", p2: new(2)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p1 := &testPolicy{timeout: tt.p1}
+			p2 := &testPolicy{timeout: tt.p2}
+			mergeOrigins := ir.MergeOrigins{}
+
+			MergeField(p1, p2, testRef("p2"), nil, MergeOptions{Strategy: tt.strategy}, mergeOrigins, timeoutAccessor, "timeout")
+
+			assert.Equal(t, tt.want, p1.timeout)
+			if tt.wantSet {
+				assert.Equal(t, []string{testRef("p2").ID()}, mergeOrigins.Get("timeout"))
+			} else {
+				assert.False(t, mergeOrigins.IsSet())
+			}
+		})
+	}
+}
+
+func TestDeepMergeField(t *testing.T) {
+	p1Headers := map[string]string{"a": "p1", "b": "p1"}
+	p2Headers := map[string]string{"b": "p2", "c": "p2"}
+
+	tests := []struct {
+		name     string
+		strategy MergeStrategy
+		want     map[string]string
+	}{
+		{name: "augmented shallow", strategy: AugmentedShallowMerge, want: p1Headers},
+		{name: "overridable shallow", strategy: OverridableShallowMerge, want: p2Headers},
+		{name: "augmented deep", strategy: AugmentedDeepMerge, want: map[string]string{"a": "p1", "b": "p1", "c": "p2"}},
+		{name: "overridable deep", strategy: OverridableDeepMerge, want: map[string]string{"a": "p1", "b": "p2", "c": "p2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p1 := &testPolicy{headers: p1Headers}
+			p2 := &testPolicy{headers: p2Headers}
+
+			DeepMergeField(p1, p2, testRef("p2"), nil, MergeOptions{Strategy: tt.strategy}, ir.MergeOrigins{}, headersAccessor, "headers", mergeHeaders)
+
+			assert.Equal(t, tt.want, p1.headers)
+			assert.Equal(t, map[string]string{"a": "p1", "b": "p1"}, p1Headers, "the merged maps must not be modified")
+		})
+	}
+}
+
+func TestAppendListField(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy MergeStrategy
+		p1       []string
+		want     []string
+		origins  []string
+	}{
+		{name: "augmented shallow", strategy: AugmentedShallowMerge, p1: []string{"p1"}, want: []string{"p1"}},
+		{name: "overridable shallow", strategy: OverridableShallowMerge, p1: []string{"p1"}, want: []string{"p2"}, origins: []string{"p2"}},
+		{name: "augmented deep", strategy: AugmentedDeepMerge, p1: []string{"p1"}, want: []string{"p1", "p2"}, origins: []string{"p1", "p2"}},
+		{name: "overridable deep", strategy: OverridableDeepMerge, p1: []string{"p1"}, want: []string{"p2", "p1"}, origins: []string{"p1", "p2"}},
+		{name: "deep with unset p1", strategy: AugmentedDeepMerge, want: []string{"p2"}, origins: []string{"p2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p1 := &testPolicy{hosts: tt.p1}
+			p2 := &testPolicy{hosts: []string{"p2"}}
+			mergeOrigins := ir.MergeOrigins{}
+			if tt.p1 != nil {
+				mergeOrigins.SetOne("hosts", testRef("p1"), nil)
+			}
+
+			AppendListField(p1, p2, testRef("p2"), nil, MergeOptions{Strategy: tt.strategy}, mergeOrigins, hostsAccessor, "hosts")
+
+			assert.Equal(t, tt.want, p1.hosts)
+			var wantOrigins []string
+			for _, name := range tt.origins {
+				wantOrigins = append(wantOrigins, testRef(name).ID())
+			}
+			if wantOrigins == nil && tt.p1 != nil {
+				wantOrigins = []string{testRef("p1").ID()}
+			}
+			assert.ElementsMatch(t, wantOrigins, mergeOrigins.Get("hosts"))
+		})
+	}
+}
+
+func TestMergePoliciesWithFieldHelpers(t *testing.T) {
+	gk := schema.GroupKind{Group: "test.io", Kind: "TestPolicy"}
+	child := ir.PolicyAtt{
+		GroupKind: gk,
+		PolicyRef: testRef("child"),
+		PolicyIr: &testPolicy{
+			hosts:   []string{"child.example.com"},
+			headers: map[string]string{"x-level": "child"},
+		},
+	}
+	parent := ir.PolicyAtt{
+		GroupKind:               gk,
+		PolicyRef:               testRef("parent"),
+		HierarchicalPriority:    -1,
+		InheritedPolicyPriority: apiannotations.DeepMergePreferChild,
+		PolicyIr: &testPolicy{
+			timeout: new(10),
+			hosts:   []string{"parent.example.com"},
+			headers: map[string]string{"x-level": "parent", "x-parent": "true"},
+		},
+	}
+	grandparent := ir.PolicyAtt{
+		GroupKind:               gk,
+		PolicyRef:               testRef("grandparent"),
+		HierarchicalPriority:    -2,
+		InheritedPolicyPriority: apiannotations.ShallowMergePreferChild,
+		PolicyIr: &testPolicy{
+			timeout: new(20),
+			headers: map[string]string{"x-level": "grandparent"},
+		},
+	}
+
+	t.Run("fields are merged by precedence", func(t *testing.T) {
+		merged := MergePolicies(
+			[]ir.PolicyAtt{grandparent, child, parent},
+			mergeTestPolicies,
+			"",
+		)
+		require.Empty(t, merged.Errors)
+
+		out := merged.PolicyIr.(*testPolicy)
+		// the child does not set a timeout, so the parent's applies before the grandparent's
+		assert.Equal(t, new(10), out.timeout)
+		// the parent prefers a deep merge with the child
+		assert.Equal(t, []string{"child.example.com", "parent.example.com"}, out.hosts)
+		assert.Equal(t, map[string]string{"x-level": "child", "x-parent": "true"}, out.headers)
+
+		// the merge origins are what the status syncer uses to report the attachment state
+		assert.Equal(t, []string{testRef("parent").ID()}, merged.MergeOrigins.Get("timeout"))
+		assert.ElementsMatch(t, []string{testRef("child").ID(), testRef("parent").ID()}, merged.MergeOrigins.Get("hosts"))
+		assert.Equal(t, ir.MergeOriginsRefCountAll, merged.MergeOrigins.GetRefCount(testRef("parent")))
+		assert.Equal(t, ir.MergeOriginsRefCountPartial, merged.MergeOrigins.GetRefCount(testRef("child")))
+		assert.Equal(t, ir.MergeOriginsRefCountNone, merged.MergeOrigins.GetRefCount(testRef("grandparent")))
+	})
+
+	t.Run("parent overrides the child", func(t *testing.T) {
+		overriding := grandparent
+		overriding.InheritedPolicyPriority = apiannotations.ShallowMergePreferParent
+		merged := MergePolicies(
+			[]ir.PolicyAtt{child, parent, overriding},
+			mergeTestPolicies,
+			"",
+		)
+
+		out := merged.PolicyIr.(*testPolicy)
+		assert.Equal(t, new(20), out.timeout)
+		assert.Equal(t, map[string]string{"x-level": "grandparent"}, out.headers)
+		assert.Equal(t, []string{testRef("grandparent").ID()}, merged.MergeOrigins.Get("timeout"))
+		assert.Equal(t, []string{testRef("grandparent").ID()}, merged.MergeOrigins.Get("headers"))
+	})
+
+	t.Run("policies with errors are not merged", func(t *testing.T) {
+		invalid := parent
+		invalid.Errors = []error{assert.AnError}
+		merged := MergePolicies(
+			[]ir.PolicyAtt{child, invalid, grandparent},
+			mergeTestPolicies,
+			"",
+		)
+
+		out := merged.PolicyIr.(*testPolicy)
+		assert.Equal(t, new(20), out.timeout)
+		assert.Equal(t, []string{"child.example.com"}, out.hosts)
+		assert.Equal(t, []error{assert.AnError}, merged.Errors)
+		assert.Equal(t, ir.MergeOriginsRefCountNone, merged.MergeOrigins.GetRefCount(testRef("parent")))
+	})
+}
@@ -19,6 +19,19 @@ const (
 
 	PolicyOverriddenMsg = "Overridden due to conflict with higher priority policy in target(s)"
 
+	PolicyTargetNotFoundMsg = "Target not found"
+
+	PolicyConflictedMsg = "Ignored fields that overlap with route filters"
+
+	RouteConflictedMsg = "Route configuration takes precedence over backend policy fields"
+
+	// RouteConditionConflicted is set on a route parent while fields of policies attached to the
+	// backends of the route are overridden by capabilities the route configures natively.
+	RouteConditionConflicted = "Conflicted"
+
+	// RouteReasonBackendPolicyOverlap is used with the Conflicted=True condition on a route parent.
+	RouteReasonBackendPolicyOverlap = "BackendPolicyOverlap"
+
 	// RouteRuleDroppedReason is used with the Accepted=False condition when the route rule is dropped.
 	RouteRuleDroppedReason = "RouteRuleDropped"
 
@@ -103,6 +116,12 @@ type AncestorRefReporter interface {
 	SetAttachmentState(
 		state PolicyAttachmentState,
 	)
+	// AddOverridingPolicies records the IDs of the policies that took precedence
+	// over this one when it was overridden.
+	AddOverridingPolicies(policyIDs ...string)
+	// AddConflicts records descriptions of the policy fields that were ignored because
+	// a targeted route configures the same capability natively.
+	AddConflicts(conflicts ...string)
 }
 
 type PolicyReporter interface {
@@ -142,4 +161,27 @@ type RouteReporter interface {
 
 type ParentRefReporter interface {
 	SetCondition(condition RouteCondition)
+	// AddInvalidRule records a rule of the route that was replaced with a direct response. The invalid
+	// rules are reported once the route is translated: as the PartiallyInvalid condition while other
+	// rules of the route remain valid, or with Accepted=False when all of them are invalid.
+	AddInvalidRule(rule InvalidRule)
+	// AddConflicts records descriptions of the fields of backend policies that are overridden by
+	// capabilities the route configures natively. They are reported as the Conflicted condition.
+	AddConflicts(conflicts ...string)
+	// AddWarnings records descriptions of route configuration that was accepted but doesn't behave
+	// as specified. They are appended to the message of the Accepted=True condition.
+	AddWarnings(warnings ...string)
+}
+
+// InvalidRule is a route rule that was replaced with a direct response.
+type InvalidRule struct {
+	// Index is the index of the rule in the route.
+	Index int
+	// RuleCount is the number of rules of the route.
+	RuleCount int
+	// Reason describes why the rule is invalid.
+	Reason string
+	// UnresolvedRefs is set when the rule is only invalid because none of its backendRefs resolve.
+	// This is reported through the ResolvedRefs condition, so it never causes the route to be rejected.
+	UnresolvedRefs bool
 }
@@ -0,0 +1,146 @@
+// Package testing is a golden file test harness for the translation of gateways. It loads
+// YAML fixtures (gateways, routes, services, policies...) in a fake cluster, translates them
+// with the kgateway plugins and the plugins under test, and compares the resulting xDS and
+// statuses against golden files. It lets out-of-tree plugins test their translation the way
+// kgateway tests its own.
+package testing
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	gotesting "testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiserverschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
+	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient"
+	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient/fake"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/envutils"
+	"github.com/kgateway-dev/kgateway/v2/test/testutils"
+	"github.com/kgateway-dev/kgateway/v2/test/translator"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of the translation tests with the actual output")
+
+// updateGoldens returns whether the golden files are rewritten rather than compared against,
+// with the -update flag or the REFRESH_GOLDEN environment variable used by the kgateway tests.
+func updateGoldens() bool {
+	return *update || envutils.IsEnvTruthy("REFRESH_GOLDEN")
+}
+
+// PluginsFn returns the plugins under test, translated along with the kgateway plugins.
+type PluginsFn func(ctx context.Context, commoncol *collections.CommonCollections, mergeSettingsJSON string) []pluginsdk.Plugin
+
+// Output is the output of the translation of a gateway, as written to its golden file.
+type Output = translator.TranslationOutput
+
+// Harness translates YAML fixtures and compares the output against golden files.
+// The zero value translates the fixtures with the kgateway plugins only.
+type Harness struct {
+	// Plugins returns the plugins under test.
+	Plugins PluginsFn
+	// Schemes add the types of the resources of the plugins under test to the scheme used
+	// to decode the fixtures.
+	Schemes runtime.SchemeBuilder
+	// GVRs are the resources of the plugins under test, served by the fake cluster.
+	GVRs []schema.GroupVersionResource
+	// CRDDirs are the directories of the CRDs used to default and prune the fixtures.
+	// Defaults to the CRDs of the kgateway chart of the working tree.
+	CRDDirs []string
+	// Settings customize the settings of kgateway.
+	Settings []func(*apisettings.Settings)
+	// Secrets and ConfigMaps are served by the fake cluster along with the fixtures.
+	Secrets    []*corev1.Secret
+	ConfigMaps []*corev1.ConfigMap
+	// ConditionTime is the LastTransitionTime of the conditions of the statuses in the output.
+	// Defaults to the zero time.
+	ConditionTime time.Time
+}
+
+// Translate translates the fixtures and returns the output of the gateway, with the secret
+// material redacted.
+func (h Harness) Translate(t *gotesting.T, gwNN types.NamespacedName, fixtures ...string) *Output {
+	t.Helper()
+	r := require.New(t)
+
+	var gvkToStructuralSchema map[schema.GroupVersionKind]*apiserverschema.Structural
+	for _, dir := range h.CRDDirs {
+		schemas, err := testutils.GetStructuralSchemas(dir)
+		r.NoErrorf(err, "error loading the CRDs of %s", dir)
+		if gvkToStructuralSchema == nil {
+			gvkToStructuralSchema = schemas
+			continue
+		}
+		for gvk, s := range schemas {
+			gvkToStructuralSchema[gvk] = s
+		}
+	}
+
+	var objs []client.Object
+	for _, secret := range h.Secrets {
+		objs = append(objs, secret.DeepCopy())
+	}
+	for _, cm := range h.ConfigMaps {
+		objs = append(objs, cm.DeepCopy())
+	}
+
+	var settingsOpts []translator.SettingsOpts
+	for _, opt := range h.Settings {
+		settingsOpts = append(settingsOpts, opt)
+	}
+
+	tc := translator.TestCase{
+		InputFiles: fixtures,
+		Objects:    objs,
+	}
+	results, err := tc.Run(t, t.Context(), translator.NewScheme(h.Schemes), translator.ExtraConfig{
+		NewClientFn: func(t *gotesting.T, objs ...client.Object) apiclient.Client {
+			return fake.NewClientWithExtraGVRs(t, h.GVRs, objs...)
+		},
+		PluginsFn:             translator.ExtraPluginsFn(h.Plugins),
+		Schemes:               h.Schemes,
+		GVKToStructuralSchema: gvkToStructuralSchema,
+	}, settingsOpts...)
+	r.NoError(err, "error translating the fixtures")
+	r.Containsf(results, gwNN, "gateway %s not found in the fixtures", gwNN)
+
+	out := translator.NewTranslationOutput(results[gwNN], h.ConditionTime)
+	r.NoError(redact(out), "error redacting the output")
+	return out
+}
+
+// Run translates the fixtures and compares the output of the gateway against the golden file.
+// When the -update flag or the REFRESH_GOLDEN environment variable is set, the golden file is
+// rewritten with the output instead.
+func (h Harness) Run(t *gotesting.T, gwNN types.NamespacedName, golden string, fixtures ...string) {
+	t.Helper()
+	r := require.New(t)
+
+	out := h.Translate(t, gwNN, fixtures...)
+	outYaml, err := testutils.MarshalAnyYaml(out)
+	r.NoError(err, "error marshaling the output to YAML")
+
+	if updateGoldens() {
+		r.NoError(os.MkdirAll(filepath.Dir(golden), 0o755))
+		t.Log("updating golden file", golden)
+		r.NoError(os.WriteFile(golden, outYaml, 0o644)) //nolint:gosec // G306: Golden test file can be readable
+		return
+	}
+
+	expected := &Output{}
+	r.NoErrorf(translator.ReadYamlFile(golden, expected), "error reading golden file %s, run the test with -update to create it", golden)
+	if diff := expected.Diff(out); diff != "" {
+		t.Fatalf("unexpected output for golden file %s, run the test with -update to rewrite it:\n%s\nactual output:\n%s", golden, diff, outYaml)
+	}
+}
@@ -0,0 +1,104 @@
+package testing
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	gotesting "testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kgateway-dev/kgateway/v2/test/testutils"
+)
+
+var gwNN = types.NamespacedName{Namespace: "default", Name: "example-gateway"}
+
+func httpsSecret(t *gotesting.T) *corev1.Secret {
+	t.Helper()
+	crt, err := os.ReadFile("testdata/tls.crt")
+	require.NoError(t, err)
+	key, err := os.ReadFile("testdata/tls.key")
+	require.NoError(t, err)
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "https"},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       crt,
+			corev1.TLSPrivateKeyKey: key,
+		},
+	}
+}
+
+func setUpdate(t *gotesting.T, value bool) {
+	t.Helper()
+	t.Setenv("REFRESH_GOLDEN", "")
+	prev := *update
+	*update = value
+	t.Cleanup(func() { *update = prev })
+}
+
+func TestRoundTrip(t *gotesting.T) {
+	setUpdate(t, false)
+	Harness{}.Run(t, gwNN, "testdata/golden/route.yaml", "testdata/route.yaml")
+
+	expected := &Output{}
+	require.NoError(t, testutils.UnmarshalAnyYaml(mustReadFile(t, "testdata/golden/route.yaml"), expected))
+	actual := Harness{}.Translate(t, gwNN, "testdata/route.yaml")
+	assert.Empty(t, expected.Diff(actual))
+
+	actual.Listeners[0].Name = "renamed"
+	assert.Contains(t, expected.Diff(actual), "renamed", "the diff should show the changed listener")
+}
+
+func TestUpdateRewritesGolden(t *gotesting.T) {
+	golden := filepath.Join(t.TempDir(), "golden", "route.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(golden), 0o755))
+	require.NoError(t, os.WriteFile(golden, []byte("Listeners: []\n"), 0o644))
+
+	setUpdate(t, true)
+	Harness{}.Run(t, gwNN, golden, "testdata/route.yaml")
+	assert.Equal(t, string(mustReadFile(t, "testdata/golden/route.yaml")), string(mustReadFile(t, golden)))
+
+	*update = false
+	Harness{}.Run(t, gwNN, golden, "testdata/route.yaml")
+}
+
+func TestRedactsSecrets(t *gotesting.T) {
+	setUpdate(t, false)
+	secret := httpsSecret(t)
+	h := Harness{Secrets: []*corev1.Secret{secret}}
+	h.Run(t, gwNN, "testdata/golden/tls.yaml", "testdata/tls.yaml")
+
+	out, err := testutils.MarshalAnyYaml(h.Translate(t, gwNN, "testdata/tls.yaml"))
+	require.NoError(t, err)
+	key := secret.Data[corev1.TLSPrivateKeyKey]
+	assert.NotContains(t, string(out), base64.StdEncoding.EncodeToString(key))
+	assert.NotContains(t, string(out), string(key))
+	assert.Contains(t, string(out), Redacted)
+	// the certificate is not secret
+	assert.Contains(t, string(out), base64.StdEncoding.EncodeToString(secret.Data[corev1.TLSCertKey]))
+}
+
+func TestConditionTime(t *gotesting.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	out := Harness{ConditionTime: now}.Translate(t, gwNN, "testdata/route.yaml")
+
+	status := out.Statuses.Gateways[gwNN.String()]
+	require.NotNil(t, status)
+	require.NotEmpty(t, status.Conditions)
+	for _, c := range status.Conditions {
+		assert.True(t, now.Equal(c.LastTransitionTime.Time), "condition %s", c.Type)
+	}
+}
+
+func mustReadFile(t *gotesting.T, file string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	return data
+}
@@ -0,0 +1,137 @@
+package testing
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Redacted replaces the secret material in the output.
+const Redacted = "[redacted]"
+
+// redactedFields are the fields of the envoy config holding secret material.
+var redactedFields = sets.New[protoreflect.FullName](
+	"envoy.extensions.transport_sockets.tls.v3.TlsCertificate.private_key",
+	"envoy.extensions.transport_sockets.tls.v3.TlsCertificate.password",
+	"envoy.extensions.transport_sockets.tls.v3.GenericSecret.secret",
+	"envoy.extensions.transport_sockets.tls.v3.TlsSessionTicketKeys.keys",
+	"envoy.extensions.filters.http.basic_auth.v3.BasicAuth.users",
+	"envoy.extensions.filters.http.api_key_auth.v3.Credential.key",
+)
+
+// redact replaces the secret material of the output with Redacted, so that golden files
+// never hold private keys or credentials, including in the configs packed in Any messages.
+func redact(out *Output) error {
+	var msgs []proto.Message
+	for _, m := range out.Listeners {
+		msgs = append(msgs, m)
+	}
+	for _, m := range out.Routes {
+		msgs = append(msgs, m)
+	}
+	for _, m := range out.Clusters {
+		msgs = append(msgs, m)
+	}
+	for _, m := range out.ExtraClusters {
+		msgs = append(msgs, m)
+	}
+	for _, m := range out.Secrets {
+		msgs = append(msgs, m)
+	}
+	for _, m := range msgs {
+		if _, err := redactMessage(m.ProtoReflect()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// redactMessage redacts the message in place and returns whether it changed.
+func redactMessage(m protoreflect.Message) (bool, error) {
+	if a, ok := m.Interface().(*anypb.Any); ok {
+		return redactAny(a)
+	}
+
+	var (
+		changed bool
+		err     error
+	)
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if redactedFields.Has(fd.FullName()) {
+			redactField(m, fd)
+			changed = true
+			return true
+		}
+		var c bool
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				return true
+			}
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				c, err = redactMessage(mv.Message())
+				changed = changed || c
+				return err == nil
+			})
+		case fd.IsList():
+			if fd.Message() == nil {
+				return true
+			}
+			for i := 0; i < v.List().Len() && err == nil; i++ {
+				c, err = redactMessage(v.List().Get(i).Message())
+				changed = changed || c
+			}
+		case fd.Message() != nil:
+			c, err = redactMessage(v.Message())
+			changed = changed || c
+		}
+		return err == nil
+	})
+	return changed, err
+}
+
+// redactAny redacts the message packed in the Any, and packs it back when it changed.
+// Messages of unknown types are left as is.
+func redactAny(a *anypb.Any) (bool, error) {
+	m, err := a.UnmarshalNew()
+	if err != nil {
+		return false, nil
+	}
+	changed, err := redactMessage(m.ProtoReflect())
+	if err != nil || !changed {
+		return false, err
+	}
+	return true, a.MarshalFrom(m)
+}
+
+// redactField replaces the value of a field holding secret material. The strings and bytes
+// are replaced with Redacted, and each message (e.g. DataSource) with one holding Redacted in
+// its inline string.
+func redactField(m protoreflect.Message, fd protoreflect.FieldDescriptor) {
+	redacted := func(fd protoreflect.FieldDescriptor) protoreflect.Value {
+		switch fd.Kind() {
+		case protoreflect.StringKind:
+			return protoreflect.ValueOfString(Redacted)
+		case protoreflect.BytesKind:
+			return protoreflect.ValueOfBytes([]byte(Redacted))
+		}
+		msg := m.NewField(fd)
+		if fd.IsList() {
+			msg = m.Get(fd).List().NewElement()
+		}
+		if inline := msg.Message().Descriptor().Fields().ByName("inline_string"); inline != nil {
+			msg.Message().Set(inline, protoreflect.ValueOfString(Redacted))
+		}
+		return msg
+	}
+
+	if fd.IsList() {
+		list := m.Mutable(fd).List()
+		for i := range list.Len() {
+			list.Set(i, redacted(fd))
+		}
+		return
+	}
+	m.Set(fd, redacted(fd))
+}
@@ -4,14 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"time"
 
 	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	"istio.io/istio/pkg/kube/controllers"
 	"istio.io/istio/pkg/kube/krt"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/endpoints"
@@ -82,6 +85,48 @@ type BackendPlugin struct {
 	Endpoints  krt.Collection[ir.EndpointsForBackend]
 }
 
+// BackendResolver resolves the Backends with the type `plugin/<name>`, where name is the name the
+// resolver is registered with.
+type BackendResolver interface {
+	// Resolve is called when a Backend of the resolver is created or its plugin config changes.
+	// The returned cluster settings are merged into the envoy cluster of the Backend, which gets its
+	// endpoints through EDS, starting with the returned endpoints.
+	// Until ctx is done, which happens when the Backend is deleted or resolved again, the resolver
+	// can send the updated endpoints of the Backend to updates. Each update replaces all the endpoints.
+	// A returned error is reported on the status of the Backend, which then has no endpoints.
+	// Resolve is called while the Backends are translated, and must not block.
+	Resolve(ctx context.Context, req BackendResolveRequest, updates chan<- []BackendEndpoint) (*BackendResolution, error)
+}
+
+// BackendResolveRequest is the Backend resolved by a BackendResolver.
+type BackendResolveRequest struct {
+	// Backend is the namespace and name of the Backend.
+	Backend types.NamespacedName
+	// Config is the raw JSON of the plugin config of the Backend, if any.
+	Config []byte
+}
+
+// BackendResolution is the result of the resolution of a Backend.
+type BackendResolution struct {
+	// Cluster optionally configures the envoy cluster of the Backend. Its name, discovery type and
+	// load assignment are ignored.
+	Cluster *envoyclusterv3.Cluster
+	// Endpoints are the initial endpoints of the Backend.
+	Endpoints []BackendEndpoint
+}
+
+// BackendEndpoint is an endpoint of a Backend resolved by a BackendResolver.
+type BackendEndpoint struct {
+	// Address is the IP address of the endpoint.
+	Address string
+	// Port is the port of the endpoint.
+	Port uint32
+	// Weight is the load balancing weight of the endpoint. Defaults to 1.
+	Weight uint32
+	// Locality is the locality of the endpoint, used for locality aware load balancing.
+	Locality ir.PodLocality
+}
+
 type KGwTranslator interface {
 	// This function is called by the reconciler when a K8s Gateway resource is created or updated.
 	// It returns an instance of the kgateway Proxy resource, that should configure a target kgateway Proxy workload.
@@ -94,16 +139,109 @@ type KGwTranslator interface {
 type (
 	GwTranslatorFactory func(gw *gwv1.Gateway) KGwTranslator
 	ContributesPolicies map[schema.GroupKind]PolicyPlugin
+	// DeployerMutator mutates in place the objects rendered by the deployer for a Gateway, after the
+	// overlays of its GatewayParameters are applied and before the objects are applied to the cluster.
+	// An error fails the deployment of the Gateway and is reported on its status.
+	DeployerMutator func(gw *gwv1.Gateway, objs []client.Object) error
 )
 
+// DeployerChartFragment contributes values and templates to the helm chart the objects of every Gateway
+// are rendered with. The objects rendered by the templates are deployed along with the ones of the chart,
+// and the overlays of the GatewayParameters of the Gateway apply to them.
+type DeployerChartFragment struct {
+	// Name is the key the values are serialized under, i.e. the templates access them with
+	// `.Values.plugins.<name>`. It must be unique among the plugins, and be a valid helm values key
+	// (letters, digits and underscores, starting with a letter).
+	Name string
+	// Values returns the values of the fragment for the Gateway. They must be serializable to JSON.
+	// An error fails the deployment of the Gateway.
+	// The values are only set by the default helm values generator.
+	Values func(gw *gwv1.Gateway) (any, error)
+	// Templates are the chart templates of the fragment, by file name.
+	Templates map[string][]byte
+}
+
+// HealthCheck is a named check of the health of a plugin, run on every probe of the
+// endpoint it feeds. Its result is recorded in the kgateway_plugin_health metric.
+type HealthCheck struct {
+	// Name identifies the check in the probe errors and the metric.
+	Name string
+	// Check returns an error while the plugin is unhealthy. Its context is canceled once
+	// the timeout expires.
+	Check func(ctx context.Context) error
+	// Timeout bounds the duration of the check, which fails if it doesn't return in time.
+	// Defaults to 5s.
+	Timeout time.Duration
+}
+
+// ClusterMetadataContributor attaches metadata to the clusters generated for the backends,
+// e.g. for access logs or upstream filters to identify the owner of a backend.
+type ClusterMetadataContributor struct {
+	// Name identifies the plugin. The namespaces of its metadata are prefixed with
+	// `plugins.<name>.` so that they don't collide with the ones of other plugins or of envoy.
+	Name string
+	// Metadata returns the filter metadata (untyped) and typed filter metadata of the cluster
+	// generated for the backend, by namespace. It may return nil to attach no metadata.
+	Metadata func(backend ir.BackendObjectIR) *envoycorev3.Metadata
+}
+
+// RouteAttachment is a route attaching to a listener of a Gateway or ListenerSet.
+type RouteAttachment struct {
+	// Route is the attaching route, e.g. an HTTPRoute or a TCPRoute.
+	Route ir.Route
+	// Parent is the Gateway or ListenerSet of the listener.
+	Parent client.Object
+	// Listener is the listener the route attaches to.
+	Listener gwv1.Listener
+}
+
+// RouteAttachmentVeto rejects the attachment of a route to a listener.
+type RouteAttachmentVeto struct {
+	// Reason is the reason of the Accepted=False condition set on the route.
+	Reason gwv1.RouteConditionReason
+	// Message is the message of the condition.
+	Message string
+}
+
+// RouteAttachmentCheck decides whether a route may attach to a listener, for rules that
+// the allowedRoutes of the listener cannot express, e.g. a lookup in a tenant registry.
+type RouteAttachmentCheck struct {
+	// Name identifies the check in logs.
+	Name string
+	// Check is called for every listener that a route is allowed to attach to, and returns a veto
+	// to reject the attachment or nil to allow it. A vetoed route is not counted in the attached
+	// routes of the listener.
+	// Check is called during translation and must be fast. Its result is cached until the generation
+	// of the route or of the parent changes, so it must only depend on the attachment.
+	// If Check panics, the attachment is rejected and Check is called again on the next translation.
+	Check func(ctx context.Context, attachment RouteAttachment) *RouteAttachmentVeto
+}
+
 type Plugin struct {
 	ContributesPolicies     ContributesPolicies
 	ContributesBackends     map[schema.GroupKind]BackendPlugin
 	ContributesGwTranslator GwTranslatorFactory
+	// ContributesBackendResolvers resolve the Backends with the type `plugin/<name>`, by name.
+	ContributesBackendResolvers map[string]BackendResolver
 	// ContributesLeaderAction is a lifecycle hook called after all collections are synced
 	// allowing Plugins to register handlers against collections, e.g. for status reporting
 	// This is executed only on a leader pod.
 	ContributesLeaderAction map[schema.GroupKind]func()
+	// ContributesDeployerMutators are called in order on the objects deployed for every Gateway.
+	// The mutators of the plugins are called in the order the plugins are registered.
+	ContributesDeployerMutators []DeployerMutator
+	// ContributesDeployerChartFragments are added to the chart the objects of every Gateway are rendered with.
+	ContributesDeployerChartFragments []DeployerChartFragment
+	// ContributesReadinessChecks hold the control plane not ready while they fail, e.g. until
+	// the plugin fetched the config it translates its policies with.
+	ContributesReadinessChecks []HealthCheck
+	// ContributesLivenessChecks fail the liveness endpoint of the control plane while they fail.
+	// Only use them for failures that restarting the control plane recovers from.
+	ContributesLivenessChecks []HealthCheck
+	// ContributesClusterMetadata attach metadata to the cluster generated for every backend.
+	ContributesClusterMetadata []ClusterMetadataContributor
+	// ContributesRouteAttachmentChecks can veto the attachment of routes to listeners.
+	ContributesRouteAttachmentChecks []RouteAttachmentCheck
 	// extra has sync beyond primary resources in the collections above
 	ExtraHasSynced func() bool
 }
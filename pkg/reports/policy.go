@@ -9,6 +9,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
@@ -16,9 +17,16 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/reporter"
 )
 
+// maxPolicyAncestors is the maximum number of ancestors allowed in a PolicyStatus
+const maxPolicyAncestors = 16
+
 type AncestorRefReport struct {
 	Conditions      []metav1.Condition
 	AttachmentState reporter.PolicyAttachmentState
+	// OverriddenBy holds the IDs of the policies that overrode this one
+	OverriddenBy sets.Set[string]
+	// Conflicts holds descriptions of the fields ignored due to overlapping route filters
+	Conflicts sets.Set[string]
 }
 
 type PolicyReport struct {
@@ -46,6 +54,20 @@ func (prr *AncestorRefReport) SetAttachmentState(
 	prr.AttachmentState |= state
 }
 
+func (prr *AncestorRefReport) AddOverridingPolicies(policyIDs ...string) {
+	if prr.OverriddenBy == nil {
+		prr.OverriddenBy = sets.New[string]()
+	}
+	prr.OverriddenBy.Insert(policyIDs...)
+}
+
+func (prr *AncestorRefReport) AddConflicts(conflicts ...string) {
+	if prr.Conflicts == nil {
+		prr.Conflicts = sets.New[string]()
+	}
+	prr.Conflicts.Insert(conflicts...)
+}
+
 func (r *statusReporter) Policy(key reporter.PolicyKey, observedGeneration int64) reporter.PolicyReporter {
 	pr := r.report.policy(key)
 	if pr == nil {
@@ -138,6 +160,7 @@ func (r *ReportMap) BuildPolicyStatus(
 
 		// Build and append the Attached Condition.Type
 		existingConditions := addAttachmentCondition(parentStatusReport)
+		existingConditions = addConflictedCondition(parentStatusReport, existingConditions)
 
 		finalConditions := make([]metav1.Condition, 0, len(existingConditions))
 		for _, pCondition := range existingConditions {
@@ -152,6 +175,10 @@ func (r *ReportMap) BuildPolicyStatus(
 		// If there are conditions on the route that are not owned by our reporter, include
 		// them in the final list of conditions to preserve conditions we do not own
 		for _, condition := range currentParentRefConditions {
+			// the Conflicted condition is owned by our reporter and is only set while conflicts exist
+			if condition.Type == string(shared.PolicyConditionConflicted) {
+				continue
+			}
 			if meta.FindStatusCondition(finalConditions, condition.Type) == nil {
 				finalConditions = append(finalConditions, condition)
 			}
@@ -176,14 +203,32 @@ func (r *ReportMap) BuildPolicyStatus(
 	// sort all parents for consistency with Equals and for Update
 	// match sorting semantics of istio/istio, see:
 	// https://github.com/istio/istio/blob/6dcaa0206bcaf20e3e3b4e45e9376f0f96365571/pilot/pkg/config/kube/gateway/conditions.go#L188-L193
-	slices.SortStableFunc(status.Ancestors, func(a, b gwv1.PolicyAncestorStatus) int {
-		return strings.Compare(ParentString(a.AncestorRef), ParentString(b.AncestorRef))
-	})
-
-	// TODO: ensure status.Ancestors is bounded by the max allowed limit, currently 16
-	if len(status.Ancestors) > 15 {
-		ignored := status.Ancestors[15:]
-		status.Ancestors = status.Ancestors[:15]
+	sortAncestors := func(ancestors []gwv1.PolicyAncestorStatus) {
+		slices.SortStableFunc(ancestors, func(a, b gwv1.PolicyAncestorStatus) int {
+			return strings.Compare(ParentString(a.AncestorRef), ParentString(b.AncestorRef))
+		})
+	}
+	sortAncestors(status.Ancestors)
+
+	// Bound status.Ancestors to the max allowed limit. Entries owned by other controllers are
+	// never evicted; our own entries are evicted in reverse sort order so the retained set is
+	// deterministic, and the last slot is replaced with a summary of how many were dropped.
+	if len(status.Ancestors) > maxPolicyAncestors {
+		var owned, others []gwv1.PolicyAncestorStatus
+		for _, ancestor := range status.Ancestors {
+			if ancestor.ControllerName == gwv1.GatewayController(controller) {
+				owned = append(owned, ancestor)
+			} else {
+				others = append(others, ancestor)
+			}
+		}
+		keep := max(maxPolicyAncestors-len(others)-1, 0)
+		ignored := owned[keep:]
+		status.Ancestors = append(others, owned[:keep]...)
+		sortAncestors(status.Ancestors)
+		if len(status.Ancestors) >= maxPolicyAncestors {
+			return &status
+		}
 		status.Ancestors = append(status.Ancestors, gwv1.PolicyAncestorStatus{
 			AncestorRef: gwv1.ParentReference{
 				Group: ptr.To(gwv1.Group("gateway.kgateway.dev")),
@@ -246,11 +291,15 @@ func addAttachmentCondition(report *AncestorRefReport) []metav1.Condition {
 
 	switch {
 	case report.AttachmentState.Has(reporter.PolicyAttachmentStateOverridden):
+		message := reporter.PolicyOverriddenMsg
+		if report.OverriddenBy.Len() > 0 {
+			message = fmt.Sprintf("%s: %s", message, strings.Join(sets.List(report.OverriddenBy), ", "))
+		}
 		meta.SetStatusCondition(&existing, metav1.Condition{
 			Type:    string(shared.PolicyConditionAttached),
 			Status:  metav1.ConditionFalse,
 			Reason:  string(shared.PolicyReasonOverridden),
-			Message: reporter.PolicyOverriddenMsg,
+			Message: message,
 		})
 
 	case report.AttachmentState.Has(reporter.PolicyAttachmentStateMerged):
@@ -272,3 +321,21 @@ func addAttachmentCondition(report *AncestorRefReport) []metav1.Condition {
 
 	return existing
 }
+
+// addConflictedCondition adds the Conflicted condition to the given conditions when fields of
+// the policy were ignored because they overlap with filters configured natively on a route.
+func addConflictedCondition(report *AncestorRefReport, conditions []metav1.Condition) []metav1.Condition {
+	if report.Conflicts.Len() == 0 {
+		return conditions
+	}
+
+	// avoid modifying the existing Conditions on the report
+	existing := slices.Clone(conditions)
+	meta.SetStatusCondition(&existing, metav1.Condition{
+		Type:    string(shared.PolicyConditionConflicted),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(shared.PolicyReasonRouteFilterOverlap),
+		Message: fmt.Sprintf("%s: %s", reporter.PolicyConflictedMsg, strings.Join(sets.List(report.Conflicts), "; ")),
+	})
+	return existing
+}
@@ -1,11 +1,15 @@
 package reports
 
 import (
+	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -453,3 +457,169 @@ func TestPolicyStatusReport(t *testing.T) {
 		})
 	}
 }
+
+func TestPolicyStatusReportPerAncestor(t *testing.T) {
+	key := reporter.PolicyKey{
+		Group:     "gateway.kgateway.dev",
+		Kind:      "TrafficPolicy",
+		Namespace: "default",
+		Name:      "policy",
+	}
+	gatewayRef := func(name string) gwv1.ParentReference {
+		return gwv1.ParentReference{
+			Group:     ptr.To(gwv1.Group("gateway.networking.k8s.io")),
+			Kind:      ptr.To(gwv1.Kind("Gateway")),
+			Namespace: ptr.To(gwv1.Namespace("default")),
+			Name:      gwv1.ObjectName(name),
+		}
+	}
+	accepted := func(r reporter.AncestorRefReporter) {
+		r.SetCondition(reporter.PolicyCondition{
+			Type:    string(shared.PolicyConditionAccepted),
+			Status:  metav1.ConditionTrue,
+			Reason:  string(shared.PolicyReasonValid),
+			Message: reporter.PolicyAcceptedMsg,
+		})
+	}
+
+	rm := NewReportMap()
+	pr := NewReporter(&rm).Policy(key, 1)
+
+	attached := pr.AncestorRef(gatewayRef("gw-1"))
+	accepted(attached)
+	attached.SetAttachmentState(reporter.PolicyAttachmentStateAttached)
+
+	overridden := pr.AncestorRef(gatewayRef("gw-2"))
+	accepted(overridden)
+	overridden.SetAttachmentState(reporter.PolicyAttachmentStateOverridden)
+	overridden.AddOverridingPolicies("gateway.kgateway.dev/TrafficPolicy/default/winner-b")
+	overridden.AddOverridingPolicies("gateway.kgateway.dev/TrafficPolicy/default/winner-a", "gateway.kgateway.dev/TrafficPolicy/default/winner-b")
+
+	status := rm.BuildPolicyStatus(t.Context(), key, "example-controller", gwv1.PolicyStatus{})
+	require.Len(t, status.Ancestors, 2)
+
+	assert.Equal(t, gatewayRef("gw-1"), status.Ancestors[0].AncestorRef)
+	cond := meta.FindStatusCondition(status.Ancestors[0].Conditions, string(shared.PolicyConditionAttached))
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, string(shared.PolicyReasonAttached), cond.Reason)
+
+	assert.Equal(t, gatewayRef("gw-2"), status.Ancestors[1].AncestorRef)
+	cond = meta.FindStatusCondition(status.Ancestors[1].Conditions, string(shared.PolicyConditionAccepted))
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	cond = meta.FindStatusCondition(status.Ancestors[1].Conditions, string(shared.PolicyConditionAttached))
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, string(shared.PolicyReasonOverridden), cond.Reason)
+	assert.Equal(t, reporter.PolicyOverriddenMsg+": gateway.kgateway.dev/TrafficPolicy/default/winner-a, gateway.kgateway.dev/TrafficPolicy/default/winner-b", cond.Message)
+}
+
+func TestPolicyStatusConflicted(t *testing.T) {
+	key := reporter.PolicyKey{
+		Group:     "gateway.kgateway.dev",
+		Kind:      "TrafficPolicy",
+		Namespace: "default",
+		Name:      "policy",
+	}
+	gatewayRef := gwv1.ParentReference{
+		Group:     ptr.To(gwv1.Group("gateway.networking.k8s.io")),
+		Kind:      ptr.To(gwv1.Kind("Gateway")),
+		Namespace: ptr.To(gwv1.Namespace("default")),
+		Name:      "gw",
+	}
+
+	rm := NewReportMap()
+	r := NewReporter(&rm).Policy(key, 1).AncestorRef(gatewayRef)
+	r.SetAttachmentState(reporter.PolicyAttachmentStateAttached)
+	r.AddConflicts("timeouts.request overlaps with the timeouts on HTTPRoute default/b rule 0")
+	r.AddConflicts("cors overlaps with the CORS filter on HTTPRoute default/a rule 1")
+
+	status := rm.BuildPolicyStatus(t.Context(), key, "example-controller", gwv1.PolicyStatus{})
+	require.Len(t, status.Ancestors, 1)
+	cond := meta.FindStatusCondition(status.Ancestors[0].Conditions, string(shared.PolicyConditionConflicted))
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, string(shared.PolicyReasonRouteFilterOverlap), cond.Reason)
+	assert.Equal(t, reporter.PolicyConflictedMsg+": cors overlaps with the CORS filter on HTTPRoute default/a rule 1; "+
+		"timeouts.request overlaps with the timeouts on HTTPRoute default/b rule 0", cond.Message)
+
+	// the condition is dropped from the existing status once the conflicts are resolved
+	rm = NewReportMap()
+	NewReporter(&rm).Policy(key, 1).AncestorRef(gatewayRef).SetAttachmentState(reporter.PolicyAttachmentStateAttached)
+	status = rm.BuildPolicyStatus(t.Context(), key, "example-controller", *status)
+	require.Len(t, status.Ancestors, 1)
+	assert.Nil(t, meta.FindStatusCondition(status.Ancestors[0].Conditions, string(shared.PolicyConditionConflicted)))
+}
+
+func TestPolicyStatusAncestorLimit(t *testing.T) {
+	key := reporter.PolicyKey{
+		Group:     "gateway.kgateway.dev",
+		Kind:      "TrafficPolicy",
+		Namespace: "default",
+		Name:      "policy",
+	}
+	gatewayRef := func(name string) gwv1.ParentReference {
+		return gwv1.ParentReference{
+			Group:     ptr.To(gwv1.Group("gateway.networking.k8s.io")),
+			Kind:      ptr.To(gwv1.Kind("Gateway")),
+			Namespace: ptr.To(gwv1.Namespace("default")),
+			Name:      gwv1.ObjectName(name),
+		}
+	}
+	build := func(names []string, currentStatus gwv1.PolicyStatus) *gwv1.PolicyStatus {
+		rm := NewReportMap()
+		pr := NewReporter(&rm).Policy(key, 1)
+		for _, name := range names {
+			pr.AncestorRef(gatewayRef(name))
+		}
+		return rm.BuildPolicyStatus(t.Context(), key, "example-controller", currentStatus)
+	}
+	ancestorRefs := func(status *gwv1.PolicyStatus) []gwv1.ParentReference {
+		refs := make([]gwv1.ParentReference, 0, len(status.Ancestors))
+		for _, ancestor := range status.Ancestors {
+			refs = append(refs, ancestor.AncestorRef)
+		}
+		return refs
+	}
+	names := make([]string, 0, 20)
+	for i := range 20 {
+		names = append(names, fmt.Sprintf("gw-%02d", i))
+	}
+
+	t.Run("evicts the last ancestors in sort order", func(t *testing.T) {
+		status := build(names, gwv1.PolicyStatus{})
+		require.Len(t, status.Ancestors, 16)
+		for i, ancestor := range status.Ancestors[:15] {
+			assert.Equal(t, gatewayRef(names[i]), ancestor.AncestorRef)
+		}
+		summary := status.Ancestors[15]
+		assert.Equal(t, gwv1.ObjectName("StatusSummary"), summary.AncestorRef.Name)
+		assert.Equal(t, "5 AncestorRefs ignored due to max status size", summary.Conditions[0].Message)
+
+		// the retained set does not depend on the order ancestors were reported in
+		reversed := slices.Clone(names)
+		slices.Reverse(reversed)
+		assert.Equal(t, ancestorRefs(status), ancestorRefs(build(reversed, gwv1.PolicyStatus{})))
+	})
+
+	t.Run("keeps ancestors of other controllers", func(t *testing.T) {
+		other := gwv1.PolicyAncestorStatus{
+			AncestorRef:    gatewayRef("gw-other"),
+			ControllerName: "other-controller",
+			Conditions: []metav1.Condition{{
+				Type:   string(shared.PolicyConditionAccepted),
+				Status: metav1.ConditionTrue,
+				Reason: string(shared.PolicyReasonValid),
+			}},
+		}
+		status := build(names, gwv1.PolicyStatus{Ancestors: []gwv1.PolicyAncestorStatus{other}})
+		require.Len(t, status.Ancestors, 16)
+		for i, ancestor := range status.Ancestors[:14] {
+			assert.Equal(t, gatewayRef(names[i]), ancestor.AncestorRef)
+		}
+		assert.Equal(t, other.AncestorRef, status.Ancestors[14].AncestorRef)
+		assert.Equal(t, other.ControllerName, status.Ancestors[14].ControllerName)
+		assert.Equal(t, "6 AncestorRefs ignored due to max status size", status.Ancestors[15].Conditions[0].Message)
+	})
+}
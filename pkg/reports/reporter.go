@@ -8,6 +8,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -51,6 +52,12 @@ type RouteReport struct {
 // TODO: rename to e.g. RouteParentRefReport
 type ParentRefReport struct {
 	Conditions []metav1.Condition
+	// invalidRules are the rules replaced with a direct response, by rule index
+	invalidRules map[int]reporter.InvalidRule
+	// conflicts holds descriptions of the backend policy fields overridden by the route
+	conflicts sets.Set[string]
+	// warnings holds descriptions of accepted route configuration that doesn't behave as specified
+	warnings sets.Set[string]
 }
 
 type ParentRefKey struct {
@@ -446,6 +453,31 @@ func (prr *ParentRefReport) SetCondition(rc reporter.RouteCondition) {
 	meta.SetStatusCondition(&prr.Conditions, condition)
 }
 
+// AddInvalidRule records the rule as invalid. A rule translated once per match or hostname is
+// recorded with the reason of its first failure.
+func (prr *ParentRefReport) AddInvalidRule(rule reporter.InvalidRule) {
+	if prr.invalidRules == nil {
+		prr.invalidRules = make(map[int]reporter.InvalidRule)
+	}
+	if _, ok := prr.invalidRules[rule.Index]; !ok {
+		prr.invalidRules[rule.Index] = rule
+	}
+}
+
+func (prr *ParentRefReport) AddConflicts(conflicts ...string) {
+	if prr.conflicts == nil {
+		prr.conflicts = sets.New[string]()
+	}
+	prr.conflicts.Insert(conflicts...)
+}
+
+func (prr *ParentRefReport) AddWarnings(warnings ...string) {
+	if prr.warnings == nil {
+		prr.warnings = sets.New[string]()
+	}
+	prr.warnings.Insert(warnings...)
+}
+
 func NewReporter(reportMap *ReportMap) reporter.Reporter {
 	return &statusReporter{
 		report: reportMap,
@@ -14,6 +14,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	apiannotations "github.com/kgateway-dev/kgateway/v2/api/annotations"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/reporter"
 	"github.com/kgateway-dev/kgateway/v2/pkg/reports"
@@ -144,6 +145,59 @@ var _ = Describe("Reporting Infrastructure", func() {
 			Expect(meta.FindStatusCondition(status.Conditions, string(gwv1.GatewayConditionInsecureFrontendValidationMode))).To(BeNil())
 		})
 
+		It("should set the draining condition when the gateway is annotated to be drained", func() {
+			gw := gw()
+			gw.Annotations = map[string]string{string(apiannotations.Drain): "true"}
+
+			rm := reports.NewReportMap()
+			reporter := reports.NewReporter(&rm)
+			reporter.Gateway(gw)
+
+			status := rm.BuildGWStatus(context.Background(), *gw, nil)
+
+			Expect(status).NotTo(BeNil())
+			condition := meta.FindStatusCondition(status.Conditions, string(reports.GatewayConditionDraining))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(string(reports.GatewayReasonDraining)))
+		})
+
+		It("should reject invalid values of the drain annotation", func() {
+			gw := gw()
+			gw.Annotations = map[string]string{string(apiannotations.Drain): "yes please"}
+
+			rm := reports.NewReportMap()
+			reporter := reports.NewReporter(&rm)
+			reporter.Gateway(gw)
+
+			status := rm.BuildGWStatus(context.Background(), *gw, nil)
+
+			Expect(status).NotTo(BeNil())
+			condition := meta.FindStatusCondition(status.Conditions, string(reports.GatewayConditionDraining))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(string(reports.GatewayReasonInvalidDrainAnnotation)))
+		})
+
+		It("should remove the draining condition when the drain annotation is removed", func() {
+			gw := gw()
+			gw.Status.Conditions = append(gw.Status.Conditions, metav1.Condition{
+				Type:   string(reports.GatewayConditionDraining),
+				Status: metav1.ConditionTrue,
+				Reason: string(reports.GatewayReasonDraining),
+			})
+			gw.Annotations = map[string]string{string(apiannotations.Drain): "false"}
+
+			rm := reports.NewReportMap()
+			reporter := reports.NewReporter(&rm)
+			reporter.Gateway(gw)
+
+			status := rm.BuildGWStatus(context.Background(), *gw, nil)
+
+			Expect(status).NotTo(BeNil())
+			Expect(meta.FindStatusCondition(status.Conditions, string(reports.GatewayConditionDraining))).To(BeNil())
+		})
+
 		It("should preserve controller-managed invalid parameters accepted conditions", func() {
 			gw := gw()
 			gw.Status.Conditions = append(gw.Status.Conditions, metav1.Condition{
@@ -165,6 +219,27 @@ var _ = Describe("Reporting Infrastructure", func() {
 			Expect(condition.Reason).To(Equal(string(gwv1.GatewayReasonInvalidParameters)))
 		})
 
+		It("should preserve controller-managed no ready replicas programmed conditions", func() {
+			gw := gw()
+			gw.Status.Conditions = append(gw.Status.Conditions, metav1.Condition{
+				Type:   string(gwv1.GatewayConditionProgrammed),
+				Status: metav1.ConditionFalse,
+				Reason: string(reports.GatewayReasonNoReadyReplicas),
+			})
+
+			rm := reports.NewReportMap()
+			reporter := reports.NewReporter(&rm)
+			reporter.Gateway(gw)
+
+			status := rm.BuildGWStatus(context.Background(), *gw, nil)
+
+			Expect(status).NotTo(BeNil())
+			condition := meta.FindStatusCondition(status.Conditions, string(gwv1.GatewayConditionProgrammed))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(string(reports.GatewayReasonNoReadyReplicas)))
+		})
+
 		It("should correctly set negative gateway conditions from report and not add extra conditions", func() {
 			gw := gw()
 			rm := reports.NewReportMap()
@@ -445,6 +520,168 @@ var _ = Describe("Reporting Infrastructure", func() {
 			Entry("delegatee route", delegateeRoute(), parentRouteRef()),
 		)
 
+		Describe("reporting invalid rules", func() {
+			It("should report a single invalid rule among many as PartiallyInvalid", func() {
+				rm := reports.NewReportMap()
+				r := reports.NewReporter(&rm)
+				obj := httpRoute()
+				r.Route(obj).ParentRef(parentRef()).AddInvalidRule(reporter.InvalidRule{
+					Index:          2,
+					RuleCount:      10,
+					Reason:         "Service default/missing not found",
+					UnresolvedRefs: true,
+				})
+
+				status := rm.BuildRouteStatus(context.Background(), obj, wellknown.DefaultGatewayControllerName)
+
+				Expect(status.Parents).To(HaveLen(1))
+				accepted := meta.FindStatusCondition(status.Parents[0].Conditions, string(gwv1.RouteConditionAccepted))
+				Expect(accepted.Status).To(Equal(metav1.ConditionTrue))
+				partiallyInvalid := meta.FindStatusCondition(status.Parents[0].Conditions, string(gwv1.RouteConditionPartiallyInvalid))
+				Expect(partiallyInvalid).NotTo(BeNil())
+				Expect(partiallyInvalid.Status).To(Equal(metav1.ConditionTrue))
+				Expect(partiallyInvalid.Reason).To(Equal(string(gwv1.RouteReasonUnsupportedValue)))
+				Expect(partiallyInvalid.Message).To(Equal("Dropped Rule (2): Service default/missing not found"))
+			})
+
+			It("should list multiple invalid rules in order, once per rule", func() {
+				rm := reports.NewReportMap()
+				r := reports.NewReporter(&rm)
+				obj := httpRoute()
+				prr := r.Route(obj).ParentRef(parentRef())
+				prr.AddInvalidRule(reporter.InvalidRule{Index: 4, RuleCount: 5, Reason: "policy not found"})
+				prr.AddInvalidRule(reporter.InvalidRule{Index: 1, RuleCount: 5, Reason: "Service default/a not found", UnresolvedRefs: true})
+				// the same rule is translated once per match
+				prr.AddInvalidRule(reporter.InvalidRule{Index: 4, RuleCount: 5, Reason: "invalid regex"})
+
+				status := rm.BuildRouteStatus(context.Background(), obj, wellknown.DefaultGatewayControllerName)
+
+				partiallyInvalid := meta.FindStatusCondition(status.Parents[0].Conditions, string(gwv1.RouteConditionPartiallyInvalid))
+				Expect(partiallyInvalid).NotTo(BeNil())
+				Expect(partiallyInvalid.Message).To(Equal(
+					"Dropped Rule (1): Service default/a not found; Dropped Rule (4): policy not found"))
+			})
+
+			It("should not accept a route when all its rules are invalid", func() {
+				rm := reports.NewReportMap()
+				r := reports.NewReporter(&rm)
+				obj := httpRoute()
+				prr := r.Route(obj).ParentRef(parentRef())
+				prr.AddInvalidRule(reporter.InvalidRule{Index: 0, RuleCount: 2, Reason: "policy not found"})
+				prr.AddInvalidRule(reporter.InvalidRule{Index: 1, RuleCount: 2, Reason: "Service default/a not found", UnresolvedRefs: true})
+
+				status := rm.BuildRouteStatus(context.Background(), obj, wellknown.DefaultGatewayControllerName)
+
+				accepted := meta.FindStatusCondition(status.Parents[0].Conditions, string(gwv1.RouteConditionAccepted))
+				Expect(accepted.Status).To(Equal(metav1.ConditionFalse))
+				Expect(accepted.Reason).To(Equal(reporter.RouteRuleReplacedReason))
+				Expect(accepted.Message).To(Equal(
+					"Replaced Rule (0): policy not found; Replaced Rule (1): Service default/a not found"))
+				Expect(meta.FindStatusCondition(status.Parents[0].Conditions, string(gwv1.RouteConditionPartiallyInvalid))).To(BeNil())
+			})
+
+			It("should accept a route when all its rules only have unresolved backendRefs", func() {
+				rm := reports.NewReportMap()
+				r := reports.NewReporter(&rm)
+				obj := httpRoute()
+				prr := r.Route(obj).ParentRef(parentRef())
+				prr.SetCondition(reporter.RouteCondition{
+					Type:   gwv1.RouteConditionResolvedRefs,
+					Status: metav1.ConditionFalse,
+					Reason: gwv1.RouteReasonBackendNotFound,
+				})
+				prr.AddInvalidRule(reporter.InvalidRule{Index: 0, RuleCount: 1, Reason: "Service default/a not found", UnresolvedRefs: true})
+
+				status := rm.BuildRouteStatus(context.Background(), obj, wellknown.DefaultGatewayControllerName)
+
+				accepted := meta.FindStatusCondition(status.Parents[0].Conditions, string(gwv1.RouteConditionAccepted))
+				Expect(accepted.Status).To(Equal(metav1.ConditionTrue))
+				resolvedRefs := meta.FindStatusCondition(status.Parents[0].Conditions, string(gwv1.RouteConditionResolvedRefs))
+				Expect(resolvedRefs.Status).To(Equal(metav1.ConditionFalse))
+			})
+		})
+
+		Describe("reporting conflicts", func() {
+			It("should list the overridden backend policy fields in the Conflicted condition", func() {
+				rm := reports.NewReportMap()
+				r := reports.NewReporter(&rm)
+				obj := httpRoute()
+				prr := r.Route(obj).ParentRef(parentRef())
+				prr.AddConflicts("loadBalancer.ringHash of BackendConfigPolicy default/b is overridden by the session persistence of rule 1")
+				prr.AddConflicts("loadBalancer.maglev of BackendConfigPolicy default/a is overridden by the session persistence of rule 0")
+				prr.AddConflicts("loadBalancer.ringHash of BackendConfigPolicy default/b is overridden by the session persistence of rule 1")
+
+				status := rm.BuildRouteStatus(context.Background(), obj, wellknown.DefaultGatewayControllerName)
+
+				conflicted := meta.FindStatusCondition(status.Parents[0].Conditions, reporter.RouteConditionConflicted)
+				Expect(conflicted).NotTo(BeNil())
+				Expect(conflicted.Status).To(Equal(metav1.ConditionTrue))
+				Expect(conflicted.Reason).To(Equal(reporter.RouteReasonBackendPolicyOverlap))
+				Expect(conflicted.Message).To(Equal(reporter.RouteConflictedMsg +
+					": loadBalancer.maglev of BackendConfigPolicy default/a is overridden by the session persistence of rule 0" +
+					"; loadBalancer.ringHash of BackendConfigPolicy default/b is overridden by the session persistence of rule 1"))
+				accepted := meta.FindStatusCondition(status.Parents[0].Conditions, string(gwv1.RouteConditionAccepted))
+				Expect(accepted.Status).To(Equal(metav1.ConditionTrue))
+			})
+
+			It("should remove the Conflicted condition once the conflicts are resolved", func() {
+				rm := reports.NewReportMap()
+				r := reports.NewReporter(&rm)
+				obj := httpRoute(metav1.Condition{
+					Type:   reporter.RouteConditionConflicted,
+					Status: metav1.ConditionTrue,
+					Reason: reporter.RouteReasonBackendPolicyOverlap,
+				})
+				r.Route(obj).ParentRef(parentRef())
+
+				status := rm.BuildRouteStatus(context.Background(), obj, wellknown.DefaultGatewayControllerName)
+
+				Expect(meta.FindStatusCondition(status.Parents[0].Conditions, reporter.RouteConditionConflicted)).To(BeNil())
+			})
+		})
+
+		Describe("reporting warnings", func() {
+			It("should append the warnings to the Accepted condition", func() {
+				rm := reports.NewReportMap()
+				r := reports.NewReporter(&rm)
+				obj := httpRoute()
+				prr := r.Route(obj).ParentRef(parentRef())
+				prr.AddWarnings("all backendRefs of rule 1 have weight 0, so traffic is distributed evenly among them")
+				prr.AddWarnings("all backendRefs of rule 0 have weight 0, so traffic is distributed evenly among them")
+				// the same rule is translated once per match
+				prr.AddWarnings("all backendRefs of rule 1 have weight 0, so traffic is distributed evenly among them")
+
+				status := rm.BuildRouteStatus(context.Background(), obj, wellknown.DefaultGatewayControllerName)
+
+				accepted := meta.FindStatusCondition(status.Parents[0].Conditions, string(gwv1.RouteConditionAccepted))
+				Expect(accepted.Status).To(Equal(metav1.ConditionTrue))
+				Expect(accepted.Reason).To(Equal(string(gwv1.RouteReasonAccepted)))
+				Expect(accepted.Message).To(Equal(reports.RouteAcceptedMessage + " with warnings: " +
+					"all backendRefs of rule 0 have weight 0, so traffic is distributed evenly among them" +
+					"; all backendRefs of rule 1 have weight 0, so traffic is distributed evenly among them"))
+			})
+
+			It("should not override a route that isn't accepted", func() {
+				rm := reports.NewReportMap()
+				r := reports.NewReporter(&rm)
+				obj := httpRoute()
+				prr := r.Route(obj).ParentRef(parentRef())
+				prr.AddWarnings("all backendRefs of rule 0 have weight 0, so traffic is distributed evenly among them")
+				prr.SetCondition(reporter.RouteCondition{
+					Type:    gwv1.RouteConditionAccepted,
+					Status:  metav1.ConditionFalse,
+					Reason:  gwv1.RouteReasonNotAllowedByListeners,
+					Message: "not allowed",
+				})
+
+				status := rm.BuildRouteStatus(context.Background(), obj, wellknown.DefaultGatewayControllerName)
+
+				accepted := meta.FindStatusCondition(status.Parents[0].Conditions, string(gwv1.RouteConditionAccepted))
+				Expect(accepted.Status).To(Equal(metav1.ConditionFalse))
+				Expect(accepted.Message).To(Equal("not allowed"))
+			})
+		})
+
 		DescribeTable("should not modify LastTransitionTime for existing conditions that have not changed",
 			func(obj client.Object) {
 				rm := reports.NewReportMap()
@@ -11,11 +11,14 @@ import (
 	"istio.io/istio/pkg/slices"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
+	apiannotations "github.com/kgateway-dev/kgateway/v2/api/annotations"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/translator/utils"
+	kgwutils "github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/reporter"
 )
@@ -33,6 +36,24 @@ const (
 	ListenerProgrammedMessage      = "Successfully programmed Listener"
 	RouteAcceptedMessage           = "Successfully accepted Route"
 	GatewayClassAcceptedMessage    = "GatewayClass accepted by kgateway controller"
+	GatewayDrainingMessage         = "Listeners are closing their connections, the proxies can be deleted once their " +
+		"active downstream connections reach zero"
+)
+
+const (
+	// GatewayConditionDraining is set on Gateways annotated to be drained.
+	GatewayConditionDraining gwv1.GatewayConditionType = "Draining"
+
+	// GatewayReasonDraining is used with the Draining=True condition while the gateway is drained.
+	GatewayReasonDraining gwv1.GatewayConditionReason = "Draining"
+
+	// GatewayReasonInvalidDrainAnnotation is used with the Draining=False condition when the drain
+	// annotation isn't a boolean.
+	GatewayReasonInvalidDrainAnnotation gwv1.GatewayConditionReason = "InvalidDrainAnnotation"
+
+	// GatewayReasonNoReadyReplicas is used with the Programmed=False condition set by the Gateway controller
+	// while the proxy Deployment it manages has no ready replicas.
+	GatewayReasonNoReadyReplicas gwv1.GatewayConditionReason = "NoReadyReplicas"
 )
 
 // TODO: refactor this struct + methods to better reflect the usage now in proxy_syncer
@@ -102,6 +123,7 @@ func (r *ReportMap) BuildGWStatus(ctx context.Context, gw gwv1.Gateway, attached
 
 	handleInvalidAddresses(gwReport, &gw)
 	handleInsecureFrontendValidationMode(gwReport, &gw)
+	handleDrain(gwReport, &gw)
 
 	addMissingGatewayConditions(r.Gateway(&gw), &gw)
 
@@ -171,6 +193,26 @@ func handleInsecureFrontendValidationMode(report *GatewayReport, g *gwv1.Gateway
 	})
 }
 
+func handleDrain(report *GatewayReport, g *gwv1.Gateway) {
+	draining, err := kgwutils.IsDraining(g)
+	switch {
+	case err != nil:
+		report.SetCondition(reporter.GatewayCondition{
+			Type:    GatewayConditionDraining,
+			Status:  metav1.ConditionFalse,
+			Reason:  GatewayReasonInvalidDrainAnnotation,
+			Message: fmt.Sprintf("invalid value of annotation %s: %v", apiannotations.Drain, err),
+		})
+	case draining:
+		report.SetCondition(reporter.GatewayCondition{
+			Type:    GatewayConditionDraining,
+			Status:  metav1.ConditionTrue,
+			Reason:  GatewayReasonDraining,
+			Message: GatewayDrainingMessage,
+		})
+	}
+}
+
 func gatewayUsesInsecureFrontendValidationMode(g *gwv1.Gateway) bool {
 	if g == nil || g.Spec.TLS == nil || g.Spec.TLS.Frontend == nil {
 		return false
@@ -197,7 +239,8 @@ func isReporterOwnedGatewayConditionType(conditionType gwv1.GatewayConditionType
 	switch conditionType {
 	case gwv1.GatewayConditionAccepted,
 		gwv1.GatewayConditionProgrammed,
-		gwv1.GatewayConditionInsecureFrontendValidationMode:
+		gwv1.GatewayConditionInsecureFrontendValidationMode,
+		GatewayConditionDraining:
 		return true
 	default:
 		return false
@@ -214,6 +257,9 @@ func shouldPreserveGatewayCondition(condition metav1.Condition, finalConditions
 		condition.Reason == string(gwv1.GatewayReasonInvalidParameters) {
 		return true
 	}
+	if isNoReadyReplicasCondition(condition) {
+		return true
+	}
 
 	return !isReporterOwnedGatewayConditionType(gwv1.GatewayConditionType(condition.Type))
 }
@@ -421,6 +467,8 @@ func (r *ReportMap) BuildRouteStatusWithParentRefDefaulting(
 			// probably because it's a parent that we don't control (e.g. Gateway from diff. controller)
 			continue
 		}
+		setInvalidRuleConditions(parentStatusReport)
+		setConflictedCondition(parentStatusReport)
 		addMissingParentRefConditions(parentStatusReport)
 
 		// Get the status of the current parentRef conditions if they exist
@@ -445,6 +493,10 @@ func (r *ReportMap) BuildRouteStatusWithParentRefDefaulting(
 		// If there are conditions on the route that are not owned by our reporter, include
 		// them in the final list of conditions to preseve conditions we do not own
 		for _, condition := range currentParentRefConditions {
+			// the Conflicted condition is owned by our reporter and is only set while conflicts exist
+			if condition.Type == reporter.RouteConditionConflicted {
+				continue
+			}
 			if meta.FindStatusCondition(finalConditions, condition.Type) == nil {
 				finalConditions = append(finalConditions, condition)
 			}
@@ -527,7 +579,11 @@ func addMissingGatewayConditions(gwReport *GatewayReport, gw *gwv1.Gateway) {
 			Message: GatewayAcceptedMessage,
 		})
 	}
-	if cond := meta.FindStatusCondition(gwReport.GetConditions(), string(gwv1.GatewayConditionProgrammed)); cond == nil {
+	// Likewise, the controller sets Programmed=False with Reason=NoReadyReplicas while the proxy has no ready replicas,
+	// and Programmed=True once it does.
+	existingProgrammed := meta.FindStatusCondition(gw.Status.Conditions, string(gwv1.GatewayConditionProgrammed))
+	noReadyReplicas := existingProgrammed != nil && isNoReadyReplicasCondition(*existingProgrammed)
+	if !noReadyReplicas && meta.FindStatusCondition(gwReport.GetConditions(), string(gwv1.GatewayConditionProgrammed)) == nil {
 		gwReport.SetCondition(reporter.GatewayCondition{
 			Type:    gwv1.GatewayConditionProgrammed,
 			Status:  metav1.ConditionTrue,
@@ -537,6 +593,12 @@ func addMissingGatewayConditions(gwReport *GatewayReport, gw *gwv1.Gateway) {
 	}
 }
 
+func isNoReadyReplicasCondition(condition metav1.Condition) bool {
+	return condition.Type == string(gwv1.GatewayConditionProgrammed) &&
+		condition.Status == metav1.ConditionFalse &&
+		condition.Reason == string(GatewayReasonNoReadyReplicas)
+}
+
 // Reports will initially only contain negative conditions found during translation,
 // so all missing conditions are assumed to be positive. Here we will add all missing conditions
 // to a given report, i.e. set healthy conditions
@@ -598,16 +660,84 @@ func AddMissingListenerConditions(lisReport *ListenerReport) {
 	}
 }
 
+// setInvalidRuleConditions reports the rules of a route that were replaced with a direct response.
+// While other rules of the route remain valid, the route is accepted and the invalid rules are listed
+// in the PartiallyInvalid condition. Once all rules are invalid, the route is no longer accepted,
+// unless the rules are only invalid because their backendRefs don't resolve, which is reported
+// through the ResolvedRefs condition instead.
+func setInvalidRuleConditions(report *ParentRefReport) {
+	if len(report.invalidRules) == 0 {
+		return
+	}
+	var ruleCount int
+	rules := make([]reporter.InvalidRule, 0, len(report.invalidRules))
+	for _, rule := range report.invalidRules {
+		rules = append(rules, rule)
+		ruleCount = max(ruleCount, rule.RuleCount)
+	}
+	rules = slices.SortBy(rules, func(r reporter.InvalidRule) int { return r.Index })
+
+	if len(rules) < ruleCount {
+		report.SetCondition(reporter.RouteCondition{
+			Type:    gwv1.RouteConditionPartiallyInvalid,
+			Status:  metav1.ConditionTrue,
+			Reason:  gwv1.RouteReasonUnsupportedValue,
+			Message: invalidRulesMessage("Dropped Rule", rules),
+		})
+		return
+	}
+	if slices.IndexFunc(rules, func(r reporter.InvalidRule) bool { return !r.UnresolvedRefs }) < 0 {
+		return
+	}
+	if cond := meta.FindStatusCondition(report.Conditions, string(gwv1.RouteConditionAccepted)); cond != nil && cond.Status == metav1.ConditionFalse {
+		return
+	}
+	report.SetCondition(reporter.RouteCondition{
+		Type:    gwv1.RouteConditionAccepted,
+		Status:  metav1.ConditionFalse,
+		Reason:  gwv1.RouteConditionReason(reporter.RouteRuleReplacedReason),
+		Message: invalidRulesMessage("Replaced Rule", rules),
+	})
+}
+
+// setConflictedCondition sets the Conflicted condition when fields of the policies attached to the
+// backends of the route are overridden by capabilities the route configures natively.
+func setConflictedCondition(report *ParentRefReport) {
+	if report.conflicts.Len() == 0 {
+		return
+	}
+	report.SetCondition(reporter.RouteCondition{
+		Type:    reporter.RouteConditionConflicted,
+		Status:  metav1.ConditionTrue,
+		Reason:  reporter.RouteReasonBackendPolicyOverlap,
+		Message: fmt.Sprintf("%s: %s", reporter.RouteConflictedMsg, strings.Join(sets.List(report.conflicts), "; ")),
+	})
+}
+
+// invalidRulesMessage lists the invalid rules and their reasons, e.g.
+// "Dropped Rule (1): Service default/a not found; Dropped Rule (3): Service default/b not found".
+func invalidRulesMessage(prefix string, rules []reporter.InvalidRule) string {
+	msgs := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		msgs = append(msgs, fmt.Sprintf("%s (%d): %s", prefix, rule.Index, rule.Reason))
+	}
+	return strings.Join(msgs, "; ")
+}
+
 // Reports will initially only contain negative conditions found during translation,
 // so all missing conditions are assumed to be positive. Here we will add all missing conditions
 // to a given report, i.e. set healthy conditions
 func addMissingParentRefConditions(report *ParentRefReport) {
 	if cond := meta.FindStatusCondition(report.Conditions, string(gwv1.RouteConditionAccepted)); cond == nil {
+		message := RouteAcceptedMessage
+		if report.warnings.Len() > 0 {
+			message = fmt.Sprintf("%s with warnings: %s", RouteAcceptedMessage, strings.Join(sets.List(report.warnings), "; "))
+		}
 		report.SetCondition(reporter.RouteCondition{
 			Type:    gwv1.RouteConditionAccepted,
 			Status:  metav1.ConditionTrue,
 			Reason:  gwv1.RouteReasonAccepted,
-			Message: RouteAcceptedMessage,
+			Message: message,
 		})
 	}
 	if cond := meta.FindStatusCondition(report.Conditions, string(gwv1.RouteConditionResolvedRefs)); cond == nil {
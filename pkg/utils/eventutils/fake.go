@@ -0,0 +1,52 @@
+package eventutils
+
+import (
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// Event is an event captured by a FakeRecorder.
+type Event struct {
+	InvolvedObject corev1.ObjectReference
+	Type           string
+	Reason         string
+	Message        string
+}
+
+// FakeRecorder is an EventRecorder that captures the events it receives, including their involved object,
+// for use in tests.
+type FakeRecorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+var _ record.EventRecorder = &FakeRecorder{}
+
+// Events returns the events captured so far.
+func (f *FakeRecorder) Events() []Event {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Event(nil), f.events...)
+}
+
+func (f *FakeRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	event := Event{Type: eventtype, Reason: reason, Message: message}
+	if ref, ok := object.(*corev1.ObjectReference); ok {
+		event.InvolvedObject = *ref
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, event)
+}
+
+func (f *FakeRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...any) {
+	f.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (f *FakeRecorder) AnnotatedEventf(object runtime.Object, _ map[string]string, eventtype, reason, messageFmt string, args ...any) {
+	f.Eventf(object, eventtype, reason, messageFmt, args...)
+}
@@ -0,0 +1,93 @@
+package eventutils
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/tools/reference"
+)
+
+// DefaultInterval is the interval within which a failure is reported at most once per object.
+const DefaultInterval = 5 * time.Minute
+
+// eventKey identifies the failures that are reported as a single event per interval.
+type eventKey struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+	Reason     string
+}
+
+// RateLimitedRecorder emits Warning events for failures, at most once per object and reason within an
+// interval, so that a persistent error doesn't turn into an event storm on every translation or sync.
+// A nil recorder discards all events.
+type RateLimitedRecorder struct {
+	mu       sync.Mutex
+	recorder record.EventRecorder
+	scheme   *runtime.Scheme
+	interval time.Duration
+	now      func() time.Time
+	emitted  map[eventKey]time.Time
+}
+
+// NewRateLimitedRecorder returns a recorder that emits events through recorder, resolving the involved
+// objects with scheme. A non-positive interval disables the rate limiting.
+func NewRateLimitedRecorder(recorder record.EventRecorder, scheme *runtime.Scheme, interval time.Duration) *RateLimitedRecorder {
+	return &RateLimitedRecorder{
+		recorder: recorder,
+		scheme:   scheme,
+		interval: interval,
+		now:      time.Now,
+		emitted:  map[eventKey]time.Time{},
+	}
+}
+
+// Warningf emits a Warning event on obj, unless an event with the same reason was emitted on obj
+// within the interval.
+func (r *RateLimitedRecorder) Warningf(obj runtime.Object, reason, messageFmt string, args ...any) {
+	if r == nil || obj == nil {
+		return
+	}
+	ref, err := reference.GetReference(r.scheme, obj)
+	if err != nil {
+		slog.Debug("unable to reference the object of an event", "reason", reason, "error", err)
+		return
+	}
+	if !r.allow(eventKey{
+		APIVersion: ref.APIVersion,
+		Kind:       ref.Kind,
+		Namespace:  ref.Namespace,
+		Name:       ref.Name,
+		Reason:     reason,
+	}) {
+		return
+	}
+	r.recorder.Event(ref, corev1.EventTypeWarning, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *RateLimitedRecorder) allow(key eventKey) bool {
+	if r.interval <= 0 {
+		return true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if last, ok := r.emitted[key]; ok && now.Sub(last) < r.interval {
+		return false
+	}
+	// drop the expired entries, so deleted objects don't accumulate
+	for k, last := range r.emitted {
+		if now.Sub(last) >= r.interval {
+			delete(r.emitted, k)
+		}
+	}
+	r.emitted[key] = now
+	return true
+}
@@ -0,0 +1,78 @@
+package eventutils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestRateLimitedRecorder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, gwv1.Install(scheme))
+
+	route := &gwv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default"}}
+	gw := &gwv1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"}}
+
+	fake := &FakeRecorder{}
+	r := NewRateLimitedRecorder(fake, scheme, time.Minute)
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	r.Warningf(route, "BackendNotFound", "backend %s not found", "svc")
+	r.Warningf(route, "BackendNotFound", "backend %s not found", "svc")
+	r.Warningf(route, "RefNotPermitted", "reference not permitted")
+	r.Warningf(gw, "BackendNotFound", "backend %s not found", "svc")
+
+	events := fake.Events()
+	require.Len(t, events, 3)
+	assert.Equal(t, Event{
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: gwv1.GroupVersion.String(),
+			Kind:       "HTTPRoute",
+			Namespace:  "default",
+			Name:       "route",
+		},
+		Type:    corev1.EventTypeWarning,
+		Reason:  "BackendNotFound",
+		Message: "backend svc not found",
+	}, events[0])
+	assert.Equal(t, "RefNotPermitted", events[1].Reason)
+	assert.Equal(t, "Gateway", events[2].InvolvedObject.Kind)
+
+	// the failure is reported again once the interval has passed
+	now = now.Add(time.Minute)
+	r.Warningf(route, "BackendNotFound", "backend %s not found", "svc")
+	r.Warningf(route, "BackendNotFound", "backend %s not found", "svc")
+	assert.Len(t, fake.Events(), 4)
+}
+
+func TestRateLimitedRecorderObjectReference(t *testing.T) {
+	fake := &FakeRecorder{}
+	r := NewRateLimitedRecorder(fake, runtime.NewScheme(), time.Minute)
+
+	ref := &corev1.ObjectReference{
+		APIVersion: gwv1.GroupVersion.String(),
+		Kind:       "Gateway",
+		Namespace:  "default",
+		Name:       "gw",
+	}
+	r.Warningf(ref, "Rejected", "rejected")
+	r.Warningf(ref, "Rejected", "rejected")
+
+	events := fake.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, *ref, events[0].InvolvedObject)
+}
+
+func TestRateLimitedRecorderNil(t *testing.T) {
+	var r *RateLimitedRecorder
+	assert.NotPanics(t, func() {
+		r.Warningf(&gwv1.Gateway{}, "Rejected", "rejected")
+	})
+}
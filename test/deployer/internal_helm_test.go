@@ -149,6 +149,34 @@ wIDAQABMA0GCSqGSIb3DQEBCwUAA4IBAQBtestcertdata
 			Name:      "gwparams with stats matcher exclusion",
 			InputFile: "stats-matcher-exclusion",
 		},
+		{
+			Name:      "gwparams with stats extra labels",
+			InputFile: "stats-extra-labels",
+		},
+		{
+			Name:      "gwparams with stats histogram buckets",
+			InputFile: "stats-histogram-buckets",
+		},
+		{
+			Name:      "gwparams with stats disabled metrics",
+			InputFile: "stats-disabled-metrics",
+		},
+		{
+			Name:      "gwparams with stats prefix",
+			InputFile: "stats-prefix",
+		},
+		{
+			Name:      "gwparams with admin listener bound to localhost",
+			InputFile: "admin-localhost",
+		},
+		{
+			Name:      "gwparams with admin listener protected by a token",
+			InputFile: "admin-token",
+		},
+		{
+			Name:      "gwparams with admin listener reload enabled",
+			InputFile: "admin-reload",
+		},
 		{
 			Name:      "envoy-infrastructure",
 			InputFile: "envoy-infrastructure",
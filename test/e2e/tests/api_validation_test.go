@@ -402,6 +402,148 @@ spec:
 `,
 			wantErrors: []string{"targetRefs may only reference Gateway resources"},
 		},
+		{
+			name: "HTTPListenerPolicy: access log sampling percentage above 100",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: HTTPListenerPolicy
+metadata:
+  name: http-listener-policy-sampling-percentage
+spec:
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: Gateway
+    name: test-gateway
+  accessLog:
+  - fileSink:
+      destination: stdout
+      stringFormat: "%REQ(:PATH)%"
+    sampling:
+      percentage: 101
+`,
+			wantErrors: []string{"should be less than or equal to 100"},
+		},
+		{
+			name: "HTTPListenerPolicy: access log sampling with both percentage and rate",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: HTTPListenerPolicy
+metadata:
+  name: http-listener-policy-sampling-percentage-and-rate
+spec:
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: Gateway
+    name: test-gateway
+  accessLog:
+  - fileSink:
+      destination: stdout
+      stringFormat: "%REQ(:PATH)%"
+    sampling:
+      percentage: 10
+      rate:
+        numerator: 1
+        denominator: TEN_THOUSAND
+      alwaysLogErrors: true
+`,
+			wantErrors: []string{"exactly one of the fields in [percentage rate] must be set"},
+		},
+		{
+			name: "HTTPListenerPolicy: B3 tracing propagation with the openTelemetry provider",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: HTTPListenerPolicy
+metadata:
+  name: http-listener-policy-tracing-b3
+spec:
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: Gateway
+    name: test-gateway
+  tracing:
+    provider:
+      openTelemetry:
+        grpcService:
+          backendRef:
+            name: otel-collector
+            port: 4317
+    propagation: B3
+`,
+			wantErrors: []string{"the openTelemetry provider only supports W3C propagation"},
+		},
+		{
+			name: "HTTPListenerPolicy: tracing custom tag with an invalid header name",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: HTTPListenerPolicy
+metadata:
+  name: http-listener-policy-tracing-custom-tags
+spec:
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: Gateway
+    name: test-gateway
+  tracing:
+    provider:
+      openTelemetry:
+        grpcService:
+          backendRef:
+            name: otel-collector
+            port: 4317
+    customTags:
+      request.id: x-request-id
+      user: "x user"
+`,
+			wantErrors: []string{"custom tag header names must be valid HTTP header names"},
+		},
+		{
+			name: "HTTPListenerPolicy: multiple tracing providers",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: HTTPListenerPolicy
+metadata:
+  name: http-listener-policy-tracing-multiple-providers
+spec:
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: Gateway
+    name: test-gateway
+  tracing:
+    provider:
+      openTelemetry:
+        grpcService:
+          backendRef:
+            name: otel-collector
+            port: 4317
+      zipkin:
+        backendRef:
+          name: zipkin
+          port: 9411
+`,
+			wantErrors: []string{"must have at most 1 items"},
+		},
+		{
+			name: "HTTPListenerPolicy: W3C tracing propagation with the zipkin provider",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: HTTPListenerPolicy
+metadata:
+  name: http-listener-policy-tracing-zipkin-w3c
+spec:
+  targetRefs:
+  - group: gateway.networking.k8s.io
+    kind: Gateway
+    name: test-gateway
+  tracing:
+    provider:
+      zipkin:
+        backendRef:
+          name: zipkin
+          port: 9411
+    propagation: W3C
+`,
+			wantErrors: []string{"the zipkin provider only supports B3 and B3AndW3C propagation"},
+		},
 		{
 			name: "DirectResponse: empty body not allowed",
 			input: `---
@@ -499,6 +641,33 @@ spec:
 `,
 			wantErrors: []string{"retry.perTryTimeout must be less than timeouts.request"},
 		},
+		{
+			name: "TrafficPolicy: grpc.timeoutOffset less than grpc.maxTimeout",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: TrafficPolicy
+metadata:
+  name: test
+spec:
+  grpc:
+    maxTimeout: 10s
+    timeoutOffset: 100ms
+`,
+		},
+		{
+			name: "TrafficPolicy: grpc.timeoutOffset must be less than grpc.maxTimeout",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: TrafficPolicy
+metadata:
+  name: test
+spec:
+  grpc:
+    maxTimeout: 1s
+    timeoutOffset: 2s
+`,
+			wantErrors: []string{"timeoutOffset must be less than maxTimeout"},
+		},
 		{
 			name: "TrafficPolicy: retry.perTryTimeout must be at least 1ms",
 			input: `---
@@ -645,6 +814,112 @@ spec:
 `,
 			wantErrors: []string{"maxRequestSize must be greater than 0 and less than 4Gi"},
 		},
+		{
+			name: "GatewayParameters: stats extraLabels with custom labels",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: GatewayParameters
+metadata:
+  name: test-stats-extra-labels
+spec:
+  kube:
+    stats:
+      extraLabels:
+        gateway: gw
+        region: us-east-1
+`,
+		},
+		{
+			name: "GatewayParameters: stats extraLabels rejects reserved label names",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: GatewayParameters
+metadata:
+  name: test-stats-extra-labels-reserved
+spec:
+  kube:
+    stats:
+      extraLabels:
+        envoy_cluster_name: foo
+`,
+			wantErrors: []string{"extraLabels names must not use a reserved label name"},
+		},
+		{
+			name: "GatewayParameters: stats histogramBuckets with custom buckets",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: GatewayParameters
+metadata:
+  name: test-stats-histogram-buckets
+spec:
+  kube:
+    stats:
+      histogramBuckets: ["0.5", "1", "5", "25", "100"]
+`,
+		},
+		{
+			name: "GatewayParameters: stats histogramBuckets rejects non-ascending buckets",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: GatewayParameters
+metadata:
+  name: test-stats-histogram-buckets-order
+spec:
+  kube:
+    stats:
+      histogramBuckets: ["1", "10", "5"]
+`,
+			wantErrors: []string{"histogramBuckets must be in ascending order"},
+		},
+		{
+			name: "GatewayParameters: stats disabledMetrics with exclusion list",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: GatewayParameters
+metadata:
+  name: test-stats-disabled-metrics
+spec:
+  kube:
+    stats:
+      disabledMetrics:
+      - 'vhost\..*\.route\..*'
+      matcher:
+        exclusionList:
+        - prefix: http.
+`,
+		},
+		{
+			name: "GatewayParameters: stats disabledMetrics rejects inclusion list",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: GatewayParameters
+metadata:
+  name: test-stats-disabled-metrics-inclusion
+spec:
+  kube:
+    stats:
+      disabledMetrics:
+      - 'vhost\..*\.route\..*'
+      matcher:
+        inclusionList:
+        - prefix: http.
+`,
+			wantErrors: []string{"disabledMetrics cannot be set with matcher.inclusionList"},
+		},
+		{
+			name: "GatewayParameters: stats prefix rejects invalid metric name prefix",
+			input: `---
+apiVersion: gateway.kgateway.dev/v1alpha1
+kind: GatewayParameters
+metadata:
+  name: test-stats-prefix
+spec:
+  kube:
+    stats:
+      prefix: edge-gw
+`,
+			wantErrors: []string{"spec.kube.stats.prefix in body should match"},
+		},
 		{
 			name: "ProxyDeployment: Strategy is fully fleshed out",
 			input: `---
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	istiokube "istio.io/istio/pkg/kube"
 	"istio.io/istio/pkg/kube/krt"
@@ -118,7 +119,37 @@ func RunController(
 		t.Fatalf("can't listen %v", err)
 	}
 
-	s, err := setup.New(
+	s, err := newSetup(cfg, apiClient, globalSettings, extraPlugins, krtDbg, l, validator, nil)
+	if err != nil {
+		t.Fatalf("error setting up kgateway %v", err)
+	}
+
+	// start kgateway
+	wg.Go(func() {
+		if err := s.Start(ctx); err != nil {
+			t.Errorf("error starting kgateway %v", err)
+		}
+	})
+
+	xdsPort := l.Addr().(*net.TCPAddr).Port
+	t.Logf("running tests, xds port: %v", xdsPort)
+	run(t, ctx, krtDbg, client, xdsPort)
+	t.Logf("controller done. shutting down. xds port: %v", xdsPort)
+}
+
+// newSetup configures kgateway to run against the API server of the rest config. mutateMgrOpts, if set,
+// adjusts the controller manager options.
+func newSetup(
+	cfg *rest.Config,
+	apiClient apiclient.Client,
+	globalSettings *apisettings.Settings,
+	extraPlugins func(ctx context.Context, commoncol *collections.CommonCollections, mergeSettingsJSON string) []pluginsdk.Plugin,
+	krtDbg *krt.DebugHandler,
+	l net.Listener,
+	validator validator.Validator,
+	mutateMgrOpts func(*ctrl.Options),
+) (setup.Server, error) {
+	return setup.New(
 		setup.WithAPIClient(apiClient),
 		setup.WithGlobalSettings(globalSettings),
 		setup.WithRestConfig(cfg),
@@ -127,7 +158,7 @@ func RunController(
 		setup.WithXDSListener(l),
 		setup.WithControllerManagerOptions(
 			func(ctx context.Context) *ctrl.Options {
-				return &ctrl.Options{
+				opts := &ctrl.Options{
 					BaseContext:      func() context.Context { return ctx },
 					Scheme:           runtime.NewScheme(),
 					PprofBindAddress: "127.0.0.1:0",
@@ -142,6 +173,10 @@ func RunController(
 						SkipNameValidation: new(true),
 					},
 				}
+				if mutateMgrOpts != nil {
+					mutateMgrOpts(opts)
+				}
+				return opts
 			}),
 		setup.WithExtraManagerConfig([]func(ctx context.Context, mgr manager.Manager, objectFilter kubetypes.DynamicObjectFilter) error{
 			func(ctx context.Context, mgr manager.Manager, objectFilter kubetypes.DynamicObjectFilter) error {
@@ -150,21 +185,60 @@ func RunController(
 		}...),
 		setup.WithValidator(validator),
 	)
+}
+
+// Replica is a kgateway controller started by StartReplica.
+type Replica struct {
+	// XdsPort is the port the replica serves xDS on.
+	XdsPort int
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Stop shuts the replica down and waits until it released its leader lease.
+func (r *Replica) Stop() {
+	r.cancel()
+	<-r.done
+}
+
+// StartReplica starts a kgateway controller against the API server of the rest config, electing its leader
+// on the lease of the given name in the default namespace like the replicas of a Deployment do. The lease
+// is released on shutdown. The replica is stopped when the test ends.
+func StartReplica(t *testing.T, cfg *rest.Config, globalSettings *apisettings.Settings, leaseName string) *Replica {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("can't listen %v", err)
+	}
+	s, err := newSetup(cfg, nil, globalSettings, nil, new(krt.DebugHandler), l, nil, func(opts *ctrl.Options) {
+		opts.LeaderElection = true
+		opts.LeaderElectionNamespace = "default"
+		opts.LeaderElectionID = leaseName
+		opts.LeaderElectionReleaseOnCancel = true
+		// short enough for a replica to take over an expired lease within the test timeouts
+		opts.LeaseDuration = new(5 * time.Second)
+		opts.RenewDeadline = new(3 * time.Second)
+		opts.RetryPeriod = new(250 * time.Millisecond)
+	})
 	if err != nil {
 		t.Fatalf("error setting up kgateway %v", err)
 	}
 
-	// start kgateway
-	wg.Go(func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &Replica{
+		XdsPort: l.Addr().(*net.TCPAddr).Port,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	go func() {
+		defer close(r.done)
 		if err := s.Start(ctx); err != nil {
 			t.Errorf("error starting kgateway %v", err)
 		}
-	})
-
-	xdsPort := l.Addr().(*net.TCPAddr).Port
-	t.Logf("running tests, xds port: %v", xdsPort)
-	run(t, ctx, krtDbg, client, xdsPort)
-	t.Logf("controller done. shutting down. xds port: %v", xdsPort)
+	}()
+	t.Cleanup(r.Stop)
+	return r
 }
 
 func GenerateKubeConfiguration(t *testing.T, restconfig *rest.Config) string {
@@ -217,6 +217,35 @@ func TestHelmChartTemplate(t *testing.T) {
   xds:
     tls:
       enabled: true
+`,
+		},
+		{
+			name: "validation-webhook-enabled",
+			valuesYAML: `controller:
+  xds:
+    tls:
+      enabled: true
+  validationWebhook:
+    enabled: true
+    annotations:
+      cert-manager.io/inject-ca-from: kgateway-system/kgateway-webhook-cert
+`,
+		},
+		{
+			name: "fault-injection-enabled",
+			valuesYAML: `controller:
+  faultInjection:
+    enabled: true
+`,
+		},
+		{
+			name: "sharding",
+			valuesYAML: `controller:
+  sharding:
+    shards: 2
+  verticalPodAutoscaler:
+    updatePolicy:
+      updateMode: Auto
 `,
 		},
 		{
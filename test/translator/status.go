@@ -31,13 +31,14 @@ func buildStatusesFromReports(
 	reportsMap reports.ReportMap,
 	gateways map[types.NamespacedName]*gwv1.Gateway,
 	listenerSets map[types.NamespacedName]*gwv1.ListenerSet,
+	conditionTime time.Time,
 ) *Statuses {
 	ctx := context.Background()
 
-	// Fixed values for deterministic golden file tests. Use the zero time
-	// for consistency and to avoid confusion about the significance of a
-	// specific date.
-	fixedTime := metav1.Time{Time: time.Time{}}
+	// Fixed values for deterministic golden file tests. The golden file tests use
+	// the zero time for consistency and to avoid confusion about the significance
+	// of a specific date.
+	fixedTime := metav1.Time{Time: conditionTime}
 
 	statuses := &Statuses{
 		Gateways:     make(map[string]*gwv1.GatewayStatus),
@@ -207,23 +208,26 @@ func normalizePolicyStatus(status *gwv1.PolicyStatus, time metav1.Time) {
 }
 
 func compareStatuses(expectedFile string, actualStatuses *Statuses) (string, error) {
-	expectedOutput := &translationResult{}
+	expectedOutput := &TranslationOutput{}
 	if err := ReadYamlFile(expectedFile, expectedOutput); err != nil {
 		return "", err
 	}
+	return diffStatuses(expectedOutput.Statuses, actualStatuses), nil
+}
 
-	if expectedOutput.Statuses == nil && actualStatuses == nil {
-		return "", nil
+func diffStatuses(expectedStatuses, actualStatuses *Statuses) string {
+	if expectedStatuses == nil && actualStatuses == nil {
+		return ""
 	}
-	if expectedOutput.Statuses == nil {
-		return "expected no statuses but got some", nil
+	if expectedStatuses == nil {
+		return "expected no statuses but got some"
 	}
 	if actualStatuses == nil {
-		return "expected statuses but got none", nil
+		return "expected statuses but got none"
 	}
 
 	// Sort statuses for consistent comparison
-	expectedSorted := sortStatuses(expectedOutput.Statuses)
+	expectedSorted := sortStatuses(expectedStatuses)
 	actualSorted := sortStatuses(actualStatuses)
 
 	// Use custom comparison options to ignore timestamp differences and handle nil vs empty slice
@@ -233,7 +237,7 @@ func compareStatuses(expectedFile string, actualStatuses *Statuses) (string, err
 		cmpopts.EquateEmpty(),
 	}
 
-	return cmp.Diff(expectedSorted, actualSorted, opts...), nil
+	return cmp.Diff(expectedSorted, actualSorted, opts...)
 }
 
 func sortStatuses(statuses *Statuses) *Statuses {
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -59,7 +60,8 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/test/testutils"
 )
 
-type translationResult struct {
+// TranslationOutput is the output of the translation of a gateway, written to its golden file.
+type TranslationOutput struct {
 	Routes        []*envoyroutev3.RouteConfiguration
 	Listeners     []*envoylistenerv3.Listener
 	ExtraClusters []*envoyclusterv3.Cluster
@@ -68,7 +70,7 @@ type translationResult struct {
 	Statuses      *Statuses
 }
 
-func (tr *translationResult) MarshalJSON() ([]byte, error) {
+func (tr *TranslationOutput) MarshalJSON() ([]byte, error) {
 	m := protojson.MarshalOptions{
 		Indent: "  ",
 	}
@@ -126,7 +128,7 @@ func (tr *translationResult) MarshalJSON() ([]byte, error) {
 	return json.Marshal(result)
 }
 
-func (tr *translationResult) UnmarshalJSON(data []byte) error {
+func (tr *TranslationOutput) UnmarshalJSON(data []byte) error {
 	m := protojson.UnmarshalOptions{}
 
 	// Create a map to hold the unmarshaled fields
@@ -296,16 +298,7 @@ func TestTranslationWithExtraPlugins(
 	//Expect(json.Unmarshal(b, &proxy)).NotTo(HaveOccurred())
 
 	// sort the output and print it
-	result.Proxy = sortProxy(result.Proxy)
-	result.Clusters = sortClusters(result.Clusters)
-	output := &translationResult{
-		Routes:        result.Proxy.Routes,
-		Listeners:     result.Proxy.Listeners,
-		ExtraClusters: result.Proxy.ExtraClusters,
-		Clusters:      result.Clusters,
-		Secrets:       result.Proxy.Secrets,
-		Statuses:      buildStatusesFromReports(result.ReportsMap, result.Gateways, result.ListenerSets),
-	}
+	output := NewTranslationOutput(result, time.Time{})
 	outputYaml, err := testutils.MarshalAnyYaml(output)
 	r.NoErrorf(err, "error marshaling output to YAML; actual result: %s", outputYaml)
 
@@ -334,6 +327,8 @@ func TestTranslationWithExtraPlugins(
 
 type TestCase struct {
 	InputFiles []string
+	// Objects are added to the objects loaded from the input files.
+	Objects []client.Object
 }
 
 type ActualTestResult struct {
@@ -344,18 +339,63 @@ type ActualTestResult struct {
 	Clusters     []*envoyclusterv3.Cluster
 }
 
+// NewTranslationOutput sorts the result of the translation of a gateway and builds its output.
+// The LastTransitionTime of the conditions of the statuses is set to conditionTime.
+func NewTranslationOutput(result ActualTestResult, conditionTime time.Time) *TranslationOutput {
+	proxy := sortProxy(result.Proxy)
+	if proxy == nil {
+		proxy = &irtranslator.TranslationResult{}
+	}
+	return &TranslationOutput{
+		Routes:        proxy.Routes,
+		Listeners:     proxy.Listeners,
+		ExtraClusters: proxy.ExtraClusters,
+		Clusters:      sortClusters(result.Clusters),
+		Secrets:       proxy.Secrets,
+		Statuses:      buildStatusesFromReports(result.ReportsMap, result.Gateways, result.ListenerSets, conditionTime),
+	}
+}
+
+// Diff returns the differences between the expected output and the actual one, or an empty
+// string when they are equivalent. The LastTransitionTime of the conditions is ignored.
+func (tr *TranslationOutput) Diff(actual *TranslationOutput) string {
+	var diffs []string
+	if d := diffProxy(tr.proxy(), actual.proxy()); d != "" {
+		diffs = append(diffs, "proxy (-expected +actual):\n"+d)
+	}
+	if d := diffClusters(tr.Clusters, actual.Clusters); d != "" {
+		diffs = append(diffs, "clusters (-expected +actual):\n"+d)
+	}
+	if d := diffStatuses(tr.Statuses, actual.Statuses); d != "" {
+		diffs = append(diffs, "statuses (-expected +actual):\n"+d)
+	}
+	return strings.Join(diffs, "\n")
+}
+
+func (tr *TranslationOutput) proxy() *irtranslator.TranslationResult {
+	return &irtranslator.TranslationResult{
+		Routes:        tr.Routes,
+		Listeners:     tr.Listeners,
+		ExtraClusters: tr.ExtraClusters,
+		Secrets:       tr.Secrets,
+	}
+}
+
 func compareProxy(expectedFile string, actualProxy *irtranslator.TranslationResult) (string, error) {
 	expectedProxy, err := ReadProxyFromFile(expectedFile)
 	if err != nil {
 		return "", err
 	}
+	return diffProxy(expectedProxy, actualProxy), nil
+}
 
+func diffProxy(expectedProxy, actualProxy *irtranslator.TranslationResult) string {
 	// Sort credentials by client name to ensure deterministic comparison
 	credentialSortFn := func(x, y *envoyapikeyauthv3.Credential) bool {
 		return x.Client < y.Client
 	}
 
-	return cmp.Diff(sortProxy(expectedProxy), sortProxy(actualProxy), protocmp.Transform(), protocmp.SortRepeated(credentialSortFn), cmpopts.EquateNaNs()), nil
+	return cmp.Diff(sortProxy(expectedProxy), sortProxy(actualProxy), protocmp.Transform(), protocmp.SortRepeated(credentialSortFn), cmpopts.EquateNaNs())
 }
 
 func sortProxy(proxy *irtranslator.TranslationResult) *irtranslator.TranslationResult {
@@ -453,13 +493,17 @@ func sortCredentialsInAny(config *anypb.Any) {
 }
 
 func compareClusters(expectedFile string, actualClusters []*envoyclusterv3.Cluster) (string, error) {
-	expectedOutput := &translationResult{}
+	expectedOutput := &TranslationOutput{}
 	if err := ReadYamlFile(expectedFile, expectedOutput); err != nil {
 		return "", err
 	}
 
+	return diffClusters(expectedOutput.Clusters, actualClusters), nil
+}
+
+func diffClusters(expectedClusters, actualClusters []*envoyclusterv3.Cluster) string {
 	// Sort both expected and actual clusters by name and compare
-	return cmp.Diff(sortClusters(expectedOutput.Clusters), sortClusters(actualClusters), protocmp.Transform(), cmpopts.EquateNaNs()), nil
+	return cmp.Diff(sortClusters(expectedClusters), sortClusters(actualClusters), protocmp.Transform(), cmpopts.EquateNaNs())
 }
 
 func sortClusters(clusters []*envoyclusterv3.Cluster) []*envoyclusterv3.Cluster {
@@ -677,6 +721,7 @@ func (tc TestCase) Run(
 		}
 		allObjs = append(allObjs, objs...)
 	}
+	allObjs = append(allObjs, tc.Objects...)
 
 	var fakeClient apiclient.Client
 	if extraConfig.NewClientFn != nil {
@@ -730,7 +775,7 @@ func (tc TestCase) Run(
 	}
 
 	v := validator.NewDocker()
-	plugins := registry.Plugins(ctx, commoncol, *settings, v)
+	plugins := registry.Plugins(ctx, commoncol, *settings, v, nil)
 	// TODO: consider moving the common code to a util that both proxy syncer and this test call
 	plugins = append(plugins, krtcollections.NewBuiltinPlugin(ctx))
 